@@ -0,0 +1,13 @@
+package events
+
+// AccountCreatedEventType identifies AccountCreatedEvent messages in the outbox.
+const AccountCreatedEventType = "account.created"
+
+// AccountCreatedEvent is published when somebody signs up.
+type AccountCreatedEvent struct {
+	AccountID string `json:"account_id"`
+	Email     string `json:"email"`
+}
+
+func (AccountCreatedEvent) EventType() string { return AccountCreatedEventType }
+func (AccountCreatedEvent) EventVersion() int { return 1 }
@@ -0,0 +1,13 @@
+package events
+
+// AccountUpdatedEventType identifies AccountUpdatedEvent messages in the outbox.
+const AccountUpdatedEventType = "account.updated"
+
+// AccountUpdatedEvent is published when the profile of an existing account changes.
+type AccountUpdatedEvent struct {
+	AccountID string `json:"account_id"`
+	Email     string `json:"email"`
+}
+
+func (AccountUpdatedEvent) EventType() string { return AccountUpdatedEventType }
+func (AccountUpdatedEvent) EventVersion() int { return 1 }
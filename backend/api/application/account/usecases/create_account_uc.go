@@ -47,7 +47,7 @@ func (uc *CreateAccountUC) Invoke(ctx context.Context, input account.Entity) (*a
 	}
 
 	// Save the new account
-	err = uc.repository.Save(ctx, input)
+	err = uc.repository.Save(ctx, input, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error while inserting account in database: %w", err)
 	}
@@ -32,7 +32,7 @@ func TestCreateAccountUC_Invoke(t *testing.T) {
 				})).Return([]account.Entity{}, nil)
 				repository.On("Save", mock.Anything, mock.MatchedBy(func(entity account.Entity) bool {
 					return entity.Email == "cabrerajjorge@gmail.com" && entity.Nickname == "jorge"
-				})).Return(nil)
+				}), mock.Anything).Return(nil)
 			},
 			then: func(t *testing.T, result *account.Entity, err error) {
 				assert.NoError(t, err)
@@ -97,7 +97,7 @@ func TestCreateAccountUC_Invoke(t *testing.T) {
 			on: func(t *testing.T, repository *amocks.Repository, validator *amocks.Validator) {
 				validator.On("Check", mock.Anything).Return([]error{})
 				repository.On("Find", mock.Anything, mock.Anything).Return([]account.Entity{}, nil)
-				repository.On("Save", mock.Anything, mock.Anything).Return(errors.New("database error"))
+				repository.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error"))
 			},
 			then: func(t *testing.T, result *account.Entity, err error) {
 				assert.Error(t, err)
@@ -3,8 +3,12 @@ package usecases
 import (
 	"context"
 	"fmt"
+	accountevent "sportlink/api/application/account/events"
 	"sportlink/api/application/auth/service"
+	appevents "sportlink/api/application/events"
 	"sportlink/api/domain/account"
+	"sportlink/api/domain/outbox"
+	"time"
 )
 
 type GoogleAuthResult struct {
@@ -44,7 +48,8 @@ func (uc *GoogleAuthUC) Invoke(ctx context.Context, idToken string) (*GoogleAuth
 	var accountID string
 	if len(accounts) == 0 {
 		newAccount := account.NewGoogleAccount(tokenInfo.Email, tokenInfo.GivenName, tokenInfo.FamilyName, tokenInfo.Picture)
-		if err := uc.accountRepo.Save(ctx, newAccount); err != nil {
+		created := accountevent.AccountCreatedEvent{AccountID: newAccount.AccountID, Email: newAccount.Email}
+		if err := uc.save(ctx, newAccount, created); err != nil {
 			return nil, fmt.Errorf("error creating account: %w", err)
 		}
 		accountID = newAccount.AccountID
@@ -60,7 +65,8 @@ func (uc *GoogleAuthUC) Invoke(ctx context.Context, idToken string) (*GoogleAuth
 			needsSave = true
 		}
 		if needsSave {
-			if err := uc.accountRepo.Save(ctx, existing); err != nil {
+			updated := accountevent.AccountUpdatedEvent{AccountID: existing.AccountID, Email: existing.Email}
+			if err := uc.save(ctx, existing, updated); err != nil {
 				return nil, fmt.Errorf("error updating account: %w", err)
 			}
 		}
@@ -74,3 +80,13 @@ func (uc *GoogleAuthUC) Invoke(ctx context.Context, idToken string) (*GoogleAuth
 
 	return &GoogleAuthResult{JWTToken: jwtToken, AccountID: accountID}, nil
 }
+
+// save writes the account together with the event it raised, so subscribers hear of
+// every account that was written.
+func (uc *GoogleAuthUC) save(ctx context.Context, entity account.Entity, event appevents.Event) error {
+	message, err := appevents.NewMessage(ctx, event, time.Now())
+	if err != nil {
+		return err
+	}
+	return uc.accountRepo.Save(ctx, entity, []outbox.Message{message})
+}
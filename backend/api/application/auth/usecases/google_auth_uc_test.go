@@ -3,9 +3,11 @@ package usecases_test
 import (
 	"context"
 	"fmt"
+	accountevent "sportlink/api/application/account/events"
 	"sportlink/api/application/auth/service"
 	"sportlink/api/application/auth/usecases"
 	"sportlink/api/domain/account"
+	"sportlink/api/domain/outbox"
 	mocks "sportlink/mocks/api/application/auth/service"
 	amocks "sportlink/mocks/api/domain/account"
 	"testing"
@@ -41,6 +43,10 @@ func TestGoogleAuthUC_Invoke(t *testing.T) {
 				})).Return([]account.Entity{}, nil)
 				repo.On("Save", mock.Anything, mock.MatchedBy(func(e account.Entity) bool {
 					return e.Email == "user@gmail.com" && e.Picture == "https://photo.url" && e.AccountID != ""
+				}), mock.MatchedBy(func(messages []outbox.Message) bool {
+					var event accountevent.AccountCreatedEvent
+					return len(messages) == 1 && messages[0].Type == accountevent.AccountCreatedEventType &&
+						messages[0].Decode(&event) == nil && event.Email == "user@gmail.com" && event.AccountID != ""
 				})).Return(nil)
 				jwt.On("Generate", mock.AnythingOfType("string")).Return("signed-jwt", nil)
 			},
@@ -56,7 +62,7 @@ func TestGoogleAuthUC_Invoke(t *testing.T) {
 			on: func(verifier *mocks.GoogleTokenVerifier, repo *amocks.Repository, jwt *mocks.JWTService) {
 				verifier.On("Verify", mock.Anything, "valid-id-token").Return(validTokenInfo, nil)
 				repo.On("Find", mock.Anything, mock.Anything).Return([]account.Entity{
-					{ID: "EMAIL#user@gmail.com", AccountID: "01JQTEST0000000000000000AB", Email: "user@gmail.com"},
+					{ID: "EMAIL#user@gmail.com", AccountID: "01JQTEST0000000000000000AB", Email: "user@gmail.com", Picture: "https://photo.url"},
 				}, nil)
 				jwt.On("Generate", "01JQTEST0000000000000000AB").Return("signed-jwt", nil)
 			},
@@ -66,6 +72,28 @@ func TestGoogleAuthUC_Invoke(t *testing.T) {
 				assert.Equal(t, "01JQTEST0000000000000000AB", result.AccountID)
 			},
 		},
+		{
+			name:    "existing user with a new picture: updates the account and returns token",
+			idToken: "valid-id-token",
+			on: func(verifier *mocks.GoogleTokenVerifier, repo *amocks.Repository, jwt *mocks.JWTService) {
+				verifier.On("Verify", mock.Anything, "valid-id-token").Return(validTokenInfo, nil)
+				repo.On("Find", mock.Anything, mock.Anything).Return([]account.Entity{
+					{ID: "EMAIL#user@gmail.com", AccountID: "01JQTEST0000000000000000AB", Email: "user@gmail.com", Picture: "https://old.url"},
+				}, nil)
+				repo.On("Save", mock.Anything, mock.MatchedBy(func(e account.Entity) bool {
+					return e.AccountID == "01JQTEST0000000000000000AB" && e.Picture == "https://photo.url"
+				}), mock.MatchedBy(func(messages []outbox.Message) bool {
+					var event accountevent.AccountUpdatedEvent
+					return len(messages) == 1 && messages[0].Decode(&event) == nil &&
+						event == accountevent.AccountUpdatedEvent{AccountID: "01JQTEST0000000000000000AB", Email: "user@gmail.com"}
+				})).Return(nil)
+				jwt.On("Generate", "01JQTEST0000000000000000AB").Return("signed-jwt", nil)
+			},
+			then: func(t *testing.T, result *usecases.GoogleAuthResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "01JQTEST0000000000000000AB", result.AccountID)
+			},
+		},
 		{
 			name:    "fails when Google token is invalid",
 			idToken: "bad-token",
@@ -97,7 +125,7 @@ func TestGoogleAuthUC_Invoke(t *testing.T) {
 			on: func(verifier *mocks.GoogleTokenVerifier, repo *amocks.Repository, jwt *mocks.JWTService) {
 				verifier.On("Verify", mock.Anything, "valid-id-token").Return(validTokenInfo, nil)
 				repo.On("Find", mock.Anything, mock.Anything).Return([]account.Entity{}, nil)
-				repo.On("Save", mock.Anything, mock.Anything).Return(fmt.Errorf("save error"))
+				repo.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("save error"))
 			},
 			then: func(t *testing.T, result *usecases.GoogleAuthResult, err error) {
 				assert.Error(t, err)
@@ -111,7 +139,7 @@ func TestGoogleAuthUC_Invoke(t *testing.T) {
 			on: func(verifier *mocks.GoogleTokenVerifier, repo *amocks.Repository, jwt *mocks.JWTService) {
 				verifier.On("Verify", mock.Anything, "valid-id-token").Return(validTokenInfo, nil)
 				repo.On("Find", mock.Anything, mock.Anything).Return([]account.Entity{
-					{ID: "EMAIL#user@gmail.com", AccountID: "01JQTEST0000000000000000AB", Email: "user@gmail.com"},
+					{ID: "EMAIL#user@gmail.com", AccountID: "01JQTEST0000000000000000AB", Email: "user@gmail.com", Picture: "https://photo.url"},
 				}, nil)
 				jwt.On("Generate", "01JQTEST0000000000000000AB").Return("", fmt.Errorf("jwt error"))
 			},
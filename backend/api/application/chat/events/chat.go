@@ -0,0 +1,34 @@
+package events
+
+// ChatMessageSentEventType identifies ChatMessageSentEvent messages in the outbox.
+const ChatMessageSentEventType = "chat.message_sent"
+
+// ChatThreadReadEventType identifies ChatThreadReadEvent messages in the outbox.
+const ChatThreadReadEventType = "chat.thread_read"
+
+// ChatMessageSentEvent is published when a member posts in a thread. Members are
+// those of the thread when the message was sent.
+type ChatMessageSentEvent struct {
+	MessageID       string   `json:"message_id"`
+	ThreadKind      string   `json:"thread_kind"`
+	ThreadID        string   `json:"thread_id"`
+	SenderAccountID string   `json:"sender_account_id"`
+	Members         []string `json:"members"`
+	Body            string   `json:"body"`
+}
+
+func (ChatMessageSentEvent) EventType() string { return ChatMessageSentEventType }
+func (ChatMessageSentEvent) EventVersion() int { return 1 }
+
+// ChatThreadReadEvent is published when a member read a thread up to a message, so
+// the others see the read receipt.
+type ChatThreadReadEvent struct {
+	ThreadKind        string   `json:"thread_kind"`
+	ThreadID          string   `json:"thread_id"`
+	AccountID         string   `json:"account_id"`
+	LastReadMessageID string   `json:"last_read_message_id"`
+	Members           []string `json:"members"`
+}
+
+func (ChatThreadReadEvent) EventType() string { return ChatThreadReadEventType }
+func (ChatThreadReadEvent) EventVersion() int { return 1 }
@@ -0,0 +1,12 @@
+package request
+
+// SendMessageRequest is a message posted to a chat thread.
+type SendMessageRequest struct {
+	Body string `json:"body" validate:"required,max=2000"`
+}
+
+// MarkThreadReadRequest marks a thread read up to MessageID, or up to its newest
+// message when MessageID is omitted.
+type MarkThreadReadRequest struct {
+	MessageID string `json:"message_id"`
+}
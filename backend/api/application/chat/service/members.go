@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"sportlink/api/domain/chat"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchrequest"
+)
+
+// Members tells who takes part in a thread.
+type Members interface {
+	// Of returns the accounts of the thread, or nil when what it refers to does not exist.
+	// Lookups are scoped to accountID, the account asking.
+	Of(ctx context.Context, thread chat.Thread, accountID string) ([]string, error)
+}
+
+// threadMembers takes the members of a thread from the match request or match it is
+// about, so they follow its changes: a participant who drops out of a match leaves
+// its thread.
+type threadMembers struct {
+	matchRequestRepository matchrequest.Repository
+	matchRepository        match.Repository
+}
+
+func NewMembers(matchRequestRepository matchrequest.Repository, matchRepository match.Repository) Members {
+	return &threadMembers{
+		matchRequestRepository: matchRequestRepository,
+		matchRepository:        matchRepository,
+	}
+}
+
+func (m *threadMembers) Of(ctx context.Context, thread chat.Thread, accountID string) ([]string, error) {
+	switch thread.Kind {
+	case chat.KindRequest:
+		requests, err := m.matchRequestRepository.Find(ctx, matchrequest.DomainQuery{IDs: []string{thread.RefID}})
+		if err != nil || len(requests) == 0 {
+			return nil, err
+		}
+		return []string{requests[0].OwnerAccountID, requests[0].RequesterAccountID}, nil
+	case chat.KindMatch:
+		entity, err := m.matchRepository.FindByID(ctx, accountID, thread.RefID)
+		if err != nil || entity == nil {
+			return nil, err
+		}
+		return entity.Participants, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"sportlink/api/domain/chat"
+	"strings"
+)
+
+// Verdict is what a moderator decided about a message.
+type Verdict struct {
+	Rejected bool
+	Reason   string // shown to the sender when the message is rejected
+}
+
+// Moderator reviews messages before they are posted. Hooks such as word filters,
+// rate limits or an external moderation service implement it.
+type Moderator interface {
+	Review(ctx context.Context, message chat.Message) (Verdict, error)
+}
+
+// Moderators runs every moderator in order and stops at the first rejection.
+type Moderators []Moderator
+
+func (m Moderators) Review(ctx context.Context, message chat.Message) (Verdict, error) {
+	for _, moderator := range m {
+		verdict, err := moderator.Review(ctx, message)
+		if err != nil || verdict.Rejected {
+			return verdict, err
+		}
+	}
+	return Verdict{}, nil
+}
+
+// BlocklistModerator rejects messages containing any of its words, ignoring case.
+type BlocklistModerator struct {
+	words []string
+}
+
+func NewBlocklistModerator(words []string) *BlocklistModerator {
+	lowered := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			lowered = append(lowered, w)
+		}
+	}
+	return &BlocklistModerator{words: lowered}
+}
+
+func (m *BlocklistModerator) Review(_ context.Context, message chat.Message) (Verdict, error) {
+	body := strings.ToLower(message.Body)
+	for _, w := range m.words {
+		if strings.Contains(body, w) {
+			return Verdict{Rejected: true, Reason: "message contains blocked words"}, nil
+		}
+	}
+	return Verdict{}, nil
+}
@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sportlink/api/application/chat/service"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/chat"
+	"sportlink/pkg/log"
+)
+
+// membersFor returns the members of the thread when accountID is one of them. Threads
+// of someone else's request or match are reported as missing, not forbidden, so their
+// existence is not revealed.
+func membersFor(ctx context.Context, members service.Members, thread chat.Thread, accountID string) ([]string, error) {
+	accounts, err := members.Of(ctx, thread, accountID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get members of chat thread %s", thread.Key()), err)
+		return nil, err
+	}
+	if !slices.Contains(accounts, accountID) {
+		return nil, errors.NotFound("chat thread not found")
+	}
+	return accounts, nil
+}
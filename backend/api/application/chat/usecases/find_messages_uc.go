@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application/chat/service"
+	"sportlink/api/domain/chat"
+	"sportlink/pkg/log"
+)
+
+type FindMessagesInput struct {
+	Thread    chat.Thread
+	AccountID string
+	Limit     int
+	Cursor    string
+}
+
+// ThreadPage is a page of a thread with how far each member has read it.
+type ThreadPage struct {
+	Page     chat.Page
+	Receipts []chat.Receipt
+}
+
+// FindMessagesUC returns a page of a thread, newest messages first, to one of its members.
+type FindMessagesUC struct {
+	chatRepository chat.Repository
+	members        service.Members
+}
+
+func NewFindMessagesUC(chatRepository chat.Repository, members service.Members) *FindMessagesUC {
+	return &FindMessagesUC{
+		chatRepository: chatRepository,
+		members:        members,
+	}
+}
+
+func (uc *FindMessagesUC) Invoke(ctx context.Context, input FindMessagesInput) (*ThreadPage, error) {
+	if _, err := membersFor(ctx, uc.members, input.Thread, input.AccountID); err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = chat.DefaultPageSize
+	}
+	limit = min(limit, chat.MaxPageSize)
+
+	page, err := uc.chatRepository.Find(ctx, chat.DomainQuery{
+		Thread: input.Thread,
+		Limit:  limit,
+		Cursor: input.Cursor,
+	})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get messages of chat thread %s", input.Thread.Key()), err)
+		return nil, err
+	}
+
+	receipts, err := uc.chatRepository.FindReceipts(ctx, input.Thread)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get receipts of chat thread %s", input.Thread.Key()), err)
+		return nil, err
+	}
+
+	return &ThreadPage{Page: page, Receipts: receipts}, nil
+}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	chatevent "sportlink/api/application/chat/events"
+	"sportlink/api/application/chat/service"
+	appevents "sportlink/api/application/events"
+	"sportlink/api/domain/chat"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type MarkThreadReadInput struct {
+	Thread    chat.Thread
+	AccountID string
+	MessageID string // last message read; the newest message of the thread when empty
+	Now       time.Time
+}
+
+// MarkThreadReadUC moves the read receipt of a member forward and lets the other
+// members know. Marking an older message keeps the receipt where it was.
+type MarkThreadReadUC struct {
+	chatRepository chat.Repository
+	members        service.Members
+	publisher      appevents.Publisher[appevents.Event]
+}
+
+func NewMarkThreadReadUC(
+	chatRepository chat.Repository,
+	members service.Members,
+	publisher appevents.Publisher[appevents.Event],
+) *MarkThreadReadUC {
+	return &MarkThreadReadUC{
+		chatRepository: chatRepository,
+		members:        members,
+		publisher:      publisher,
+	}
+}
+
+func (uc *MarkThreadReadUC) Invoke(ctx context.Context, input MarkThreadReadInput) (*chat.Receipt, error) {
+	members, err := membersFor(ctx, uc.members, input.Thread, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := uc.currentReceipt(ctx, input.Thread, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	messageID := input.MessageID
+	if messageID == "" {
+		page, err := uc.chatRepository.Find(ctx, chat.DomainQuery{Thread: input.Thread, Limit: 1})
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to get latest message of chat thread %s", input.Thread.Key()), err)
+			return nil, err
+		}
+		if len(page.Entities) == 0 {
+			return &receipt, nil
+		}
+		messageID = page.Entities[0].ID
+	}
+
+	receipt, advanced := receipt.Advance(messageID, input.Now)
+	if !advanced {
+		return &receipt, nil
+	}
+	if err = uc.chatRepository.SaveReceipt(ctx, receipt); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to save receipt of account %s in chat thread %s", input.AccountID, input.Thread.Key()), err)
+		return nil, err
+	}
+
+	err = uc.publisher.Publish(ctx, chatevent.ChatThreadReadEvent{
+		ThreadKind:        input.Thread.Kind.String(),
+		ThreadID:          input.Thread.RefID,
+		AccountID:         input.AccountID,
+		LastReadMessageID: receipt.LastReadMessageID,
+		Members:           members,
+	})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to publish receipt of account %s in chat thread %s", input.AccountID, input.Thread.Key()), err)
+	}
+	return &receipt, nil
+}
+
+func (uc *MarkThreadReadUC) currentReceipt(ctx context.Context, thread chat.Thread, accountID string) (chat.Receipt, error) {
+	receipts, err := uc.chatRepository.FindReceipts(ctx, thread)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get receipts of chat thread %s", thread.Key()), err)
+		return chat.Receipt{}, err
+	}
+	for _, r := range receipts {
+		if r.AccountID == accountID {
+			return r, nil
+		}
+	}
+	return chat.Receipt{Thread: thread, AccountID: accountID}, nil
+}
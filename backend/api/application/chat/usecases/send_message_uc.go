@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	chatevent "sportlink/api/application/chat/events"
+	"sportlink/api/application/chat/service"
+	"sportlink/api/application/errors"
+	appevents "sportlink/api/application/events"
+	"sportlink/api/domain/chat"
+	"sportlink/pkg/log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type SendMessageInput struct {
+	Thread    chat.Thread
+	AccountID string
+	Body      string
+	Now       time.Time
+}
+
+// SendMessageUC posts a message of a member to a thread once the moderators let it
+// through. The sender has read its own message, and the other members are told about
+// it through the message sent event.
+type SendMessageUC struct {
+	chatRepository chat.Repository
+	members        service.Members
+	moderator      service.Moderator
+	publisher      appevents.Publisher[appevents.Event]
+}
+
+func NewSendMessageUC(
+	chatRepository chat.Repository,
+	members service.Members,
+	moderator service.Moderator,
+	publisher appevents.Publisher[appevents.Event],
+) *SendMessageUC {
+	return &SendMessageUC{
+		chatRepository: chatRepository,
+		members:        members,
+		moderator:      moderator,
+		publisher:      publisher,
+	}
+}
+
+func (uc *SendMessageUC) Invoke(ctx context.Context, input SendMessageInput) (*chat.Message, error) {
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return nil, errors.RequestValidationFailed("message body is empty")
+	}
+	if utf8.RuneCountInString(body) > chat.MaxBodyLength {
+		return nil, errors.RequestValidationFailed(fmt.Sprintf("message body is longer than %d characters", chat.MaxBodyLength))
+	}
+
+	members, err := membersFor(ctx, uc.members, input.Thread, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	message := chat.NewMessage(input.Thread, input.AccountID, body, input.Now)
+	verdict, err := uc.moderator.Review(ctx, message)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to moderate message in chat thread %s", input.Thread.Key()), err)
+		return nil, err
+	}
+	if verdict.Rejected {
+		return nil, errors.UseCaseExecutionFailed(verdict.Reason)
+	}
+
+	if err = uc.chatRepository.Save(ctx, message); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to save message in chat thread %s", input.Thread.Key()), err)
+		return nil, err
+	}
+
+	// the message is posted; a stale receipt or a missed event is only logged
+	receipt := chat.Receipt{Thread: input.Thread, AccountID: input.AccountID, LastReadMessageID: message.ID, ReadAt: message.SentAt}
+	if err = uc.chatRepository.SaveReceipt(ctx, receipt); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to save receipt of account %s in chat thread %s", input.AccountID, input.Thread.Key()), err)
+	}
+	err = uc.publisher.Publish(ctx, chatevent.ChatMessageSentEvent{
+		MessageID:       message.ID,
+		ThreadKind:      input.Thread.Kind.String(),
+		ThreadID:        input.Thread.RefID,
+		SenderAccountID: input.AccountID,
+		Members:         members,
+		Body:            message.Body,
+	})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to publish message %s of chat thread %s", message.ID, input.Thread.Key()), err)
+	}
+
+	return &message, nil
+}
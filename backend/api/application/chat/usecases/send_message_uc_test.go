@@ -0,0 +1,140 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	chatevent "sportlink/api/application/chat/events"
+	"sportlink/api/application/chat/service"
+	"sportlink/api/application/chat/usecases"
+	apperrors "sportlink/api/application/errors"
+	appevents "sportlink/api/application/events"
+	"sportlink/api/domain/chat"
+	chatservicemocks "sportlink/mocks/api/application/chat/service"
+	eventmocks "sportlink/mocks/api/application/events"
+	chatmocks "sportlink/mocks/api/domain/chat"
+)
+
+func TestSendMessageUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 10, 18, 0, 0, 0, time.UTC)
+	thread := chat.NewThread(chat.KindMatch, "match-1")
+
+	input := usecases.SendMessageInput{
+		Thread:    thread,
+		AccountID: "player-1",
+		Body:      "  see you at the court  ",
+		Now:       now,
+	}
+
+	isCtx := mock.MatchedBy(func(c context.Context) bool { return c == ctx })
+	isMessage := mock.MatchedBy(func(m chat.Message) bool {
+		return m.Thread == thread && m.SenderAccountID == "player-1" && m.Body == "see you at the court" && m.SentAt.Equal(now)
+	})
+
+	testCases := []struct {
+		name  string
+		input usecases.SendMessageInput
+		on    func(t *testing.T, repository *chatmocks.Repository, members *chatservicemocks.Members, publisher *eventmocks.Publisher[appevents.Event])
+		then  func(t *testing.T, result *chat.Message, err error)
+	}{
+		{
+			name:  "given a member of the thread when sending a message then it is saved, read by the sender and announced to the members",
+			input: input,
+			on: func(t *testing.T, repository *chatmocks.Repository, members *chatservicemocks.Members, publisher *eventmocks.Publisher[appevents.Event]) {
+				members.On("Of", isCtx, thread, "player-1").Return([]string{"player-1", "player-2"}, nil)
+				repository.On("Save", isCtx, isMessage).Return(nil)
+				repository.On("SaveReceipt", isCtx, mock.MatchedBy(func(r chat.Receipt) bool {
+					return r.Thread == thread && r.AccountID == "player-1" && r.LastReadMessageID != "" && r.ReadAt.Equal(now)
+				})).Return(nil)
+				publisher.On("Publish", isCtx, mock.MatchedBy(func(e appevents.Event) bool {
+					sent, ok := e.(chatevent.ChatMessageSentEvent)
+					return ok && sent.ThreadKind == "MATCH" && sent.ThreadID == "match-1" &&
+						sent.SenderAccountID == "player-1" && assert.ObjectsAreEqual([]string{"player-1", "player-2"}, sent.Members)
+				})).Return(nil)
+			},
+			then: func(t *testing.T, result *chat.Message, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "see you at the court", result.Body)
+				assert.NotEmpty(t, result.ID)
+			},
+		},
+		{
+			name:  "given an account outside the thread when sending a message then returns not found",
+			input: input,
+			on: func(t *testing.T, repository *chatmocks.Repository, members *chatservicemocks.Members, publisher *eventmocks.Publisher[appevents.Event]) {
+				members.On("Of", isCtx, thread, "player-1").Return([]string{"player-2", "player-3"}, nil)
+			},
+			then: func(t *testing.T, result *chat.Message, err error) {
+				assert.Nil(t, result)
+				assert.Equal(t, apperrors.NotFound("chat thread not found"), err)
+			},
+		},
+		{
+			name: "given a message with a blocked word when sending it then it is rejected and nothing is saved",
+			input: usecases.SendMessageInput{
+				Thread:    thread,
+				AccountID: "player-1",
+				Body:      "this is SPAM",
+				Now:       now,
+			},
+			on: func(t *testing.T, repository *chatmocks.Repository, members *chatservicemocks.Members, publisher *eventmocks.Publisher[appevents.Event]) {
+				members.On("Of", isCtx, thread, "player-1").Return([]string{"player-1", "player-2"}, nil)
+			},
+			then: func(t *testing.T, result *chat.Message, err error) {
+				assert.Nil(t, result)
+				assert.Equal(t, apperrors.UseCaseExecutionFailed("message contains blocked words"), err)
+			},
+		},
+		{
+			name: "given a blank message when sending it then returns a validation error",
+			input: usecases.SendMessageInput{
+				Thread:    thread,
+				AccountID: "player-1",
+				Body:      "   ",
+				Now:       now,
+			},
+			on: func(t *testing.T, repository *chatmocks.Repository, members *chatservicemocks.Members, publisher *eventmocks.Publisher[appevents.Event]) {
+			},
+			then: func(t *testing.T, result *chat.Message, err error) {
+				assert.Nil(t, result)
+				assert.Equal(t, apperrors.RequestValidationFailed("message body is empty"), err)
+			},
+		},
+		{
+			name:  "given the message cannot be saved when sending it then returns error and nothing is announced",
+			input: input,
+			on: func(t *testing.T, repository *chatmocks.Repository, members *chatservicemocks.Members, publisher *eventmocks.Publisher[appevents.Event]) {
+				members.On("Of", isCtx, thread, "player-1").Return([]string{"player-1", "player-2"}, nil)
+				repository.On("Save", isCtx, isMessage).Return(errors.New("db connection error"))
+			},
+			then: func(t *testing.T, result *chat.Message, err error) {
+				assert.Nil(t, result)
+				assert.EqualError(t, err, "db connection error")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repository := chatmocks.NewRepository(t)
+			members := chatservicemocks.NewMembers(t)
+			publisher := eventmocks.NewPublisher[appevents.Event](t)
+			moderator := service.Moderators{service.NewBlocklistModerator([]string{"spam"})}
+			uc := usecases.NewSendMessageUC(repository, members, moderator, publisher)
+
+			tc.on(t, repository, members, publisher)
+
+			result, err := uc.Invoke(ctx, tc.input)
+
+			tc.then(t, result, err)
+		})
+	}
+}
@@ -9,6 +9,9 @@ const (
 	UnauthorizedErrorCode            ErrorCode = "unauthorized"
 	UnexpectedErrorCode              ErrorCode = "unexpected_error"
 	UseCaseExecutionErrorCode        ErrorCode = "use_case_execution_error"
+	MatchOfferFullErrorCode          ErrorCode = "match_offer_full"
+	ConcurrentModificationErrorCode  ErrorCode = "concurrent_modification"
+	InvalidTransitionErrorCode       ErrorCode = "invalid_status_transition"
 )
 
 type AppError struct {
@@ -36,3 +36,24 @@ func Unauthorized(message string) AppError {
 		Message: message,
 	}
 }
+
+func MatchOfferFull(message string) AppError {
+	return AppError{
+		Code:    MatchOfferFullErrorCode,
+		Message: message,
+	}
+}
+
+func ConcurrentModification(message string) AppError {
+	return AppError{
+		Code:    ConcurrentModificationErrorCode,
+		Message: message,
+	}
+}
+
+func InvalidTransition(message string) AppError {
+	return AppError{
+		Code:    InvalidTransitionErrorCode,
+		Message: message,
+	}
+}
@@ -0,0 +1,37 @@
+package events
+
+import "context"
+
+// SystemActor is the actor of events raised outside a request, e.g. by schedulers.
+const SystemActor = "system"
+
+type contextKey int
+
+const (
+	actorKey contextKey = iota
+	correlationIDKey
+)
+
+// WithActor stores the account acting in the current request.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// Actor returns the account acting in ctx, or SystemActor when there is none.
+func Actor(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
+
+// WithCorrelationID stores the ID shared by every event raised while serving one request.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+// CorrelationID returns the correlation ID of ctx, or an empty string when there is none.
+func CorrelationID(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey).(string)
+	return correlationID
+}
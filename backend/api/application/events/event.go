@@ -0,0 +1,41 @@
+package events
+
+import (
+	"context"
+	"sportlink/api/domain/outbox"
+	"time"
+)
+
+// Event is a domain event of the catalogue. Its type names it on the wire and its
+// version is bumped whenever the payload changes in a way subscribers must notice.
+type Event interface {
+	EventType() string
+	EventVersion() int
+}
+
+// NewMessage wraps event in an outbox message carrying the actor and correlation ID
+// found in ctx.
+func NewMessage(ctx context.Context, event Event, now time.Time) (outbox.Message, error) {
+	message, err := outbox.NewMessage(event.EventType(), event, now)
+	if err != nil {
+		return outbox.Message{}, err
+	}
+	message.Version = event.EventVersion()
+	message.Actor = Actor(ctx)
+	message.CorrelationID = CorrelationID(ctx)
+	return message, nil
+}
+
+// NewMessages wraps each event in an outbox message, in the order given, for use cases
+// that write them together with the change that raised them.
+func NewMessages(ctx context.Context, now time.Time, events ...Event) ([]outbox.Message, error) {
+	messages := make([]outbox.Message, 0, len(events))
+	for _, event := range events {
+		message, err := NewMessage(ctx, event, now)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
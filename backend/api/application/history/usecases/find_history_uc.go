@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/pkg/log"
+)
+
+type FindHistoryInput struct {
+	Kind      history.Kind
+	EntityID  string
+	AccountID string // account asking for the history
+}
+
+// FindHistoryUC returns the status history of an offer, a request or a match, oldest change
+// first. Only the accounts taking part in it and admins may read it: the owner and
+// requesters of an offer, the owner and requester of a request, the participants of a match.
+type FindHistoryUC struct {
+	historyRepository      history.Repository
+	matchOfferRepository   matchoffer.Repository
+	matchRequestRepository matchrequest.Repository
+	matchRepository        match.Repository
+	adminAccountIDs        []string
+}
+
+func NewFindHistoryUC(
+	historyRepository history.Repository,
+	matchOfferRepository matchoffer.Repository,
+	matchRequestRepository matchrequest.Repository,
+	matchRepository match.Repository,
+	adminAccountIDs []string,
+) *FindHistoryUC {
+	return &FindHistoryUC{
+		historyRepository:      historyRepository,
+		matchOfferRepository:   matchOfferRepository,
+		matchRequestRepository: matchRequestRepository,
+		matchRepository:        matchRepository,
+		adminAccountIDs:        adminAccountIDs,
+	}
+}
+
+func (uc *FindHistoryUC) Invoke(ctx context.Context, input FindHistoryInput) (*[]history.Entry, error) {
+	if !slices.Contains(uc.adminAccountIDs, input.AccountID) {
+		accounts, err := uc.accountsOf(ctx, input)
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to get the accounts of %s %s", input.Kind, input.EntityID), err)
+			return nil, err
+		}
+		// someone else's entities are reported as missing, so their existence is not revealed
+		if !slices.Contains(accounts, input.AccountID) {
+			return nil, errors.NotFound("history not found")
+		}
+	}
+
+	entries, err := uc.historyRepository.Find(ctx, input.Kind, input.EntityID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get the history of %s %s", input.Kind, input.EntityID), err)
+		return nil, err
+	}
+	return &entries, nil
+}
+
+// accountsOf returns the accounts taking part in the entity that matter to input.AccountID,
+// or nil when the entity does not exist.
+func (uc *FindHistoryUC) accountsOf(ctx context.Context, input FindHistoryInput) ([]string, error) {
+	switch input.Kind {
+	case history.KindMatchOffer:
+		page, err := uc.matchOfferRepository.Find(ctx, matchoffer.DomainQuery{IDs: []string{input.EntityID}})
+		if err != nil || len(page.Entities) == 0 {
+			return nil, err
+		}
+		// requesters only show up through their own request
+		requests, err := uc.matchRequestRepository.Find(ctx, matchrequest.DomainQuery{
+			IDs: []string{matchrequest.GenerateMatchRequestID(input.AccountID, input.EntityID)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		accounts := []string{page.Entities[0].OwnerAccountID}
+		for _, r := range requests {
+			accounts = append(accounts, r.RequesterAccountID)
+		}
+		return accounts, nil
+	case history.KindMatchRequest:
+		requests, err := uc.matchRequestRepository.Find(ctx, matchrequest.DomainQuery{IDs: []string{input.EntityID}})
+		if err != nil || len(requests) == 0 {
+			return nil, err
+		}
+		return []string{requests[0].OwnerAccountID, requests[0].RequesterAccountID}, nil
+	case history.KindMatch:
+		entity, err := uc.matchRepository.FindByID(ctx, input.AccountID, input.EntityID)
+		if err != nil || entity == nil {
+			return nil, err
+		}
+		return entity.Participants, nil
+	default:
+		return nil, nil
+	}
+}
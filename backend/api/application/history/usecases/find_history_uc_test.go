@@ -0,0 +1,145 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "sportlink/api/application/errors"
+	"sportlink/api/application/history/usecases"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	domainmatch "sportlink/api/domain/match"
+	domainoffer "sportlink/api/domain/matchoffer"
+	domainreq "sportlink/api/domain/matchrequest"
+	historymocks "sportlink/mocks/api/domain/history"
+	matchmocks "sportlink/mocks/api/domain/match"
+	offermocks "sportlink/mocks/api/domain/matchoffer"
+	reqmocks "sportlink/mocks/api/domain/matchrequest"
+)
+
+func TestFindHistoryUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	isCtx := mock.MatchedBy(func(c context.Context) bool { return c == ctx })
+
+	offer := domainoffer.Entity{ID: "offer-1", OwnerAccountID: "owner-acc"}
+	requestID := domainreq.GenerateMatchRequestID("requester-acc", "offer-1")
+	request := domainreq.Entity{ID: requestID, MatchOfferID: "offer-1", OwnerAccountID: "owner-acc", RequesterAccountID: "requester-acc"}
+	rejection := history.Entry{
+		ID:         "01J00000000000000000000000",
+		Kind:       history.KindMatchRequest,
+		EntityID:   requestID,
+		From:       "PENDING",
+		To:         "REJECTED",
+		Actor:      common.ActorOwner,
+		AccountID:  "owner-acc",
+		Reason:     "we found a full team",
+		Source:     history.SourceREST,
+		OccurredAt: time.Date(2026, 5, 10, 18, 0, 0, 0, time.UTC),
+	}
+
+	type mocks struct {
+		history *historymocks.Repository
+		offers  *offermocks.Repository
+		reqs    *reqmocks.Repository
+		matches *matchmocks.Repository
+	}
+
+	testCases := []struct {
+		name  string
+		input usecases.FindHistoryInput
+		on    func(t *testing.T, m mocks)
+		then  func(t *testing.T, result *[]history.Entry, err error)
+	}{
+		{
+			name:  "given the requester of a request when reading its history then returns its entries",
+			input: usecases.FindHistoryInput{Kind: history.KindMatchRequest, EntityID: requestID, AccountID: "requester-acc"},
+			on: func(t *testing.T, m mocks) {
+				m.reqs.On("Find", isCtx, mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+					return len(q.IDs) == 1 && q.IDs[0] == requestID
+				})).Return([]domainreq.Entity{request}, nil)
+				m.history.On("Find", isCtx, history.KindMatchRequest, requestID).Return([]history.Entry{rejection}, nil)
+			},
+			then: func(t *testing.T, result *[]history.Entry, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []history.Entry{rejection}, *result)
+			},
+		},
+		{
+			name:  "given an account with a request on an offer when reading the offer history then returns its entries",
+			input: usecases.FindHistoryInput{Kind: history.KindMatchOffer, EntityID: "offer-1", AccountID: "requester-acc"},
+			on: func(t *testing.T, m mocks) {
+				m.offers.On("Find", isCtx, mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+					return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+				})).Return(domainoffer.Page{Entities: []domainoffer.Entity{offer}, Total: 1}, nil)
+				m.reqs.On("Find", isCtx, mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+					return len(q.IDs) == 1 && q.IDs[0] == requestID
+				})).Return([]domainreq.Entity{request}, nil)
+				m.history.On("Find", isCtx, history.KindMatchOffer, "offer-1").Return([]history.Entry{}, nil)
+			},
+			then: func(t *testing.T, result *[]history.Entry, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, *result)
+			},
+		},
+		{
+			name:  "given an account outside the match when reading its history then it is reported as not found",
+			input: usecases.FindHistoryInput{Kind: history.KindMatch, EntityID: "match-1", AccountID: "stranger-acc"},
+			on: func(t *testing.T, m mocks) {
+				m.matches.On("FindByID", isCtx, "stranger-acc", "match-1").
+					Return(&domainmatch.Entity{ID: "match-1", Participants: []string{"owner-acc", "requester-acc"}}, nil)
+			},
+			then: func(t *testing.T, result *[]history.Entry, err error) {
+				assert.Nil(t, result)
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.NotFoundErrorCode, appErr.Code)
+			},
+		},
+		{
+			name:  "given an admin when reading the history of a match then it is returned without checking participants",
+			input: usecases.FindHistoryInput{Kind: history.KindMatch, EntityID: "match-1", AccountID: "admin-acc"},
+			on: func(t *testing.T, m mocks) {
+				m.history.On("Find", isCtx, history.KindMatch, "match-1").Return([]history.Entry{}, nil)
+			},
+			then: func(t *testing.T, result *[]history.Entry, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			},
+		},
+		{
+			name:  "given the history cannot be read when reading it then returns the error",
+			input: usecases.FindHistoryInput{Kind: history.KindMatchRequest, EntityID: requestID, AccountID: "owner-acc"},
+			on: func(t *testing.T, m mocks) {
+				m.reqs.On("Find", isCtx, mock.Anything).Return([]domainreq.Entity{request}, nil)
+				m.history.On("Find", isCtx, history.KindMatchRequest, requestID).Return(nil, errors.New("db connection error"))
+			},
+			then: func(t *testing.T, result *[]history.Entry, err error) {
+				assert.Nil(t, result)
+				assert.ErrorContains(t, err, "db connection error")
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mocks{
+				history: historymocks.NewRepository(t),
+				offers:  offermocks.NewRepository(t),
+				reqs:    reqmocks.NewRepository(t),
+				matches: matchmocks.NewRepository(t),
+			}
+			uc := usecases.NewFindHistoryUC(m.history, m.offers, m.reqs, m.matches, []string{"admin-acc"})
+
+			tt.on(t, m)
+
+			result, err := uc.Invoke(ctx, tt.input)
+
+			tt.then(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package events
+
+// MatchCancelledEventType identifies MatchCancelledEvent messages in the outbox.
+const MatchCancelledEventType = "match.cancelled"
+
+// MatchCancelledEvent is published when a confirmed match is called off before
+// being played.
+type MatchCancelledEvent struct {
+	MatchID      string   `json:"match_id"`
+	MatchOfferID string   `json:"match_offer_id"`
+	Participants []string `json:"participants"`
+}
+
+func (MatchCancelledEvent) EventType() string { return MatchCancelledEventType }
+func (MatchCancelledEvent) EventVersion() int { return 1 }
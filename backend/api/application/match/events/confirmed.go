@@ -0,0 +1,24 @@
+package events
+
+import (
+	"sportlink/api/domain/common"
+	"time"
+)
+
+// MatchConfirmedEventType identifies MatchConfirmedEvent messages in the outbox.
+const MatchConfirmedEventType = "match.confirmed"
+
+// MatchConfirmedEvent is published when an offer is confirmed and its match is
+// created, either by the owner or by the capacity consumer. Every participant is
+// someone to notify.
+type MatchConfirmedEvent struct {
+	MatchID        string       `json:"match_id"`
+	MatchOfferID   string       `json:"match_offer_id"`
+	OwnerAccountID string       `json:"owner_account_id"`
+	Participants   []string     `json:"participants"`
+	Sport          common.Sport `json:"sport"`
+	Day            time.Time    `json:"day"`
+}
+
+func (MatchConfirmedEvent) EventType() string { return MatchConfirmedEventType }
+func (MatchConfirmedEvent) EventVersion() int { return 1 }
@@ -0,0 +1,16 @@
+package events
+
+// MatchPlayedEventType identifies MatchPlayedEvent messages in the outbox.
+const MatchPlayedEventType = "match.played"
+
+// MatchPlayedEvent is published when the result of a match is recorded. Stats
+// subscribers use it to update the records of the participants.
+type MatchPlayedEvent struct {
+	MatchID         string   `json:"match_id"`
+	MatchOfferID    string   `json:"match_offer_id"`
+	Participants    []string `json:"participants"`
+	WinnerAccountID string   `json:"winner_account_id,omitempty"`
+}
+
+func (MatchPlayedEvent) EventType() string { return MatchPlayedEventType }
+func (MatchPlayedEvent) EventVersion() int { return 1 }
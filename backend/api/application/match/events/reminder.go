@@ -0,0 +1,37 @@
+package events
+
+import "time"
+
+// MatchReminderDueEventType identifies MatchReminderDueEvent messages in the outbox.
+const MatchReminderDueEventType = "match.reminder_due"
+
+// MatchAttendanceUnconfirmedEventType identifies MatchAttendanceUnconfirmedEvent messages in the outbox.
+const MatchAttendanceUnconfirmedEventType = "match.attendance_unconfirmed"
+
+// MatchReminderDueEvent is published when the participants of a match are to be
+// reminded of it. Lead tells how long before kickoff it was sent, e.g. "2h".
+type MatchReminderDueEvent struct {
+	MatchID      string    `json:"match_id"`
+	MatchOfferID string    `json:"match_offer_id"`
+	Participants []string  `json:"participants"`
+	StartTime    time.Time `json:"start_time"`
+	Lead         string    `json:"lead"`
+}
+
+func (MatchReminderDueEvent) EventType() string { return MatchReminderDueEventType }
+func (MatchReminderDueEvent) EventVersion() int { return 1 }
+
+// MatchAttendanceUnconfirmedEvent is published with the last reminder of a match when
+// some participants have not confirmed they are still coming, so its organiser can
+// chase them or find replacements.
+type MatchAttendanceUnconfirmedEvent struct {
+	MatchID            string   `json:"match_id"`
+	MatchOfferID       string   `json:"match_offer_id"`
+	OrganiserAccountID string   `json:"organiser_account_id"`
+	Unconfirmed        []string `json:"unconfirmed"`
+}
+
+func (MatchAttendanceUnconfirmedEvent) EventType() string {
+	return MatchAttendanceUnconfirmedEventType
+}
+func (MatchAttendanceUnconfirmedEvent) EventVersion() int { return 1 }
@@ -0,0 +1,9 @@
+package request
+
+// RecordMatchResultRequest represents the HTTP request body for recording the result of a
+// match. WinnerAccountID is left empty for a draw.
+type RecordMatchResultRequest struct {
+	LocalScore      *int   `json:"local_score" validate:"required,min=0"`
+	VisitorScore    *int   `json:"visitor_score" validate:"required,min=0"`
+	WinnerAccountID string `json:"winner_account_id"`
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type ChargeRequest struct {
+	MatchID        string
+	AccountID      string
+	Amount         int64 // minor units of Currency
+	Currency       string
+	IdempotencyKey string // the provider charges a key at most once, returning the first receipt on repeats
+}
+
+type ChargeReceipt struct {
+	Reference string
+}
+
+type RefundRequest struct {
+	MatchID         string
+	AccountID       string
+	Amount          int64 // minor units of Currency
+	Currency        string
+	ChargeReference string // reference of the charge being refunded, refunded at most once
+}
+
+type RefundReceipt struct {
+	Reference string
+}
+
+// ShareIdempotencyKey identifies the charge of a participant's share, so concurrent or
+// repeated payments of the same share are charged only once.
+func ShareIdempotencyKey(matchID, accountID string) string {
+	return fmt.Sprintf("match-share#%s#%s", matchID, accountID)
+}
+
+// PaymentProvider collects a participant's share of the match cost and hands it back
+// when the participant leaves.
+type PaymentProvider interface {
+	Charge(ctx context.Context, req ChargeRequest) (*ChargeReceipt, error)
+	Refund(ctx context.Context, req RefundRequest) (*RefundReceipt, error)
+}
+
+// fakePaymentProvider approves every charge and refund without moving money. It backs
+// local development and tests until a real provider is plugged in.
+type fakePaymentProvider struct {
+	mu       sync.Mutex
+	charges  map[string]ChargeReceipt
+	refunds  map[string]RefundReceipt
+	sequence int
+}
+
+func NewFakePaymentProvider() PaymentProvider {
+	return &fakePaymentProvider{
+		charges: map[string]ChargeReceipt{},
+		refunds: map[string]RefundReceipt{},
+	}
+}
+
+func (p *fakePaymentProvider) Charge(_ context.Context, req ChargeRequest) (*ChargeReceipt, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("charge amount must be positive")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if receipt, ok := p.charges[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		return &receipt, nil
+	}
+	p.sequence++
+	receipt := ChargeReceipt{Reference: fmt.Sprintf("fake-%s-%s-%d", req.MatchID, req.AccountID, p.sequence)}
+	if req.IdempotencyKey != "" {
+		p.charges[req.IdempotencyKey] = receipt
+	}
+	return &receipt, nil
+}
+
+func (p *fakePaymentProvider) Refund(_ context.Context, req RefundRequest) (*RefundReceipt, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("refund amount must be positive")
+	}
+	if req.ChargeReference == "" {
+		return nil, fmt.Errorf("refund needs the reference of the charge")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if receipt, ok := p.refunds[req.ChargeReference]; ok {
+		return &receipt, nil
+	}
+	p.sequence++
+	receipt := RefundReceipt{Reference: fmt.Sprintf("fake-refund-%s-%d", req.ChargeReference, p.sequence)}
+	p.refunds[req.ChargeReference] = receipt
+	return &receipt, nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/match"
+	"sportlink/pkg/log"
+)
+
+// PayOutRefunds hands back the shares refunded by the change from before to after, once
+// after has been saved. Refunds are keyed by the charge they return, so paying them out
+// again is harmless; a failed one stays recorded on the match for reconciliation.
+func PayOutRefunds(ctx context.Context, provider PaymentProvider, before, after match.Entity) {
+	if after.Payment == nil {
+		return
+	}
+	known := 0
+	if before.Payment != nil {
+		known = len(before.Payment.Refunds)
+	}
+	for _, refund := range after.Payment.Refunds[min(known, len(after.Payment.Refunds)):] {
+		_, err := provider.Refund(ctx, RefundRequest{
+			MatchID:         after.ID,
+			AccountID:       refund.AccountID,
+			Amount:          refund.Amount,
+			Currency:        after.Payment.Currency,
+			ChargeReference: refund.Reference,
+		})
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to refund account %s for match %s, charge reference %s", refund.AccountID, after.ID, refund.Reference), err)
+		}
+	}
+}
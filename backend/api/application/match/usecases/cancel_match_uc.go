@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sportlink/api/application/errors"
+	appevents "sportlink/api/application/events"
+	matchevent "sportlink/api/application/match/events"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type CancelMatchInput struct {
+	MatchID        string
+	OwnerAccountID string
+	Now            time.Time
+}
+
+// CancelMatchUC lets the owner of the originating offer call the match off before
+// kickoff.
+type CancelMatchUC struct {
+	matchRepository      match.Repository
+	matchOfferRepository matchoffer.Repository
+}
+
+func NewCancelMatchUC(matchRepository match.Repository, matchOfferRepository matchoffer.Repository) *CancelMatchUC {
+	return &CancelMatchUC{
+		matchRepository:      matchRepository,
+		matchOfferRepository: matchOfferRepository,
+	}
+}
+
+func (uc *CancelMatchUC) Invoke(ctx context.Context, input CancelMatchInput) (*match.Entity, error) {
+	entity, err := uc.matchRepository.FindByID(ctx, input.OwnerAccountID, input.MatchID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match %s", input.MatchID), err)
+		return nil, err
+	}
+	if entity == nil {
+		return nil, errors.NotFound("match not found")
+	}
+
+	offer, err := findOfferOfMatch(ctx, uc.matchOfferRepository, *entity)
+	if err != nil {
+		return nil, err
+	}
+	if offer.OwnerAccountID != input.OwnerAccountID {
+		return nil, errors.Unauthorized("only the match owner can cancel the match")
+	}
+	if offer.HasStarted(input.Now) {
+		return nil, errors.UseCaseExecutionFailed("match has already started")
+	}
+
+	cancelled, err := entity.Cancel(common.ActorOwner)
+	if err != nil {
+		return nil, errors.InvalidTransition(err.Error())
+	}
+
+	message, err := appevents.NewMessage(ctx, matchevent.MatchCancelledEvent{
+		MatchID:      cancelled.ID,
+		MatchOfferID: cancelled.MatchOfferID,
+		Participants: cancelled.Participants,
+	}, input.Now)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to build cancelled event for match %s", input.MatchID), err)
+		return nil, err
+	}
+
+	if err = uc.matchRepository.Save(ctx, cancelled, []outbox.Message{message}); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to cancel match %s", input.MatchID), err)
+		if stderrors.Is(err, common.ErrVersionConflict) {
+			return nil, errors.ConcurrentModification(err.Error())
+		}
+		return nil, err
+	}
+
+	return &cancelled, nil
+}
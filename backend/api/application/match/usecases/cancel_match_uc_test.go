@@ -0,0 +1,131 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "sportlink/api/application/errors"
+	matchevent "sportlink/api/application/match/events"
+	"sportlink/api/application/match/usecases"
+	"sportlink/api/domain/common"
+	domainmatch "sportlink/api/domain/match"
+	domainoffer "sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	matchmocks "sportlink/mocks/api/domain/match"
+	offermocks "sportlink/mocks/api/domain/matchoffer"
+)
+
+func TestCancelMatchUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	kickoff := time.Date(2030, 5, 10, 18, 0, 0, 0, time.UTC)
+
+	accepted := domainmatch.Entity{
+		ID:           "match-1",
+		MatchOfferID: "offer-1",
+		Participants: []string{"owner-1", "player-1"},
+		Status:       domainmatch.StatusAccepted,
+	}
+	offer := domainoffer.Entity{
+		ID:             "offer-1",
+		OwnerAccountID: "owner-1",
+		TimeSlot:       domainoffer.TimeSlot{StartTime: kickoff, EndTime: kickoff.Add(2 * time.Hour)},
+	}
+
+	input := usecases.CancelMatchInput{MatchID: "match-1", OwnerAccountID: "owner-1", Now: kickoff.Add(-time.Hour)}
+
+	expectMatchAndOffer := func(matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, accountID string) {
+		matchRepo.On("FindByID",
+			mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+			accountID,
+			"match-1",
+		).Return(&accepted, nil)
+		offerRepo.On("Find",
+			mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+			mock.MatchedBy(func(q domainoffer.DomainQuery) bool { return len(q.IDs) == 1 && q.IDs[0] == "offer-1" }),
+		).Return(domainoffer.Page{Entities: []domainoffer.Entity{offer}, Total: 1}, nil)
+	}
+
+	testCases := []struct {
+		name  string
+		input usecases.CancelMatchInput
+		on    func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository)
+		then  func(t *testing.T, result *domainmatch.Entity, err error)
+	}{
+		{
+			name:  "given accepted match before kickoff when the owner cancels then saves it as cancelled with the cancelled event",
+			input: input,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository) {
+				expectMatchAndOffer(matchRepo, offerRepo, "owner-1")
+				matchRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m domainmatch.Entity) bool { return m.Status == domainmatch.StatusCancelled }),
+					mock.MatchedBy(func(messages []outbox.Message) bool {
+						return len(messages) == 1 && messages[0].Type == matchevent.MatchCancelledEventType
+					}),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, domainmatch.StatusCancelled, result.Status)
+			},
+		},
+		{
+			name:  "given a participant who does not own the offer when cancelling then returns unauthorized",
+			input: usecases.CancelMatchInput{MatchID: "match-1", OwnerAccountID: "player-1", Now: input.Now},
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository) {
+				expectMatchAndOffer(matchRepo, offerRepo, "player-1")
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.ErrorContains(t, err, "only the match owner can cancel the match")
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name:  "given match that already kicked off when cancelling then returns error",
+			input: usecases.CancelMatchInput{MatchID: "match-1", OwnerAccountID: "owner-1", Now: kickoff},
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository) {
+				expectMatchAndOffer(matchRepo, offerRepo, "owner-1")
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.ErrorContains(t, err, "match has already started")
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name:  "given the match changed concurrently when cancelling then returns concurrent modification",
+			input: input,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository) {
+				expectMatchAndOffer(matchRepo, offerRepo, "owner-1")
+				matchRepo.On("Save", mock.Anything, mock.Anything, mock.Anything).
+					Return(fmt.Errorf("match match-1: %w", common.ErrVersionConflict))
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.ConcurrentModificationErrorCode, appErr.Code)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			matchRepo := matchmocks.NewRepository(t)
+			offerRepo := offermocks.NewRepository(t)
+			uc := usecases.NewCancelMatchUC(matchRepo, offerRepo)
+
+			tc.on(t, matchRepo, offerRepo)
+
+			result, err := uc.Invoke(ctx, tc.input)
+
+			tc.then(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/match"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type ConfirmAttendanceInput struct {
+	MatchID   string
+	AccountID string
+	Now       time.Time
+}
+
+// ConfirmAttendanceUC records that a participant is still coming to a match, the
+// one-tap answer to a reminder. Confirming again keeps the first confirmation.
+type ConfirmAttendanceUC struct {
+	matchRepository      match.Repository
+	attendanceRepository match.AttendanceRepository
+}
+
+func NewConfirmAttendanceUC(matchRepository match.Repository, attendanceRepository match.AttendanceRepository) *ConfirmAttendanceUC {
+	return &ConfirmAttendanceUC{
+		matchRepository:      matchRepository,
+		attendanceRepository: attendanceRepository,
+	}
+}
+
+func (uc *ConfirmAttendanceUC) Invoke(ctx context.Context, input ConfirmAttendanceInput) (*match.Attendance, error) {
+	entity, err := uc.matchRepository.FindByID(ctx, input.AccountID, input.MatchID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match %s", input.MatchID), err)
+		return nil, err
+	}
+	if entity == nil {
+		return nil, errors.NotFound("match not found")
+	}
+	if !slices.Contains(entity.Participants, input.AccountID) {
+		return nil, errors.Unauthorized("account is not a participant of the match")
+	}
+	if entity.Status != match.StatusAccepted {
+		return nil, errors.UseCaseExecutionFailed("match is no longer going ahead")
+	}
+
+	attendance := match.Attendance{
+		MatchID:     entity.ID,
+		AccountID:   input.AccountID,
+		ConfirmedAt: input.Now.UTC(),
+	}
+	if err = uc.attendanceRepository.Save(ctx, attendance); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to save attendance of account %s to match %s", input.AccountID, input.MatchID), err)
+		return nil, err
+	}
+	return &attendance, nil
+}
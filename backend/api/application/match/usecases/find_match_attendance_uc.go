@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/pkg/log"
+)
+
+type FindMatchAttendanceInput struct {
+	MatchID        string
+	OwnerAccountID string
+}
+
+// MatchAttendance tells who confirmed they are still coming to a match and who did not.
+type MatchAttendance struct {
+	MatchID     string
+	Confirmed   []match.Attendance
+	Unconfirmed []string
+}
+
+// FindMatchAttendanceUC lets the owner of the originating offer see which participants
+// have not confirmed their attendance.
+type FindMatchAttendanceUC struct {
+	matchRepository      match.Repository
+	matchOfferRepository matchoffer.Repository
+	attendanceRepository match.AttendanceRepository
+}
+
+func NewFindMatchAttendanceUC(
+	matchRepository match.Repository,
+	matchOfferRepository matchoffer.Repository,
+	attendanceRepository match.AttendanceRepository,
+) *FindMatchAttendanceUC {
+	return &FindMatchAttendanceUC{
+		matchRepository:      matchRepository,
+		matchOfferRepository: matchOfferRepository,
+		attendanceRepository: attendanceRepository,
+	}
+}
+
+func (uc *FindMatchAttendanceUC) Invoke(ctx context.Context, input FindMatchAttendanceInput) (*MatchAttendance, error) {
+	entity, err := uc.matchRepository.FindByID(ctx, input.OwnerAccountID, input.MatchID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match %s", input.MatchID), err)
+		return nil, err
+	}
+	if entity == nil {
+		return nil, errors.NotFound("match not found")
+	}
+
+	page, err := uc.matchOfferRepository.Find(ctx, matchoffer.DomainQuery{IDs: []string{entity.MatchOfferID}})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match offer %s", entity.MatchOfferID), err)
+		return nil, err
+	}
+	if len(page.Entities) == 0 || page.Entities[0].OwnerAccountID != input.OwnerAccountID {
+		return nil, errors.Unauthorized("only the match owner can see attendance")
+	}
+
+	attendances, err := uc.attendanceRepository.Find(ctx, entity.ID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get attendance of match %s", entity.ID), err)
+		return nil, err
+	}
+
+	return &MatchAttendance{
+		MatchID:     entity.ID,
+		Confirmed:   attendances,
+		Unconfirmed: match.Unconfirmed(entity.Participants, attendances, input.OwnerAccountID),
+	}, nil
+}
@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/pkg/log"
+)
+
+type FindMatchPaymentInput struct {
+	MatchID        string
+	OwnerAccountID string
+}
+
+// FindMatchPaymentUC lets the owner of the originating offer see how the cost was split
+// and who still owes their share.
+type FindMatchPaymentUC struct {
+	matchRepository      match.Repository
+	matchOfferRepository matchoffer.Repository
+}
+
+func NewFindMatchPaymentUC(matchRepository match.Repository, matchOfferRepository matchoffer.Repository) *FindMatchPaymentUC {
+	return &FindMatchPaymentUC{
+		matchRepository:      matchRepository,
+		matchOfferRepository: matchOfferRepository,
+	}
+}
+
+func (uc *FindMatchPaymentUC) Invoke(ctx context.Context, input FindMatchPaymentInput) (*match.Payment, error) {
+	entity, err := uc.matchRepository.FindByID(ctx, input.OwnerAccountID, input.MatchID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match %s", input.MatchID), err)
+		return nil, err
+	}
+	if entity == nil {
+		return nil, errors.NotFound("match not found")
+	}
+
+	page, err := uc.matchOfferRepository.Find(ctx, matchoffer.DomainQuery{IDs: []string{entity.MatchOfferID}})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match offer %s", entity.MatchOfferID), err)
+		return nil, err
+	}
+	if len(page.Entities) == 0 || page.Entities[0].OwnerAccountID != input.OwnerAccountID {
+		return nil, errors.Unauthorized("only the match owner can see outstanding payments")
+	}
+
+	if entity.Payment == nil {
+		return nil, errors.NotFound("match has no cost")
+	}
+	return entity.Payment, nil
+}
@@ -66,14 +66,14 @@ func (uc *FindMatchesUC) resolveOffers(ctx context.Context, entities []match.Ent
 		ids = append(ids, id)
 	}
 
-	page, err := uc.matchOfferRepository.Find(ctx, matchoffer.DomainQuery{IDs: ids})
+	offers, err := uc.matchOfferRepository.FindByIDs(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
 
-	offerMap := make(map[string]*matchoffer.Entity, len(page.Entities))
-	for i := range page.Entities {
-		o := page.Entities[i]
+	offerMap := make(map[string]*matchoffer.Entity, len(offers))
+	for i := range offers {
+		o := offers[i]
 		offerMap[o.ID] = &o
 	}
 	return offerMap, nil
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sportlink/api/application/errors"
+	"sportlink/api/application/match/service"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/match"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type PayMatchShareInput struct {
+	MatchID   string
+	AccountID string
+}
+
+// PayMatchShareUC charges a participant's share of the court cost through the payment
+// provider and records it as paid on the match.
+type PayMatchShareUC struct {
+	matchRepository match.Repository
+	paymentProvider service.PaymentProvider
+}
+
+func NewPayMatchShareUC(matchRepository match.Repository, paymentProvider service.PaymentProvider) *PayMatchShareUC {
+	return &PayMatchShareUC{
+		matchRepository: matchRepository,
+		paymentProvider: paymentProvider,
+	}
+}
+
+func (uc *PayMatchShareUC) Invoke(ctx context.Context, input PayMatchShareInput) (*match.Entity, error) {
+	entity, err := uc.matchRepository.FindByID(ctx, input.AccountID, input.MatchID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match %s", input.MatchID), err)
+		return nil, err
+	}
+	if entity == nil {
+		return nil, errors.NotFound("match not found")
+	}
+	if entity.Payment == nil {
+		return nil, errors.UseCaseExecutionFailed("match has no cost to pay")
+	}
+
+	share, ok := entity.Payment.Share(input.AccountID)
+	if !ok {
+		return nil, errors.Unauthorized("account is not a participant of the match")
+	}
+	if share.IsPaid() {
+		return nil, errors.UseCaseExecutionFailed("share is already paid")
+	}
+
+	receipt, err := uc.paymentProvider.Charge(ctx, service.ChargeRequest{
+		MatchID:   entity.ID,
+		AccountID: input.AccountID,
+		Amount:    share.Amount,
+		Currency:  entity.Payment.Currency,
+		// concurrent payments of the share, or a retry after the payment failed to be
+		// recorded, are charged once by the provider
+		IdempotencyKey: service.ShareIdempotencyKey(entity.ID, input.AccountID),
+	})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to charge share of account %s for match %s", input.AccountID, input.MatchID), err)
+		return nil, errors.UseCaseExecutionFailed("payment was not accepted")
+	}
+
+	paid, err := uc.recordPayment(ctx, *entity, input.AccountID, receipt.Reference)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to save paid share for match %s, provider reference %s", input.MatchID, receipt.Reference), err)
+		return nil, err
+	}
+
+	return paid, nil
+}
+
+// maxSaveAttempts bounds how many times a charged share is recorded against a match
+// that keeps changing concurrently.
+const maxSaveAttempts = 3
+
+// recordPayment marks the share as paid and saves the match. The charge already went
+// through, so when someone else updated the match in between it is reloaded and the
+// payment recorded again instead of charging twice.
+func (uc *PayMatchShareUC) recordPayment(ctx context.Context, entity match.Entity, accountID, reference string) (*match.Entity, error) {
+	for attempt := 1; ; attempt++ {
+		paid, err := entity.MarkSharePaid(accountID, reference, time.Now().UTC())
+		if err != nil {
+			return nil, errors.UseCaseExecutionFailed(err.Error())
+		}
+
+		err = uc.matchRepository.Save(ctx, paid, nil)
+		if err == nil {
+			return &paid, nil
+		}
+		if !stderrors.Is(err, common.ErrVersionConflict) {
+			return nil, err
+		}
+		if attempt == maxSaveAttempts {
+			return nil, errors.ConcurrentModification(err.Error())
+		}
+
+		reloaded, err := uc.matchRepository.FindByID(ctx, accountID, entity.ID)
+		if err != nil {
+			return nil, err
+		}
+		if reloaded == nil {
+			return nil, errors.NotFound("match not found")
+		}
+		entity = *reloaded
+	}
+}
@@ -0,0 +1,171 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "sportlink/api/application/errors"
+	"sportlink/api/application/match/service"
+	"sportlink/api/application/match/usecases"
+	"sportlink/api/domain/common"
+	domainmatch "sportlink/api/domain/match"
+	servicemocks "sportlink/mocks/api/application/match/service"
+	matchmocks "sportlink/mocks/api/domain/match"
+)
+
+func TestPayMatchShareUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+
+	matchWithCost := domainmatch.Entity{
+		ID:           "match-1",
+		MatchOfferID: "offer-1",
+		Participants: []string{"owner-1", "player-1"},
+		Status:       domainmatch.StatusAccepted,
+	}.SplitCost(2000, "ARS")
+
+	input := usecases.PayMatchShareInput{MatchID: "match-1", AccountID: "player-1"}
+
+	expectMatch := func(matchRepo *matchmocks.Repository, entity *domainmatch.Entity, err error) {
+		matchRepo.On("FindByID",
+			mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+			"player-1",
+			"match-1",
+		).Return(entity, err)
+	}
+
+	testCases := []struct {
+		name  string
+		input usecases.PayMatchShareInput
+		on    func(t *testing.T, matchRepo *matchmocks.Repository, provider *servicemocks.PaymentProvider)
+		then  func(t *testing.T, result *domainmatch.Entity, err error)
+	}{
+		{
+			name:  "given pending share when paying then charges the share amount and saves it as paid",
+			input: input,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, provider *servicemocks.PaymentProvider) {
+				expectMatch(matchRepo, &matchWithCost, nil)
+				provider.On("Charge",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					service.ChargeRequest{MatchID: "match-1", AccountID: "player-1", Amount: 1000, Currency: "ARS", IdempotencyKey: "match-share#match-1#player-1"},
+				).Return(&service.ChargeReceipt{Reference: "ref-1"}, nil)
+				matchRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m domainmatch.Entity) bool {
+						share, _ := m.Payment.Share("player-1")
+						return share.IsPaid() && share.Reference == "ref-1"
+					}),
+					mock.Anything,
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result.Payment.Outstanding(), 1)
+				assert.Equal(t, "owner-1", result.Payment.Outstanding()[0].AccountID)
+			},
+		},
+		{
+			name:  "given the match changed concurrently when saving then reloads it and records the payment without charging again",
+			input: input,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, provider *servicemocks.PaymentProvider) {
+				reloaded, _ := matchWithCost.MarkSharePaid("owner-1", "ref-owner", matchWithCost.CreatedAt)
+				reloaded.Version = 2
+				matchRepo.On("FindByID", mock.Anything, "player-1", "match-1").Return(&matchWithCost, nil).Once()
+				matchRepo.On("FindByID", mock.Anything, "player-1", "match-1").Return(&reloaded, nil).Once()
+				provider.On("Charge", mock.Anything, mock.Anything).Return(&service.ChargeReceipt{Reference: "ref-1"}, nil).Once()
+				matchRepo.On("Save", mock.Anything, mock.MatchedBy(func(m domainmatch.Entity) bool { return m.Version == 0 }), mock.Anything).
+					Return(fmt.Errorf("match match-1: %w", common.ErrVersionConflict)).Once()
+				matchRepo.On("Save", mock.Anything, mock.MatchedBy(func(m domainmatch.Entity) bool { return m.Version == 2 }), mock.Anything).
+					Return(nil).Once()
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, result.Payment.Outstanding())
+			},
+		},
+		{
+			name:  "given the match keeps changing concurrently when saving then gives up with a concurrent modification",
+			input: input,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, provider *servicemocks.PaymentProvider) {
+				matchRepo.On("FindByID", mock.Anything, "player-1", "match-1").Return(&matchWithCost, nil)
+				provider.On("Charge", mock.Anything, mock.Anything).Return(&service.ChargeReceipt{Reference: "ref-1"}, nil).Once()
+				matchRepo.On("Save", mock.Anything, mock.Anything, mock.Anything).
+					Return(fmt.Errorf("match match-1: %w", common.ErrVersionConflict)).Times(3)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.ConcurrentModificationErrorCode, appErr.Code)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name:  "given share already paid when paying then returns error without charging",
+			input: input,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, _ *servicemocks.PaymentProvider) {
+				paid, _ := matchWithCost.MarkSharePaid("player-1", "ref-0", matchWithCost.CreatedAt)
+				expectMatch(matchRepo, &paid, nil)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.ErrorContains(t, err, "share is already paid")
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name:  "given match without cost when paying then returns error",
+			input: input,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, _ *servicemocks.PaymentProvider) {
+				free := matchWithCost
+				free.Payment = nil
+				expectMatch(matchRepo, &free, nil)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.ErrorContains(t, err, "match has no cost to pay")
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name:  "given match not found when paying then returns not found",
+			input: input,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, _ *servicemocks.PaymentProvider) {
+				expectMatch(matchRepo, nil, nil)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.ErrorContains(t, err, "match not found")
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name:  "given payment provider rejects the charge when paying then does not save the match",
+			input: input,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, provider *servicemocks.PaymentProvider) {
+				expectMatch(matchRepo, &matchWithCost, nil)
+				provider.On("Charge", mock.Anything, mock.Anything).Return(nil, errors.New("card declined"))
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.ErrorContains(t, err, "payment was not accepted")
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			matchRepo := matchmocks.NewRepository(t)
+			provider := servicemocks.NewPaymentProvider(t)
+			uc := usecases.NewPayMatchShareUC(matchRepo, provider)
+
+			tc.on(t, matchRepo, provider)
+
+			result, err := uc.Invoke(ctx, tc.input)
+
+			tc.then(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"sportlink/api/application/errors"
+	appevents "sportlink/api/application/events"
+	matchevent "sportlink/api/application/match/events"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type RecordMatchResultInput struct {
+	MatchID         string
+	AccountID       string
+	Result          match.Result
+	WinnerAccountID string // empty for a draw
+	Now             time.Time
+}
+
+// RecordMatchResultUC lets a participant record the result once the match kicked off,
+// which marks it as played.
+type RecordMatchResultUC struct {
+	matchRepository      match.Repository
+	matchOfferRepository matchoffer.Repository
+}
+
+func NewRecordMatchResultUC(matchRepository match.Repository, matchOfferRepository matchoffer.Repository) *RecordMatchResultUC {
+	return &RecordMatchResultUC{
+		matchRepository:      matchRepository,
+		matchOfferRepository: matchOfferRepository,
+	}
+}
+
+func (uc *RecordMatchResultUC) Invoke(ctx context.Context, input RecordMatchResultInput) (*match.Entity, error) {
+	entity, err := uc.matchRepository.FindByID(ctx, input.AccountID, input.MatchID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match %s", input.MatchID), err)
+		return nil, err
+	}
+	if entity == nil {
+		return nil, errors.NotFound("match not found")
+	}
+	if !slices.Contains(entity.Participants, input.AccountID) {
+		return nil, errors.Unauthorized("account is not a participant of the match")
+	}
+
+	offer, err := findOfferOfMatch(ctx, uc.matchOfferRepository, *entity)
+	if err != nil {
+		return nil, err
+	}
+	if !offer.HasStarted(input.Now) {
+		return nil, errors.UseCaseExecutionFailed("match has not started yet")
+	}
+
+	played, err := entity.Play(input.Result, input.WinnerAccountID)
+	if stderrors.Is(err, common.ErrInvalidTransition) {
+		return nil, errors.InvalidTransition(err.Error())
+	}
+	if err != nil {
+		return nil, errors.UseCaseExecutionFailed(err.Error())
+	}
+
+	message, err := appevents.NewMessage(ctx, matchevent.MatchPlayedEvent{
+		MatchID:         played.ID,
+		MatchOfferID:    played.MatchOfferID,
+		Participants:    played.Participants,
+		WinnerAccountID: played.WinnerAccountID,
+	}, input.Now)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to build played event for match %s", input.MatchID), err)
+		return nil, err
+	}
+
+	if err = uc.matchRepository.Save(ctx, played, []outbox.Message{message}); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to save result of match %s", input.MatchID), err)
+		if stderrors.Is(err, common.ErrVersionConflict) {
+			return nil, errors.ConcurrentModification(err.Error())
+		}
+		return nil, err
+	}
+
+	return &played, nil
+}
+
+// findOfferOfMatch returns the offer the match was created from.
+func findOfferOfMatch(ctx context.Context, repo matchoffer.Repository, entity match.Entity) (*matchoffer.Entity, error) {
+	page, err := repo.Find(ctx, matchoffer.DomainQuery{IDs: []string{entity.MatchOfferID}})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match offer %s", entity.MatchOfferID), err)
+		return nil, err
+	}
+	if len(page.Entities) == 0 {
+		return nil, errors.NotFound("match offer not found")
+	}
+	return &page.Entities[0], nil
+}
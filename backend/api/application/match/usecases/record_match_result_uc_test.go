@@ -0,0 +1,167 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "sportlink/api/application/errors"
+	matchevent "sportlink/api/application/match/events"
+	"sportlink/api/application/match/usecases"
+	domainmatch "sportlink/api/domain/match"
+	domainoffer "sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	matchmocks "sportlink/mocks/api/domain/match"
+	offermocks "sportlink/mocks/api/domain/matchoffer"
+)
+
+func TestRecordMatchResultUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	kickoff := time.Date(2030, 5, 10, 18, 0, 0, 0, time.UTC)
+
+	accepted := domainmatch.Entity{
+		ID:           "match-1",
+		MatchOfferID: "offer-1",
+		Participants: []string{"owner-1", "player-1"},
+		Status:       domainmatch.StatusAccepted,
+		Version:      3,
+	}
+	offer := domainoffer.Entity{
+		ID:             "offer-1",
+		OwnerAccountID: "owner-1",
+		TimeSlot:       domainoffer.TimeSlot{StartTime: kickoff, EndTime: kickoff.Add(2 * time.Hour)},
+	}
+
+	input := usecases.RecordMatchResultInput{
+		MatchID:         "match-1",
+		AccountID:       "player-1",
+		Result:          domainmatch.Result{LocalScore: 2, VisitorScore: 1},
+		WinnerAccountID: "owner-1",
+		Now:             kickoff.Add(3 * time.Hour),
+	}
+
+	expectMatch := func(matchRepo *matchmocks.Repository, entity *domainmatch.Entity) {
+		matchRepo.On("FindByID",
+			mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+			"player-1",
+			"match-1",
+		).Return(entity, nil)
+	}
+	expectOffer := func(offerRepo *offermocks.Repository) {
+		offerRepo.On("Find",
+			mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+			mock.MatchedBy(func(q domainoffer.DomainQuery) bool { return len(q.IDs) == 1 && q.IDs[0] == "offer-1" }),
+		).Return(domainoffer.Page{Entities: []domainoffer.Entity{offer}, Total: 1}, nil)
+	}
+
+	testCases := []struct {
+		name  string
+		input func() usecases.RecordMatchResultInput
+		on    func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository)
+		then  func(t *testing.T, result *domainmatch.Entity, err error)
+	}{
+		{
+			name:  "given accepted match after kickoff when a participant records the result then saves it as played with the played event",
+			input: func() usecases.RecordMatchResultInput { return input },
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository) {
+				expectMatch(matchRepo, &accepted)
+				expectOffer(offerRepo)
+				matchRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m domainmatch.Entity) bool {
+						return m.Status == domainmatch.StatusPlayed && m.WinnerAccountID == "owner-1" &&
+							m.Result != nil && m.Result.LocalScore == 2 && m.Version == 3
+					}),
+					mock.MatchedBy(func(messages []outbox.Message) bool {
+						var e matchevent.MatchPlayedEvent
+						return len(messages) == 1 && messages[0].Type == matchevent.MatchPlayedEventType &&
+							json.Unmarshal(messages[0].Payload, &e) == nil && e.WinnerAccountID == "owner-1"
+					}),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, domainmatch.StatusPlayed, result.Status)
+			},
+		},
+		{
+			name: "given match before kickoff when recording the result then returns error without saving",
+			input: func() usecases.RecordMatchResultInput {
+				early := input
+				early.Now = kickoff.Add(-time.Hour)
+				return early
+			},
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository) {
+				expectMatch(matchRepo, &accepted)
+				expectOffer(offerRepo)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.ErrorContains(t, err, "match has not started yet")
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name:  "given match already cancelled when recording the result then returns invalid transition",
+			input: func() usecases.RecordMatchResultInput { return input },
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository) {
+				cancelled := accepted
+				cancelled.Status = domainmatch.StatusCancelled
+				expectMatch(matchRepo, &cancelled)
+				expectOffer(offerRepo)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.Equal(t, apperrors.InvalidTransition("match cannot go from CANCELLED to PLAYED by PARTICIPANT"), err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "given winner who did not play when recording the result then returns error",
+			input: func() usecases.RecordMatchResultInput {
+				stranger := input
+				stranger.WinnerAccountID = "stranger"
+				return stranger
+			},
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository) {
+				expectMatch(matchRepo, &accepted)
+				expectOffer(offerRepo)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.ErrorContains(t, err, "winner stranger is not a participant")
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name:  "given account outside the match when recording the result then returns unauthorized",
+			input: func() usecases.RecordMatchResultInput { return input },
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, _ *offermocks.Repository) {
+				other := accepted
+				other.Participants = []string{"owner-1", "player-2"}
+				expectMatch(matchRepo, &other)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.ErrorContains(t, err, "account is not a participant of the match")
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			matchRepo := matchmocks.NewRepository(t)
+			offerRepo := offermocks.NewRepository(t)
+			uc := usecases.NewRecordMatchResultUC(matchRepo, offerRepo)
+
+			tc.on(t, matchRepo, offerRepo)
+
+			result, err := uc.Invoke(ctx, tc.input())
+
+			tc.then(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type ScheduleMatchRemindersInput struct {
+	MatchID            string
+	MatchOfferID       string
+	OrganiserAccountID string
+	Now                time.Time
+}
+
+// ScheduleMatchRemindersUC plans the reminders of a newly confirmed match at the
+// configured offsets before the start of its time slot. Scheduling again for the same
+// match leaves the same reminders.
+type ScheduleMatchRemindersUC struct {
+	reminderRepository   match.ReminderRepository
+	matchOfferRepository matchoffer.Repository
+	offsets              []time.Duration
+}
+
+func NewScheduleMatchRemindersUC(
+	reminderRepository match.ReminderRepository,
+	matchOfferRepository matchoffer.Repository,
+	offsets []time.Duration,
+) *ScheduleMatchRemindersUC {
+	return &ScheduleMatchRemindersUC{
+		reminderRepository:   reminderRepository,
+		matchOfferRepository: matchOfferRepository,
+		offsets:              offsets,
+	}
+}
+
+func (uc *ScheduleMatchRemindersUC) Invoke(ctx context.Context, input ScheduleMatchRemindersInput) (*[]match.Reminder, error) {
+	page, err := uc.matchOfferRepository.Find(ctx, matchoffer.DomainQuery{IDs: []string{input.MatchOfferID}})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match offer %s", input.MatchOfferID), err)
+		return nil, err
+	}
+	if len(page.Entities) == 0 {
+		log.GetLogger(ctx).Info(fmt.Sprintf("match offer %s not found, no reminders for match %s", input.MatchOfferID, input.MatchID))
+		return &[]match.Reminder{}, nil
+	}
+
+	reminders := match.NewReminders(input.MatchID, input.OrganiserAccountID, page.Entities[0].TimeSlot.StartTime, uc.offsets, input.Now)
+	if len(reminders) == 0 {
+		return &reminders, nil
+	}
+	if err = uc.reminderRepository.Schedule(ctx, reminders); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to schedule reminders of match %s", input.MatchID), err)
+		return nil, err
+	}
+	return &reminders, nil
+}
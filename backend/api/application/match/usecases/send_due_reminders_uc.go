@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	appevents "sportlink/api/application/events"
+	matchevent "sportlink/api/application/match/events"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type SendDueRemindersInput struct {
+	Now time.Time
+}
+
+type SendDueRemindersResult struct {
+	Sent int
+}
+
+// reminderLease is how long a claimed reminder is kept from other instances. A reminder
+// that was not sent by then, because publishing failed or the instance stopped, is
+// claimed and sent again by a later run.
+const reminderLease = 5 * time.Minute
+
+// SendDueRemindersUC sends the reminders that are due. Each reminder is claimed before
+// it is sent, so instances running it at the same time never send one twice, and its
+// messages are written in the same transaction that deletes it while the claim still
+// holds. With the last reminder of a match, its organiser learns who has not confirmed
+// attendance yet.
+type SendDueRemindersUC struct {
+	reminderRepository   match.ReminderRepository
+	matchRepository      match.Repository
+	attendanceRepository match.AttendanceRepository
+	batchSize            int
+}
+
+func NewSendDueRemindersUC(
+	reminderRepository match.ReminderRepository,
+	matchRepository match.Repository,
+	attendanceRepository match.AttendanceRepository,
+	batchSize int,
+) *SendDueRemindersUC {
+	return &SendDueRemindersUC{
+		reminderRepository:   reminderRepository,
+		matchRepository:      matchRepository,
+		attendanceRepository: attendanceRepository,
+		batchSize:            batchSize,
+	}
+}
+
+func (uc *SendDueRemindersUC) Invoke(ctx context.Context, input SendDueRemindersInput) (*SendDueRemindersResult, error) {
+	reminders, err := uc.reminderRepository.FindDue(ctx, input.Now, uc.batchSize)
+	if err != nil {
+		log.GetLogger(ctx).Error("failed to find due reminders", err)
+		return nil, err
+	}
+
+	result := &SendDueRemindersResult{}
+	for _, reminder := range reminders {
+		leasedUntil := input.Now.Add(reminderLease)
+		claimed, err := uc.reminderRepository.Claim(ctx, reminder, input.Now, leasedUntil)
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to claim reminder of match %s", reminder.MatchID), err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if uc.send(ctx, reminder, leasedUntil, input.Now) {
+			result.Sent++
+		}
+	}
+	return result, nil
+}
+
+// send deletes the reminder of a match still going ahead together with the messages
+// announcing it. A reminder that could not be written stays claimed until its lease is
+// over, and is sent by the first run after that; one whose lease was lost meanwhile is
+// left to the instance that holds it now.
+func (uc *SendDueRemindersUC) send(ctx context.Context, reminder match.Reminder, leasedUntil, now time.Time) bool {
+	entity, err := uc.matchRepository.FindByID(ctx, reminder.OrganiserAccountID, reminder.MatchID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match %s", reminder.MatchID), err)
+		return false
+	}
+	if entity == nil || entity.Status != match.StatusAccepted {
+		uc.delete(ctx, reminder, leasedUntil, nil)
+		return false
+	}
+
+	events := []appevents.Event{matchevent.MatchReminderDueEvent{
+		MatchID:      entity.ID,
+		MatchOfferID: entity.MatchOfferID,
+		Participants: entity.Participants,
+		StartTime:    reminder.StartTime,
+		Lead:         reminder.Lead(),
+	}}
+	if reminder.Final {
+		if unconfirmed := uc.unconfirmed(ctx, *entity, reminder.OrganiserAccountID); unconfirmed != nil {
+			events = append(events, *unconfirmed)
+		}
+	}
+	messages, err := appevents.NewMessages(ctx, now, events...)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to build reminder of match %s", entity.ID), err)
+		return false
+	}
+	return uc.delete(ctx, reminder, leasedUntil, messages)
+}
+
+// unconfirmed tells the organiser which participants have not confirmed they are still
+// coming, or returns nil when everyone did. The reminder goes out anyway, so failures to
+// read the attendance are only logged.
+func (uc *SendDueRemindersUC) unconfirmed(ctx context.Context, entity match.Entity, organiserAccountID string) *matchevent.MatchAttendanceUnconfirmedEvent {
+	attendances, err := uc.attendanceRepository.Find(ctx, entity.ID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get attendance of match %s", entity.ID), err)
+		return nil
+	}
+
+	unconfirmed := match.Unconfirmed(entity.Participants, attendances, organiserAccountID)
+	if len(unconfirmed) == 0 {
+		return nil
+	}
+	return &matchevent.MatchAttendanceUnconfirmedEvent{
+		MatchID:            entity.ID,
+		MatchOfferID:       entity.MatchOfferID,
+		OrganiserAccountID: organiserAccountID,
+		Unconfirmed:        unconfirmed,
+	}
+}
+
+// delete takes the reminder out of the schedule with the messages raised by sending it,
+// and reports whether it did. Failures are only logged: the reminder stays claimed and is
+// picked up again once its lease is over.
+func (uc *SendDueRemindersUC) delete(ctx context.Context, reminder match.Reminder, leasedUntil time.Time, messages []outbox.Message) bool {
+	err := uc.reminderRepository.Delete(ctx, reminder, leasedUntil, messages)
+	if stderrors.Is(err, match.ErrReminderLeaseLost) {
+		log.GetLogger(ctx).Error(fmt.Sprintf("lease on reminder of match %s ended before it was sent, leaving it to the next claim", reminder.MatchID), err)
+		return false
+	}
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to delete reminder of match %s", reminder.MatchID), err)
+		return false
+	}
+	return true
+}
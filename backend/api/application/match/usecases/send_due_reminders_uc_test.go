@@ -0,0 +1,165 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	matchevent "sportlink/api/application/match/events"
+	"sportlink/api/application/match/usecases"
+	domainmatch "sportlink/api/domain/match"
+	"sportlink/api/domain/outbox"
+	matchmocks "sportlink/mocks/api/domain/match"
+)
+
+func TestSendDueRemindersUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 12, 17, 0, 0, 0, time.UTC)
+	kickoff := now.Add(2 * time.Hour)
+
+	upcoming := domainmatch.Entity{
+		ID:           "match-1",
+		MatchOfferID: "offer-1",
+		Participants: []string{"owner-1", "player-1", "player-2"},
+		Status:       domainmatch.StatusAccepted,
+	}
+	dayBefore := domainmatch.Reminder{MatchID: "match-1", OrganiserAccountID: "owner-1", StartTime: kickoff, Offset: 24 * time.Hour}
+	final := domainmatch.Reminder{MatchID: "match-1", OrganiserAccountID: "owner-1", StartTime: kickoff, Offset: 2 * time.Hour, Final: true}
+
+	leaseEnd := now.Add(5 * time.Minute)
+
+	isCtx := mock.MatchedBy(func(c context.Context) bool { return c == ctx })
+	isReminderMessage := func(messages []outbox.Message, lead string) bool {
+		var e matchevent.MatchReminderDueEvent
+		return len(messages) > 0 && messages[0].Type == matchevent.MatchReminderDueEventType &&
+			messages[0].Decode(&e) == nil && e.MatchID == "match-1" && e.MatchOfferID == "offer-1" &&
+			assert.ObjectsAreEqual(upcoming.Participants, e.Participants) && e.StartTime.Equal(kickoff) && e.Lead == lead
+	}
+
+	type dependencies struct {
+		reminders   *matchmocks.ReminderRepository
+		matches     *matchmocks.Repository
+		attendances *matchmocks.AttendanceRepository
+	}
+
+	testCases := []struct {
+		name string
+		on   func(t *testing.T, d dependencies)
+		then func(t *testing.T, result *usecases.SendDueRemindersResult, err error)
+	}{
+		{
+			name: "given a due reminder when sending then every participant is reminded how long before kickoff",
+			on: func(t *testing.T, d dependencies) {
+				d.reminders.On("FindDue", isCtx, now, 50).Return([]domainmatch.Reminder{dayBefore}, nil)
+				d.reminders.On("Claim", isCtx, dayBefore, now, leaseEnd).Return(true, nil)
+				d.matches.On("FindByID", isCtx, "owner-1", "match-1").Return(&upcoming, nil)
+				d.reminders.On("Delete", isCtx, dayBefore, leaseEnd, mock.MatchedBy(func(messages []outbox.Message) bool {
+					return len(messages) == 1 && isReminderMessage(messages, "24h")
+				})).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.SendDueRemindersResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, result.Sent)
+			},
+		},
+		{
+			name: "given the final reminder when sending then the organiser learns who has not confirmed in the same write",
+			on: func(t *testing.T, d dependencies) {
+				d.reminders.On("FindDue", isCtx, now, 50).Return([]domainmatch.Reminder{final}, nil)
+				d.reminders.On("Claim", isCtx, final, now, leaseEnd).Return(true, nil)
+				d.matches.On("FindByID", isCtx, "owner-1", "match-1").Return(&upcoming, nil)
+				d.attendances.On("Find", isCtx, "match-1").Return([]domainmatch.Attendance{
+					{MatchID: "match-1", AccountID: "player-1", ConfirmedAt: now.Add(-time.Hour)},
+				}, nil)
+				d.reminders.On("Delete", isCtx, final, leaseEnd, mock.MatchedBy(func(messages []outbox.Message) bool {
+					var e matchevent.MatchAttendanceUnconfirmedEvent
+					return len(messages) == 2 && isReminderMessage(messages, "2h") &&
+						messages[1].Type == matchevent.MatchAttendanceUnconfirmedEventType && messages[1].Decode(&e) == nil &&
+						e.MatchID == "match-1" && e.OrganiserAccountID == "owner-1" &&
+						assert.ObjectsAreEqual([]string{"player-2"}, e.Unconfirmed)
+				})).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.SendDueRemindersResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, result.Sent)
+			},
+		},
+		{
+			name: "given another instance claimed the reminder first when sending then it is not sent again",
+			on: func(t *testing.T, d dependencies) {
+				d.reminders.On("FindDue", isCtx, now, 50).Return([]domainmatch.Reminder{dayBefore}, nil)
+				d.reminders.On("Claim", isCtx, dayBefore, now, leaseEnd).Return(false, nil)
+			},
+			then: func(t *testing.T, result *usecases.SendDueRemindersResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, result.Sent)
+			},
+		},
+		{
+			name: "given the match was cancelled when sending then the reminder is dropped",
+			on: func(t *testing.T, d dependencies) {
+				cancelled := upcoming
+				cancelled.Status = domainmatch.StatusCancelled
+				d.reminders.On("FindDue", isCtx, now, 50).Return([]domainmatch.Reminder{dayBefore}, nil)
+				d.reminders.On("Claim", isCtx, dayBefore, now, leaseEnd).Return(true, nil)
+				d.matches.On("FindByID", isCtx, "owner-1", "match-1").Return(&cancelled, nil)
+				d.reminders.On("Delete", isCtx, dayBefore, leaseEnd, []outbox.Message(nil)).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.SendDueRemindersResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, result.Sent)
+			},
+		},
+		{
+			name: "given the reminder cannot be written when sending then it is kept for a later run",
+			on: func(t *testing.T, d dependencies) {
+				d.reminders.On("FindDue", isCtx, now, 50).Return([]domainmatch.Reminder{dayBefore}, nil)
+				d.reminders.On("Claim", isCtx, dayBefore, now, leaseEnd).Return(true, nil)
+				d.matches.On("FindByID", isCtx, "owner-1", "match-1").Return(&upcoming, nil)
+				d.reminders.On("Delete", isCtx, dayBefore, leaseEnd, mock.Anything).Return(errors.New("db connection error"))
+			},
+			then: func(t *testing.T, result *usecases.SendDueRemindersResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, result.Sent)
+			},
+		},
+		{
+			name: "given the lease ended and another instance claimed the reminder when sending then it is left to that instance",
+			on: func(t *testing.T, d dependencies) {
+				d.reminders.On("FindDue", isCtx, now, 50).Return([]domainmatch.Reminder{dayBefore}, nil)
+				d.reminders.On("Claim", isCtx, dayBefore, now, leaseEnd).Return(true, nil)
+				d.matches.On("FindByID", isCtx, "owner-1", "match-1").Return(&upcoming, nil)
+				d.reminders.On("Delete", isCtx, dayBefore, leaseEnd, mock.Anything).
+					Return(fmt.Errorf("reminder of match match-1: %w", domainmatch.ErrReminderLeaseLost))
+			},
+			then: func(t *testing.T, result *usecases.SendDueRemindersResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, result.Sent)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := dependencies{
+				reminders:   matchmocks.NewReminderRepository(t),
+				matches:     matchmocks.NewRepository(t),
+				attendances: matchmocks.NewAttendanceRepository(t),
+			}
+			uc := usecases.NewSendDueRemindersUC(d.reminders, d.matches, d.attendances, 50)
+
+			tc.on(t, d)
+
+			result, err := uc.Invoke(ctx, usecases.SendDueRemindersInput{Now: now})
+
+			tc.then(t, result, err)
+		})
+	}
+}
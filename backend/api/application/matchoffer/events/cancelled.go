@@ -0,0 +1,16 @@
+package events
+
+// MatchOfferCancelledEventType identifies MatchOfferCancelledEvent messages in the outbox.
+const MatchOfferCancelledEventType = "matchoffer.cancelled"
+
+// MatchOfferCancelledEvent is published when the owner withdraws an offer. The requests
+// that were still pending or waitlisted at that moment are closed along with it.
+type MatchOfferCancelledEvent struct {
+	MatchOfferID        string   `json:"match_offer_id"`
+	OwnerAccountID      string   `json:"owner_account_id"`
+	ClosedRequestIDs    []string `json:"closed_request_ids,omitempty"`
+	RequesterAccountIDs []string `json:"requester_account_ids,omitempty"`
+}
+
+func (MatchOfferCancelledEvent) EventType() string { return MatchOfferCancelledEventType }
+func (MatchOfferCancelledEvent) EventVersion() int { return 1 }
@@ -1,9 +1,15 @@
 package events
 
+// MatchOfferCapacityReachedEventType identifies MatchOfferCapacityReachedEvent messages in the outbox.
+const MatchOfferCapacityReachedEventType = "matchoffer.capacity_reached"
+
 // MatchOfferCapacityReachedEvent is published when all spots in a match offer
 // have been accepted. A consumer can then call ConfirmMatchOfferUC to create
 // the match automatically.
 type MatchOfferCapacityReachedEvent struct {
-	MatchOfferID   string
-	OwnerAccountID string
+	MatchOfferID   string `json:"match_offer_id"`
+	OwnerAccountID string `json:"owner_account_id"`
 }
+
+func (MatchOfferCapacityReachedEvent) EventType() string { return MatchOfferCapacityReachedEventType }
+func (MatchOfferCapacityReachedEvent) EventVersion() int { return 1 }
@@ -0,0 +1,23 @@
+package events
+
+import (
+	"sportlink/api/domain/common"
+	"time"
+)
+
+// MatchOfferCreatedEventType identifies MatchOfferCreatedEvent messages in the outbox.
+const MatchOfferCreatedEventType = "matchoffer.created"
+
+// MatchOfferCreatedEvent is published when an account publishes a new match offer.
+type MatchOfferCreatedEvent struct {
+	MatchOfferID   string       `json:"match_offer_id"`
+	OwnerAccountID string       `json:"owner_account_id"`
+	Sport          common.Sport `json:"sport"`
+	Day            time.Time    `json:"day"`
+	StartTime      time.Time    `json:"start_time"`
+	EndTime        time.Time    `json:"end_time"`
+	Locality       string       `json:"locality"`
+}
+
+func (MatchOfferCreatedEvent) EventType() string { return MatchOfferCreatedEventType }
+func (MatchOfferCreatedEvent) EventVersion() int { return 1 }
@@ -0,0 +1,17 @@
+package events
+
+// MatchOfferExpiredEventType identifies MatchOfferExpiredEvent messages in the outbox.
+const MatchOfferExpiredEventType = "matchoffer.expired"
+
+// MatchOfferExpiredEvent is published when the expiry sweeper marks an offer as
+// expired because its time slot ended before it was confirmed. The requests that
+// were still pending at that moment are expired along with it.
+type MatchOfferExpiredEvent struct {
+	MatchOfferID        string   `json:"match_offer_id"`
+	OwnerAccountID      string   `json:"owner_account_id"`
+	ExpiredRequestIDs   []string `json:"expired_request_ids"`
+	RequesterAccountIDs []string `json:"requester_account_ids"`
+}
+
+func (MatchOfferExpiredEvent) EventType() string { return MatchOfferExpiredEventType }
+func (MatchOfferExpiredEvent) EventVersion() int { return 1 }
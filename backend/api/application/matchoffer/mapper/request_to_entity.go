@@ -110,7 +110,32 @@ func CreationRequestToEntity(req request.NewMatchOfferRequest, ownerAccountID st
 	dayInTz := day.In(tz)
 	createdAt := time.Now().In(tz)
 
-	return matchoffer.NewMatchOffer(
+	positions := make([]matchoffer.PositionNeed, len(req.PositionNeeds))
+	for i, p := range req.PositionNeeds {
+		positions[i] = matchoffer.PositionNeed{Position: p.Position, Spots: p.Spots}
+	}
+	playerNeeds, err := matchoffer.NewPlayerNeeds(req.PlayersCommitted, req.SpotsNeeded, positions)
+	if err != nil {
+		return matchoffer.Entity{}, err
+	}
+	if req.Capacity > 0 && playerNeeds.SpotsNeeded == 0 && playerNeeds.Committed >= req.Capacity {
+		return matchoffer.Entity{}, fmt.Errorf("players committed (%d) must be fewer than the capacity (%d)", playerNeeds.Committed, req.Capacity)
+	}
+
+	visibility, err := matchoffer.ParseVisibility(req.Visibility)
+	if err != nil {
+		return matchoffer.Entity{}, err
+	}
+
+	var cost matchoffer.Cost
+	if req.Cost != nil {
+		cost, err = matchoffer.NewCost(req.Cost.Amount, req.Cost.Currency)
+		if err != nil {
+			return matchoffer.Entity{}, err
+		}
+	}
+
+	offer := matchoffer.NewMatchOffer(
 		req.TeamName,
 		sport,
 		dayInTz,
@@ -121,5 +146,12 @@ func CreationRequestToEntity(req request.NewMatchOfferRequest, ownerAccountID st
 		createdAt,
 		ownerAccountID,
 		req.Capacity,
-	), nil
+	)
+	offer.TeamID = req.TeamID
+	offer.PlayerNeeds = playerNeeds
+	offer.Visibility = visibility
+	offer.InvitedAccountIDs = req.InvitedAccountIDs
+	offer.Cost = cost
+
+	return offer, nil
 }
@@ -2,6 +2,7 @@ package request
 
 // NewMatchOfferRequest defines the structure of the request body for the match offer creation endpoint.
 type NewMatchOfferRequest struct {
+	TeamID             string             `json:"team_id"` // takes precedence over team_name when set
 	TeamName           string             `json:"team_name"`
 	Sport              string             `json:"sport" validate:"required"`
 	Day                string             `json:"day" validate:"required"` // ISO date string
@@ -9,6 +10,18 @@ type NewMatchOfferRequest struct {
 	Location           Location           `json:"location" validate:"required"`
 	AdmittedCategories CategoryRangeInput `json:"admitted_categories" validate:"required"`
 	Capacity           int                `json:"capacity"` // 0 = no auto-confirm; >0 = total spots (owner + requesters)
+	PlayersCommitted   int                `json:"players_committed" validate:"gte=0"`
+	SpotsNeeded        int                `json:"spots_needed" validate:"gte=0"` // takes precedence over capacity when set
+	PositionNeeds      []PositionNeed     `json:"position_needs" validate:"omitempty,dive"`
+	Visibility         string             `json:"visibility" validate:"omitempty,oneof=PUBLIC TEAM INVITE LINK"` // empty = PUBLIC
+	InvitedAccountIDs  []string           `json:"invited_account_ids" validate:"omitempty,dive,required"`
+	Cost               *Cost              `json:"cost" validate:"omitempty"` // absent = free match
+}
+
+// Cost is the total court booking cost, in minor units of Currency (e.g. cents)
+type Cost struct {
+	Amount   int64  `json:"amount" validate:"gte=0"`
+	Currency string `json:"currency" validate:"omitempty,len=3,uppercase"`
 }
 
 type TimeSlot struct {
@@ -24,6 +37,11 @@ type Location struct {
 	Longitude *float64 `json:"longitude,omitempty"`
 }
 
+type PositionNeed struct {
+	Position string `json:"position" validate:"required"`
+	Spots    int    `json:"spots" validate:"required,gt=0"`
+}
+
 type CategoryRangeInput struct {
 	Type       string `json:"type" validate:"required,oneof=SPECIFIC GREATER_THAN LESS_THAN BETWEEN"`
 	Categories []int  `json:"categories" validate:"omitempty"`
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/team"
+)
+
+// Viewer is the account looking at an offer, with the share token it arrived with (if any).
+type Viewer struct {
+	AccountID  string
+	ShareToken string
+}
+
+// VisibilityPolicy decides whether a viewer may see a match offer and send requests to it.
+type VisibilityPolicy interface {
+	CanView(ctx context.Context, offer matchoffer.Entity, viewer Viewer) (bool, error)
+	// AudienceOf returns what the account can see without a share link, so searches can
+	// filter on it.
+	AudienceOf(ctx context.Context, accountID string) (matchoffer.Audience, error)
+}
+
+type visibilityPolicy struct {
+	teamRepository team.Repository
+	signer         ShareLinkSigner
+}
+
+func NewVisibilityPolicy(teamRepository team.Repository, signer ShareLinkSigner) VisibilityPolicy {
+	return &visibilityPolicy{
+		teamRepository: teamRepository,
+		signer:         signer,
+	}
+}
+
+// CanView lets the owner and invited accounts see any offer. Otherwise:
+//   - PUBLIC: everyone
+//   - TEAM: members of the team the offer was published for (matched by team ID)
+//   - LINK: whoever holds a valid share token
+//   - INVITE: nobody else
+func (p *visibilityPolicy) CanView(ctx context.Context, offer matchoffer.Entity, viewer Viewer) (bool, error) {
+	if (matchoffer.Audience{AccountID: viewer.AccountID}).CanView(offer) {
+		return true, nil
+	}
+
+	switch offer.Visibility {
+	case matchoffer.VisibilityLink:
+		return p.signer.Verify(offer, viewer.ShareToken), nil
+	case matchoffer.VisibilityTeam:
+		audience, err := p.AudienceOf(ctx, viewer.AccountID)
+		if err != nil {
+			return false, err
+		}
+		return audience.CanView(offer), nil
+	default:
+		return false, nil
+	}
+}
+
+// AudienceOf looks up the teams the account plays in with a single query.
+func (p *visibilityPolicy) AudienceOf(ctx context.Context, accountID string) (matchoffer.Audience, error) {
+	audience := matchoffer.Audience{AccountID: accountID}
+	if accountID == "" {
+		return audience, nil
+	}
+	teams, err := p.teamRepository.Find(ctx, team.DomainQuery{MemberAccountID: accountID})
+	if err != nil {
+		return matchoffer.Audience{}, fmt.Errorf("failed to find teams of account %s: %w", accountID, err)
+	}
+	for _, t := range teams {
+		audience.Teams = append(audience.Teams, matchoffer.TeamRef{ID: t.ID})
+	}
+	return audience, nil
+}
@@ -0,0 +1,247 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sportlink/api/application/matchoffer/service"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/player"
+	"sportlink/api/domain/team"
+	teammocks "sportlink/mocks/api/domain/team"
+)
+
+func TestVisibilityPolicy_CanView(t *testing.T) {
+	ctx := context.Background()
+	signer := service.NewShareLinkSigner("share-secret", time.Hour)
+	expiredSigner := service.NewShareLinkSigner("share-secret", -time.Minute)
+
+	offer := matchoffer.Entity{
+		ID:                "offer-1",
+		TeamID:            "team-1",
+		TeamName:          "Los Leones FC",
+		Sport:             common.Football,
+		OwnerAccountID:    "owner-1",
+		InvitedAccountIDs: []string{"invited-1"},
+	}
+	withVisibility := func(v matchoffer.Visibility) matchoffer.Entity {
+		o := offer
+		o.Visibility = v
+		return o
+	}
+	linkOffer := withVisibility(matchoffer.VisibilityLink)
+	validToken, _, _ := signer.Sign(linkOffer)
+	otherOfferToken, _, _ := signer.Sign(matchoffer.Entity{ID: "offer-2", Visibility: matchoffer.VisibilityLink})
+	expiredToken, _, _ := expiredSigner.Sign(linkOffer)
+
+	testCases := []struct {
+		name   string
+		offer  matchoffer.Entity
+		viewer service.Viewer
+		on     func(t *testing.T, teamRepo *teammocks.Repository)
+		then   func(t *testing.T, canView bool, err error)
+	}{
+		{
+			name:   "given public offer when any account views it then it is visible",
+			offer:  withVisibility(matchoffer.VisibilityPublic),
+			viewer: service.Viewer{AccountID: "stranger-1"},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, canView)
+			},
+		},
+		{
+			name:   "given offer saved before visibility existed when any account views it then it is visible",
+			offer:  offer,
+			viewer: service.Viewer{AccountID: "stranger-1"},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, canView)
+			},
+		},
+		{
+			name:   "given invite-only offer when an invited account views it then it is visible",
+			offer:  withVisibility(matchoffer.VisibilityInvite),
+			viewer: service.Viewer{AccountID: "invited-1"},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, canView)
+			},
+		},
+		{
+			name:   "given invite-only offer when a stranger views it then it is hidden",
+			offer:  withVisibility(matchoffer.VisibilityInvite),
+			viewer: service.Viewer{AccountID: "stranger-1"},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, canView)
+			},
+		},
+		{
+			name:   "given invite-only offer when the owner views it then it is visible",
+			offer:  withVisibility(matchoffer.VisibilityInvite),
+			viewer: service.Viewer{AccountID: "owner-1"},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, canView)
+			},
+		},
+		{
+			name:   "given link offer when a stranger holds a valid share token then it is visible",
+			offer:  linkOffer,
+			viewer: service.Viewer{AccountID: "stranger-1", ShareToken: validToken},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, canView)
+			},
+		},
+		{
+			name:   "given link offer when the share token belongs to another offer then it is hidden",
+			offer:  linkOffer,
+			viewer: service.Viewer{AccountID: "stranger-1", ShareToken: otherOfferToken},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, canView)
+			},
+		},
+		{
+			name:   "given link offer when the share token expired then it is hidden",
+			offer:  linkOffer,
+			viewer: service.Viewer{AccountID: "stranger-1", ShareToken: expiredToken},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, canView)
+			},
+		},
+		{
+			name:   "given link offer when its share links were revoked after signing then it is hidden",
+			offer:  linkOffer.RevokeShareLinks(),
+			viewer: service.Viewer{AccountID: "stranger-1", ShareToken: validToken},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, canView)
+			},
+		},
+		{
+			name:   "given link offer when the share token was tampered with then it is hidden",
+			offer:  linkOffer,
+			viewer: service.Viewer{AccountID: "stranger-1", ShareToken: "9999999999." + validToken[strings.Index(validToken, ".")+1:]},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, canView)
+			},
+		},
+		{
+			name:   "given team offer when a member of the owner's team views it then it is visible",
+			offer:  withVisibility(matchoffer.VisibilityTeam),
+			viewer: service.Viewer{AccountID: "member-1"},
+			on: func(t *testing.T, teamRepo *teammocks.Repository) {
+				teamRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q team.DomainQuery) bool { return q.MemberAccountID == "member-1" }),
+				).Return([]team.Entity{{
+					ID:             "team-1",
+					Name:           "Los Leones FC",
+					Sport:          common.Football,
+					OwnerAccountID: "owner-1",
+					Members:        []player.Entity{{ID: "member-1"}},
+				}}, nil)
+			},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, canView)
+			},
+		},
+		{
+			name:   "given team offer when its team was renamed since then members still see it",
+			offer:  withVisibility(matchoffer.VisibilityTeam),
+			viewer: service.Viewer{AccountID: "member-1"},
+			on: func(t *testing.T, teamRepo *teammocks.Repository) {
+				teamRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q team.DomainQuery) bool { return q.MemberAccountID == "member-1" }),
+				).Return([]team.Entity{{
+					ID:             "team-1",
+					Name:           "Los Tigres FC",
+					Sport:          common.Football,
+					OwnerAccountID: "owner-1",
+					Members:        []player.Entity{{ID: "member-1"}},
+				}}, nil)
+			},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, canView)
+			},
+		},
+		{
+			name:   "given team offer when the viewer plays in a namesake team of another owner then it is hidden",
+			offer:  withVisibility(matchoffer.VisibilityTeam),
+			viewer: service.Viewer{AccountID: "member-2"},
+			on: func(t *testing.T, teamRepo *teammocks.Repository) {
+				teamRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q team.DomainQuery) bool { return q.MemberAccountID == "member-2" }),
+				).Return([]team.Entity{{
+					ID:             "team-2",
+					Name:           "Los Leones FC",
+					Sport:          common.Football,
+					OwnerAccountID: "owner-2",
+					Members:        []player.Entity{{ID: "member-2"}},
+				}}, nil)
+			},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, canView)
+			},
+		},
+		{
+			name:   "given team offer when team lookup fails then returns error",
+			offer:  withVisibility(matchoffer.VisibilityTeam),
+			viewer: service.Viewer{AccountID: "member-1"},
+			on: func(t *testing.T, teamRepo *teammocks.Repository) {
+				teamRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q team.DomainQuery) bool { return q.MemberAccountID == "member-1" }),
+				).Return(nil, errors.New("db connection error"))
+			},
+			then: func(t *testing.T, canView bool, err error) {
+				assert.Error(t, err)
+				assert.False(t, canView)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			teamRepo := teammocks.NewRepository(t)
+			policy := service.NewVisibilityPolicy(teamRepo, signer)
+			if tc.on != nil {
+				tc.on(t, teamRepo)
+			}
+
+			canView, err := policy.CanView(ctx, tc.offer, tc.viewer)
+
+			tc.then(t, canView, err)
+		})
+	}
+}
+
+func TestShareLinkSigner_WithoutSecret(t *testing.T) {
+	linkOffer := matchoffer.Entity{ID: "offer-1", Visibility: matchoffer.VisibilityLink}
+	token, _, _ := service.NewShareLinkSigner("share-secret", time.Hour).Sign(linkOffer)
+	disabled := service.NewShareLinkSigner("", time.Hour)
+
+	_, _, err := disabled.Sign(linkOffer)
+
+	assert.ErrorIs(t, err, service.ErrShareLinksDisabled)
+	assert.False(t, disabled.Verify(linkOffer, token))
+}
@@ -2,12 +2,18 @@ package usecases
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"sportlink/api/application/errors"
+	appevents "sportlink/api/application/events"
+	matchevent "sportlink/api/application/match/events"
+	"sportlink/api/domain/common"
 	"sportlink/api/domain/match"
 	"sportlink/api/domain/matchoffer"
 	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
 	"sportlink/pkg/log"
+	"time"
 )
 
 type ConfirmMatchOfferInput struct {
@@ -33,6 +39,9 @@ func NewConfirmMatchOfferUC(
 	}
 }
 
+// Invoke confirms the offer and creates its match. It is idempotent per offer: invoking it
+// again, or concurrently from the capacity consumer and the REST endpoint, returns the
+// match created by whichever call won.
 func (uc *ConfirmMatchOfferUC) Invoke(ctx context.Context, input ConfirmMatchOfferInput) (*match.Entity, error) {
 	offer, err := uc.getMatchOffer(ctx, input.MatchOfferID)
 	if err != nil {
@@ -44,60 +53,136 @@ func (uc *ConfirmMatchOfferUC) Invoke(ctx context.Context, input ConfirmMatchOff
 		return nil, errors.Unauthorized("owner account ID does not match")
 	}
 
+	if offer.IsConfirm() {
+		return uc.findConfirmedMatch(ctx, *offer)
+	}
+
 	if !offer.IsPending() {
 		err = errors.UseCaseExecutionFailed("match offer is not pending")
 		log.GetLogger(ctx).Error(fmt.Sprintf("match offer %s is not pending, status: %s", input.MatchOfferID, offer.Status), err)
 		return nil, err
 	}
 
+	confirmed, err := offer.Confirm(common.ActorOwner)
+	if err != nil {
+		return nil, errors.InvalidTransition(err.Error())
+	}
+
 	acceptedRequests, err := uc.getAcceptedRequests(ctx, input.MatchOfferID)
 	if err != nil {
 		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get accepted requests for offer %s", input.MatchOfferID), err)
 		return nil, err
 	}
 
+	waitlisted, err := uc.pendingRequestsToWaitlist(ctx, input.MatchOfferID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get pending requests for offer %s", input.MatchOfferID), err)
+		return nil, err
+	}
+
 	newMatch := match.NewMatch(
 		offer.ID,
 		buildParticipants(input.OwnerAccountID, acceptedRequests),
 		offer.Sport,
 		offer.Day,
-	)
+	).SplitCost(offer.Cost.Amount, offer.Cost.Currency)
 
-	if err = uc.matchRepository.Save(ctx, newMatch); err != nil {
-		log.GetLogger(ctx).Error(fmt.Sprintf("failed to save match for offer %s", input.MatchOfferID), err)
+	// only the call that creates the match announces it, in the same write
+	message, err := appevents.NewMessage(ctx, buildConfirmedEvent(newMatch, *offer), time.Now())
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to build confirmed event for offer %s", input.MatchOfferID), err)
 		return nil, err
 	}
 
-	if err = uc.matchOfferRepository.Save(ctx, offer.Confirm()); err != nil {
+	err = uc.matchRepository.SaveConfirmation(ctx, newMatch, confirmed, waitlisted, []outbox.Message{message})
+	if stderrors.Is(err, common.ErrVersionConflict) {
+		return uc.resolveConflict(ctx, input.MatchOfferID)
+	}
+	if stderrors.Is(err, match.ErrWaitlistIncomplete) {
+		// the match exists; the requests left pending are read again and waitlisted
+		log.GetLogger(ctx).Error(fmt.Sprintf("match offer %s confirmed with requests left pending", input.MatchOfferID), err)
+		uc.waitlistLeftovers(ctx, input.MatchOfferID)
+		err = nil
+	}
+	if err != nil {
 		log.GetLogger(ctx).Error(fmt.Sprintf("failed to confirm match offer %s", input.MatchOfferID), err)
 		return nil, err
 	}
 
-	if err = uc.rejectPendingRequests(ctx, input.MatchOfferID); err != nil {
-		log.GetLogger(ctx).Error(fmt.Sprintf("failed to reject pending requests for offer %s", input.MatchOfferID), err)
+	return &newMatch, nil
+}
+
+// resolveConflict handles a confirmation that lost against another write. When that write
+// was another confirmation its match is returned; otherwise the caller has to retry.
+func (uc *ConfirmMatchOfferUC) resolveConflict(ctx context.Context, matchOfferID string) (*match.Entity, error) {
+	offer, err := uc.getMatchOffer(ctx, matchOfferID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match offer %s", matchOfferID), err)
+		return nil, err
+	}
+	if !offer.IsConfirm() {
+		err = errors.ConcurrentModification(fmt.Sprintf("match offer %s was modified while confirming it", matchOfferID))
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to confirm match offer %s", matchOfferID), err)
+		return nil, err
+	}
+	return uc.findConfirmedMatch(ctx, *offer)
+}
+
+// findConfirmedMatch returns the match of an already confirmed offer, waitlisting any
+// request a previous confirmation left pending.
+func (uc *ConfirmMatchOfferUC) findConfirmedMatch(ctx context.Context, offer matchoffer.Entity) (*match.Entity, error) {
+	confirmed, err := uc.matchRepository.FindByID(ctx, offer.OwnerAccountID, match.IDForOffer(offer.ID))
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match of offer %s", offer.ID), err)
+		return nil, err
+	}
+	if confirmed == nil {
+		// offers confirmed before match IDs were derived from the offer cannot be looked up
+		err = errors.UseCaseExecutionFailed("match offer is not pending")
+		log.GetLogger(ctx).Error(fmt.Sprintf("match offer %s is not pending, status: %s", offer.ID, offer.Status), err)
+		return nil, err
 	}
 
-	return &newMatch, nil
+	uc.waitlistLeftovers(ctx, offer.ID)
+
+	return confirmed, nil
 }
 
-func (uc *ConfirmMatchOfferUC) rejectPendingRequests(ctx context.Context, matchOfferID string) error {
+// waitlistLeftovers waitlists the requests a confirmation left PENDING on the confirmed
+// offer. Each request is saved at the version just read, so one that changed meanwhile is
+// skipped rather than overwritten; any still pending are picked up by the next call.
+func (uc *ConfirmMatchOfferUC) waitlistLeftovers(ctx context.Context, matchOfferID string) {
+	leftovers, err := uc.pendingRequestsToWaitlist(ctx, matchOfferID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get pending requests for offer %s", matchOfferID), err)
+		return
+	}
+	for _, r := range leftovers {
+		if err = uc.matchRequestRepository.Save(ctx, r, nil); err != nil && !stderrors.Is(err, common.ErrVersionConflict) {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to waitlist match request %s of offer %s", r.ID, matchOfferID), err)
+		}
+	}
+}
+
+// pendingRequestsToWaitlist returns the pending requests of the offer moved to the waitlist.
+// They did not make it into the match and are promoted in arrival order when a participant
+// drops out.
+func (uc *ConfirmMatchOfferUC) pendingRequestsToWaitlist(ctx context.Context, matchOfferID string) ([]matchrequest.Entity, error) {
 	pending, err := uc.matchRequestRepository.Find(ctx, matchrequest.DomainQuery{
 		MatchOfferIDs: []string{matchOfferID},
 		Statuses:      []matchrequest.Status{matchrequest.StatusPending},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to find pending requests: %w", err)
-	}
-	if len(pending) == 0 {
-		return nil
+		return nil, fmt.Errorf("failed to find pending requests: %w", err)
 	}
 
-	rejected := make([]matchrequest.Entity, len(pending))
+	waitlisted := make([]matchrequest.Entity, len(pending))
 	for i, r := range pending {
-		rejected[i] = r.Reject()
+		if waitlisted[i], err = r.Waitlist(); err != nil {
+			return nil, err
+		}
 	}
-
-	return uc.matchRequestRepository.SaveAll(ctx, rejected)
+	return waitlisted, nil
 }
 
 func (uc *ConfirmMatchOfferUC) getMatchOffer(ctx context.Context, matchOfferID string) (*matchoffer.Entity, error) {
@@ -118,6 +203,17 @@ func (uc *ConfirmMatchOfferUC) getAcceptedRequests(ctx context.Context, matchOff
 	})
 }
 
+func buildConfirmedEvent(confirmed match.Entity, offer matchoffer.Entity) matchevent.MatchConfirmedEvent {
+	return matchevent.MatchConfirmedEvent{
+		MatchID:        confirmed.ID,
+		MatchOfferID:   offer.ID,
+		OwnerAccountID: offer.OwnerAccountID,
+		Participants:   confirmed.Participants,
+		Sport:          confirmed.Sport,
+		Day:            confirmed.Day,
+	}
+}
+
 func buildParticipants(ownerAccountID string, requests []matchrequest.Entity) []string {
 	participants := make([]string, 0, 1+len(requests))
 	participants = append(participants, ownerAccountID)
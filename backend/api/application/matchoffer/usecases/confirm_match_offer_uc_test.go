@@ -2,18 +2,23 @@ package usecases_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	apperrors "sportlink/api/application/errors"
+	matchevent "sportlink/api/application/match/events"
 	"sportlink/api/application/matchoffer/usecases"
 	"sportlink/api/domain/common"
 	domainmatch "sportlink/api/domain/match"
 	domainoffer "sportlink/api/domain/matchoffer"
 	domainreq "sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
 	matchmocks "sportlink/mocks/api/domain/match"
 	offermocks "sportlink/mocks/api/domain/matchoffer"
 	reqmocks "sportlink/mocks/api/domain/matchrequest"
@@ -22,8 +27,8 @@ import (
 func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 	ctx := context.Background()
 
-	fixedDay := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
-	fixedNow := time.Date(2026, 4, 11, 12, 0, 0, 0, time.UTC)
+	fixedDay := time.Date(2030, 5, 10, 0, 0, 0, 0, time.UTC)
+	fixedNow := time.Date(2030, 4, 11, 12, 0, 0, 0, time.UTC)
 
 	pendingOffer := domainoffer.Entity{
 		ID:       "offer-1",
@@ -47,6 +52,37 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 		CreatedAt:          fixedNow,
 	}
 
+	pendingRequest := domainreq.Entity{
+		ID:                 "AccountId#requester-3#MatchOfferId#offer-1",
+		MatchOfferID:       "offer-1",
+		OwnerAccountID:     "owner-1",
+		RequesterAccountID: "requester-3",
+		Status:             domainreq.StatusPending,
+		CreatedAt:          fixedNow,
+	}
+
+	confirmedOffer := pendingOffer
+	confirmedOffer.Status = domainoffer.StatusConfirmed
+
+	existingMatch := domainmatch.Entity{
+		ID:           "offer-1",
+		MatchOfferID: "offer-1",
+		Participants: []string{"owner-1", "requester-1"},
+		Sport:        common.Paddle,
+		Day:          fixedDay,
+		Status:       domainmatch.StatusAccepted,
+	}
+
+	isConfirmedMessage := mock.MatchedBy(func(messages []outbox.Message) bool {
+		if len(messages) != 1 || messages[0].Type != matchevent.MatchConfirmedEventType {
+			return false
+		}
+		var e matchevent.MatchConfirmedEvent
+		return json.Unmarshal(messages[0].Payload, &e) == nil &&
+			e.MatchID == "offer-1" && e.MatchOfferID == "offer-1" && e.OwnerAccountID == "owner-1" &&
+			len(e.Participants) > 0 && e.Participants[0] == "owner-1"
+	})
+
 	validInput := usecases.ConfirmMatchOfferInput{
 		MatchOfferID:   "offer-1",
 		OwnerAccountID: "owner-1",
@@ -59,7 +95,7 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 		then  func(t *testing.T, result *domainmatch.Entity, err error)
 	}{
 		{
-			name:  "given pending offer with accepted requests when confirming then creates match and confirms offer",
+			name:  "given pending offer with accepted requests when confirming then saves match, confirmed offer and waitlist in one write",
 			input: validInput,
 			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
 				offerRepo.On("Find",
@@ -68,7 +104,6 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
-
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -76,44 +111,87 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
 					}),
 				).Return([]domainreq.Entity{acceptedRequest}, nil)
-
-				matchRepo.On("Save",
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
+					}),
+				).Return([]domainreq.Entity{}, nil)
+				matchRepo.On("SaveConfirmation",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(m domainmatch.Entity) bool {
-						return len(m.Participants) == 2 &&
+						return m.ID == "offer-1" &&
+							len(m.Participants) == 2 &&
 							m.Participants[0] == "owner-1" &&
 							m.Participants[1] == "requester-1" &&
 							m.Sport == common.Paddle &&
 							m.Day.Equal(fixedDay) &&
 							m.Status == domainmatch.StatusAccepted
 					}),
-				).Return(nil)
-
-				offerRepo.On("Save",
-					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(o domainoffer.Entity) bool {
 						return o.ID == "offer-1" && o.Status == domainoffer.StatusConfirmed
 					}),
-				).Return(nil)
-
-				reqRepo.On("Find",
-					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
-					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
-						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
-							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
+					mock.MatchedBy(func(waitlisted []domainreq.Entity) bool {
+						return len(waitlisted) == 0
 					}),
-				).Return([]domainreq.Entity{}, nil)
+					isConfirmedMessage,
+				).Return(nil)
 			},
 			then: func(t *testing.T, result *domainmatch.Entity, err error) {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
+				assert.Equal(t, "offer-1", result.ID)
 				assert.Equal(t, []string{"owner-1", "requester-1"}, result.Participants)
 				assert.Equal(t, common.Paddle, result.Sport)
 				assert.Equal(t, domainmatch.StatusAccepted, result.Status)
+				assert.Nil(t, result.Payment)
 			},
 		},
 		{
-			name:  "given pending offer with multiple accepted requests when confirming then creates match with all participants",
+			name:  "given confirmation saved without every waitlisted request when confirming then waitlists the leftovers again and announces the match",
+			input: validInput,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
+					}),
+				).Return([]domainreq.Entity{acceptedRequest}, nil)
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
+					}),
+				).Return([]domainreq.Entity{pendingRequest}, nil).Twice()
+				matchRepo.On("SaveConfirmation",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.Anything,
+					mock.Anything,
+					mock.Anything,
+					isConfirmedMessage,
+				).Return(fmt.Errorf("failed to waitlist remaining requests of match offer offer-1: %w", domainmatch.ErrWaitlistIncomplete))
+				reqRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(r domainreq.Entity) bool {
+						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusWaitlisted
+					}),
+					mock.Anything,
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "offer-1", result.ID)
+			},
+		},
+		{
+			name:  "given pending offer with multiple accepted requests when confirming then creates match with all participants and waitlists the rest",
 			input: validInput,
 			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
 				offerRepo.On("Find",
@@ -122,7 +200,6 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
-
 				twoRequests := []domainreq.Entity{
 					acceptedRequest,
 					{
@@ -138,8 +215,14 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
 					}),
 				).Return(twoRequests, nil)
-
-				matchRepo.On("Save",
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
+					}),
+				).Return([]domainreq.Entity{pendingRequest}, nil)
+				matchRepo.On("SaveConfirmation",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(m domainmatch.Entity) bool {
 						return len(m.Participants) == 3 &&
@@ -147,39 +230,68 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 							m.Participants[1] == "requester-1" &&
 							m.Participants[2] == "requester-2"
 					}),
-				).Return(nil)
-
-				offerRepo.On("Save",
-					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(o domainoffer.Entity) bool {
 						return o.ID == "offer-1" && o.Status == domainoffer.StatusConfirmed
 					}),
+					mock.MatchedBy(func(waitlisted []domainreq.Entity) bool {
+						return len(waitlisted) == 1 &&
+							waitlisted[0].ID == pendingRequest.ID &&
+							waitlisted[0].Status == domainreq.StatusWaitlisted
+					}),
+					isConfirmedMessage,
 				).Return(nil)
-
-				pendingRequest := domainreq.Entity{
-					ID: "AccountId#requester-3#MatchOfferId#offer-1", MatchOfferID: "offer-1",
-					OwnerAccountID: "owner-1", RequesterAccountID: "requester-3",
-					Status: domainreq.StatusPending, CreatedAt: fixedNow,
-				}
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Equal(t, []string{"owner-1", "requester-1", "requester-2"}, result.Participants)
+			},
+		},
+		{
+			name:  "given offer with a court cost when confirming then splits the cost among participants",
+			input: validInput,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				paidOffer := pendingOffer
+				paidOffer.Cost = domainoffer.Cost{Amount: 1001, Currency: "ARS"}
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{paidOffer}, Total: 1}, nil)
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
+					}),
+				).Return([]domainreq.Entity{acceptedRequest}, nil)
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
 						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
 							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
 					}),
-				).Return([]domainreq.Entity{pendingRequest}, nil)
-
-				reqRepo.On("SaveAll",
+				).Return([]domainreq.Entity{}, nil)
+				matchRepo.On("SaveConfirmation",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
-					mock.MatchedBy(func(entities []domainreq.Entity) bool {
-						return len(entities) == 1 && entities[0].Status == domainreq.StatusRejected
+					mock.MatchedBy(func(m domainmatch.Entity) bool {
+						return m.Payment != nil && m.Payment.Total == 1001 && len(m.Payment.Shares) == 2
 					}),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1"
+					}),
+					mock.MatchedBy(func(waitlisted []domainreq.Entity) bool {
+						return true
+					}),
+					isConfirmedMessage,
 				).Return(nil)
 			},
 			then: func(t *testing.T, result *domainmatch.Entity, err error) {
 				assert.NoError(t, err)
-				assert.NotNil(t, result)
-				assert.Equal(t, []string{"owner-1", "requester-1", "requester-2"}, result.Participants)
+				assert.Equal(t, "ARS", result.Payment.Currency)
+				assert.Equal(t, domainmatch.Share{AccountID: "owner-1", Amount: 501, Status: domainmatch.ShareStatusPending}, result.Payment.Shares[0])
+				assert.Equal(t, domainmatch.Share{AccountID: "requester-1", Amount: 500, Status: domainmatch.ShareStatusPending}, result.Payment.Shares[1])
 			},
 		},
 		{
@@ -237,17 +349,83 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 			},
 		},
 		{
-			name:  "given already confirmed offer when confirming then returns error",
+			name:  "given already confirmed offer when confirming again then returns the existing match",
 			input: validInput,
 			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
-				confirmedOffer := pendingOffer
-				confirmedOffer.Status = domainoffer.StatusConfirmed
 				offerRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{confirmedOffer}, Total: 1}, nil)
+				matchRepo.On("FindByID",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					"owner-1",
+					"offer-1",
+				).Return(&existingMatch, nil)
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
+					}),
+				).Return([]domainreq.Entity{}, nil)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, &existingMatch, result)
+			},
+		},
+		{
+			name:  "given already confirmed offer with requests left pending when confirming again then waitlists them",
+			input: validInput,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{confirmedOffer}, Total: 1}, nil)
+				matchRepo.On("FindByID",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					"owner-1",
+					"offer-1",
+				).Return(&existingMatch, nil)
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
+					}),
+				).Return([]domainreq.Entity{pendingRequest}, nil)
+				reqRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(r domainreq.Entity) bool {
+						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusWaitlisted
+					}),
+					mock.Anything,
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainmatch.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, &existingMatch, result)
+			},
+		},
+		{
+			name:  "given offer confirmed without a match for it when confirming then returns error",
+			input: validInput,
+			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{confirmedOffer}, Total: 1}, nil)
+				matchRepo.On("FindByID",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					"owner-1",
+					"offer-1",
+				).Return(nil, nil)
 			},
 			then: func(t *testing.T, result *domainmatch.Entity, err error) {
 				assert.Error(t, err)
@@ -265,7 +443,6 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
-
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -273,28 +450,26 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
 					}),
 				).Return([]domainreq.Entity{}, nil)
-
-				matchRepo.On("Save",
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
+					}),
+				).Return([]domainreq.Entity{}, nil)
+				matchRepo.On("SaveConfirmation",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(m domainmatch.Entity) bool {
 						return len(m.Participants) == 1 && m.Participants[0] == "owner-1"
 					}),
-				).Return(nil)
-
-				offerRepo.On("Save",
-					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(o domainoffer.Entity) bool {
 						return o.ID == "offer-1" && o.Status == domainoffer.StatusConfirmed
 					}),
-				).Return(nil)
-
-				reqRepo.On("Find",
-					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
-					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
-						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
-							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
+					mock.MatchedBy(func(waitlisted []domainreq.Entity) bool {
+						return len(waitlisted) == 0
 					}),
-				).Return([]domainreq.Entity{}, nil)
+					isConfirmedMessage,
+				).Return(nil)
 			},
 			then: func(t *testing.T, result *domainmatch.Entity, err error) {
 				assert.NoError(t, err)
@@ -312,11 +487,11 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
-
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
-						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1"
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
 					}),
 				).Return(nil, errors.New("db connection error"))
 			},
@@ -327,7 +502,7 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 			},
 		},
 		{
-			name:  "given match repository fails when saving match then returns error",
+			name:  "given repository error when finding pending requests then returns error without confirming",
 			input: validInput,
 			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
 				offerRepo.On("Find",
@@ -336,29 +511,29 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
-
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
-						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1"
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
 					}),
 				).Return([]domainreq.Entity{acceptedRequest}, nil)
-
-				matchRepo.On("Save",
+				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
-					mock.MatchedBy(func(m domainmatch.Entity) bool {
-						return len(m.Participants) == 2
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
 					}),
-				).Return(errors.New("match save failed"))
+				).Return(nil, errors.New("db connection error"))
 			},
 			then: func(t *testing.T, result *domainmatch.Entity, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, result)
-				assert.Contains(t, err.Error(), "match save failed")
+				assert.Contains(t, err.Error(), "db connection error")
 			},
 		},
 		{
-			name:  "given repository error when finding pending requests then confirms match and logs error",
+			name:  "given confirmation write fails when confirming then returns error",
 			input: validInput,
 			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
 				offerRepo.On("Find",
@@ -367,7 +542,6 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
-
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -375,36 +549,35 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
 					}),
 				).Return([]domainreq.Entity{acceptedRequest}, nil)
-
-				matchRepo.On("Save",
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
+					}),
+				).Return([]domainreq.Entity{}, nil)
+				matchRepo.On("SaveConfirmation",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(m domainmatch.Entity) bool {
 						return len(m.Participants) == 2
 					}),
-				).Return(nil)
-
-				offerRepo.On("Save",
-					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(o domainoffer.Entity) bool {
 						return o.ID == "offer-1" && o.Status == domainoffer.StatusConfirmed
 					}),
-				).Return(nil)
-
-				reqRepo.On("Find",
-					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
-					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
-						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
-							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
+					mock.MatchedBy(func(waitlisted []domainreq.Entity) bool {
+						return true
 					}),
-				).Return(nil, errors.New("db connection error"))
+					isConfirmedMessage,
+				).Return(errors.New("transaction failed"))
 			},
 			then: func(t *testing.T, result *domainmatch.Entity, err error) {
-				assert.NoError(t, err)
-				assert.NotNil(t, result)
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				assert.Contains(t, err.Error(), "transaction failed")
 			},
 		},
 		{
-			name:  "given save all fails when rejecting pending requests then confirms match and logs error",
+			name:  "given offer confirmed concurrently when confirming then returns the match of the winning confirmation",
 			input: validInput,
 			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
 				offerRepo.On("Find",
@@ -412,8 +585,7 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
-				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
-
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil).Once()
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -421,48 +593,45 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
 					}),
 				).Return([]domainreq.Entity{acceptedRequest}, nil)
-
-				matchRepo.On("Save",
-					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
-					mock.MatchedBy(func(m domainmatch.Entity) bool {
-						return len(m.Participants) == 2
-					}),
-				).Return(nil)
-
-				offerRepo.On("Save",
-					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
-					mock.MatchedBy(func(o domainoffer.Entity) bool {
-						return o.ID == "offer-1" && o.Status == domainoffer.StatusConfirmed
-					}),
-				).Return(nil)
-
-				pendingRequest := domainreq.Entity{
-					ID: "AccountId#requester-2#MatchOfferId#offer-1", MatchOfferID: "offer-1",
-					OwnerAccountID: "owner-1", RequesterAccountID: "requester-2",
-					Status: domainreq.StatusPending, CreatedAt: fixedNow,
-				}
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
 						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
 							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
 					}),
-				).Return([]domainreq.Entity{pendingRequest}, nil)
-
-				reqRepo.On("SaveAll",
+				).Return([]domainreq.Entity{}, nil)
+				matchRepo.On("SaveConfirmation",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m domainmatch.Entity) bool {
+						return true
+					}),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return true
+					}),
+					mock.MatchedBy(func(waitlisted []domainreq.Entity) bool {
+						return true
+					}),
+					isConfirmedMessage,
+				).Return(fmt.Errorf("confirmation of match offer offer-1: %w", common.ErrVersionConflict))
+				offerRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
-					mock.MatchedBy(func(entities []domainreq.Entity) bool {
-						return len(entities) == 1 && entities[0].Status == domainreq.StatusRejected
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
-				).Return(errors.New("batch write failed"))
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{confirmedOffer}, Total: 1}, nil).Once()
+				matchRepo.On("FindByID",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					"owner-1",
+					"offer-1",
+				).Return(&existingMatch, nil)
 			},
 			then: func(t *testing.T, result *domainmatch.Entity, err error) {
 				assert.NoError(t, err)
-				assert.NotNil(t, result)
+				assert.Equal(t, &existingMatch, result)
 			},
 		},
 		{
-			name:  "given offer repository fails when saving confirmed offer then returns error",
+			name:  "given offer modified concurrently without being confirmed when confirming then returns concurrent modification",
 			input: validInput,
 			on: func(t *testing.T, matchRepo *matchmocks.Repository, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
 				offerRepo.On("Find",
@@ -471,32 +640,39 @@ func TestConfirmMatchOfferUC_Invoke(t *testing.T) {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
-
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
-						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1"
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
 					}),
 				).Return([]domainreq.Entity{acceptedRequest}, nil)
-
-				matchRepo.On("Save",
+				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
-					mock.MatchedBy(func(m domainmatch.Entity) bool {
-						return len(m.Participants) == 2
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusPending
 					}),
-				).Return(nil)
-
-				offerRepo.On("Save",
+				).Return([]domainreq.Entity{}, nil)
+				matchRepo.On("SaveConfirmation",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m domainmatch.Entity) bool {
+						return true
+					}),
 					mock.MatchedBy(func(o domainoffer.Entity) bool {
-						return o.ID == "offer-1" && o.Status == domainoffer.StatusConfirmed
+						return true
 					}),
-				).Return(errors.New("offer save failed"))
+					mock.MatchedBy(func(waitlisted []domainreq.Entity) bool {
+						return true
+					}),
+					isConfirmedMessage,
+				).Return(fmt.Errorf("confirmation of match offer offer-1: %w", common.ErrVersionConflict))
 			},
 			then: func(t *testing.T, result *domainmatch.Entity, err error) {
-				assert.Error(t, err)
 				assert.Nil(t, result)
-				assert.Contains(t, err.Error(), "offer save failed")
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.ConcurrentModificationErrorCode, appErr.Code)
 			},
 		},
 	}
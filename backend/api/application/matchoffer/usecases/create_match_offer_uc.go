@@ -3,19 +3,27 @@ package usecases
 import (
 	"context"
 	"fmt"
+	appevents "sportlink/api/application/events"
+	matchofferevent "sportlink/api/application/matchoffer/events"
+	"sportlink/api/domain/common"
 	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/domain/team"
 	"time"
 )
 
 type CreateMatchOfferUC struct {
 	matchOfferRepository matchoffer.Repository
+	teamRepository       team.Repository
 }
 
 func NewCreateMatchOfferUC(
 	matchOfferRepository matchoffer.Repository,
+	teamRepository team.Repository,
 ) *CreateMatchOfferUC {
 	return &CreateMatchOfferUC{
 		matchOfferRepository: matchOfferRepository,
+		teamRepository:       teamRepository,
 	}
 }
 
@@ -24,13 +32,71 @@ func (uc *CreateMatchOfferUC) Invoke(ctx context.Context, input matchoffer.Entit
 		return nil, err
 	}
 
-	if err := uc.matchOfferRepository.Save(ctx, input); err != nil {
+	input, err := uc.resolveTeam(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := appevents.NewMessage(ctx, buildCreatedEvent(input), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error while building created event for match offer: %w", err)
+	}
+	if err := uc.matchOfferRepository.Save(ctx, input, []outbox.Message{message}); err != nil {
 		return nil, fmt.Errorf("error while inserting match offer in database: %w", err)
 	}
 
 	return &input, nil
 }
 
+// resolveTeam points the offer to the team it is published for by ID. An offer naming a
+// team ID takes the current name of that team; one naming only a team name is linked to
+// the team of the sport with that name, and keeps the name as free text when there is
+// none. TEAM offers must end up pointing to a team, since its members are the audience.
+func (uc *CreateMatchOfferUC) resolveTeam(ctx context.Context, offer matchoffer.Entity) (matchoffer.Entity, error) {
+	if offer.TeamID == "" && offer.TeamName == "" {
+		if offer.Visibility == matchoffer.VisibilityTeam {
+			return matchoffer.Entity{}, fmt.Errorf("team offers must name the team they are published for")
+		}
+		return offer, nil
+	}
+
+	query := team.DomainQuery{Name: offer.TeamName, Sports: []common.Sport{offer.Sport}}
+	if offer.TeamID != "" {
+		query = team.DomainQuery{Ids: []string{offer.TeamID}, Sports: []common.Sport{offer.Sport}}
+	}
+	teams, err := uc.teamRepository.Find(ctx, query)
+	if err != nil {
+		return matchoffer.Entity{}, fmt.Errorf("error while finding team of match offer: %w", err)
+	}
+	for _, t := range teams {
+		if offer.TeamID != "" || t.Name == offer.TeamName {
+			offer.TeamID = t.ID
+			offer.TeamName = t.Name
+			return offer, nil
+		}
+	}
+
+	if offer.TeamID != "" {
+		return matchoffer.Entity{}, fmt.Errorf("team %s not found for sport %s", offer.TeamID, offer.Sport)
+	}
+	if offer.Visibility == matchoffer.VisibilityTeam {
+		return matchoffer.Entity{}, fmt.Errorf("team %s not found for sport %s", offer.TeamName, offer.Sport)
+	}
+	return offer, nil
+}
+
+func buildCreatedEvent(offer matchoffer.Entity) matchofferevent.MatchOfferCreatedEvent {
+	return matchofferevent.MatchOfferCreatedEvent{
+		MatchOfferID:   offer.ID,
+		OwnerAccountID: offer.OwnerAccountID,
+		Sport:          offer.Sport,
+		Day:            offer.Day,
+		StartTime:      offer.TimeSlot.StartTime,
+		EndTime:        offer.TimeSlot.EndTime,
+		Locality:       offer.Location.Locality,
+	}
+}
+
 func (uc *CreateMatchOfferUC) validateOffer(input matchoffer.Entity) error {
 	if input.Sport == "" {
 		return fmt.Errorf("sport cannot be empty")
@@ -3,10 +3,14 @@ package usecases_test
 import (
 	"context"
 	"fmt"
+	matchofferevent "sportlink/api/application/matchoffer/events"
 	"sportlink/api/application/matchoffer/usecases"
 	"sportlink/api/domain/common"
 	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/domain/team"
 	mmocks "sportlink/mocks/api/domain/matchoffer"
+	teammocks "sportlink/mocks/api/domain/team"
 	"testing"
 	"time"
 
@@ -31,10 +35,17 @@ func TestCreateMatchOfferUC_Invoke(t *testing.T) {
 	categoryRange := matchoffer.NewSpecificCategories([]common.Category{5, 6, 7})
 	greaterThanRange := matchoffer.NewGreaterThanCategory(5)
 
+	isCreated := mock.MatchedBy(func(messages []outbox.Message) bool {
+		var created matchofferevent.MatchOfferCreatedEvent
+		return len(messages) == 1 && messages[0].Type == matchofferevent.MatchOfferCreatedEventType &&
+			messages[0].Decode(&created) == nil && created.Sport == common.Paddle && created.Locality == "CABA" &&
+			created.StartTime.Equal(startTime)
+	})
+
 	tests := []struct {
 		name  string
 		input matchoffer.Entity
-		on    func(t *testing.T, repository *mmocks.Repository)
+		on    func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository)
 		then  func(t *testing.T, result *matchoffer.Entity, err error)
 	}{
 		{
@@ -48,12 +59,13 @@ func TestCreateMatchOfferUC_Invoke(t *testing.T) {
 				Status:             matchoffer.StatusPending,
 				CreatedAt:          time.Now().In(tz),
 			},
-			on: func(t *testing.T, repository *mmocks.Repository) {
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
 				repository.On("Save",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(entity matchoffer.Entity) bool {
 						return entity.Sport == common.Paddle && entity.Status == matchoffer.StatusPending
 					}),
+					isCreated,
 				).Return(nil)
 			},
 			then: func(t *testing.T, result *matchoffer.Entity, err error) {
@@ -74,12 +86,17 @@ func TestCreateMatchOfferUC_Invoke(t *testing.T) {
 				Status:             matchoffer.StatusPending,
 				CreatedAt:          time.Now().In(tz),
 			},
-			on: func(t *testing.T, repository *mmocks.Repository) {
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
+				teams.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					team.DomainQuery{Name: "Thunder Strikers", Sports: []common.Sport{common.Paddle}},
+				).Return([]team.Entity{}, nil)
 				repository.On("Save",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(entity matchoffer.Entity) bool {
 						return entity.TeamName == "Thunder Strikers"
 					}),
+					isCreated,
 				).Return(nil)
 			},
 			then: func(t *testing.T, result *matchoffer.Entity, err error) {
@@ -88,6 +105,113 @@ func TestCreateMatchOfferUC_Invoke(t *testing.T) {
 				assert.Equal(t, "Thunder Strikers", result.TeamName)
 			},
 		},
+		{
+			name: "given offer naming an existing team when saving then points to the team by ID",
+			input: matchoffer.Entity{
+				TeamName:           "Thunder Strikers",
+				Sport:              common.Paddle,
+				Day:                tomorrow,
+				TimeSlot:           timeSlot,
+				Location:           location,
+				AdmittedCategories: categoryRange,
+				Status:             matchoffer.StatusPending,
+				CreatedAt:          time.Now().In(tz),
+			},
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
+				teams.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					team.DomainQuery{Name: "Thunder Strikers", Sports: []common.Sport{common.Paddle}},
+				).Return([]team.Entity{
+					{ID: "team-2", Name: "Thunder Strikers B", Sport: common.Paddle},
+					{ID: "team-1", Name: "Thunder Strikers", Sport: common.Paddle},
+				}, nil)
+				repository.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(entity matchoffer.Entity) bool {
+						return entity.TeamID == "team-1" && entity.TeamName == "Thunder Strikers"
+					}),
+					isCreated,
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *matchoffer.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "team-1", result.TeamID)
+			},
+		},
+		{
+			name: "given offer with team ID when saving then takes the current name of the team",
+			input: matchoffer.Entity{
+				TeamID:             "team-1",
+				TeamName:           "Old Name",
+				Sport:              common.Paddle,
+				Day:                tomorrow,
+				TimeSlot:           timeSlot,
+				Location:           location,
+				AdmittedCategories: categoryRange,
+				Status:             matchoffer.StatusPending,
+				Visibility:         matchoffer.VisibilityTeam,
+				CreatedAt:          time.Now().In(tz),
+			},
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
+				teams.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					team.DomainQuery{Ids: []string{"team-1"}, Sports: []common.Sport{common.Paddle}},
+				).Return([]team.Entity{{ID: "team-1", Name: "Thunder Strikers", Sport: common.Paddle}}, nil)
+				repository.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(entity matchoffer.Entity) bool {
+						return entity.TeamID == "team-1" && entity.TeamName == "Thunder Strikers"
+					}),
+					isCreated,
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *matchoffer.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "Thunder Strikers", result.TeamName)
+			},
+		},
+		{
+			name: "given offer with unknown team ID when creating then returns error",
+			input: matchoffer.Entity{
+				TeamID:             "team-9",
+				Sport:              common.Paddle,
+				Day:                tomorrow,
+				TimeSlot:           timeSlot,
+				Location:           location,
+				AdmittedCategories: categoryRange,
+				Status:             matchoffer.StatusPending,
+				CreatedAt:          time.Now().In(tz),
+			},
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
+				teams.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					team.DomainQuery{Ids: []string{"team-9"}, Sports: []common.Sport{common.Paddle}},
+				).Return([]team.Entity{}, nil)
+			},
+			then: func(t *testing.T, result *matchoffer.Entity, err error) {
+				assert.Nil(t, result)
+				assert.EqualError(t, err, "team team-9 not found for sport Paddle")
+			},
+		},
+		{
+			name: "given team offer without a team when creating then returns error",
+			input: matchoffer.Entity{
+				Sport:              common.Paddle,
+				Day:                tomorrow,
+				TimeSlot:           timeSlot,
+				Location:           location,
+				AdmittedCategories: categoryRange,
+				Status:             matchoffer.StatusPending,
+				Visibility:         matchoffer.VisibilityTeam,
+				CreatedAt:          time.Now().In(tz),
+			},
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
+			},
+			then: func(t *testing.T, result *matchoffer.Entity, err error) {
+				assert.Nil(t, result)
+				assert.EqualError(t, err, "team offers must name the team they are published for")
+			},
+		},
 		{
 			name: "given offer with GreaterThan category range when saving then saves successfully",
 			input: matchoffer.Entity{
@@ -99,12 +223,13 @@ func TestCreateMatchOfferUC_Invoke(t *testing.T) {
 				Status:             matchoffer.StatusPending,
 				CreatedAt:          time.Now().In(tz),
 			},
-			on: func(t *testing.T, repository *mmocks.Repository) {
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
 				repository.On("Save",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(entity matchoffer.Entity) bool {
 						return entity.AdmittedCategories.Type == matchoffer.RangeTypeGreaterThan
 					}),
+					isCreated,
 				).Return(nil)
 			},
 			then: func(t *testing.T, result *matchoffer.Entity, err error) {
@@ -124,10 +249,11 @@ func TestCreateMatchOfferUC_Invoke(t *testing.T) {
 				Status:             matchoffer.StatusPending,
 				CreatedAt:          time.Now().In(tz),
 			},
-			on: func(t *testing.T, repository *mmocks.Repository) {
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
 				repository.On("Save",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.Anything,
+					mock.Anything,
 				).Return(fmt.Errorf("database error"))
 			},
 			then: func(t *testing.T, result *matchoffer.Entity, err error) {
@@ -147,7 +273,8 @@ func TestCreateMatchOfferUC_Invoke(t *testing.T) {
 				Status:             matchoffer.StatusPending,
 				CreatedAt:          time.Now().In(tz),
 			},
-			on: func(t *testing.T, repository *mmocks.Repository) {},
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
+			},
 			then: func(t *testing.T, result *matchoffer.Entity, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, result)
@@ -165,7 +292,8 @@ func TestCreateMatchOfferUC_Invoke(t *testing.T) {
 				Status:             matchoffer.StatusPending,
 				CreatedAt:          time.Now().In(tz),
 			},
-			on: func(t *testing.T, repository *mmocks.Repository) {},
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
+			},
 			then: func(t *testing.T, result *matchoffer.Entity, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, result)
@@ -183,7 +311,8 @@ func TestCreateMatchOfferUC_Invoke(t *testing.T) {
 				Status:             matchoffer.StatusPending,
 				CreatedAt:          time.Now().In(tz),
 			},
-			on: func(t *testing.T, repository *mmocks.Repository) {},
+			on: func(t *testing.T, repository *mmocks.Repository, teams *teammocks.Repository) {
+			},
 			then: func(t *testing.T, result *matchoffer.Entity, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, result)
@@ -197,9 +326,10 @@ func TestCreateMatchOfferUC_Invoke(t *testing.T) {
 			t.Parallel()
 
 			repo := mmocks.NewRepository(t)
-			uc := usecases.NewCreateMatchOfferUC(repo)
+			teams := teammocks.NewRepository(t)
+			uc := usecases.NewCreateMatchOfferUC(repo, teams)
 
-			tt.on(t, repo)
+			tt.on(t, repo, teams)
 
 			result, err := uc.Invoke(ctx, tt.input)
 
@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application/errors"
+	"sportlink/api/application/matchoffer/service"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type CreateShareLinkInput struct {
+	MatchOfferID   string
+	OwnerAccountID string
+}
+
+type ShareLinkResult struct {
+	MatchOfferID string
+	Token        string
+	ExpiresAt    time.Time
+}
+
+// CreateShareLinkUC hands the owner of a LINK offer the token that lets anyone holding
+// it view the offer and send a match request, until it expires or is revoked.
+type CreateShareLinkUC struct {
+	matchOfferRepository matchoffer.Repository
+	signer               service.ShareLinkSigner
+}
+
+func NewCreateShareLinkUC(matchOfferRepository matchoffer.Repository, signer service.ShareLinkSigner) *CreateShareLinkUC {
+	return &CreateShareLinkUC{
+		matchOfferRepository: matchOfferRepository,
+		signer:               signer,
+	}
+}
+
+func (uc *CreateShareLinkUC) Invoke(ctx context.Context, input CreateShareLinkInput) (*ShareLinkResult, error) {
+	page, err := uc.matchOfferRepository.Find(ctx, matchoffer.DomainQuery{IDs: []string{input.MatchOfferID}})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match offer %s", input.MatchOfferID), err)
+		return nil, err
+	}
+	if len(page.Entities) == 0 {
+		return nil, errors.NotFound("match offer not found")
+	}
+
+	offer := page.Entities[0]
+	if offer.OwnerAccountID != input.OwnerAccountID {
+		return nil, errors.Unauthorized("owner account ID does not match")
+	}
+	if offer.Visibility != matchoffer.VisibilityLink {
+		return nil, errors.UseCaseExecutionFailed("only LINK match offers can be shared by link")
+	}
+
+	token, expiresAt, err := uc.signer.Sign(offer)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to sign share link of match offer %s", offer.ID), err)
+		return nil, errors.UseCaseExecutionFailed(err.Error())
+	}
+	return &ShareLinkResult{
+		MatchOfferID: offer.ID,
+		Token:        token,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
@@ -2,17 +2,92 @@ package usecases
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
+	"sportlink/api/application/errors"
+	appevents "sportlink/api/application/events"
+	matchofferevent "sportlink/api/application/matchoffer/events"
+	"sportlink/api/domain/common"
 	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+	"sportlink/pkg/slices"
+	"time"
 )
 
+type DeleteMatchOfferInput struct {
+	MatchOfferID   string
+	OwnerAccountID string
+}
+
+// DeleteMatchOfferUC withdraws an offer on behalf of its owner. The offer is archived as
+// CANCELLED rather than deleted, so owners and requesters keep an accurate history.
 type DeleteMatchOfferUC struct {
-	matchOfferRepository matchoffer.Repository
+	matchOfferRepository   matchoffer.Repository
+	matchRequestRepository matchrequest.Repository
+}
+
+func NewDeleteMatchOfferUC(
+	matchOfferRepository matchoffer.Repository,
+	matchRequestRepository matchrequest.Repository,
+) *DeleteMatchOfferUC {
+	return &DeleteMatchOfferUC{
+		matchOfferRepository:   matchOfferRepository,
+		matchRequestRepository: matchRequestRepository,
+	}
 }
 
-func NewDeleteMatchOfferUC(repo matchoffer.Repository) *DeleteMatchOfferUC {
-	return &DeleteMatchOfferUC{matchOfferRepository: repo}
+// Invoke cancels the offer and closes its open requests. Only pending offers can be
+// cancelled; confirmed ones live on as their match. The open requests are closed first so
+// a failure leaves the offer pending and the owner can retry. The cancelled event is
+// written with the offer, which is saved only while it is still at the version it was read at.
+func (uc *DeleteMatchOfferUC) Invoke(ctx context.Context, input DeleteMatchOfferInput) error {
+	page, err := uc.matchOfferRepository.Find(ctx, matchoffer.DomainQuery{IDs: []string{input.MatchOfferID}})
+	if err != nil {
+		return fmt.Errorf("error while finding match offer: %w", err)
+	}
+	if len(page.Entities) == 0 {
+		return errors.NotFound("match offer not found")
+	}
+
+	offer := page.Entities[0]
+	if offer.OwnerAccountID != input.OwnerAccountID {
+		return errors.Unauthorized("owner account ID does not match")
+	}
+	cancelled, err := offer.Cancel()
+	if err != nil {
+		return errors.InvalidTransition(err.Error())
+	}
+
+	requests, err := closeOpenRequests(ctx, uc.matchRequestRepository, offer.ID, matchrequest.Entity.Close)
+	if err != nil {
+		return fmt.Errorf("error while closing the requests of match offer %s: %w", offer.ID, err)
+	}
+
+	message, err := appevents.NewMessage(ctx, buildCancelledEvent(offer, requests), time.Now())
+	if err != nil {
+		return fmt.Errorf("error while building cancelled event for offer %s: %w", offer.ID, err)
+	}
+	if err = uc.matchOfferRepository.Save(ctx, cancelled, []outbox.Message{message}); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to cancel match offer %s", offer.ID), err)
+		if stderrors.Is(err, common.ErrVersionConflict) {
+			return errors.ConcurrentModification(err.Error())
+		}
+		return err
+	}
+	return nil
 }
 
-func (uc *DeleteMatchOfferUC) Invoke(ctx context.Context, offerID string) error {
-	return uc.matchOfferRepository.Delete(ctx, offerID)
+func buildCancelledEvent(offer matchoffer.Entity, requests []matchrequest.Entity) matchofferevent.MatchOfferCancelledEvent {
+	return matchofferevent.MatchOfferCancelledEvent{
+		MatchOfferID:   offer.ID,
+		OwnerAccountID: offer.OwnerAccountID,
+		ClosedRequestIDs: slices.Map(requests, func(r matchrequest.Entity) string {
+			return r.ID
+		}),
+		RequesterAccountIDs: slices.Map(requests, func(r matchrequest.Entity) string {
+			return r.RequesterAccountID
+		}),
+	}
 }
@@ -0,0 +1,175 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "sportlink/api/application/errors"
+	matchofferevent "sportlink/api/application/matchoffer/events"
+	"sportlink/api/application/matchoffer/usecases"
+	"sportlink/api/domain/common"
+	domainoffer "sportlink/api/domain/matchoffer"
+	domainreq "sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	offermocks "sportlink/mocks/api/domain/matchoffer"
+	reqmocks "sportlink/mocks/api/domain/matchrequest"
+)
+
+func TestDeleteMatchOfferUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+
+	fixedDay := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	pendingOffer := domainoffer.Entity{
+		ID:       "offer-1",
+		TeamName: "Los Leones FC",
+		Sport:    common.Paddle,
+		Day:      fixedDay,
+		TimeSlot: domainoffer.TimeSlot{
+			StartTime: fixedDay.Add(18 * time.Hour),
+			EndTime:   fixedDay.Add(20 * time.Hour),
+		},
+		Status:         domainoffer.StatusPending,
+		OwnerAccountID: "owner-1",
+		Version:        3,
+	}
+
+	confirmedOffer := pendingOffer
+	confirmedOffer.Status = domainoffer.StatusConfirmed
+
+	pendingRequest := domainreq.Entity{
+		ID:                 "AccountId#requester-1#MatchOfferId#offer-1",
+		MatchOfferID:       "offer-1",
+		OwnerAccountID:     "owner-1",
+		RequesterAccountID: "requester-1",
+		Status:             domainreq.StatusPending,
+		CreatedAt:          fixedDay,
+	}
+
+	matchesOfferQuery := mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+		return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+	})
+	matchesOpenRequestsQuery := mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+		return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+			assert.ObjectsAreEqual([]domainreq.Status{domainreq.StatusPending, domainreq.StatusWaitlisted}, q.Statuses)
+	})
+	isCancelledOffer := mock.MatchedBy(func(o domainoffer.Entity) bool {
+		return o.ID == "offer-1" && o.Status == domainoffer.StatusCancelled && o.Version == 3 &&
+			assert.ObjectsAreEqual([]common.StatusChange{{
+				From:  domainoffer.StatusPending.String(),
+				To:    domainoffer.StatusCancelled.String(),
+				Actor: common.ActorOwner,
+			}}, o.Changes)
+	})
+	isCancelledMessage := func(closedRequestIDs ...string) interface{} {
+		return mock.MatchedBy(func(messages []outbox.Message) bool {
+			var e matchofferevent.MatchOfferCancelledEvent
+			return len(messages) == 1 && messages[0].Type == matchofferevent.MatchOfferCancelledEventType &&
+				messages[0].Decode(&e) == nil && e.MatchOfferID == "offer-1" && e.OwnerAccountID == "owner-1" &&
+				assert.ObjectsAreEqual(closedRequestIDs, e.ClosedRequestIDs)
+		})
+	}
+
+	testCases := []struct {
+		name  string
+		input usecases.DeleteMatchOfferInput
+		on    func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository)
+		then  func(t *testing.T, err error)
+	}{
+		{
+			name:  "given a pending offer with an open request when its owner deletes it then the request is closed and the offer is saved cancelled with its history and event",
+			input: usecases.DeleteMatchOfferInput{MatchOfferID: "offer-1", OwnerAccountID: "owner-1"},
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find", ctx, matchesOfferQuery).
+					Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
+				reqRepo.On("Find", ctx, matchesOpenRequestsQuery).Return([]domainreq.Entity{pendingRequest}, nil)
+				reqRepo.On("Save", ctx,
+					mock.MatchedBy(func(r domainreq.Entity) bool {
+						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusExpired &&
+							len(r.Changes) == 1 && r.Changes[0].Reason == "the match offer was cancelled"
+					}),
+					mock.Anything,
+				).Return(nil)
+				offerRepo.On("Save", ctx, isCancelledOffer, isCancelledMessage(pendingRequest.ID)).Return(nil)
+			},
+			then: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:  "given another account when it deletes the offer then returns unauthorized and saves nothing",
+			input: usecases.DeleteMatchOfferInput{MatchOfferID: "offer-1", OwnerAccountID: "intruder"},
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find", ctx, matchesOfferQuery).
+					Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
+			},
+			then: func(t *testing.T, err error) {
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.UnauthorizedErrorCode, appErr.Code)
+			},
+		},
+		{
+			name:  "given a missing offer when deleting it then returns not found",
+			input: usecases.DeleteMatchOfferInput{MatchOfferID: "offer-1", OwnerAccountID: "owner-1"},
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find", ctx, matchesOfferQuery).Return(domainoffer.Page{}, nil)
+			},
+			then: func(t *testing.T, err error) {
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.NotFoundErrorCode, appErr.Code)
+			},
+		},
+		{
+			name:  "given a confirmed offer when deleting it then returns an invalid transition and saves nothing",
+			input: usecases.DeleteMatchOfferInput{MatchOfferID: "offer-1", OwnerAccountID: "owner-1"},
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find", ctx, matchesOfferQuery).
+					Return(domainoffer.Page{Entities: []domainoffer.Entity{confirmedOffer}, Total: 1}, nil)
+			},
+			then: func(t *testing.T, err error) {
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.InvalidTransitionErrorCode, appErr.Code)
+			},
+		},
+		{
+			name:  "given the offer changed since it was read when deleting it then returns a concurrent modification",
+			input: usecases.DeleteMatchOfferInput{MatchOfferID: "offer-1", OwnerAccountID: "owner-1"},
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find", ctx, matchesOfferQuery).
+					Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
+				reqRepo.On("Find", ctx, matchesOpenRequestsQuery).Return([]domainreq.Entity{}, nil)
+				offerRepo.On("Save", ctx, isCancelledOffer, isCancelledMessage()).
+					Return(fmt.Errorf("match offer offer-1: %w", common.ErrVersionConflict))
+			},
+			then: func(t *testing.T, err error) {
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.ConcurrentModificationErrorCode, appErr.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			offerRepo := offermocks.NewRepository(t)
+			reqRepo := reqmocks.NewRepository(t)
+			uc := usecases.NewDeleteMatchOfferUC(offerRepo, reqRepo)
+
+			tc.on(t, offerRepo, reqRepo)
+
+			err := uc.Invoke(ctx, tc.input)
+
+			tc.then(t, err)
+		})
+	}
+}
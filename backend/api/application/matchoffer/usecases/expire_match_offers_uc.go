@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	appevents "sportlink/api/application/events"
+	matchofferevent "sportlink/api/application/matchoffer/events"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+	"sportlink/pkg/slices"
+	"time"
+)
+
+type ExpireMatchOffersInput struct {
+	Now time.Time
+}
+
+type ExpireMatchOffersResult struct {
+	ExpiredOfferIDs           []string
+	ExpiredWaitlistRequestIDs []string // requests left waiting on confirmed offers that were played
+}
+
+const (
+	endedOffersPageSize = 100
+	// confirmedLookback bounds how far back the sweep looks for played confirmed offers whose
+	// waitlist is still open. Any sweep within that window closes them.
+	confirmedLookback = 7 * 24 * time.Hour
+)
+
+// ExpireMatchOffersUC marks pending offers whose time slot has already ended as EXPIRED,
+// together with their open match requests, and expires the requests still waiting on
+// confirmed offers that were already played. Items are archived, never deleted, so owners
+// and requesters keep an accurate history.
+type ExpireMatchOffersUC struct {
+	matchOfferRepository   matchoffer.Repository
+	matchRequestRepository matchrequest.Repository
+}
+
+func NewExpireMatchOffersUC(
+	matchOfferRepository matchoffer.Repository,
+	matchRequestRepository matchrequest.Repository,
+) *ExpireMatchOffersUC {
+	return &ExpireMatchOffersUC{
+		matchOfferRepository:   matchOfferRepository,
+		matchRequestRepository: matchRequestRepository,
+	}
+}
+
+func (uc *ExpireMatchOffersUC) Invoke(ctx context.Context, input ExpireMatchOffersInput) (*ExpireMatchOffersResult, error) {
+	offers, err := uc.findEndedOffers(ctx, matchoffer.DomainQuery{
+		Statuses: []matchoffer.Status{matchoffer.StatusPending},
+		ToDate:   input.Now,
+	}, input.Now)
+	if err != nil {
+		log.GetLogger(ctx).Error("failed to find ended match offers", err)
+		return nil, err
+	}
+
+	expired := make([]string, 0, len(offers))
+	for _, offer := range offers {
+		if err = uc.expireOffer(ctx, offer); err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to expire match offer %s", offer.ID), err)
+			continue
+		}
+		expired = append(expired, offer.ID)
+	}
+
+	confirmed, err := uc.findEndedOffers(ctx, matchoffer.DomainQuery{
+		Statuses: []matchoffer.Status{matchoffer.StatusConfirmed},
+		FromDate: input.Now.Add(-confirmedLookback),
+		ToDate:   input.Now,
+	}, input.Now)
+	if err != nil {
+		log.GetLogger(ctx).Error("failed to find played confirmed match offers", err)
+		return nil, err
+	}
+
+	waitlisted := make([]string, 0)
+	for _, offer := range confirmed {
+		requests, err := closeOpenRequests(ctx, uc.matchRequestRepository, offer.ID, matchrequest.Entity.Expire)
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to close the waitlist of match offer %s", offer.ID), err)
+			continue
+		}
+		for _, r := range requests {
+			waitlisted = append(waitlisted, r.ID)
+		}
+	}
+
+	return &ExpireMatchOffersResult{ExpiredOfferIDs: expired, ExpiredWaitlistRequestIDs: waitlisted}, nil
+}
+
+// findEndedOffers pages through the offers matching query and returns those whose time slot
+// already ended. All pages are read before anything is expired so offsets stay stable.
+func (uc *ExpireMatchOffersUC) findEndedOffers(ctx context.Context, query matchoffer.DomainQuery, now time.Time) ([]matchoffer.Entity, error) {
+	ended := make([]matchoffer.Entity, 0)
+	query.Limit = endedOffersPageSize
+	for {
+		page, err := uc.matchOfferRepository.Find(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		ended = append(ended, slices.Filter(page.Entities, func(o matchoffer.Entity) bool {
+			return o.HasEnded(now)
+		})...)
+
+		query.Offset += len(page.Entities)
+		if len(page.Entities) < query.Limit || query.Offset >= page.Total {
+			return ended, nil
+		}
+	}
+}
+
+// expireOffer expires the open requests first so a failure leaves the offer pending
+// and the next sweep retries the whole offer. The expired event is written with the offer.
+func (uc *ExpireMatchOffersUC) expireOffer(ctx context.Context, offer matchoffer.Entity) error {
+	expiredOffer, err := offer.Expire()
+	if err != nil {
+		return err
+	}
+
+	requests, err := closeOpenRequests(ctx, uc.matchRequestRepository, offer.ID, matchrequest.Entity.Expire)
+	if err != nil {
+		return err
+	}
+
+	message, err := appevents.NewMessage(ctx, buildExpiredEvent(offer, requests), time.Now())
+	if err != nil {
+		return err
+	}
+	return uc.matchOfferRepository.Save(ctx, expiredOffer, []outbox.Message{message})
+}
+
+// closeOpenRequests applies close to the requests of the offer that are still pending or
+// waitlisted. Each one is written only while it is still at the version it was read at; a
+// request accepted, promoted or cancelled meanwhile keeps its new status and is left out.
+func closeOpenRequests(
+	ctx context.Context,
+	matchRequestRepository matchrequest.Repository,
+	matchOfferID string,
+	close func(matchrequest.Entity) (matchrequest.Entity, error),
+) ([]matchrequest.Entity, error) {
+	pending, err := matchRequestRepository.Find(ctx, matchrequest.DomainQuery{
+		MatchOfferIDs: []string{matchOfferID},
+		Statuses:      []matchrequest.Status{matchrequest.StatusPending, matchrequest.StatusWaitlisted},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open requests: %w", err)
+	}
+	if len(pending) == 0 {
+		return pending, nil
+	}
+
+	closed := make([]matchrequest.Entity, 0, len(pending))
+	for _, r := range pending {
+		request, err := close(r)
+		if err != nil {
+			return nil, err
+		}
+		err = matchRequestRepository.Save(ctx, request, nil)
+		if stderrors.Is(err, common.ErrVersionConflict) {
+			log.GetLogger(ctx).Error(fmt.Sprintf("match request %s changed while closing it, leaving it as is", r.ID), err)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to close open requests: %w", err)
+		}
+		closed = append(closed, request)
+	}
+	return closed, nil
+}
+
+func buildExpiredEvent(offer matchoffer.Entity, requests []matchrequest.Entity) matchofferevent.MatchOfferExpiredEvent {
+	return matchofferevent.MatchOfferExpiredEvent{
+		MatchOfferID:   offer.ID,
+		OwnerAccountID: offer.OwnerAccountID,
+		ExpiredRequestIDs: slices.Map(requests, func(r matchrequest.Entity) string {
+			return r.ID
+		}),
+		RequesterAccountIDs: slices.Map(requests, func(r matchrequest.Entity) string {
+			return r.RequesterAccountID
+		}),
+	}
+}
@@ -0,0 +1,348 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	matchofferevent "sportlink/api/application/matchoffer/events"
+	"sportlink/api/application/matchoffer/usecases"
+	"sportlink/api/domain/common"
+	domainoffer "sportlink/api/domain/matchoffer"
+	domainreq "sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	offermocks "sportlink/mocks/api/domain/matchoffer"
+	reqmocks "sportlink/mocks/api/domain/matchrequest"
+)
+
+func TestExpireMatchOffersUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+
+	fixedDay := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	now := fixedDay.Add(21 * time.Hour)
+
+	endedOffer := domainoffer.Entity{
+		ID:       "offer-1",
+		TeamName: "Los Leones FC",
+		Sport:    common.Paddle,
+		Day:      fixedDay,
+		TimeSlot: domainoffer.TimeSlot{
+			StartTime: fixedDay.Add(18 * time.Hour),
+			EndTime:   fixedDay.Add(20 * time.Hour),
+		},
+		Status:         domainoffer.StatusPending,
+		OwnerAccountID: "owner-1",
+	}
+
+	laterToday := endedOffer
+	laterToday.ID = "offer-2"
+	laterToday.TimeSlot = domainoffer.TimeSlot{
+		StartTime: fixedDay.Add(22 * time.Hour),
+		EndTime:   fixedDay.Add(23 * time.Hour),
+	}
+
+	pendingRequest := domainreq.Entity{
+		ID:                 "AccountId#requester-1#MatchOfferId#offer-1",
+		MatchOfferID:       "offer-1",
+		OwnerAccountID:     "owner-1",
+		RequesterAccountID: "requester-1",
+		Status:             domainreq.StatusPending,
+		CreatedAt:          fixedDay,
+	}
+
+	playedConfirmed := endedOffer
+	playedConfirmed.ID = "offer-3"
+	playedConfirmed.Status = domainoffer.StatusConfirmed
+
+	waitlistedRequest := pendingRequest
+	waitlistedRequest.ID = "AccountId#requester-2#MatchOfferId#offer-3"
+	waitlistedRequest.MatchOfferID = "offer-3"
+	waitlistedRequest.RequesterAccountID = "requester-2"
+	waitlistedRequest.Status = domainreq.StatusWaitlisted
+
+	// a full first page of offers that are still to be played
+	fullPage := make([]domainoffer.Entity, 100)
+	for i := range fullPage {
+		fullPage[i] = laterToday
+	}
+
+	matchesEndedOffersPage := func(offset int) interface{} {
+		return mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+			return len(q.Statuses) == 1 && q.Statuses[0] == domainoffer.StatusPending && q.ToDate.Equal(now) &&
+				q.Limit == 100 && q.Offset == offset
+		})
+	}
+	matchesEndedOffersQuery := matchesEndedOffersPage(0)
+	matchesConfirmedOffersQuery := mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+		return len(q.Statuses) == 1 && q.Statuses[0] == domainoffer.StatusConfirmed &&
+			q.FromDate.Equal(now.Add(-7*24*time.Hour)) && q.ToDate.Equal(now) && q.Offset == 0
+	})
+	matchesPendingRequestsQuery := func(offerID string) interface{} {
+		return mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+			return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == offerID &&
+				assert.ObjectsAreEqual([]domainreq.Status{domainreq.StatusPending, domainreq.StatusWaitlisted}, q.Statuses)
+		})
+	}
+	noConfirmedOffers := func(offerRepo *offermocks.Repository) {
+		offerRepo.On("Find",
+			mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+			matchesConfirmedOffersQuery,
+		).Return(domainoffer.Page{}, nil)
+	}
+
+	testCases := []struct {
+		name string
+		on   func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository)
+		then func(t *testing.T, result *usecases.ExpireMatchOffersResult, err error)
+	}{
+		{
+			name: "given an offer whose match already ended when sweeping then the offer and its pending requests are expired and announced",
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesEndedOffersQuery,
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{endedOffer}, Total: 1}, nil)
+				noConfirmedOffers(offerRepo)
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesPendingRequestsQuery("offer-1"),
+				).Return([]domainreq.Entity{pendingRequest}, nil)
+
+				reqRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(r domainreq.Entity) bool {
+						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusExpired
+					}),
+					mock.Anything,
+				).Return(nil)
+
+				offerRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1" && o.Status == domainoffer.StatusExpired
+					}),
+					mock.MatchedBy(func(messages []outbox.Message) bool {
+						var e matchofferevent.MatchOfferExpiredEvent
+						return len(messages) == 1 && messages[0].Type == matchofferevent.MatchOfferExpiredEventType &&
+							messages[0].Decode(&e) == nil && e.MatchOfferID == "offer-1" && e.OwnerAccountID == "owner-1" &&
+							len(e.ExpiredRequestIDs) == 1 && e.ExpiredRequestIDs[0] == pendingRequest.ID &&
+							len(e.RequesterAccountIDs) == 1 && e.RequesterAccountIDs[0] == "requester-1"
+					}),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.ExpireMatchOffersResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-1"}, result.ExpiredOfferIDs)
+			},
+		},
+		{
+			name: "given an offer played later today when sweeping then it stays pending",
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesEndedOffersQuery,
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{laterToday}, Total: 1}, nil)
+				noConfirmedOffers(offerRepo)
+			},
+			then: func(t *testing.T, result *usecases.ExpireMatchOffersResult, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, result.ExpiredOfferIDs)
+			},
+		},
+		{
+			name: "given pending requests cannot be expired when sweeping then the offer stays pending for the next sweep",
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesEndedOffersQuery,
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{endedOffer}, Total: 1}, nil)
+				noConfirmedOffers(offerRepo)
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesPendingRequestsQuery("offer-1"),
+				).Return([]domainreq.Entity{pendingRequest}, nil)
+
+				reqRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(r domainreq.Entity) bool {
+						return r.Status == domainreq.StatusExpired
+					}),
+					mock.Anything,
+				).Return(errors.New("db connection error"))
+			},
+			then: func(t *testing.T, result *usecases.ExpireMatchOffersResult, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, result.ExpiredOfferIDs)
+			},
+		},
+		{
+			name: "given a pending request was accepted while sweeping when expiring it then it is left out and the offer write decides",
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesEndedOffersQuery,
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{endedOffer}, Total: 1}, nil)
+				noConfirmedOffers(offerRepo)
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesPendingRequestsQuery("offer-1"),
+				).Return([]domainreq.Entity{pendingRequest}, nil)
+
+				reqRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(r domainreq.Entity) bool {
+						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusExpired
+					}),
+					mock.Anything,
+				).Return(common.ErrVersionConflict)
+
+				// the acceptance also bumped the offer, so expiring it conflicts too
+				offerRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1" && o.Status == domainoffer.StatusExpired
+					}),
+					mock.Anything,
+				).Return(common.ErrVersionConflict)
+			},
+			then: func(t *testing.T, result *usecases.ExpireMatchOffersResult, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, result.ExpiredOfferIDs)
+			},
+		},
+		{
+			name: "given an ended offer without pending requests when sweeping then the offer is expired with an empty request list",
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesEndedOffersQuery,
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{endedOffer}, Total: 1}, nil)
+				noConfirmedOffers(offerRepo)
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesPendingRequestsQuery("offer-1"),
+				).Return([]domainreq.Entity{}, nil)
+
+				offerRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1" && o.Status == domainoffer.StatusExpired
+					}),
+					mock.MatchedBy(func(messages []outbox.Message) bool {
+						var e matchofferevent.MatchOfferExpiredEvent
+						return len(messages) == 1 && messages[0].Type == matchofferevent.MatchOfferExpiredEventType &&
+							messages[0].Decode(&e) == nil && e.MatchOfferID == "offer-1" && len(e.ExpiredRequestIDs) == 0
+					}),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.ExpireMatchOffersResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-1"}, result.ExpiredOfferIDs)
+			},
+		},
+		{
+			name: "given more ended offers than fit in a page when sweeping then every page is read",
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesEndedOffersPage(0),
+				).Return(domainoffer.Page{Entities: fullPage, Total: 101}, nil)
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesEndedOffersPage(100),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{endedOffer}, Total: 101}, nil)
+				noConfirmedOffers(offerRepo)
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesPendingRequestsQuery("offer-1"),
+				).Return([]domainreq.Entity{}, nil)
+
+				offerRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1" && o.Status == domainoffer.StatusExpired
+					}),
+					mock.MatchedBy(func(messages []outbox.Message) bool {
+						var e matchofferevent.MatchOfferExpiredEvent
+						return len(messages) == 1 && messages[0].Type == matchofferevent.MatchOfferExpiredEventType &&
+							messages[0].Decode(&e) == nil && e.MatchOfferID == "offer-1"
+					}),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.ExpireMatchOffersResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-1"}, result.ExpiredOfferIDs)
+			},
+		},
+		{
+			name: "given a confirmed offer was played with requests still waitlisted when sweeping then the waitlist is expired and the offer kept",
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesEndedOffersQuery,
+				).Return(domainoffer.Page{}, nil)
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesConfirmedOffersQuery,
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{playedConfirmed}, Total: 1}, nil)
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesPendingRequestsQuery("offer-3"),
+				).Return([]domainreq.Entity{waitlistedRequest}, nil)
+
+				reqRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(r domainreq.Entity) bool {
+						return r.ID == waitlistedRequest.ID && r.Status == domainreq.StatusExpired
+					}),
+					mock.Anything,
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.ExpireMatchOffersResult, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, result.ExpiredOfferIDs)
+				assert.Equal(t, []string{waitlistedRequest.ID}, result.ExpiredWaitlistRequestIDs)
+			},
+		},
+		{
+			name: "given offers cannot be searched when sweeping then returns error",
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository) {
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					matchesEndedOffersQuery,
+				).Return(domainoffer.Page{}, errors.New("db connection error"))
+			},
+			then: func(t *testing.T, result *usecases.ExpireMatchOffersResult, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				assert.Contains(t, err.Error(), "db connection error")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			offerRepo := offermocks.NewRepository(t)
+			reqRepo := reqmocks.NewRepository(t)
+			uc := usecases.NewExpireMatchOffersUC(offerRepo, reqRepo)
+
+			tc.on(t, offerRepo, reqRepo)
+
+			result, err := uc.Invoke(ctx, usecases.ExpireMatchOffersInput{Now: now})
+
+			tc.then(t, result, err)
+		})
+	}
+}
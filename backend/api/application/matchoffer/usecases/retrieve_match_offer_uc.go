@@ -2,21 +2,51 @@ package usecases
 
 import (
 	"context"
+	"sportlink/api/application/matchoffer/service"
 	"sportlink/api/domain/matchoffer"
 )
 
+// RetrieveMatchOfferInput identifies the offer and who is asking for it. ShareToken is
+// only needed for LINK offers the viewer was not invited to.
+type RetrieveMatchOfferInput struct {
+	MatchOfferID    string
+	ViewerAccountID string
+	ShareToken      string
+}
+
 type RetrieveMatchOfferUC struct {
 	matchOfferRepository matchoffer.Repository
+	visibilityPolicy     service.VisibilityPolicy
 }
 
-func NewRetrieveMatchOfferUC(repo matchoffer.Repository) *RetrieveMatchOfferUC {
-	return &RetrieveMatchOfferUC{matchOfferRepository: repo}
+func NewRetrieveMatchOfferUC(repo matchoffer.Repository, visibilityPolicy service.VisibilityPolicy) *RetrieveMatchOfferUC {
+	return &RetrieveMatchOfferUC{
+		matchOfferRepository: repo,
+		visibilityPolicy:     visibilityPolicy,
+	}
 }
 
-func (rm *RetrieveMatchOfferUC) Invoke(ctx context.Context, query matchoffer.DomainQuery) (*matchoffer.Entity, error) {
-	result, err := rm.matchOfferRepository.Find(ctx, query)
+// Invoke returns nil when the offer does not exist or is hidden from the viewer, so
+// private offers cannot be told apart from missing ones.
+func (rm *RetrieveMatchOfferUC) Invoke(ctx context.Context, input RetrieveMatchOfferInput) (*matchoffer.Entity, error) {
+	result, err := rm.matchOfferRepository.Find(ctx, matchoffer.DomainQuery{IDs: []string{input.MatchOfferID}})
 	if err != nil {
 		return nil, err
 	}
-	return &result.Entities[0], nil
+	if len(result.Entities) == 0 {
+		return nil, nil
+	}
+
+	offer := result.Entities[0]
+	canView, err := rm.visibilityPolicy.CanView(ctx, offer, service.Viewer{
+		AccountID:  input.ViewerAccountID,
+		ShareToken: input.ShareToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, nil
+	}
+	return &offer, nil
 }
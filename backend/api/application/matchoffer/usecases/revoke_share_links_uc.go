@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/pkg/log"
+)
+
+type RevokeShareLinksInput struct {
+	MatchOfferID   string
+	OwnerAccountID string
+}
+
+// RevokeShareLinksUC invalidates every share link handed out for an offer. Accounts that
+// already sent a match request keep it; new links can be created afterwards.
+type RevokeShareLinksUC struct {
+	matchOfferRepository matchoffer.Repository
+}
+
+func NewRevokeShareLinksUC(matchOfferRepository matchoffer.Repository) *RevokeShareLinksUC {
+	return &RevokeShareLinksUC{matchOfferRepository: matchOfferRepository}
+}
+
+func (uc *RevokeShareLinksUC) Invoke(ctx context.Context, input RevokeShareLinksInput) (*matchoffer.Entity, error) {
+	page, err := uc.matchOfferRepository.Find(ctx, matchoffer.DomainQuery{IDs: []string{input.MatchOfferID}})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match offer %s", input.MatchOfferID), err)
+		return nil, err
+	}
+	if len(page.Entities) == 0 {
+		return nil, errors.NotFound("match offer not found")
+	}
+
+	offer := page.Entities[0]
+	if offer.OwnerAccountID != input.OwnerAccountID {
+		return nil, errors.Unauthorized("owner account ID does not match")
+	}
+
+	revoked := offer.RevokeShareLinks()
+	if err = uc.matchOfferRepository.Save(ctx, revoked, nil); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to revoke share links of match offer %s", offer.ID), err)
+		if stderrors.Is(err, common.ErrVersionConflict) {
+			return nil, errors.ConcurrentModification(err.Error())
+		}
+		return nil, err
+	}
+	return &revoked, nil
+}
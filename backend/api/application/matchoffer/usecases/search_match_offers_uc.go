@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"sportlink/api/application/matchoffer/service"
 	"sportlink/api/domain/matchoffer"
 	"sportlink/api/domain/matchrequest"
 	"sportlink/pkg/slices"
@@ -11,23 +12,29 @@ import (
 type SearchMatchOffersInput struct {
 	ViewerAccountID string
 	Query           matchoffer.DomainQuery
+	MinOpenSpots    int    // Only offers with at least this many open spots (0 = no filter)
+	Position        string // Only offers with an open spot for this position (optional)
 }
 
 // SearchMatchOffersUC returns match offers available for a given account, automatically
 // excluding offers owned by that account and offers where the account already has a
-// PENDING or ACCEPTED match request.
+// PENDING, ACCEPTED or WAITLISTED match request. Non-public offers are only listed for
+// the accounts their visibility allows; LINK offers never show up without an invitation.
 type SearchMatchOffersUC struct {
 	matchOfferRepo   matchoffer.Repository
 	matchRequestRepo matchrequest.Repository
+	visibilityPolicy service.VisibilityPolicy
 }
 
 func NewSearchMatchOffersUC(
 	matchOfferRepo matchoffer.Repository,
 	matchRequestRepo matchrequest.Repository,
+	visibilityPolicy service.VisibilityPolicy,
 ) *SearchMatchOffersUC {
 	return &SearchMatchOffersUC{
 		matchOfferRepo:   matchOfferRepo,
 		matchRequestRepo: matchRequestRepo,
+		visibilityPolicy: visibilityPolicy,
 	}
 }
 
@@ -37,34 +44,41 @@ func (uc *SearchMatchOffersUC) Invoke(ctx context.Context, input SearchMatchOffe
 		return nil, err
 	}
 
-	page, err := uc.matchOfferRepo.Find(ctx, input.Query)
+	audience, err := uc.visibilityPolicy.AudienceOf(ctx, input.ViewerAccountID)
 	if err != nil {
 		return nil, err
 	}
 
-	available := uc.excludeOffersUnavailableForViewer(page.Entities, input.ViewerAccountID, requestedOfferIDs)
+	// Visibility, open spots and the viewer's own or already requested offers are filtered
+	// by the repository, so pages are full and the total is exact.
+	query := input.Query
+	query.VisibleTo = &audience
+	query.ExcludedOwner = input.ViewerAccountID
+	query.ExcludedIDs = requestedOfferIDs
 
-	pageInfo := CalculatePageInfo(
-		input.Query.Limit,
-		input.Query.Offset,
-		adjustTotal(page.Total, page.Entities, available),
-	)
+	query.MinOpenSpots = input.MinOpenSpots
+	query.OpenPosition = input.Position
+
+	page, err := uc.matchOfferRepo.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
 
 	return &FindMatchOfferResult{
-		Entities: available,
-		Page:     pageInfo,
+		Entities: page.Entities,
+		Page:     CalculatePageInfo(input.Query.Limit, input.Query.Offset, page.Total),
 	}, nil
 }
 
 // fetchOfferIDsWithActiveRequestByViewer returns the IDs of match offers for which the viewer
-// already has a PENDING or ACCEPTED match request.
+// already has a PENDING, ACCEPTED or WAITLISTED match request.
 func (uc *SearchMatchOffersUC) fetchOfferIDsWithActiveRequestByViewer(
 	ctx context.Context,
 	viewerAccountID string,
 ) ([]string, error) {
 	requests, err := uc.matchRequestRepo.Find(ctx, matchrequest.DomainQuery{
 		RequesterAccountIDs: []string{viewerAccountID},
-		Statuses:            []matchrequest.Status{matchrequest.StatusPending, matchrequest.StatusAccepted},
+		Statuses:            []matchrequest.Status{matchrequest.StatusPending, matchrequest.StatusAccepted, matchrequest.StatusWaitlisted},
 	})
 	if err != nil {
 		return nil, err
@@ -73,25 +87,3 @@ func (uc *SearchMatchOffersUC) fetchOfferIDsWithActiveRequestByViewer(
 		return r.MatchOfferID
 	}), nil
 }
-
-// excludeOffersUnavailableForViewer filters out offers owned by the viewer and offers
-// for which the viewer already has an active match request.
-func (uc *SearchMatchOffersUC) excludeOffersUnavailableForViewer(
-	offers []matchoffer.Entity,
-	viewerAccountID string,
-	requestedOfferIDs []string,
-) []matchoffer.Entity {
-	return slices.Filter(offers, func(e matchoffer.Entity) bool {
-		return e.OwnerAccountID != viewerAccountID && !slices.Contains(requestedOfferIDs, e.ID)
-	})
-}
-
-// adjustTotal corrects the total count by subtracting the number of offers excluded on the current page.
-// This is a best-effort approximation since DynamoDB's total includes offers that were filtered out.
-func adjustTotal(total int, original, filtered []matchoffer.Entity) int {
-	adjusted := total - (len(original) - len(filtered))
-	if adjusted < 0 {
-		return 0
-	}
-	return adjusted
-}
@@ -0,0 +1,198 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sportlink/api/application/matchoffer/usecases"
+	domainoffer "sportlink/api/domain/matchoffer"
+	domainreq "sportlink/api/domain/matchrequest"
+	servicemocks "sportlink/mocks/api/application/matchoffer/service"
+	offermocks "sportlink/mocks/api/domain/matchoffer"
+	reqmocks "sportlink/mocks/api/domain/matchrequest"
+)
+
+func TestSearchMatchOffersUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+
+	groupOffer := domainoffer.Entity{
+		ID:             "offer-group",
+		OwnerAccountID: "owner-1",
+		Status:         domainoffer.StatusPending,
+		PlayerNeeds: domainoffer.PlayerNeeds{
+			Committed:   8,
+			SpotsNeeded: 3,
+			Positions: []domainoffer.PositionNeed{
+				{Position: "GOALKEEPER", Spots: 1},
+				{Position: "DEFENDER", Spots: 2},
+			},
+		},
+	}
+	unlimitedOffer := domainoffer.Entity{
+		ID:             "offer-unlimited",
+		OwnerAccountID: "owner-2",
+		Status:         domainoffer.StatusPending,
+	}
+	expectViewerRequests := func(reqRepo *reqmocks.Repository) {
+		reqRepo.On("Find",
+			mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+			mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+				return len(q.RequesterAccountIDs) == 1 && q.RequesterAccountIDs[0] == "viewer-1"
+			}),
+		).Return([]domainreq.Entity{}, nil)
+	}
+	expectAudience := func(policy *servicemocks.VisibilityPolicy) {
+		policy.On("AudienceOf",
+			mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+			"viewer-1",
+		).Return(domainoffer.Audience{AccountID: "viewer-1"}, nil)
+	}
+	expectOffers := func(offerRepo *offermocks.Repository) {
+		offerRepo.On("Find",
+			mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+			mock.MatchedBy(func(q domainoffer.DomainQuery) bool { return q.Limit == 10 }),
+		).Return(domainoffer.Page{Entities: []domainoffer.Entity{groupOffer, unlimitedOffer}, Total: 2}, nil)
+	}
+	testCases := []struct {
+		name  string
+		input usecases.SearchMatchOffersInput
+		on    func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository, policy *servicemocks.VisibilityPolicy)
+		then  func(t *testing.T, result *usecases.FindMatchOfferResult, err error)
+	}{
+		{
+			name: "given no open spots filter when searching then returns the offers found",
+			input: usecases.SearchMatchOffersInput{
+				ViewerAccountID: "viewer-1",
+				Query:           domainoffer.DomainQuery{Limit: 10},
+			},
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository, policy *servicemocks.VisibilityPolicy) {
+				expectViewerRequests(reqRepo)
+				expectAudience(policy)
+				expectOffers(offerRepo)
+			},
+			then: func(t *testing.T, result *usecases.FindMatchOfferResult, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result.Entities, 2)
+			},
+		},
+		{
+			name: "given open spots and a position asked for when searching then the repository filters on them",
+			input: usecases.SearchMatchOffersInput{
+				ViewerAccountID: "viewer-1",
+				Query:           domainoffer.DomainQuery{Limit: 10},
+				MinOpenSpots:    2,
+				Position:        "DEFENDER",
+			},
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository, policy *servicemocks.VisibilityPolicy) {
+				expectViewerRequests(reqRepo)
+				expectAudience(policy)
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return q.Limit == 10 && q.MinOpenSpots == 2 && q.OpenPosition == "DEFENDER"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{groupOffer}, Total: 11}, nil)
+			},
+			then: func(t *testing.T, result *usecases.FindMatchOfferResult, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result.Entities, 1)
+				assert.Equal(t, "offer-group", result.Entities[0].ID)
+				assert.Equal(t, 11, result.Page.Total)
+				assert.Equal(t, 2, result.Page.OutOf)
+			},
+		},
+		{
+			name: "given viewer with requests and teams when searching then the repository filters on visibility and exclusions",
+			input: usecases.SearchMatchOffersInput{
+				ViewerAccountID: "viewer-1",
+				Query:           domainoffer.DomainQuery{Limit: 10},
+			},
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository, policy *servicemocks.VisibilityPolicy) {
+				audience := domainoffer.Audience{
+					AccountID: "viewer-1",
+					Teams:     []domainoffer.TeamRef{{ID: "team-3"}},
+				}
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.RequesterAccountIDs) == 1 && q.RequesterAccountIDs[0] == "viewer-1"
+					}),
+				).Return([]domainreq.Entity{{ID: "request-1", MatchOfferID: "offer-requested"}}, nil)
+				policy.On("AudienceOf",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					"viewer-1",
+				).Return(audience, nil)
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return q.Limit == 10 &&
+							q.VisibleTo != nil && assert.ObjectsAreEqual(audience, *q.VisibleTo) &&
+							q.ExcludedOwner == "viewer-1" &&
+							assert.ObjectsAreEqual([]string{"offer-requested"}, q.ExcludedIDs)
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{unlimitedOffer}, Total: 21}, nil)
+			},
+			then: func(t *testing.T, result *usecases.FindMatchOfferResult, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result.Entities, 1)
+				assert.Equal(t, 21, result.Page.Total)
+			},
+		},
+		{
+			name: "given team lookup fails when searching then returns error",
+			input: usecases.SearchMatchOffersInput{
+				ViewerAccountID: "viewer-1",
+				Query:           domainoffer.DomainQuery{Limit: 10},
+			},
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository, policy *servicemocks.VisibilityPolicy) {
+				expectViewerRequests(reqRepo)
+				policy.On("AudienceOf",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					"viewer-1",
+				).Return(domainoffer.Audience{}, errors.New("db connection error"))
+			},
+			then: func(t *testing.T, result *usecases.FindMatchOfferResult, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "given repository error when finding offers then returns error",
+			input: usecases.SearchMatchOffersInput{
+				ViewerAccountID: "viewer-1",
+				Query:           domainoffer.DomainQuery{Limit: 10},
+				MinOpenSpots:    1,
+			},
+			on: func(t *testing.T, offerRepo *offermocks.Repository, reqRepo *reqmocks.Repository, policy *servicemocks.VisibilityPolicy) {
+				expectViewerRequests(reqRepo)
+				expectAudience(policy)
+				offerRepo.On("Find", mock.Anything, mock.Anything).Return(domainoffer.Page{}, errors.New("db connection error"))
+			},
+			then: func(t *testing.T, result *usecases.FindMatchOfferResult, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			offerRepo := offermocks.NewRepository(t)
+			reqRepo := reqmocks.NewRepository(t)
+			policy := servicemocks.NewVisibilityPolicy(t)
+			uc := usecases.NewSearchMatchOffersUC(offerRepo, reqRepo, policy)
+
+			tc.on(t, offerRepo, reqRepo, policy)
+
+			result, err := uc.Invoke(ctx, tc.input)
+
+			tc.then(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package events
+
+// MatchRequestAcceptedEventType identifies MatchRequestAcceptedEvent messages in the outbox.
+const MatchRequestAcceptedEventType = "matchrequest.accepted"
+
+// MatchRequestAcceptedEvent is published when the offer owner accepts a request and
+// the requester takes one of the offer's spots. The requester is the one to notify.
+type MatchRequestAcceptedEvent struct {
+	MatchRequestID     string `json:"match_request_id"`
+	MatchOfferID       string `json:"match_offer_id"`
+	RequesterAccountID string `json:"requester_account_id"`
+	OwnerAccountID     string `json:"owner_account_id"`
+	Position           string `json:"position,omitempty"`
+}
+
+func (MatchRequestAcceptedEvent) EventType() string { return MatchRequestAcceptedEventType }
+func (MatchRequestAcceptedEvent) EventVersion() int { return 1 }
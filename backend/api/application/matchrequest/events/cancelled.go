@@ -0,0 +1,16 @@
+package events
+
+// MatchRequestCancelledEventType identifies MatchRequestCancelledEvent messages in the outbox.
+const MatchRequestCancelledEventType = "matchrequest.cancelled"
+
+// MatchRequestCancelledEvent is published when the requester withdraws a request,
+// or drops out of a confirmed match. The offer owner is the one to notify.
+type MatchRequestCancelledEvent struct {
+	MatchRequestID     string `json:"match_request_id"`
+	MatchOfferID       string `json:"match_offer_id"`
+	RequesterAccountID string `json:"requester_account_id"`
+	OwnerAccountID     string `json:"owner_account_id"`
+}
+
+func (MatchRequestCancelledEvent) EventType() string { return MatchRequestCancelledEventType }
+func (MatchRequestCancelledEvent) EventVersion() int { return 1 }
@@ -0,0 +1,17 @@
+package events
+
+// MatchRequestCreatedEventType identifies MatchRequestCreatedEvent messages in the outbox.
+const MatchRequestCreatedEventType = "matchrequest.created"
+
+// MatchRequestCreatedEvent is published when an account asks to join a match offer.
+// The offer owner is the one to notify.
+type MatchRequestCreatedEvent struct {
+	MatchRequestID     string `json:"match_request_id"`
+	MatchOfferID       string `json:"match_offer_id"`
+	RequesterAccountID string `json:"requester_account_id"`
+	OwnerAccountID     string `json:"owner_account_id"`
+	Position           string `json:"position,omitempty"`
+}
+
+func (MatchRequestCreatedEvent) EventType() string { return MatchRequestCreatedEventType }
+func (MatchRequestCreatedEvent) EventVersion() int { return 1 }
@@ -0,0 +1,18 @@
+package events
+
+// MatchRequestPromotedEventType identifies MatchRequestPromotedEvent messages in the outbox.
+const MatchRequestPromotedEventType = "matchrequest.promoted"
+
+// MatchRequestPromotedEvent is published when an accepted participant cancels a
+// confirmed match and the next waitlisted request takes the freed spot. The
+// requester is the one to notify.
+type MatchRequestPromotedEvent struct {
+	MatchRequestID     string `json:"match_request_id"`
+	MatchOfferID       string `json:"match_offer_id"`
+	MatchID            string `json:"match_id"`
+	RequesterAccountID string `json:"requester_account_id"`
+	OwnerAccountID     string `json:"owner_account_id"`
+}
+
+func (MatchRequestPromotedEvent) EventType() string { return MatchRequestPromotedEventType }
+func (MatchRequestPromotedEvent) EventVersion() int { return 1 }
@@ -0,0 +1,17 @@
+package events
+
+// MatchRequestRejectedEventType identifies MatchRequestRejectedEvent messages in the outbox.
+const MatchRequestRejectedEventType = "matchrequest.rejected"
+
+// MatchRequestRejectedEvent is published when the offer owner turns a request down.
+// The requester is the one to notify.
+type MatchRequestRejectedEvent struct {
+	MatchRequestID     string `json:"match_request_id"`
+	MatchOfferID       string `json:"match_offer_id"`
+	RequesterAccountID string `json:"requester_account_id"`
+	OwnerAccountID     string `json:"owner_account_id"`
+	Reason             string `json:"reason,omitempty"` // given by the owner; empty when none was
+}
+
+func (MatchRequestRejectedEvent) EventType() string { return MatchRequestRejectedEventType }
+func (MatchRequestRejectedEvent) EventVersion() int { return 1 }
@@ -0,0 +1,20 @@
+package request
+
+// BulkAcceptMatchRequestsRequest represents the HTTP request body for accepting several
+// match requests at once. They are accepted in the order given.
+type BulkAcceptMatchRequestsRequest struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=50,dive,required"`
+}
+
+// BulkRejectMatchRequestsRequest represents the HTTP request body for rejecting several
+// match requests at once. The reason, when given, is shown to the requesters.
+type BulkRejectMatchRequestsRequest struct {
+	IDs    []string `json:"ids" validate:"required,min=1,max=50,dive,required"`
+	Reason string   `json:"reason" validate:"max=280"`
+}
+
+// RejectPendingMatchRequestsRequest represents the optional HTTP request body for
+// rejecting every request still pending on an offer.
+type RejectPendingMatchRequestsRequest struct {
+	Reason string `json:"reason" validate:"max=280"`
+}
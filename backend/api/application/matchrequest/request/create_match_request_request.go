@@ -1,4 +1,9 @@
 package request
 
-// CreateMatchRequestRequest represents the HTTP request body for creating a match request
-type CreateMatchRequestRequest struct{}
+// CreateMatchRequestRequest represents the HTTP request body for creating a match request.
+// The body is optional; Position is only relevant for offers that need specific positions,
+// and ShareToken for LINK offers the requester was not invited to.
+type CreateMatchRequestRequest struct {
+	Position   string `json:"position"`
+	ShareToken string `json:"share_token"`
+}
@@ -1,6 +1,7 @@
 package request
 
-// UpdateMatchRequestRequest represents the HTTP request body for updating a match request status
+// UpdateMatchRequestRequest represents the HTTP request body for updating a match request status.
+// Accepting goes through the accept endpoint so the offer's spots stay in sync.
 type UpdateMatchRequestRequest struct {
-	Status string `json:"status" validate:"required,oneof=ACCEPTED REJECTED"`
+	Status string `json:"status" validate:"required,oneof=REJECTED"`
 }
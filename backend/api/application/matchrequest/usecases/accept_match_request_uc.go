@@ -2,13 +2,18 @@ package usecases
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"sportlink/api/application/errors"
 	appevents "sportlink/api/application/events"
 	matchofferevent "sportlink/api/application/matchoffer/events"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
+	"sportlink/api/domain/common"
 	"sportlink/api/domain/matchoffer"
 	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
 	"sportlink/pkg/log"
+	"time"
 )
 
 type AcceptMatchRequestInput struct {
@@ -19,18 +24,15 @@ type AcceptMatchRequestInput struct {
 type AcceptMatchRequestUC struct {
 	matchRequestRepository matchrequest.Repository
 	matchOfferRepository   matchoffer.Repository
-	publisher              appevents.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]
 }
 
 func NewAcceptMatchRequestUC(
 	matchRequestRepository matchrequest.Repository,
 	matchOfferRepository matchoffer.Repository,
-	publisher appevents.Publisher[matchofferevent.MatchOfferCapacityReachedEvent],
 ) *AcceptMatchRequestUC {
 	return &AcceptMatchRequestUC{
 		matchRequestRepository: matchRequestRepository,
 		matchOfferRepository:   matchOfferRepository,
-		publisher:              publisher,
 	}
 }
 
@@ -45,10 +47,10 @@ func (uc *AcceptMatchRequestUC) Invoke(ctx context.Context, input AcceptMatchReq
 		return nil, errors.Unauthorized("owner account ID does not match")
 	}
 
-	if !matchReq.IsPending() {
-		err = errors.UseCaseExecutionFailed("match request is not pending")
-		log.GetLogger(ctx).Error(fmt.Sprintf("match request %s is not pending, status: %s", input.MatchRequestId, matchReq.Status), err)
-		return nil, err
+	accepted, err := matchReq.Accept(common.ActorOwner)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("match request %s cannot be accepted", input.MatchRequestId), err)
+		return nil, errors.InvalidTransition(err.Error())
 	}
 
 	matchOffer, err := getMatchOffer(ctx, uc.matchOfferRepository, matchReq.MatchOfferID)
@@ -62,45 +64,59 @@ func (uc *AcceptMatchRequestUC) Invoke(ctx context.Context, input AcceptMatchReq
 		return nil, err
 	}
 
-	accepted := matchReq.Accept()
-	if err = uc.matchRequestRepository.Save(ctx, accepted); err != nil {
-		log.GetLogger(ctx).Error(fmt.Sprintf("failed to save accepted match request %s", input.MatchRequestId), err)
+	claimed, err := matchOffer.ClaimSpot(matchReq.Position)
+	if err != nil {
+		err = errors.MatchOfferFull(err.Error())
+		log.GetLogger(ctx).Error(fmt.Sprintf("match offer %s has no open spots for request %s", matchOffer.ID, input.MatchRequestId), err)
 		return nil, err
 	}
 
-	if err = uc.tryPublishIfCapacityReached(ctx, matchOffer); err != nil {
-		log.GetLogger(ctx).Error(fmt.Sprintf("failed to publish capacity reached event for offer %s", matchOffer.ID), err)
+	messages, err := acceptanceMessages(ctx, accepted, claimed)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to build acceptance events for request %s", input.MatchRequestId), err)
+		return nil, err
+	}
+
+	if err = uc.matchRequestRepository.SaveWithOffer(ctx, accepted, claimed, messages); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to save accepted match request %s", input.MatchRequestId), err)
+		return nil, mapAcceptanceError(err)
 	}
 
 	return &accepted, nil
 }
 
-func (uc *AcceptMatchRequestUC) tryPublishIfCapacityReached(ctx context.Context, offer *matchoffer.Entity) error {
-	if offer.Capacity == 0 {
-		return nil
-	}
-	count, err := uc.countAcceptedRequests(ctx, offer.ID)
-	if err != nil {
+// mapAcceptanceError turns the conditions the repository checks while saving the
+// acceptance into errors the caller can act on. Anything else is returned as is.
+func mapAcceptanceError(err error) error {
+	switch {
+	case stderrors.Is(err, matchoffer.ErrOfferFull):
+		return errors.MatchOfferFull(err.Error())
+	case stderrors.Is(err, common.ErrVersionConflict):
+		return errors.ConcurrentModification(err.Error())
+	default:
 		return err
 	}
-	if count == offer.Capacity-1 {
-		return uc.publisher.Publish(ctx, matchofferevent.MatchOfferCapacityReachedEvent{
+}
+
+// acceptanceMessages returns the events raised by the acceptance: the accepted event, and
+// the capacity reached event when the request took the last spot of the offer. They go to
+// the outbox together with the acceptance, so the offer gets confirmed even if the process
+// stops right after.
+func acceptanceMessages(ctx context.Context, accepted matchrequest.Entity, offer matchoffer.Entity) ([]outbox.Message, error) {
+	raised := []appevents.Event{matchrequestevent.MatchRequestAcceptedEvent{
+		MatchRequestID:     accepted.ID,
+		MatchOfferID:       accepted.MatchOfferID,
+		RequesterAccountID: accepted.RequesterAccountID,
+		OwnerAccountID:     accepted.OwnerAccountID,
+		Position:           accepted.Position,
+	}}
+	if offer.OpenSpots(offer.AcceptedCount) == 0 {
+		raised = append(raised, matchofferevent.MatchOfferCapacityReachedEvent{
 			MatchOfferID:   offer.ID,
 			OwnerAccountID: offer.OwnerAccountID,
 		})
 	}
-	return nil
-}
-
-func (uc *AcceptMatchRequestUC) countAcceptedRequests(ctx context.Context, matchOfferID string) (int, error) {
-	requests, err := uc.matchRequestRepository.Find(ctx, matchrequest.DomainQuery{
-		MatchOfferIDs: []string{matchOfferID},
-		Statuses:      []matchrequest.Status{matchrequest.StatusAccepted},
-	})
-	if err != nil {
-		return 0, err
-	}
-	return len(requests), nil
+	return appevents.NewMessages(ctx, time.Now(), raised...)
 }
 
 func getMatchRequest(
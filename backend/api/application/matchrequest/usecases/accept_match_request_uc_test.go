@@ -3,18 +3,21 @@ package usecases_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	apperrors "sportlink/api/application/errors"
 	matchofferevent "sportlink/api/application/matchoffer/events"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
 	"sportlink/api/application/matchrequest/usecases"
 	"sportlink/api/domain/common"
 	domainoffer "sportlink/api/domain/matchoffer"
 	domainreq "sportlink/api/domain/matchrequest"
-	eventmocks "sportlink/mocks/api/application/events"
+	"sportlink/api/domain/outbox"
 	offermocks "sportlink/mocks/api/domain/matchoffer"
 	reqmocks "sportlink/mocks/api/domain/matchrequest"
 )
@@ -22,8 +25,8 @@ import (
 func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 	ctx := context.Background()
 
-	fixedDay := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
-	fixedNow := time.Date(2026, 4, 11, 12, 0, 0, 0, time.UTC)
+	fixedDay := time.Date(2030, 5, 10, 0, 0, 0, 0, time.UTC)
+	fixedNow := time.Date(2030, 4, 11, 12, 0, 0, 0, time.UTC)
 
 	pendingRequest := domainreq.Entity{
 		ID:                 "AccountId#requester-1#MatchOfferId#offer-1",
@@ -34,6 +37,26 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 		CreatedAt:          fixedNow,
 	}
 
+	isAccepted := func(message outbox.Message) bool {
+		if message.Type != matchrequestevent.MatchRequestAcceptedEventType || message.Version != 1 {
+			return false
+		}
+		var event matchrequestevent.MatchRequestAcceptedEvent
+		return message.Decode(&event) == nil && event.MatchRequestID == pendingRequest.ID && event.RequesterAccountID == "requester-1"
+	}
+
+	isOnlyAccepted := func(messages []outbox.Message) bool {
+		return len(messages) == 1 && isAccepted(messages[0])
+	}
+
+	isCapacityReached := func(messages []outbox.Message) bool {
+		if len(messages) != 2 || !isAccepted(messages[0]) || messages[1].Type != matchofferevent.MatchOfferCapacityReachedEventType {
+			return false
+		}
+		var event matchofferevent.MatchOfferCapacityReachedEvent
+		return messages[1].Decode(&event) == nil && event.MatchOfferID == "offer-1" && event.OwnerAccountID == "owner-1"
+	}
+
 	pendingOffer := domainoffer.Entity{
 		ID:       "offer-1",
 		TeamName: "Los Leones FC",
@@ -55,13 +78,13 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 	testCases := []struct {
 		name  string
 		input usecases.AcceptMatchRequestInput
-		on    func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent])
+		on    func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository)
 		then  func(t *testing.T, result *domainreq.Entity, err error)
 	}{
 		{
 			name:  "given pending request and pending offer with no capacity when accepting then saves accepted request",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -76,11 +99,15 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
 
-				reqRepo.On("Save",
+				reqRepo.On("SaveWithOffer",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(r domainreq.Entity) bool {
 						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusAccepted
 					}),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1" && o.AcceptedCount == 1
+					}),
+					mock.MatchedBy(isOnlyAccepted),
 				).Return(nil)
 			},
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
@@ -96,7 +123,7 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 				MatchRequestId: pendingRequest.ID,
 				OwnerAccountID: "another-account",
 			},
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -116,7 +143,7 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 				MatchRequestId: "non-existent-id",
 				OwnerAccountID: "owner-1",
 			},
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -133,7 +160,7 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 		{
 			name:  "given repository error when finding match request then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -150,7 +177,7 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 		{
 			name:  "given already accepted match request when accepting then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
 				acceptedRequest := pendingRequest
 				acceptedRequest.Status = domainreq.StatusAccepted
 				reqRepo.On("Find",
@@ -163,13 +190,13 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, result)
-				assert.Contains(t, err.Error(), "match request is not pending")
+				assert.Equal(t, apperrors.InvalidTransition("match request cannot go from ACCEPTED to ACCEPTED by OWNER"), err)
 			},
 		},
 		{
 			name:  "given match offer not found when accepting then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -193,7 +220,7 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 		{
 			name:  "given already confirmed match offer when accepting then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -219,7 +246,7 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 		{
 			name:  "given repository fails when saving accepted request then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -234,11 +261,15 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
 
-				reqRepo.On("Save",
+				reqRepo.On("SaveWithOffer",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(r domainreq.Entity) bool {
 						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusAccepted
 					}),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1" && o.AcceptedCount == 1
+					}),
+					mock.MatchedBy(isOnlyAccepted),
 				).Return(errors.New("request save failed"))
 			},
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
@@ -248,9 +279,9 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 			},
 		},
 		{
-			name:  "given offer with capacity reached when accepting last request then publishes event",
+			name:  "given offer with capacity reached when accepting last request then writes capacity reached event to the outbox",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
 				offerWithCapacity := pendingOffer
 				offerWithCapacity.Capacity = 2 // owner + 1 requester
 
@@ -268,28 +299,56 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 					}),
 				).Return(domainoffer.Page{Entities: []domainoffer.Entity{offerWithCapacity}, Total: 1}, nil)
 
-				reqRepo.On("Save",
+				reqRepo.On("SaveWithOffer",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(r domainreq.Entity) bool {
 						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusAccepted
 					}),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1" && o.AcceptedCount == 1
+					}),
+					mock.MatchedBy(isCapacityReached),
 				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Equal(t, domainreq.StatusAccepted, result.Status)
+			},
+		},
+		{
+			name:  "given offer with capacity not yet reached when accepting request then writes no outbox message",
+			input: validInput,
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				offerWithCapacity := pendingOffer
+				offerWithCapacity.Capacity = 4      // owner + 3 requesters
+				offerWithCapacity.AcceptedCount = 1 // 2 of 3 spots taken after this one
 
-				// After save, count accepted requests (now 1 accepted == capacity-1)
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
-						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
-							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
+						return len(q.IDs) == 1 && q.IDs[0] == pendingRequest.ID
 					}),
 				).Return([]domainreq.Entity{pendingRequest}, nil)
 
-				publisher.On("Publish",
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{offerWithCapacity}, Total: 1}, nil)
+
+				reqRepo.On("SaveWithOffer",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
-					mock.MatchedBy(func(e matchofferevent.MatchOfferCapacityReachedEvent) bool {
-						return e.MatchOfferID == "offer-1" && e.OwnerAccountID == "owner-1"
+					mock.MatchedBy(func(r domainreq.Entity) bool {
+						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusAccepted
+					}),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1" && o.AcceptedCount == 2
 					}),
+					mock.MatchedBy(isOnlyAccepted),
 				).Return(nil)
+
 			},
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
 				assert.NoError(t, err)
@@ -298,11 +357,12 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 			},
 		},
 		{
-			name:  "given offer with capacity not yet reached when accepting request then does not publish event",
+			name:  "given offer with no open spots when accepting request then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, publisher *eventmocks.Publisher[matchofferevent.MatchOfferCapacityReachedEvent]) {
-				offerWithCapacity := pendingOffer
-				offerWithCapacity.Capacity = 4 // owner + 3 requesters
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				fullOffer := pendingOffer
+				fullOffer.Capacity = 2 // owner + 1 requester, already taken
+				fullOffer.AcceptedCount = 1
 
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
@@ -316,28 +376,151 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
-				).Return(domainoffer.Page{Entities: []domainoffer.Entity{offerWithCapacity}, Total: 1}, nil)
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{fullOffer}, Total: 1}, nil)
+
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				assert.Contains(t, err.Error(), "match offer is full")
+			},
+		},
+		{
+			name:  "given group that needs two more players when accepting the second one then writes capacity reached event to the outbox",
+			input: validInput,
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				groupOffer := pendingOffer
+				groupOffer.PlayerNeeds = domainoffer.PlayerNeeds{Committed: 8, SpotsNeeded: 2}
+				groupOffer.AcceptedCount = 1
 
-				reqRepo.On("Save",
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == pendingRequest.ID
+					}),
+				).Return([]domainreq.Entity{pendingRequest}, nil)
+
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{groupOffer}, Total: 1}, nil)
+
+				reqRepo.On("SaveWithOffer",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(r domainreq.Entity) bool {
 						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusAccepted
 					}),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1" && o.AcceptedCount == 2
+					}),
+					mock.MatchedBy(isCapacityReached),
 				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Equal(t, domainreq.StatusAccepted, result.Status)
+			},
+		},
+		{
+			name:  "given position already filled when accepting request for that position then returns error",
+			input: validInput,
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				goalkeeperRequest := pendingRequest
+				goalkeeperRequest.Position = "GOALKEEPER"
+
+				positionsOffer := pendingOffer
+				positionsOffer.PlayerNeeds = domainoffer.PlayerNeeds{
+					Committed:   8,
+					SpotsNeeded: 3,
+					Positions: []domainoffer.PositionNeed{
+						{Position: "GOALKEEPER", Spots: 1},
+						{Position: "DEFENDER", Spots: 2},
+					},
+				}
+				positionsOffer.AcceptedCount = 1
+				positionsOffer.AcceptedByPosition = map[string]int{"GOALKEEPER": 1}
 
-				// count returns 1 accepted, capacity-1 == 3, not reached
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
-						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
-							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusAccepted
+						return len(q.IDs) == 1 && q.IDs[0] == pendingRequest.ID
+					}),
+				).Return([]domainreq.Entity{goalkeeperRequest}, nil)
+
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{positionsOffer}, Total: 1}, nil)
+
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				assert.Contains(t, err.Error(), "match offer is full for position GOALKEEPER")
+			},
+		},
+		{
+			name:  "given offer filled concurrently when saving accepted request then returns offer full",
+			input: validInput,
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				offerWithCapacity := pendingOffer
+				offerWithCapacity.Capacity = 2
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.Anything,
 				).Return([]domainreq.Entity{pendingRequest}, nil)
+
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.Anything,
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{offerWithCapacity}, Total: 1}, nil)
+
+				reqRepo.On("SaveWithOffer",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.Anything,
+					mock.Anything,
+					mock.Anything,
+				).Return(fmt.Errorf("match offer offer-1: %w", domainoffer.ErrOfferFull))
 			},
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
-				assert.NoError(t, err)
-				assert.NotNil(t, result)
-				assert.Equal(t, domainreq.StatusAccepted, result.Status)
+				assert.Nil(t, result)
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.MatchOfferFullErrorCode, appErr.Code)
+			},
+		},
+		{
+			name:  "given offer modified concurrently when saving accepted request then returns concurrent modification",
+			input: validInput,
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.Anything,
+				).Return([]domainreq.Entity{pendingRequest}, nil)
+
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.Anything,
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
+
+				reqRepo.On("SaveWithOffer",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.Anything,
+					mock.Anything,
+					mock.Anything,
+				).Return(fmt.Errorf("match offer offer-1: %w", common.ErrVersionConflict))
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.Nil(t, result)
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.ConcurrentModificationErrorCode, appErr.Code)
 			},
 		},
 	}
@@ -348,10 +531,9 @@ func TestAcceptMatchRequestUC_Invoke(t *testing.T) {
 
 			reqRepo := reqmocks.NewRepository(t)
 			offerRepo := offermocks.NewRepository(t)
-			publisher := eventmocks.NewPublisher[matchofferevent.MatchOfferCapacityReachedEvent](t)
-			uc := usecases.NewAcceptMatchRequestUC(reqRepo, offerRepo, publisher)
+			uc := usecases.NewAcceptMatchRequestUC(reqRepo, offerRepo)
 
-			tc.on(t, reqRepo, offerRepo, publisher)
+			tc.on(t, reqRepo, offerRepo)
 
 			result, err := uc.Invoke(ctx, tc.input)
 
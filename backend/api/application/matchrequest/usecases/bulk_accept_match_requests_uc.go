@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/pkg/log"
+)
+
+type BulkAcceptMatchRequestsInput struct {
+	OwnerAccountID string
+	IDs            []string // accepted in this order, so the first ones get the spots left
+}
+
+// BulkAcceptMatchRequestsUC accepts several requests of the owner at once. Every request
+// claims its spot like AcceptMatchRequestUC does, in its own transaction with the offer
+// counters, so capacity holds even against concurrent accepts. A request that cannot be
+// accepted does not stop the others; each one gets its own result.
+type BulkAcceptMatchRequestsUC struct {
+	matchRequestRepository matchrequest.Repository
+	matchOfferRepository   matchoffer.Repository
+}
+
+func NewBulkAcceptMatchRequestsUC(
+	matchRequestRepository matchrequest.Repository,
+	matchOfferRepository matchoffer.Repository,
+) *BulkAcceptMatchRequestsUC {
+	return &BulkAcceptMatchRequestsUC{
+		matchRequestRepository: matchRequestRepository,
+		matchOfferRepository:   matchOfferRepository,
+	}
+}
+
+func (uc *BulkAcceptMatchRequestsUC) Invoke(ctx context.Context, input BulkAcceptMatchRequestsInput) (*[]BulkItemResult, error) {
+	ids := uniqueIDs(input.IDs)
+	if len(ids) == 0 || len(ids) > MaxBulkSize {
+		return nil, errors.RequestValidationFailed(fmt.Sprintf("between 1 and %d match requests can be accepted at once", MaxBulkSize))
+	}
+
+	owned, failed, err := findOwned(ctx, uc.matchRequestRepository, ids, input.OwnerAccountID)
+	if err != nil {
+		log.GetLogger(ctx).Error("failed to get match requests to accept", err)
+		return nil, err
+	}
+
+	// offers as they stand after the acceptances made so far
+	offers := make(map[string]matchoffer.Entity)
+	results := make([]BulkItemResult, 0, len(ids))
+	for _, id := range ids {
+		if err, ok := failed[id]; ok {
+			results = append(results, BulkItemResult{MatchRequestID: id, Err: err})
+			continue
+		}
+		accepted, err := uc.accept(ctx, owned[id], offers)
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to accept match request %s", id), err)
+			results = append(results, BulkItemResult{MatchRequestID: id, Err: err})
+			continue
+		}
+		results = append(results, BulkItemResult{MatchRequestID: id, Request: accepted})
+	}
+	return &results, nil
+}
+
+func (uc *BulkAcceptMatchRequestsUC) accept(ctx context.Context, request matchrequest.Entity, offers map[string]matchoffer.Entity) (*matchrequest.Entity, error) {
+	accepted, err := request.Accept(common.ActorOwner)
+	if err != nil {
+		return nil, errors.InvalidTransition(err.Error())
+	}
+
+	offer, ok := offers[request.MatchOfferID]
+	if !ok {
+		found, err := getMatchOffer(ctx, uc.matchOfferRepository, request.MatchOfferID)
+		if err != nil {
+			return nil, err
+		}
+		offer = *found
+	}
+	if !offer.IsPending() {
+		return nil, errors.UseCaseExecutionFailed("match offer is not pending")
+	}
+
+	claimed, err := offer.ClaimSpot(request.Position)
+	if err != nil {
+		return nil, errors.MatchOfferFull(err.Error())
+	}
+
+	messages, err := acceptanceMessages(ctx, accepted, claimed)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = uc.matchRequestRepository.SaveWithOffer(ctx, accepted, claimed, messages); err != nil {
+		// the stored offer is not the one held here anymore; the next request reads it again
+		delete(offers, request.MatchOfferID)
+		return nil, mapAcceptanceError(err)
+	}
+
+	claimed.Version++
+	offers[request.MatchOfferID] = claimed
+	accepted.Version++
+	return &accepted, nil
+}
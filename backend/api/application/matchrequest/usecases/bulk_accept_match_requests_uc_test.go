@@ -0,0 +1,182 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "sportlink/api/application/errors"
+	"sportlink/api/application/matchrequest/usecases"
+	"sportlink/api/domain/common"
+	domainoffer "sportlink/api/domain/matchoffer"
+	domainreq "sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	offermocks "sportlink/mocks/api/domain/matchoffer"
+	reqmocks "sportlink/mocks/api/domain/matchrequest"
+)
+
+func TestBulkAcceptMatchRequestsUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	fixedDay := time.Date(2030, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	pendingRequest := func(requester string) domainreq.Entity {
+		return domainreq.Entity{
+			ID:                 domainreq.GenerateMatchRequestID(requester, "offer-1"),
+			MatchOfferID:       "offer-1",
+			OwnerAccountID:     "owner-1",
+			RequesterAccountID: requester,
+			Status:             domainreq.StatusPending,
+			CreatedAt:          fixedDay.Add(-48 * time.Hour),
+		}
+	}
+	first, second, third := pendingRequest("requester-1"), pendingRequest("requester-2"), pendingRequest("requester-3")
+
+	pendingOffer := domainoffer.Entity{
+		ID:    "offer-1",
+		Sport: common.Paddle,
+		Day:   fixedDay,
+		TimeSlot: domainoffer.TimeSlot{
+			StartTime: fixedDay.Add(18 * time.Hour),
+			EndTime:   fixedDay.Add(20 * time.Hour),
+		},
+		Status:         domainoffer.StatusPending,
+		OwnerAccountID: "owner-1",
+		Capacity:       3, // owner + 2 requesters
+	}
+
+	isCtx := mock.MatchedBy(func(c context.Context) bool { return c == ctx })
+	isOffer := mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+		return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+	})
+	anyMessages := mock.MatchedBy(func(messages []outbox.Message) bool { return len(messages) > 0 })
+	isAcceptedRequest := func(id string) interface{} {
+		return mock.MatchedBy(func(r domainreq.Entity) bool {
+			return r.ID == id && r.Status == domainreq.StatusAccepted
+		})
+	}
+	isOfferAt := func(acceptedCount, version int) interface{} {
+		return mock.MatchedBy(func(o domainoffer.Entity) bool {
+			return o.ID == "offer-1" && o.AcceptedCount == acceptedCount && o.Version == version
+		})
+	}
+
+	testCases := []struct {
+		name  string
+		input usecases.BulkAcceptMatchRequestsInput
+		on    func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository)
+		then  func(t *testing.T, results *[]usecases.BulkItemResult, err error)
+	}{
+		{
+			name: "given more requests than open spots when accepting them in bulk then they are accepted in order until the offer is full",
+			input: usecases.BulkAcceptMatchRequestsInput{
+				OwnerAccountID: "owner-1",
+				IDs:            []string{first.ID, second.ID, third.ID},
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				reqRepo.On("FindByIDs", isCtx, []string{first.ID, second.ID, third.ID}).
+					Return([]domainreq.Entity{third, first, second}, nil)
+				offerRepo.On("Find", isCtx, isOffer).
+					Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil).Once()
+				reqRepo.On("SaveWithOffer", isCtx, isAcceptedRequest(first.ID), isOfferAt(1, 0), anyMessages).Return(nil).Once()
+				reqRepo.On("SaveWithOffer", isCtx, isAcceptedRequest(second.ID), isOfferAt(2, 1), anyMessages).Return(nil).Once()
+			},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, *results, 3)
+				assert.True(t, (*results)[0].Succeeded())
+				assert.Equal(t, domainreq.StatusAccepted, (*results)[0].Request.Status)
+				assert.True(t, (*results)[1].Succeeded())
+				assert.Equal(t, third.ID, (*results)[2].MatchRequestID)
+				assert.Equal(t, apperrors.MatchOfferFull(domainoffer.ErrOfferFull.Error()), (*results)[2].Err)
+			},
+		},
+		{
+			name: "given requests that cannot be accepted when accepting them in bulk then each one fails on its own",
+			input: usecases.BulkAcceptMatchRequestsInput{
+				OwnerAccountID: "owner-1",
+				IDs:            []string{"missing", first.ID, second.ID, "missing"},
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				foreign := first
+				foreign.OwnerAccountID = "owner-2"
+				accepted := second
+				accepted.Status = domainreq.StatusAccepted
+				reqRepo.On("FindByIDs", isCtx, []string{"missing", first.ID, second.ID}).
+					Return([]domainreq.Entity{foreign, accepted}, nil)
+			},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, *results, 3)
+				assert.Equal(t, apperrors.NotFound("match request not found"), (*results)[0].Err)
+				assert.Equal(t, apperrors.Unauthorized("owner account ID does not match"), (*results)[1].Err)
+				assert.Equal(t, apperrors.InvalidTransition("match request cannot go from ACCEPTED to ACCEPTED by OWNER"), (*results)[2].Err)
+			},
+		},
+		{
+			name: "given the offer changed while accepting when accepting in bulk then that request fails and the next one reads the offer again",
+			input: usecases.BulkAcceptMatchRequestsInput{
+				OwnerAccountID: "owner-1",
+				IDs:            []string{first.ID, second.ID},
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				reqRepo.On("FindByIDs", isCtx, []string{first.ID, second.ID}).
+					Return([]domainreq.Entity{first, second}, nil)
+				offerRepo.On("Find", isCtx, isOffer).
+					Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil).Twice()
+				reqRepo.On("SaveWithOffer", isCtx, isAcceptedRequest(first.ID), isOfferAt(1, 0), anyMessages).
+					Return(fmt.Errorf("match offer offer-1: %w", common.ErrVersionConflict)).Once()
+				reqRepo.On("SaveWithOffer", isCtx, isAcceptedRequest(second.ID), isOfferAt(1, 0), anyMessages).Return(nil).Once()
+			},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, *results, 2)
+				assert.Equal(t, apperrors.ConcurrentModificationErrorCode, (*results)[0].Err.(apperrors.AppError).Code)
+				assert.True(t, (*results)[1].Succeeded())
+			},
+		},
+		{
+			name: "given the requests cannot be read when accepting in bulk then returns error",
+			input: usecases.BulkAcceptMatchRequestsInput{
+				OwnerAccountID: "owner-1",
+				IDs:            []string{first.ID},
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				reqRepo.On("FindByIDs", isCtx, []string{first.ID}).Return(nil, errors.New("db connection error"))
+			},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.EqualError(t, err, "db connection error")
+				assert.Nil(t, results)
+			},
+		},
+		{
+			name:  "given no request IDs when accepting in bulk then returns a validation error",
+			input: usecases.BulkAcceptMatchRequestsInput{OwnerAccountID: "owner-1"},
+			on:    func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.Equal(t, apperrors.RequestValidationFailedErrorCode, err.(apperrors.AppError).Code)
+				assert.Nil(t, results)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reqRepo := reqmocks.NewRepository(t)
+			offerRepo := offermocks.NewRepository(t)
+			uc := usecases.NewBulkAcceptMatchRequestsUC(reqRepo, offerRepo)
+
+			tc.on(t, reqRepo, offerRepo)
+
+			results, err := uc.Invoke(ctx, tc.input)
+
+			tc.then(t, results, err)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application/errors"
+	appevents "sportlink/api/application/events"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/pkg/log"
+	"strings"
+	"time"
+)
+
+// MaxRejectionReasonLength is the longest reason accepted, in characters.
+const MaxRejectionReasonLength = 280
+
+type BulkRejectMatchRequestsInput struct {
+	OwnerAccountID string
+	IDs            []string // requests to reject; ignored when MatchOfferID is set
+	MatchOfferID   string   // when set, every request still pending on the offer is rejected
+	Reason         string   // optional; shown to the requesters
+}
+
+// BulkRejectMatchRequestsUC rejects several requests of the owner at once, either by ID
+// or all the ones still pending on an offer. Each rejection is written on its own and
+// version-checked; the requests that cannot be rejected get a failed result without
+// stopping the others.
+type BulkRejectMatchRequestsUC struct {
+	matchRequestRepository matchrequest.Repository
+	matchOfferRepository   matchoffer.Repository
+}
+
+func NewBulkRejectMatchRequestsUC(
+	matchRequestRepository matchrequest.Repository,
+	matchOfferRepository matchoffer.Repository,
+) *BulkRejectMatchRequestsUC {
+	return &BulkRejectMatchRequestsUC{
+		matchRequestRepository: matchRequestRepository,
+		matchOfferRepository:   matchOfferRepository,
+	}
+}
+
+func (uc *BulkRejectMatchRequestsUC) Invoke(ctx context.Context, input BulkRejectMatchRequestsInput) (*[]BulkItemResult, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if len([]rune(reason)) > MaxRejectionReasonLength {
+		return nil, errors.RequestValidationFailed(fmt.Sprintf("rejection reason is longer than %d characters", MaxRejectionReasonLength))
+	}
+
+	ids, owned, failed, err := uc.candidates(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BulkItemResult, 0, len(ids))
+	for _, id := range ids {
+		request, ok := owned[id]
+		if !ok {
+			results = append(results, BulkItemResult{MatchRequestID: id, Err: failed[id]})
+			continue
+		}
+		rejected, err := uc.reject(ctx, request, reason)
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to reject match request %s", id), err)
+			results = append(results, BulkItemResult{MatchRequestID: id, Err: err})
+			continue
+		}
+		results = append(results, BulkItemResult{MatchRequestID: id, Request: rejected})
+	}
+	return &results, nil
+}
+
+// reject writes the rejection only while the request is still at the version it was read
+// at, so a request accepted or waitlisted meanwhile keeps its status and its spot. The
+// requester is told in the same write.
+func (uc *BulkRejectMatchRequestsUC) reject(ctx context.Context, request matchrequest.Entity, reason string) (*matchrequest.Entity, error) {
+	rejected, err := request.Reject(reason)
+	if err != nil {
+		return nil, errors.InvalidTransition(err.Error())
+	}
+	messages, err := appevents.NewMessages(ctx, time.Now(), buildStatusChangedEvent(rejected, matchrequest.StatusRejected))
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.matchRequestRepository.Save(ctx, rejected, messages); err != nil {
+		return nil, mapAcceptanceError(err)
+	}
+
+	rejected.Version++
+	return &rejected, nil
+}
+
+// candidates returns the requests to reject in order, the ones among them the owner
+// may answer and why the others cannot be rejected. Only the owner of an offer may reject all of its
+// pending requests.
+func (uc *BulkRejectMatchRequestsUC) candidates(
+	ctx context.Context,
+	input BulkRejectMatchRequestsInput,
+) ([]string, map[string]matchrequest.Entity, map[string]error, error) {
+	if input.MatchOfferID == "" {
+		ids := uniqueIDs(input.IDs)
+		if len(ids) == 0 || len(ids) > MaxBulkSize {
+			return nil, nil, nil, errors.RequestValidationFailed(fmt.Sprintf("between 1 and %d match requests can be rejected at once", MaxBulkSize))
+		}
+		owned, failed, err := findOwned(ctx, uc.matchRequestRepository, ids, input.OwnerAccountID)
+		if err != nil {
+			log.GetLogger(ctx).Error("failed to get match requests to reject", err)
+			return nil, nil, nil, err
+		}
+		return ids, owned, failed, nil
+	}
+
+	offer, err := getMatchOffer(ctx, uc.matchOfferRepository, input.MatchOfferID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match offer %s", input.MatchOfferID), err)
+		return nil, nil, nil, err
+	}
+	if offer.OwnerAccountID != input.OwnerAccountID {
+		return nil, nil, nil, errors.Unauthorized("owner account ID does not match")
+	}
+
+	requests, err := uc.matchRequestRepository.Find(ctx, matchrequest.DomainQuery{
+		MatchOfferIDs: []string{input.MatchOfferID},
+		Statuses:      []matchrequest.Status{matchrequest.StatusPending},
+	})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get pending match requests of offer %s", input.MatchOfferID), err)
+		return nil, nil, nil, err
+	}
+	ids := make([]string, len(requests))
+	owned := make(map[string]matchrequest.Entity, len(requests))
+	for i, request := range requests {
+		ids[i] = request.ID
+		owned[request.ID] = request
+	}
+	return ids, owned, map[string]error{}, nil
+}
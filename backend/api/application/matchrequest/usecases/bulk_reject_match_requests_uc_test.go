@@ -0,0 +1,203 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "sportlink/api/application/errors"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
+	"sportlink/api/application/matchrequest/usecases"
+	"sportlink/api/domain/common"
+	domainoffer "sportlink/api/domain/matchoffer"
+	domainreq "sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	offermocks "sportlink/mocks/api/domain/matchoffer"
+	reqmocks "sportlink/mocks/api/domain/matchrequest"
+)
+
+func TestBulkRejectMatchRequestsUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2030, 5, 8, 0, 0, 0, 0, time.UTC)
+
+	pendingRequest := func(requester string) domainreq.Entity {
+		return domainreq.Entity{
+			ID:                 domainreq.GenerateMatchRequestID(requester, "offer-1"),
+			MatchOfferID:       "offer-1",
+			OwnerAccountID:     "owner-1",
+			RequesterAccountID: requester,
+			Status:             domainreq.StatusPending,
+			CreatedAt:          createdAt,
+		}
+	}
+	first, second := pendingRequest("requester-1"), pendingRequest("requester-2")
+	rejected := func(request domainreq.Entity, reason string) domainreq.Entity {
+		request.Status = domainreq.StatusRejected
+		request.RejectionReason = reason
+		request.Changes = []common.StatusChange{
+			{From: "PENDING", To: "REJECTED", Actor: common.ActorOwner, Reason: reason},
+		}
+		return request
+	}
+
+	isCtx := mock.MatchedBy(func(c context.Context) bool { return c == ctx })
+	isOffer := mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+		return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+	})
+	rejectedMessage := func(request domainreq.Entity, reason string) interface{} {
+		want := matchrequestevent.MatchRequestRejectedEvent{
+			MatchRequestID:     request.ID,
+			MatchOfferID:       "offer-1",
+			RequesterAccountID: request.RequesterAccountID,
+			OwnerAccountID:     "owner-1",
+			Reason:             reason,
+		}
+		return mock.MatchedBy(func(messages []outbox.Message) bool {
+			var got matchrequestevent.MatchRequestRejectedEvent
+			return len(messages) == 1 && messages[0].Type == matchrequestevent.MatchRequestRejectedEventType &&
+				messages[0].Decode(&got) == nil && got == want
+		})
+	}
+
+	testCases := []struct {
+		name  string
+		input usecases.BulkRejectMatchRequestsInput
+		on    func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository)
+		then  func(t *testing.T, results *[]usecases.BulkItemResult, err error)
+	}{
+		{
+			name: "given pending requests and a reason when rejecting them in bulk then each is saved and its requester is told why",
+			input: usecases.BulkRejectMatchRequestsInput{
+				OwnerAccountID: "owner-1",
+				IDs:            []string{first.ID, second.ID},
+				Reason:         "  we found a full team  ",
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				reqRepo.On("FindByIDs", isCtx, []string{first.ID, second.ID}).
+					Return([]domainreq.Entity{first, second}, nil)
+				reqRepo.On("Save", isCtx, rejected(first, "we found a full team"), rejectedMessage(first, "we found a full team")).Return(nil)
+				reqRepo.On("Save", isCtx, rejected(second, "we found a full team"), rejectedMessage(second, "we found a full team")).Return(nil)
+			},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, *results, 2)
+				for _, result := range *results {
+					assert.True(t, result.Succeeded())
+					assert.Equal(t, domainreq.StatusRejected, result.Request.Status)
+					assert.Equal(t, "we found a full team", result.Request.RejectionReason)
+				}
+			},
+		},
+		{
+			name: "given a request that is no longer pending when rejecting in bulk then only the others are rejected",
+			input: usecases.BulkRejectMatchRequestsInput{
+				OwnerAccountID: "owner-1",
+				IDs:            []string{first.ID, second.ID},
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				cancelled := first
+				cancelled.Status = domainreq.StatusCancel
+				reqRepo.On("FindByIDs", isCtx, []string{first.ID, second.ID}).
+					Return([]domainreq.Entity{cancelled, second}, nil)
+				reqRepo.On("Save", isCtx, rejected(second, ""), rejectedMessage(second, "")).Return(nil)
+			},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, apperrors.InvalidTransition("match request cannot go from CANCEL to REJECTED by OWNER"), (*results)[0].Err)
+				assert.True(t, (*results)[1].Succeeded())
+			},
+		},
+		{
+			name: "given an offer with pending requests when its owner rejects the rest then every pending request is rejected",
+			input: usecases.BulkRejectMatchRequestsInput{
+				OwnerAccountID: "owner-1",
+				MatchOfferID:   "offer-1",
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				offerRepo.On("Find", isCtx, isOffer).Return(domainoffer.Page{
+					Entities: []domainoffer.Entity{{ID: "offer-1", OwnerAccountID: "owner-1"}}, Total: 1,
+				}, nil)
+				reqRepo.On("Find", isCtx, domainreq.DomainQuery{
+					MatchOfferIDs: []string{"offer-1"},
+					Statuses:      []domainreq.Status{domainreq.StatusPending},
+				}).Return([]domainreq.Entity{first, second}, nil)
+				reqRepo.On("Save", isCtx, rejected(first, ""), rejectedMessage(first, "")).Return(nil)
+				reqRepo.On("Save", isCtx, rejected(second, ""), rejectedMessage(second, "")).Return(nil)
+			},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, *results, 2)
+			},
+		},
+		{
+			name: "given an offer of someone else when rejecting its pending requests then returns unauthorized",
+			input: usecases.BulkRejectMatchRequestsInput{
+				OwnerAccountID: "owner-2",
+				MatchOfferID:   "offer-1",
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				offerRepo.On("Find", isCtx, isOffer).Return(domainoffer.Page{
+					Entities: []domainoffer.Entity{{ID: "offer-1", OwnerAccountID: "owner-1"}}, Total: 1,
+				}, nil)
+			},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.Equal(t, apperrors.Unauthorized("owner account ID does not match"), err)
+				assert.Nil(t, results)
+			},
+		},
+		{
+			name: "given a request that changed since it was read when rejecting in bulk then it fails with concurrent modification and the others are rejected",
+			input: usecases.BulkRejectMatchRequestsInput{
+				OwnerAccountID: "owner-1",
+				IDs:            []string{first.ID, second.ID},
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				reqRepo.On("FindByIDs", isCtx, []string{first.ID, second.ID}).Return([]domainreq.Entity{first, second}, nil)
+				reqRepo.On("Save", isCtx, rejected(first, ""), mock.Anything).Return(common.ErrVersionConflict)
+				reqRepo.On("Save", isCtx, rejected(second, ""), rejectedMessage(second, "")).Return(nil)
+			},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, apperrors.ConcurrentModification(common.ErrVersionConflict.Error()), (*results)[0].Err)
+				assert.True(t, (*results)[1].Succeeded())
+				assert.Equal(t, 1, (*results)[1].Request.Version)
+			},
+		},
+		{
+			name: "given the rejection cannot be saved when rejecting in bulk then the request fails and nobody is told",
+			input: usecases.BulkRejectMatchRequestsInput{
+				OwnerAccountID: "owner-1",
+				IDs:            []string{first.ID},
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				reqRepo.On("FindByIDs", isCtx, []string{first.ID}).Return([]domainreq.Entity{first}, nil)
+				reqRepo.On("Save", isCtx, rejected(first, ""), mock.Anything).Return(errors.New("db connection error"))
+			},
+			then: func(t *testing.T, results *[]usecases.BulkItemResult, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, *results, 1)
+				assert.EqualError(t, (*results)[0].Err, "db connection error")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reqRepo := reqmocks.NewRepository(t)
+			offerRepo := offermocks.NewRepository(t)
+			uc := usecases.NewBulkRejectMatchRequestsUC(reqRepo, offerRepo)
+
+			tc.on(t, reqRepo, offerRepo)
+
+			results, err := uc.Invoke(ctx, tc.input)
+
+			tc.then(t, results, err)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/matchrequest"
+)
+
+// MaxBulkSize is the most match requests a single bulk operation handles.
+const MaxBulkSize = 50
+
+// BulkItemResult is the outcome of a bulk operation for one match request. Err is nil
+// when the request was updated; Request then holds it as stored.
+type BulkItemResult struct {
+	MatchRequestID string
+	Request        *matchrequest.Entity
+	Err            error
+}
+
+func (r BulkItemResult) Succeeded() bool {
+	return r.Err == nil
+}
+
+// uniqueIDs drops repeated IDs, keeping the order in which they were first given.
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// findOwned loads the requests by ID and checks each one belongs to an offer of the
+// owner. The requests that do are returned by ID; the others get a failed result.
+func findOwned(
+	ctx context.Context,
+	repo matchrequest.Repository,
+	ids []string,
+	ownerAccountID string,
+) (map[string]matchrequest.Entity, map[string]error, error) {
+	found, err := repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[string]matchrequest.Entity, len(found))
+	for _, request := range found {
+		byID[request.ID] = request
+	}
+
+	owned := make(map[string]matchrequest.Entity, len(ids))
+	failed := make(map[string]error)
+	for _, id := range ids {
+		request, ok := byID[id]
+		switch {
+		case !ok:
+			failed[id] = errors.NotFound("match request not found")
+		case request.OwnerAccountID != ownerAccountID:
+			failed[id] = errors.Unauthorized("owner account ID does not match")
+		default:
+			owned[id] = request
+		}
+	}
+	return owned, failed, nil
+}
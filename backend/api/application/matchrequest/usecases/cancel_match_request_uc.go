@@ -2,11 +2,20 @@ package usecases
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"sort"
 	"sportlink/api/application/errors"
+	appevents "sportlink/api/application/events"
+	matchservice "sportlink/api/application/match/service"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/match"
 	"sportlink/api/domain/matchoffer"
 	"sportlink/api/domain/matchrequest"
 	"sportlink/pkg/log"
+	"sportlink/pkg/slices"
+	"time"
 )
 
 type CancelMatchRequestInput struct {
@@ -17,15 +26,21 @@ type CancelMatchRequestInput struct {
 type CancelMatchRequestUC struct {
 	matchRequestRepository matchrequest.Repository
 	matchOfferRepository   matchoffer.Repository
+	matchRepository        match.Repository
+	paymentProvider        matchservice.PaymentProvider
 }
 
 func NewCancelMatchRequestUC(
 	matchRequestRepository matchrequest.Repository,
 	matchOfferRepository matchoffer.Repository,
+	matchRepository match.Repository,
+	paymentProvider matchservice.PaymentProvider,
 ) *CancelMatchRequestUC {
 	return &CancelMatchRequestUC{
 		matchRequestRepository: matchRequestRepository,
 		matchOfferRepository:   matchOfferRepository,
+		matchRepository:        matchRepository,
+		paymentProvider:        paymentProvider,
 	}
 }
 
@@ -35,10 +50,15 @@ func (uc *CancelMatchRequestUC) Invoke(ctx context.Context, input CancelMatchReq
 		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match request %s", input.MatchRequestId), err)
 		return nil, err
 	}
+	actor, err := cancellingActor(*matchReq, input.RequesterAccountID)
+	if err != nil {
+		return nil, err
+	}
 
-	if matchReq.IsRejected() {
-		log.GetLogger(ctx).Error(fmt.Sprintf("match request %s is already rejected, status: %s", input.MatchRequestId, matchReq.Status), err)
-		return nil, errors.UseCaseExecutionFailed("match request is already rejected")
+	canceled, err := matchReq.Cancel(actor)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("match request %s cannot be cancelled", input.MatchRequestId), err)
+		return nil, errors.InvalidTransition(err.Error())
 	}
 
 	matchOffer, err := getMatchOffer(ctx, uc.matchOfferRepository, matchReq.MatchOfferID)
@@ -48,13 +68,182 @@ func (uc *CancelMatchRequestUC) Invoke(ctx context.Context, input CancelMatchReq
 	}
 
 	if matchOffer.IsConfirm() {
-		log.GetLogger(ctx).Error(fmt.Sprintf("match offer %s is already confirmed, status: %s", matchReq.MatchOfferID, matchOffer.Status), err)
-		return nil, errors.UseCaseExecutionFailed("match offer is already confirmed")
+		return uc.cancelFromConfirmedOffer(ctx, *matchReq, canceled, *matchOffer)
 	}
 
-	canceled := matchReq.Cancel()
-	if err = uc.matchRequestRepository.Save(ctx, canceled); err != nil {
+	messages, err := appevents.NewMessages(ctx, time.Now(), cancelledEvent(canceled))
+	if err != nil {
+		return nil, fmt.Errorf("error while building cancelled event: %w", err)
+	}
+	if matchReq.IsAccepted() {
+		// the spot the request held goes back to the offer in the same write
+		err = uc.matchRequestRepository.SaveWithOffer(ctx, canceled, matchOffer.ReleaseSpot(matchReq.Position), messages)
+	} else {
+		err = uc.matchRequestRepository.Save(ctx, canceled, messages)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("error while cancelling match request: %w", err)
 	}
 	return &canceled, nil
 }
+
+// cancelFromConfirmedOffer handles cancellations once the match exists. A waitlisted
+// requester may always leave the waitlist; an accepted participant may drop out
+// before kickoff, in which case the next waitlisted request takes the spot.
+func (uc *CancelMatchRequestUC) cancelFromConfirmedOffer(
+	ctx context.Context,
+	matchReq matchrequest.Entity,
+	canceled matchrequest.Entity,
+	offer matchoffer.Entity,
+) (*matchrequest.Entity, error) {
+	if !matchReq.IsWaitlisted() && !matchReq.IsAccepted() {
+		err := errors.UseCaseExecutionFailed("match offer is already confirmed")
+		log.GetLogger(ctx).Error(fmt.Sprintf("match offer %s is already confirmed, status: %s", offer.ID, offer.Status), err)
+		return nil, err
+	}
+
+	if matchReq.IsAccepted() && offer.HasStarted(time.Now()) {
+		err := errors.UseCaseExecutionFailed("match has already started")
+		log.GetLogger(ctx).Error(fmt.Sprintf("match for offer %s has already started", offer.ID), err)
+		return nil, err
+	}
+
+	if matchReq.IsWaitlisted() {
+		messages, err := appevents.NewMessages(ctx, time.Now(), cancelledEvent(canceled))
+		if err != nil {
+			return nil, fmt.Errorf("error while building cancelled event: %w", err)
+		}
+		if err = uc.matchRequestRepository.Save(ctx, canceled, messages); err != nil {
+			return nil, fmt.Errorf("error while cancelling match request: %w", err)
+		}
+		return &canceled, nil
+	}
+
+	if err := uc.dropOut(ctx, offer, canceled); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to drop %s out of offer %s", canceled.RequesterAccountID, offer.ID), err)
+		if stderrors.Is(err, common.ErrVersionConflict) {
+			return nil, errors.ConcurrentModification(err.Error())
+		}
+		return nil, err
+	}
+
+	return &canceled, nil
+}
+
+// cancellingActor tells whether the account cancelling the request is its requester or
+// the owner of its offer, leaving the transition table to decide what each may do. Any
+// other account is refused.
+func cancellingActor(request matchrequest.Entity, accountID string) (common.Actor, error) {
+	switch accountID {
+	case request.RequesterAccountID:
+		return common.ActorRequester, nil
+	case request.OwnerAccountID:
+		return common.ActorOwner, nil
+	default:
+		return "", errors.Unauthorized("requester account ID does not match")
+	}
+}
+
+func cancelledEvent(canceled matchrequest.Entity) appevents.Event {
+	return matchrequestevent.MatchRequestCancelledEvent{
+		MatchRequestID:     canceled.ID,
+		MatchOfferID:       canceled.MatchOfferID,
+		RequesterAccountID: canceled.RequesterAccountID,
+		OwnerAccountID:     canceled.OwnerAccountID,
+	}
+}
+
+// dropOut cancels an accepted request of a confirmed offer. The oldest waitlisted request
+// that fits the freed spot takes it; when nobody is waitlisted the participant is just removed. The
+// cancellation, the promotion, the new participants of the match and their events are written
+// together, and the share the participant already paid, if any, is then refunded.
+func (uc *CancelMatchRequestUC) dropOut(ctx context.Context, offer matchoffer.Entity, canceled matchrequest.Entity) error {
+	leavingAccountID := canceled.RequesterAccountID
+	confirmedMatch, err := uc.findMatchForOffer(ctx, leavingAccountID, offer.ID)
+	if err != nil {
+		return err
+	}
+
+	next, err := uc.nextWaitlisted(ctx, offer, canceled.Position)
+	if err != nil {
+		return err
+	}
+
+	if next == nil {
+		updatedMatch := confirmedMatch.RemoveParticipant(leavingAccountID)
+		messages, err := appevents.NewMessages(ctx, time.Now(), cancelledEvent(canceled))
+		if err != nil {
+			return err
+		}
+		if err = uc.matchRepository.RemoveParticipant(ctx, updatedMatch, leavingAccountID, []matchrequest.Entity{canceled}, messages); err != nil {
+			return fmt.Errorf("failed to remove %s from match %s: %w", leavingAccountID, updatedMatch.ID, err)
+		}
+		matchservice.PayOutRefunds(ctx, uc.paymentProvider, *confirmedMatch, updatedMatch)
+		return nil
+	}
+
+	promoted, err := next.Accept(common.ActorSystem)
+	if err != nil {
+		return err
+	}
+
+	updatedMatch := confirmedMatch.ReplaceParticipant(leavingAccountID, promoted.RequesterAccountID)
+	messages, err := appevents.NewMessages(ctx, time.Now(), cancelledEvent(canceled), matchrequestevent.MatchRequestPromotedEvent{
+		MatchRequestID:     promoted.ID,
+		MatchOfferID:       offer.ID,
+		MatchID:            updatedMatch.ID,
+		RequesterAccountID: promoted.RequesterAccountID,
+		OwnerAccountID:     promoted.OwnerAccountID,
+	})
+	if err != nil {
+		return err
+	}
+	if err = uc.matchRepository.RemoveParticipant(ctx, updatedMatch, leavingAccountID, []matchrequest.Entity{canceled, promoted}, messages); err != nil {
+		return fmt.Errorf("failed to replace %s in match %s: %w", leavingAccountID, updatedMatch.ID, err)
+	}
+	matchservice.PayOutRefunds(ctx, uc.paymentProvider, *confirmedMatch, updatedMatch)
+
+	return nil
+}
+
+func (uc *CancelMatchRequestUC) findMatchForOffer(ctx context.Context, accountID, matchOfferID string) (*match.Entity, error) {
+	matches, err := uc.matchRepository.Find(ctx, match.DomainQuery{
+		AccountID: accountID,
+		Statuses:  []match.Status{match.StatusAccepted},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find matches for account %s: %w", accountID, err)
+	}
+	forOffer := slices.Filter(matches, func(m match.Entity) bool {
+		return m.MatchOfferID == matchOfferID
+	})
+	if len(forOffer) == 0 {
+		return nil, errors.NotFound(fmt.Sprintf("match for offer %s not found", matchOfferID))
+	}
+	return &forOffer[0], nil
+}
+
+// nextWaitlisted returns the waitlisted request that arrived first among those fitting the
+// freed spot, or nil when none does. When the offer needs specific positions only requests
+// for the position that was freed fit; otherwise every waitlisted request does.
+func (uc *CancelMatchRequestUC) nextWaitlisted(ctx context.Context, offer matchoffer.Entity, freedPosition string) (*matchrequest.Entity, error) {
+	waitlisted, err := uc.matchRequestRepository.Find(ctx, matchrequest.DomainQuery{
+		MatchOfferIDs: []string{offer.ID},
+		Statuses:      []matchrequest.Status{matchrequest.StatusWaitlisted},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find waitlisted requests: %w", err)
+	}
+	if offer.PlayerNeeds.HasPositions() {
+		waitlisted = slices.Filter(waitlisted, func(r matchrequest.Entity) bool {
+			return r.Position == freedPosition
+		})
+	}
+	if len(waitlisted) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(waitlisted, func(i, j int) bool {
+		return waitlisted[i].CreatedAt.Before(waitlisted[j].CreatedAt)
+	})
+	return &waitlisted[0], nil
+}
@@ -3,16 +3,24 @@ package usecases_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	apperrors "sportlink/api/application/errors"
+	"sportlink/api/application/match/service"
+	reqevents "sportlink/api/application/matchrequest/events"
 	"sportlink/api/application/matchrequest/usecases"
 	"sportlink/api/domain/common"
+	domainmatch "sportlink/api/domain/match"
 	domainoffer "sportlink/api/domain/matchoffer"
 	domainreq "sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	servicemocks "sportlink/mocks/api/application/match/service"
+	matchmocks "sportlink/mocks/api/domain/match"
 	offermocks "sportlink/mocks/api/domain/matchoffer"
 	reqmocks "sportlink/mocks/api/domain/matchrequest"
 )
@@ -20,8 +28,8 @@ import (
 func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 	ctx := context.Background()
 
-	fixedDay := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
-	fixedNow := time.Date(2026, 4, 11, 12, 0, 0, 0, time.UTC)
+	fixedDay := time.Date(2030, 5, 10, 0, 0, 0, 0, time.UTC)
+	fixedNow := time.Date(2030, 4, 11, 12, 0, 0, 0, time.UTC)
 
 	pendingRequest := domainreq.Entity{
 		ID:                 "AccountId#requester-1#MatchOfferId#offer-1",
@@ -45,21 +53,77 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 		OwnerAccountID: "owner-1",
 	}
 
+	confirmedOffer := pendingOffer
+	confirmedOffer.Status = domainoffer.StatusConfirmed
+
+	acceptedRequest := pendingRequest
+	acceptedRequest.Status = domainreq.StatusAccepted
+
+	confirmedMatch := domainmatch.Entity{
+		ID:           "match-1",
+		MatchOfferID: "offer-1",
+		Participants: []string{"owner-1", "requester-1", "requester-2"},
+		Sport:        common.Paddle,
+		Day:          fixedDay,
+		Status:       domainmatch.StatusAccepted,
+	}
+
+	firstWaitlisted := domainreq.Entity{
+		ID:                 "AccountId#requester-3#MatchOfferId#offer-1",
+		MatchOfferID:       "offer-1",
+		OwnerAccountID:     "owner-1",
+		RequesterAccountID: "requester-3",
+		Status:             domainreq.StatusWaitlisted,
+		CreatedAt:          fixedNow.Add(time.Hour),
+	}
+	secondWaitlisted := domainreq.Entity{
+		ID:                 "AccountId#requester-4#MatchOfferId#offer-1",
+		MatchOfferID:       "offer-1",
+		OwnerAccountID:     "owner-1",
+		RequesterAccountID: "requester-4",
+		Status:             domainreq.StatusWaitlisted,
+		CreatedAt:          fixedNow.Add(2 * time.Hour),
+	}
+
+	positionedOffer := confirmedOffer
+	positionedOffer.PlayerNeeds = domainoffer.PlayerNeeds{
+		Committed:   1,
+		SpotsNeeded: 2,
+		Positions:   []domainoffer.PositionNeed{{Position: "GOALKEEPER", Spots: 1}, {Position: "DEFENDER", Spots: 1}},
+	}
+	acceptedGoalkeeper := acceptedRequest
+	acceptedGoalkeeper.Position = "GOALKEEPER"
+	waitlistedDefender := firstWaitlisted
+	waitlistedDefender.Position = "DEFENDER"
+	waitlistedGoalkeeper := secondWaitlisted
+	waitlistedGoalkeeper.Position = "GOALKEEPER"
+
 	validInput := usecases.CancelMatchRequestInput{
 		MatchRequestId:     pendingRequest.ID,
 		RequesterAccountID: "requester-1",
 	}
 
+	isCancelledMessage := func(message outbox.Message, requestID string) bool {
+		var cancelled reqevents.MatchRequestCancelledEvent
+		return message.Type == reqevents.MatchRequestCancelledEventType && message.Decode(&cancelled) == nil &&
+			cancelled.MatchRequestID == requestID && cancelled.MatchOfferID == "offer-1"
+	}
+	isCancelled := func(requestID string) interface{} {
+		return mock.MatchedBy(func(messages []outbox.Message) bool {
+			return len(messages) == 1 && isCancelledMessage(messages[0], requestID)
+		})
+	}
+
 	testCases := []struct {
 		name  string
 		input usecases.CancelMatchRequestInput
-		on    func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository)
+		on    func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository)
 		then  func(t *testing.T, result *domainreq.Entity, err error)
 	}{
 		{
 			name:  "given pending request and pending offer when cancelling then saves cancelled request",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -79,6 +143,7 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 					mock.MatchedBy(func(r domainreq.Entity) bool {
 						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusCancel
 					}),
+					isCancelled(pendingRequest.ID),
 				).Return(nil)
 			},
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
@@ -91,7 +156,7 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 		{
 			name:  "given match request not found when cancelling then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -108,7 +173,7 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 		{
 			name:  "given repository error when finding request then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -122,10 +187,42 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 				assert.Contains(t, err.Error(), "db connection error")
 			},
 		},
+		{
+			name:  "given another account when cancelling then returns unauthorized without writing",
+			input: usecases.CancelMatchRequestInput{MatchRequestId: pendingRequest.ID, RequesterAccountID: "stranger-1"},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == pendingRequest.ID
+					}),
+				).Return([]domainreq.Entity{acceptedRequest}, nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.Nil(t, result)
+				assert.Equal(t, apperrors.Unauthorized("requester account ID does not match"), err)
+			},
+		},
+		{
+			name:  "given the offer owner when cancelling then refuses the transition without writing",
+			input: usecases.CancelMatchRequestInput{MatchRequestId: pendingRequest.ID, RequesterAccountID: "owner-1"},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == pendingRequest.ID
+					}),
+				).Return([]domainreq.Entity{pendingRequest}, nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.Nil(t, result)
+				assert.Equal(t, apperrors.InvalidTransition("match request cannot go from PENDING to CANCEL by OWNER"), err)
+			},
+		},
 		{
 			name:  "given already rejected request when cancelling then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
 				rejectedRequest := pendingRequest
 				rejectedRequest.Status = domainreq.StatusRejected
 				reqRepo.On("Find",
@@ -138,13 +235,13 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, result)
-				assert.Contains(t, err.Error(), "match request is already rejected")
+				assert.Equal(t, apperrors.InvalidTransition("match request cannot go from REJECTED to CANCEL by REQUESTER"), err)
 			},
 		},
 		{
-			name:  "given confirmed offer when cancelling request then returns error",
+			name:  "given confirmed offer when cancelling pending request then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -152,8 +249,6 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 					}),
 				).Return([]domainreq.Entity{pendingRequest}, nil)
 
-				confirmedOffer := pendingOffer
-				confirmedOffer.Status = domainoffer.StatusConfirmed
 				offerRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
@@ -170,7 +265,7 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 		{
 			name:  "given repository fails when saving cancelled request then returns error",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -190,6 +285,7 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 					mock.MatchedBy(func(r domainreq.Entity) bool {
 						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusCancel
 					}),
+					mock.Anything,
 				).Return(errors.New("save failed"))
 			},
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
@@ -199,11 +295,12 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 			},
 		},
 		{
-			name:  "given accepted request when cancelling then saves cancelled request",
+			name:  "given accepted request when cancelling then saves cancelled request and releases its spot on the offer",
 			input: validInput,
-			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
-				acceptedRequest := pendingRequest
-				acceptedRequest.Status = domainreq.StatusAccepted
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
+				offerWithOneAccepted := pendingOffer
+				offerWithOneAccepted.AcceptedCount = 1
+
 				reqRepo.On("Find",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
@@ -216,13 +313,302 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
 						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 					}),
-				).Return(domainoffer.Page{Entities: []domainoffer.Entity{pendingOffer}, Total: 1}, nil)
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{offerWithOneAccepted}, Total: 1}, nil)
 
-				reqRepo.On("Save",
+				reqRepo.On("SaveWithOffer",
 					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
 					mock.MatchedBy(func(r domainreq.Entity) bool {
 						return r.ID == pendingRequest.ID && r.Status == domainreq.StatusCancel
 					}),
+					mock.MatchedBy(func(o domainoffer.Entity) bool {
+						return o.ID == "offer-1" && o.AcceptedCount == 0
+					}),
+					isCancelled(pendingRequest.ID),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Equal(t, domainreq.StatusCancel, result.Status)
+			},
+		},
+		{
+			name:  "given confirmed offer when accepted participant cancels before kickoff then promotes the oldest waitlisted request",
+			input: validInput,
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == pendingRequest.ID
+					}),
+				).Return([]domainreq.Entity{acceptedRequest}, nil)
+
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{confirmedOffer}, Total: 1}, nil)
+
+				matchRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainmatch.DomainQuery) bool {
+						return q.AccountID == "requester-1"
+					}),
+				).Return([]domainmatch.Entity{confirmedMatch}, nil)
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.MatchOfferIDs) == 1 && q.MatchOfferIDs[0] == "offer-1" &&
+							len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusWaitlisted
+					}),
+				).Return([]domainreq.Entity{secondWaitlisted, firstWaitlisted}, nil)
+
+				matchRepo.On("RemoveParticipant",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m domainmatch.Entity) bool {
+						return m.ID == "match-1" && len(m.Participants) == 3 &&
+							m.Participants[1] == "requester-3" && m.Participants[2] == "requester-2"
+					}),
+					"requester-1",
+					mock.MatchedBy(func(requests []domainreq.Entity) bool {
+						return len(requests) == 2 &&
+							requests[0].ID == pendingRequest.ID && requests[0].Status == domainreq.StatusCancel &&
+							requests[1].ID == firstWaitlisted.ID && requests[1].Status == domainreq.StatusAccepted
+					}),
+					mock.MatchedBy(func(messages []outbox.Message) bool {
+						var promoted reqevents.MatchRequestPromotedEvent
+						return len(messages) == 2 && isCancelledMessage(messages[0], pendingRequest.ID) &&
+							messages[1].Type == reqevents.MatchRequestPromotedEventType && messages[1].Decode(&promoted) == nil &&
+							promoted == reqevents.MatchRequestPromotedEvent{
+								MatchRequestID:     firstWaitlisted.ID,
+								MatchOfferID:       "offer-1",
+								MatchID:            "match-1",
+								RequesterAccountID: "requester-3",
+								OwnerAccountID:     "owner-1",
+							}
+					}),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Equal(t, domainreq.StatusCancel, result.Status)
+			},
+		},
+		{
+			name:  "given confirmed offer needing positions when accepted goalkeeper cancels then promotes the oldest waitlisted goalkeeper",
+			input: validInput,
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == pendingRequest.ID
+					}),
+				).Return([]domainreq.Entity{acceptedGoalkeeper}, nil)
+
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{positionedOffer}, Total: 1}, nil)
+
+				matchRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainmatch.DomainQuery) bool {
+						return q.AccountID == "requester-1"
+					}),
+				).Return([]domainmatch.Entity{confirmedMatch}, nil)
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusWaitlisted
+					}),
+				).Return([]domainreq.Entity{waitlistedDefender, waitlistedGoalkeeper}, nil)
+
+				matchRepo.On("RemoveParticipant",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m domainmatch.Entity) bool {
+						return m.ID == "match-1" && m.Participants[1] == "requester-4"
+					}),
+					"requester-1",
+					mock.MatchedBy(func(requests []domainreq.Entity) bool {
+						return len(requests) == 2 &&
+							requests[1].ID == waitlistedGoalkeeper.ID && requests[1].Status == domainreq.StatusAccepted
+					}),
+					mock.MatchedBy(func(messages []outbox.Message) bool {
+						var promoted reqevents.MatchRequestPromotedEvent
+						return len(messages) == 2 && messages[1].Type == reqevents.MatchRequestPromotedEventType &&
+							messages[1].Decode(&promoted) == nil && promoted.MatchRequestID == waitlistedGoalkeeper.ID
+					}),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, domainreq.StatusCancel, result.Status)
+			},
+		},
+		{
+			name:  "given confirmed offer with empty waitlist when accepted participant cancels then removes participant from match",
+			input: validInput,
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == pendingRequest.ID
+					}),
+				).Return([]domainreq.Entity{acceptedRequest}, nil)
+
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{confirmedOffer}, Total: 1}, nil)
+
+				matchRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainmatch.DomainQuery) bool {
+						return q.AccountID == "requester-1"
+					}),
+				).Return([]domainmatch.Entity{confirmedMatch}, nil)
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusWaitlisted
+					}),
+				).Return([]domainreq.Entity{}, nil)
+
+				matchRepo.On("RemoveParticipant",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m domainmatch.Entity) bool {
+						return m.ID == "match-1" && len(m.Participants) == 2 &&
+							m.Participants[0] == "owner-1" && m.Participants[1] == "requester-2"
+					}),
+					"requester-1",
+					mock.MatchedBy(func(requests []domainreq.Entity) bool {
+						return len(requests) == 1 && requests[0].ID == pendingRequest.ID && requests[0].Status == domainreq.StatusCancel
+					}),
+					mock.MatchedBy(func(messages []outbox.Message) bool {
+						return len(messages) == 1 && isCancelledMessage(messages[0], pendingRequest.ID)
+					}),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Equal(t, domainreq.StatusCancel, result.Status)
+			},
+		},
+		{
+			name:  "given the match changed concurrently when accepted participant cancels then nothing is written and returns concurrent modification",
+			input: validInput,
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == pendingRequest.ID
+					}),
+				).Return([]domainreq.Entity{acceptedRequest}, nil)
+
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{confirmedOffer}, Total: 1}, nil)
+
+				matchRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainmatch.DomainQuery) bool {
+						return q.AccountID == "requester-1"
+					}),
+				).Return([]domainmatch.Entity{confirmedMatch}, nil)
+
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.Statuses) == 1 && q.Statuses[0] == domainreq.StatusWaitlisted
+					}),
+				).Return([]domainreq.Entity{}, nil)
+
+				matchRepo.On("RemoveParticipant",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m domainmatch.Entity) bool {
+						return m.ID == "match-1" && len(m.Participants) == 2 &&
+							m.Participants[0] == "owner-1" && m.Participants[1] == "requester-2"
+					}),
+					"requester-1",
+					mock.MatchedBy(func(requests []domainreq.Entity) bool {
+						return len(requests) == 1 && requests[0].ID == pendingRequest.ID && requests[0].Status == domainreq.StatusCancel
+					}),
+					mock.Anything,
+				).Return(fmt.Errorf("match match-1: %w", common.ErrVersionConflict))
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.ConcurrentModificationErrorCode, appErr.Code)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name:  "given confirmed offer that already kicked off when accepted participant cancels then returns error",
+			input: validInput,
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == pendingRequest.ID
+					}),
+				).Return([]domainreq.Entity{acceptedRequest}, nil)
+
+				startedOffer := confirmedOffer
+				startedOffer.TimeSlot = domainoffer.TimeSlot{
+					StartTime: time.Now().Add(-time.Hour),
+					EndTime:   time.Now().Add(time.Hour),
+				}
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{startedOffer}, Total: 1}, nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				assert.Contains(t, err.Error(), "match has already started")
+			},
+		},
+		{
+			name:  "given confirmed offer when waitlisted requester cancels then leaves the waitlist",
+			input: usecases.CancelMatchRequestInput{MatchRequestId: firstWaitlisted.ID, RequesterAccountID: "requester-3"},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository, matchRepo *matchmocks.Repository) {
+				reqRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == firstWaitlisted.ID
+					}),
+				).Return([]domainreq.Entity{firstWaitlisted}, nil)
+
+				offerRepo.On("Find",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
+						return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
+					}),
+				).Return(domainoffer.Page{Entities: []domainoffer.Entity{confirmedOffer}, Total: 1}, nil)
+
+				reqRepo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(r domainreq.Entity) bool {
+						return r.ID == firstWaitlisted.ID && r.Status == domainreq.StatusCancel
+					}),
+					isCancelled(firstWaitlisted.ID),
 				).Return(nil)
 			},
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
@@ -239,9 +625,10 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 
 			reqRepo := reqmocks.NewRepository(t)
 			offerRepo := offermocks.NewRepository(t)
-			uc := usecases.NewCancelMatchRequestUC(reqRepo, offerRepo)
+			matchRepo := matchmocks.NewRepository(t)
+			uc := usecases.NewCancelMatchRequestUC(reqRepo, offerRepo, matchRepo, servicemocks.NewPaymentProvider(t))
 
-			tc.on(t, reqRepo, offerRepo)
+			tc.on(t, reqRepo, offerRepo, matchRepo)
 
 			result, err := uc.Invoke(ctx, tc.input)
 
@@ -249,3 +636,55 @@ func TestCancelMatchRequestUC_Invoke(t *testing.T) {
 		})
 	}
 }
+
+func TestCancelMatchRequestUC_Invoke_RefundsPaidShare(t *testing.T) {
+	// given
+	ctx := context.Background()
+	day := time.Now().UTC().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	accepted := domainreq.Entity{
+		ID:                 "AccountId#requester-1#MatchOfferId#offer-1",
+		MatchOfferID:       "offer-1",
+		OwnerAccountID:     "owner-1",
+		RequesterAccountID: "requester-1",
+		Status:             domainreq.StatusAccepted,
+	}
+	offer := domainoffer.Entity{
+		ID:             "offer-1",
+		Day:            day,
+		TimeSlot:       domainoffer.TimeSlot{StartTime: day.Add(18 * time.Hour), EndTime: day.Add(20 * time.Hour)},
+		Status:         domainoffer.StatusConfirmed,
+		OwnerAccountID: "owner-1",
+	}
+	paidMatch, _ := domainmatch.Entity{
+		ID:           "match-1",
+		MatchOfferID: "offer-1",
+		Participants: []string{"owner-1", "requester-1", "requester-2"},
+		Status:       domainmatch.StatusAccepted,
+	}.SplitCost(3000, "ARS").MarkSharePaid("requester-1", "charge-1", day)
+
+	reqRepo := reqmocks.NewRepository(t)
+	offerRepo := offermocks.NewRepository(t)
+	matchRepo := matchmocks.NewRepository(t)
+	provider := servicemocks.NewPaymentProvider(t)
+
+	reqRepo.On("Find", mock.Anything, mock.MatchedBy(func(q domainreq.DomainQuery) bool { return len(q.IDs) == 1 })).
+		Return([]domainreq.Entity{accepted}, nil)
+	reqRepo.On("Find", mock.Anything, mock.MatchedBy(func(q domainreq.DomainQuery) bool { return len(q.Statuses) == 1 })).
+		Return([]domainreq.Entity{}, nil)
+	offerRepo.On("Find", mock.Anything, mock.Anything).Return(domainoffer.Page{Entities: []domainoffer.Entity{offer}, Total: 1}, nil)
+	matchRepo.On("Find", mock.Anything, mock.Anything).Return([]domainmatch.Entity{paidMatch}, nil)
+	matchRepo.On("RemoveParticipant", mock.Anything, mock.Anything, "requester-1", mock.Anything, mock.Anything).Return(nil)
+	provider.On("Refund",
+		mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+		service.RefundRequest{MatchID: "match-1", AccountID: "requester-1", Amount: 1000, Currency: "ARS", ChargeReference: "charge-1"},
+	).Return(&service.RefundReceipt{Reference: "refund-1"}, nil).Once()
+
+	uc := usecases.NewCancelMatchRequestUC(reqRepo, offerRepo, matchRepo, provider)
+
+	// when
+	result, err := uc.Invoke(ctx, usecases.CancelMatchRequestInput{MatchRequestId: accepted.ID, RequesterAccountID: "requester-1"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, domainreq.StatusCancel, result.Status)
+}
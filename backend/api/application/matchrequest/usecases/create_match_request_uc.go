@@ -2,28 +2,40 @@ package usecases
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"sportlink/api/application/errors"
+	appevents "sportlink/api/application/events"
+	"sportlink/api/application/matchoffer/service"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
 	"sportlink/api/domain/matchoffer"
 	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	"time"
 )
 
 type CreateMatchRequestInput struct {
 	MatchOfferID string
 	RequesterAccountID  string
+	Position            string // required when the offer needs specific positions
+	ShareToken          string // only needed for LINK offers the requester was not invited to
 }
 
 type CreateMatchRequestUC struct {
 	matchRequestRepository      matchrequest.Repository
 	matchOfferRepository matchoffer.Repository
+	visibilityPolicy     service.VisibilityPolicy
 }
 
 func NewCreateMatchRequestUC(
 	matchRequestRepository matchrequest.Repository,
 	matchOfferRepository matchoffer.Repository,
+	visibilityPolicy service.VisibilityPolicy,
 ) *CreateMatchRequestUC {
 	return &CreateMatchRequestUC{
 		matchRequestRepository:      matchRequestRepository,
 		matchOfferRepository: matchOfferRepository,
+		visibilityPolicy:     visibilityPolicy,
 	}
 }
 
@@ -45,15 +57,60 @@ func (uc *CreateMatchRequestUC) Invoke(ctx context.Context, input CreateMatchReq
 		return nil, fmt.Errorf("cannot send a match request to your own offer")
 	}
 
+	// Only accounts allowed to see the offer may ask to join it
+	canView, err := uc.visibilityPolicy.CanView(ctx, *offer, service.Viewer{
+		AccountID:  input.RequesterAccountID,
+		ShareToken: input.ShareToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while checking match offer visibility: %w", err)
+	}
+	if !canView {
+		return nil, fmt.Errorf("match offer '%s' not found", input.MatchOfferID)
+	}
+
+	if err := validatePosition(*offer, input.Position); err != nil {
+		return nil, err
+	}
+
 	entity := matchrequest.NewMatchRequest(
 		input.MatchOfferID,
 		offer.OwnerAccountID,
 		input.RequesterAccountID,
+		input.Position,
 	)
 
-	if err := uc.matchRequestRepository.Save(ctx, entity); err != nil {
+	message, err := appevents.NewMessage(ctx, matchrequestevent.MatchRequestCreatedEvent{
+		MatchRequestID:     entity.ID,
+		MatchOfferID:       entity.MatchOfferID,
+		RequesterAccountID: entity.RequesterAccountID,
+		OwnerAccountID:     entity.OwnerAccountID,
+		Position:           entity.Position,
+	}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error while building created event for match request: %w", err)
+	}
+
+	if err := uc.matchRequestRepository.Create(ctx, entity, []outbox.Message{message}); err != nil {
+		if stderrors.Is(err, matchrequest.ErrOpenRequestExists) {
+			return nil, errors.UseCaseExecutionFailed("you already have an open request for this match offer")
+		}
 		return nil, fmt.Errorf("error while saving match request: %w", err)
 	}
 
 	return &entity, nil
 }
+
+// validatePosition checks the requested position against the positions the offer needs.
+func validatePosition(offer matchoffer.Entity, position string) error {
+	if !offer.PlayerNeeds.HasPositions() {
+		if position != "" {
+			return fmt.Errorf("match offer '%s' does not need specific positions", offer.ID)
+		}
+		return nil
+	}
+	if _, ok := offer.PlayerNeeds.SpotsFor(position); !ok {
+		return fmt.Errorf("match offer '%s' does not need position '%s'", offer.ID, position)
+	}
+	return nil
+}
@@ -3,20 +3,30 @@ package usecases_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	apperrors "sportlink/api/application/errors"
+	"sportlink/api/application/matchoffer/service"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
 	"sportlink/api/application/matchrequest/usecases"
 	domainoffer "sportlink/api/domain/matchoffer"
 	domainreq "sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
 	offermocks "sportlink/mocks/api/domain/matchoffer"
 	reqmocks "sportlink/mocks/api/domain/matchrequest"
+	teammocks "sportlink/mocks/api/domain/team"
 )
 
 func TestCreateMatchRequestUC_Invoke(t *testing.T) {
 	ctx := context.Background()
+	signer := service.NewShareLinkSigner("share-secret", time.Hour)
+	linkOffer := domainoffer.Entity{ID: "offer-1", OwnerAccountID: "owner-acc", Visibility: domainoffer.VisibilityLink}
+	linkToken, _, _ := signer.Sign(linkOffer)
 
 	testCases := []struct {
 		name  string
@@ -38,11 +48,17 @@ func TestCreateMatchRequestUC_Invoke(t *testing.T) {
 				offerRepo.On("Find", mock.Anything, mock.MatchedBy(func(q domainoffer.DomainQuery) bool {
 					return len(q.IDs) == 1 && q.IDs[0] == "offer-1"
 				})).Return(domainoffer.Page{Entities: []domainoffer.Entity{offer}}, nil)
-				reqRepo.On("Save", mock.Anything, mock.MatchedBy(func(e domainreq.Entity) bool {
+				reqRepo.On("Create", mock.Anything, mock.MatchedBy(func(e domainreq.Entity) bool {
 					return e.MatchOfferID == "offer-1" &&
 						e.OwnerAccountID == "owner-acc" &&
 						e.RequesterAccountID == "requester-acc" &&
 						e.Status == domainreq.StatusPending
+				}), mock.MatchedBy(func(messages []outbox.Message) bool {
+					var e matchrequestevent.MatchRequestCreatedEvent
+					return len(messages) == 1 && messages[0].Type == matchrequestevent.MatchRequestCreatedEventType &&
+						messages[0].Decode(&e) == nil &&
+						e.MatchRequestID == domainreq.GenerateMatchRequestID("requester-acc", "offer-1") &&
+						e.OwnerAccountID == "owner-acc" && e.RequesterAccountID == "requester-acc"
 				})).Return(nil)
 			},
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
@@ -102,6 +118,65 @@ func TestCreateMatchRequestUC_Invoke(t *testing.T) {
 				assert.Contains(t, err.Error(), "cannot send a match request to your own offer")
 			},
 		},
+		{
+			name: "given invite-only offer when an uninvited account requests it then returns not found error",
+			input: usecases.CreateMatchRequestInput{
+				MatchOfferID:       "offer-1",
+				RequesterAccountID: "requester-acc",
+			},
+			on: func(t *testing.T, _ *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				offer := domainoffer.Entity{
+					ID:                "offer-1",
+					OwnerAccountID:    "owner-acc",
+					Visibility:        domainoffer.VisibilityInvite,
+					InvitedAccountIDs: []string{"invited-acc"},
+				}
+				offerRepo.On("Find", mock.Anything, mock.Anything).Return(domainoffer.Page{Entities: []domainoffer.Entity{offer}}, nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				assert.Contains(t, err.Error(), "match offer 'offer-1' not found")
+			},
+		},
+		{
+			name: "given link offer when requester holds a valid share token then returns saved entity",
+			input: usecases.CreateMatchRequestInput{
+				MatchOfferID:       "offer-1",
+				RequesterAccountID: "requester-acc",
+				ShareToken:         linkToken,
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				offerRepo.On("Find", mock.Anything, mock.Anything).Return(domainoffer.Page{Entities: []domainoffer.Entity{linkOffer}}, nil)
+				reqRepo.On("Create", mock.Anything, mock.Anything, mock.MatchedBy(func(messages []outbox.Message) bool {
+					var e matchrequestevent.MatchRequestCreatedEvent
+					return len(messages) == 1 && messages[0].Decode(&e) == nil &&
+						e.MatchOfferID == "offer-1" && e.RequesterAccountID == "requester-acc"
+				})).Return(nil)
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			},
+		},
+		{
+			name: "given requester already has an open request on the offer when creating then returns conflict",
+			input: usecases.CreateMatchRequestInput{
+				MatchOfferID:       "offer-1",
+				RequesterAccountID: "requester-acc",
+			},
+			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
+				offer := domainoffer.Entity{ID: "offer-1", OwnerAccountID: "owner-acc"}
+				offerRepo.On("Find", mock.Anything, mock.Anything).Return(domainoffer.Page{Entities: []domainoffer.Entity{offer}}, nil)
+				reqRepo.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("match request x: %w", domainreq.ErrOpenRequestExists))
+			},
+			then: func(t *testing.T, result *domainreq.Entity, err error) {
+				assert.Nil(t, result)
+				var appErr apperrors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, apperrors.UseCaseExecutionErrorCode, appErr.Code)
+			},
+		},
 		{
 			name: "given match request save fails when creating then returns wrapped error",
 			input: usecases.CreateMatchRequestInput{
@@ -111,7 +186,7 @@ func TestCreateMatchRequestUC_Invoke(t *testing.T) {
 			on: func(t *testing.T, reqRepo *reqmocks.Repository, offerRepo *offermocks.Repository) {
 				offer := domainoffer.Entity{ID: "offer-1", OwnerAccountID: "owner-acc"}
 				offerRepo.On("Find", mock.Anything, mock.Anything).Return(domainoffer.Page{Entities: []domainoffer.Entity{offer}}, nil)
-				reqRepo.On("Save", mock.Anything, mock.Anything).Return(errors.New("persist failed"))
+				reqRepo.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("persist failed"))
 			},
 			then: func(t *testing.T, result *domainreq.Entity, err error) {
 				assert.Error(t, err)
@@ -127,7 +202,8 @@ func TestCreateMatchRequestUC_Invoke(t *testing.T) {
 			// set up
 			reqRepo := reqmocks.NewRepository(t)
 			offerRepo := offermocks.NewRepository(t)
-			uc := usecases.NewCreateMatchRequestUC(reqRepo, offerRepo)
+			policy := service.NewVisibilityPolicy(teammocks.NewRepository(t), signer)
+			uc := usecases.NewCreateMatchRequestUC(reqRepo, offerRepo, policy)
 
 			// given
 			tt.on(t, reqRepo, offerRepo)
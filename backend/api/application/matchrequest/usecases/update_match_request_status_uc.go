@@ -3,7 +3,14 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"sportlink/api/application/errors"
+	appevents "sportlink/api/application/events"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
+	"sportlink/api/domain/common"
 	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+	"time"
 )
 
 type UpdateMatchRequestStatusInput struct {
@@ -17,15 +24,67 @@ type UpdateMatchRequestStatusUC struct {
 }
 
 func NewUpdateMatchRequestStatusUC(matchRequestRepository matchrequest.Repository) *UpdateMatchRequestStatusUC {
-	return &UpdateMatchRequestStatusUC{
-		matchRequestRepository: matchRequestRepository,
-	}
+	return &UpdateMatchRequestStatusUC{matchRequestRepository: matchRequestRepository}
 }
 
+// Invoke moves the request along its transition table on behalf of the owner, so a
+// request can only leave PENDING and never come back to it.
 func (uc *UpdateMatchRequestStatusUC) Invoke(ctx context.Context, input UpdateMatchRequestStatusInput) error {
-	err := uc.matchRequestRepository.UpdateStatus(ctx, input.ID, input.OwnerAccountID, input.NewStatus)
+	if input.NewStatus == matchrequest.StatusAccepted {
+		// accepting has to claim a spot on the offer, which only AcceptMatchRequestUC does
+		return errors.UseCaseExecutionFailed("match requests are accepted through the accept endpoint")
+	}
+
+	request, err := getMatchRequest(ctx, uc.matchRequestRepository, input.ID)
+	if err != nil {
+		return fmt.Errorf("error while finding match request: %w", err)
+	}
+	if request.OwnerAccountID != input.OwnerAccountID {
+		return errors.Unauthorized("owner account ID does not match")
+	}
+	updated, err := request.TransitionTo(input.NewStatus, common.ActorOwner)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("match request %s cannot change status", input.ID), err)
+		return errors.InvalidTransition(err.Error())
+	}
+
+	messages, err := statusChangeMessages(ctx, updated)
+	if err != nil {
+		return fmt.Errorf("error while building status change event: %w", err)
+	}
+
+	// the write is still conditioned on the stored request being pending, in case it
+	// changed since it was read
+	err = uc.matchRequestRepository.UpdateStatus(ctx, updated, messages)
 	if err != nil {
 		return fmt.Errorf("error while updating match request status: %w", err)
 	}
 	return nil
 }
+
+// statusChangeMessages returns the event announcing a rejection or a cancellation, to be
+// written with the new status. Other statuses announce nothing.
+func statusChangeMessages(ctx context.Context, updated matchrequest.Entity) ([]outbox.Message, error) {
+	if updated.Status != matchrequest.StatusRejected && updated.Status != matchrequest.StatusCancel {
+		return nil, nil
+	}
+	return appevents.NewMessages(ctx, time.Now(), buildStatusChangedEvent(updated, updated.Status))
+}
+
+func buildStatusChangedEvent(request matchrequest.Entity, status matchrequest.Status) appevents.Event {
+	if status == matchrequest.StatusRejected {
+		return matchrequestevent.MatchRequestRejectedEvent{
+			MatchRequestID:     request.ID,
+			MatchOfferID:       request.MatchOfferID,
+			RequesterAccountID: request.RequesterAccountID,
+			OwnerAccountID:     request.OwnerAccountID,
+			Reason:             request.RejectionReason,
+		}
+	}
+	return matchrequestevent.MatchRequestCancelledEvent{
+		MatchRequestID:     request.ID,
+		MatchOfferID:       request.MatchOfferID,
+		RequesterAccountID: request.RequesterAccountID,
+		OwnerAccountID:     request.OwnerAccountID,
+	}
+}
@@ -8,14 +8,41 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	apperrors "sportlink/api/application/errors"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
 	"sportlink/api/application/matchrequest/usecases"
+	"sportlink/api/domain/common"
 	domainreq "sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
 	reqmocks "sportlink/mocks/api/domain/matchrequest"
 )
 
 func TestUpdateMatchRequestStatusUC_Invoke(t *testing.T) {
 	ctx := context.Background()
 
+	pendingRequest := domainreq.Entity{
+		ID:                 "mr-1",
+		MatchOfferID:       "offer-1",
+		OwnerAccountID:     "owner-acc",
+		RequesterAccountID: "requester-acc",
+		Status:             domainreq.StatusPending,
+	}
+
+	isCtx := mock.MatchedBy(func(c context.Context) bool { return c == ctx })
+	matchesRequestLookup := mock.MatchedBy(func(q domainreq.DomainQuery) bool {
+		return len(q.IDs) == 1 && q.IDs[0] == "mr-1"
+	})
+	isRejected := mock.MatchedBy(func(r domainreq.Entity) bool {
+		return r.ID == "mr-1" && r.OwnerAccountID == "owner-acc" && r.Status == domainreq.StatusRejected &&
+			len(r.Changes) == 1 && r.Changes[0].From == "PENDING" && r.Changes[0].Actor == common.ActorOwner
+	})
+
+	rejectInput := usecases.UpdateMatchRequestStatusInput{
+		ID:             "mr-1",
+		OwnerAccountID: "owner-acc",
+		NewStatus:      domainreq.StatusRejected,
+	}
+
 	testCases := []struct {
 		name  string
 		input usecases.UpdateMatchRequestStatusInput
@@ -23,28 +50,81 @@ func TestUpdateMatchRequestStatusUC_Invoke(t *testing.T) {
 		then  func(t *testing.T, err error)
 	}{
 		{
-			name: "given repository succeeds when updating status then returns no error",
+			name:  "given a pending request when its owner rejects it then the status is updated and the rejection announced",
+			input: rejectInput,
+			on: func(t *testing.T, repository *reqmocks.Repository) {
+				repository.On("Find", isCtx, matchesRequestLookup).Return([]domainreq.Entity{pendingRequest}, nil)
+				repository.On("UpdateStatus", isCtx, isRejected, mock.MatchedBy(func(messages []outbox.Message) bool {
+					var e matchrequestevent.MatchRequestRejectedEvent
+					return len(messages) == 1 && messages[0].Type == matchrequestevent.MatchRequestRejectedEventType &&
+						messages[0].Decode(&e) == nil &&
+						e.MatchRequestID == "mr-1" && e.MatchOfferID == "offer-1" && e.RequesterAccountID == "requester-acc"
+				})).Return(nil)
+			},
+			then: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:  "given a cancelled request when its owner rejects it then fails with an invalid transition and nothing is written",
+			input: rejectInput,
+			on: func(t *testing.T, repository *reqmocks.Repository) {
+				cancelled := pendingRequest
+				cancelled.Status = domainreq.StatusCancel
+				repository.On("Find", isCtx, matchesRequestLookup).Return([]domainreq.Entity{cancelled}, nil)
+			},
+			then: func(t *testing.T, err error) {
+				assert.Equal(t, apperrors.InvalidTransition("match request cannot go from CANCEL to REJECTED by OWNER"), err)
+			},
+		},
+		{
+			name: "given a request moved back to pending when updating status then fails with an invalid transition",
 			input: usecases.UpdateMatchRequestStatusInput{
 				ID:             "mr-1",
 				OwnerAccountID: "owner-acc",
-				NewStatus:      domainreq.StatusAccepted,
+				NewStatus:      domainreq.StatusPending,
 			},
 			on: func(t *testing.T, repository *reqmocks.Repository) {
-				repository.On("UpdateStatus", mock.Anything, "mr-1", "owner-acc", domainreq.StatusAccepted).Return(nil)
+				repository.On("Find", isCtx, matchesRequestLookup).Return([]domainreq.Entity{pendingRequest}, nil)
 			},
 			then: func(t *testing.T, err error) {
-				assert.NoError(t, err)
+				assert.Equal(t, apperrors.InvalidTransitionErrorCode, err.(apperrors.AppError).Code)
 			},
 		},
 		{
-			name: "given repository fails when updating status then returns wrapped error",
+			name: "given a request of another owner when updating status then returns unauthorized",
 			input: usecases.UpdateMatchRequestStatusInput{
 				ID:             "mr-1",
-				OwnerAccountID: "owner-acc",
+				OwnerAccountID: "someone-else",
 				NewStatus:      domainreq.StatusRejected,
 			},
 			on: func(t *testing.T, repository *reqmocks.Repository) {
-				repository.On("UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				repository.On("Find", isCtx, matchesRequestLookup).Return([]domainreq.Entity{pendingRequest}, nil)
+			},
+			then: func(t *testing.T, err error) {
+				assert.Equal(t, apperrors.Unauthorized("owner account ID does not match"), err)
+			},
+		},
+		{
+			name: "given accepted as new status when updating status then fails without touching the repository",
+			input: usecases.UpdateMatchRequestStatusInput{
+				ID:             "mr-1",
+				OwnerAccountID: "owner-acc",
+				NewStatus:      domainreq.StatusAccepted,
+			},
+			on: func(t *testing.T, repository *reqmocks.Repository) {
+			},
+			then: func(t *testing.T, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "accept endpoint")
+			},
+		},
+		{
+			name:  "given repository fails when updating status then returns wrapped error",
+			input: rejectInput,
+			on: func(t *testing.T, repository *reqmocks.Repository) {
+				repository.On("Find", isCtx, matchesRequestLookup).Return([]domainreq.Entity{pendingRequest}, nil)
+				repository.On("UpdateStatus", isCtx, isRejected, mock.Anything).
 					Return(errors.New("conditional check failed"))
 			},
 			then: func(t *testing.T, err error) {
@@ -6,6 +6,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"log"
+	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 )
@@ -15,6 +17,13 @@ type Broker interface {
 	SendMessage(ctx context.Context, message string) error
 	SendMessages(ctx context.Context, batch []Message) (SendMessagesOutput, error)
 	ReceiveMessages(ctx context.Context, batchSize int) ([]string, error)
+	// Receive takes up to batchSize messages, waiting up to wait for the first one. Each
+	// message must be deleted once handled, or it is delivered again after its visibility
+	// timeout and moved to the dead-letter queue once it was received too many times.
+	Receive(ctx context.Context, batchSize int, wait time.Duration) ([]ReceivedMessage, error)
+	Delete(ctx context.Context, receiptHandle string) error
+	// Delay makes a received message visible again after delay, to retry it later.
+	Delay(ctx context.Context, receiptHandle string, delay time.Duration) error
 }
 
 type Message struct {
@@ -108,3 +117,48 @@ func (broker *SQSMessageBroker) ReceiveMessages(ctx context.Context, batchSize i
 
 	return messages, nil
 }
+
+func (broker *SQSMessageBroker) Receive(ctx context.Context, batchSize int, wait time.Duration) ([]ReceivedMessage, error) {
+	input := &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(broker.queueUrl),
+		MaxNumberOfMessages: int32(batchSize),
+		WaitTimeSeconds:     int32(wait.Seconds()),
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{
+			types.MessageSystemAttributeNameApproximateReceiveCount,
+		},
+	}
+
+	result, err := broker.client.ReceiveMessage(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]ReceivedMessage, 0, len(result.Messages))
+	for _, msg := range result.Messages {
+		receiveCount, _ := strconv.Atoi(msg.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+		messages = append(messages, ReceivedMessage{
+			Body:          aws.ToString(msg.Body),
+			ReceiptHandle: aws.ToString(msg.ReceiptHandle),
+			ReceiveCount:  receiveCount,
+		})
+	}
+
+	return messages, nil
+}
+
+func (broker *SQSMessageBroker) Delete(ctx context.Context, receiptHandle string) error {
+	_, err := broker.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(broker.queueUrl),
+		ReceiptHandle: aws.String(receiptHandle),
+	})
+	return err
+}
+
+func (broker *SQSMessageBroker) Delay(ctx context.Context, receiptHandle string, delay time.Duration) error {
+	_, err := broker.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(broker.queueUrl),
+		ReceiptHandle:     aws.String(receiptHandle),
+		VisibilityTimeout: int32(delay.Seconds()),
+	})
+	return err
+}
@@ -0,0 +1,149 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// memoryVisibilityTimeout mirrors the SQS default: a received message that is neither
+// deleted nor delayed is delivered again once it elapses.
+const memoryVisibilityTimeout = 30 * time.Second
+
+// memoryReceivePollInterval is how often Receive looks for messages while it waits.
+const memoryReceivePollInterval = 50 * time.Millisecond
+
+type memoryMessage struct {
+	body         string
+	visibleAt    time.Time
+	receiveCount int
+}
+
+// MemoryMessageBroker is a queue kept in the memory of the process, for running the API
+// without SQS. Messages only reach the consumers of the same instance and have no
+// dead-letter queue.
+type MemoryMessageBroker struct {
+	mu       sync.Mutex
+	messages map[string]*memoryMessage
+	order    []string
+}
+
+func NewMemoryBroker() Broker {
+	return &MemoryMessageBroker{messages: map[string]*memoryMessage{}}
+}
+
+func (broker *MemoryMessageBroker) SendMessage(_ context.Context, message string) error {
+	if message == "" {
+		return fmt.Errorf("message is empty")
+	}
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+
+	broker.enqueue(message)
+	return nil
+}
+
+func (broker *MemoryMessageBroker) SendMessages(_ context.Context, batch []Message) (SendMessagesOutput, error) {
+	if len(batch) > 10 {
+		return SendMessagesOutput{}, fmt.Errorf("batch size exceeds SQS limit of 10 messages per batch")
+	}
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+
+	for _, message := range batch {
+		broker.enqueue(message.Message)
+	}
+	return SendMessagesOutput{Succeeded: len(batch)}, nil
+}
+
+func (broker *MemoryMessageBroker) ReceiveMessages(_ context.Context, batchSize int) ([]string, error) {
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+
+	received := broker.take(batchSize)
+	messages := make([]string, len(received))
+	for i, message := range received {
+		messages[i] = message.Body
+	}
+	return messages, nil
+}
+
+func (broker *MemoryMessageBroker) Receive(ctx context.Context, batchSize int, wait time.Duration) ([]ReceivedMessage, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		broker.mu.Lock()
+		received := broker.take(batchSize)
+		broker.mu.Unlock()
+		if len(received) > 0 || !time.Now().Before(deadline) {
+			return received, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(memoryReceivePollInterval):
+		}
+	}
+}
+
+func (broker *MemoryMessageBroker) Delete(_ context.Context, receiptHandle string) error {
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+
+	if _, ok := broker.messages[receiptHandle]; !ok {
+		return fmt.Errorf("unknown receipt handle %s", receiptHandle)
+	}
+	delete(broker.messages, receiptHandle)
+	for i, handle := range broker.order {
+		if handle == receiptHandle {
+			broker.order = append(broker.order[:i], broker.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (broker *MemoryMessageBroker) Delay(_ context.Context, receiptHandle string, delay time.Duration) error {
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+
+	message, ok := broker.messages[receiptHandle]
+	if !ok {
+		return fmt.Errorf("unknown receipt handle %s", receiptHandle)
+	}
+	message.visibleAt = time.Now().Add(delay)
+	return nil
+}
+
+// enqueue adds a message visible right away. The caller holds the lock.
+func (broker *MemoryMessageBroker) enqueue(body string) {
+	handle := ulid.Make().String()
+	broker.messages[handle] = &memoryMessage{body: body}
+	broker.order = append(broker.order, handle)
+}
+
+// take hides up to batchSize visible messages for the visibility timeout, oldest first.
+// The caller holds the lock.
+func (broker *MemoryMessageBroker) take(batchSize int) []ReceivedMessage {
+	now := time.Now()
+	received := make([]ReceivedMessage, 0)
+	for _, handle := range broker.order {
+		if len(received) == batchSize {
+			break
+		}
+		message := broker.messages[handle]
+		if message.visibleAt.After(now) {
+			continue
+		}
+		message.receiveCount++
+		message.visibleAt = now.Add(memoryVisibilityTimeout)
+		received = append(received, ReceivedMessage{
+			Body:          message.body,
+			ReceiptHandle: handle,
+			ReceiveCount:  message.receiveCount,
+		})
+	}
+	return received
+}
@@ -0,0 +1,96 @@
+package messaging_test
+
+import (
+	"context"
+	"sportlink/api/application/messaging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryMessageBroker_Receive(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name       string
+		on         func(t *testing.T, broker messaging.Broker)
+		assertions func(t *testing.T, received []messaging.ReceivedMessage, err error)
+	}{
+		{
+			name: "given a sent message when receiving then returns it with its first receive count",
+			on: func(t *testing.T, broker messaging.Broker) {
+				assert.NoError(t, broker.SendMessage(ctx, "first"))
+			},
+			assertions: func(t *testing.T, received []messaging.ReceivedMessage, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, received, 1)
+				assert.Equal(t, "first", received[0].Body)
+				assert.Equal(t, 1, received[0].ReceiveCount)
+			},
+		},
+		{
+			name: "given a received message that was not deleted when receiving then it stays hidden",
+			on: func(t *testing.T, broker messaging.Broker) {
+				assert.NoError(t, broker.SendMessage(ctx, "first"))
+				_, _ = broker.Receive(ctx, 10, 0)
+			},
+			assertions: func(t *testing.T, received []messaging.ReceivedMessage, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, received)
+			},
+		},
+		{
+			name: "given a received message that was delayed when the delay elapses then it is delivered again",
+			on: func(t *testing.T, broker messaging.Broker) {
+				assert.NoError(t, broker.SendMessage(ctx, "first"))
+				received, _ := broker.Receive(ctx, 10, 0)
+				assert.NoError(t, broker.Delay(ctx, received[0].ReceiptHandle, 0))
+			},
+			assertions: func(t *testing.T, received []messaging.ReceivedMessage, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, received, 1)
+				assert.Equal(t, 2, received[0].ReceiveCount)
+			},
+		},
+		{
+			name: "given a received message that was deleted when receiving then it is gone",
+			on: func(t *testing.T, broker messaging.Broker) {
+				assert.NoError(t, broker.SendMessage(ctx, "first"))
+				received, _ := broker.Receive(ctx, 10, 0)
+				assert.NoError(t, broker.Delete(ctx, received[0].ReceiptHandle))
+				assert.Error(t, broker.Delay(ctx, received[0].ReceiptHandle, 0))
+			},
+			assertions: func(t *testing.T, received []messaging.ReceivedMessage, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, received)
+			},
+		},
+		{
+			name: "given more messages than the batch when receiving then returns the oldest ones",
+			on: func(t *testing.T, broker messaging.Broker) {
+				_, err := broker.SendMessages(ctx, []messaging.Message{{Id: "1", Message: "first"}, {Id: "2", Message: "second"}})
+				assert.NoError(t, err)
+			},
+			assertions: func(t *testing.T, received []messaging.ReceivedMessage, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, received, 1)
+				assert.Equal(t, "first", received[0].Body)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			broker := messaging.NewMemoryBroker()
+			testCase.on(t, broker)
+
+			// when
+			received, err := broker.Receive(ctx, 1, 100*time.Millisecond)
+
+			// then
+			testCase.assertions(t, received, err)
+		})
+	}
+}
@@ -4,3 +4,11 @@ type SendMessagesOutput struct {
 	Succeeded int
 	Failed    int
 }
+
+// ReceivedMessage is a message taken from the queue, identified by its receipt handle
+// until it is deleted. ReceiveCount includes the current delivery.
+type ReceivedMessage struct {
+	Body          string
+	ReceiptHandle string
+	ReceiveCount  int
+}
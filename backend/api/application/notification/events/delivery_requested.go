@@ -0,0 +1,20 @@
+package events
+
+import "sportlink/api/domain/notification"
+
+// NotificationDeliveryRequestedEventType identifies NotificationDeliveryRequestedEvent messages in the outbox.
+const NotificationDeliveryRequestedEventType = "notification.delivery_requested"
+
+// NotificationDeliveryRequestedEvent asks for a notification to be sent through one
+// off-app channel. One event is raised per channel, so a failing channel is retried
+// without sending again through the others.
+type NotificationDeliveryRequestedEvent struct {
+	NotificationID string               `json:"notification_id"`
+	AccountID      string               `json:"account_id"`
+	Channel        notification.Channel `json:"channel"`
+}
+
+func (NotificationDeliveryRequestedEvent) EventType() string {
+	return NotificationDeliveryRequestedEventType
+}
+func (NotificationDeliveryRequestedEvent) EventVersion() int { return 1 }
@@ -0,0 +1,6 @@
+package request
+
+// RegisterPushTokenRequest registers a browser or device to receive push notifications.
+type RegisterPushTokenRequest struct {
+	Token string `json:"token" validate:"required,max=4096"`
+}
@@ -0,0 +1,20 @@
+package request
+
+// UpdateNotificationPreferencesRequest changes what an account is notified about and how
+// it is reached off-app. Types and channels are turned on (true) or off (false); those
+// not listed keep their current setting, as do omitted fields.
+type UpdateNotificationPreferencesRequest struct {
+	Types           map[string]bool    `json:"types"`
+	Channels        map[string]bool    `json:"channels"`
+	QuietHours      *QuietHoursRequest `json:"quiet_hours"`
+	ClearQuietHours bool               `json:"clear_quiet_hours"`
+	Locale          string             `json:"locale" validate:"omitempty,oneof=es en"`
+}
+
+// QuietHoursRequest is a daily window, given as "HH:MM" times in the time zone of the
+// account, during which nothing is sent off-app.
+type QuietHoursRequest struct {
+	Start    string `json:"start" validate:"required"`
+	End      string `json:"end" validate:"required"`
+	TimeZone string `json:"time_zone" validate:"required"`
+}
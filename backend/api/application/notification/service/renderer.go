@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"sportlink/api/domain/notification"
+	"text/template"
+)
+
+// TemplateData is what notification templates can refer to.
+type TemplateData struct {
+	Summary string            // one-line description of the match, e.g. "Paddle · L3-L5 · Palermo, AR"
+	Data    map[string]string // references carried by the notification
+}
+
+// Rendered is a notification written for people.
+type Rendered struct {
+	Subject string
+	Body    string
+}
+
+// Renderer writes notifications in the language of the account.
+type Renderer interface {
+	Render(locale string, notificationType notification.Type, data TemplateData) (Rendered, error)
+}
+
+type localizedTemplate struct {
+	subject string
+	body    string
+}
+
+// fallbackSummaries stand in for the match summary when the offer is gone.
+var fallbackSummaries = map[string]string{
+	notification.LocaleSpanish: "tu partido",
+	notification.LocaleEnglish: "your match",
+}
+
+var catalogue = map[string]map[notification.Type]localizedTemplate{
+	notification.LocaleSpanish: {
+		notification.TypeMatchRequestReceived:  {"Nueva solicitud para tu partido", "Alguien quiere sumarse a {{.Summary}}."},
+		notification.TypeMatchRequestAccepted:  {"¡Te aceptaron!", "Tu solicitud para {{.Summary}} fue aceptada."},
+		notification.TypeMatchRequestRejected:  {"Solicitud rechazada", "Tu solicitud para {{.Summary}} no fue aceptada.{{with index .Data \"reason\"}} Motivo: {{.}}{{end}}"},
+		notification.TypeMatchRequestCancelled: {"Solicitud cancelada", "Se canceló una solicitud de {{.Summary}}."},
+		notification.TypeMatchRequestPromoted:  {"¡Se liberó un lugar!", "Pasaste de la lista de espera a {{.Summary}}."},
+		notification.TypeMatchOfferExpired:     {"El partido ya pasó", "{{.Summary}} terminó antes de que respondieran tu solicitud."},
+		notification.TypeMatchConfirmed:        {"Partido confirmado", "{{.Summary}} está confirmado. ¡A jugar!"},
+		notification.TypeMatchReminder:         {"Tu partido empieza pronto", "{{.Summary}} empieza en {{index .Data \"lead\"}}. ¿Seguís viniendo? Confirmalo con un toque."},
+		notification.TypeAttendanceUnconfirmed: {"Faltan confirmaciones", "{{index .Data \"unconfirmed_count\"}} jugadores todavía no confirmaron que vienen a {{.Summary}}."},
+	},
+	notification.LocaleEnglish: {
+		notification.TypeMatchRequestReceived:  {"New request for your match", "Someone wants to join {{.Summary}}."},
+		notification.TypeMatchRequestAccepted:  {"You're in!", "Your request for {{.Summary}} was accepted."},
+		notification.TypeMatchRequestRejected:  {"Request declined", "Your request for {{.Summary}} was not accepted.{{with index .Data \"reason\"}} Reason: {{.}}{{end}}"},
+		notification.TypeMatchRequestCancelled: {"Request cancelled", "A request for {{.Summary}} was cancelled."},
+		notification.TypeMatchRequestPromoted:  {"A spot opened up!", "You moved from the waitlist into {{.Summary}}."},
+		notification.TypeMatchOfferExpired:     {"The match is over", "{{.Summary}} ended before your request was answered."},
+		notification.TypeMatchConfirmed:        {"Match confirmed", "{{.Summary}} is confirmed. Game on!"},
+		notification.TypeMatchReminder:         {"Your match starts soon", "{{.Summary}} starts in {{index .Data \"lead\"}}. Still coming? Confirm with one tap."},
+		notification.TypeAttendanceUnconfirmed: {"Players yet to confirm", "{{index .Data \"unconfirmed_count\"}} players have not confirmed they are coming to {{.Summary}}."},
+	},
+}
+
+type templateRenderer struct {
+	templates map[string]map[notification.Type]*template.Template
+}
+
+// NewRenderer builds the renderer of the built-in Spanish and English templates.
+// Unknown locales are written in notification.DefaultLocale.
+func NewRenderer() Renderer {
+	templates := make(map[string]map[notification.Type]*template.Template, len(catalogue))
+	for locale, entries := range catalogue {
+		templates[locale] = make(map[notification.Type]*template.Template, len(entries))
+		for notificationType, entry := range entries {
+			name := locale + "/" + notificationType.String()
+			templates[locale][notificationType] = template.Must(
+				template.New(name).Parse(`{{define "subject"}}` + entry.subject + `{{end}}{{define "body"}}` + entry.body + `{{end}}`),
+			)
+		}
+	}
+	return &templateRenderer{templates: templates}
+}
+
+func (r *templateRenderer) Render(locale string, notificationType notification.Type, data TemplateData) (Rendered, error) {
+	if _, ok := r.templates[locale]; !ok {
+		locale = notification.DefaultLocale
+	}
+	tmpl, ok := r.templates[locale][notificationType]
+	if !ok {
+		return Rendered{}, fmt.Errorf("no %s template for %s notifications", locale, notificationType)
+	}
+	if data.Summary == "" {
+		data.Summary = fallbackSummaries[locale]
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Rendered{}, fmt.Errorf("failed to render %s subject: %w", notificationType, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return Rendered{}, fmt.Errorf("failed to render %s body: %w", notificationType, err)
+	}
+	return Rendered{Subject: subject.String(), Body: body.String()}, nil
+}
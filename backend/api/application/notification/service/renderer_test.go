@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"sportlink/api/application/notification/service"
+	"sportlink/api/domain/notification"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	renderer := service.NewRenderer()
+
+	tests := []struct {
+		name   string
+		locale string
+		data   service.TemplateData
+		then   func(t *testing.T, rendered service.Rendered, err error)
+	}{
+		{
+			name:   "given an English account when rendering then the summary of the match is written in English",
+			locale: notification.LocaleEnglish,
+			data:   service.TemplateData{Summary: "Paddle · L3-L5 · Palermo, ARG"},
+			then: func(t *testing.T, rendered service.Rendered, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "You're in!", rendered.Subject)
+				assert.Equal(t, "Your request for Paddle · L3-L5 · Palermo, ARG was accepted.", rendered.Body)
+			},
+		},
+		{
+			name:   "given an unknown locale when rendering then the notification is written in Spanish",
+			locale: "fr",
+			data:   service.TemplateData{Summary: "Paddle · L3-L5 · Palermo, ARG"},
+			then: func(t *testing.T, rendered service.Rendered, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "¡Te aceptaron!", rendered.Subject)
+				assert.Equal(t, "Tu solicitud para Paddle · L3-L5 · Palermo, ARG fue aceptada.", rendered.Body)
+			},
+		},
+		{
+			name:   "given no summary when rendering then a generic wording stands in for the match",
+			locale: notification.LocaleSpanish,
+			then: func(t *testing.T, rendered service.Rendered, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "Tu solicitud para tu partido fue aceptada.", rendered.Body)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := renderer.Render(tt.locale, notification.TypeMatchRequestAccepted, tt.data)
+
+			tt.then(t, rendered, err)
+		})
+	}
+}
+
+func TestRenderer_EveryTypeHasTemplates(t *testing.T) {
+	renderer := service.NewRenderer()
+
+	for _, locale := range []string{notification.LocaleSpanish, notification.LocaleEnglish} {
+		for _, notificationType := range notification.Types() {
+			rendered, err := renderer.Render(locale, notificationType, service.TemplateData{})
+			assert.NoError(t, err, "%s/%s", locale, notificationType)
+			assert.NotEmpty(t, rendered.Subject, "%s/%s", locale, notificationType)
+			assert.NotEmpty(t, rendered.Body, "%s/%s", locale, notificationType)
+		}
+	}
+}
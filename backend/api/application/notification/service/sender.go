@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// Message is a rendered notification on its way to one address of an account.
+type Message struct {
+	NotificationID string
+	AccountID      string
+	Address        string // email address or push token, depending on the channel
+	Subject        string
+	Body           string
+}
+
+// Sender hands messages over to the transport of one channel.
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// FakeSender keeps the messages it is given instead of sending them. It backs local
+// development and tests until a real transport is configured.
+type FakeSender struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewFakeSender() *FakeSender {
+	return &FakeSender{}
+}
+
+func (s *FakeSender) Send(_ context.Context, message Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, message)
+	return nil
+}
+
+// Sent returns the messages sent so far, oldest first.
+func (s *FakeSender) Sent() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.sent...)
+}
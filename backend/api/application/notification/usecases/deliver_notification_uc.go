@@ -0,0 +1,181 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application/notification/service"
+	"sportlink/api/domain/account"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type DeliverNotificationInput struct {
+	NotificationID string
+	AccountID      string
+	Channel        notification.Channel
+	Now            time.Time
+}
+
+// deliveredAddresses is the name the addresses a notification was already sent to are
+// recorded under in the processed message store.
+const deliveredAddresses = "notification-delivery"
+
+type DeliverNotificationResult struct {
+	Sent          int        // messages handed to the channel, one per address not reached before
+	DeferredUntil *time.Time // set when quiet hours hold the delivery back
+}
+
+// DeliverNotificationUC sends a notification of the inbox through one off-app channel,
+// written in the language of the account. Nothing is sent when the account turned the
+// channel off meanwhile, and deliveries falling in quiet hours are deferred to their end.
+// Errors are returned so the delivery is retried; every address reached is recorded as
+// soon as it is sent, so a retry only sends to the addresses that were not.
+type DeliverNotificationUC struct {
+	notificationRepository notification.Repository
+	preferencesRepository  notification.PreferencesRepository
+	accountRepository      account.Repository
+	matchOfferRepository   matchoffer.Repository
+	delivered              outbox.ProcessedRepository
+	renderer               service.Renderer
+	senders                map[notification.Channel]service.Sender
+}
+
+func NewDeliverNotificationUC(
+	notificationRepository notification.Repository,
+	preferencesRepository notification.PreferencesRepository,
+	accountRepository account.Repository,
+	matchOfferRepository matchoffer.Repository,
+	delivered outbox.ProcessedRepository,
+	renderer service.Renderer,
+	senders map[notification.Channel]service.Sender,
+) *DeliverNotificationUC {
+	return &DeliverNotificationUC{
+		notificationRepository: notificationRepository,
+		preferencesRepository:  preferencesRepository,
+		accountRepository:      accountRepository,
+		matchOfferRepository:   matchOfferRepository,
+		delivered:              delivered,
+		renderer:               renderer,
+		senders:                senders,
+	}
+}
+
+func (uc *DeliverNotificationUC) Invoke(ctx context.Context, input DeliverNotificationInput) (*DeliverNotificationResult, error) {
+	sender, ok := uc.senders[input.Channel]
+	if !ok {
+		return nil, fmt.Errorf("no sender for %s channel", input.Channel)
+	}
+
+	entity, err := uc.notificationRepository.FindByID(ctx, input.AccountID, input.NotificationID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get notification %s", input.NotificationID), err)
+		return nil, err
+	}
+	if entity == nil {
+		return &DeliverNotificationResult{}, nil
+	}
+
+	preferences, err := uc.preferencesRepository.Find(ctx, input.AccountID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get notification preferences of account %s", input.AccountID), err)
+		return nil, err
+	}
+	if !preferences.AllowsChannel(input.Channel) || !preferences.Allows(entity.Type) {
+		return &DeliverNotificationResult{}, nil
+	}
+	if preferences.QuietHours != nil && preferences.QuietHours.Contains(input.Now) {
+		until := preferences.QuietHours.EndsAfter(input.Now)
+		return &DeliverNotificationResult{DeferredUntil: &until}, nil
+	}
+
+	addresses, err := uc.addresses(ctx, input.Channel, preferences)
+	if err != nil {
+		return nil, err
+	}
+	if len(addresses) == 0 {
+		return &DeliverNotificationResult{}, nil
+	}
+
+	rendered, err := uc.renderer.Render(preferences.Locale, entity.Type, service.TemplateData{
+		Summary: uc.summary(ctx, *entity),
+		Data:    entity.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DeliverNotificationResult{}
+	for _, address := range addresses {
+		key := deliveryKey(entity.ID, input.Channel, address)
+		sent, err := uc.delivered.IsProcessed(ctx, deliveredAddresses, key)
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to check the deliveries of notification %s", entity.ID), err)
+			return nil, err
+		}
+		if sent {
+			continue
+		}
+
+		err = sender.Send(ctx, service.Message{
+			NotificationID: entity.ID,
+			AccountID:      entity.AccountID,
+			Address:        address,
+			Subject:        rendered.Subject,
+			Body:           rendered.Body,
+		})
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to send notification %s through %s", entity.ID, input.Channel), err)
+			return nil, err
+		}
+		result.Sent++
+		if err = uc.delivered.MarkProcessed(ctx, deliveredAddresses, key); err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to record the delivery of notification %s", entity.ID), err)
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// deliveryKey identifies the delivery of a notification to one address of a channel.
+func deliveryKey(notificationID string, channel notification.Channel, address string) string {
+	return notificationID + "#" + string(channel) + "#" + address
+}
+
+// addresses returns where the channel reaches the account: its email address, or the
+// devices it registered for push notifications.
+func (uc *DeliverNotificationUC) addresses(ctx context.Context, channel notification.Channel, preferences notification.Preferences) ([]string, error) {
+	if channel == notification.ChannelPush {
+		return preferences.PushTokens, nil
+	}
+
+	accounts, err := uc.accountRepository.Find(ctx, account.DomainQuery{AccountIDs: []string{preferences.AccountID}})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get account %s", preferences.AccountID), err)
+		return nil, err
+	}
+	if len(accounts) == 0 || accounts[0].Email == "" {
+		return nil, nil
+	}
+	return []string{accounts[0].Email}, nil
+}
+
+// summary describes the match of the notification with the title of its offer. The
+// templates fall back to a generic wording when the offer cannot be read.
+func (uc *DeliverNotificationUC) summary(ctx context.Context, entity notification.Entity) string {
+	offerID := entity.Data["match_offer_id"]
+	if offerID == "" {
+		return ""
+	}
+	page, err := uc.matchOfferRepository.Find(ctx, matchoffer.DomainQuery{IDs: []string{offerID}})
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get match offer %s for notification %s", offerID, entity.ID), err)
+		return ""
+	}
+	if len(page.Entities) == 0 {
+		return ""
+	}
+	return page.Entities[0].GetTitle()
+}
@@ -0,0 +1,243 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sportlink/api/application/notification/service"
+	"sportlink/api/application/notification/usecases"
+	"sportlink/api/domain/account"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/notification"
+	accountmocks "sportlink/mocks/api/domain/account"
+	matchoffermocks "sportlink/mocks/api/domain/matchoffer"
+	notificationmocks "sportlink/mocks/api/domain/notification"
+	outboxmocks "sportlink/mocks/api/domain/outbox"
+)
+
+func TestDeliverNotificationUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 10, 18, 0, 0, 0, time.UTC) // 15:00 in Buenos Aires
+
+	entity := notification.NewNotification(
+		"01JEVENT0000000000000000AB", "requester-1", notification.TypeMatchRequestAccepted,
+		map[string]string{"match_offer_id": "offer-1", "match_request_id": "request-1"}, now,
+	)
+	offer := matchoffer.Entity{
+		ID:       "offer-1",
+		Sport:    "Paddle",
+		Location: matchoffer.Location{Country: "Argentina", Locality: "Palermo"},
+	}
+	input := usecases.DeliverNotificationInput{
+		NotificationID: entity.ID,
+		AccountID:      "requester-1",
+		Channel:        notification.ChannelEmail,
+		Now:            now,
+	}
+
+	isCtx := mock.MatchedBy(func(c context.Context) bool { return c == ctx })
+	afternoon, _ := notification.NewQuietHours("14:00", "16:00", "America/Argentina/Buenos_Aires")
+	deliveryKey := func(channel notification.Channel, address string) string {
+		return entity.ID + "#" + string(channel) + "#" + address
+	}
+
+	type dependencies struct {
+		notifications *notificationmocks.Repository
+		preferences   *notificationmocks.PreferencesRepository
+		accounts      *accountmocks.Repository
+		matchOffers   *matchoffermocks.Repository
+		delivered     *outboxmocks.ProcessedRepository
+	}
+
+	testCases := []struct {
+		name  string
+		input usecases.DeliverNotificationInput
+		on    func(t *testing.T, d dependencies)
+		then  func(t *testing.T, result *usecases.DeliverNotificationResult, err error, email, push *service.FakeSender)
+	}{
+		{
+			name:  "given an English account when delivering by email then the rendered notification is sent to its address",
+			input: input,
+			on: func(t *testing.T, d dependencies) {
+				preferences := notification.NewPreferences("requester-1")
+				preferences.Locale = notification.LocaleEnglish
+				d.notifications.On("FindByID", isCtx, "requester-1", entity.ID).Return(&entity, nil)
+				d.preferences.On("Find", isCtx, "requester-1").Return(preferences, nil)
+				d.accounts.On("Find", isCtx, account.DomainQuery{AccountIDs: []string{"requester-1"}}).
+					Return([]account.Entity{{ID: "requester-1", Email: "requester@sportlink.app"}}, nil)
+				d.matchOffers.On("Find", isCtx, matchoffer.DomainQuery{IDs: []string{"offer-1"}}).
+					Return(matchoffer.Page{Entities: []matchoffer.Entity{offer}}, nil)
+				key := deliveryKey(notification.ChannelEmail, "requester@sportlink.app")
+				d.delivered.On("IsProcessed", isCtx, "notification-delivery", key).Return(false, nil)
+				d.delivered.On("MarkProcessed", isCtx, "notification-delivery", key).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.DeliverNotificationResult, err error, email, push *service.FakeSender) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, result.Sent)
+				assert.Nil(t, result.DeferredUntil)
+				sent := email.Sent()
+				assert.Len(t, sent, 1)
+				assert.Equal(t, "requester@sportlink.app", sent[0].Address)
+				assert.Equal(t, "You're in!", sent[0].Subject)
+				assert.Equal(t, "Your request for "+offer.GetTitle()+" was accepted.", sent[0].Body)
+				assert.Empty(t, push.Sent())
+			},
+		},
+		{
+			name: "given two registered devices when delivering by push then both receive the notification",
+			input: usecases.DeliverNotificationInput{
+				NotificationID: entity.ID,
+				AccountID:      "requester-1",
+				Channel:        notification.ChannelPush,
+				Now:            now,
+			},
+			on: func(t *testing.T, d dependencies) {
+				preferences := notification.NewPreferences("requester-1").WithPushToken("token-1").WithPushToken("token-2")
+				d.notifications.On("FindByID", isCtx, "requester-1", entity.ID).Return(&entity, nil)
+				d.preferences.On("Find", isCtx, "requester-1").Return(preferences, nil)
+				d.matchOffers.On("Find", isCtx, matchoffer.DomainQuery{IDs: []string{"offer-1"}}).
+					Return(matchoffer.Page{}, nil)
+				for _, token := range []string{"token-1", "token-2"} {
+					key := deliveryKey(notification.ChannelPush, token)
+					d.delivered.On("IsProcessed", isCtx, "notification-delivery", key).Return(false, nil)
+					d.delivered.On("MarkProcessed", isCtx, "notification-delivery", key).Return(nil)
+				}
+			},
+			then: func(t *testing.T, result *usecases.DeliverNotificationResult, err error, email, push *service.FakeSender) {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, result.Sent)
+				sent := push.Sent()
+				assert.Len(t, sent, 2)
+				assert.Equal(t, "token-1", sent[0].Address)
+				assert.Equal(t, "token-2", sent[1].Address)
+				assert.Equal(t, "Tu solicitud para tu partido fue aceptada.", sent[0].Body)
+			},
+		},
+		{
+			name: "given a redelivery after the first device received the notification when delivering by push then only the second one is sent to",
+			input: usecases.DeliverNotificationInput{
+				NotificationID: entity.ID,
+				AccountID:      "requester-1",
+				Channel:        notification.ChannelPush,
+				Now:            now,
+			},
+			on: func(t *testing.T, d dependencies) {
+				preferences := notification.NewPreferences("requester-1").WithPushToken("token-1").WithPushToken("token-2")
+				d.notifications.On("FindByID", isCtx, "requester-1", entity.ID).Return(&entity, nil)
+				d.preferences.On("Find", isCtx, "requester-1").Return(preferences, nil)
+				d.matchOffers.On("Find", isCtx, matchoffer.DomainQuery{IDs: []string{"offer-1"}}).
+					Return(matchoffer.Page{}, nil)
+				d.delivered.On("IsProcessed", isCtx, "notification-delivery", deliveryKey(notification.ChannelPush, "token-1")).
+					Return(true, nil)
+				d.delivered.On("IsProcessed", isCtx, "notification-delivery", deliveryKey(notification.ChannelPush, "token-2")).
+					Return(false, nil)
+				d.delivered.On("MarkProcessed", isCtx, "notification-delivery", deliveryKey(notification.ChannelPush, "token-2")).
+					Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.DeliverNotificationResult, err error, email, push *service.FakeSender) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, result.Sent)
+				sent := push.Sent()
+				assert.Len(t, sent, 1)
+				assert.Equal(t, "token-2", sent[0].Address)
+			},
+		},
+		{
+			name:  "given the deliveries cannot be checked when delivering then returns error and nothing is sent",
+			input: input,
+			on: func(t *testing.T, d dependencies) {
+				d.notifications.On("FindByID", isCtx, "requester-1", entity.ID).Return(&entity, nil)
+				d.preferences.On("Find", isCtx, "requester-1").Return(notification.NewPreferences("requester-1"), nil)
+				d.accounts.On("Find", isCtx, account.DomainQuery{AccountIDs: []string{"requester-1"}}).
+					Return([]account.Entity{{ID: "requester-1", Email: "requester@sportlink.app"}}, nil)
+				d.matchOffers.On("Find", isCtx, matchoffer.DomainQuery{IDs: []string{"offer-1"}}).
+					Return(matchoffer.Page{}, nil)
+				d.delivered.On("IsProcessed", isCtx, "notification-delivery", deliveryKey(notification.ChannelEmail, "requester@sportlink.app")).
+					Return(false, errors.New("db connection error"))
+			},
+			then: func(t *testing.T, result *usecases.DeliverNotificationResult, err error, email, push *service.FakeSender) {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				assert.Empty(t, email.Sent())
+			},
+		},
+		{
+			name:  "given the account turned the channel off after the request when delivering then nothing is sent",
+			input: input,
+			on: func(t *testing.T, d dependencies) {
+				d.notifications.On("FindByID", isCtx, "requester-1", entity.ID).Return(&entity, nil)
+				d.preferences.On("Find", isCtx, "requester-1").Return(
+					notification.NewPreferences("requester-1").WithChannel(notification.ChannelEmail, false), nil,
+				)
+			},
+			then: func(t *testing.T, result *usecases.DeliverNotificationResult, err error, email, push *service.FakeSender) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, result.Sent)
+				assert.Empty(t, email.Sent())
+			},
+		},
+		{
+			name:  "given the account is in quiet hours when delivering then the delivery is deferred to their end",
+			input: input,
+			on: func(t *testing.T, d dependencies) {
+				preferences := notification.NewPreferences("requester-1")
+				preferences.QuietHours = &afternoon
+				d.notifications.On("FindByID", isCtx, "requester-1", entity.ID).Return(&entity, nil)
+				d.preferences.On("Find", isCtx, "requester-1").Return(preferences, nil)
+			},
+			then: func(t *testing.T, result *usecases.DeliverNotificationResult, err error, email, push *service.FakeSender) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, result.Sent)
+				assert.NotNil(t, result.DeferredUntil)
+				assert.True(t, result.DeferredUntil.Equal(time.Date(2026, 5, 10, 19, 0, 0, 0, time.UTC)))
+				assert.Empty(t, email.Sent())
+			},
+		},
+		{
+			name:  "given the notification no longer exists when delivering then nothing is sent",
+			input: input,
+			on: func(t *testing.T, d dependencies) {
+				d.notifications.On("FindByID", isCtx, "requester-1", entity.ID).Return(nil, nil)
+			},
+			then: func(t *testing.T, result *usecases.DeliverNotificationResult, err error, email, push *service.FakeSender) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, result.Sent)
+				assert.Empty(t, email.Sent())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := dependencies{
+				notifications: notificationmocks.NewRepository(t),
+				preferences:   notificationmocks.NewPreferencesRepository(t),
+				accounts:      accountmocks.NewRepository(t),
+				matchOffers:   matchoffermocks.NewRepository(t),
+				delivered:     outboxmocks.NewProcessedRepository(t),
+			}
+			email := service.NewFakeSender()
+			push := service.NewFakeSender()
+			uc := usecases.NewDeliverNotificationUC(
+				d.notifications, d.preferences, d.accounts, d.matchOffers, d.delivered, service.NewRenderer(),
+				map[notification.Channel]service.Sender{
+					notification.ChannelEmail: email,
+					notification.ChannelPush:  push,
+				},
+			)
+
+			tc.on(t, d)
+
+			result, err := uc.Invoke(ctx, tc.input)
+
+			tc.then(t, result, err, email, push)
+		})
+	}
+}
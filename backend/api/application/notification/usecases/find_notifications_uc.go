@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/notification"
+	"sportlink/pkg/log"
+)
+
+// FindNotificationsUC returns a page of the inbox of an account, newest first.
+type FindNotificationsUC struct {
+	notificationRepository notification.Repository
+}
+
+func NewFindNotificationsUC(notificationRepository notification.Repository) *FindNotificationsUC {
+	return &FindNotificationsUC{notificationRepository: notificationRepository}
+}
+
+func (uc *FindNotificationsUC) Invoke(ctx context.Context, query notification.DomainQuery) (*notification.Page, error) {
+	if query.Limit <= 0 {
+		query.Limit = notification.DefaultPageSize
+	}
+	query.Limit = min(query.Limit, notification.MaxPageSize)
+
+	page, err := uc.notificationRepository.Find(ctx, query)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to find notifications of account %s", query.AccountID), err)
+		return nil, err
+	}
+	return &page, nil
+}
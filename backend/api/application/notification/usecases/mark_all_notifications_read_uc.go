@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/notification"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type MarkAllNotificationsReadResult struct {
+	Updated int // number of notifications that were unread
+}
+
+// MarkAllNotificationsReadUC flags every unread notification of the account as read,
+// walking the unread inbox one page at a time.
+type MarkAllNotificationsReadUC struct {
+	notificationRepository notification.Repository
+}
+
+func NewMarkAllNotificationsReadUC(notificationRepository notification.Repository) *MarkAllNotificationsReadUC {
+	return &MarkAllNotificationsReadUC{notificationRepository: notificationRepository}
+}
+
+func (uc *MarkAllNotificationsReadUC) Invoke(ctx context.Context, accountID string) (*MarkAllNotificationsReadResult, error) {
+	now := time.Now()
+	result := &MarkAllNotificationsReadResult{}
+
+	query := notification.DomainQuery{AccountID: accountID, UnreadOnly: true, Limit: notification.MaxPageSize}
+	for {
+		page, err := uc.notificationRepository.Find(ctx, query)
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to find unread notifications of account %s", accountID), err)
+			return nil, err
+		}
+
+		read := make([]notification.Entity, len(page.Entities))
+		for i, entity := range page.Entities {
+			read[i] = entity.MarkRead(now)
+		}
+		if err = uc.notificationRepository.SaveAll(ctx, read); err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to mark notifications of account %s as read", accountID), err)
+			return nil, err
+		}
+		result.Updated += len(read)
+
+		if page.NextCursor == "" {
+			return result, nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,91 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sportlink/api/application/notification/usecases"
+	"sportlink/api/domain/notification"
+	notificationmocks "sportlink/mocks/api/domain/notification"
+)
+
+func TestMarkAllNotificationsReadUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2026, 5, 10, 18, 0, 0, 0, time.UTC)
+
+	unread := func(id string) notification.Entity {
+		return notification.NewNotification(id, "account-1", notification.TypeMatchRequestReceived, nil, createdAt)
+	}
+
+	isCtx := mock.MatchedBy(func(c context.Context) bool { return c == ctx })
+	isUnreadPage := func(cursor string) interface{} {
+		return mock.MatchedBy(func(q notification.DomainQuery) bool {
+			return q.AccountID == "account-1" && q.UnreadOnly && q.Cursor == cursor
+		})
+	}
+	allRead := func(ids ...string) interface{} {
+		return mock.MatchedBy(func(entities []notification.Entity) bool {
+			if len(entities) != len(ids) {
+				return false
+			}
+			for i, e := range entities {
+				if e.ID != ids[i] || !e.IsRead() {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	testCases := []struct {
+		name string
+		on   func(t *testing.T, repository *notificationmocks.Repository)
+		then func(t *testing.T, result *usecases.MarkAllNotificationsReadResult, err error)
+	}{
+		{
+			name: "given unread notifications over two pages when marking all read then every page is marked",
+			on: func(t *testing.T, repository *notificationmocks.Repository) {
+				repository.On("Find", isCtx, isUnreadPage("")).
+					Return(notification.Page{Entities: []notification.Entity{unread("n-3"), unread("n-2")}, NextCursor: "n-2"}, nil)
+				repository.On("SaveAll", isCtx, allRead("n-3", "n-2")).Return(nil)
+				repository.On("Find", isCtx, isUnreadPage("n-2")).
+					Return(notification.Page{Entities: []notification.Entity{unread("n-1")}}, nil)
+				repository.On("SaveAll", isCtx, allRead("n-1")).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.MarkAllNotificationsReadResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 3, result.Updated)
+			},
+		},
+		{
+			name: "given the inbox cannot be read when marking all read then returns error",
+			on: func(t *testing.T, repository *notificationmocks.Repository) {
+				repository.On("Find", isCtx, isUnreadPage("")).Return(notification.Page{}, errors.New("db connection error"))
+			},
+			then: func(t *testing.T, result *usecases.MarkAllNotificationsReadResult, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repository := notificationmocks.NewRepository(t)
+			uc := usecases.NewMarkAllNotificationsReadUC(repository)
+
+			tc.on(t, repository)
+
+			result, err := uc.Invoke(ctx, "account-1")
+
+			tc.then(t, result, err)
+		})
+	}
+}
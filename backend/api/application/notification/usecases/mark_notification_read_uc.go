@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/notification"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type MarkNotificationReadInput struct {
+	AccountID      string
+	NotificationID string
+}
+
+// MarkNotificationReadUC flags one notification of the account's inbox as read.
+type MarkNotificationReadUC struct {
+	notificationRepository notification.Repository
+}
+
+func NewMarkNotificationReadUC(notificationRepository notification.Repository) *MarkNotificationReadUC {
+	return &MarkNotificationReadUC{notificationRepository: notificationRepository}
+}
+
+func (uc *MarkNotificationReadUC) Invoke(ctx context.Context, input MarkNotificationReadInput) (*notification.Entity, error) {
+	entity, err := uc.notificationRepository.FindByID(ctx, input.AccountID, input.NotificationID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get notification %s", input.NotificationID), err)
+		return nil, err
+	}
+	if entity == nil {
+		return nil, errors.NotFound("notification not found")
+	}
+	if entity.IsRead() {
+		return entity, nil
+	}
+
+	read := entity.MarkRead(time.Now())
+	if err = uc.notificationRepository.Save(ctx, read, nil); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to mark notification %s as read", input.NotificationID), err)
+		return nil, err
+	}
+	return &read, nil
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	appevents "sportlink/api/application/events"
+	notificationevent "sportlink/api/application/notification/events"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type NotifyAccountsInput struct {
+	EventID    string // ID of the domain event, reused as notification ID
+	Type       notification.Type
+	Recipients []string
+	Actor      string // account that caused the event; it is never notified about its own action
+	Data       map[string]string
+	OccurredAt time.Time
+}
+
+// NotifyAccountsUC adds a notification to the inbox of every recipient that wants
+// notifications of the type, and asks for it to be delivered through each off-app
+// channel the recipient keeps on. The delivery requests are written together with the
+// notification, and notifications are keyed by the event ID, so running it again for a
+// redelivered event neither duplicates them nor sends them twice.
+type NotifyAccountsUC struct {
+	notificationRepository notification.Repository
+	preferencesRepository  notification.PreferencesRepository
+}
+
+func NewNotifyAccountsUC(
+	notificationRepository notification.Repository,
+	preferencesRepository notification.PreferencesRepository,
+) *NotifyAccountsUC {
+	return &NotifyAccountsUC{
+		notificationRepository: notificationRepository,
+		preferencesRepository:  preferencesRepository,
+	}
+}
+
+func (uc *NotifyAccountsUC) Invoke(ctx context.Context, input NotifyAccountsInput) (*[]notification.Entity, error) {
+	notified := make([]notification.Entity, 0, len(input.Recipients))
+	for _, accountID := range uniqueRecipients(input.Recipients, input.Actor) {
+		preferences, err := uc.preferencesRepository.Find(ctx, accountID)
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to get notification preferences of account %s", accountID), err)
+			return nil, err
+		}
+		if !preferences.Allows(input.Type) {
+			continue
+		}
+
+		existing, err := uc.notificationRepository.FindByID(ctx, accountID, input.EventID)
+		if err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to get notification %s of account %s", input.EventID, accountID), err)
+			return nil, err
+		}
+		if existing != nil {
+			continue
+		}
+
+		entity := notification.NewNotification(input.EventID, accountID, input.Type, input.Data, input.OccurredAt)
+		messages, err := deliveryRequests(ctx, entity, preferences)
+		if err != nil {
+			return nil, fmt.Errorf("error while building delivery requests of notification %s: %w", entity.ID, err)
+		}
+		if err = uc.notificationRepository.Save(ctx, entity, messages); err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to save notification %s for account %s", input.EventID, accountID), err)
+			return nil, err
+		}
+		notified = append(notified, entity)
+	}
+	return &notified, nil
+}
+
+// deliveryRequests asks for the notification to be sent through every channel the
+// account keeps on.
+func deliveryRequests(ctx context.Context, entity notification.Entity, preferences notification.Preferences) ([]outbox.Message, error) {
+	var events []appevents.Event
+	for _, channel := range notification.Channels() {
+		if !preferences.AllowsChannel(channel) {
+			continue
+		}
+		events = append(events, notificationevent.NotificationDeliveryRequestedEvent{
+			NotificationID: entity.ID,
+			AccountID:      entity.AccountID,
+			Channel:        channel,
+		})
+	}
+	return appevents.NewMessages(ctx, time.Now(), events...)
+}
+
+// uniqueRecipients drops empty and repeated account IDs and the actor.
+func uniqueRecipients(recipients []string, actor string) []string {
+	seen := make(map[string]bool, len(recipients))
+	unique := make([]string, 0, len(recipients))
+	for _, accountID := range recipients {
+		if accountID == "" || accountID == actor || seen[accountID] {
+			continue
+		}
+		seen[accountID] = true
+		unique = append(unique, accountID)
+	}
+	return unique
+}
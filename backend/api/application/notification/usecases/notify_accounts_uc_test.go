@@ -0,0 +1,159 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	notificationevent "sportlink/api/application/notification/events"
+	"sportlink/api/application/notification/usecases"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	notificationmocks "sportlink/mocks/api/domain/notification"
+)
+
+func TestNotifyAccountsUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	occurredAt := time.Date(2026, 5, 10, 18, 0, 0, 0, time.UTC)
+
+	input := usecases.NotifyAccountsInput{
+		EventID:    "01JEVENT0000000000000000AB",
+		Type:       notification.TypeMatchRequestCancelled,
+		Recipients: []string{"owner-1", "requester-1"},
+		Actor:      "requester-1",
+		Data:       map[string]string{"match_offer_id": "offer-1"},
+		OccurredAt: occurredAt,
+	}
+
+	isCtx := mock.MatchedBy(func(c context.Context) bool { return c == ctx })
+	requestsDeliveries := func(accountID string, channels ...notification.Channel) interface{} {
+		return mock.MatchedBy(func(messages []outbox.Message) bool {
+			if len(messages) != len(channels) {
+				return false
+			}
+			for i, message := range messages {
+				var e notificationevent.NotificationDeliveryRequestedEvent
+				if message.Type != notificationevent.NotificationDeliveryRequestedEventType || message.Decode(&e) != nil ||
+					e != (notificationevent.NotificationDeliveryRequestedEvent{NotificationID: input.EventID, AccountID: accountID, Channel: channels[i]}) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	testCases := []struct {
+		name  string
+		input usecases.NotifyAccountsInput
+		on    func(t *testing.T, repository *notificationmocks.Repository, preferences *notificationmocks.PreferencesRepository)
+		then  func(t *testing.T, result *[]notification.Entity, err error)
+	}{
+		{
+			name:  "given an event caused by one of the recipients when notifying then only the other recipient is notified",
+			input: input,
+			on: func(t *testing.T, repository *notificationmocks.Repository, preferences *notificationmocks.PreferencesRepository) {
+				preferences.On("Find", isCtx, "owner-1").Return(notification.NewPreferences("owner-1"), nil)
+				repository.On("FindByID", isCtx, "owner-1", input.EventID).Return(nil, nil)
+				repository.On("Save", isCtx, notification.NewNotification(
+					"01JEVENT0000000000000000AB", "owner-1", notification.TypeMatchRequestCancelled,
+					map[string]string{"match_offer_id": "offer-1"}, occurredAt,
+				), requestsDeliveries("owner-1", notification.Channels()...)).Return(nil)
+			},
+			then: func(t *testing.T, result *[]notification.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, *result, 1)
+				assert.Equal(t, "owner-1", (*result)[0].AccountID)
+				assert.False(t, (*result)[0].IsRead())
+			},
+		},
+		{
+			name: "given a recipient that turned the type off when notifying then nothing is saved for it",
+			input: usecases.NotifyAccountsInput{
+				EventID:    input.EventID,
+				Type:       notification.TypeMatchRequestCancelled,
+				Recipients: []string{"owner-1"},
+				OccurredAt: occurredAt,
+			},
+			on: func(t *testing.T, repository *notificationmocks.Repository, preferences *notificationmocks.PreferencesRepository) {
+				preferences.On("Find", isCtx, "owner-1").Return(
+					notification.NewPreferences("owner-1").With(notification.TypeMatchRequestCancelled, false), nil,
+				)
+			},
+			then: func(t *testing.T, result *[]notification.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, *result)
+			},
+		},
+		{
+			name: "given a recipient listed twice when notifying then it gets a single notification delivered through its channels only",
+			input: usecases.NotifyAccountsInput{
+				EventID:    input.EventID,
+				Type:       notification.TypeMatchConfirmed,
+				Recipients: []string{"owner-1", "owner-1"},
+				Actor:      "system",
+				OccurredAt: occurredAt,
+			},
+			on: func(t *testing.T, repository *notificationmocks.Repository, preferences *notificationmocks.PreferencesRepository) {
+				preferences.On("Find", isCtx, "owner-1").Return(
+					notification.NewPreferences("owner-1").WithChannel(notification.ChannelPush, false), nil,
+				).Once()
+				repository.On("FindByID", isCtx, "owner-1", input.EventID).Return(nil, nil).Once()
+				repository.On("Save", isCtx, mock.MatchedBy(func(e notification.Entity) bool {
+					return e.ID == input.EventID && e.AccountID == "owner-1" && e.Type == notification.TypeMatchConfirmed
+				}), requestsDeliveries("owner-1", notification.ChannelEmail)).Return(nil).Once()
+			},
+			then: func(t *testing.T, result *[]notification.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, *result, 1)
+			},
+		},
+		{
+			name:  "given a redelivered event when notifying then the existing notification is neither saved nor delivered again",
+			input: input,
+			on: func(t *testing.T, repository *notificationmocks.Repository, preferences *notificationmocks.PreferencesRepository) {
+				existing := notification.NewNotification(input.EventID, "owner-1", input.Type, input.Data, occurredAt)
+				preferences.On("Find", isCtx, "owner-1").Return(notification.NewPreferences("owner-1"), nil)
+				repository.On("FindByID", isCtx, "owner-1", input.EventID).Return(&existing, nil)
+			},
+			then: func(t *testing.T, result *[]notification.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, *result)
+			},
+		},
+		{
+			name:  "given the notification cannot be saved when notifying then returns error so the event is retried",
+			input: input,
+			on: func(t *testing.T, repository *notificationmocks.Repository, preferences *notificationmocks.PreferencesRepository) {
+				preferences.On("Find", isCtx, "owner-1").Return(notification.NewPreferences("owner-1"), nil)
+				repository.On("FindByID", isCtx, "owner-1", input.EventID).Return(nil, nil)
+				repository.On("Save", isCtx, mock.MatchedBy(func(e notification.Entity) bool {
+					return e.AccountID == "owner-1"
+				}), mock.Anything).Return(errors.New("db connection error"))
+			},
+			then: func(t *testing.T, result *[]notification.Entity, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repository := notificationmocks.NewRepository(t)
+			preferences := notificationmocks.NewPreferencesRepository(t)
+			uc := usecases.NewNotifyAccountsUC(repository, preferences)
+
+			tc.on(t, repository, preferences)
+
+			result, err := uc.Invoke(ctx, tc.input)
+
+			tc.then(t, result, err)
+		})
+	}
+}
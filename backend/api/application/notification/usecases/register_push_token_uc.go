@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/notification"
+	"sportlink/pkg/log"
+)
+
+type RegisterPushTokenInput struct {
+	AccountID string
+	Token     string // Web Push / FCM registration token of the device
+}
+
+// RegisterPushTokenUC adds a device to the ones push notifications are sent to.
+type RegisterPushTokenUC struct {
+	preferencesRepository notification.PreferencesRepository
+}
+
+func NewRegisterPushTokenUC(preferencesRepository notification.PreferencesRepository) *RegisterPushTokenUC {
+	return &RegisterPushTokenUC{preferencesRepository: preferencesRepository}
+}
+
+func (uc *RegisterPushTokenUC) Invoke(ctx context.Context, input RegisterPushTokenInput) (*notification.Preferences, error) {
+	preferences, err := uc.preferencesRepository.Find(ctx, input.AccountID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get notification preferences of account %s", input.AccountID), err)
+		return nil, err
+	}
+
+	preferences = preferences.WithPushToken(input.Token)
+	if err = uc.preferencesRepository.Save(ctx, preferences); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to register push token for account %s", input.AccountID), err)
+		return nil, err
+	}
+	return &preferences, nil
+}
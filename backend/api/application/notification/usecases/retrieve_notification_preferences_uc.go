@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/notification"
+	"sportlink/pkg/log"
+)
+
+// RetrieveNotificationPreferencesUC returns which notification types an account receives.
+type RetrieveNotificationPreferencesUC struct {
+	preferencesRepository notification.PreferencesRepository
+}
+
+func NewRetrieveNotificationPreferencesUC(preferencesRepository notification.PreferencesRepository) *RetrieveNotificationPreferencesUC {
+	return &RetrieveNotificationPreferencesUC{preferencesRepository: preferencesRepository}
+}
+
+func (uc *RetrieveNotificationPreferencesUC) Invoke(ctx context.Context, accountID string) (*notification.Preferences, error) {
+	preferences, err := uc.preferencesRepository.Find(ctx, accountID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get notification preferences of account %s", accountID), err)
+		return nil, err
+	}
+	return &preferences, nil
+}
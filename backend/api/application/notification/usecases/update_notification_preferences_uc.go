@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/notification"
+	"sportlink/pkg/log"
+)
+
+type UpdateNotificationPreferencesInput struct {
+	AccountID       string
+	Types           map[notification.Type]bool    // types to turn on (true) or off (false); others keep their setting
+	Channels        map[notification.Channel]bool // channels to turn on (true) or off (false); others keep their setting
+	QuietHours      *notification.QuietHours      // replaces the quiet hours when set
+	ClearQuietHours bool                          // removes the quiet hours
+	Locale          string                        // replaces the language when set
+}
+
+// UpdateNotificationPreferencesUC changes which notifications an account receives and
+// how it is reached off-app.
+type UpdateNotificationPreferencesUC struct {
+	preferencesRepository notification.PreferencesRepository
+}
+
+func NewUpdateNotificationPreferencesUC(preferencesRepository notification.PreferencesRepository) *UpdateNotificationPreferencesUC {
+	return &UpdateNotificationPreferencesUC{preferencesRepository: preferencesRepository}
+}
+
+func (uc *UpdateNotificationPreferencesUC) Invoke(ctx context.Context, input UpdateNotificationPreferencesInput) (*notification.Preferences, error) {
+	preferences, err := uc.preferencesRepository.Find(ctx, input.AccountID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to get notification preferences of account %s", input.AccountID), err)
+		return nil, err
+	}
+
+	for notificationType, enabled := range input.Types {
+		preferences = preferences.With(notificationType, enabled)
+	}
+	for channel, enabled := range input.Channels {
+		preferences = preferences.WithChannel(channel, enabled)
+	}
+	if input.ClearQuietHours {
+		preferences.QuietHours = nil
+	}
+	if input.QuietHours != nil {
+		preferences.QuietHours = input.QuietHours
+	}
+	if input.Locale != "" {
+		preferences.Locale = input.Locale
+	}
+
+	if err = uc.preferencesRepository.Save(ctx, preferences); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to save notification preferences of account %s", input.AccountID), err)
+		return nil, err
+	}
+	return &preferences, nil
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application/messaging"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+	"time"
+)
+
+type RelayOutboxInput struct {
+	Now       time.Time
+	BatchSize int
+}
+
+type RelayOutboxResult struct {
+	Published int
+	Retried   int
+	Failed    int
+}
+
+// RelayOutboxUC hands the due outbox messages over to the broker. A message is only
+// removed from the outbox after the broker took it, so a crash in between publishes it
+// again and consumers see it at least once. Failed publishes are retried with backoff
+// until the retry policy gives up and the message is left FAILED in the outbox.
+type RelayOutboxUC struct {
+	outboxRepository outbox.Repository
+	broker           messaging.Broker
+	retryPolicy      outbox.RetryPolicy
+}
+
+func NewRelayOutboxUC(
+	outboxRepository outbox.Repository,
+	broker messaging.Broker,
+	retryPolicy outbox.RetryPolicy,
+) *RelayOutboxUC {
+	return &RelayOutboxUC{
+		outboxRepository: outboxRepository,
+		broker:           broker,
+		retryPolicy:      retryPolicy,
+	}
+}
+
+func (uc *RelayOutboxUC) Invoke(ctx context.Context, input RelayOutboxInput) (*RelayOutboxResult, error) {
+	messages, err := uc.outboxRepository.FindDue(ctx, input.Now, input.BatchSize)
+	if err != nil {
+		log.GetLogger(ctx).Error("failed to find due outbox messages", err)
+		return nil, err
+	}
+
+	result := &RelayOutboxResult{}
+	for _, message := range messages {
+		if err = uc.publish(ctx, message); err == nil {
+			result.Published++
+			continue
+		}
+
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to publish outbox message %s of type %s", message.ID, message.Type), err)
+		failed := message.FailedAttempt(err, input.Now, uc.retryPolicy)
+		if err = uc.outboxRepository.Save(ctx, failed); err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to record attempt of outbox message %s", message.ID), err)
+			continue
+		}
+		if failed.Status == outbox.StatusFailed {
+			result.Failed++
+		} else {
+			result.Retried++
+		}
+	}
+
+	return result, nil
+}
+
+func (uc *RelayOutboxUC) publish(ctx context.Context, message outbox.Message) error {
+	body, err := message.ToEnvelope()
+	if err != nil {
+		return err
+	}
+	if err = uc.broker.SendMessage(ctx, body); err != nil {
+		return err
+	}
+	if err = uc.outboxRepository.Delete(ctx, message.ID); err != nil {
+		// the message will be published again; consumers drop it by its ID
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to remove published outbox message %s", message.ID), err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"sportlink/api/application/outbox/usecases"
+	"sportlink/api/domain/outbox"
+	messagingmocks "sportlink/mocks/api/application/messaging"
+	outboxmocks "sportlink/mocks/api/domain/outbox"
+)
+
+func TestRelayOutboxUC_Invoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2030, 5, 10, 18, 0, 0, 0, time.UTC)
+	policy := outbox.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute}
+	input := usecases.RelayOutboxInput{Now: now, BatchSize: 10}
+
+	pending := outbox.Message{
+		ID:            "01J0000000000000000000000A",
+		Type:          "matchoffer.capacity_reached",
+		Payload:       []byte(`{"match_offer_id":"offer-1"}`),
+		OccurredAt:    now,
+		Status:        outbox.StatusPending,
+		NextAttemptAt: now,
+	}
+	lastAttempt := pending
+	lastAttempt.ID = "01J0000000000000000000000B"
+	lastAttempt.Attempts = 2
+
+	testCases := []struct {
+		name string
+		on   func(t *testing.T, repo *outboxmocks.Repository, broker *messagingmocks.Broker)
+		then func(t *testing.T, result *usecases.RelayOutboxResult, err error)
+	}{
+		{
+			name: "given a due message when relaying then publishes its envelope and removes it from the outbox",
+			on: func(t *testing.T, repo *outboxmocks.Repository, broker *messagingmocks.Broker) {
+				repo.On("FindDue", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), now, 10).
+					Return([]outbox.Message{pending}, nil)
+				broker.On("SendMessage",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(body string) bool {
+						received, err := outbox.FromEnvelope(body)
+						return err == nil && received.ID == pending.ID && received.Type == pending.Type
+					}),
+				).Return(nil)
+				repo.On("Delete", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), pending.ID).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.RelayOutboxResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, usecases.RelayOutboxResult{Published: 1}, *result)
+			},
+		},
+		{
+			name: "given published message that cannot be removed when relaying then it still counts as published",
+			on: func(t *testing.T, repo *outboxmocks.Repository, broker *messagingmocks.Broker) {
+				repo.On("FindDue", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), now, 10).
+					Return([]outbox.Message{pending}, nil)
+				broker.On("SendMessage", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), mock.Anything).Return(nil)
+				repo.On("Delete", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), pending.ID).
+					Return(errors.New("dynamo unavailable"))
+			},
+			then: func(t *testing.T, result *usecases.RelayOutboxResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, usecases.RelayOutboxResult{Published: 1}, *result)
+			},
+		},
+		{
+			name: "given broker failure when relaying then schedules the message for a later retry",
+			on: func(t *testing.T, repo *outboxmocks.Repository, broker *messagingmocks.Broker) {
+				repo.On("FindDue", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), now, 10).
+					Return([]outbox.Message{pending}, nil)
+				broker.On("SendMessage", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), mock.Anything).
+					Return(errors.New("queue unavailable"))
+				repo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m outbox.Message) bool {
+						return m.ID == pending.ID && m.Status == outbox.StatusPending && m.Attempts == 1 &&
+							m.NextAttemptAt.Equal(now.Add(time.Second)) && m.LastError == "queue unavailable"
+					}),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.RelayOutboxResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, usecases.RelayOutboxResult{Retried: 1}, *result)
+			},
+		},
+		{
+			name: "given broker failure on the last allowed attempt when relaying then marks the message failed",
+			on: func(t *testing.T, repo *outboxmocks.Repository, broker *messagingmocks.Broker) {
+				repo.On("FindDue", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), now, 10).
+					Return([]outbox.Message{pending, lastAttempt}, nil)
+				broker.On("SendMessage", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), mock.Anything).
+					Return(nil).Once()
+				repo.On("Delete", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), pending.ID).Return(nil)
+				broker.On("SendMessage", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), mock.Anything).
+					Return(errors.New("queue unavailable")).Once()
+				repo.On("Save",
+					mock.MatchedBy(func(c context.Context) bool { return c == ctx }),
+					mock.MatchedBy(func(m outbox.Message) bool {
+						return m.ID == lastAttempt.ID && m.Status == outbox.StatusFailed && m.Attempts == 3
+					}),
+				).Return(nil)
+			},
+			then: func(t *testing.T, result *usecases.RelayOutboxResult, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, usecases.RelayOutboxResult{Published: 1, Failed: 1}, *result)
+			},
+		},
+		{
+			name: "given outbox read failure when relaying then returns error",
+			on: func(t *testing.T, repo *outboxmocks.Repository, broker *messagingmocks.Broker) {
+				repo.On("FindDue", mock.MatchedBy(func(c context.Context) bool { return c == ctx }), now, 10).
+					Return(nil, errors.New("dynamo unavailable"))
+			},
+			then: func(t *testing.T, result *usecases.RelayOutboxResult, err error) {
+				assert.Nil(t, result)
+				assert.EqualError(t, err, "dynamo unavailable")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := outboxmocks.NewRepository(t)
+			broker := messagingmocks.NewBroker(t)
+			uc := usecases.NewRelayOutboxUC(repo, broker, policy)
+
+			tc.on(t, repo, broker)
+
+			result, err := uc.Invoke(ctx, input)
+
+			tc.then(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package realtime
+
+import "context"
+
+// Backplane carries updates to every API instance, so an account receives them on
+// whichever instance holds its stream. Each instance hands what it receives to its Hub.
+type Backplane interface {
+	Publish(ctx context.Context, update Update) error
+}
@@ -0,0 +1,64 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"sportlink/pkg/log"
+	"sync"
+)
+
+// subscriptionBuffer is how many updates a slow stream may fall behind before new
+// ones are dropped for it.
+const subscriptionBuffer = 32
+
+// Hub keeps the streams open on this instance and delivers updates to the streams of
+// their account. An account may have several streams, e.g. one per device.
+type Hub struct {
+	mu      sync.RWMutex
+	streams map[string]map[chan Update]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{streams: map[string]map[chan Update]struct{}{}}
+}
+
+// Subscribe opens a stream for accountID. The returned function closes it and must be
+// called once the client is gone.
+func (h *Hub) Subscribe(accountID string) (<-chan Update, func()) {
+	stream := make(chan Update, subscriptionBuffer)
+
+	h.mu.Lock()
+	if h.streams[accountID] == nil {
+		h.streams[accountID] = map[chan Update]struct{}{}
+	}
+	h.streams[accountID][stream] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return stream, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.streams[accountID], stream)
+			if len(h.streams[accountID]) == 0 {
+				delete(h.streams, accountID)
+			}
+			h.mu.Unlock()
+			close(stream)
+		})
+	}
+}
+
+// Deliver pushes update to every stream of its account open on this instance. It never
+// blocks: a stream whose buffer is full misses the update.
+func (h *Hub) Deliver(ctx context.Context, update Update) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for stream := range h.streams[update.AccountID] {
+		select {
+		case stream <- update:
+		default:
+			log.GetLogger(ctx).Info(fmt.Sprintf("stream of account %s is full, dropping update %s", update.AccountID, update.ID))
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package realtime_test
+
+import (
+	"context"
+	"sportlink/api/application/realtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_Deliver(t *testing.T) {
+	ctx := context.Background()
+	update := realtime.Update{ID: "01JEVENT0000000000000000AB", AccountID: "account-1", Type: realtime.UpdateMatchConfirmed}
+
+	tests := []struct {
+		name string
+		then func(t *testing.T, hub *realtime.Hub)
+	}{
+		{
+			name: "given two streams of the account when delivering then both receive the update",
+			then: func(t *testing.T, hub *realtime.Hub) {
+				phone, closePhone := hub.Subscribe("account-1")
+				defer closePhone()
+				laptop, closeLaptop := hub.Subscribe("account-1")
+				defer closeLaptop()
+
+				hub.Deliver(ctx, update)
+
+				assert.Equal(t, update, <-phone)
+				assert.Equal(t, update, <-laptop)
+			},
+		},
+		{
+			name: "given a stream of another account when delivering then it receives nothing",
+			then: func(t *testing.T, hub *realtime.Hub) {
+				other, closeOther := hub.Subscribe("account-2")
+				defer closeOther()
+
+				hub.Deliver(ctx, update)
+
+				assert.Empty(t, other)
+			},
+		},
+		{
+			name: "given a closed stream when delivering then the update is dropped and the channel is closed",
+			then: func(t *testing.T, hub *realtime.Hub) {
+				stream, closeStream := hub.Subscribe("account-1")
+				closeStream()
+				closeStream()
+
+				hub.Deliver(ctx, update)
+
+				_, open := <-stream
+				assert.False(t, open)
+			},
+		},
+		{
+			name: "given a stream that stopped reading when delivering then the hub does not block",
+			then: func(t *testing.T, hub *realtime.Hub) {
+				stream, closeStream := hub.Subscribe("account-1")
+				defer closeStream()
+
+				for i := 0; i < 100; i++ {
+					hub.Deliver(ctx, update)
+				}
+
+				assert.NotEmpty(t, stream)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.then(t, realtime.NewHub())
+		})
+	}
+}
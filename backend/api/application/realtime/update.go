@@ -0,0 +1,24 @@
+package realtime
+
+import "time"
+
+const (
+	UpdateMatchRequestReceived = "match_request.received"
+	UpdateMatchRequestAccepted = "match_request.accepted"
+	UpdateMatchRequestRejected = "match_request.rejected"
+	UpdateMatchOfferFull       = "match_offer.full"
+	UpdateMatchConfirmed       = "match.confirmed"
+	UpdateChatMessage          = "chat.message"
+	UpdateChatRead             = "chat.read"
+)
+
+// Update is a change pushed to the open streams of one account. ID is the ID of the
+// domain event behind it; events are delivered at least once, so clients drop updates
+// whose ID they already saw.
+type Update struct {
+	ID         string            `json:"id"`
+	AccountID  string            `json:"account_id"`
+	Type       string            `json:"type"`
+	Data       map[string]string `json:"data"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
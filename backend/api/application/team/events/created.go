@@ -0,0 +1,19 @@
+package events
+
+import "sportlink/api/domain/common"
+
+// TeamCreatedEventType identifies TeamCreatedEvent messages in the outbox.
+const TeamCreatedEventType = "team.created"
+
+// TeamCreatedEvent is published when an account registers a new team.
+type TeamCreatedEvent struct {
+	TeamID         string          `json:"team_id"`
+	Name           string          `json:"name"`
+	Sport          common.Sport    `json:"sport"`
+	Category       common.Category `json:"category"`
+	OwnerAccountID string          `json:"owner_account_id,omitempty"`
+	MemberIDs      []string        `json:"member_ids"`
+}
+
+func (TeamCreatedEvent) EventType() string { return TeamCreatedEventType }
+func (TeamCreatedEvent) EventVersion() int { return 1 }
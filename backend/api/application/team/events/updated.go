@@ -0,0 +1,18 @@
+package events
+
+import "sportlink/api/domain/common"
+
+// TeamUpdatedEventType identifies TeamUpdatedEvent messages in the outbox.
+const TeamUpdatedEventType = "team.updated"
+
+// TeamUpdatedEvent is published when a team is changed. PreviousName differs from Name
+// when the team was renamed; its ID stays the same.
+type TeamUpdatedEvent struct {
+	TeamID       string       `json:"team_id"`
+	PreviousName string       `json:"previous_name"`
+	Name         string       `json:"name"`
+	Sport        common.Sport `json:"sport"`
+}
+
+func (TeamUpdatedEvent) EventType() string { return TeamUpdatedEventType }
+func (TeamUpdatedEvent) EventVersion() int { return 2 }
@@ -18,5 +18,20 @@ func CreationRequestToEntity(request team2.NewTeamRequest, ownerAccountID string
 	for _, playerId := range request.PlayerIds {
 		players = append(players, player.Entity{ID: playerId})
 	}
-	return team.NewTeam(request.Name, category, *stats, sport, players, ownerAccountID), nil
+	entity := team.NewTeam(request.Name, category, *stats, sport, players, ownerAccountID)
+	entity.LookingForPlayers = request.LookingForPlayers
+	entity.HomeArea = HomeAreaRequestToDomain(request.HomeArea)
+	return entity, nil
+}
+
+// HomeAreaRequestToDomain returns nil when the request has no home area.
+func HomeAreaRequestToDomain(request *team2.HomeArea) *team.HomeArea {
+	if request == nil {
+		return nil
+	}
+	return &team.HomeArea{
+		Locality:  request.Locality,
+		Latitude:  request.Latitude,
+		Longitude: request.Longitude,
+	}
 }
@@ -6,4 +6,14 @@ type NewTeamRequest struct {
 	Name      string   `json:"name" validate:"required"`
 	Category  int      `json:"category" validate:"omitempty"`
 	PlayerIds []string `json:"players" validate:"omitempty"`
+	// LookingForPlayers lists the team in the searches of players looking for one.
+	LookingForPlayers bool      `json:"looking_for_players"`
+	HomeArea          *HomeArea `json:"home_area" validate:"omitempty"` // absent = no home area
+}
+
+// HomeArea is where the team usually plays.
+type HomeArea struct {
+	Locality  string  `json:"locality" validate:"required"`
+	Latitude  float64 `json:"latitude" validate:"latitude"`
+	Longitude float64 `json:"longitude" validate:"longitude"`
 }
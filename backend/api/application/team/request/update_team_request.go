@@ -3,5 +3,7 @@ package request
 // UpdateTeamRequest defines the body for the team update (PATCH) endpoint.
 // All fields are optional; only provided fields are applied.
 type UpdateTeamRequest struct {
-	Name string `json:"name" validate:"omitempty"`
+	Name              string    `json:"name" validate:"omitempty"`
+	LookingForPlayers *bool     `json:"looking_for_players"`
+	HomeArea          *HomeArea `json:"home_area" validate:"omitempty"`
 }
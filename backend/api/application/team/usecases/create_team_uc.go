@@ -3,8 +3,12 @@ package usecases
 import (
 	"context"
 	"fmt"
+	appevents "sportlink/api/application/events"
+	teamevent "sportlink/api/application/team/events"
+	"sportlink/api/domain/outbox"
 	"sportlink/api/domain/player"
 	"sportlink/api/domain/team"
+	"time"
 )
 
 type CreateTeamUC struct {
@@ -12,7 +16,10 @@ type CreateTeamUC struct {
 	teamRepository   team.Repository
 }
 
-func NewCreateTeamUC(playerRepository player.Repository, teamRepository team.Repository) *CreateTeamUC {
+func NewCreateTeamUC(
+	playerRepository player.Repository,
+	teamRepository team.Repository,
+) *CreateTeamUC {
 	return &CreateTeamUC{
 		playerRepository: playerRepository,
 		teamRepository:   teamRepository,
@@ -25,7 +32,11 @@ func (uc *CreateTeamUC) Invoke(ctx context.Context, input team.Entity) (*team.En
 		return nil, err
 	}
 
-	err = uc.teamRepository.Save(ctx, input)
+	message, err := appevents.NewMessage(ctx, buildCreatedEvent(input), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error while building created event for team: %w", err)
+	}
+	err = uc.teamRepository.Save(ctx, input, []outbox.Message{message})
 	if err != nil {
 		return nil, fmt.Errorf("error while inserting team in database: %w", err)
 	}
@@ -53,3 +64,18 @@ func (uc *CreateTeamUC) validateTeamMembers(ctx context.Context, input team.Enti
 	}
 	return nil
 }
+
+func buildCreatedEvent(created team.Entity) teamevent.TeamCreatedEvent {
+	memberIDs := make([]string, len(created.Members))
+	for i, member := range created.Members {
+		memberIDs[i] = member.ID
+	}
+	return teamevent.TeamCreatedEvent{
+		TeamID:         created.ID,
+		Name:           created.Name,
+		Sport:          created.Sport,
+		Category:       created.Category,
+		OwnerAccountID: created.OwnerAccountID,
+		MemberIDs:      memberIDs,
+	}
+}
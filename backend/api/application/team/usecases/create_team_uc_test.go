@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	teamevent "sportlink/api/application/team/events"
 	"sportlink/api/application/team/usecases"
 	"sportlink/api/domain/common"
+	"sportlink/api/domain/outbox"
 	"sportlink/api/domain/player"
 	"sportlink/api/domain/team"
 	pmocks "sportlink/mocks/api/domain/player"
@@ -17,6 +19,11 @@ import (
 )
 
 func TestCreateTeamUC_Invoke(t *testing.T) {
+	isCreated := mock.MatchedBy(func(messages []outbox.Message) bool {
+		var event teamevent.TeamCreatedEvent
+		return len(messages) == 1 && messages[0].Type == teamevent.TeamCreatedEventType &&
+			messages[0].Decode(&event) == nil && event.Name == "Boca Jr" && event.Sport == common.Football
+	})
 
 	tests := []struct {
 		name   string
@@ -41,7 +48,7 @@ func TestCreateTeamUC_Invoke(t *testing.T) {
 						team.Sport == common.Football &&
 						len(team.Members) == 0 &&
 						team.Stats == *common.NewStats(10, 0, 0)
-				})).Return(nil)
+				}), isCreated).Return(nil)
 			},
 			then: func(t *testing.T, response *team.Entity, err error) {
 				assert.NoError(t, err)
@@ -53,7 +60,7 @@ func TestCreateTeamUC_Invoke(t *testing.T) {
 					make([]player.Entity, 0),
 					"",
 				)
-				assert.Equal(t, expected.ID, response.ID)
+				assert.NotEmpty(t, response.ID)
 				assert.Equal(t, expected.Name, response.Name)
 				assert.Equal(t, expected.Category, response.Category)
 				assert.Equal(t, expected.Sport, response.Sport)
@@ -87,7 +94,7 @@ func TestCreateTeamUC_Invoke(t *testing.T) {
 						team.Sport == common.Football &&
 						len(team.Members) == 2 &&
 						team.Stats == *common.NewStats(10, 0, 0)
-				})).Return(nil)
+				}), isCreated).Return(nil)
 
 				playerRepository.On("Find", mock.Anything, mock.MatchedBy(func(query player.DomainQuery) bool {
 					return reflect.DeepEqual(query.Ids, []string{"eldiegote", "elpajaro"})
@@ -125,7 +132,7 @@ func TestCreateTeamUC_Invoke(t *testing.T) {
 					},
 					"",
 				)
-				assert.Equal(t, expected.ID, response.ID)
+				assert.NotEmpty(t, response.ID)
 				assert.Equal(t, expected.Name, response.Name)
 				assert.Equal(t, expected.Category, response.Category)
 				assert.Equal(t, expected.Sport, response.Sport)
@@ -160,7 +167,7 @@ func TestCreateTeamUC_Invoke(t *testing.T) {
 						team.Sport == common.Football &&
 						len(team.Members) == 2 &&
 						team.Stats == *common.NewStats(10, 0, 0)
-				})).Return(nil)
+				}), mock.Anything).Return(nil)
 
 				playerRepository.On("Find", mock.Anything, mock.MatchedBy(func(query player.DomainQuery) bool {
 					return reflect.DeepEqual(query.Ids, []string{"eldiegote", "elpajaro"})
@@ -195,7 +202,7 @@ func TestCreateTeamUC_Invoke(t *testing.T) {
 						team.Sport == common.Football &&
 						len(team.Members) == 0 &&
 						team.Stats == *common.NewStats(10, 0, 0)
-				})).Return(fmt.Errorf("it was an error"))
+				}), mock.Anything).Return(fmt.Errorf("it was an error"))
 			},
 			then: func(t *testing.T, response *team.Entity, err error) {
 				assert.Error(t, err)
@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+	"sportlink/api/domain/team"
+)
+
+// ListAccountTeamsUC returns the teams an account owns.
+type ListAccountTeamsUC struct {
+	teamRepository team.Repository
+}
+
+func NewListAccountTeamsUC(teamRepository team.Repository) *ListAccountTeamsUC {
+	return &ListAccountTeamsUC{teamRepository: teamRepository}
+}
+
+func (uc *ListAccountTeamsUC) Invoke(ctx context.Context, accountID string) (*[]team.Entity, error) {
+	result, err := uc.teamRepository.Find(ctx, team.DomainQuery{OwnerAccountID: accountID})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
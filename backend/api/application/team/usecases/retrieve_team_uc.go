@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"fmt"
 	"sportlink/api/domain/common"
 	"sportlink/api/domain/team"
 )
@@ -17,14 +18,27 @@ func NewRetrieveTeamUC(teamRepository team.Repository) *RetrieveTeamUC {
 }
 
 func (uc *RetrieveTeamUC) Invoke(ctx context.Context, id team.ID) (*team.Entity, error) {
-	teams, err := uc.teamRepository.Find(ctx, team.DomainQuery{
-		Name: id.Name,
-		Sports: []common.Sport{
-			id.Sport,
-		},
-	})
+	entity, err := findByName(ctx, uc.teamRepository, id)
 	if err != nil {
 		return nil, err
 	}
-	return &teams[0], nil
+	return &entity, nil
+}
+
+// findByName returns the team of the sport with exactly the name, out of the teams whose
+// name starts with it.
+func findByName(ctx context.Context, teamRepository team.Repository, id team.ID) (team.Entity, error) {
+	teams, err := teamRepository.Find(ctx, team.DomainQuery{
+		Name:   id.Name,
+		Sports: []common.Sport{id.Sport},
+	})
+	if err != nil {
+		return team.Entity{}, fmt.Errorf("error finding team: %w", err)
+	}
+	for _, entity := range teams {
+		if entity.Name == id.Name {
+			return entity, nil
+		}
+	}
+	return team.Entity{}, fmt.Errorf("team not found")
 }
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/team"
+	"sportlink/pkg/log"
+)
+
+// SearchTeamsUC returns a page of the teams to discover, in search name order.
+type SearchTeamsUC struct {
+	teamRepository team.Repository
+}
+
+func NewSearchTeamsUC(teamRepository team.Repository) *SearchTeamsUC {
+	return &SearchTeamsUC{teamRepository: teamRepository}
+}
+
+func (uc *SearchTeamsUC) Invoke(ctx context.Context, query team.SearchQuery) (*team.Page, error) {
+	if query.Limit <= 0 {
+		query.Limit = team.DefaultPageSize
+	}
+	query.Limit = min(query.Limit, team.MaxPageSize)
+
+	page, err := uc.teamRepository.Search(ctx, query)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("failed to search teams named %q", query.Name), err)
+		return nil, err
+	}
+	return &page, nil
+}
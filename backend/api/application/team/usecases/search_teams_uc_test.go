@@ -0,0 +1,82 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application/team/usecases"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/team"
+	mmocks "sportlink/mocks/api/domain/team"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchTeamsUC_Invoke(t *testing.T) {
+	tests := []struct {
+		name  string
+		query team.SearchQuery
+		on    func(t *testing.T, repository *mmocks.Repository)
+		then  func(t *testing.T, result *team.Page, err error)
+	}{
+		{
+			name:  "given no limit when searching then asks for a default sized page",
+			query: team.SearchQuery{Name: "Boca", Fuzzy: true},
+			on: func(t *testing.T, repository *mmocks.Repository) {
+				repository.On("Search", mock.Anything, mock.MatchedBy(func(query team.SearchQuery) bool {
+					return query.Limit == team.DefaultPageSize && query.Name == "Boca" && query.Fuzzy
+				})).Return(team.Page{
+					Entities:   []team.Entity{{ID: "01JTEAM0000000000000000001", Name: "Boca Juniors", Sport: common.Football}},
+					NextCursor: "boca juniors#01JTEAM0000000000000000001",
+				}, nil)
+			},
+			then: func(t *testing.T, result *team.Page, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, result.Entities, 1)
+				assert.Equal(t, "boca juniors#01JTEAM0000000000000000001", result.NextCursor)
+			},
+		},
+		{
+			name:  "given a limit above the maximum when searching then asks for a page of the maximum size",
+			query: team.SearchQuery{Limit: 500, Cursor: "boca#01JTEAM0000000000000000001"},
+			on: func(t *testing.T, repository *mmocks.Repository) {
+				repository.On("Search", mock.Anything, mock.MatchedBy(func(query team.SearchQuery) bool {
+					return query.Limit == team.MaxPageSize && query.Cursor == "boca#01JTEAM0000000000000000001"
+				})).Return(team.Page{Entities: []team.Entity{}}, nil)
+			},
+			then: func(t *testing.T, result *team.Page, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, result.Entities)
+				assert.Empty(t, result.NextCursor)
+			},
+		},
+		{
+			name:  "given the repository fails when searching then returns its error",
+			query: team.SearchQuery{Limit: 10},
+			on: func(t *testing.T, repository *mmocks.Repository) {
+				repository.On("Search", mock.Anything, mock.Anything).Return(team.Page{}, fmt.Errorf("database connection error"))
+			},
+			then: func(t *testing.T, result *team.Page, err error) {
+				assert.EqualError(t, err, "database connection error")
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// setup
+			teamRepository := &mmocks.Repository{}
+			uc := usecases.NewSearchTeamsUC(teamRepository)
+
+			// given
+			tt.on(t, teamRepository)
+
+			// when
+			result, err := uc.Invoke(context.Background(), tt.query)
+
+			// then
+			tt.then(t, result, err)
+		})
+	}
+}
@@ -3,8 +3,11 @@ package usecases
 import (
 	"context"
 	"fmt"
-	"sportlink/api/domain/common"
+	appevents "sportlink/api/application/events"
+	teamevent "sportlink/api/application/team/events"
+	"sportlink/api/domain/outbox"
 	"sportlink/api/domain/team"
+	"time"
 )
 
 type UpdateTeamUC struct {
@@ -16,25 +19,33 @@ func NewUpdateTeamUC(teamRepository team.Repository) *UpdateTeamUC {
 }
 
 func (uc *UpdateTeamUC) Invoke(ctx context.Context, input team.PatchInput) (*team.Entity, error) {
-	teams, err := uc.teamRepository.Find(ctx, team.DomainQuery{
-		Name:   input.ID.Name,
-		Sports: []common.Sport{input.ID.Sport},
-	})
+	entity, err := findByName(ctx, uc.teamRepository, input.ID)
 	if err != nil {
-		return nil, fmt.Errorf("error finding team: %w", err)
+		return nil, err
 	}
-	if len(teams) == 0 {
-		return nil, fmt.Errorf("team not found")
-	}
-
-	entity := teams[0]
-	oldID := entity.ID
+	previousName := entity.Name
 
 	if input.Name != nil {
 		entity = entity.WithName(*input.Name)
 	}
+	if input.LookingForPlayers != nil {
+		entity.LookingForPlayers = *input.LookingForPlayers
+	}
+	if input.HomeArea != nil {
+		entity.HomeArea = input.HomeArea
+	}
+
+	message, err := appevents.NewMessage(ctx, teamevent.TeamUpdatedEvent{
+		TeamID:       entity.ID,
+		PreviousName: previousName,
+		Name:         entity.Name,
+		Sport:        entity.Sport,
+	}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error building updated event for team: %w", err)
+	}
 
-	if err = uc.teamRepository.Update(ctx, oldID, entity); err != nil {
+	if err = uc.teamRepository.Update(ctx, previousName, entity, []outbox.Message{message}); err != nil {
 		return nil, fmt.Errorf("error updating team: %w", err)
 	}
 
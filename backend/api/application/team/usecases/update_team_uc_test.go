@@ -3,8 +3,10 @@ package usecases_test
 import (
 	"context"
 	"fmt"
+	teamevent "sportlink/api/application/team/events"
 	"sportlink/api/application/team/usecases"
 	"sportlink/api/domain/common"
+	"sportlink/api/domain/outbox"
 	"sportlink/api/domain/player"
 	"sportlink/api/domain/team"
 	mmocks "sportlink/mocks/api/domain/team"
@@ -16,7 +18,7 @@ import (
 
 func TestUpdateTeamUC_Invoke(t *testing.T) {
 	existingTeam := team.Entity{
-		ID:       "SPORT#Football#NAME#Boca Juniors",
+		ID:       "01JA0000000000000000000001",
 		Name:     "Boca Juniors",
 		Sport:    common.Football,
 		Category: common.L1,
@@ -41,14 +43,18 @@ func TestUpdateTeamUC_Invoke(t *testing.T) {
 					return q.Name == "Boca Juniors" && len(q.Sports) == 1 && q.Sports[0] == common.Football
 				})).Return([]team.Entity{existingTeam}, nil)
 
-				repo.On("Update", mock.Anything, "SPORT#Football#NAME#Boca Juniors", mock.MatchedBy(func(e team.Entity) bool {
-					return e.Name == "Boca Senior" && e.ID == "SPORT#Football#NAME#Boca Senior"
+				repo.On("Update", mock.Anything, "Boca Juniors", mock.MatchedBy(func(e team.Entity) bool {
+					return e.Name == "Boca Senior" && e.ID == existingTeam.ID
+				}), mock.MatchedBy(func(messages []outbox.Message) bool {
+					var e teamevent.TeamUpdatedEvent
+					return len(messages) == 1 && messages[0].Decode(&e) == nil &&
+						e.TeamID == existingTeam.ID && e.PreviousName == "Boca Juniors" && e.Name == "Boca Senior"
 				})).Return(nil)
 			},
 			then: func(t *testing.T, result *team.Entity, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, "Boca Senior", result.Name)
-				assert.Equal(t, "SPORT#Football#NAME#Boca Senior", result.ID)
+				assert.Equal(t, existingTeam.ID, result.ID)
 			},
 		},
 		{
@@ -58,8 +64,12 @@ func TestUpdateTeamUC_Invoke(t *testing.T) {
 			},
 			on: func(t *testing.T, repo *mmocks.Repository) {
 				repo.On("Find", mock.Anything, mock.Anything).Return([]team.Entity{existingTeam}, nil)
-				repo.On("Update", mock.Anything, "SPORT#Football#NAME#Boca Juniors", mock.MatchedBy(func(e team.Entity) bool {
+				repo.On("Update", mock.Anything, "Boca Juniors", mock.MatchedBy(func(e team.Entity) bool {
 					return e.Name == "Boca Juniors"
+				}), mock.MatchedBy(func(messages []outbox.Message) bool {
+					var e teamevent.TeamUpdatedEvent
+					return len(messages) == 1 && messages[0].Decode(&e) == nil &&
+						e.PreviousName == "Boca Juniors"
 				})).Return(nil)
 			},
 			then: func(t *testing.T, result *team.Entity, err error) {
@@ -67,6 +77,58 @@ func TestUpdateTeamUC_Invoke(t *testing.T) {
 				assert.Equal(t, "Boca Juniors", result.Name)
 			},
 		},
+		{
+			name: "sets whether the team looks for players and its home area, keeping its name",
+			input: team.PatchInput{
+				ID:                team.ID{Sport: common.Football, Name: "Boca Juniors"},
+				LookingForPlayers: boolPtr(true),
+				HomeArea:          &team.HomeArea{Locality: "La Boca", Latitude: -34.6345, Longitude: -58.3631},
+			},
+			on: func(t *testing.T, repo *mmocks.Repository) {
+				repo.On("Find", mock.Anything, mock.Anything).Return([]team.Entity{existingTeam}, nil)
+				repo.On("Update", mock.Anything, "Boca Juniors", mock.MatchedBy(func(e team.Entity) bool {
+					return e.Name == "Boca Juniors" && e.LookingForPlayers && e.HomeArea != nil && e.HomeArea.Locality == "La Boca"
+				}), mock.Anything).Return(nil)
+			},
+			then: func(t *testing.T, result *team.Entity, err error) {
+				assert.NoError(t, err)
+				assert.True(t, result.LookingForPlayers)
+				assert.Equal(t, &team.HomeArea{Locality: "La Boca", Latitude: -34.6345, Longitude: -58.3631}, result.HomeArea)
+			},
+		},
+		{
+			name: "renames the team with exactly the name among those starting with it",
+			input: team.PatchInput{
+				ID:   team.ID{Sport: common.Football, Name: "Boca"},
+				Name: strPtr("Boca Unidos"),
+			},
+			on: func(t *testing.T, repo *mmocks.Repository) {
+				boca := team.Entity{ID: "01JA0000000000000000000002", Name: "Boca", Sport: common.Football}
+				repo.On("Find", mock.Anything, mock.Anything).Return([]team.Entity{existingTeam, boca}, nil)
+				repo.On("Update", mock.Anything, "Boca", mock.MatchedBy(func(e team.Entity) bool {
+					return e.ID == boca.ID && e.Name == "Boca Unidos"
+				}), mock.Anything).Return(nil)
+			},
+			then: func(t *testing.T, result *team.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "01JA0000000000000000000002", result.ID)
+			},
+		},
+		{
+			name: "returns error when the new name is taken",
+			input: team.PatchInput{
+				ID:   team.ID{Sport: common.Football, Name: "Boca Juniors"},
+				Name: strPtr("River"),
+			},
+			on: func(t *testing.T, repo *mmocks.Repository) {
+				repo.On("Find", mock.Anything, mock.Anything).Return([]team.Entity{existingTeam}, nil)
+				repo.On("Update", mock.Anything, "Boca Juniors", mock.Anything, mock.Anything).Return(team.ErrNameTaken)
+			},
+			then: func(t *testing.T, result *team.Entity, err error) {
+				assert.ErrorIs(t, err, team.ErrNameTaken)
+				assert.Nil(t, result)
+			},
+		},
 		{
 			name: "returns error when team not found",
 			input: team.PatchInput{
@@ -104,7 +166,7 @@ func TestUpdateTeamUC_Invoke(t *testing.T) {
 			},
 			on: func(t *testing.T, repo *mmocks.Repository) {
 				repo.On("Find", mock.Anything, mock.Anything).Return([]team.Entity{existingTeam}, nil)
-				repo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("update failed"))
+				repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("update failed"))
 			},
 			then: func(t *testing.T, result *team.Entity, err error) {
 				assert.Error(t, err)
@@ -130,3 +192,4 @@ func TestUpdateTeamUC_Invoke(t *testing.T) {
 }
 
 func strPtr(s string) *string { return &s }
+func boolPtr(b bool) *bool    { return &b }
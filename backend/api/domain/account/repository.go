@@ -1,9 +1,14 @@
 package account
 
-import "context"
+import (
+	"context"
+	"slices"
+	"sportlink/api/domain/outbox"
+)
 
 type Repository interface {
-	Save(ctx context.Context, entity Entity) error
+	// Save writes the account together with the outbox messages raised by the change.
+	Save(ctx context.Context, entity Entity, messages []outbox.Message) error
 	Find(ctx context.Context, query DomainQuery) ([]Entity, error)
 }
 
@@ -13,3 +18,12 @@ type DomainQuery struct {
 	Emails     []string
 	Nicknames  []string
 }
+
+// Matches reports whether the account meets every criterion of the query. Criteria left
+// empty match any account.
+func (q DomainQuery) Matches(entity Entity) bool {
+	return (len(q.Ids) == 0 || slices.Contains(q.Ids, entity.ID)) &&
+		(len(q.AccountIDs) == 0 || slices.Contains(q.AccountIDs, entity.AccountID)) &&
+		(len(q.Emails) == 0 || slices.Contains(q.Emails, entity.Email)) &&
+		(len(q.Nicknames) == 0 || slices.Contains(q.Nicknames, entity.Nickname))
+}
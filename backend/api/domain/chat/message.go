@@ -0,0 +1,54 @@
+package chat
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// MaxBodyLength is the longest message accepted, in characters.
+const MaxBodyLength = 2000
+
+// Message is something a member wrote in a thread. IDs are ULIDs, so they sort in the
+// order messages were sent.
+type Message struct {
+	ID              string
+	Thread          Thread
+	SenderAccountID string
+	Body            string
+	SentAt          time.Time
+}
+
+func NewMessage(thread Thread, senderAccountID, body string, sentAt time.Time) Message {
+	return Message{
+		ID:              ulid.MustNew(ulid.Timestamp(sentAt), ulid.DefaultEntropy()).String(),
+		Thread:          thread,
+		SenderAccountID: senderAccountID,
+		Body:            body,
+		SentAt:          sentAt.UTC(),
+	}
+}
+
+// Receipt is how far a member has read a thread.
+type Receipt struct {
+	Thread            Thread
+	AccountID         string
+	LastReadMessageID string
+	ReadAt            time.Time
+}
+
+// HasRead reports whether the member has read the message.
+func (r Receipt) HasRead(messageID string) bool {
+	return messageID <= r.LastReadMessageID
+}
+
+// Advance moves the receipt up to messageID. Receipts never move back, so reading an
+// older message again keeps them as they are.
+func (r Receipt) Advance(messageID string, readAt time.Time) (Receipt, bool) {
+	if r.HasRead(messageID) {
+		return r, false
+	}
+	r.LastReadMessageID = messageID
+	r.ReadAt = readAt.UTC()
+	return r, true
+}
@@ -0,0 +1,51 @@
+package chat_test
+
+import (
+	"sportlink/api/domain/chat"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceipt_Advance(t *testing.T) {
+	readAt := time.Date(2026, 5, 10, 18, 0, 0, 0, time.UTC)
+	thread := chat.NewThread(chat.KindRequest, "request-1")
+	older := chat.NewMessage(thread, "owner-1", "hi", readAt.Add(-time.Hour))
+	newer := chat.NewMessage(thread, "requester-1", "hello", readAt.Add(-time.Minute))
+
+	tests := []struct {
+		name      string
+		receipt   chat.Receipt
+		messageID string
+		then      func(t *testing.T, receipt chat.Receipt, moved bool)
+	}{
+		{
+			name:      "given a member who never read the thread when reading a message then the receipt moves to it",
+			receipt:   chat.Receipt{Thread: thread, AccountID: "owner-1"},
+			messageID: older.ID,
+			then: func(t *testing.T, receipt chat.Receipt, moved bool) {
+				assert.True(t, moved)
+				assert.Equal(t, older.ID, receipt.LastReadMessageID)
+				assert.Equal(t, readAt, receipt.ReadAt)
+			},
+		},
+		{
+			name:      "given a member who read a newer message when reading an older one then the receipt stays",
+			receipt:   chat.Receipt{Thread: thread, AccountID: "owner-1", LastReadMessageID: newer.ID},
+			messageID: older.ID,
+			then: func(t *testing.T, receipt chat.Receipt, moved bool) {
+				assert.False(t, moved)
+				assert.Equal(t, newer.ID, receipt.LastReadMessageID)
+				assert.True(t, receipt.HasRead(older.ID))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt, moved := tt.receipt.Advance(tt.messageID, readAt)
+			tt.then(t, receipt, moved)
+		})
+	}
+}
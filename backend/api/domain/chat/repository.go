@@ -0,0 +1,33 @@
+package chat
+
+import "context"
+
+const (
+	DefaultPageSize = 30
+	MaxPageSize     = 100
+)
+
+// DomainQuery selects a page of a thread, newest messages first.
+type DomainQuery struct {
+	Thread Thread
+	Limit  int    // Maximum number of messages to return
+	Cursor string // NextCursor of the previous page; empty for the first page
+}
+
+// Page contains the results of a Find operation and where the next page starts
+type Page struct {
+	Entities   []Message
+	NextCursor string // empty when there are no older messages
+}
+
+type Repository interface {
+	Save(ctx context.Context, message Message) error
+
+	Find(ctx context.Context, query DomainQuery) (Page, error)
+
+	// SaveReceipt inserts or overwrites the receipt of a member.
+	SaveReceipt(ctx context.Context, receipt Receipt) error
+
+	// FindReceipts returns the receipts of the members who read the thread at least once.
+	FindReceipts(ctx context.Context, thread Thread) ([]Receipt, error)
+}
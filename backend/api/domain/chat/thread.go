@@ -0,0 +1,51 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Kind string
+
+const (
+	KindRequest Kind = "REQUEST" // Owner of an offer and one requester, keyed by the match request
+	KindMatch   Kind = "MATCH"   // Every participant of a confirmed match, keyed by the match
+)
+
+func (k Kind) IsValid() bool {
+	switch k {
+	case KindRequest, KindMatch:
+		return true
+	default:
+		return false
+	}
+}
+
+func (k Kind) String() string {
+	return string(k)
+}
+
+// ParseKind accepts the kind in any case, as it appears in URLs.
+func ParseKind(s string) (Kind, error) {
+	kind := Kind(strings.ToUpper(s))
+	if !kind.IsValid() {
+		return "", fmt.Errorf("invalid chat thread kind: %s", s)
+	}
+	return kind, nil
+}
+
+// Thread is the conversation about one match request or one match. It has no record of
+// its own: its members are those of the request or match it refers to.
+type Thread struct {
+	Kind  Kind
+	RefID string // match request ID or match ID, depending on Kind
+}
+
+func NewThread(kind Kind, refID string) Thread {
+	return Thread{Kind: kind, RefID: refID}
+}
+
+// Key identifies the thread in storage and events, e.g. "MATCH#01J...".
+func (t Thread) Key() string {
+	return t.Kind.String() + "#" + t.RefID
+}
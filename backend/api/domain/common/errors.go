@@ -0,0 +1,7 @@
+package common
+
+import "errors"
+
+// ErrVersionConflict is returned by repositories when an entity changed since it was read.
+// Callers should reload the entity and retry, or report the conflict.
+var ErrVersionConflict = errors.New("entity was modified concurrently")
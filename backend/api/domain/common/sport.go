@@ -7,3 +7,8 @@ const (
 	Football Sport = "Football"
 	Tennis   Sport = "Tennis"
 )
+
+// Sports returns every sport teams and offers can be of.
+func Sports() []Sport {
+	return []Sport{Paddle, Football, Tennis}
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Actor is who asks for a status change.
+type Actor string
+
+const (
+	ActorOwner       Actor = "OWNER"       // Owner of the match offer
+	ActorRequester   Actor = "REQUESTER"   // Account that sent the match request
+	ActorParticipant Actor = "PARTICIPANT" // Account playing the match
+	ActorSystem      Actor = "SYSTEM"      // Schedulers and event consumers
+)
+
+// ErrInvalidTransition is wrapped by every TransitionError, for callers that only need
+// to know a status change was refused.
+var ErrInvalidTransition = errors.New("status transition not allowed")
+
+// TransitionError tells which status change was refused and to whom.
+type TransitionError struct {
+	Entity string // what changes status, e.g. "match request"
+	From   string
+	To     string
+	Actor  Actor
+}
+
+func (e TransitionError) Error() string {
+	return fmt.Sprintf("%s cannot go from %s to %s by %s", e.Entity, e.From, e.To, e.Actor)
+}
+
+func (e TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// StatusChange is a status change an entity went through since it was read. Repositories
+// write the pending changes as the entity's history along with the entity itself.
+type StatusChange struct {
+	From   string
+	To     string
+	Actor  Actor
+	Reason string // why the change happened, when the caller gave a reason
+}
+
+// AppendChange returns changes followed by change, never writing into the backing array
+// of changes so entities copied by value keep their own history.
+func AppendChange(changes []StatusChange, change StatusChange) []StatusChange {
+	return append(slices.Clip(changes), change)
+}
+
+// Transitions is a status transition table: for each status, the statuses it may move
+// to and the actors allowed to move it there. Statuses without entries are final.
+type Transitions[S ~string] map[S]map[S][]Actor
+
+// Allows reports whether actor may move an entity from one status to the other.
+func (t Transitions[S]) Allows(from, to S, actor Actor) bool {
+	return slices.Contains(t[from][to], actor)
+}
+
+// Check returns a TransitionError naming entity when the change is not allowed.
+func (t Transitions[S]) Check(entity string, from, to S, actor Actor) error {
+	if t.Allows(from, to, actor) {
+		return nil
+	}
+	return TransitionError{Entity: entity, From: string(from), To: string(to), Actor: actor}
+}
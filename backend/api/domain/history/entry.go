@@ -0,0 +1,51 @@
+package history
+
+import (
+	"sportlink/api/domain/common"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// Kind is the kind of entity a history belongs to.
+type Kind string
+
+const (
+	KindMatchOffer   Kind = "MATCH_OFFER"
+	KindMatchRequest Kind = "MATCH_REQUEST"
+	KindMatch        Kind = "MATCH"
+)
+
+// Entry is one status change of an entity. Entries are only ever appended, never updated.
+type Entry struct {
+	ID         string // ULID, so entries sort in the order they were written
+	Kind       Kind
+	EntityID   string
+	From       string
+	To         string
+	Actor      common.Actor // role the change was made in
+	AccountID  string       // account that asked for the change, empty when the system did
+	Reason     string
+	Source     Source
+	OccurredAt time.Time
+}
+
+// NewEntries returns one entry per change of the entity, stamped with origin and now.
+func NewEntries(origin Origin, kind Kind, entityID string, changes []common.StatusChange, now time.Time) []Entry {
+	entries := make([]Entry, 0, len(changes))
+	for _, change := range changes {
+		entries = append(entries, Entry{
+			ID:         ulid.Make().String(),
+			Kind:       kind,
+			EntityID:   entityID,
+			From:       change.From,
+			To:         change.To,
+			Actor:      change.Actor,
+			AccountID:  origin.AccountID,
+			Reason:     change.Reason,
+			Source:     origin.Source,
+			OccurredAt: now,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,34 @@
+package history
+
+import "context"
+
+// Source is the entry point a status change was asked through.
+type Source string
+
+const (
+	SourceREST     Source = "REST"     // an API request
+	SourceConsumer Source = "CONSUMER" // an event consumer
+	SourceSweeper  Source = "SWEEPER"  // a scheduled sweep
+	SourceUnknown  Source = "UNKNOWN"
+)
+
+// Origin tells who asked for the changes made while serving ctx, and through what.
+type Origin struct {
+	AccountID string // empty for system processes
+	Source    Source
+}
+
+type contextKey struct{}
+
+// WithOrigin stores the origin of the changes made while serving ctx.
+func WithOrigin(ctx context.Context, origin Origin) context.Context {
+	return context.WithValue(ctx, contextKey{}, origin)
+}
+
+// OriginOf returns the origin stored in ctx, or an unknown source when there is none.
+func OriginOf(ctx context.Context) Origin {
+	if origin, ok := ctx.Value(contextKey{}).(Origin); ok {
+		return origin
+	}
+	return Origin{Source: SourceUnknown}
+}
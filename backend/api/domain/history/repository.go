@@ -0,0 +1,10 @@
+package history
+
+import "context"
+
+// Repository reads the status history of entities. Entries are written by the repositories
+// of the entities themselves, in the same write as the change they record.
+type Repository interface {
+	// Find returns the history of an entity, oldest entry first.
+	Find(ctx context.Context, kind Kind, entityID string) ([]Entry, error)
+}
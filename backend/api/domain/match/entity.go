@@ -1,6 +1,8 @@
 package match
 
 import (
+	"fmt"
+	"slices"
 	"sportlink/api/domain/common"
 	"time"
 
@@ -8,15 +10,18 @@ import (
 )
 
 type Entity struct {
-	ID             string
-	MatchOfferID   string
-	Participants   []string
-	Sport          common.Sport
-	Day            time.Time
-	Status         Status
-	Result         *Result
+	ID              string
+	MatchOfferID    string
+	Participants    []string
+	Sport           common.Sport
+	Day             time.Time
+	Status          Status
+	Result          *Result
 	WinnerAccountID string
-	CreatedAt      time.Time
+	Payment         *Payment // nil when the offer had no cost
+	CreatedAt       time.Time
+	Version         int                   // optimistic lock; 0 means the match was never persisted with a version
+	Changes         []common.StatusChange // status changes since the match was read, written as its history
 }
 
 func NewMatch(
@@ -26,7 +31,7 @@ func NewMatch(
 	day time.Time,
 ) Entity {
 	return Entity{
-		ID:           generateMatchID(),
+		ID:           IDForOffer(matchOfferID),
 		MatchOfferID: matchOfferID,
 		Participants: participants,
 		Sport:        sport,
@@ -36,6 +41,120 @@ func NewMatch(
 	}
 }
 
+// TransitionTo moves the match to next when actor may do so, and fails with a
+// common.TransitionError otherwise.
+func (e Entity) TransitionTo(next Status, actor common.Actor) (Entity, error) {
+	if err := transitions.Check("match", e.Status, next, actor); err != nil {
+		return e, err
+	}
+	e.Changes = common.AppendChange(e.Changes, common.StatusChange{
+		From:  e.Status.String(),
+		To:    next.String(),
+		Actor: actor,
+	})
+	e.Status = next
+	return e, nil
+}
+
+// Play records the result of the match on behalf of a participant. winnerAccountID is
+// empty for a draw and must otherwise be one of the participants.
+func (e Entity) Play(result Result, winnerAccountID string) (Entity, error) {
+	played, err := e.TransitionTo(StatusPlayed, common.ActorParticipant)
+	if err != nil {
+		return e, err
+	}
+	if winnerAccountID != "" && !slices.Contains(e.Participants, winnerAccountID) {
+		return e, fmt.Errorf("winner %s is not a participant of match %s", winnerAccountID, e.ID)
+	}
+	played.Result = &result
+	played.WinnerAccountID = winnerAccountID
+	return played, nil
+}
+
+// Cancel calls the match off on behalf of actor.
+func (e Entity) Cancel(actor common.Actor) (Entity, error) {
+	return e.TransitionTo(StatusCancelled, actor)
+}
+
+// ReplaceParticipant swaps oldAccountID for newAccountID keeping the participant order.
+// The entity is returned unchanged when oldAccountID is not a participant.
+func (e Entity) ReplaceParticipant(oldAccountID, newAccountID string) Entity {
+	participants := make([]string, len(e.Participants))
+	for i, p := range e.Participants {
+		if p == oldAccountID {
+			p = newAccountID
+		}
+		participants[i] = p
+	}
+	e.Participants = participants
+	return e.resplitPayment()
+}
+
+// RemoveParticipant drops accountID from the participants.
+func (e Entity) RemoveParticipant(accountID string) Entity {
+	participants := make([]string, 0, len(e.Participants))
+	for _, p := range e.Participants {
+		if p != accountID {
+			participants = append(participants, p)
+		}
+	}
+	e.Participants = participants
+	return e.resplitPayment()
+}
+
+// SplitCost attaches a payment that splits total evenly among the participants.
+// A zero total leaves the match without a payment.
+func (e Entity) SplitCost(total int64, currency string) Entity {
+	if total <= 0 {
+		e.Payment = nil
+		return e
+	}
+	e.Payment = NewPayment(total, currency, e.Participants)
+	return e
+}
+
+// MarkSharePaid records that accountID settled its share of the match cost.
+func (e Entity) MarkSharePaid(accountID, reference string, paidAt time.Time) (Entity, error) {
+	if e.Payment == nil {
+		return e, fmt.Errorf("match %s has no cost to pay", e.ID)
+	}
+	shares := make([]Share, len(e.Payment.Shares))
+	copy(shares, e.Payment.Shares)
+	for i, s := range shares {
+		if s.AccountID != accountID {
+			continue
+		}
+		if s.IsPaid() {
+			return e, fmt.Errorf("share of account %s is already paid", accountID)
+		}
+		shares[i].Status = ShareStatusPaid
+		shares[i].Reference = reference
+		shares[i].PaidAt = &paidAt
+		payment := *e.Payment
+		payment.Shares = shares
+		e.Payment = &payment
+		return e, nil
+	}
+	return e, fmt.Errorf("account %s has no share in match %s", accountID, e.ID)
+}
+
+// resplitPayment recomputes the shares after the participants changed.
+func (e Entity) resplitPayment() Entity {
+	if e.Payment != nil {
+		e.Payment = e.Payment.resplit(e.Participants)
+	}
+	return e
+}
+
+// IDForOffer returns the ID of the match created when the offer is confirmed. It is
+// derived from the offer so confirming it more than once always targets the same match.
+func IDForOffer(matchOfferID string) string {
+	if matchOfferID == "" {
+		return generateMatchID()
+	}
+	return matchOfferID
+}
+
 func generateMatchID() string {
 	entropy := ulid.DefaultEntropy()
 	return ulid.MustNew(ulid.Timestamp(time.Now()), entropy).String()
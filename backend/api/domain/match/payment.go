@@ -0,0 +1,173 @@
+package match
+
+import (
+	"fmt"
+	"time"
+)
+
+type ShareStatus string
+
+const (
+	ShareStatusPending ShareStatus = "PENDING" // Participant still owes the share
+	ShareStatusPaid    ShareStatus = "PAID"    // Participant settled the share
+)
+
+// ParseShareStatus converts a string to ShareStatus
+func ParseShareStatus(s string) (ShareStatus, error) {
+	status := ShareStatus(s)
+	switch status {
+	case ShareStatusPending, ShareStatusPaid:
+		return status, nil
+	default:
+		return "", fmt.Errorf("invalid share status: %s", s)
+	}
+}
+
+// Share is the part of the match cost a single participant owes
+type Share struct {
+	AccountID string
+	Amount    int64 // minor units of Payment.Currency
+	Status    ShareStatus
+	Reference string // payment provider reference, set once paid
+	PaidAt    *time.Time
+}
+
+func (s Share) IsPaid() bool {
+	return s.Status == ShareStatusPaid
+}
+
+// Refund is a paid share handed back to a participant who left the match
+type Refund struct {
+	AccountID string
+	Amount    int64  // minor units of Payment.Currency
+	Reference string // payment provider reference of the refunded share
+}
+
+// Payment tracks how the court cost is split among participants
+type Payment struct {
+	Total    int64 // minor units of Currency
+	Currency string
+	Shares   []Share
+	Refunds  []Refund // shares owed back to participants who left after paying
+}
+
+// NewPayment splits total evenly among participants. Remainder minor units go to the first
+// participants, so the owner (always first) absorbs rounding before anyone else.
+func NewPayment(total int64, currency string, participants []string) *Payment {
+	return &Payment{
+		Total:    total,
+		Currency: currency,
+		Shares:   splitShares(total, participants),
+	}
+}
+
+// Share returns the share owed by accountID
+func (p Payment) Share(accountID string) (Share, bool) {
+	for _, s := range p.Shares {
+		if s.AccountID == accountID {
+			return s, true
+		}
+	}
+	return Share{}, false
+}
+
+// Outstanding returns the shares still pending, in participant order
+func (p Payment) Outstanding() []Share {
+	var pending []Share
+	for _, s := range p.Shares {
+		if !s.IsPaid() {
+			pending = append(pending, s)
+		}
+	}
+	return pending
+}
+
+// OutstandingAmount is what participants still owe in total
+func (p Payment) OutstandingAmount() int64 {
+	var amount int64
+	for _, s := range p.Outstanding() {
+		amount += s.Amount
+	}
+	return amount
+}
+
+// resplit recomputes the shares for a new participant list. Paid shares are never
+// changed: only the outstanding balance is split again among the participants who still
+// owe. A participant who leaves after paying is refunded and their share joins the
+// balance, unless nobody is left to owe it, in which case the paid share stays.
+func (p Payment) resplit(participants []string) *Payment {
+	staying := make(map[string]bool, len(participants))
+	for _, accountID := range participants {
+		staying[accountID] = true
+	}
+	paid := make(map[string]Share, len(p.Shares))
+	for _, s := range p.Shares {
+		if s.IsPaid() {
+			paid[s.AccountID] = s
+		}
+	}
+
+	var owing []string
+	for _, accountID := range participants {
+		if _, ok := paid[accountID]; !ok {
+			owing = append(owing, accountID)
+		}
+	}
+
+	refunds := append([]Refund(nil), p.Refunds...)
+	var shares []Share
+	balance := p.Total
+	for _, s := range p.Shares {
+		if !s.IsPaid() {
+			continue
+		}
+		if !staying[s.AccountID] && len(owing) > 0 {
+			refunds = append(refunds, Refund{AccountID: s.AccountID, Amount: s.Amount, Reference: s.Reference})
+			continue
+		}
+		shares = append(shares, s)
+		balance -= s.Amount
+	}
+
+	pending := splitShares(max(balance, 0), owing)
+	byAccount := make(map[string]Share, len(shares)+len(pending))
+	for _, s := range append(shares, pending...) {
+		byAccount[s.AccountID] = s
+	}
+	ordered := make([]Share, 0, len(byAccount))
+	for _, accountID := range participants {
+		ordered = append(ordered, byAccount[accountID])
+	}
+	for _, s := range shares {
+		if !staying[s.AccountID] {
+			ordered = append(ordered, s)
+		}
+	}
+
+	return &Payment{
+		Total:    p.Total,
+		Currency: p.Currency,
+		Shares:   ordered,
+		Refunds:  refunds,
+	}
+}
+
+// splitShares splits total evenly into pending shares, giving the remainder minor units
+// to the first participants.
+func splitShares(total int64, participants []string) []Share {
+	if len(participants) == 0 {
+		return nil
+	}
+	base := total / int64(len(participants))
+	remainder := total % int64(len(participants))
+
+	shares := make([]Share, len(participants))
+	for i, accountID := range participants {
+		amount := base
+		if int64(i) < remainder {
+			amount++
+		}
+		shares[i] = Share{AccountID: accountID, Amount: amount, Status: ShareStatusPending}
+	}
+	return shares
+}
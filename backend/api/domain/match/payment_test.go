@@ -0,0 +1,164 @@
+package match_test
+
+import (
+	"sportlink/api/domain/match"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntity_SplitCost(t *testing.T) {
+	paidAt := time.Date(2030, 5, 10, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		entity func() match.Entity
+		then   func(t *testing.T, entity match.Entity)
+	}{
+		{
+			name: "given cost that does not divide evenly when splitting then the first participants absorb the remainder",
+			entity: func() match.Entity {
+				return match.Entity{ID: "m-1", Participants: []string{"owner", "p1", "p2"}}.SplitCost(1000, "ARS")
+			},
+			then: func(t *testing.T, entity match.Entity) {
+				assert.Equal(t, "ARS", entity.Payment.Currency)
+				assert.Equal(t, []int64{334, 333, 333}, shareAmounts(entity.Payment.Shares))
+				assert.Equal(t, int64(1000), entity.Payment.OutstandingAmount())
+			},
+		},
+		{
+			name: "given free match when splitting then the match has no payment",
+			entity: func() match.Entity {
+				return match.Entity{ID: "m-1", Participants: []string{"owner", "p1"}}.SplitCost(0, "")
+			},
+			then: func(t *testing.T, entity match.Entity) {
+				assert.Nil(t, entity.Payment)
+			},
+		},
+		{
+			name: "given a paid share when a participant drops out then the paid share is kept and only the balance is split again",
+			entity: func() match.Entity {
+				e := match.Entity{ID: "m-1", Participants: []string{"owner", "p1", "p2", "p3"}}.SplitCost(1000, "ARS")
+				e, _ = e.MarkSharePaid("p1", "ref-1", paidAt)
+				return e.RemoveParticipant("p3")
+			},
+			then: func(t *testing.T, entity match.Entity) {
+				assert.Equal(t, []int64{375, 250, 375}, shareAmounts(entity.Payment.Shares))
+				share, ok := entity.Payment.Share("p1")
+				assert.True(t, ok)
+				assert.True(t, share.IsPaid())
+				assert.Equal(t, "ref-1", share.Reference)
+				assert.Equal(t, int64(750), entity.Payment.OutstandingAmount())
+				assert.Empty(t, entity.Payment.Refunds)
+			},
+		},
+		{
+			name: "given a participant who paid when they drop out then they are refunded and the others owe their share",
+			entity: func() match.Entity {
+				e := match.Entity{ID: "m-1", Participants: []string{"owner", "p1", "p2"}}.SplitCost(900, "ARS")
+				e, _ = e.MarkSharePaid("p1", "ref-1", paidAt)
+				return e.RemoveParticipant("p1")
+			},
+			then: func(t *testing.T, entity match.Entity) {
+				assert.Equal(t, []int64{450, 450}, shareAmounts(entity.Payment.Shares))
+				assert.Equal(t, int64(900), entity.Payment.OutstandingAmount())
+				assert.Equal(t, []match.Refund{{AccountID: "p1", Amount: 300, Reference: "ref-1"}}, entity.Payment.Refunds)
+			},
+		},
+		{
+			name: "given everyone else already paid when a paid participant drops out then their share stays paid without a refund",
+			entity: func() match.Entity {
+				e := match.Entity{ID: "m-1", Participants: []string{"owner", "p1"}}.SplitCost(1000, "ARS")
+				e, _ = e.MarkSharePaid("owner", "ref-0", paidAt)
+				e, _ = e.MarkSharePaid("p1", "ref-1", paidAt)
+				return e.RemoveParticipant("p1")
+			},
+			then: func(t *testing.T, entity match.Entity) {
+				assert.Equal(t, []int64{500, 500}, shareAmounts(entity.Payment.Shares))
+				assert.Equal(t, int64(0), entity.Payment.OutstandingAmount())
+				assert.Empty(t, entity.Payment.Refunds)
+			},
+		},
+		{
+			name: "given a participant replaced by a substitute when resplitting then the substitute owes a pending share",
+			entity: func() match.Entity {
+				e := match.Entity{ID: "m-1", Participants: []string{"owner", "p1"}}.SplitCost(1000, "ARS")
+				e, _ = e.MarkSharePaid("p1", "ref-1", paidAt)
+				return e.ReplaceParticipant("p1", "sub")
+			},
+			then: func(t *testing.T, entity match.Entity) {
+				share, ok := entity.Payment.Share("sub")
+				assert.True(t, ok)
+				assert.Equal(t, match.ShareStatusPending, share.Status)
+				assert.Equal(t, int64(500), share.Amount)
+				_, ok = entity.Payment.Share("p1")
+				assert.False(t, ok)
+				assert.Equal(t, []match.Refund{{AccountID: "p1", Amount: 500, Reference: "ref-1"}}, entity.Payment.Refunds)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.then(t, tt.entity())
+		})
+	}
+}
+
+func TestEntity_MarkSharePaid(t *testing.T) {
+	paidAt := time.Date(2030, 5, 10, 18, 0, 0, 0, time.UTC)
+	withCost := match.Entity{ID: "m-1", Participants: []string{"owner", "p1"}}.SplitCost(1000, "ARS")
+
+	tests := []struct {
+		name      string
+		entity    match.Entity
+		accountID string
+		then      func(t *testing.T, original, updated match.Entity, err error)
+	}{
+		{
+			name:      "given pending share when marking it as paid then records the reference without touching the original",
+			entity:    withCost,
+			accountID: "p1",
+			then: func(t *testing.T, original, updated match.Entity, err error) {
+				assert.NoError(t, err)
+				share, _ := updated.Payment.Share("p1")
+				assert.True(t, share.IsPaid())
+				assert.Equal(t, paidAt, *share.PaidAt)
+				originalShare, _ := original.Payment.Share("p1")
+				assert.False(t, originalShare.IsPaid())
+			},
+		},
+		{
+			name:      "given account without a share when marking it as paid then returns error",
+			entity:    withCost,
+			accountID: "stranger",
+			then: func(t *testing.T, _, _ match.Entity, err error) {
+				assert.ErrorContains(t, err, "has no share")
+			},
+		},
+		{
+			name:      "given match without cost when marking a share as paid then returns error",
+			entity:    match.Entity{ID: "m-1", Participants: []string{"owner", "p1"}},
+			accountID: "p1",
+			then: func(t *testing.T, _, _ match.Entity, err error) {
+				assert.ErrorContains(t, err, "has no cost to pay")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := tt.entity.MarkSharePaid(tt.accountID, "ref-1", paidAt)
+			tt.then(t, tt.entity, updated, err)
+		})
+	}
+}
+
+func shareAmounts(shares []match.Share) []int64 {
+	amounts := make([]int64, len(shares))
+	for i, s := range shares {
+		amounts[i] = s.Amount
+	}
+	return amounts
+}
@@ -0,0 +1,80 @@
+package match
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Reminder tells the participants of a match, a fixed time before kickoff, that they
+// are expected. The last reminder of a match also tells its organiser who has not
+// confirmed they are still coming.
+type Reminder struct {
+	MatchID            string
+	OrganiserAccountID string
+	StartTime          time.Time
+	Offset             time.Duration // how long before StartTime the reminder is sent
+	Final              bool
+}
+
+// NewReminders builds one reminder per offset, skipping those already due at now.
+// The smallest offset is the final one even if it is skipped, so that a match
+// confirmed at the last minute does not bother its organiser.
+func NewReminders(matchID, organiserAccountID string, startTime time.Time, offsets []time.Duration, now time.Time) []Reminder {
+	if len(offsets) == 0 {
+		return nil
+	}
+	final := slices.Min(offsets)
+
+	reminders := make([]Reminder, 0, len(offsets))
+	for _, offset := range offsets {
+		reminder := Reminder{
+			MatchID:            matchID,
+			OrganiserAccountID: organiserAccountID,
+			StartTime:          startTime,
+			Offset:             offset,
+			Final:              offset == final,
+		}
+		if !reminder.DueAt().After(now) {
+			continue
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders
+}
+
+// DueAt is when the reminder is to be sent.
+func (r Reminder) DueAt() time.Time {
+	return r.StartTime.Add(-r.Offset)
+}
+
+// Lead describes the offset the way reminders show it, e.g. "24h" or "90m".
+func (r Reminder) Lead() string {
+	if r.Offset%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(r.Offset/time.Hour))
+	}
+	return fmt.Sprintf("%dm", int(r.Offset/time.Minute))
+}
+
+// Attendance is a participant confirming they are still coming to a match.
+type Attendance struct {
+	MatchID     string
+	AccountID   string
+	ConfirmedAt time.Time
+}
+
+// Unconfirmed returns the participants, other than the organiser, who have not
+// confirmed their attendance, in participant order.
+func Unconfirmed(participants []string, attendances []Attendance, organiserAccountID string) []string {
+	confirmed := make(map[string]bool, len(attendances))
+	for _, a := range attendances {
+		confirmed[a.AccountID] = true
+	}
+	unconfirmed := make([]string, 0, len(participants))
+	for _, accountID := range participants {
+		if accountID != organiserAccountID && !confirmed[accountID] {
+			unconfirmed = append(unconfirmed, accountID)
+		}
+	}
+	return unconfirmed
+}
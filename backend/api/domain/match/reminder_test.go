@@ -0,0 +1,63 @@
+package match_test
+
+import (
+	"sportlink/api/domain/match"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReminders(t *testing.T) {
+	kickoff := time.Date(2026, 5, 12, 19, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{24 * time.Hour, 2 * time.Hour}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		then func(t *testing.T, reminders []match.Reminder)
+	}{
+		{
+			name: "given a match two days away when scheduling then one reminder per offset is planned and the closest is final",
+			now:  kickoff.Add(-48 * time.Hour),
+			then: func(t *testing.T, reminders []match.Reminder) {
+				assert.Len(t, reminders, 2)
+				assert.Equal(t, kickoff.Add(-24*time.Hour), reminders[0].DueAt())
+				assert.False(t, reminders[0].Final)
+				assert.Equal(t, "24h", reminders[0].Lead())
+				assert.Equal(t, kickoff.Add(-2*time.Hour), reminders[1].DueAt())
+				assert.True(t, reminders[1].Final)
+			},
+		},
+		{
+			name: "given a match confirmed the same afternoon when scheduling then reminders already due are skipped",
+			now:  kickoff.Add(-5 * time.Hour),
+			then: func(t *testing.T, reminders []match.Reminder) {
+				assert.Len(t, reminders, 1)
+				assert.Equal(t, 2*time.Hour, reminders[0].Offset)
+				assert.True(t, reminders[0].Final)
+			},
+		},
+		{
+			name: "given a match about to start when scheduling then nothing is planned",
+			now:  kickoff.Add(-time.Hour),
+			then: func(t *testing.T, reminders []match.Reminder) {
+				assert.Empty(t, reminders)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.then(t, match.NewReminders("match-1", "owner-1", kickoff, offsets, tt.now))
+		})
+	}
+}
+
+func TestUnconfirmed(t *testing.T) {
+	attendances := []match.Attendance{{MatchID: "match-1", AccountID: "player-2"}}
+
+	unconfirmed := match.Unconfirmed([]string{"owner-1", "player-1", "player-2", "player-3"}, attendances, "owner-1")
+
+	assert.Equal(t, []string{"player-1", "player-3"}, unconfirmed)
+}
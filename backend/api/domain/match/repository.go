@@ -1,6 +1,23 @@
 package match
 
-import "context"
+import (
+	"context"
+	"errors"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	"time"
+)
+
+// ErrWaitlistIncomplete is returned by SaveConfirmation when the confirmation was saved but
+// some of the waitlisted requests that did not fit in its transaction were not. Those
+// requests are still PENDING on the confirmed offer and can be waitlisted again.
+var ErrWaitlistIncomplete = errors.New("confirmation saved without waitlisting every request")
+
+// ErrReminderLeaseLost is returned by ReminderRepository.Delete when the lease of the
+// caller ended and the reminder was claimed again, or was already deleted. Nothing is
+// written, so the reminder is sent by whoever holds it now.
+var ErrReminderLeaseLost = errors.New("reminder lease lost")
 
 type DomainQuery struct {
 	AccountID string
@@ -8,13 +25,61 @@ type DomainQuery struct {
 }
 
 type Repository interface {
-	// Save persists a match. Writes two records (one per account) so both
-	// All participants can efficiently list their matches.
-	Save(ctx context.Context, entity Entity) error
+	// Save persists a match. It writes one record per participant so every participant
+	// can efficiently list their matches, together with the outbox messages raised by the
+	// change. It fails with common.ErrVersionConflict when the match changed since it was
+	// read.
+	Save(ctx context.Context, entity Entity, messages []outbox.Message) error
 
 	// Find returns all matches for the given account (as local or visitor).
 	Find(ctx context.Context, query DomainQuery) ([]Entity, error)
 
 	// FindByID returns a single match by ID, scoped to one of its participant accounts.
 	FindByID(ctx context.Context, accountID, matchID string) (*Entity, error)
+
+	// SaveConfirmation writes the new match, the confirmed offer, the outbox messages
+	// announcing it and the waitlisted requests in one transaction. It fails with common.ErrVersionConflict when the match already
+	// exists or the offer or a request changed since they were read, and with
+	// ErrWaitlistIncomplete when the confirmation was saved but not all requests were.
+	SaveConfirmation(ctx context.Context, entity Entity, offer matchoffer.Entity, waitlisted []matchrequest.Entity, messages []outbox.Message) error
+
+	// RemoveParticipant persists a match whose participant list no longer includes
+	// removedAccountID and drops its listing record so the match stops showing up for it.
+	// The requests that changed with it, the cancelled one and a promoted one, and the
+	// outbox messages announcing them are written in the same transaction. It fails with
+	// common.ErrVersionConflict when the match or a request changed since they were read.
+	RemoveParticipant(ctx context.Context, entity Entity, removedAccountID string, requests []matchrequest.Entity, messages []outbox.Message) error
+}
+
+// ReminderRepository keeps the reminders of upcoming matches until they are sent.
+// Reminders live in storage rather than in timers, so they survive restarts and any
+// instance can send them.
+type ReminderRepository interface {
+	// Schedule stores the reminders. Scheduling a reminder again keeps a single one.
+	Schedule(ctx context.Context, reminders []Reminder) error
+
+	// FindDue returns up to limit reminders due at or before now, the earliest first.
+	// Reminders claimed by a lease that runs past now are left out.
+	FindDue(ctx context.Context, now time.Time, limit int) ([]Reminder, error)
+
+	// Claim leases a due reminder until the given time so that a single instance sends
+	// it. It returns false when another instance holds a lease running past now. The
+	// reminder stays in the schedule, so it is claimed again once the lease is over if
+	// it was never deleted.
+	Claim(ctx context.Context, reminder Reminder, now, until time.Time) (bool, error)
+
+	// Delete takes a reminder out of the schedule once it was sent or is no longer needed,
+	// writing the outbox messages raised by sending it in the same transaction. It only
+	// deletes the reminder while the lease claimed until leasedUntil still holds it, and
+	// fails with ErrReminderLeaseLost otherwise.
+	Delete(ctx context.Context, reminder Reminder, leasedUntil time.Time, messages []outbox.Message) error
+}
+
+// AttendanceRepository records which participants confirmed they are still coming.
+type AttendanceRepository interface {
+	// Save records the confirmation; confirming again keeps the first one.
+	Save(ctx context.Context, attendance Attendance) error
+
+	// Find returns the confirmations of a match.
+	Find(ctx context.Context, matchID string) ([]Attendance, error)
 }
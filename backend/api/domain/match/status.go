@@ -1,6 +1,9 @@
 package match
 
-import "fmt"
+import (
+	"fmt"
+	"sportlink/api/domain/common"
+)
 
 type Status string
 
@@ -30,3 +33,17 @@ func ParseStatus(s string) (Status, error) {
 	}
 	return status, nil
 }
+
+// transitions lists who may move a match between statuses. Played and cancelled
+// matches are final.
+var transitions = common.Transitions[Status]{
+	StatusAccepted: {
+		StatusPlayed:    {common.ActorParticipant, common.ActorSystem},
+		StatusCancelled: {common.ActorOwner, common.ActorSystem},
+	},
+}
+
+// CanTransitionTo reports whether actor may move a match from s to next.
+func (s Status) CanTransitionTo(next Status, actor common.Actor) bool {
+	return transitions.Allows(s, next, actor)
+}
@@ -0,0 +1,60 @@
+package match_test
+
+import (
+	"errors"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/match"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntity_TransitionTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    match.Status
+		to      match.Status
+		actor   common.Actor
+		allowed bool
+	}{
+		{name: "given an accepted match when a participant records it as played then it is allowed", from: match.StatusAccepted, to: match.StatusPlayed, actor: common.ActorParticipant, allowed: true},
+		{name: "given an accepted match when the system records it as played then it is allowed", from: match.StatusAccepted, to: match.StatusPlayed, actor: common.ActorSystem, allowed: true},
+		{name: "given an accepted match when the owner cancels it then it is allowed", from: match.StatusAccepted, to: match.StatusCancelled, actor: common.ActorOwner, allowed: true},
+		{name: "given an accepted match when a participant cancels it then it is refused", from: match.StatusAccepted, to: match.StatusCancelled, actor: common.ActorParticipant},
+		{name: "given a played match when the owner cancels it then it is refused", from: match.StatusPlayed, to: match.StatusCancelled, actor: common.ActorOwner},
+		{name: "given a cancelled match when a participant records it as played then it is refused", from: match.StatusCancelled, to: match.StatusPlayed, actor: common.ActorParticipant},
+		{name: "given a played match when the system moves it back to accepted then it is refused", from: match.StatusPlayed, to: match.StatusAccepted, actor: common.ActorSystem},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := match.Entity{ID: "m-1", Status: tt.from}
+
+			moved, err := entity.TransitionTo(tt.to, tt.actor)
+
+			assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to, tt.actor))
+			if tt.allowed {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, moved.Status)
+				assert.Equal(t, []common.StatusChange{{From: string(tt.from), To: string(tt.to), Actor: tt.actor}}, moved.Changes)
+				return
+			}
+			assert.True(t, errors.Is(err, common.ErrInvalidTransition))
+			assert.Equal(t, tt.from, moved.Status)
+		})
+	}
+}
+
+func TestEntity_Play(t *testing.T) {
+	entity := match.Entity{ID: "m-1", Participants: []string{"owner", "p1"}, Status: match.StatusAccepted}
+
+	played, err := entity.Play(match.Result{LocalScore: 3, VisitorScore: 3}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, match.StatusPlayed, played.Status)
+	assert.Equal(t, &match.Result{LocalScore: 3, VisitorScore: 3}, played.Result)
+	assert.Empty(t, played.WinnerAccountID)
+
+	_, err = entity.Play(match.Result{LocalScore: 1}, "stranger")
+	assert.ErrorContains(t, err, "is not a participant")
+}
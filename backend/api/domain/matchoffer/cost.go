@@ -0,0 +1,34 @@
+package matchoffer
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Cost is what booking the court costs in total, in minor units of Currency (e.g. cents).
+// The zero value means the match is free.
+type Cost struct {
+	Amount   int64
+	Currency string // ISO 4217 code, e.g. ARS
+}
+
+// NewCost validates the amount and currency. A zero amount yields a free match.
+func NewCost(amount int64, currency string) (Cost, error) {
+	if amount < 0 {
+		return Cost{}, fmt.Errorf("cost amount must not be negative")
+	}
+	if amount == 0 {
+		return Cost{}, nil
+	}
+	if !currencyCodePattern.MatchString(currency) {
+		return Cost{}, fmt.Errorf("invalid currency: %s", currency)
+	}
+	return Cost{Amount: amount, Currency: currency}, nil
+}
+
+// IsFree reports whether there is nothing to split among participants
+func (c Cost) IsFree() bool {
+	return c.Amount == 0
+}
@@ -1,6 +1,7 @@
 package matchoffer
 
 import (
+	"fmt"
 	"sportlink/api/domain/common"
 	"time"
 
@@ -9,10 +10,10 @@ import (
 
 // Entity represents a match offer in the domain
 // ID is generated automatically when the entity is created
-// ExpiresAt is the responsibility of the persistence layer
 type Entity struct {
 	ID                 string
-	TeamName           string
+	TeamID             string // ULID of the team the offer is published for, empty when it names none
+	TeamName           string // name of the team when the offer was published
 	Sport              common.Sport
 	Day                time.Time
 	TimeSlot           TimeSlot
@@ -22,6 +23,15 @@ type Entity struct {
 	CreatedAt          time.Time
 	OwnerAccountID     string
 	Capacity           int // 0 = no auto-confirm; >0 = total spots (owner + accepted requesters)
+	PlayerNeeds        PlayerNeeds
+	Visibility         Visibility
+	InvitedAccountIDs  []string              // accounts allowed to see a non-public offer besides the owner
+	ShareLinkVersion   int                   // bumped to revoke every share link handed out so far
+	Cost               Cost                  // court booking cost split among participants once confirmed
+	AcceptedCount      int                   // requests accepted so far, kept on the offer to enforce spots atomically
+	AcceptedByPosition map[string]int        // AcceptedCount broken down by position, only for offers with position needs
+	Version            int                   // optimistic lock; 0 means the offer was never persisted with a version
+	Changes            []common.StatusChange // status changes since the offer was read, written as its history
 }
 
 func NewMatchOffer(
@@ -48,6 +58,7 @@ func NewMatchOffer(
 		CreatedAt:          createdAt,
 		OwnerAccountID:     ownerAccountID,
 		Capacity:           capacity,
+		Visibility:         VisibilityPublic,
 	}
 }
 
@@ -56,8 +67,44 @@ func (s Entity) GetTitle() string {
 	return BuildTitle(s.Sport, s.AdmittedCategories, s.Location)
 }
 
-func (s Entity) Confirm() Entity {
-	s.Status = StatusConfirmed
+// TransitionTo moves the offer to next when actor may do so, and fails with a
+// common.TransitionError otherwise.
+func (s Entity) TransitionTo(next Status, actor common.Actor) (Entity, error) {
+	return s.transition(next, actor, "")
+}
+
+// transition is TransitionTo recording why the offer changed status.
+func (s Entity) transition(next Status, actor common.Actor, reason string) (Entity, error) {
+	if err := transitions.Check("match offer", s.Status, next, actor); err != nil {
+		return s, err
+	}
+	s.Changes = common.AppendChange(s.Changes, common.StatusChange{
+		From:   s.Status.String(),
+		To:     next.String(),
+		Actor:  actor,
+		Reason: reason,
+	})
+	s.Status = next
+	return s, nil
+}
+
+func (s Entity) Confirm(actor common.Actor) (Entity, error) {
+	return s.TransitionTo(StatusConfirmed, actor)
+}
+
+// Cancel withdraws the offer on behalf of its owner.
+func (s Entity) Cancel() (Entity, error) {
+	return s.TransitionTo(StatusCancelled, common.ActorOwner)
+}
+
+// Expire marks the offer as expired. Expired offers are kept for history, never deleted.
+func (s Entity) Expire() (Entity, error) {
+	return s.transition(StatusExpired, common.ActorSystem, "the time slot ended before the offer was confirmed")
+}
+
+// RevokeShareLinks invalidates the share links handed out so far for the offer.
+func (s Entity) RevokeShareLinks() Entity {
+	s.ShareLinkVersion++
 	return s
 }
 
@@ -85,6 +132,117 @@ func (s Entity) IsExpire(loc *time.Location) bool {
 	return time.Now().In(loc).After(s.TimeSlot.EndTime.In(loc))
 }
 
+// HasEnded reports whether the offer's time slot finished before now.
+func (s Entity) HasEnded(now time.Time) bool {
+	return now.After(s.TimeSlot.EndTime)
+}
+
+// IsPublic reports whether anyone can see the offer. Offers saved before visibility
+// existed have no value and are public.
+func (s Entity) IsPublic() bool {
+	return s.Visibility == "" || s.Visibility == VisibilityPublic
+}
+
+// IsInvited reports whether the account was explicitly invited by the owner.
+func (s Entity) IsInvited(accountID string) bool {
+	for _, id := range s.InvitedAccountIDs {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// SpotsToFill returns how many requests have to be accepted for the offer to be full.
+// SpotsNeeded takes precedence over Capacity, which the committed players, or at least
+// the owner, already take part of; 0 means there is no limit.
+func (s Entity) SpotsToFill() int {
+	if s.PlayerNeeds.SpotsNeeded > 0 {
+		return s.PlayerNeeds.SpotsNeeded
+	}
+	if s.Capacity > 0 {
+		return s.Capacity - max(s.PlayerNeeds.Committed, 1)
+	}
+	return 0
+}
+
+// OpenSpots returns the spots still free given the number of accepted requests.
+// Offers without a limit always report -1.
+func (s Entity) OpenSpots(acceptedCount int) int {
+	spots := s.SpotsToFill()
+	if spots == 0 {
+		return -1
+	}
+	return max(spots-acceptedCount, 0)
+}
+
+// OpenSpotsByPosition returns the spots still free for each position the offer needs.
+func (s Entity) OpenSpotsByPosition() map[string]int {
+	if !s.PlayerNeeds.HasPositions() {
+		return nil
+	}
+	open := make(map[string]int, len(s.PlayerNeeds.Positions))
+	for _, p := range s.PlayerNeeds.Positions {
+		open[p.Position] = max(p.Spots-s.AcceptedByPosition[p.Position], 0)
+	}
+	return open
+}
+
+// HasOpenSpots reports whether at least minOpenSpots are still free and, when position is
+// set, whether the offer needs that position and has that many spots, or one, free for it.
+// Offers without a spot limit are always open unless a position is asked for.
+func (s Entity) HasOpenSpots(minOpenSpots int, position string) bool {
+	if open := s.OpenSpots(s.AcceptedCount); open >= 0 && open < minOpenSpots {
+		return false
+	}
+	if position == "" {
+		return true
+	}
+	open, ok := s.OpenSpotsByPosition()[position]
+	return ok && open >= max(minOpenSpots, 1)
+}
+
+// ClaimSpot counts one more accepted request, for position when the offer needs specific
+// positions. It fails with ErrOfferFull when no spot is left.
+func (s Entity) ClaimSpot(position string) (Entity, error) {
+	if s.OpenSpots(s.AcceptedCount) == 0 {
+		return s, ErrOfferFull
+	}
+	if spots, ok := s.PlayerNeeds.SpotsFor(position); ok {
+		if s.AcceptedByPosition[position] >= spots {
+			return s, fmt.Errorf("%w for position %s", ErrOfferFull, position)
+		}
+		s.AcceptedByPosition = s.withPositionCount(position, 1)
+	}
+	s.AcceptedCount++
+	return s, nil
+}
+
+// ReleaseSpot frees the spot taken by an accepted request that is no longer in.
+func (s Entity) ReleaseSpot(position string) Entity {
+	if s.AcceptedCount > 0 {
+		s.AcceptedCount--
+	}
+	if s.AcceptedByPosition[position] > 0 {
+		s.AcceptedByPosition = s.withPositionCount(position, -1)
+	}
+	return s
+}
+
+func (s Entity) withPositionCount(position string, delta int) map[string]int {
+	counts := make(map[string]int, len(s.AcceptedByPosition)+1)
+	for p, c := range s.AcceptedByPosition {
+		counts[p] = c
+	}
+	counts[position] += delta
+	return counts
+}
+
+// HasStarted reports whether the offer's time slot already kicked off at now.
+func (s Entity) HasStarted(now time.Time) bool {
+	return !now.Before(s.TimeSlot.StartTime)
+}
+
 // generateMatchOfferID generates a ULID for the match offer
 func generateMatchOfferID() string {
 	entropy := ulid.DefaultEntropy()
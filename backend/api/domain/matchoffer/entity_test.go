@@ -0,0 +1,63 @@
+package matchoffer_test
+
+import (
+	"sportlink/api/domain/matchoffer"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntity_HasOpenSpots(t *testing.T) {
+	group := matchoffer.Entity{
+		PlayerNeeds: matchoffer.PlayerNeeds{
+			Committed:   8,
+			SpotsNeeded: 3,
+			Positions: []matchoffer.PositionNeed{
+				{Position: "GOALKEEPER", Spots: 1},
+				{Position: "DEFENDER", Spots: 2},
+			},
+		},
+		AcceptedCount:      1,
+		AcceptedByPosition: map[string]int{"GOALKEEPER": 1},
+	}
+
+	tests := []struct {
+		name         string
+		offer        matchoffer.Entity
+		minOpenSpots int
+		position     string
+		open         bool
+	}{
+		{name: "given a group that accepted one of three players when asking for two spots then it is open", offer: group, minOpenSpots: 2, open: true},
+		{name: "given a group that accepted one of three players when asking for three spots then it is not open", offer: group, minOpenSpots: 3},
+		{name: "given a filled goalkeeper spot when asking for a goalkeeper then it is not open", offer: group, position: "GOALKEEPER"},
+		{name: "given free defender spots when asking for a defender then it is open", offer: group, position: "DEFENDER", open: true},
+		{name: "given free defender spots when asking for three defenders then it is not open", offer: group, minOpenSpots: 3, position: "DEFENDER"},
+		{name: "given a position the group does not need when asking for it then it is not open", offer: group, position: "STRIKER"},
+		{name: "given an offer without a limit when asking for spots then it is open", offer: matchoffer.Entity{}, minOpenSpots: 5, open: true},
+		{name: "given an offer without a limit when asking for a position then it is not open", offer: matchoffer.Entity{}, position: "DEFENDER"},
+		{
+			name:         "given a capacity of ten with six committed players when asking for four spots then it is open",
+			offer:        matchoffer.Entity{Capacity: 10, PlayerNeeds: matchoffer.PlayerNeeds{Committed: 6}},
+			minOpenSpots: 4,
+			open:         true,
+		},
+		{
+			name:         "given a capacity of ten with six committed players when asking for five spots then it is not open",
+			offer:        matchoffer.Entity{Capacity: 10, PlayerNeeds: matchoffer.PlayerNeeds{Committed: 6}},
+			minOpenSpots: 5,
+		},
+		{
+			name:         "given a capacity of ten without committed players when asking for nine spots then the owner takes the tenth",
+			offer:        matchoffer.Entity{Capacity: 10},
+			minOpenSpots: 9,
+			open:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.open, tt.offer.HasOpenSpots(tt.minOpenSpots, tt.position))
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package matchoffer
+
+import "errors"
+
+// ErrOfferFull is returned when accepting one more player would exceed the spots the
+// offer needs, overall or for the requested position.
+var ErrOfferFull = errors.New("match offer is full")
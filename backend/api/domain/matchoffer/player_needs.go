@@ -0,0 +1,72 @@
+package matchoffer
+
+import "fmt"
+
+// PlayerNeeds describes how many players an existing group already has and how many
+// more it is looking for, e.g. a football group of 8 that needs 2 more.
+type PlayerNeeds struct {
+	Committed   int            // Players already in the group, owner included
+	SpotsNeeded int            // Players still missing (0 = not set, Capacity applies)
+	Positions   []PositionNeed // Optional breakdown of SpotsNeeded by position/role
+}
+
+// PositionNeed is the number of players needed for a given position/role (e.g: "GOALKEEPER")
+type PositionNeed struct {
+	Position string
+	Spots    int
+}
+
+// NewPlayerNeeds validates and builds the player needs of an offer.
+// When positions are given and spotsNeeded is 0, spotsNeeded becomes the sum of the positions.
+func NewPlayerNeeds(committed, spotsNeeded int, positions []PositionNeed) (PlayerNeeds, error) {
+	if committed < 0 {
+		return PlayerNeeds{}, fmt.Errorf("players committed cannot be negative")
+	}
+	if spotsNeeded < 0 {
+		return PlayerNeeds{}, fmt.Errorf("spots needed cannot be negative")
+	}
+
+	total := 0
+	seen := make(map[string]struct{}, len(positions))
+	for _, p := range positions {
+		if p.Position == "" {
+			return PlayerNeeds{}, fmt.Errorf("position cannot be empty")
+		}
+		if p.Spots <= 0 {
+			return PlayerNeeds{}, fmt.Errorf("spots for position %s must be positive", p.Position)
+		}
+		if _, ok := seen[p.Position]; ok {
+			return PlayerNeeds{}, fmt.Errorf("position %s is repeated", p.Position)
+		}
+		seen[p.Position] = struct{}{}
+		total += p.Spots
+	}
+
+	if spotsNeeded == 0 {
+		spotsNeeded = total
+	}
+	if spotsNeeded < total {
+		return PlayerNeeds{}, fmt.Errorf("spots needed (%d) cannot be less than the spots per position (%d)", spotsNeeded, total)
+	}
+
+	return PlayerNeeds{
+		Committed:   committed,
+		SpotsNeeded: spotsNeeded,
+		Positions:   positions,
+	}, nil
+}
+
+// SpotsFor returns the spots needed for the given position and whether the position is needed at all.
+func (n PlayerNeeds) SpotsFor(position string) (int, bool) {
+	for _, p := range n.Positions {
+		if p.Position == position {
+			return p.Spots, true
+		}
+	}
+	return 0, false
+}
+
+// HasPositions reports whether the needs are broken down by position.
+func (n PlayerNeeds) HasPositions() bool {
+	return len(n.Positions) > 0
+}
@@ -2,7 +2,9 @@ package matchoffer
 
 import (
 	"context"
+	"slices"
 	"sportlink/api/domain/common"
+	"sportlink/api/domain/outbox"
 	"time"
 )
 
@@ -14,10 +16,19 @@ type Page struct {
 
 // Repository defines the persistence operations for match offers
 type Repository interface {
-	Save(ctx context.Context, entity Entity) error
+	// Save writes the offer only while the stored one is still at the version it was read
+	// at, and fails with common.ErrVersionConflict otherwise. The outbox messages raised by
+	// the change are written in the same transaction.
+	Save(ctx context.Context, entity Entity, messages []outbox.Message) error
+	// SaveAll saves each offer like Save, without messages, and returns how many were
+	// written. Offers that changed since they were read are skipped and reported as
+	// common.ErrVersionConflict.
 	SaveAll(ctx context.Context, entities []Entity) (int, error)
 	Find(ctx context.Context, query DomainQuery) (Page, error)
-	Delete(ctx context.Context, offerID string) error
+	// FindByIDs returns the offers stored under ids, read in batches rather than one
+	// lookup per ID. IDs without an offer are left out, and the offers come in no
+	// particular order.
+	FindByIDs(ctx context.Context, ids []string) ([]Entity, error)
 }
 
 // GeoFilter represents a geolocation-based proximity filter
@@ -27,6 +38,12 @@ type GeoFilter struct {
 	RadiusKm  float64 // Search radius in kilometers
 }
 
+// Covers reports whether the location lies within the search radius, measured as the
+// great-circle distance. Locations without coordinates are never covered.
+func (f GeoFilter) Covers(location Location) bool {
+	return location.HasCoords() && common.DistanceKm(f.Latitude, f.Longitude, location.Latitude, location.Longitude) <= f.RadiusKm
+}
+
 // DomainQuery represents the search criteria for match offers
 type DomainQuery struct {
 	IDs            []string          // Search by specific IDs
@@ -38,6 +55,11 @@ type DomainQuery struct {
 	Location       *Location         // Search by exact location text (optional)
 	GeoFilter      *GeoFilter        // Search by proximity (optional, uses GSI)
 	OwnerAccountID string            // Filter by owner account ID (optional)
+	VisibleTo      *Audience         // Only offers this audience can see without a share link (optional)
+	ExcludedOwner  string            // Leave out the offers of this account (optional)
+	ExcludedIDs    []string          // Leave out these offers (optional)
+	MinOpenSpots   int               // Only offers with at least this many open spots (0 = no filter)
+	OpenPosition   string            // Only offers with an open spot for this position (optional)
 	Limit          int               // Maximum number of results to return (0 = no limit)
 	Offset         int               // Number of results to skip (0 = no offset)
 }
@@ -67,3 +89,52 @@ func NewDomainQuery(
 		Offset:     offset,
 	}
 }
+
+// Matches reports whether the offer meets every criterion of the query, leaving Limit and
+// Offset aside. Criteria left empty match any offer.
+func (q DomainQuery) Matches(offer Entity) bool {
+	if len(q.IDs) > 0 && !slices.Contains(q.IDs, offer.ID) {
+		return false
+	}
+	if len(q.Sports) > 0 && !slices.Contains(q.Sports, offer.Sport) {
+		return false
+	}
+	if len(q.Categories) > 0 && !slices.ContainsFunc(q.Categories, offer.AdmittedCategories.Admits) {
+		return false
+	}
+	if len(q.Statuses) > 0 && !slices.Contains(q.Statuses, offer.Status) {
+		return false
+	}
+	if !q.FromDate.IsZero() && offer.Day.Before(q.FromDate) {
+		return false
+	}
+	if !q.ToDate.IsZero() && offer.Day.After(q.ToDate) {
+		return false
+	}
+	if q.Location != nil && !matchesLocation(offer.Location, *q.Location) {
+		return false
+	}
+	if q.GeoFilter != nil && !q.GeoFilter.Covers(offer.Location) {
+		return false
+	}
+	if q.OwnerAccountID != "" && offer.OwnerAccountID != q.OwnerAccountID {
+		return false
+	}
+	if q.VisibleTo != nil && !q.VisibleTo.CanView(offer) {
+		return false
+	}
+	if q.ExcludedOwner != "" && offer.OwnerAccountID == q.ExcludedOwner {
+		return false
+	}
+	if (q.MinOpenSpots > 0 || q.OpenPosition != "") && !offer.HasOpenSpots(q.MinOpenSpots, q.OpenPosition) {
+		return false
+	}
+	return !slices.Contains(q.ExcludedIDs, offer.ID)
+}
+
+// matchesLocation compares the parts of the location the query sets.
+func matchesLocation(location, query Location) bool {
+	return (query.Country == "" || location.Country == query.Country) &&
+		(query.Province == "" || location.Province == query.Province) &&
+		(query.Locality == "" || location.Locality == query.Locality)
+}
@@ -1,6 +1,9 @@
 package matchoffer
 
-import "fmt"
+import (
+	"fmt"
+	"sportlink/api/domain/common"
+)
 
 // Status represents the state of a match offer
 type Status string
@@ -9,7 +12,7 @@ const (
 	StatusPending   Status = "PENDING"   // Offer published, waiting for responses
 	StatusConfirmed Status = "CONFIRMED" // Match confirmed with another team
 	StatusCancelled Status = "CANCELLED" // Offer cancelled by the team
-	StatusExpired   Status = "EXPIRED"   // Offer expired by TTL
+	StatusExpired   Status = "EXPIRED"   // Offer time slot ended before it was confirmed
 )
 
 // AllStatus returns all valid statuses
@@ -45,3 +48,18 @@ func ParseStatus(s string) (Status, error) {
 	}
 	return status, nil
 }
+
+// transitions lists who may move an offer between statuses. Only pending offers change;
+// confirmed ones live on as their match, and expired ones are kept for history.
+var transitions = common.Transitions[Status]{
+	StatusPending: {
+		StatusConfirmed: {common.ActorOwner, common.ActorSystem},
+		StatusCancelled: {common.ActorOwner},
+		StatusExpired:   {common.ActorSystem},
+	},
+}
+
+// CanTransitionTo reports whether actor may move an offer from s to next.
+func (s Status) CanTransitionTo(next Status, actor common.Actor) bool {
+	return transitions.Allows(s, next, actor)
+}
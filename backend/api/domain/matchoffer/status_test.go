@@ -0,0 +1,50 @@
+package matchoffer_test
+
+import (
+	"errors"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchoffer"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntity_TransitionTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    matchoffer.Status
+		to      matchoffer.Status
+		actor   common.Actor
+		allowed bool
+	}{
+		{name: "given a pending offer when the owner confirms it then it is allowed", from: matchoffer.StatusPending, to: matchoffer.StatusConfirmed, actor: common.ActorOwner, allowed: true},
+		{name: "given a pending offer when the system confirms it then it is allowed", from: matchoffer.StatusPending, to: matchoffer.StatusConfirmed, actor: common.ActorSystem, allowed: true},
+		{name: "given a pending offer when a requester confirms it then it is refused", from: matchoffer.StatusPending, to: matchoffer.StatusConfirmed, actor: common.ActorRequester},
+		{name: "given a pending offer when the owner cancels it then it is allowed", from: matchoffer.StatusPending, to: matchoffer.StatusCancelled, actor: common.ActorOwner, allowed: true},
+		{name: "given a pending offer when the system cancels it then it is refused", from: matchoffer.StatusPending, to: matchoffer.StatusCancelled, actor: common.ActorSystem},
+		{name: "given a pending offer when the system expires it then it is allowed", from: matchoffer.StatusPending, to: matchoffer.StatusExpired, actor: common.ActorSystem, allowed: true},
+		{name: "given a pending offer when the owner expires it then it is refused", from: matchoffer.StatusPending, to: matchoffer.StatusExpired, actor: common.ActorOwner},
+		{name: "given a confirmed offer when the owner cancels it then it is refused", from: matchoffer.StatusConfirmed, to: matchoffer.StatusCancelled, actor: common.ActorOwner},
+		{name: "given a confirmed offer when the system expires it then it is refused", from: matchoffer.StatusConfirmed, to: matchoffer.StatusExpired, actor: common.ActorSystem},
+		{name: "given an expired offer when the owner moves it back to pending then it is refused", from: matchoffer.StatusExpired, to: matchoffer.StatusPending, actor: common.ActorOwner},
+		{name: "given a cancelled offer when the owner confirms it then it is refused", from: matchoffer.StatusCancelled, to: matchoffer.StatusConfirmed, actor: common.ActorOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offer := matchoffer.Entity{ID: "mo-1", Status: tt.from}
+
+			moved, err := offer.TransitionTo(tt.to, tt.actor)
+
+			assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to, tt.actor))
+			if tt.allowed {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, moved.Status)
+				assert.Equal(t, []common.StatusChange{{From: string(tt.from), To: string(tt.to), Actor: tt.actor}}, moved.Changes)
+				return
+			}
+			assert.True(t, errors.Is(err, common.ErrInvalidTransition))
+			assert.Equal(t, tt.from, moved.Status)
+		})
+	}
+}
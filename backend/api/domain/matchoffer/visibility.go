@@ -0,0 +1,73 @@
+package matchoffer
+
+import "fmt"
+
+// Visibility controls who can see a match offer and send requests to it
+type Visibility string
+
+const (
+	VisibilityPublic Visibility = "PUBLIC" // Listed in search, anyone can request
+	VisibilityTeam   Visibility = "TEAM"   // Only members of the owner's team
+	VisibilityInvite Visibility = "INVITE" // Only the invited accounts
+	VisibilityLink   Visibility = "LINK"   // Anyone holding a signed share link
+)
+
+// IsValid checks if a visibility is valid
+func (v Visibility) IsValid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityTeam, VisibilityInvite, VisibilityLink:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the visibility
+func (v Visibility) String() string {
+	return string(v)
+}
+
+// ParseVisibility converts a string to Visibility. An empty string means public.
+func ParseVisibility(s string) (Visibility, error) {
+	if s == "" {
+		return VisibilityPublic, nil
+	}
+	visibility := Visibility(s)
+	if !visibility.IsValid() {
+		return "", fmt.Errorf("invalid visibility: %s", s)
+	}
+	return visibility, nil
+}
+
+// TeamRef identifies the team a TEAM offer was published for by its ID, which stays the
+// same when the team is renamed.
+type TeamRef struct {
+	ID string
+}
+
+// Audience is what an account can see without a share link: its own offers, the offers it
+// was invited to and the TEAM offers of the teams it plays in.
+type Audience struct {
+	AccountID string
+	Teams     []TeamRef
+}
+
+// CanView reports whether the offer is visible to the audience. LINK offers are only
+// visible to their owner and invited accounts; anyone else needs the share link.
+func (a Audience) CanView(offer Entity) bool {
+	if offer.IsPublic() {
+		return true
+	}
+	if a.AccountID != "" && (offer.OwnerAccountID == a.AccountID || offer.IsInvited(a.AccountID)) {
+		return true
+	}
+	if offer.Visibility != VisibilityTeam {
+		return false
+	}
+	for _, t := range a.Teams {
+		if offer.TeamID != "" && t.ID == offer.TeamID {
+			return true
+		}
+	}
+	return false
+}
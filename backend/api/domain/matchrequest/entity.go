@@ -2,6 +2,7 @@ package matchrequest
 
 import (
 	"fmt"
+	"sportlink/api/domain/common"
 	"time"
 )
 
@@ -12,37 +13,84 @@ type Entity struct {
 	MatchOfferID       string
 	OwnerAccountID     string // account ID of the match announcement owner (receives the request)
 	RequesterAccountID string // account ID of the user sending the request
+	Position           string // optional position/role the requester applies for
 	Status             Status
+	RejectionReason    string // optional; shown to the requester when the owner rejects the request
 	CreatedAt          time.Time
+	Version            int                   // optimistic lock; 0 means the request was never persisted with a version
+	Changes            []common.StatusChange // status changes since the request was read, written as its history
 }
 
 func NewMatchRequest(
 	matchOfferID string,
 	ownerAccountID string,
 	requesterAccountID string,
+	position string,
 ) Entity {
 	return Entity{
 		ID:                 GenerateMatchRequestID(requesterAccountID, matchOfferID),
 		MatchOfferID:       matchOfferID,
 		OwnerAccountID:     ownerAccountID,
 		RequesterAccountID: requesterAccountID,
+		Position:           position,
 		Status:             StatusPending,
 		CreatedAt:          time.Now(),
 	}
 }
-func (s Entity) Accept() Entity {
-	s.Status = StatusAccepted
-	return s
+
+// TransitionTo moves the request to next when actor may do so, and fails with a
+// common.TransitionError otherwise.
+func (s Entity) TransitionTo(next Status, actor common.Actor) (Entity, error) {
+	return s.transition(next, actor, "")
+}
+
+// transition is TransitionTo recording why the request changed status.
+func (s Entity) transition(next Status, actor common.Actor, reason string) (Entity, error) {
+	if err := transitions.Check("match request", s.Status, next, actor); err != nil {
+		return s, err
+	}
+	s.Changes = common.AppendChange(s.Changes, common.StatusChange{
+		From:   s.Status.String(),
+		To:     next.String(),
+		Actor:  actor,
+		Reason: reason,
+	})
+	s.Status = next
+	return s, nil
 }
 
-func (s Entity) Cancel() Entity {
-	s.Status = StatusCancel
-	return s
+// Accept lets the requester in: the owner accepts pending requests, and the system
+// promotes waitlisted ones.
+func (s Entity) Accept(actor common.Actor) (Entity, error) {
+	return s.TransitionTo(StatusAccepted, actor)
 }
 
-func (s Entity) Reject() Entity {
-	s.Status = StatusRejected
-	return s
+// Cancel withdraws the request. Only the requester may withdraw it.
+func (s Entity) Cancel(actor common.Actor) (Entity, error) {
+	return s.TransitionTo(StatusCancel, actor)
+}
+
+// Reject turns the request down on behalf of the owner, keeping the reason given for it.
+func (s Entity) Reject(reason string) (Entity, error) {
+	rejected, err := s.transition(StatusRejected, common.ActorOwner, reason)
+	if err != nil {
+		return s, err
+	}
+	rejected.RejectionReason = reason
+	return rejected, nil
+}
+
+func (s Entity) Expire() (Entity, error) {
+	return s.transition(StatusExpired, common.ActorSystem, "the match offer ended")
+}
+
+// Close expires the request because its owner cancelled the match offer.
+func (s Entity) Close() (Entity, error) {
+	return s.transition(StatusExpired, common.ActorSystem, "the match offer was cancelled")
+}
+
+func (s Entity) Waitlist() (Entity, error) {
+	return s.transition(StatusWaitlisted, common.ActorSystem, "the match offer was full when it was confirmed")
 }
 
 func (s Entity) IsPending() bool {
@@ -57,6 +105,10 @@ func (s Entity) IsAccepted() bool {
 	return s.Status == StatusAccepted
 }
 
+func (s Entity) IsWaitlisted() bool {
+	return s.Status == StatusWaitlisted
+}
+
 // GenerateMatchRequestID returns the composite sort key for a match request.
 // Format: AccountId#<requesterAccountID>#MatchOfferId#<matchOfferID>
 func GenerateMatchRequestID(requesterAccountID, matchOfferID string) string {
@@ -0,0 +1,7 @@
+package matchrequest
+
+import "errors"
+
+// ErrOpenRequestExists is returned when the requester already has a request on the offer
+// that is still in play, so a new one would overwrite it.
+var ErrOpenRequestExists = errors.New("an open match request for the offer already exists")
@@ -1,6 +1,11 @@
 package matchrequest
 
-import "context"
+import (
+	"context"
+	"slices"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+)
 
 // DomainQuery represents the search criteria for match requests
 type DomainQuery struct {
@@ -11,10 +16,45 @@ type DomainQuery struct {
 	Statuses            []Status // Search by statuses
 }
 
+// Matches reports whether the request meets every criterion of the query. Criteria left
+// empty match any request.
+func (q DomainQuery) Matches(request Entity) bool {
+	return (len(q.IDs) == 0 || slices.Contains(q.IDs, request.ID)) &&
+		(len(q.MatchOfferIDs) == 0 || slices.Contains(q.MatchOfferIDs, request.MatchOfferID)) &&
+		(len(q.OwnerAccountIDs) == 0 || slices.Contains(q.OwnerAccountIDs, request.OwnerAccountID)) &&
+		(len(q.RequesterAccountIDs) == 0 || slices.Contains(q.RequesterAccountIDs, request.RequesterAccountID)) &&
+		(len(q.Statuses) == 0 || slices.Contains(q.Statuses, request.Status))
+}
+
 // Repository defines the persistence operations for match requests
 type Repository interface {
-	Save(ctx context.Context, entity Entity) error
+	// Create writes a new request. Request IDs are derived from the requester and the
+	// offer, so it fails with ErrOpenRequestExists unless any request already stored under
+	// the ID is in one of the FinalStatuses. The outbox messages raised by the request are
+	// written in the same transaction.
+	Create(ctx context.Context, entity Entity, messages []outbox.Message) error
+	// Save writes the request only while the stored one is still at the version it was
+	// read at, and fails with common.ErrVersionConflict otherwise. The outbox messages
+	// raised by the change are written in the same transaction.
+	Save(ctx context.Context, entity Entity, messages []outbox.Message) error
+	// SaveAll writes the requests without checking their versions, overwriting any change
+	// made since they were read. It is meant for seeding and backfills; requests other
+	// writers may be changing go through Save one by one.
 	SaveAll(ctx context.Context, entities []Entity) error
 	Find(ctx context.Context, query DomainQuery) ([]Entity, error)
-	UpdateStatus(ctx context.Context, id string, ownerAccountID string, newStatus Status) error
+	// FindByIDs returns the requests stored under ids, read in batches rather than one
+	// lookup per ID. IDs without a request are left out, and the requests come in no
+	// particular order.
+	FindByIDs(ctx context.Context, ids []string) ([]Entity, error)
+	// UpdateStatus writes the status of entity, only while the stored request is still
+	// pending and belongs to the owner of entity, together with the outbox messages raised
+	// by the change.
+	UpdateStatus(ctx context.Context, entity Entity, messages []outbox.Message) error
+
+	// SaveWithOffer persists the request together with the accepted-spot counters of its
+	// offer in a single transaction. Both writes are version-checked. Taking a spot on an
+	// offer that is already full fails with matchoffer.ErrOfferFull; any other concurrent
+	// change fails with common.ErrVersionConflict. The outbox messages raised by the change
+	// are written in the same transaction.
+	SaveWithOffer(ctx context.Context, request Entity, offer matchoffer.Entity, messages []outbox.Message) error
 }
@@ -1,14 +1,19 @@
 package matchrequest
 
-import "fmt"
+import (
+	"fmt"
+	"sportlink/api/domain/common"
+)
 
 type Status string
 
 const (
-	StatusPending  Status = "PENDING"
-	StatusAccepted Status = "ACCEPTED"
-	StatusCancel   Status = "CANCEL"
-	StatusRejected Status = "REJECTED"
+	StatusPending    Status = "PENDING"
+	StatusAccepted   Status = "ACCEPTED"
+	StatusCancel     Status = "CANCEL"
+	StatusRejected   Status = "REJECTED"
+	StatusExpired    Status = "EXPIRED"    // Offer ended or was cancelled while the request was still pending or waitlisted
+	StatusWaitlisted Status = "WAITLISTED" // Offer was full when confirmed; promoted in arrival order when a spot frees up
 )
 
 func (s Status) String() string {
@@ -17,7 +22,7 @@ func (s Status) String() string {
 
 func (s Status) IsValid() bool {
 	switch s {
-	case StatusPending, StatusAccepted, StatusRejected, StatusCancel:
+	case StatusPending, StatusAccepted, StatusRejected, StatusCancel, StatusExpired, StatusWaitlisted:
 		return true
 	}
 	return false
@@ -30,3 +35,34 @@ func ParseStatus(s string) (Status, error) {
 	}
 	return status, nil
 }
+
+// transitions lists who may move a request between statuses. Cancelled, rejected and
+// expired requests are final.
+var transitions = common.Transitions[Status]{
+	StatusPending: {
+		StatusAccepted:   {common.ActorOwner},
+		StatusRejected:   {common.ActorOwner},
+		StatusCancel:     {common.ActorRequester},
+		StatusExpired:    {common.ActorSystem},
+		StatusWaitlisted: {common.ActorSystem},
+	},
+	StatusAccepted: {
+		StatusCancel: {common.ActorRequester},
+	},
+	StatusWaitlisted: {
+		StatusAccepted: {common.ActorSystem}, // promoted when a participant drops out
+		StatusCancel:   {common.ActorRequester},
+		StatusExpired:  {common.ActorSystem}, // the match was played without a free spot
+	},
+}
+
+// FinalStatuses returns the statuses a request never leaves. Only a request in one of
+// them may be replaced by a new request of the same requester for the same offer.
+func FinalStatuses() []Status {
+	return []Status{StatusCancel, StatusRejected, StatusExpired}
+}
+
+// CanTransitionTo reports whether actor may move a request from s to next.
+func (s Status) CanTransitionTo(next Status, actor common.Actor) bool {
+	return transitions.Allows(s, next, actor)
+}
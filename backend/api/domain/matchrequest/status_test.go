@@ -0,0 +1,61 @@
+package matchrequest_test
+
+import (
+	"errors"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchrequest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntity_TransitionTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    matchrequest.Status
+		to      matchrequest.Status
+		actor   common.Actor
+		allowed bool
+	}{
+		{name: "given a pending request when the owner accepts it then it is allowed", from: matchrequest.StatusPending, to: matchrequest.StatusAccepted, actor: common.ActorOwner, allowed: true},
+		{name: "given a pending request when the requester accepts it then it is refused", from: matchrequest.StatusPending, to: matchrequest.StatusAccepted, actor: common.ActorRequester},
+		{name: "given a pending request when the requester cancels it then it is allowed", from: matchrequest.StatusPending, to: matchrequest.StatusCancel, actor: common.ActorRequester, allowed: true},
+		{name: "given a pending request when the system waitlists it then it is allowed", from: matchrequest.StatusPending, to: matchrequest.StatusWaitlisted, actor: common.ActorSystem, allowed: true},
+		{name: "given a waitlisted request when the system promotes it then it is allowed", from: matchrequest.StatusWaitlisted, to: matchrequest.StatusAccepted, actor: common.ActorSystem, allowed: true},
+		{name: "given a waitlisted request when the system expires it then it is allowed", from: matchrequest.StatusWaitlisted, to: matchrequest.StatusExpired, actor: common.ActorSystem, allowed: true},
+		{name: "given a waitlisted request when the owner accepts it then it is refused", from: matchrequest.StatusWaitlisted, to: matchrequest.StatusAccepted, actor: common.ActorOwner},
+		{name: "given a cancelled request when the owner accepts it then it is refused", from: matchrequest.StatusCancel, to: matchrequest.StatusAccepted, actor: common.ActorOwner},
+		{name: "given a rejected request when the owner moves it back to pending then it is refused", from: matchrequest.StatusRejected, to: matchrequest.StatusPending, actor: common.ActorOwner},
+		{name: "given an accepted request when the owner rejects it then it is refused", from: matchrequest.StatusAccepted, to: matchrequest.StatusRejected, actor: common.ActorOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := matchrequest.Entity{ID: "mr-1", Status: tt.from}
+
+			moved, err := request.TransitionTo(tt.to, tt.actor)
+
+			assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to, tt.actor))
+			if tt.allowed {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, moved.Status)
+				assert.Equal(t, []common.StatusChange{{From: string(tt.from), To: string(tt.to), Actor: tt.actor}}, moved.Changes)
+				return
+			}
+			assert.True(t, errors.Is(err, common.ErrInvalidTransition))
+			assert.Equal(t, tt.from, moved.Status)
+		})
+	}
+}
+
+func TestEntity_Reject(t *testing.T) {
+	request := matchrequest.Entity{ID: "mr-1", Status: matchrequest.StatusPending}
+
+	rejected, err := request.Reject("team is full")
+
+	assert.NoError(t, err)
+	assert.Equal(t, matchrequest.StatusRejected, rejected.Status)
+	assert.Equal(t, "team is full", rejected.RejectionReason)
+	assert.Equal(t, "team is full", rejected.Changes[0].Reason)
+	assert.Empty(t, request.Changes, "the original request keeps its own history")
+}
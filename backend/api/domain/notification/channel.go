@@ -0,0 +1,37 @@
+package notification
+
+import "fmt"
+
+// Channel is a way to reach an account outside the app. The in-app inbox always
+// receives notifications and is not a channel.
+type Channel string
+
+const (
+	ChannelPush  Channel = "PUSH"  // Web Push / FCM to the devices the account registered
+	ChannelEmail Channel = "EMAIL" // Email to the address of the account
+)
+
+func Channels() []Channel {
+	return []Channel{ChannelPush, ChannelEmail}
+}
+
+func (c Channel) IsValid() bool {
+	switch c {
+	case ChannelPush, ChannelEmail:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c Channel) String() string {
+	return string(c)
+}
+
+func ParseChannel(s string) (Channel, error) {
+	c := Channel(s)
+	if !c.IsValid() {
+		return "", fmt.Errorf("invalid notification channel: %s", s)
+	}
+	return c, nil
+}
@@ -0,0 +1,39 @@
+package notification
+
+import "time"
+
+// Entity is a message in the inbox of one account. ID is the ID of the domain event
+// that raised it, so a redelivered event overwrites its notification instead of adding
+// a second one. Event IDs are ULIDs, which also keeps the inbox sorted by time.
+type Entity struct {
+	ID        string
+	AccountID string // account whose inbox holds the notification
+	Type      Type
+	Data      map[string]string // IDs of the entities the notification is about, e.g. match_offer_id
+	CreatedAt time.Time
+	ReadAt    *time.Time // nil while unread
+}
+
+func NewNotification(id, accountID string, notificationType Type, data map[string]string, createdAt time.Time) Entity {
+	return Entity{
+		ID:        id,
+		AccountID: accountID,
+		Type:      notificationType,
+		Data:      data,
+		CreatedAt: createdAt,
+	}
+}
+
+func (e Entity) IsRead() bool {
+	return e.ReadAt != nil
+}
+
+// MarkRead flags the notification as read at now. A notification already read keeps
+// the time it was first read.
+func (e Entity) MarkRead(now time.Time) Entity {
+	if e.IsRead() {
+		return e
+	}
+	e.ReadAt = &now
+	return e
+}
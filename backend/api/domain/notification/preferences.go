@@ -0,0 +1,77 @@
+package notification
+
+import "slices"
+
+const (
+	LocaleSpanish = "es"
+	LocaleEnglish = "en"
+	DefaultLocale = LocaleSpanish
+)
+
+// Preferences holds which notification types an account receives and how it is
+// reached off-app. Every type and channel is enabled until the account turns it off,
+// so new ones reach existing accounts.
+type Preferences struct {
+	AccountID        string
+	Disabled         map[Type]bool
+	DisabledChannels map[Channel]bool
+	QuietHours       *QuietHours // nil when the account can be reached at any time
+	Locale           string      // language of off-app messages
+	PushTokens       []string    // devices registered for push notifications
+}
+
+func NewPreferences(accountID string) Preferences {
+	return Preferences{
+		AccountID:        accountID,
+		Disabled:         map[Type]bool{},
+		DisabledChannels: map[Channel]bool{},
+		Locale:           DefaultLocale,
+	}
+}
+
+// Allows reports whether the account wants notifications of notificationType.
+func (p Preferences) Allows(notificationType Type) bool {
+	return !p.Disabled[notificationType]
+}
+
+// AllowsChannel reports whether the account wants to be reached through channel.
+func (p Preferences) AllowsChannel(channel Channel) bool {
+	return !p.DisabledChannels[channel]
+}
+
+// With returns a copy of the preferences with notificationType turned on or off.
+func (p Preferences) With(notificationType Type, enabled bool) Preferences {
+	p.Disabled = toggled(p.Disabled, notificationType, enabled)
+	return p
+}
+
+// WithChannel returns a copy of the preferences with channel turned on or off.
+func (p Preferences) WithChannel(channel Channel, enabled bool) Preferences {
+	p.DisabledChannels = toggled(p.DisabledChannels, channel, enabled)
+	return p
+}
+
+// WithPushToken returns a copy of the preferences with token registered, once.
+func (p Preferences) WithPushToken(token string) Preferences {
+	if slices.Contains(p.PushTokens, token) {
+		return p
+	}
+	p.PushTokens = append(slices.Clone(p.PushTokens), token)
+	return p
+}
+
+// toggled copies the set of disabled keys with key turned on or off.
+func toggled[K comparable](disabled map[K]bool, key K, enabled bool) map[K]bool {
+	copied := make(map[K]bool, len(disabled)+1)
+	for k, off := range disabled {
+		if off {
+			copied[k] = true
+		}
+	}
+	if enabled {
+		delete(copied, key)
+	} else {
+		copied[key] = true
+	}
+	return copied
+}
@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuietHours is a daily window, in the time zone of the account, during which nothing
+// is sent off-app. Start and End are minutes after midnight; a window whose end comes
+// before its start spans midnight, e.g. 22:00 to 08:00.
+type QuietHours struct {
+	Start    int
+	End      int
+	TimeZone string // IANA name, e.g. America/Argentina/Buenos_Aires
+}
+
+// NewQuietHours parses a window given as "HH:MM" times.
+func NewQuietHours(start, end, timeZone string) (QuietHours, error) {
+	startMinute, err := parseClock(start)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	endMinute, err := parseClock(end)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	if _, err = time.LoadLocation(timeZone); err != nil {
+		return QuietHours{}, fmt.Errorf("invalid time zone: %s", timeZone)
+	}
+	if startMinute == endMinute {
+		return QuietHours{}, fmt.Errorf("quiet hours must not start and end at the same time")
+	}
+	return QuietHours{Start: startMinute, End: endMinute, TimeZone: timeZone}, nil
+}
+
+// Contains reports whether t falls inside the window.
+func (q QuietHours) Contains(t time.Time) bool {
+	minute := q.minuteOfDay(t)
+	if q.Start < q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	return minute >= q.Start || minute < q.End
+}
+
+// EndsAfter returns the first end of the window after t.
+func (q QuietHours) EndsAfter(t time.Time) time.Time {
+	local := t.In(q.location())
+	end := time.Date(local.Year(), local.Month(), local.Day(), q.End/60, q.End%60, 0, 0, local.Location())
+	if !end.After(local) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// StartClock and EndClock format the window bounds as "HH:MM".
+func (q QuietHours) StartClock() string {
+	return fmt.Sprintf("%02d:%02d", q.Start/60, q.Start%60)
+}
+
+func (q QuietHours) EndClock() string {
+	return fmt.Sprintf("%02d:%02d", q.End/60, q.End%60)
+}
+
+func (q QuietHours) minuteOfDay(t time.Time) int {
+	local := t.In(q.location())
+	return local.Hour()*60 + local.Minute()
+}
+
+func (q QuietHours) location() *time.Location {
+	loc, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func parseClock(clock string) (int, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", clock)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
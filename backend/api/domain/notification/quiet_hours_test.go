@@ -0,0 +1,72 @@
+package notification_test
+
+import (
+	"sportlink/api/domain/notification"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuietHours(t *testing.T) {
+	buenosAires, _ := time.LoadLocation("America/Argentina/Buenos_Aires")
+
+	tests := []struct {
+		name  string
+		start string
+		end   string
+		now   time.Time
+		then  func(t *testing.T, quietHours notification.QuietHours, now time.Time)
+	}{
+		{
+			name:  "given a window spanning midnight when it is night in the account time zone then it is quiet until the morning",
+			start: "22:00",
+			end:   "08:00",
+			now:   time.Date(2026, 5, 10, 2, 30, 0, 0, time.UTC), // 23:30 in Buenos Aires
+			then: func(t *testing.T, quietHours notification.QuietHours, now time.Time) {
+				assert.True(t, quietHours.Contains(now))
+				assert.Equal(t, time.Date(2026, 5, 10, 8, 0, 0, 0, buenosAires), quietHours.EndsAfter(now))
+			},
+		},
+		{
+			name:  "given a window spanning midnight when it is afternoon in the account time zone then it is not quiet",
+			start: "22:00",
+			end:   "08:00",
+			now:   time.Date(2026, 5, 10, 18, 0, 0, 0, time.UTC), // 15:00 in Buenos Aires
+			then: func(t *testing.T, quietHours notification.QuietHours, now time.Time) {
+				assert.False(t, quietHours.Contains(now))
+			},
+		},
+		{
+			name:  "given a window within the day when its end is reached then it is no longer quiet",
+			start: "13:00",
+			end:   "15:00",
+			now:   time.Date(2026, 5, 10, 15, 0, 0, 0, buenosAires),
+			then: func(t *testing.T, quietHours notification.QuietHours, now time.Time) {
+				assert.False(t, quietHours.Contains(now))
+				assert.Equal(t, "13:00", quietHours.StartClock())
+				assert.Equal(t, "15:00", quietHours.EndClock())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quietHours, err := notification.NewQuietHours(tt.start, tt.end, "America/Argentina/Buenos_Aires")
+			assert.NoError(t, err)
+
+			tt.then(t, quietHours, tt.now)
+		})
+	}
+}
+
+func TestNewQuietHours_Invalid(t *testing.T) {
+	_, err := notification.NewQuietHours("25:00", "08:00", "UTC")
+	assert.Error(t, err)
+
+	_, err = notification.NewQuietHours("22:00", "08:00", "Mars/Olympus")
+	assert.Error(t, err)
+
+	_, err = notification.NewQuietHours("22:00", "22:00", "UTC")
+	assert.Error(t, err)
+}
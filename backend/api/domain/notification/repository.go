@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"context"
+	"sportlink/api/domain/outbox"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// DomainQuery selects a page of the inbox of one account, newest first.
+type DomainQuery struct {
+	AccountID  string
+	UnreadOnly bool
+	Limit      int    // Maximum number of notifications to return
+	Cursor     string // NextCursor of the previous page; empty for the first page
+}
+
+// Page contains the results of a Find operation and where the next page starts
+type Page struct {
+	Entities   []Entity
+	NextCursor string // empty when there are no more notifications
+}
+
+type Repository interface {
+	// Save inserts or overwrites a notification together with the outbox messages
+	// raised by the change.
+	Save(ctx context.Context, entity Entity, messages []outbox.Message) error
+
+	// SaveAll writes several notifications of the same inbox.
+	SaveAll(ctx context.Context, entities []Entity) error
+
+	Find(ctx context.Context, query DomainQuery) (Page, error)
+
+	// FindByID returns nil when the account has no such notification.
+	FindByID(ctx context.Context, accountID, notificationID string) (*Entity, error)
+}
+
+type PreferencesRepository interface {
+	// Find returns the saved preferences of the account, or the defaults when it
+	// never changed them.
+	Find(ctx context.Context, accountID string) (Preferences, error)
+	Save(ctx context.Context, preferences Preferences) error
+}
@@ -0,0 +1,53 @@
+package notification
+
+import "fmt"
+
+type Type string
+
+const (
+	TypeMatchRequestReceived  Type = "MATCH_REQUEST_RECEIVED"  // Someone asked to join an offer of the account
+	TypeMatchRequestAccepted  Type = "MATCH_REQUEST_ACCEPTED"  // The owner accepted the account's request
+	TypeMatchRequestRejected  Type = "MATCH_REQUEST_REJECTED"  // The owner rejected the account's request
+	TypeMatchRequestCancelled Type = "MATCH_REQUEST_CANCELLED" // The other party cancelled a request
+	TypeMatchRequestPromoted  Type = "MATCH_REQUEST_PROMOTED"  // A waitlisted request took a freed spot
+	TypeMatchOfferExpired     Type = "MATCH_OFFER_EXPIRED"     // An offer ended before the account's request was answered
+	TypeMatchConfirmed        Type = "MATCH_CONFIRMED"         // A match the account takes part in was confirmed
+	TypeMatchReminder         Type = "MATCH_REMINDER"          // A match the account takes part in starts soon
+	TypeAttendanceUnconfirmed Type = "ATTENDANCE_UNCONFIRMED"  // Participants of the account's match did not confirm they are coming
+)
+
+// Types lists every notification type, in the order preferences are shown.
+func Types() []Type {
+	return []Type{
+		TypeMatchRequestReceived,
+		TypeMatchRequestAccepted,
+		TypeMatchRequestRejected,
+		TypeMatchRequestCancelled,
+		TypeMatchRequestPromoted,
+		TypeMatchOfferExpired,
+		TypeMatchConfirmed,
+		TypeMatchReminder,
+		TypeAttendanceUnconfirmed,
+	}
+}
+
+func (t Type) IsValid() bool {
+	for _, known := range Types() {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Type) String() string {
+	return string(t)
+}
+
+func ParseType(s string) (Type, error) {
+	t := Type(s)
+	if !t.IsValid() {
+		return "", fmt.Errorf("invalid notification type: %s", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// envelope is the wire format of a message once it leaves the outbox.
+type envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Actor         string          `json:"actor,omitempty"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// ToEnvelope returns the body sent to the broker for the message.
+func (m Message) ToEnvelope() (string, error) {
+	body, err := json.Marshal(envelope{
+		ID:            m.ID,
+		Type:          m.Type,
+		Version:       m.Version,
+		OccurredAt:    m.OccurredAt.UTC(),
+		Actor:         m.Actor,
+		CorrelationID: m.CorrelationID,
+		Payload:       m.Payload,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build envelope of message %s: %w", m.ID, err)
+	}
+	return string(body), nil
+}
+
+// FromEnvelope rebuilds the message received from the broker.
+func FromEnvelope(body string) (Message, error) {
+	var env envelope
+	if err := json.Unmarshal([]byte(body), &env); err != nil {
+		return Message{}, fmt.Errorf("failed to parse message envelope: %w", err)
+	}
+	if env.ID == "" || env.Type == "" {
+		return Message{}, fmt.Errorf("message envelope is missing its id or type")
+	}
+	return Message{
+		ID:            env.ID,
+		Type:          env.Type,
+		Version:       env.Version,
+		OccurredAt:    env.OccurredAt,
+		Actor:         env.Actor,
+		CorrelationID: env.CorrelationID,
+		Payload:       env.Payload,
+		Status:        StatusPublished,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// Message is a domain event waiting in the outbox to be published. It is written in
+// the same transaction as the entity change that raised it, so the event is never
+// lost when the process dies right after the change. ID doubles as the idempotency
+// key consumers use to skip redeliveries.
+type Message struct {
+	ID            string
+	Type          string
+	Version       int // version of the payload schema of Type
+	Payload       json.RawMessage
+	OccurredAt    time.Time
+	Actor         string // account that caused the event, or the system process
+	CorrelationID string // shared by every event raised while serving one request
+	Status        Status
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+// NewMessage serializes payload as the body of a new pending message of eventType.
+func NewMessage(eventType string, payload any, now time.Time) (Message, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to serialize %s event: %w", eventType, err)
+	}
+	return Message{
+		ID:            generateMessageID(now),
+		Type:          eventType,
+		Version:       1,
+		Payload:       body,
+		OccurredAt:    now,
+		Status:        StatusPending,
+		NextAttemptAt: now,
+	}, nil
+}
+
+// IsDue reports whether the relay should try to publish the message at now.
+func (m Message) IsDue(now time.Time) bool {
+	return m.Status == StatusPending && !now.Before(m.NextAttemptAt)
+}
+
+// FailedAttempt records a failed publish. The message is retried after the policy's
+// backoff, or marked FAILED once it ran out of attempts.
+func (m Message) FailedAttempt(cause error, now time.Time, policy RetryPolicy) Message {
+	m.Attempts++
+	m.LastError = cause.Error()
+	if m.Attempts >= policy.MaxAttempts {
+		m.Status = StatusFailed
+		return m
+	}
+	m.NextAttemptAt = now.Add(policy.Backoff(m.Attempts))
+	return m
+}
+
+// Decode unmarshals the payload into target.
+func (m Message) Decode(target any) error {
+	if err := json.Unmarshal(m.Payload, target); err != nil {
+		return fmt.Errorf("failed to decode %s event %s: %w", m.Type, m.ID, err)
+	}
+	return nil
+}
+
+func generateMessageID(now time.Time) string {
+	entropy := ulid.DefaultEntropy()
+	return ulid.MustNew(ulid.Timestamp(now), entropy).String()
+}
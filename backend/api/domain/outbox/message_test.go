@@ -0,0 +1,108 @@
+package outbox_test
+
+import (
+	"errors"
+	"sportlink/api/domain/outbox"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sampleEvent struct {
+	OfferID string `json:"offer_id"`
+}
+
+func TestMessage_FailedAttempt(t *testing.T) {
+	now := time.Date(2030, 5, 10, 18, 0, 0, 0, time.UTC)
+	policy := outbox.RetryPolicy{MaxAttempts: 3, BaseDelay: 2 * time.Second, MaxDelay: 5 * time.Second}
+
+	tests := []struct {
+		name    string
+		message func() outbox.Message
+		then    func(t *testing.T, message outbox.Message)
+	}{
+		{
+			name: "given first failed publish when recording it then the message is retried after the base delay",
+			message: func() outbox.Message {
+				m, _ := outbox.NewMessage("sample", sampleEvent{OfferID: "offer-1"}, now)
+				return m.FailedAttempt(errors.New("broker down"), now, policy)
+			},
+			then: func(t *testing.T, message outbox.Message) {
+				assert.Equal(t, outbox.StatusPending, message.Status)
+				assert.Equal(t, 1, message.Attempts)
+				assert.Equal(t, "broker down", message.LastError)
+				assert.Equal(t, now.Add(2*time.Second), message.NextAttemptAt)
+				assert.False(t, message.IsDue(now))
+				assert.True(t, message.IsDue(now.Add(2*time.Second)))
+			},
+		},
+		{
+			name: "given second failed publish when recording it then the delay doubles up to the max delay",
+			message: func() outbox.Message {
+				m, _ := outbox.NewMessage("sample", sampleEvent{OfferID: "offer-1"}, now)
+				m = m.FailedAttempt(errors.New("broker down"), now, policy)
+				return m.FailedAttempt(errors.New("broker down"), now, policy)
+			},
+			then: func(t *testing.T, message outbox.Message) {
+				assert.Equal(t, outbox.StatusPending, message.Status)
+				assert.Equal(t, 2, message.Attempts)
+				assert.Equal(t, now.Add(4*time.Second), message.NextAttemptAt)
+			},
+		},
+		{
+			name: "given the last allowed attempt fails when recording it then the message is marked failed",
+			message: func() outbox.Message {
+				m, _ := outbox.NewMessage("sample", sampleEvent{OfferID: "offer-1"}, now)
+				for i := 0; i < policy.MaxAttempts; i++ {
+					m = m.FailedAttempt(errors.New("broker down"), now, policy)
+				}
+				return m
+			},
+			then: func(t *testing.T, message outbox.Message) {
+				assert.Equal(t, outbox.StatusFailed, message.Status)
+				assert.Equal(t, 3, message.Attempts)
+				assert.False(t, message.IsDue(now.Add(time.Hour)))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.then(t, tt.message())
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := outbox.RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	assert.Equal(t, time.Duration(0), policy.Backoff(0))
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 8*time.Second, policy.Backoff(4))
+	assert.Equal(t, 10*time.Second, policy.Backoff(5))
+	assert.Equal(t, 10*time.Second, policy.Backoff(60))
+}
+
+func TestMessage_Envelope(t *testing.T) {
+	now := time.Date(2030, 5, 10, 18, 0, 0, 0, time.UTC)
+	message, err := outbox.NewMessage("sample", sampleEvent{OfferID: "offer-1"}, now)
+	assert.NoError(t, err)
+
+	body, err := message.ToEnvelope()
+	assert.NoError(t, err)
+
+	received, err := outbox.FromEnvelope(body)
+	assert.NoError(t, err)
+	assert.Equal(t, message.ID, received.ID)
+	assert.Equal(t, "sample", received.Type)
+	assert.True(t, now.Equal(received.OccurredAt))
+
+	var event sampleEvent
+	assert.NoError(t, received.Decode(&event))
+	assert.Equal(t, "offer-1", event.OfferID)
+
+	_, err = outbox.FromEnvelope(`{"payload":{}}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,25 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// Save writes the message, inserting it or updating its delivery state.
+	Save(ctx context.Context, message Message) error
+
+	// Delete removes a message once it was handed over to the broker.
+	Delete(ctx context.Context, messageID string) error
+
+	// FindDue returns up to limit pending messages whose next attempt is due at now,
+	// oldest first.
+	FindDue(ctx context.Context, now time.Time, limit int) ([]Message, error)
+}
+
+// ProcessedRepository remembers which messages a consumer already handled, so a
+// redelivered message is acknowledged without running the handler twice.
+type ProcessedRepository interface {
+	IsProcessed(ctx context.Context, consumer, messageID string) (bool, error)
+	MarkProcessed(ctx context.Context, consumer, messageID string) error
+}
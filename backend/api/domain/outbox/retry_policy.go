@@ -0,0 +1,31 @@
+package outbox
+
+import "time"
+
+// RetryPolicy bounds how often and how fast failed deliveries are retried.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy retries for roughly half an hour before giving up.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 10, BaseDelay: 2 * time.Second, MaxDelay: 10 * time.Minute}
+}
+
+// Backoff returns the delay before the next try after attempt failures, doubling the
+// base delay every time and capping it at MaxDelay.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return min(delay, p.MaxDelay)
+}
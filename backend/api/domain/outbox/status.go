@@ -0,0 +1,36 @@
+package outbox
+
+import "fmt"
+
+// Status represents the delivery state of an outbox message
+type Status string
+
+const (
+	StatusPending   Status = "PENDING"   // Waiting to be published, possibly after a failed attempt
+	StatusPublished Status = "PUBLISHED" // Handed over to the broker
+	StatusFailed    Status = "FAILED"    // Gave up after too many attempts, needs a manual look
+)
+
+// IsValid checks if a status is valid
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusPublished, StatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the status
+func (s Status) String() string {
+	return string(s)
+}
+
+// ParseStatus converts a string to Status
+func ParseStatus(s string) (Status, error) {
+	status := Status(s)
+	if !status.IsValid() {
+		return "", fmt.Errorf("invalid outbox status: %s", s)
+	}
+	return status, nil
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"sportlink/api/domain/common"
 	"sportlink/api/domain/player"
+	"time"
+
+	"github.com/oklog/ulid/v2"
 )
 
 type Entity struct {
@@ -14,6 +17,9 @@ type Entity struct {
 	Sport          common.Sport
 	Members        []player.Entity
 	OwnerAccountID string
+	// HomeArea is where the team usually plays, nil when it never said.
+	HomeArea          *HomeArea
+	LookingForPlayers bool
 }
 
 func NewTeam(
@@ -25,7 +31,7 @@ func NewTeam(
 	ownerAccountID string,
 ) Entity {
 	return Entity{
-		ID:             generateTeamID(sport, name),
+		ID:             generateTeamID(),
 		Name:           name,
 		Category:       category,
 		Stats:          stats,
@@ -35,14 +41,27 @@ func NewTeam(
 	}
 }
 
-// WithName returns a new Entity with the updated name and regenerated ID.
+// WithName returns a new Entity with the updated name. The ID stays, so whatever refers
+// to the team keeps doing so after a rename.
 func (e Entity) WithName(name string) Entity {
 	e.Name = name
-	e.ID = generateTeamID(e.Sport, name)
 	return e
 }
 
-// generateTeamID creates a team ID in the format: SPORT#<sport>#NAME#<name>
-func generateTeamID(sport common.Sport, name string) string {
+// NameKey identifies the name of the team within its sport, which no other team of the
+// sport may have.
+func (e Entity) NameKey() string {
+	return NameKey(e.Sport, e.Name)
+}
+
+// NameKey builds the key a team name is claimed under, in the format:
+// SPORT#<sport>#NAME#<name>. Names are compared as written, the way they were back when
+// they made up the team ID.
+func NameKey(sport common.Sport, name string) string {
 	return fmt.Sprintf("SPORT#%s#NAME#%s", sport, name)
 }
+
+func generateTeamID() string {
+	entropy := ulid.DefaultEntropy()
+	return ulid.MustNew(ulid.Timestamp(time.Now()), entropy).String()
+}
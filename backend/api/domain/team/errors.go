@@ -0,0 +1,6 @@
+package team
+
+import "errors"
+
+// ErrNameTaken is returned when another team of the sport already has the name.
+var ErrNameTaken = errors.New("team name already taken")
@@ -2,18 +2,35 @@ package team
 
 import (
 	"context"
+	"slices"
 	"sportlink/api/domain/common"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/domain/player"
+	"strings"
 )
 
 type Repository interface {
-	Save(ctx context.Context, entity Entity) error
+	// Save writes a new team and claims its name, failing with ErrNameTaken when another
+	// team of the sport has it. The outbox messages raised by the team are written in the
+	// same transaction.
+	Save(ctx context.Context, entity Entity, messages []outbox.Message) error
 	Find(ctx context.Context, query DomainQuery) ([]Entity, error)
-	Update(ctx context.Context, oldID string, entity Entity) error
+	// Update writes a team that was read with previousName, moving its claim to the new
+	// name in the same write when it was renamed. It fails with ErrNameTaken when another
+	// team of the sport has the new name, and with common.ErrVersionConflict when the team
+	// is gone or was renamed since it was read. The outbox messages raised by the change are
+	// written in the same transaction.
+	Update(ctx context.Context, previousName string, entity Entity, messages []outbox.Message) error
+	// Search returns the page of teams matching the query that starts right after its
+	// cursor, in SearchKey order.
+	Search(ctx context.Context, query SearchQuery) (Page, error)
 }
 
 type PatchInput struct {
-	ID   ID
-	Name *string
+	ID                ID
+	Name              *string
+	LookingForPlayers *bool
+	HomeArea          *HomeArea
 }
 
 type ID struct {
@@ -22,9 +39,23 @@ type ID struct {
 }
 
 type DomainQuery struct {
-	Name           string
-	Ids            []string
-	Categories     []common.Category
-	Sports         []common.Sport
-	OwnerAccountID string // When set, queries the GSI to list teams by owner
+	Name            string
+	Ids             []string
+	Categories      []common.Category
+	Sports          []common.Sport
+	OwnerAccountID  string // When set, queries the GSI to list teams by owner
+	MemberAccountID string // Only teams this player is a member of
+}
+
+// Matches reports whether the team meets every criterion of the query. Name matches the
+// teams whose name starts with it, the same way claimed names are searched by prefix.
+func (q DomainQuery) Matches(entity Entity) bool {
+	return strings.HasPrefix(entity.Name, q.Name) &&
+		(len(q.Ids) == 0 || slices.Contains(q.Ids, entity.ID)) &&
+		(len(q.Categories) == 0 || slices.Contains(q.Categories, entity.Category)) &&
+		(len(q.Sports) == 0 || slices.Contains(q.Sports, entity.Sport)) &&
+		(q.OwnerAccountID == "" || entity.OwnerAccountID == q.OwnerAccountID) &&
+		(q.MemberAccountID == "" || slices.ContainsFunc(entity.Members, func(member player.Entity) bool {
+			return member.ID == q.MemberAccountID
+		}))
 }
@@ -0,0 +1,159 @@
+package team
+
+import (
+	"slices"
+	"sportlink/api/domain/common"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// HomeArea is where a team usually plays.
+type HomeArea struct {
+	Locality  string
+	Latitude  float64
+	Longitude float64
+}
+
+// GeoFilter selects the teams whose home area lies within RadiusKm of a point.
+type GeoFilter struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+}
+
+// Covers reports whether the home area lies within the search radius, measured as the
+// great-circle distance. Teams without a home area are never covered.
+func (f GeoFilter) Covers(area *HomeArea) bool {
+	return area != nil && common.DistanceKm(f.Latitude, f.Longitude, area.Latitude, area.Longitude) <= f.RadiusKm
+}
+
+// SearchQuery selects a page of teams to discover, ordered by their search name.
+type SearchQuery struct {
+	Name              string            // Prefix of the name, compared once folded with SearchName
+	Fuzzy             bool              // Also match any word of the name starting with Name, allowing typos
+	Sports            []common.Sport    // Every sport when empty
+	Categories        []common.Category // Any category when empty
+	Near              *GeoFilter        // Only teams whose home area it covers (optional)
+	LookingForPlayers bool              // Only teams looking for players
+	Limit             int               // Maximum number of teams to return
+	Cursor            string            // NextCursor of the previous page; empty for the first page
+}
+
+// Page contains the results of a Search and where the next page starts
+type Page struct {
+	Entities   []Entity
+	NextCursor string // empty when there are no more teams
+}
+
+// SearchedSports returns the sports of the query, or every sport when it names none.
+func (q SearchQuery) SearchedSports() []common.Sport {
+	if len(q.Sports) == 0 {
+		return common.Sports()
+	}
+	return q.Sports
+}
+
+// Prefix returns the folded name every search name the query matches starts with, which
+// is none when the query is fuzzy.
+func (q SearchQuery) Prefix() string {
+	if q.Fuzzy {
+		return ""
+	}
+	return SearchName(q.Name)
+}
+
+// MatchesName reports whether the folded name of a team matches the name of the query.
+// Fuzzy queries match when a word of the name starts with the query within a few typos:
+// none for queries under 4 letters, one up to 7 letters and two from then on.
+func (q SearchQuery) MatchesName(searchName string) bool {
+	name := SearchName(q.Name)
+	if strings.HasPrefix(searchName, name) {
+		return true
+	}
+	if !q.Fuzzy {
+		return false
+	}
+	typos := 0
+	switch length := utf8.RuneCountInString(name); {
+	case length >= 8:
+		typos = 2
+	case length >= 4:
+		typos = 1
+	}
+	query := []rune(name)
+	for _, word := range wordSuffixes(searchName) {
+		if prefixDistance(query, []rune(word)) <= typos {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether the team meets every criterion of the query, the cursor aside.
+func (q SearchQuery) Matches(entity Entity) bool {
+	return q.MatchesName(SearchName(entity.Name)) &&
+		slices.Contains(q.SearchedSports(), entity.Sport) &&
+		(len(q.Categories) == 0 || slices.Contains(q.Categories, entity.Category)) &&
+		(q.Near == nil || q.Near.Covers(entity.HomeArea)) &&
+		(!q.LookingForPlayers || entity.LookingForPlayers)
+}
+
+// accents pairs the accented letters of Spanish and its neighbours with the plain ones
+// they fold into.
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c",
+)
+
+// SearchName folds a team name the way searches compare it: lower case, without accents
+// and with single spaces between words, so "  Club  Atlético " is "club atletico".
+func SearchName(name string) string {
+	return strings.Join(strings.Fields(accents.Replace(strings.ToLower(name))), " ")
+}
+
+// SearchKey orders the teams of a search by their folded name, the ID breaking ties. The
+// key of the last team of a page is the cursor of the next one.
+func (e Entity) SearchKey() string {
+	return SearchName(e.Name) + "#" + e.ID
+}
+
+// wordSuffixes returns the name from the start of each of its words.
+func wordSuffixes(name string) []string {
+	suffixes := []string{name}
+	for i, r := range name {
+		if r == ' ' {
+			suffixes = append(suffixes, name[i+1:])
+		}
+	}
+	return suffixes
+}
+
+// prefixDistance returns the fewest edits that turn the query into a prefix of the word.
+func prefixDistance(query, word []rune) int {
+	previous := make([]int, len(word)+1)
+	current := make([]int, len(word)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(query); i++ {
+		current[0] = i
+		for j := 1; j <= len(word); j++ {
+			substitution := previous[j-1]
+			if query[i-1] != word[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return slices.Min(previous)
+}
@@ -0,0 +1,113 @@
+package team_test
+
+import (
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/team"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchName(t *testing.T) {
+	assert.Equal(t, "club atletico", team.SearchName("  Club  Atlético "))
+	assert.Equal(t, "los ninos de penarol", team.SearchName("LOS NIÑOS de Peñarol"))
+	assert.Equal(t, "union", team.SearchName("Unión"))
+}
+
+func TestSearchQuery_Matches(t *testing.T) {
+	boca := team.Entity{
+		ID:                "01JTEAM0000000000000000001",
+		Name:              "Club Atlético Boca Juniors",
+		Sport:             common.Football,
+		Category:          common.L3,
+		HomeArea:          &team.HomeArea{Locality: "La Boca", Latitude: -34.6345, Longitude: -58.3631},
+		LookingForPlayers: true,
+	}
+
+	tests := []struct {
+		name    string
+		query   team.SearchQuery
+		matches bool
+	}{
+		{
+			name:    "given a prefix written in another case and without accents then it matches",
+			query:   team.SearchQuery{Name: "club atletico"},
+			matches: true,
+		},
+		{
+			name:    "given a prefix of a later word when the query is not fuzzy then it does not match",
+			query:   team.SearchQuery{Name: "Boca"},
+			matches: false,
+		},
+		{
+			name:    "given a prefix of a later word when the query is fuzzy then it matches",
+			query:   team.SearchQuery{Name: "Boca", Fuzzy: true},
+			matches: true,
+		},
+		{
+			name:    "given two typos in a word of four to seven letters when the query is fuzzy then it does not match",
+			query:   team.SearchQuery{Name: "Junoirs", Fuzzy: true},
+			matches: false,
+		},
+		{
+			name:    "given a typo in a word of four to seven letters when the query is fuzzy then it matches",
+			query:   team.SearchQuery{Name: "Jniors", Fuzzy: true},
+			matches: true,
+		},
+		{
+			name:    "given a typo in a word of fewer than four letters when the query is fuzzy then it does not match",
+			query:   team.SearchQuery{Name: "Bkc", Fuzzy: true},
+			matches: false,
+		},
+		{
+			name:    "given two typos in a long word when the query is fuzzy then it matches",
+			query:   team.SearchQuery{Name: "Atleitco Boca", Fuzzy: true},
+			matches: true,
+		},
+		{
+			name:    "given another sport then it does not match",
+			query:   team.SearchQuery{Sports: []common.Sport{common.Paddle, common.Tennis}},
+			matches: false,
+		},
+		{
+			name:    "given several sports including the one of the team then it matches",
+			query:   team.SearchQuery{Sports: []common.Sport{common.Paddle, common.Football}},
+			matches: true,
+		},
+		{
+			name:    "given categories without the one of the team then it does not match",
+			query:   team.SearchQuery{Categories: []common.Category{common.L1, common.L2}},
+			matches: false,
+		},
+		{
+			name:    "given a radius covering the home area then it matches",
+			query:   team.SearchQuery{Near: &team.GeoFilter{Latitude: -34.6037, Longitude: -58.3816, RadiusKm: 5}},
+			matches: true,
+		},
+		{
+			name:    "given a radius not covering the home area then it does not match",
+			query:   team.SearchQuery{Near: &team.GeoFilter{Latitude: -31.4201, Longitude: -64.1888, RadiusKm: 50}},
+			matches: false,
+		},
+		{
+			name:    "given only teams looking for players then it matches",
+			query:   team.SearchQuery{LookingForPlayers: true},
+			matches: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, tt.query.Matches(boca))
+		})
+	}
+}
+
+func TestSearchQuery_Matches_WithoutHomeArea(t *testing.T) {
+	entity := team.Entity{ID: "01JTEAM0000000000000000002", Name: "River Plate", Sport: common.Football}
+
+	query := team.SearchQuery{Near: &team.GeoFilter{Latitude: -34.5453, Longitude: -58.4498, RadiusKm: 100}}
+
+	assert.False(t, query.Matches(entity))
+	assert.False(t, team.SearchQuery{LookingForPlayers: true}.Matches(entity))
+}
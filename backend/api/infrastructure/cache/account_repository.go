@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"sportlink/api/domain/account"
+	"sportlink/api/domain/outbox"
+	"time"
+)
+
+// AccountRepository caches the accounts looked up by their account ID in front of another
+// repository. Lookups by email or nickname go straight to it.
+type AccountRepository struct {
+	next     account.Repository
+	accounts *entityCache[account.Entity]
+}
+
+func NewAccountRepository(next account.Repository, backend Backend, ttl time.Duration) *AccountRepository {
+	return &AccountRepository{
+		next: next,
+		accounts: &entityCache[account.Entity]{
+			backend: backend,
+			entity:  "account",
+			ttl:     ttl,
+			idOf:    func(entity account.Entity) string { return entity.AccountID },
+		},
+	}
+}
+
+func (repo *AccountRepository) Save(ctx context.Context, entity account.Entity, messages []outbox.Message) error {
+	defer repo.accounts.invalidate(ctx, entity.AccountID)
+	return repo.next.Save(ctx, entity, messages)
+}
+
+// Find serves the queries for a single account ID from the cache, checking the rest of
+// their criteria on the cached account.
+func (repo *AccountRepository) Find(ctx context.Context, query account.DomainQuery) ([]account.Entity, error) {
+	if len(query.AccountIDs) != 1 {
+		return repo.next.Find(ctx, query)
+	}
+	accounts, err := repo.accounts.find(ctx, query.AccountIDs, func(ctx context.Context, ids []string) ([]account.Entity, error) {
+		return repo.next.Find(ctx, account.DomainQuery{AccountIDs: ids})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(accounts, func(entity account.Entity) bool {
+		return !query.Matches(entity)
+	}), nil
+}
+
+func (repo *AccountRepository) Invalidate(ctx context.Context, ids ...string) {
+	repo.accounts.invalidate(ctx, ids...)
+}
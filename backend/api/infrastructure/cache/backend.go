@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Backend keeps encoded entities under string keys until their TTL runs out. Entries may
+// be dropped earlier, so a miss never means the entity does not exist.
+type Backend interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// tiered reads through the local backend to the shared one and keeps what the shared one
+// had locally, for at most localTTL. Writes and deletes reach both.
+type tiered struct {
+	local    Backend
+	shared   Backend
+	localTTL time.Duration
+}
+
+// Tiered puts local in front of shared. Deletes only reach the local backend of the
+// instance that made them, so localTTL bounds how long other instances keep serving an
+// entry after it was invalidated.
+func Tiered(local, shared Backend, localTTL time.Duration) Backend {
+	return &tiered{local: local, shared: shared, localTTL: localTTL}
+}
+
+func (t *tiered) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if value, ok, err := t.local.Get(ctx, key); err != nil || ok {
+		return value, ok, err
+	}
+	value, ok, err := t.shared.Get(ctx, key)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return value, true, t.local.Set(ctx, key, value, t.localTTL)
+}
+
+func (t *tiered) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := t.shared.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	return t.local.Set(ctx, key, value, min(ttl, t.localTTL))
+}
+
+// Delete removes the keys locally even when the shared backend fails, so this instance
+// at least stops serving them.
+func (t *tiered) Delete(ctx context.Context, keys ...string) error {
+	if err := t.local.Delete(ctx, keys...); err != nil {
+		return err
+	}
+	return t.shared.Delete(ctx, keys...)
+}
+
+// Invalidator drops the entities cached under ids, for changes made without going through
+// the cached repository, such as those domain events report.
+type Invalidator interface {
+	Invalidate(ctx context.Context, ids ...string)
+}
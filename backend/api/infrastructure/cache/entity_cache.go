@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sportlink/pkg/log"
+	"time"
+)
+
+// keyPrefix namespaces the keys in a shared backend. Bump its version whenever the
+// encoding of a cached entity changes, so instances of different releases do not read
+// each other's entries.
+const keyPrefix = "sportlink:v1:"
+
+// entityCache keeps the entities of one kind in a backend, encoded as JSON like the
+// documents of the postgres backend. Backend failures are counted and logged, and the
+// lookup falls back to the repository: the cache never fails a request.
+//
+// A read that loaded an entity before a concurrent write invalidated it may cache the
+// stale entity until its TTL runs out. Versioned writes of a stale entity fail with a
+// version conflict, and invalidate it.
+type entityCache[E any] struct {
+	backend Backend
+	entity  string // names the entity in keys and metrics
+	ttl     time.Duration
+	idOf    func(E) string
+	restore func(*E) // restores what JSON does not keep, e.g. the time zones; optional
+}
+
+func (c *entityCache[E]) key(id string) string {
+	return keyPrefix + c.entity + ":" + id
+}
+
+// find returns the entities cached under ids, loading the missing ones with load and
+// caching them. Repeated ids are looked up once; ids load finds nothing for are left out.
+func (c *entityCache[E]) find(ctx context.Context, ids []string, load func(ctx context.Context, ids []string) ([]E, error)) ([]E, error) {
+	found := make([]E, 0, len(ids))
+	var missing []string
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if entity, ok := c.get(ctx, id); ok {
+			found = append(found, entity)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return found, nil
+	}
+
+	loaded, err := load(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range loaded {
+		c.put(ctx, entity)
+	}
+	return append(found, loaded...), nil
+}
+
+func (c *entityCache[E]) get(ctx context.Context, id string) (E, bool) {
+	var entity E
+	value, ok, err := c.backend.Get(ctx, c.key(id))
+	if err == nil && ok {
+		err = json.Unmarshal(value, &entity)
+	}
+	if err != nil {
+		c.fail(ctx, fmt.Sprintf("failed to read %s %s from the cache", c.entity, id), err)
+		ok = false
+	}
+	if !ok {
+		lookups.WithLabelValues(c.entity, "miss").Inc()
+		return entity, false
+	}
+
+	lookups.WithLabelValues(c.entity, "hit").Inc()
+	if c.restore != nil {
+		c.restore(&entity)
+	}
+	return entity, true
+}
+
+func (c *entityCache[E]) put(ctx context.Context, entity E) {
+	value, err := json.Marshal(entity)
+	if err == nil {
+		err = c.backend.Set(ctx, c.key(c.idOf(entity)), value, c.ttl)
+	}
+	if err != nil {
+		c.fail(ctx, fmt.Sprintf("failed to cache %s %s", c.entity, c.idOf(entity)), err)
+	}
+}
+
+// invalidate drops the entities cached under ids, after they were written or deleted.
+func (c *entityCache[E]) invalidate(ctx context.Context, ids ...string) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			keys = append(keys, c.key(id))
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	invalidations.WithLabelValues(c.entity).Add(float64(len(keys)))
+	if err := c.backend.Delete(ctx, keys...); err != nil {
+		c.fail(ctx, fmt.Sprintf("failed to invalidate %s %v", c.entity, ids), err)
+	}
+}
+
+func (c *entityCache[E]) fail(ctx context.Context, msg string, err error) {
+	errorsTotal.WithLabelValues(c.entity).Inc()
+	log.GetLogger(ctx).Error(msg, err)
+}
+
+// paginate skips offset entities and keeps up to limit of the rest; 0 means no limit.
+func paginate[T any](entities []T, limit, offset int) []T {
+	entities = entities[min(max(offset, 0), len(entities)):]
+	if limit > 0 && len(entities) > limit {
+		entities = entities[:limit]
+	}
+	return entities
+}
@@ -0,0 +1,139 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sportlink/api/infrastructure/cache"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name       string
+		given      func(lru *cache.LRU)
+		assertions func(t *testing.T, lru *cache.LRU)
+	}{
+		{
+			name: "given a stored entry when getting it then returns its value",
+			given: func(lru *cache.LRU) {
+				_ = lru.Set(ctx, "a", []byte("1"), time.Minute)
+			},
+			assertions: func(t *testing.T, lru *cache.LRU) {
+				value, ok, err := lru.Get(ctx, "a")
+				assert.NoError(t, err)
+				assert.True(t, ok)
+				assert.Equal(t, []byte("1"), value)
+			},
+		},
+		{
+			name: "given a full cache when storing another entry then evicts the least recently used one",
+			given: func(lru *cache.LRU) {
+				_ = lru.Set(ctx, "a", []byte("1"), time.Minute)
+				_ = lru.Set(ctx, "b", []byte("2"), time.Minute)
+				_, _, _ = lru.Get(ctx, "a")
+				_ = lru.Set(ctx, "c", []byte("3"), time.Minute)
+			},
+			assertions: func(t *testing.T, lru *cache.LRU) {
+				_, ok, _ := lru.Get(ctx, "b")
+				assert.False(t, ok)
+				_, ok, _ = lru.Get(ctx, "a")
+				assert.True(t, ok)
+				assert.Equal(t, 2, lru.Len())
+			},
+		},
+		{
+			name: "given an expired entry when getting it then misses and drops it",
+			given: func(lru *cache.LRU) {
+				_ = lru.Set(ctx, "a", []byte("1"), time.Millisecond)
+				time.Sleep(5 * time.Millisecond)
+			},
+			assertions: func(t *testing.T, lru *cache.LRU) {
+				_, ok, err := lru.Get(ctx, "a")
+				assert.NoError(t, err)
+				assert.False(t, ok)
+				assert.Zero(t, lru.Len())
+			},
+		},
+		{
+			name: "given stored entries when deleting some then only those are gone",
+			given: func(lru *cache.LRU) {
+				_ = lru.Set(ctx, "a", []byte("1"), time.Minute)
+				_ = lru.Set(ctx, "b", []byte("2"), time.Minute)
+				_ = lru.Delete(ctx, "a", "missing")
+			},
+			assertions: func(t *testing.T, lru *cache.LRU) {
+				_, ok, _ := lru.Get(ctx, "a")
+				assert.False(t, ok)
+				_, ok, _ = lru.Get(ctx, "b")
+				assert.True(t, ok)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			lru := cache.NewLRU(2)
+			testCase.given(lru)
+
+			// then
+			testCase.assertions(t, lru)
+		})
+	}
+}
+
+// failingBackend stands in for a shared backend that is down.
+type failingBackend struct{}
+
+func (failingBackend) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (failingBackend) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingBackend) Delete(context.Context, ...string) error {
+	return errors.New("connection refused")
+}
+
+func TestTiered(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("given an entry only the shared backend has when getting it then keeps it locally", func(t *testing.T) {
+		// given
+		local, shared := cache.NewLRU(10), cache.NewLRU(10)
+		_ = shared.Set(ctx, "a", []byte("1"), time.Minute)
+		tiered := cache.Tiered(local, shared, time.Minute)
+
+		// when
+		value, ok, err := tiered.Get(ctx, "a")
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1"), value)
+		_, ok, _ = local.Get(ctx, "a")
+		assert.True(t, ok)
+	})
+
+	t.Run("given a shared backend that is down when deleting then still deletes locally", func(t *testing.T) {
+		// given
+		local := cache.NewLRU(10)
+		_ = local.Set(ctx, "a", []byte("1"), time.Minute)
+		tiered := cache.Tiered(local, failingBackend{}, time.Minute)
+
+		// when
+		err := tiered.Delete(ctx, "a")
+
+		// then
+		assert.Error(t, err)
+		_, ok, _ := local.Get(ctx, "a")
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"context"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+)
+
+// MatchRepository invalidates the cached offer a match is confirmed with, so a confirmation
+// retried after a lost race reads the offer as CONFIRMED. Matches themselves are not cached.
+type MatchRepository struct {
+	match.Repository
+	offers Invalidator
+}
+
+func NewMatchRepository(next match.Repository, offers Invalidator) *MatchRepository {
+	return &MatchRepository{Repository: next, offers: offers}
+}
+
+func (repo *MatchRepository) SaveConfirmation(
+	ctx context.Context,
+	entity match.Entity,
+	offer matchoffer.Entity,
+	waitlisted []matchrequest.Entity,
+	messages []outbox.Message,
+) error {
+	defer repo.offers.Invalidate(ctx, offer.ID)
+	return repo.Repository.SaveConfirmation(ctx, entity, offer, waitlisted, messages)
+}
@@ -0,0 +1,56 @@
+package cache_test
+
+import (
+	"context"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/infrastructure/cache"
+	matchmocks "sportlink/mocks/api/domain/match"
+	offermocks "sportlink/mocks/api/domain/matchoffer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMatchRepository_SaveConfirmation(t *testing.T) {
+	ctx := context.Background()
+	pending := matchoffer.Entity{ID: "offer-1", Status: matchoffer.StatusPending}
+	confirmed := matchoffer.Entity{ID: "offer-1", Status: matchoffer.StatusConfirmed, Version: 1}
+	byID := matchoffer.DomainQuery{IDs: []string{"offer-1"}}
+
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{name: "given a cached offer when a match is confirmed with it then the next find reads it again"},
+		{name: "given a cached offer when the confirmation loses a race then the next find reads the winning one", err: common.ErrVersionConflict},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			nextOffers := offermocks.NewRepository(t)
+			nextOffers.On("FindByIDs", mock.Anything, []string{"offer-1"}).Return([]matchoffer.Entity{pending}, nil).Once()
+			nextOffers.On("FindByIDs", mock.Anything, []string{"offer-1"}).Return([]matchoffer.Entity{confirmed}, nil).Once()
+			nextMatches := matchmocks.NewRepository(t)
+			nextMatches.On("SaveConfirmation", mock.Anything, mock.Anything, pending, mock.Anything, mock.Anything).Return(testCase.err)
+
+			offers := cache.NewMatchOfferRepository(nextOffers, cache.NewLRU(10), time.Minute)
+			matches := cache.NewMatchRepository(nextMatches, offers)
+			_, err := offers.Find(ctx, byID)
+			assert.NoError(t, err)
+
+			// when
+			err = matches.SaveConfirmation(ctx, match.Entity{MatchOfferID: "offer-1"}, pending, nil, nil)
+			page, findErr := offers.Find(ctx, byID)
+
+			// then
+			assert.ErrorIs(t, err, testCase.err)
+			assert.NoError(t, findErr)
+			assert.Equal(t, []matchoffer.Entity{confirmed}, page.Entities)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	"time"
+)
+
+// MatchOfferRepository caches the offers looked up by ID in front of another repository.
+// Searches go straight to it.
+type MatchOfferRepository struct {
+	next   matchoffer.Repository
+	offers *entityCache[matchoffer.Entity]
+}
+
+func NewMatchOfferRepository(next matchoffer.Repository, backend Backend, ttl time.Duration) *MatchOfferRepository {
+	return &MatchOfferRepository{
+		next: next,
+		offers: &entityCache[matchoffer.Entity]{
+			backend: backend,
+			entity:  "matchoffer",
+			ttl:     ttl,
+			idOf:    func(offer matchoffer.Entity) string { return offer.ID },
+			restore: restoreOfferTimezone,
+		},
+	}
+}
+
+// Save invalidates the offer whether or not it was written, so a version conflict makes
+// the next read load the stored offer.
+func (repo *MatchOfferRepository) Save(ctx context.Context, entity matchoffer.Entity, messages []outbox.Message) error {
+	defer repo.offers.invalidate(ctx, entity.ID)
+	return repo.next.Save(ctx, entity, messages)
+}
+
+func (repo *MatchOfferRepository) SaveAll(ctx context.Context, entities []matchoffer.Entity) (int, error) {
+	defer repo.offers.invalidate(ctx, offerIDs(entities)...)
+	return repo.next.SaveAll(ctx, entities)
+}
+
+// Find serves the queries for a single offer from the cache, checking the rest of their
+// criteria on the cached offer.
+func (repo *MatchOfferRepository) Find(ctx context.Context, query matchoffer.DomainQuery) (matchoffer.Page, error) {
+	if len(query.IDs) != 1 {
+		return repo.next.Find(ctx, query)
+	}
+	offers, err := repo.FindByIDs(ctx, query.IDs)
+	if err != nil {
+		return matchoffer.Page{}, err
+	}
+	matching := slices.DeleteFunc(offers, func(offer matchoffer.Entity) bool {
+		return !query.Matches(offer)
+	})
+	return matchoffer.Page{Entities: paginate(matching, query.Limit, query.Offset), Total: len(matching)}, nil
+}
+
+// FindByIDs reads the offers missing from the cache in a single lookup.
+func (repo *MatchOfferRepository) FindByIDs(ctx context.Context, ids []string) ([]matchoffer.Entity, error) {
+	return repo.offers.find(ctx, ids, repo.next.FindByIDs)
+}
+
+func (repo *MatchOfferRepository) Invalidate(ctx context.Context, ids ...string) {
+	repo.offers.invalidate(ctx, ids...)
+}
+
+// restoreOfferTimezone puts the times of the offer back in the time zone of its location,
+// which they are read in from the repository.
+func restoreOfferTimezone(offer *matchoffer.Entity) {
+	tz := offer.Location.GetTimezone()
+	offer.Day = offer.Day.In(tz)
+	offer.CreatedAt = offer.CreatedAt.In(tz)
+	offer.TimeSlot.StartTime = offer.TimeSlot.StartTime.In(tz)
+	offer.TimeSlot.EndTime = offer.TimeSlot.EndTime.In(tz)
+}
+
+func offerIDs(offers []matchoffer.Entity) []string {
+	ids := make([]string, len(offers))
+	for i, offer := range offers {
+		ids[i] = offer.ID
+	}
+	return ids
+}
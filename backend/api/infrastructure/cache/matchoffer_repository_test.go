@@ -0,0 +1,196 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/infrastructure/cache"
+	mocks "sportlink/mocks/api/domain/matchoffer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMatchOfferRepository(t *testing.T) {
+	ctx := context.Background()
+	location := matchoffer.NewLocation("Argentina", "Buenos Aires", "CABA")
+	tz := location.GetTimezone()
+	day := time.Now().In(tz).AddDate(0, 0, 1)
+	timeSlot, _ := matchoffer.NewTimeSlot(day, day.Add(2*time.Hour))
+	offer := matchoffer.NewMatchOffer(
+		"Thunder Strikers",
+		common.Paddle,
+		day,
+		timeSlot,
+		location,
+		matchoffer.NewSpecificCategories([]common.Category{5}),
+		matchoffer.StatusPending,
+		time.Now().In(tz).Truncate(time.Second),
+		"owner-1",
+		0,
+	)
+	byID := matchoffer.DomainQuery{IDs: []string{offer.ID}}
+
+	testCases := []struct {
+		name    string
+		backend cache.Backend
+		on      func(next *mocks.Repository)
+		when    func(repository *cache.MatchOfferRepository) (any, error)
+		then    func(t *testing.T, result any, err error)
+	}{
+		{
+			name:    "given an offer read once when finding it again then serves it from the cache",
+			backend: cache.NewLRU(10),
+			on: func(next *mocks.Repository) {
+				next.On("FindByIDs", mock.Anything, []string{offer.ID}).Return([]matchoffer.Entity{offer}, nil).Once()
+			},
+			when: func(repository *cache.MatchOfferRepository) (any, error) {
+				if _, err := repository.Find(ctx, byID); err != nil {
+					return nil, err
+				}
+				return repository.Find(ctx, byID)
+			},
+			then: func(t *testing.T, result any, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, matchoffer.Page{Entities: []matchoffer.Entity{offer}, Total: 1}, result)
+			},
+		},
+		{
+			name:    "given a cached offer when finding it with criteria it does not meet then returns an empty page",
+			backend: cache.NewLRU(10),
+			on: func(next *mocks.Repository) {
+				next.On("FindByIDs", mock.Anything, []string{offer.ID}).Return([]matchoffer.Entity{offer}, nil).Once()
+			},
+			when: func(repository *cache.MatchOfferRepository) (any, error) {
+				if _, err := repository.Find(ctx, byID); err != nil {
+					return nil, err
+				}
+				return repository.Find(ctx, matchoffer.DomainQuery{IDs: []string{offer.ID}, OwnerAccountID: "owner-2"})
+			},
+			then: func(t *testing.T, result any, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, matchoffer.Page{Entities: []matchoffer.Entity{}, Total: 0}, result)
+			},
+		},
+		{
+			name:    "given a cached offer when saving it then the next find reads it again",
+			backend: cache.NewLRU(10),
+			on: func(next *mocks.Repository) {
+				next.On("FindByIDs", mock.Anything, []string{offer.ID}).Return([]matchoffer.Entity{offer}, nil).Twice()
+				next.On("Save", mock.Anything, offer, mock.Anything).Return(nil).Once()
+			},
+			when: func(repository *cache.MatchOfferRepository) (any, error) {
+				if _, err := repository.Find(ctx, byID); err != nil {
+					return nil, err
+				}
+				if err := repository.Save(ctx, offer, nil); err != nil {
+					return nil, err
+				}
+				return repository.Find(ctx, byID)
+			},
+			then: func(t *testing.T, result any, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, result.(matchoffer.Page).Total)
+			},
+		},
+		{
+			name:    "given a cached offer when a save fails then the next find reads it again",
+			backend: cache.NewLRU(10),
+			on: func(next *mocks.Repository) {
+				next.On("FindByIDs", mock.Anything, []string{offer.ID}).Return([]matchoffer.Entity{offer}, nil).Twice()
+				next.On("Save", mock.Anything, offer, mock.Anything).Return(common.ErrVersionConflict).Once()
+			},
+			when: func(repository *cache.MatchOfferRepository) (any, error) {
+				if _, err := repository.Find(ctx, byID); err != nil {
+					return nil, err
+				}
+				_ = repository.Save(ctx, offer, nil)
+				return repository.Find(ctx, byID)
+			},
+			then: func(t *testing.T, result any, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:    "given some cached offers when finding several then only reads the missing ones",
+			backend: cache.NewLRU(10),
+			on: func(next *mocks.Repository) {
+				next.On("FindByIDs", mock.Anything, []string{offer.ID}).Return([]matchoffer.Entity{offer}, nil).Once()
+				next.On("FindByIDs", mock.Anything, []string{"missing"}).Return([]matchoffer.Entity{}, nil).Once()
+			},
+			when: func(repository *cache.MatchOfferRepository) (any, error) {
+				if _, err := repository.FindByIDs(ctx, []string{offer.ID}); err != nil {
+					return nil, err
+				}
+				return repository.FindByIDs(ctx, []string{offer.ID, "missing", offer.ID})
+			},
+			then: func(t *testing.T, result any, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []matchoffer.Entity{offer}, result)
+			},
+		},
+		{
+			name:    "given a cache backend that is down when finding an offer then reads it from the repository",
+			backend: failingBackend{},
+			on: func(next *mocks.Repository) {
+				next.On("FindByIDs", mock.Anything, []string{offer.ID}).Return([]matchoffer.Entity{offer}, nil).Twice()
+			},
+			when: func(repository *cache.MatchOfferRepository) (any, error) {
+				if _, err := repository.Find(ctx, byID); err != nil {
+					return nil, err
+				}
+				return repository.Find(ctx, byID)
+			},
+			then: func(t *testing.T, result any, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, matchoffer.Page{Entities: []matchoffer.Entity{offer}, Total: 1}, result)
+			},
+		},
+		{
+			name:    "given a search when finding then goes straight to the repository",
+			backend: cache.NewLRU(10),
+			on: func(next *mocks.Repository) {
+				next.On("Find", mock.Anything, matchoffer.DomainQuery{OwnerAccountID: "owner-1"}).
+					Return(matchoffer.Page{Entities: []matchoffer.Entity{offer}, Total: 1}, nil).Once()
+			},
+			when: func(repository *cache.MatchOfferRepository) (any, error) {
+				return repository.Find(ctx, matchoffer.DomainQuery{OwnerAccountID: "owner-1"})
+			},
+			then: func(t *testing.T, result any, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, result.(matchoffer.Page).Total)
+			},
+		},
+		{
+			name:    "given the repository fails when finding an offer then returns the error",
+			backend: cache.NewLRU(10),
+			on: func(next *mocks.Repository) {
+				next.On("FindByIDs", mock.Anything, []string{offer.ID}).Return(nil, errors.New("db connection error")).Once()
+			},
+			when: func(repository *cache.MatchOfferRepository) (any, error) {
+				return repository.Find(ctx, byID)
+			},
+			then: func(t *testing.T, result any, err error) {
+				assert.EqualError(t, err, "db connection error")
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			next := mocks.NewRepository(t)
+			testCase.on(next)
+			repository := cache.NewMatchOfferRepository(next, testCase.backend, time.Minute)
+
+			// when
+			result, err := testCase.when(repository)
+
+			// then
+			testCase.then(t, result, err)
+		})
+	}
+}
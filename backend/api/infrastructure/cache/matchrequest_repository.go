@@ -0,0 +1,24 @@
+package cache
+
+import (
+	"context"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+)
+
+// MatchRequestRepository invalidates the cached offer whose spot counters a request is
+// saved with. Requests themselves are not cached.
+type MatchRequestRepository struct {
+	matchrequest.Repository
+	offers Invalidator
+}
+
+func NewMatchRequestRepository(next matchrequest.Repository, offers Invalidator) *MatchRequestRepository {
+	return &MatchRequestRepository{Repository: next, offers: offers}
+}
+
+func (repo *MatchRequestRepository) SaveWithOffer(ctx context.Context, request matchrequest.Entity, offer matchoffer.Entity, messages []outbox.Message) error {
+	defer repo.offers.Invalidate(ctx, offer.ID)
+	return repo.Repository.SaveWithOffer(ctx, request, offer, messages)
+}
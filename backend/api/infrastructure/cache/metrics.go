@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Lookups and invalidations are exposed on /metrics by entity, so the hit ratio of each
+// cache is hits over hits plus misses.
+var (
+	lookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sportlink_cache_lookups_total",
+		Help: "Cache lookups by entity and result (hit or miss).",
+	}, []string{"entity", "result"})
+	invalidations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sportlink_cache_invalidations_total",
+		Help: "Cache entries invalidated by entity.",
+	}, []string{"entity"})
+	errorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sportlink_cache_errors_total",
+		Help: "Cache backend failures by entity, served from the repository instead.",
+	}, []string{"entity"})
+)
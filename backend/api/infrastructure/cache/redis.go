@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// redisTimeout bounds a command whose context has no deadline, so a stalled Redis slows
+// lookups down to a miss rather than hanging them.
+const redisTimeout = 500 * time.Millisecond
+
+// Redis is a Backend shared by every instance, speaking the Redis protocol over a small
+// pool of connections. It only sends GET, SET with PX and DEL.
+type Redis struct {
+	addr   string
+	dialer net.Dialer
+	idle   chan *redisConn
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedis connects lazily to the Redis server at addr, keeping up to poolSize idle
+// connections.
+func NewRedis(addr string, poolSize int) *Redis {
+	return &Redis{addr: addr, idle: make(chan *redisConn, max(poolSize, 1))}
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+	value, ok := reply.([]byte)
+	return value, ok, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := r.do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	return err
+}
+
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := r.do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+// do sends a command and reads its reply: a string, an integer, a []byte or nil when the
+// key does not exist. Connections that fail are closed instead of going back to the pool.
+func (r *Redis) do(ctx context.Context, args ...string) (any, error) {
+	c, err := r.conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisTimeout)
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		c.conn.Close()
+		return nil, err
+	}
+
+	reply, err := c.roundTrip(args)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		c.conn.Close()
+		return nil, fmt.Errorf("redis %s failed: %w", args[0], err)
+	}
+	select {
+	case r.idle <- c:
+	default:
+		c.conn.Close()
+	}
+	return reply, err
+}
+
+func (r *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-r.idle:
+		return c, nil
+	default:
+	}
+	conn, err := r.dialer.DialContext(ctx, "tcp", r.addr)
+	if err != nil {
+		return nil, err
+	}
+	return &redisConn{conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+func (c *redisConn) roundTrip(args []string) (any, error) {
+	command := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		command = append(command, "$"+strconv.Itoa(len(arg))+"\r\n"+arg+"\r\n"...)
+	}
+	if _, err := c.conn.Write(command); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+// redisError is an error reply, after which the connection is still usable.
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+func (c *redisConn) readReply() (any, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 {
+		return nil, fmt.Errorf("malformed reply %q", line)
+	}
+	payload := line[1 : len(line)-2]
+	switch line[0] {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, redisError(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(payload)
+		if err != nil || size < 0 {
+			return nil, err
+		}
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(c.reader, value); err != nil {
+			return nil, err
+		}
+		return value[:size], nil
+	default:
+		return nil, fmt.Errorf("unexpected reply %q", line)
+	}
+}
@@ -0,0 +1,145 @@
+package cache_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sportlink/api/infrastructure/cache"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis answers GET, SET and DEL from a map, ignoring expiry, and records the
+// commands it received.
+type fakeRedis struct {
+	mu       sync.Mutex
+	values   map[string]string
+	commands []string
+}
+
+func startFakeRedis(t *testing.T) (string, *fakeRedis) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeRedis{values: map[string]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return listener.Addr().String(), server
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.Join(args, " "))
+		var reply string
+		switch args[0] {
+		case "GET":
+			if value, ok := s.values[args[1]]; ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case "SET":
+			s.values[args[1]] = args[2]
+			reply = "+OK\r\n"
+		case "DEL":
+			for _, key := range args[1:] {
+				delete(s.values, key)
+			}
+			reply = ":" + strconv.Itoa(len(args)-1) + "\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		s.mu.Unlock()
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, count)
+	for i := range args {
+		line, err = reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		arg := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return nil, err
+		}
+		args[i] = string(arg[:size])
+	}
+	return args, nil
+}
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	addr, server := startFakeRedis(t)
+	redis := cache.NewRedis(addr, 2)
+
+	// given
+	require.NoError(t, redis.Set(ctx, "offer-1", []byte("a\r\nvalue"), 30*time.Second))
+
+	// when
+	value, ok, err := redis.Get(ctx, "offer-1")
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a\r\nvalue"), value)
+
+	// when
+	require.NoError(t, redis.Delete(ctx, "offer-1", "offer-2"))
+	_, ok, err = redis.Get(ctx, "offer-1")
+
+	// then
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, server.commands, "SET offer-1 a\r\nvalue PX 30000")
+	assert.Contains(t, server.commands, "DEL offer-1 offer-2")
+}
+
+func TestRedis_Unreachable(t *testing.T) {
+	// given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+	redis := cache.NewRedis(addr, 2)
+
+	// when
+	_, ok, err := redis.Get(context.Background(), "offer-1")
+
+	// then
+	assert.Error(t, err)
+	assert.False(t, ok)
+}
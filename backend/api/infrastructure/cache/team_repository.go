@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/domain/team"
+	"time"
+)
+
+// TeamRepository caches the teams looked up by ID in front of another repository.
+// Searches go straight to it.
+type TeamRepository struct {
+	next  team.Repository
+	teams *entityCache[team.Entity]
+}
+
+func NewTeamRepository(next team.Repository, backend Backend, ttl time.Duration) *TeamRepository {
+	return &TeamRepository{
+		next: next,
+		teams: &entityCache[team.Entity]{
+			backend: backend,
+			entity:  "team",
+			ttl:     ttl,
+			idOf:    func(entity team.Entity) string { return entity.ID },
+		},
+	}
+}
+
+func (repo *TeamRepository) Save(ctx context.Context, entity team.Entity, messages []outbox.Message) error {
+	defer repo.teams.invalidate(ctx, entity.ID)
+	return repo.next.Save(ctx, entity, messages)
+}
+
+func (repo *TeamRepository) Update(ctx context.Context, previousName string, entity team.Entity, messages []outbox.Message) error {
+	defer repo.teams.invalidate(ctx, entity.ID)
+	return repo.next.Update(ctx, previousName, entity, messages)
+}
+
+// Find serves the queries for a single team ID from the cache, checking the rest of
+// their criteria on the cached team.
+func (repo *TeamRepository) Find(ctx context.Context, query team.DomainQuery) ([]team.Entity, error) {
+	if len(query.Ids) != 1 {
+		return repo.next.Find(ctx, query)
+	}
+	teams, err := repo.teams.find(ctx, query.Ids, func(ctx context.Context, ids []string) ([]team.Entity, error) {
+		return repo.next.Find(ctx, team.DomainQuery{Ids: ids})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(teams, func(entity team.Entity) bool {
+		return !query.Matches(entity)
+	}), nil
+}
+
+func (repo *TeamRepository) Search(ctx context.Context, query team.SearchQuery) (team.Page, error) {
+	return repo.next.Search(ctx, query)
+}
+
+func (repo *TeamRepository) Invalidate(ctx context.Context, ids ...string) {
+	repo.teams.invalidate(ctx, ids...)
+}
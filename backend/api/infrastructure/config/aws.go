@@ -6,6 +6,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"log"
 )
 
@@ -32,3 +33,33 @@ func NewDynamoDBClient(dynamoDbCfg DynamoDbCfg) *dynamodb.Client {
 	}
 	return dynamodb.NewFromConfig(cfg)
 }
+
+// NewSQSClient returns a client for the queues domain events and realtime updates go
+// through. When EventsCfg.SqsUrl is set, requests go to that endpoint, LocalStack in
+// development, with its dummy credentials. Otherwise the default AWS endpoint and
+// credential chain (environment, shared config, instance role) are used.
+func NewSQSClient(dynamoDbCfg DynamoDbCfg, eventsCfg EventsCfg) *sqs.Client {
+	options := []func(*config.LoadOptions) error{config.WithRegion(dynamoDbCfg.Region)}
+	if eventsCfg.SqsUrl != "" {
+		options = append(options,
+			config.WithEndpointResolver(aws.EndpointResolverFunc(
+				func(service, region string) (aws.Endpoint, error) {
+					if service == sqs.ServiceID {
+						return aws.Endpoint{
+							PartitionID:   "config",
+							URL:           eventsCfg.SqsUrl,
+							SigningRegion: dynamoDbCfg.Region,
+						}, nil
+					}
+					return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+				},
+			)),
+			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("dummy", "dummy", "dummy")),
+		)
+	}
+	cfg, err := config.LoadDefaultConfig(context.TODO(), options...)
+	if err != nil {
+		log.Fatalf("unable to load SDK config, %v", err)
+	}
+	return sqs.NewFromConfig(cfg)
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewPostgresPool(postgresCfg PostgresCfg) *pgxpool.Pool {
+	pool, err := pgxpool.New(context.Background(), postgresCfg.Url)
+	if err != nil {
+		log.Fatalf("unable to connect to PostgreSQL, %v", err)
+	}
+	return pool
+}
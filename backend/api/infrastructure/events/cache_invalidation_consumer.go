@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	accountevent "sportlink/api/application/account/events"
+	matchevent "sportlink/api/application/match/events"
+	matchofferevent "sportlink/api/application/matchoffer/events"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
+	teamevent "sportlink/api/application/team/events"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/infrastructure/cache"
+)
+
+// CacheInvalidationConsumer drops the cached offers, accounts and teams that domain events
+// report changed. The cached repositories invalidate what they write themselves; this
+// catches the changes written around them, e.g. by another instance or a migration.
+type CacheInvalidationConsumer struct {
+	offers   cache.Invalidator
+	accounts cache.Invalidator
+	teams    cache.Invalidator
+}
+
+func NewCacheInvalidationConsumer(offers, accounts, teams cache.Invalidator) *CacheInvalidationConsumer {
+	return &CacheInvalidationConsumer{offers: offers, accounts: accounts, teams: teams}
+}
+
+// EventTypes lists the event types that change a cached entity.
+func (c *CacheInvalidationConsumer) EventTypes() []string {
+	return []string{
+		matchofferevent.MatchOfferCreatedEventType,
+		matchofferevent.MatchOfferCancelledEventType,
+		matchofferevent.MatchOfferCapacityReachedEventType,
+		matchofferevent.MatchOfferExpiredEventType,
+		matchrequestevent.MatchRequestAcceptedEventType,
+		matchrequestevent.MatchRequestCancelledEventType,
+		matchrequestevent.MatchRequestPromotedEventType,
+		matchevent.MatchConfirmedEventType,
+		matchevent.MatchCancelledEventType,
+		accountevent.AccountCreatedEventType,
+		accountevent.AccountUpdatedEventType,
+		teamevent.TeamCreatedEventType,
+		teamevent.TeamUpdatedEventType,
+	}
+}
+
+// Handle invalidates every cached entity the event names. Invalidating is idempotent, and
+// the IDs an event does not carry are left empty and skipped.
+func (c *CacheInvalidationConsumer) Handle(ctx context.Context, message outbox.Message) error {
+	var changed struct {
+		MatchOfferID string `json:"match_offer_id"`
+		AccountID    string `json:"account_id"`
+		TeamID       string `json:"team_id"`
+	}
+	if err := message.Decode(&changed); err != nil {
+		return err
+	}
+
+	c.offers.Invalidate(ctx, changed.MatchOfferID)
+	c.accounts.Invalidate(ctx, changed.AccountID)
+	c.teams.Invalidate(ctx, changed.TeamID)
+	return nil
+}
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"context"
+	"sportlink/api/application"
+	notificationevent "sportlink/api/application/notification/events"
+	"sportlink/api/application/notification/usecases"
+	"sportlink/api/domain/outbox"
+	"time"
+)
+
+// DeliveryConsumer sends notifications through the off-app channel each
+// NotificationDeliveryRequestedEvent asks for. Failed sends are retried by the
+// consumer, and deliveries falling in quiet hours come back once they are over.
+type DeliveryConsumer struct {
+	deliverUC application.UseCase[usecases.DeliverNotificationInput, usecases.DeliverNotificationResult]
+	now       func() time.Time
+}
+
+func NewDeliveryConsumer(deliverUC application.UseCase[usecases.DeliverNotificationInput, usecases.DeliverNotificationResult]) *DeliveryConsumer {
+	return &DeliveryConsumer{deliverUC: deliverUC, now: time.Now}
+}
+
+func (c *DeliveryConsumer) Handle(ctx context.Context, message outbox.Message) error {
+	var event notificationevent.NotificationDeliveryRequestedEvent
+	if err := message.Decode(&event); err != nil {
+		return err
+	}
+
+	result, err := c.deliverUC.Invoke(ctx, usecases.DeliverNotificationInput{
+		NotificationID: event.NotificationID,
+		AccountID:      event.AccountID,
+		Channel:        event.Channel,
+		Now:            c.now(),
+	})
+	if err != nil {
+		return err
+	}
+	if result.DeferredUntil != nil {
+		return DeferredError{Until: *result.DeferredUntil}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sportlink/pkg/log"
+)
+
+// LogPublisher is a Publisher that only logs the event. It is used for events that
+// have no subscriber yet, so publishing never blocks the caller.
+type LogPublisher[T any] struct {
+	name string
+}
+
+func NewLogPublisher[T any](name string) *LogPublisher[T] {
+	return &LogPublisher[T]{name: name}
+}
+
+func (p *LogPublisher[T]) Publish(ctx context.Context, event T) error {
+	log.GetLogger(ctx).Info(fmt.Sprintf("%s published: %+v", p.name, event))
+	return nil
+}
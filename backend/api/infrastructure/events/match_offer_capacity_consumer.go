@@ -2,49 +2,46 @@ package events
 
 import (
 	"context"
+	stderrors "errors"
+	"sportlink/api/application/errors"
 	matchofferevent "sportlink/api/application/matchoffer/events"
 	"sportlink/api/application/matchoffer/usecases"
+	"sportlink/api/domain/outbox"
 	"sportlink/pkg/log"
 )
 
-// MatchOfferCapacityConsumer listens for MatchOfferCapacityReachedEvent and
-// triggers the confirm match offer use case to auto-create the match.
+// MatchOfferCapacityConsumer handles MatchOfferCapacityReachedEvent messages by
+// triggering the confirm match offer use case to auto-create the match. Confirming
+// is idempotent per offer, so duplicated events and a concurrent manual
+// confirmation end up with the same match.
 type MatchOfferCapacityConsumer struct {
-	ch        <-chan matchofferevent.MatchOfferCapacityReachedEvent
 	confirmUC *usecases.ConfirmMatchOfferUC
 }
 
-func NewMatchOfferCapacityConsumer(
-	ch <-chan matchofferevent.MatchOfferCapacityReachedEvent,
-	confirmUC *usecases.ConfirmMatchOfferUC,
-) *MatchOfferCapacityConsumer {
-	return &MatchOfferCapacityConsumer{ch: ch, confirmUC: confirmUC}
+func NewMatchOfferCapacityConsumer(confirmUC *usecases.ConfirmMatchOfferUC) *MatchOfferCapacityConsumer {
+	return &MatchOfferCapacityConsumer{confirmUC: confirmUC}
 }
 
-// Start launches the consumer goroutine. It stops when ctx is cancelled or
-// the channel is closed.
-func (c *MatchOfferCapacityConsumer) Start(ctx context.Context) {
-	go func() {
-		for {
-			select {
-			case event, ok := <-c.ch:
-				if !ok {
-					return
-				}
-				c.handleEvent(ctx, event)
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
-}
+// Handle confirms the offer of the event. Only failures worth retrying are returned;
+// an offer that can no longer be confirmed is logged and dropped.
+func (c *MatchOfferCapacityConsumer) Handle(ctx context.Context, message outbox.Message) error {
+	var event matchofferevent.MatchOfferCapacityReachedEvent
+	if err := message.Decode(&event); err != nil {
+		return err
+	}
 
-func (c *MatchOfferCapacityConsumer) handleEvent(ctx context.Context, event matchofferevent.MatchOfferCapacityReachedEvent) {
 	_, err := c.confirmUC.Invoke(ctx, usecases.ConfirmMatchOfferInput{
 		MatchOfferID:   event.MatchOfferID,
 		OwnerAccountID: event.OwnerAccountID,
 	})
-	if err != nil {
+	if err == nil {
+		return nil
+	}
+
+	var appErr errors.AppError
+	if stderrors.As(err, &appErr) && appErr.Code != errors.ConcurrentModificationErrorCode {
 		log.GetLogger(ctx).Error("auto-confirm failed for match offer "+event.MatchOfferID, err)
+		return nil
 	}
+	return err
 }
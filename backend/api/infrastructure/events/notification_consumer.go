@@ -0,0 +1,176 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application"
+	matchevent "sportlink/api/application/match/events"
+	matchofferevent "sportlink/api/application/matchoffer/events"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
+	"sportlink/api/application/notification/usecases"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NotificationConsumer turns domain events into inbox notifications for the accounts
+// they concern. The account that caused an event is not notified.
+type NotificationConsumer struct {
+	notifyUC application.UseCase[usecases.NotifyAccountsInput, []notification.Entity]
+}
+
+func NewNotificationConsumer(notifyUC application.UseCase[usecases.NotifyAccountsInput, []notification.Entity]) *NotificationConsumer {
+	return &NotificationConsumer{notifyUC: notifyUC}
+}
+
+// EventTypes lists the event types the consumer turns into notifications.
+func (c *NotificationConsumer) EventTypes() []string {
+	return []string{
+		matchrequestevent.MatchRequestCreatedEventType,
+		matchrequestevent.MatchRequestAcceptedEventType,
+		matchrequestevent.MatchRequestRejectedEventType,
+		matchrequestevent.MatchRequestCancelledEventType,
+		matchrequestevent.MatchRequestPromotedEventType,
+		matchofferevent.MatchOfferExpiredEventType,
+		matchevent.MatchConfirmedEventType,
+		matchevent.MatchReminderDueEventType,
+		matchevent.MatchAttendanceUnconfirmedEventType,
+	}
+}
+
+func (c *NotificationConsumer) Handle(ctx context.Context, message outbox.Message) error {
+	input, err := c.buildInput(message)
+	if err != nil {
+		return err
+	}
+	input.EventID = message.ID
+	input.Actor = message.Actor
+	input.OccurredAt = message.OccurredAt
+
+	_, err = c.notifyUC.Invoke(ctx, input)
+	return err
+}
+
+// buildInput decides who is notified about the event and with which references.
+func (c *NotificationConsumer) buildInput(message outbox.Message) (usecases.NotifyAccountsInput, error) {
+	switch message.Type {
+	case matchrequestevent.MatchRequestCreatedEventType:
+		var event matchrequestevent.MatchRequestCreatedEvent
+		if err := message.Decode(&event); err != nil {
+			return usecases.NotifyAccountsInput{}, err
+		}
+		return usecases.NotifyAccountsInput{
+			Type:       notification.TypeMatchRequestReceived,
+			Recipients: []string{event.OwnerAccountID},
+			Data:       requestData(event.MatchRequestID, event.MatchOfferID),
+		}, nil
+	case matchrequestevent.MatchRequestAcceptedEventType:
+		var event matchrequestevent.MatchRequestAcceptedEvent
+		if err := message.Decode(&event); err != nil {
+			return usecases.NotifyAccountsInput{}, err
+		}
+		return usecases.NotifyAccountsInput{
+			Type:       notification.TypeMatchRequestAccepted,
+			Recipients: []string{event.RequesterAccountID},
+			Data:       requestData(event.MatchRequestID, event.MatchOfferID),
+		}, nil
+	case matchrequestevent.MatchRequestRejectedEventType:
+		var event matchrequestevent.MatchRequestRejectedEvent
+		if err := message.Decode(&event); err != nil {
+			return usecases.NotifyAccountsInput{}, err
+		}
+		data := requestData(event.MatchRequestID, event.MatchOfferID)
+		if event.Reason != "" {
+			data["reason"] = event.Reason
+		}
+		return usecases.NotifyAccountsInput{
+			Type:       notification.TypeMatchRequestRejected,
+			Recipients: []string{event.RequesterAccountID},
+			Data:       data,
+		}, nil
+	case matchrequestevent.MatchRequestCancelledEventType:
+		var event matchrequestevent.MatchRequestCancelledEvent
+		if err := message.Decode(&event); err != nil {
+			return usecases.NotifyAccountsInput{}, err
+		}
+		// either side may cancel; the actor filter leaves only the other one
+		return usecases.NotifyAccountsInput{
+			Type:       notification.TypeMatchRequestCancelled,
+			Recipients: []string{event.OwnerAccountID, event.RequesterAccountID},
+			Data:       requestData(event.MatchRequestID, event.MatchOfferID),
+		}, nil
+	case matchrequestevent.MatchRequestPromotedEventType:
+		var event matchrequestevent.MatchRequestPromotedEvent
+		if err := message.Decode(&event); err != nil {
+			return usecases.NotifyAccountsInput{}, err
+		}
+		data := requestData(event.MatchRequestID, event.MatchOfferID)
+		data["match_id"] = event.MatchID
+		return usecases.NotifyAccountsInput{
+			Type:       notification.TypeMatchRequestPromoted,
+			Recipients: []string{event.RequesterAccountID},
+			Data:       data,
+		}, nil
+	case matchofferevent.MatchOfferExpiredEventType:
+		var event matchofferevent.MatchOfferExpiredEvent
+		if err := message.Decode(&event); err != nil {
+			return usecases.NotifyAccountsInput{}, err
+		}
+		return usecases.NotifyAccountsInput{
+			Type:       notification.TypeMatchOfferExpired,
+			Recipients: event.RequesterAccountIDs,
+			Data:       map[string]string{"match_offer_id": event.MatchOfferID},
+		}, nil
+	case matchevent.MatchConfirmedEventType:
+		var event matchevent.MatchConfirmedEvent
+		if err := message.Decode(&event); err != nil {
+			return usecases.NotifyAccountsInput{}, err
+		}
+		return usecases.NotifyAccountsInput{
+			Type:       notification.TypeMatchConfirmed,
+			Recipients: event.Participants,
+			Data:       map[string]string{"match_id": event.MatchID, "match_offer_id": event.MatchOfferID},
+		}, nil
+	case matchevent.MatchReminderDueEventType:
+		var event matchevent.MatchReminderDueEvent
+		if err := message.Decode(&event); err != nil {
+			return usecases.NotifyAccountsInput{}, err
+		}
+		return usecases.NotifyAccountsInput{
+			Type:       notification.TypeMatchReminder,
+			Recipients: event.Participants,
+			Data: map[string]string{
+				"match_id":       event.MatchID,
+				"match_offer_id": event.MatchOfferID,
+				"start_time":     event.StartTime.Format(time.RFC3339),
+				"lead":           event.Lead,
+			},
+		}, nil
+	case matchevent.MatchAttendanceUnconfirmedEventType:
+		var event matchevent.MatchAttendanceUnconfirmedEvent
+		if err := message.Decode(&event); err != nil {
+			return usecases.NotifyAccountsInput{}, err
+		}
+		return usecases.NotifyAccountsInput{
+			Type:       notification.TypeAttendanceUnconfirmed,
+			Recipients: []string{event.OrganiserAccountID},
+			Data: map[string]string{
+				"match_id":          event.MatchID,
+				"match_offer_id":    event.MatchOfferID,
+				"unconfirmed":       strings.Join(event.Unconfirmed, ","),
+				"unconfirmed_count": strconv.Itoa(len(event.Unconfirmed)),
+			},
+		}, nil
+	default:
+		return usecases.NotifyAccountsInput{}, fmt.Errorf("no notification for %s events", message.Type)
+	}
+}
+
+func requestData(matchRequestID, matchOfferID string) map[string]string {
+	return map[string]string{
+		"match_request_id": matchRequestID,
+		"match_offer_id":   matchOfferID,
+	}
+}
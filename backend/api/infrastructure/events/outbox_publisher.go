@@ -0,0 +1,28 @@
+package events
+
+import (
+	"context"
+	appevents "sportlink/api/application/events"
+	"sportlink/api/domain/outbox"
+	"time"
+)
+
+// OutboxPublisher publishes catalogue events by writing them to the outbox, from where
+// the relay hands them over to the broker. The write happens after the change that
+// raised the event; use cases that need both to be atomic add the message to their
+// own transaction instead.
+type OutboxPublisher struct {
+	repository outbox.Repository
+}
+
+func NewOutboxPublisher(repository outbox.Repository) *OutboxPublisher {
+	return &OutboxPublisher{repository: repository}
+}
+
+func (p *OutboxPublisher) Publish(ctx context.Context, event appevents.Event) error {
+	message, err := appevents.NewMessage(ctx, event, time.Now())
+	if err != nil {
+		return err
+	}
+	return p.repository.Save(ctx, message)
+}
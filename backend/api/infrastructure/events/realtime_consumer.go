@@ -0,0 +1,124 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	chatevent "sportlink/api/application/chat/events"
+	matchevent "sportlink/api/application/match/events"
+	matchofferevent "sportlink/api/application/matchoffer/events"
+	matchrequestevent "sportlink/api/application/matchrequest/events"
+	"sportlink/api/application/realtime"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+)
+
+// RealtimeConsumer pushes domain events to the open streams of the accounts they
+// concern. Updates are only worth delivering while fresh, so a failed push is logged
+// instead of retried.
+type RealtimeConsumer struct {
+	backplane realtime.Backplane
+}
+
+func NewRealtimeConsumer(backplane realtime.Backplane) *RealtimeConsumer {
+	return &RealtimeConsumer{backplane: backplane}
+}
+
+// EventTypes lists the event types pushed to streams.
+func (c *RealtimeConsumer) EventTypes() []string {
+	return []string{
+		matchrequestevent.MatchRequestCreatedEventType,
+		matchrequestevent.MatchRequestAcceptedEventType,
+		matchrequestevent.MatchRequestRejectedEventType,
+		matchofferevent.MatchOfferCapacityReachedEventType,
+		matchevent.MatchConfirmedEventType,
+		chatevent.ChatMessageSentEventType,
+		chatevent.ChatThreadReadEventType,
+	}
+}
+
+func (c *RealtimeConsumer) Handle(ctx context.Context, message outbox.Message) error {
+	updateType, recipients, data, err := c.route(message)
+	if err != nil {
+		return err
+	}
+
+	for _, accountID := range recipients {
+		update := realtime.Update{
+			ID:         message.ID,
+			AccountID:  accountID,
+			Type:       updateType,
+			Data:       data,
+			OccurredAt: message.OccurredAt,
+		}
+		if err = c.backplane.Publish(ctx, update); err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("failed to push %s update to account %s", updateType, accountID), err)
+		}
+	}
+	return nil
+}
+
+// route decides which update the event becomes and which accounts receive it.
+func (c *RealtimeConsumer) route(message outbox.Message) (string, []string, map[string]string, error) {
+	switch message.Type {
+	case matchrequestevent.MatchRequestCreatedEventType:
+		var event matchrequestevent.MatchRequestCreatedEvent
+		if err := message.Decode(&event); err != nil {
+			return "", nil, nil, err
+		}
+		return realtime.UpdateMatchRequestReceived, []string{event.OwnerAccountID},
+			requestData(event.MatchRequestID, event.MatchOfferID), nil
+	case matchrequestevent.MatchRequestAcceptedEventType:
+		var event matchrequestevent.MatchRequestAcceptedEvent
+		if err := message.Decode(&event); err != nil {
+			return "", nil, nil, err
+		}
+		return realtime.UpdateMatchRequestAccepted, []string{event.RequesterAccountID},
+			requestData(event.MatchRequestID, event.MatchOfferID), nil
+	case matchrequestevent.MatchRequestRejectedEventType:
+		var event matchrequestevent.MatchRequestRejectedEvent
+		if err := message.Decode(&event); err != nil {
+			return "", nil, nil, err
+		}
+		return realtime.UpdateMatchRequestRejected, []string{event.RequesterAccountID},
+			requestData(event.MatchRequestID, event.MatchOfferID), nil
+	case matchofferevent.MatchOfferCapacityReachedEventType:
+		var event matchofferevent.MatchOfferCapacityReachedEvent
+		if err := message.Decode(&event); err != nil {
+			return "", nil, nil, err
+		}
+		return realtime.UpdateMatchOfferFull, []string{event.OwnerAccountID},
+			map[string]string{"match_offer_id": event.MatchOfferID}, nil
+	case matchevent.MatchConfirmedEventType:
+		var event matchevent.MatchConfirmedEvent
+		if err := message.Decode(&event); err != nil {
+			return "", nil, nil, err
+		}
+		return realtime.UpdateMatchConfirmed, event.Participants,
+			map[string]string{"match_id": event.MatchID, "match_offer_id": event.MatchOfferID}, nil
+	case chatevent.ChatMessageSentEventType:
+		var event chatevent.ChatMessageSentEvent
+		if err := message.Decode(&event); err != nil {
+			return "", nil, nil, err
+		}
+		return realtime.UpdateChatMessage, event.Members, map[string]string{
+			"thread_kind":       event.ThreadKind,
+			"thread_id":         event.ThreadID,
+			"message_id":        event.MessageID,
+			"sender_account_id": event.SenderAccountID,
+			"body":              event.Body,
+		}, nil
+	case chatevent.ChatThreadReadEventType:
+		var event chatevent.ChatThreadReadEvent
+		if err := message.Decode(&event); err != nil {
+			return "", nil, nil, err
+		}
+		return realtime.UpdateChatRead, event.Members, map[string]string{
+			"thread_kind":          event.ThreadKind,
+			"thread_id":            event.ThreadID,
+			"account_id":           event.AccountID,
+			"last_read_message_id": event.LastReadMessageID,
+		}, nil
+	default:
+		return "", nil, nil, fmt.Errorf("no realtime update for %s events", message.Type)
+	}
+}
@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+	"sportlink/api/application"
+	matchevent "sportlink/api/application/match/events"
+	"sportlink/api/application/match/usecases"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/outbox"
+	"time"
+)
+
+// ReminderConsumer plans the reminders of every match once it is confirmed.
+type ReminderConsumer struct {
+	scheduleUC application.UseCase[usecases.ScheduleMatchRemindersInput, []match.Reminder]
+	now        func() time.Time
+}
+
+func NewReminderConsumer(scheduleUC application.UseCase[usecases.ScheduleMatchRemindersInput, []match.Reminder]) *ReminderConsumer {
+	return &ReminderConsumer{scheduleUC: scheduleUC, now: time.Now}
+}
+
+func (c *ReminderConsumer) Handle(ctx context.Context, message outbox.Message) error {
+	var event matchevent.MatchConfirmedEvent
+	if err := message.Decode(&event); err != nil {
+		return err
+	}
+
+	_, err := c.scheduleUC.Invoke(ctx, usecases.ScheduleMatchRemindersInput{
+		MatchID:            event.MatchID,
+		MatchOfferID:       event.MatchOfferID,
+		OrganiserAccountID: event.OwnerAccountID,
+		Now:                c.now(),
+	})
+	return err
+}
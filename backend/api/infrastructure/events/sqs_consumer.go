@@ -0,0 +1,175 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	appevents "sportlink/api/application/events"
+	"sportlink/api/application/messaging"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/outbox"
+	"sportlink/pkg/log"
+	"time"
+)
+
+const (
+	receiveBatchSize = 10
+	receiveWait      = 10 * time.Second
+	receiveErrorWait = 5 * time.Second
+	// maxDeferral is the longest SQS keeps a received message hidden
+	maxDeferral = 12 * time.Hour
+)
+
+// DeferredError is returned by handlers that cannot act on a message yet, e.g. a
+// delivery held back by quiet hours. The message comes back once Until is reached,
+// without counting as a failure.
+type DeferredError struct {
+	Until time.Time
+}
+
+func (e DeferredError) Error() string {
+	return fmt.Sprintf("deferred until %s", e.Until.Format(time.RFC3339))
+}
+
+// Handler processes the messages of one event type. Returning an error retries the
+// message later; handlers must therefore be safe to run more than once.
+type Handler interface {
+	Handle(ctx context.Context, message outbox.Message) error
+}
+
+// SQSConsumer receives outbox messages from the queue and dispatches them to the
+// handlers registered for their type. Delivery is at least once: a message is deleted
+// from the queue only after its handler succeeded, and the IDs of handled messages are
+// remembered so a redelivery is acknowledged without running the handler again.
+// Failed messages are retried with backoff until the queue moves them to its
+// dead-letter queue. When one of several handlers of a type fails, all of them run
+// again on the retry.
+type SQSConsumer struct {
+	name        string
+	broker      messaging.Broker
+	processed   outbox.ProcessedRepository
+	retryPolicy outbox.RetryPolicy
+	handlers    map[string][]Handler
+}
+
+func NewSQSConsumer(
+	name string,
+	broker messaging.Broker,
+	processed outbox.ProcessedRepository,
+	retryPolicy outbox.RetryPolicy,
+) *SQSConsumer {
+	return &SQSConsumer{
+		name:        name,
+		broker:      broker,
+		processed:   processed,
+		retryPolicy: retryPolicy,
+		handlers:    map[string][]Handler{},
+	}
+}
+
+// Register routes the messages of eventType to handler, in addition to the handlers
+// already registered for it.
+func (c *SQSConsumer) Register(eventType string, handler Handler) *SQSConsumer {
+	c.handlers[eventType] = append(c.handlers[eventType], handler)
+	return c
+}
+
+// Start launches the consumer goroutine. It stops when ctx is cancelled.
+func (c *SQSConsumer) Start(ctx context.Context) {
+	go func() {
+		for ctx.Err() == nil {
+			received, err := c.broker.Receive(ctx, receiveBatchSize, receiveWait)
+			if err != nil {
+				log.GetLogger(ctx).Error(fmt.Sprintf("%s failed to receive messages", c.name), err)
+				select {
+				case <-time.After(receiveErrorWait):
+				case <-ctx.Done():
+				}
+				continue
+			}
+			for _, r := range received {
+				c.consume(ctx, r)
+			}
+		}
+	}()
+}
+
+func (c *SQSConsumer) consume(ctx context.Context, received messaging.ReceivedMessage) {
+	message, err := outbox.FromEnvelope(received.Body)
+	if err != nil {
+		// left on the queue so it ends up in the dead-letter queue for inspection
+		log.GetLogger(ctx).Error(fmt.Sprintf("%s received a malformed message", c.name), err)
+		return
+	}
+
+	handlers, ok := c.handlers[message.Type]
+	if !ok {
+		log.GetLogger(ctx).Info(fmt.Sprintf("%s has no handler for %s, dropping message %s", c.name, message.Type, message.ID))
+		c.ack(ctx, received, message)
+		return
+	}
+
+	processed, err := c.processed.IsProcessed(ctx, c.name, message.ID)
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("%s failed to check message %s", c.name, message.ID), err)
+		return
+	}
+	if processed {
+		c.ack(ctx, received, message)
+		return
+	}
+
+	// events raised while handling the message share its correlation ID, and the status
+	// changes it causes are put down to the account that raised it
+	err = c.handle(handlerContext(ctx, message), handlers, message)
+	var deferred DeferredError
+	if errors.As(err, &deferred) {
+		c.deferUntil(ctx, received, message, deferred.Until)
+		return
+	}
+	if err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("%s failed to handle %s message %s, attempt %d", c.name, message.Type, message.ID, received.ReceiveCount), err)
+		if err = c.broker.Delay(ctx, received.ReceiptHandle, c.retryPolicy.Backoff(received.ReceiveCount)); err != nil {
+			log.GetLogger(ctx).Error(fmt.Sprintf("%s failed to delay message %s", c.name, message.ID), err)
+		}
+		return
+	}
+
+	if err = c.processed.MarkProcessed(ctx, c.name, message.ID); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("%s failed to remember message %s", c.name, message.ID), err)
+	}
+	c.ack(ctx, received, message)
+}
+
+func (c *SQSConsumer) handle(ctx context.Context, handlers []Handler, message outbox.Message) error {
+	for _, handler := range handlers {
+		if err := handler.Handle(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deferUntil hides the message until the given time, or for as long as SQS allows;
+// a message deferred further is simply deferred again when it comes back.
+func (c *SQSConsumer) deferUntil(ctx context.Context, received messaging.ReceivedMessage, message outbox.Message, until time.Time) {
+	delay := min(max(time.Until(until), 0), maxDeferral)
+	if err := c.broker.Delay(ctx, received.ReceiptHandle, delay); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("%s failed to defer message %s", c.name, message.ID), err)
+	}
+}
+
+func (c *SQSConsumer) ack(ctx context.Context, received messaging.ReceivedMessage, message outbox.Message) {
+	if err := c.broker.Delete(ctx, received.ReceiptHandle); err != nil {
+		log.GetLogger(ctx).Error(fmt.Sprintf("%s failed to delete message %s", c.name, message.ID), err)
+	}
+}
+
+// handlerContext returns the context the handlers of message run in.
+func handlerContext(ctx context.Context, message outbox.Message) context.Context {
+	origin := history.Origin{Source: history.SourceConsumer}
+	if message.Actor != appevents.SystemActor {
+		origin.AccountID = message.Actor
+	}
+	return history.WithOrigin(appevents.WithCorrelationID(ctx, message.CorrelationID), origin)
+}
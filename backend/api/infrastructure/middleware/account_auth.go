@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"sportlink/api/application/auth/service"
+	"sportlink/api/application/errors"
+	"sportlink/api/application/events"
+	"sportlink/api/domain/history"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessTokenQueryParam carries the access token for clients that cannot set headers,
+// such as the browser EventSource API.
+const AccessTokenQueryParam = "access_token"
+
+// AccountAuth only lets requests through when they carry an access token issued to the
+// account of the :account_id path parameter. The token is read from the Authorization
+// bearer header, or from the access_token query parameter. The account of the verified
+// token is recorded as the actor of the events and history written for the request.
+func AccountAuth(jwtService service.JWTService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if token == "" {
+			token = c.Query(AccessTokenQueryParam)
+		}
+		if token == "" {
+			c.Error(errors.Unauthorized("missing access token"))
+			c.Abort()
+			return
+		}
+
+		claims, err := jwtService.Parse(token)
+		if err != nil {
+			c.Error(errors.Unauthorized("invalid access token"))
+			c.Abort()
+			return
+		}
+		if claims.AccountID != c.Param("account_id") {
+			c.Error(errors.Unauthorized("access token belongs to another account"))
+			c.Abort()
+			return
+		}
+
+		ctx := events.WithActor(c.Request.Context(), claims.AccountID)
+		origin := history.OriginOf(ctx)
+		origin.AccountID = claims.AccountID
+		c.Request = c.Request.WithContext(history.WithOrigin(ctx, origin))
+
+		c.Next()
+	}
+}
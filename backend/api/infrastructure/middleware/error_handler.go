@@ -23,7 +23,8 @@ func ErrorHandler() gin.HandlerFunc {
 					status = http.StatusNotFound
 				case appErrors.UnauthorizedErrorCode:
 					status = http.StatusUnauthorized
-				case appErrors.UseCaseExecutionErrorCode:
+				case appErrors.UseCaseExecutionErrorCode, appErrors.MatchOfferFullErrorCode, appErrors.ConcurrentModificationErrorCode,
+					appErrors.InvalidTransitionErrorCode:
 					status = http.StatusConflict
 				}
 
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"sportlink/api/application/events"
+	"sportlink/api/domain/history"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oklog/ulid/v2"
+)
+
+// CorrelationIDHeader carries the correlation ID of a request in and out of the API.
+const CorrelationIDHeader = "X-Correlation-Id"
+
+// EventContext stores in the request context the actor and correlation ID stamped on the
+// domain events raised while serving the request, and the origin recorded in the history
+// of the entities it changes. The correlation ID is taken from the request header, or
+// generated when missing, and echoed in the response. The actor is left unset here:
+// AccountAuth records it from the verified access token.
+func EventContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		correlationID := c.GetHeader(CorrelationIDHeader)
+		if correlationID == "" {
+			correlationID = ulid.Make().String()
+		}
+		c.Header(CorrelationIDHeader, correlationID)
+
+		ctx := events.WithCorrelationID(c.Request.Context(), correlationID)
+		ctx = history.WithOrigin(ctx, history.Origin{Source: history.SourceREST})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sportlink/api/application/notification/service"
+	"time"
+)
+
+const fcmEndpoint = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+
+// FCMSender sends push notifications to browsers and devices through the Firebase Cloud
+// Messaging HTTP v1 API. Web Push subscriptions registered through Firebase are
+// addressed by their FCM token as well.
+type FCMSender struct {
+	client      *http.Client
+	endpoint    string
+	accessToken string
+}
+
+// NewFCMSender creates a sender for the Firebase project. The access token is an OAuth2
+// token of a service account allowed to send messages; it is refreshed outside the
+// process and read from configuration.
+func NewFCMSender(projectID, accessToken string) *FCMSender {
+	return &FCMSender{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		endpoint:    fmt.Sprintf(fcmEndpoint, projectID),
+		accessToken: accessToken,
+	}
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func (s *FCMSender) Send(ctx context.Context, message service.Message) error {
+	payload, err := json.Marshal(fcmRequest{Message: fcmMessage{
+		Token:        message.Address,
+		Notification: fcmNotification{Title: message.Subject, Body: message.Body},
+		Data:         map[string]string{"notification_id": message.NotificationID},
+	}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send push notification to account %s: %w", message.AccountID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to send push notification to account %s: status %d", message.AccountID, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"sportlink/api/application/notification/service"
+	"strings"
+)
+
+// SMTPSender sends notifications as plain text emails through an SMTP relay.
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPSender creates a sender for the relay at host:port. Authentication is skipped
+// when no username is given, as with a local relay.
+func NewSMTPSender(host string, port int, username, password, from string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{
+		addr: net.JoinHostPort(host, fmt.Sprint(port)),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (s *SMTPSender) Send(_ context.Context, message service.Message) error {
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{message.Address}, s.compose(message)); err != nil {
+		return fmt.Errorf("failed to send email to account %s: %w", message.AccountID, err)
+	}
+	return nil
+}
+
+func (s *SMTPSender) compose(message service.Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + s.from + "\r\n")
+	b.WriteString("To: " + message.Address + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", message.Subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(message.Body)
+	return []byte(b.String())
+}
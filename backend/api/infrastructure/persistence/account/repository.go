@@ -4,17 +4,21 @@ import (
 	"context"
 	"fmt"
 	"sportlink/api/domain/account"
+	"sportlink/api/domain/outbox"
+	ioutbox "sportlink/api/infrastructure/persistence/outbox"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
 // DynamoDBClientInterface defines the interface for DynamoDB operations needed by the repository
 type DynamoDBClientInterface interface {
 	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
 	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
 }
 
 type RepositoryAdapter struct {
@@ -37,7 +41,8 @@ func NewRepositoryWithInterface(client DynamoDBClientInterface, tableName string
 	}
 }
 
-func (repo *RepositoryAdapter) Save(ctx context.Context, entity account.Entity) error {
+// Save writes the account, in one transaction with the outbox messages when there are any.
+func (repo *RepositoryAdapter) Save(ctx context.Context, entity account.Entity, messages []outbox.Message) error {
 	dto, err := From(entity)
 	if err != nil {
 		return err
@@ -48,9 +53,21 @@ func (repo *RepositoryAdapter) Save(ctx context.Context, entity account.Entity)
 		return err
 	}
 
-	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: &repo.tableName,
-		Item:      av,
+	if len(messages) == 0 {
+		_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName: &repo.tableName,
+			Item:      av,
+		})
+		return err
+	}
+	messageItems, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append([]types.TransactWriteItem{{
+			Put: &types.Put{TableName: &repo.tableName, Item: av},
+		}}, messageItems...),
 	})
 	return err
 }
@@ -80,6 +97,8 @@ func (repo *RepositoryAdapter) Find(ctx context.Context, query account.DomainQue
 	return repo.findWithQuery(ctx, query)
 }
 
+// findByAccountIDs reads the accounts through the AccountId GSI and keeps those matching
+// the other criteria of the query.
 func (repo *RepositoryAdapter) findByAccountIDs(ctx context.Context, query account.DomainQuery) ([]account.Entity, error) {
 	var results []account.Entity
 	seen := make(map[string]bool)
@@ -109,7 +128,7 @@ func (repo *RepositoryAdapter) findByAccountIDs(ctx context.Context, query accou
 				return nil, fmt.Errorf("failed to unmarshal item: %w", err)
 			}
 			entity := dto.ToDomain()
-			if !seen[entity.AccountID] {
+			if !seen[entity.AccountID] && query.Matches(entity) {
 				seen[entity.AccountID] = true
 				results = append(results, entity)
 			}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	daccount "sportlink/api/domain/account"
+	"sportlink/api/domain/outbox"
 	"sportlink/api/infrastructure/persistence/account"
 	amocks "sportlink/mocks/api/infrastructure/persistence/account"
 	"testing"
@@ -19,6 +20,7 @@ func TestRepository_Save(t *testing.T) {
 	testCases := []struct {
 		name       string
 		entity     daccount.Entity
+		messages   []outbox.Message
 		setupMock  func(*amocks.DynamoDBClientInterface, daccount.Entity)
 		assertions func(*testing.T, error)
 	}{
@@ -39,6 +41,24 @@ func TestRepository_Save(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			name:     "given account with messages when saving then writes them in the same transaction",
+			entity:   daccount.Entity{Email: "test@example.com", Nickname: "testuser"},
+			messages: []outbox.Message{{ID: "message-1", Type: "AccountCreated"}},
+			setupMock: func(mockClient *amocks.DynamoDBClientInterface, entity daccount.Entity) {
+				mockClient.On("TransactWriteItems", mock.Anything, mock.MatchedBy(func(input *dynamodb.TransactWriteItemsInput) bool {
+					if len(input.TransactItems) != 2 {
+						return false
+					}
+					var savedDto account.Dto
+					_ = attributevalue.UnmarshalMap(input.TransactItems[0].Put.Item, &savedDto)
+					return savedDto.Email == entity.Email && input.TransactItems[1].Put != nil
+				})).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+			},
+			assertions: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
 		{
 			name:   "given account with empty email when saving then returns error",
 			entity: daccount.Entity{Email: "", Nickname: "testuser"},
@@ -67,7 +87,7 @@ func TestRepository_Save(t *testing.T) {
 			tc.setupMock(mockClient, tc.entity)
 			repository := account.NewRepositoryWithInterface(mockClient, "SportLinkCore")
 
-			err := repository.Save(context.Background(), tc.entity)
+			err := repository.Save(context.Background(), tc.entity, tc.messages)
 
 			tc.assertions(t, err)
 			mockClient.AssertExpectations(t)
@@ -0,0 +1,80 @@
+package chat
+
+import (
+	"sportlink/api/domain/chat"
+	"strings"
+	"time"
+)
+
+func messagesEntityID(thread chat.Thread) string {
+	return "Entity#ChatMessage#" + thread.Key()
+}
+
+func receiptsEntityID(thread chat.Thread) string {
+	return "Entity#ChatReceipt#" + thread.Key()
+}
+
+// Dto is a message of a thread. All messages of a thread share one partition and sort
+// by their ULID, so pages are read newest first with a backwards query.
+type Dto struct {
+	EntityId        string `dynamodbav:"EntityId"` // "Entity#ChatMessage#<kind>#<refId>"
+	Id              string `dynamodbav:"Id"`       // "<ulid>"
+	ThreadKey       string `dynamodbav:"ThreadKey"`
+	SenderAccountId string `dynamodbav:"SenderAccountId"`
+	Body            string `dynamodbav:"Body"`
+	SentAt          int64  `dynamodbav:"SentAt"` // Unix timestamp in milliseconds
+}
+
+// ReceiptDto records how far a member has read a thread.
+type ReceiptDto struct {
+	EntityId          string `dynamodbav:"EntityId"` // "Entity#ChatReceipt#<kind>#<refId>"
+	Id                string `dynamodbav:"Id"`       // "<accountId>"
+	ThreadKey         string `dynamodbav:"ThreadKey"`
+	LastReadMessageId string `dynamodbav:"LastReadMessageId"`
+	ReadAt            int64  `dynamodbav:"ReadAt"` // Unix timestamp in milliseconds
+}
+
+func (d *Dto) ToDomain() chat.Message {
+	return chat.Message{
+		ID:              d.Id,
+		Thread:          threadFromKey(d.ThreadKey),
+		SenderAccountID: d.SenderAccountId,
+		Body:            d.Body,
+		SentAt:          time.UnixMilli(d.SentAt).UTC(),
+	}
+}
+
+func From(message chat.Message) Dto {
+	return Dto{
+		EntityId:        messagesEntityID(message.Thread),
+		Id:              message.ID,
+		ThreadKey:       message.Thread.Key(),
+		SenderAccountId: message.SenderAccountID,
+		Body:            message.Body,
+		SentAt:          message.SentAt.UnixMilli(),
+	}
+}
+
+func (d *ReceiptDto) ToDomain() chat.Receipt {
+	return chat.Receipt{
+		Thread:            threadFromKey(d.ThreadKey),
+		AccountID:         d.Id,
+		LastReadMessageID: d.LastReadMessageId,
+		ReadAt:            time.UnixMilli(d.ReadAt).UTC(),
+	}
+}
+
+func FromReceipt(receipt chat.Receipt) ReceiptDto {
+	return ReceiptDto{
+		EntityId:          receiptsEntityID(receipt.Thread),
+		Id:                receipt.AccountID,
+		ThreadKey:         receipt.Thread.Key(),
+		LastReadMessageId: receipt.LastReadMessageID,
+		ReadAt:            receipt.ReadAt.UnixMilli(),
+	}
+}
+
+func threadFromKey(key string) chat.Thread {
+	kind, refID, _ := strings.Cut(key, "#")
+	return chat.NewThread(chat.Kind(kind), refID)
+}
@@ -0,0 +1,150 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/chat"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBClientInterface defines the interface for DynamoDB operations needed by the repository
+type DynamoDBClientInterface interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+}
+
+type RepositoryAdapter struct {
+	dbClient  DynamoDBClientInterface
+	tableName string
+}
+
+func NewRepository(client *dynamodb.Client, tableName string) chat.Repository {
+	return &RepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func NewRepositoryWithInterface(client DynamoDBClientInterface, tableName string) chat.Repository {
+	return &RepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func (repo *RepositoryAdapter) Save(ctx context.Context, message chat.Message) error {
+	av, err := attributevalue.MarshalMap(From(message))
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message %s: %w", message.ID, err)
+	}
+	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(repo.tableName),
+		Item:      av,
+	})
+	return err
+}
+
+// Find reads the thread partition backwards, starting right after the cursor. One more
+// message than requested is read to know whether another page follows.
+func (repo *RepositoryAdapter) Find(ctx context.Context, query chat.DomainQuery) (chat.Page, error) {
+	partition := messagesEntityID(query.Thread)
+	expr, err := expression.NewBuilder().WithKeyCondition(
+		expression.KeyEqual(expression.Key("EntityId"), expression.Value(partition)),
+	).Build()
+	if err != nil {
+		return chat.Page{}, err
+	}
+
+	var lastKey map[string]types.AttributeValue
+	if query.Cursor != "" {
+		lastKey, err = attributevalue.MarshalMap(map[string]string{
+			"EntityId": partition,
+			"Id":       query.Cursor,
+		})
+		if err != nil {
+			return chat.Page{}, err
+		}
+	}
+
+	resp, err := repo.dbClient.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(repo.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ExclusiveStartKey:         lastKey,
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     aws.Int32(int32(query.Limit + 1)),
+	})
+	if err != nil {
+		return chat.Page{}, err
+	}
+
+	messages := make([]chat.Message, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		var dto Dto
+		if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+			return chat.Page{}, fmt.Errorf("failed to unmarshal chat message: %w", err)
+		}
+		messages = append(messages, dto.ToDomain())
+	}
+
+	page := chat.Page{Entities: messages}
+	if len(messages) > query.Limit {
+		page.Entities = messages[:query.Limit]
+		page.NextCursor = page.Entities[query.Limit-1].ID
+	}
+	return page, nil
+}
+
+func (repo *RepositoryAdapter) SaveReceipt(ctx context.Context, receipt chat.Receipt) error {
+	av, err := attributevalue.MarshalMap(FromReceipt(receipt))
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat receipt: %w", err)
+	}
+	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(repo.tableName),
+		Item:      av,
+	})
+	return err
+}
+
+func (repo *RepositoryAdapter) FindReceipts(ctx context.Context, thread chat.Thread) ([]chat.Receipt, error) {
+	expr, err := expression.NewBuilder().WithKeyCondition(
+		expression.KeyEqual(expression.Key("EntityId"), expression.Value(receiptsEntityID(thread))),
+	).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]chat.Receipt, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		resp, err := repo.dbClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(repo.tableName),
+			KeyConditionExpression:    expr.KeyCondition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ExclusiveStartKey:         lastKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Items {
+			var dto ReceiptDto
+			if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal chat receipt: %w", err)
+			}
+			receipts = append(receipts, dto.ToDomain())
+		}
+		if resp.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = resp.LastEvaluatedKey
+	}
+	return receipts, nil
+}
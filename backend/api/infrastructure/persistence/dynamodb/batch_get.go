@@ -0,0 +1,71 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// BatchGetMaxKeys is the most keys DynamoDB accepts in a single BatchGetItem call.
+const BatchGetMaxKeys = 100
+
+// batchGetMaxBackoff caps the wait before retrying the keys DynamoDB left unprocessed.
+const batchGetMaxBackoff = time.Second
+
+// batchGetBackoff is the wait before the first retry of unprocessed keys, doubled on every
+// retry of the same chunk.
+var batchGetBackoff = 50 * time.Millisecond
+
+// BatchGetClient is the part of the DynamoDB client BatchGet reads with.
+type BatchGetClient interface {
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
+}
+
+// BatchGet reads the items of the entityID partition stored under ids, BatchGetMaxKeys at a
+// time. Keys DynamoDB leaves unprocessed are retried with backoff until every key was read.
+// Duplicated ids are read once, and ids without an item are left out; the items come in no
+// particular order.
+func BatchGet(ctx context.Context, client BatchGetClient, tableName, entityID string, ids []string) ([]map[string]types.AttributeValue, error) {
+	keys := make([]map[string]types.AttributeValue, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		keys = append(keys, map[string]types.AttributeValue{
+			"EntityId": &types.AttributeValueMemberS{Value: entityID},
+			"Id":       &types.AttributeValueMemberS{Value: id},
+		})
+	}
+
+	items := make([]map[string]types.AttributeValue, 0, len(keys))
+	for start := 0; start < len(keys); start += BatchGetMaxKeys {
+		pending := keys[start:min(start+BatchGetMaxKeys, len(keys))]
+		backoff := batchGetBackoff
+		for len(pending) > 0 {
+			resp, err := client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: map[string]types.KeysAndAttributes{tableName: {Keys: pending}},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to batch get %s items: %w", entityID, err)
+			}
+			items = append(items, resp.Responses[tableName]...)
+
+			pending = resp.UnprocessedKeys[tableName].Keys
+			if len(pending) == 0 {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, batchGetMaxBackoff)
+		}
+	}
+	return items, nil
+}
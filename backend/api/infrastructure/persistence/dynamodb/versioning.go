@@ -0,0 +1,73 @@
+package dynamodb
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// VersionAttribute is the item attribute holding the optimistic lock of versioned entities.
+const VersionAttribute = "Version"
+
+// VersionCondition guards a write of an item that was read at version. Version 0 means the
+// entity is new or predates versioning, so the stored item must still lack a version:
+// two first writers cannot both win.
+func VersionCondition(version int) expression.ConditionBuilder {
+	if version == 0 {
+		return expression.Or(
+			expression.AttributeNotExists(expression.Name(VersionAttribute)),
+			expression.Name(VersionAttribute).Equal(expression.Value(0)),
+		)
+	}
+	return expression.Name(VersionAttribute).Equal(expression.Value(version))
+}
+
+// IsConditionalCheckFailed reports whether a single-item write was rejected by its condition.
+func IsConditionalCheckFailed(err error) bool {
+	var ccf *types.ConditionalCheckFailedException
+	return errors.As(err, &ccf)
+}
+
+// CancellationReasons returns the per-item reasons of a cancelled transaction, in the
+// order the items were sent, or nil when err is not a cancelled transaction.
+func CancellationReasons(err error) []types.CancellationReason {
+	var canceled *types.TransactionCanceledException
+	if errors.As(err, &canceled) {
+		return canceled.CancellationReasons
+	}
+	return nil
+}
+
+// IsTransactionConditionFailed reports whether a transaction was cancelled because the
+// condition of at least one of its items did not hold.
+func IsTransactionConditionFailed(err error) bool {
+	for _, r := range CancellationReasons(err) {
+		if aws.ToString(r.Code) == "ConditionalCheckFailed" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsConditionFailed reports whether a single-item write or a transaction was rejected by
+// a condition, for writes that only use a transaction when they carry extra items.
+func IsConditionFailed(err error) bool {
+	return IsConditionalCheckFailed(err) || IsTransactionConditionFailed(err)
+}
+
+// VersionedPut returns a transactional put of item conditioned on the version it was read at.
+func VersionedPut(tableName string, item map[string]types.AttributeValue, version int) (*types.Put, error) {
+	expr, err := expression.NewBuilder().WithCondition(VersionCondition(version)).Build()
+	if err != nil {
+		return nil, err
+	}
+	return &types.Put{
+		TableName:                 aws.String(tableName),
+		Item:                      item,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package history
+
+import (
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"time"
+)
+
+func historyEntityID(kind history.Kind, entityID string) string {
+	return "Entity#History#" + string(kind) + "#" + entityID
+}
+
+// Dto is one history entry. The entries of an entity share a partition and sort by
+// their ULID, so they read back in the order they were written.
+type Dto struct {
+	EntityId   string `dynamodbav:"EntityId"` // "Entity#History#<kind>#<entity id>"
+	Id         string `dynamodbav:"Id"`       // "<ulid>"
+	Kind       string `dynamodbav:"Kind"`
+	SubjectId  string `dynamodbav:"SubjectId"` // ID of the entity the entry belongs to
+	From       string `dynamodbav:"From"`
+	To         string `dynamodbav:"To"`
+	Actor      string `dynamodbav:"Actor"`
+	AccountId  string `dynamodbav:"AccountId,omitempty"`
+	Reason     string `dynamodbav:"Reason,omitempty"`
+	Source     string `dynamodbav:"Source"`
+	OccurredAt int64  `dynamodbav:"OccurredAt"` // Unix timestamp in milliseconds
+}
+
+func (d *Dto) ToDomain() history.Entry {
+	return history.Entry{
+		ID:         d.Id,
+		Kind:       history.Kind(d.Kind),
+		EntityID:   d.SubjectId,
+		From:       d.From,
+		To:         d.To,
+		Actor:      common.Actor(d.Actor),
+		AccountID:  d.AccountId,
+		Reason:     d.Reason,
+		Source:     history.Source(d.Source),
+		OccurredAt: time.UnixMilli(d.OccurredAt).UTC(),
+	}
+}
+
+func From(entry history.Entry) Dto {
+	return Dto{
+		EntityId:   historyEntityID(entry.Kind, entry.EntityID),
+		Id:         entry.ID,
+		Kind:       string(entry.Kind),
+		SubjectId:  entry.EntityID,
+		From:       entry.From,
+		To:         entry.To,
+		Actor:      string(entry.Actor),
+		AccountId:  entry.AccountID,
+		Reason:     entry.Reason,
+		Source:     string(entry.Source),
+		OccurredAt: entry.OccurredAt.UnixMilli(),
+	}
+}
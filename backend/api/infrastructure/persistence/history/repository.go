@@ -0,0 +1,132 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBClientInterface defines the interface for DynamoDB operations needed by the repository
+type DynamoDBClientInterface interface {
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+}
+
+// Writer is the part of the DynamoDB client PutWithHistory needs.
+type Writer interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+}
+
+type RepositoryAdapter struct {
+	dbClient  DynamoDBClientInterface
+	tableName string
+}
+
+func NewRepository(client *dynamodb.Client, tableName string) history.Repository {
+	return &RepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func NewRepositoryWithInterface(client DynamoDBClientInterface, tableName string) history.Repository {
+	return &RepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+// BuildPuts returns one transactional put per change of the entity, stamped with the
+// origin stored in ctx, so other repositories can write the history together with the
+// entity. It returns no puts when the entity has not changed status.
+func BuildPuts(ctx context.Context, tableName string, kind history.Kind, entityID string, changes []common.StatusChange) ([]types.TransactWriteItem, error) {
+	entries := history.NewEntries(history.OriginOf(ctx), kind, entityID, changes, time.Now())
+	items := make([]types.TransactWriteItem, 0, len(entries))
+	for _, entry := range entries {
+		av, err := attributevalue.MarshalMap(From(entry))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal history entry of %s: %w", entityID, err)
+		}
+		items = append(items, types.TransactWriteItem{
+			Put: &types.Put{TableName: aws.String(tableName), Item: av},
+		})
+	}
+	return items, nil
+}
+
+// BuildWriteRequests is BuildPuts for batch writes.
+func BuildWriteRequests(ctx context.Context, tableName string, kind history.Kind, entityID string, changes []common.StatusChange) ([]types.WriteRequest, error) {
+	puts, err := BuildPuts(ctx, tableName, kind, entityID, changes)
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]types.WriteRequest, 0, len(puts))
+	for _, put := range puts {
+		requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: put.Put.Item}})
+	}
+	return requests, nil
+}
+
+// PutWithHistory writes put together with the history items in one transaction, or as a
+// plain put when there is no history to write. Either way a failed condition of put is
+// reported by ddb.IsConditionFailed.
+func PutWithHistory(ctx context.Context, client Writer, put *types.Put, items []types.TransactWriteItem) error {
+	if len(items) == 0 {
+		_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName:                 put.TableName,
+			Item:                      put.Item,
+			ConditionExpression:       put.ConditionExpression,
+			ExpressionAttributeNames:  put.ExpressionAttributeNames,
+			ExpressionAttributeValues: put.ExpressionAttributeValues,
+		})
+		return err
+	}
+	_, err := client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append([]types.TransactWriteItem{{Put: put}}, items...),
+	})
+	return err
+}
+
+func (repo *RepositoryAdapter) Find(ctx context.Context, kind history.Kind, entityID string) ([]history.Entry, error) {
+	keyCond := expression.KeyEqual(expression.Key("EntityId"), expression.Value(historyEntityID(kind, entityID)))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]history.Entry, 0)
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for {
+		resp, err := repo.dbClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(repo.tableName),
+			KeyConditionExpression:    expr.KeyCondition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ExclusiveStartKey:         lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query history of %s: %w", entityID, err)
+		}
+
+		var dtos []Dto
+		if err = attributevalue.UnmarshalListOfMaps(resp.Items, &dtos); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal history of %s: %w", entityID, err)
+		}
+		for i := range dtos {
+			entries = append(entries, dtos[i].ToDomain())
+		}
+
+		if resp.LastEvaluatedKey == nil {
+			return entries, nil
+		}
+		lastEvaluatedKey = resp.LastEvaluatedKey
+	}
+}
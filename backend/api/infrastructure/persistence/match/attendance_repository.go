@@ -0,0 +1,97 @@
+package match
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/match"
+	ddb "sportlink/api/infrastructure/persistence/dynamodb"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type AttendanceRepositoryAdapter struct {
+	dbClient  DynamoDBClientInterface
+	tableName string
+}
+
+func NewAttendanceRepository(client *dynamodb.Client, tableName string) match.AttendanceRepository {
+	return &AttendanceRepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func NewAttendanceRepositoryWithInterface(client DynamoDBClientInterface, tableName string) match.AttendanceRepository {
+	return &AttendanceRepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+// Save only writes the confirmation when the participant has none yet, so the time of
+// the first tap is kept.
+func (repo *AttendanceRepositoryAdapter) Save(ctx context.Context, attendance match.Attendance) error {
+	av, err := attributevalue.MarshalMap(FromAttendance(attendance))
+	if err != nil {
+		return fmt.Errorf("failed to marshal attendance: %w", err)
+	}
+	expr, err := expression.NewBuilder().
+		WithCondition(expression.AttributeNotExists(expression.Name("Id"))).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(repo.tableName),
+		Item:                     av,
+		ConditionExpression:      expr.Condition(),
+		ExpressionAttributeNames: expr.Names(),
+	})
+	if err != nil && !ddb.IsConditionalCheckFailed(err) {
+		return fmt.Errorf("failed to save attendance of account %s to match %s: %w", attendance.AccountID, attendance.MatchID, err)
+	}
+	return nil
+}
+
+func (repo *AttendanceRepositoryAdapter) Find(ctx context.Context, matchID string) ([]match.Attendance, error) {
+	keyCond := expression.KeyEqual(expression.Key("EntityId"), expression.Value(attendanceEntityID(matchID)))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	attendances := make([]match.Attendance, 0)
+	var lastKey map[string]types.AttributeValue
+	for {
+		input := &dynamodb.QueryInput{
+			TableName:                 aws.String(repo.tableName),
+			KeyConditionExpression:    expr.KeyCondition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		}
+		if lastKey != nil {
+			input.ExclusiveStartKey = lastKey
+		}
+		resp, err := repo.dbClient.Query(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Items {
+			var dto AttendanceDto
+			if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal attendance: %w", err)
+			}
+			attendances = append(attendances, dto.ToDomain(matchID))
+		}
+		if resp.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = resp.LastEvaluatedKey
+	}
+	return attendances, nil
+}
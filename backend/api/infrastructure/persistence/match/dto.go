@@ -26,17 +26,38 @@ func matchAccountIDKey(matchID string) string {
 // MatchDto is the canonical record. It is the single source of truth for all
 // mutable match data (status, result, etc.). There is exactly one per match.
 type MatchDto struct {
-	EntityId        string   `dynamodbav:"EntityId"` // "Entity#Match"
-	Id              string   `dynamodbav:"Id"`       // "<ulid>"
-	MatchOfferID    string   `dynamodbav:"MatchOfferId,omitempty"`
-	Participants    []string `dynamodbav:"Participants"`
-	Sport           string   `dynamodbav:"Sport"`
-	Day             int64    `dynamodbav:"Day"` // Unix timestamp
-	Status          string   `dynamodbav:"Status"`
-	LocalScore      *int     `dynamodbav:"LocalScore"`
-	VisitorScore    *int     `dynamodbav:"VisitorScore"`
-	WinnerAccountId string   `dynamodbav:"WinnerAccountId"` // empty when not played or draw
-	CreatedAt       int64    `dynamodbav:"CreatedAt"`       // Unix timestamp
+	EntityId        string             `dynamodbav:"EntityId"` // "Entity#Match"
+	Id              string             `dynamodbav:"Id"`       // "<ulid>"
+	MatchOfferID    string             `dynamodbav:"MatchOfferId,omitempty"`
+	Participants    []string           `dynamodbav:"Participants"`
+	Sport           string             `dynamodbav:"Sport"`
+	Day             int64              `dynamodbav:"Day"` // Unix timestamp
+	Status          string             `dynamodbav:"Status"`
+	LocalScore      *int               `dynamodbav:"LocalScore"`
+	VisitorScore    *int               `dynamodbav:"VisitorScore"`
+	WinnerAccountId string             `dynamodbav:"WinnerAccountId"`        // empty when not played or draw
+	CreatedAt       int64              `dynamodbav:"CreatedAt"`              // Unix timestamp
+	PaymentTotal    int64              `dynamodbav:"PaymentTotal,omitempty"` // court cost in minor units; absent for free matches
+	PaymentCurrency string             `dynamodbav:"PaymentCurrency,omitempty"`
+	PaymentShares   []PaymentShareDto  `dynamodbav:"PaymentShares,omitempty"`
+	PaymentRefunds  []PaymentRefundDto `dynamodbav:"PaymentRefunds,omitempty"`
+	Version         int                `dynamodbav:"Version"` // Optimistic lock, bumped on every write
+}
+
+// PaymentShareDto is the part of the court cost one participant owes
+type PaymentShareDto struct {
+	AccountId string `dynamodbav:"AccountId"`
+	Amount    int64  `dynamodbav:"Amount"`
+	Status    string `dynamodbav:"Status"`
+	Reference string `dynamodbav:"Reference,omitempty"`
+	PaidAt    *int64 `dynamodbav:"PaidAt,omitempty"` // Unix timestamp
+}
+
+// PaymentRefundDto is a paid share owed back to a participant who left the match
+type PaymentRefundDto struct {
+	AccountId string `dynamodbav:"AccountId"`
+	Amount    int64  `dynamodbav:"Amount"`
+	Reference string `dynamodbav:"Reference,omitempty"`
 }
 
 func (d *MatchDto) ToDomain() match.Entity {
@@ -59,7 +80,39 @@ func (d *MatchDto) ToDomain() match.Entity {
 		Status:          status,
 		Result:          result,
 		WinnerAccountID: d.WinnerAccountId,
+		Payment:         d.payment(),
 		CreatedAt:       time.Unix(d.CreatedAt, 0).UTC(),
+		Version:         d.Version,
+	}
+}
+
+func (d *MatchDto) payment() *match.Payment {
+	if d.PaymentTotal == 0 {
+		return nil
+	}
+	shares := make([]match.Share, len(d.PaymentShares))
+	for i, s := range d.PaymentShares {
+		status, _ := match.ParseShareStatus(s.Status)
+		shares[i] = match.Share{
+			AccountID: s.AccountId,
+			Amount:    s.Amount,
+			Status:    status,
+			Reference: s.Reference,
+		}
+		if s.PaidAt != nil {
+			paidAt := time.Unix(*s.PaidAt, 0).UTC()
+			shares[i].PaidAt = &paidAt
+		}
+	}
+	var refunds []match.Refund
+	for _, r := range d.PaymentRefunds {
+		refunds = append(refunds, match.Refund{AccountID: r.AccountId, Amount: r.Amount, Reference: r.Reference})
+	}
+	return &match.Payment{
+		Total:    d.PaymentTotal,
+		Currency: d.PaymentCurrency,
+		Shares:   shares,
+		Refunds:  refunds,
 	}
 }
 
@@ -82,6 +135,32 @@ func fromEntity(entity match.Entity) (canonical MatchDto, pointers []MatchAccoun
 		Status:          entity.Status.String(),
 		WinnerAccountId: entity.WinnerAccountID,
 		CreatedAt:       entity.CreatedAt.Unix(),
+		Version:         entity.Version + 1,
+	}
+
+	if entity.Payment != nil {
+		canonical.PaymentTotal = entity.Payment.Total
+		canonical.PaymentCurrency = entity.Payment.Currency
+		for _, s := range entity.Payment.Shares {
+			share := PaymentShareDto{
+				AccountId: s.AccountID,
+				Amount:    s.Amount,
+				Status:    string(s.Status),
+				Reference: s.Reference,
+			}
+			if s.PaidAt != nil {
+				paidAt := s.PaidAt.Unix()
+				share.PaidAt = &paidAt
+			}
+			canonical.PaymentShares = append(canonical.PaymentShares, share)
+		}
+		for _, r := range entity.Payment.Refunds {
+			canonical.PaymentRefunds = append(canonical.PaymentRefunds, PaymentRefundDto{
+				AccountId: r.AccountID,
+				Amount:    r.Amount,
+				Reference: r.Reference,
+			})
+		}
 	}
 
 	if entity.Result != nil {
@@ -0,0 +1,81 @@
+package match
+
+import (
+	"fmt"
+	"sportlink/api/domain/match"
+	"time"
+)
+
+const reminderEntityID = "Entity#MatchReminder"
+
+// reminderIDKey sorts reminders by the time they are due. The timestamp is zero padded
+// so that the lexical order of the keys is the chronological one.
+func reminderIDKey(reminder match.Reminder) string {
+	return fmt.Sprintf("%s#%s#%d", dueKey(reminder.DueAt()), reminder.MatchID, int64(reminder.Offset/time.Minute))
+}
+
+func dueKey(t time.Time) string {
+	return fmt.Sprintf("%015d", t.UnixMilli())
+}
+
+func attendanceEntityID(matchID string) string {
+	return "Entity#MatchAttendance#" + matchID
+}
+
+// ReminderDto is a reminder waiting to be sent. All reminders share one partition and
+// sort by the time they are due, so the scheduler reads the due ones with a range query.
+type ReminderDto struct {
+	EntityId           string `dynamodbav:"EntityId"` // "Entity#MatchReminder"
+	Id                 string `dynamodbav:"Id"`       // "<dueAt millis>#<matchId>#<offset minutes>"
+	MatchId            string `dynamodbav:"MatchId"`
+	OrganiserAccountId string `dynamodbav:"OrganiserAccountId"`
+	StartTime          int64  `dynamodbav:"StartTime"`     // Unix timestamp in milliseconds
+	OffsetMinutes      int64  `dynamodbav:"OffsetMinutes"` // how long before StartTime it is sent
+	Final              bool   `dynamodbav:"Final"`
+	ClaimedUntil       int64  `dynamodbav:"ClaimedUntil,omitempty"` // Unix timestamp in milliseconds; absent until claimed
+}
+
+// AttendanceDto is a participant confirming they are still coming.
+type AttendanceDto struct {
+	EntityId    string `dynamodbav:"EntityId"`    // "Entity#MatchAttendance#<matchId>"
+	Id          string `dynamodbav:"Id"`          // "<accountId>"
+	ConfirmedAt int64  `dynamodbav:"ConfirmedAt"` // Unix timestamp
+}
+
+func (d *ReminderDto) ToDomain() match.Reminder {
+	return match.Reminder{
+		MatchID:            d.MatchId,
+		OrganiserAccountID: d.OrganiserAccountId,
+		StartTime:          time.UnixMilli(d.StartTime).UTC(),
+		Offset:             time.Duration(d.OffsetMinutes) * time.Minute,
+		Final:              d.Final,
+	}
+}
+
+func FromReminder(reminder match.Reminder) ReminderDto {
+	return ReminderDto{
+		EntityId:           reminderEntityID,
+		Id:                 reminderIDKey(reminder),
+		MatchId:            reminder.MatchID,
+		OrganiserAccountId: reminder.OrganiserAccountID,
+		StartTime:          reminder.StartTime.UnixMilli(),
+		OffsetMinutes:      int64(reminder.Offset / time.Minute),
+		Final:              reminder.Final,
+	}
+}
+
+func (d *AttendanceDto) ToDomain(matchID string) match.Attendance {
+	return match.Attendance{
+		MatchID:     matchID,
+		AccountID:   d.Id,
+		ConfirmedAt: time.Unix(d.ConfirmedAt, 0).UTC(),
+	}
+}
+
+func FromAttendance(attendance match.Attendance) AttendanceDto {
+	return AttendanceDto{
+		EntityId:    attendanceEntityID(attendance.MatchID),
+		Id:          attendance.AccountID,
+		ConfirmedAt: attendance.ConfirmedAt.Unix(),
+	}
+}
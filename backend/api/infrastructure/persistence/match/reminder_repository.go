@@ -0,0 +1,199 @@
+package match
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/outbox"
+	ddb "sportlink/api/infrastructure/persistence/dynamodb"
+	ioutbox "sportlink/api/infrastructure/persistence/outbox"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// batchWriteMaxItems is the most items DynamoDB accepts in a single BatchWriteItem call.
+const batchWriteMaxItems = 25
+
+type ReminderRepositoryAdapter struct {
+	dbClient  DynamoDBClientInterface
+	tableName string
+}
+
+func NewReminderRepository(client *dynamodb.Client, tableName string) match.ReminderRepository {
+	return &ReminderRepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func NewReminderRepositoryWithInterface(client DynamoDBClientInterface, tableName string) match.ReminderRepository {
+	return &ReminderRepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+// Schedule writes the reminders in batches. A reminder is keyed by its match, offset
+// and due time, so scheduling it again overwrites it with the same content and without
+// a lease.
+func (repo *ReminderRepositoryAdapter) Schedule(ctx context.Context, reminders []match.Reminder) error {
+	for start := 0; start < len(reminders); start += batchWriteMaxItems {
+		end := min(start+batchWriteMaxItems, len(reminders))
+		requests := make([]types.WriteRequest, 0, end-start)
+		for _, reminder := range reminders[start:end] {
+			av, err := attributevalue.MarshalMap(FromReminder(reminder))
+			if err != nil {
+				return fmt.Errorf("failed to marshal reminder of match %s: %w", reminder.MatchID, err)
+			}
+			requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: av}})
+		}
+
+		for len(requests) > 0 {
+			resp, err := repo.dbClient.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{repo.tableName: requests},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to schedule reminders: %w", err)
+			}
+			requests = resp.UnprocessedItems[repo.tableName]
+		}
+	}
+	return nil
+}
+
+// FindDue reads the reminders partition in key order up to the first reminder that is
+// not due yet, leaving out those leased past now. The limit applies before leased
+// reminders are left out, so a page may hold fewer reminders than are due.
+func (repo *ReminderRepositoryAdapter) FindDue(ctx context.Context, now time.Time, limit int) ([]match.Reminder, error) {
+	keyCond := expression.KeyAnd(
+		expression.KeyEqual(expression.Key("EntityId"), expression.Value(reminderEntityID)),
+		expression.KeyLessThan(expression.Key("Id"), expression.Value(dueKey(now.Add(time.Millisecond)))),
+	)
+	expr, err := expression.NewBuilder().
+		WithKeyCondition(keyCond).
+		WithFilter(leaseOver(now)).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := repo.dbClient.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(repo.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int32(int32(limit)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := make([]match.Reminder, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		var dto ReminderDto
+		if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal reminder: %w", err)
+		}
+		reminders = append(reminders, dto.ToDomain())
+	}
+	return reminders, nil
+}
+
+// Claim writes the lease on the condition that the reminder is still there and not
+// leased past now, so of several instances reading the same due reminder only one gets
+// to send it.
+func (repo *ReminderRepositoryAdapter) Claim(ctx context.Context, reminder match.Reminder, now, until time.Time) (bool, error) {
+	dto := FromReminder(reminder)
+	dto.ClaimedUntil = until.UnixMilli()
+	av, err := attributevalue.MarshalMap(dto)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal reminder of match %s: %w", reminder.MatchID, err)
+	}
+	expr, err := expression.NewBuilder().
+		WithCondition(expression.AttributeExists(expression.Name("Id")).And(leaseOver(now))).
+		Build()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(repo.tableName),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if ddb.IsConditionalCheckFailed(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim reminder of match %s: %w", reminder.MatchID, err)
+	}
+	return true, nil
+}
+
+// Delete takes the reminder out of the schedule on the condition that it is still leased
+// until leasedUntil. The outbox messages, if any, are written in the same transaction.
+func (repo *ReminderRepositoryAdapter) Delete(ctx context.Context, reminder match.Reminder, leasedUntil time.Time, messages []outbox.Message) error {
+	key, err := attributevalue.MarshalMap(map[string]string{
+		"EntityId": reminderEntityID,
+		"Id":       reminderIDKey(reminder),
+	})
+	if err != nil {
+		return err
+	}
+	expr, err := expression.NewBuilder().
+		WithCondition(expression.Equal(expression.Name("ClaimedUntil"), expression.Value(leasedUntil.UnixMilli()))).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	if len(messages) == 0 {
+		_, err = repo.dbClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName:                 aws.String(repo.tableName),
+			Key:                       key,
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		})
+	} else {
+		var messageItems []types.TransactWriteItem
+		messageItems, err = ioutbox.BuildPuts(repo.tableName, messages)
+		if err != nil {
+			return err
+		}
+		_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: append([]types.TransactWriteItem{{
+				Delete: &types.Delete{
+					TableName:                 aws.String(repo.tableName),
+					Key:                       key,
+					ConditionExpression:       expr.Condition(),
+					ExpressionAttributeNames:  expr.Names(),
+					ExpressionAttributeValues: expr.Values(),
+				},
+			}}, messageItems...),
+		})
+	}
+	if ddb.IsConditionFailed(err) {
+		return fmt.Errorf("reminder of match %s: %w", reminder.MatchID, match.ErrReminderLeaseLost)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete reminder of match %s: %w", reminder.MatchID, err)
+	}
+	return nil
+}
+
+// leaseOver holds for reminders nobody claimed or whose lease ended by now.
+func leaseOver(now time.Time) expression.ConditionBuilder {
+	return expression.Or(
+		expression.AttributeNotExists(expression.Name("ClaimedUntil")),
+		expression.LessThanEqual(expression.Name("ClaimedUntil"), expression.Value(now.UnixMilli())),
+	)
+}
@@ -3,7 +3,17 @@ package match
 import (
 	"context"
 	"fmt"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
 	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	ddb "sportlink/api/infrastructure/persistence/dynamodb"
+	ihistory "sportlink/api/infrastructure/persistence/history"
+	imatchoffer "sportlink/api/infrastructure/persistence/matchoffer"
+	imatchrequest "sportlink/api/infrastructure/persistence/matchrequest"
+	ioutbox "sportlink/api/infrastructure/persistence/outbox"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -14,6 +24,9 @@ import (
 )
 
 type DynamoDBClientInterface interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
 	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
 	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
 	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
@@ -40,17 +53,271 @@ func NewRepositoryWithInterface(client DynamoDBClientInterface, tableName string
 }
 
 // Save persists a match atomically:
-//   - one canonical record (source of truth for all mutable data)
+//   - one canonical record (source of truth for all mutable data), only overwritten at
+//     the version it was read; otherwise common.ErrVersionConflict
 //   - one immutable pointer record per participant (for efficient listing)
-func (repo *RepositoryAdapter) Save(ctx context.Context, entity match.Entity) error {
+//   - the outbox messages raised by the change
+//   - the history of its status changes
+func (repo *RepositoryAdapter) Save(ctx context.Context, entity match.Entity, messages []outbox.Message) error {
+	transactItems, err := repo.buildPutItems(entity)
+	if err != nil {
+		return err
+	}
+	if err = conditionOnVersion(transactItems[0].Put, entity.Version); err != nil {
+		return err
+	}
+	messageItems, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
+		return err
+	}
+	transactItems = append(transactItems, messageItems...)
+	historyItems, err := ihistory.BuildPuts(ctx, repo.tableName, history.KindMatch, entity.ID, entity.Changes)
+	if err != nil {
+		return err
+	}
+	transactItems = append(transactItems, historyItems...)
+
+	_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if ddb.IsTransactionConditionFailed(err) {
+		return fmt.Errorf("match %s: %w", entity.ID, common.ErrVersionConflict)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save match transaction: %w", err)
+	}
+
+	return nil
+}
+
+// maxTransactItems is the most items DynamoDB accepts in a single TransactWriteItems call.
+const maxTransactItems = 100
+
+// SaveConfirmation writes the match, the confirmed offer, the outbox messages and the
+// waitlisted requests in one transaction, each entity with its history:
+//   - the canonical match is only created when it does not exist yet, so a retried
+//     confirmation can never leave two matches for the same offer
+//   - the offer is only confirmed while it is still PENDING at the version it was read
+//   - each request is only overwritten at the version it was read
+//
+// Requests that do not fit in the transaction are written right after it, each still at
+// the version it was read. If that fails they stay PENDING on the confirmed offer and
+// match.ErrWaitlistIncomplete is returned so the caller can waitlist them again.
+func (repo *RepositoryAdapter) SaveConfirmation(
+	ctx context.Context,
+	entity match.Entity,
+	offer matchoffer.Entity,
+	waitlisted []matchrequest.Entity,
+	messages []outbox.Message,
+) error {
+	transactItems, err := repo.buildPutItems(entity)
+	if err != nil {
+		return err
+	}
+	if err = conditionOnNewMatch(transactItems[0].Put); err != nil {
+		return err
+	}
+
+	offerPut, err := repo.buildConfirmedOfferPut(offer)
+	if err != nil {
+		return err
+	}
+	transactItems = append(transactItems, types.TransactWriteItem{Put: offerPut})
+
+	messageItems, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
+		return err
+	}
+	transactItems = append(transactItems, messageItems...)
+
+	offerHistory, err := ihistory.BuildPuts(ctx, repo.tableName, history.KindMatchOffer, offer.ID, offer.Changes)
+	if err != nil {
+		return err
+	}
+	transactItems = append(transactItems, offerHistory...)
+
+	requestGroups, err := repo.buildRequestPuts(ctx, waitlisted)
+	if err != nil {
+		return err
+	}
+	// a request and its history always go in the same transaction
+	fit := 0
+	for fit < len(requestGroups) && len(transactItems)+len(requestGroups[fit]) <= maxTransactItems {
+		transactItems = append(transactItems, requestGroups[fit]...)
+		fit++
+	}
+
+	_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if ddb.IsTransactionConditionFailed(err) {
+		return fmt.Errorf("confirmation of match offer %s: %w", offer.ID, common.ErrVersionConflict)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save match confirmation transaction: %w", err)
+	}
+
+	for rest := requestGroups[fit:]; len(rest) > 0; {
+		batch := make([]types.TransactWriteItem, 0, maxTransactItems)
+		for len(rest) > 0 && len(batch)+len(rest[0]) <= maxTransactItems {
+			batch = append(batch, rest[0]...)
+			rest = rest[1:]
+		}
+		if _, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: batch,
+		}); err != nil {
+			return fmt.Errorf("failed to waitlist remaining requests of match offer %s: %w: %w", offer.ID, match.ErrWaitlistIncomplete, err)
+		}
+	}
+
+	return nil
+}
+
+// conditionOnNewMatch makes the put of the canonical record fail when the match exists.
+func conditionOnNewMatch(put *types.Put) error {
+	expr, err := expression.NewBuilder().
+		WithCondition(expression.AttributeNotExists(expression.Name("Id"))).
+		Build()
+	if err != nil {
+		return err
+	}
+	put.ConditionExpression = expr.Condition()
+	put.ExpressionAttributeNames = expr.Names()
+	return nil
+}
+
+// conditionOnVersion makes the put of the canonical record fail unless the match is still
+// at the version it was read. Matches read without a version must still lack one.
+func conditionOnVersion(put *types.Put, version int) error {
+	expr, err := expression.NewBuilder().WithCondition(ddb.VersionCondition(version)).Build()
+	if err != nil {
+		return err
+	}
+	put.ConditionExpression = expr.Condition()
+	put.ExpressionAttributeNames = expr.Names()
+	put.ExpressionAttributeValues = expr.Values()
+	return nil
+}
+
+// buildConfirmedOfferPut returns the put of the confirmed offer, conditioned on the offer
+// still being PENDING at the version it was read. Offers read without a version must still
+// lack one, so two confirmations of a legacy offer cannot both win.
+func (repo *RepositoryAdapter) buildConfirmedOfferPut(offer matchoffer.Entity) (*types.Put, error) {
+	dto, err := imatchoffer.From(offer)
+	if err != nil {
+		return nil, err
+	}
+	av, err := attributevalue.MarshalMap(dto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal confirmed match offer: %w", err)
+	}
+
+	cond := expression.And(
+		expression.Name("Status").Equal(expression.Value(matchoffer.StatusPending.String())),
+		ddb.VersionCondition(offer.Version),
+	)
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Put{
+		TableName:                 aws.String(repo.tableName),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}, nil
+}
+
+// buildRequestPuts returns, for each request, its versioned put followed by the puts of
+// its history.
+func (repo *RepositoryAdapter) buildRequestPuts(ctx context.Context, requests []matchrequest.Entity) ([][]types.TransactWriteItem, error) {
+	groups := make([][]types.TransactWriteItem, 0, len(requests))
+	for _, r := range requests {
+		av, err := attributevalue.MarshalMap(imatchrequest.From(r))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal match request %s: %w", r.ID, err)
+		}
+		put, err := ddb.VersionedPut(repo.tableName, av, r.Version)
+		if err != nil {
+			return nil, err
+		}
+		historyItems, err := ihistory.BuildPuts(ctx, repo.tableName, history.KindMatchRequest, r.ID, r.Changes)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, append([]types.TransactWriteItem{{Put: put}}, historyItems...))
+	}
+	return groups, nil
+}
+
+// RemoveParticipant rewrites the canonical record and the participant pointers, deletes
+// the pointer of removedAccountID and writes the requests with their history and the
+// outbox messages, all in the same transaction.
+// The canonical record and each request are only overwritten at the version they were read.
+func (repo *RepositoryAdapter) RemoveParticipant(
+	ctx context.Context,
+	entity match.Entity,
+	removedAccountID string,
+	requests []matchrequest.Entity,
+	messages []outbox.Message,
+) error {
+	transactItems, err := repo.buildPutItems(entity)
+	if err != nil {
+		return err
+	}
+	if err = conditionOnVersion(transactItems[0].Put, entity.Version); err != nil {
+		return err
+	}
+
+	key, err := attributevalue.MarshalMap(map[string]string{
+		"EntityId": matchAccountEntityID(removedAccountID),
+		"Id":       matchAccountIDKey(entity.ID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal removed match account pointer key: %w", err)
+	}
+	transactItems = append(transactItems, types.TransactWriteItem{
+		Delete: &types.Delete{TableName: aws.String(repo.tableName), Key: key},
+	})
+
+	requestGroups, err := repo.buildRequestPuts(ctx, requests)
+	if err != nil {
+		return err
+	}
+	for _, group := range requestGroups {
+		transactItems = append(transactItems, group...)
+	}
+	messageItems, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
+		return err
+	}
+	transactItems = append(transactItems, messageItems...)
+
+	_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if ddb.IsTransactionConditionFailed(err) {
+		return fmt.Errorf("match %s: %w", entity.ID, common.ErrVersionConflict)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove match participant transaction: %w", err)
+	}
+
+	return nil
+}
+
+// buildPutItems returns the puts for the canonical record and one pointer per participant.
+func (repo *RepositoryAdapter) buildPutItems(entity match.Entity) ([]types.TransactWriteItem, error) {
 	canonical, pointers := fromEntity(entity)
 
 	canonicalAV, err := attributevalue.MarshalMap(canonical)
 	if err != nil {
-		return fmt.Errorf("failed to marshal canonical match: %w", err)
+		return nil, fmt.Errorf("failed to marshal canonical match: %w", err)
 	}
 
-	transactItems := make([]types.TransactWriteItem, 0, 1+len(pointers))
+	transactItems := make([]types.TransactWriteItem, 0, 2+len(pointers))
 	transactItems = append(transactItems, types.TransactWriteItem{
 		Put: &types.Put{TableName: aws.String(repo.tableName), Item: canonicalAV},
 	})
@@ -58,21 +325,14 @@ func (repo *RepositoryAdapter) Save(ctx context.Context, entity match.Entity) er
 	for i, ptr := range pointers {
 		av, err := attributevalue.MarshalMap(ptr)
 		if err != nil {
-			return fmt.Errorf("failed to marshal match account pointer %d: %w", i, err)
+			return nil, fmt.Errorf("failed to marshal match account pointer %d: %w", i, err)
 		}
 		transactItems = append(transactItems, types.TransactWriteItem{
 			Put: &types.Put{TableName: aws.String(repo.tableName), Item: av},
 		})
 	}
 
-	_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
-		TransactItems: transactItems,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to save match transaction: %w", err)
-	}
-
-	return nil
+	return transactItems, nil
 }
 
 // Find lists all matches for a given account by:
@@ -7,28 +7,46 @@ import (
 )
 
 type Dto struct {
-	EntityId       string   `dynamodbav:"EntityId"`                 // "Entity#MatchOffer"
-	Id             string   `dynamodbav:"Id"`                       // Generated UUID
-	TeamName       string   `dynamodbav:"TeamName"`                 // Team name
-	Sport          string   `dynamodbav:"Sport"`                    // Sport type
-	Day            int64    `dynamodbav:"Day"`                      // Unix timestamp of the day
-	StartTime      int64    `dynamodbav:"StartTime"`                // Unix timestamp of start time
-	EndTime        int64    `dynamodbav:"EndTime"`                  // Unix timestamp of end time
-	Country        string   `dynamodbav:"Country"`                  // Location country
-	Province       string   `dynamodbav:"Province"`                 // Location province
-	Locality       string   `dynamodbav:"Locality"`                 // Location locality
-	GeohashPrefix  *string  `dynamodbav:"GeohashPrefix,omitempty"`  // Geohash prefix (precision 3) for GSI — absent when no coords
-	Latitude       *float64 `dynamodbav:"Latitude,omitempty"`       // GPS latitude — absent when no coords
-	Longitude      *float64 `dynamodbav:"Longitude,omitempty"`      // GPS longitude — absent when no coords
-	RangeType      string   `dynamodbav:"RangeType"`                // Category range type
-	Categories     []int    `dynamodbav:"Categories"`               // Specific categories
-	MinLevel       int      `dynamodbav:"MinLevel"`                 // Minimum category level
-	MaxLevel       int      `dynamodbav:"MaxLevel"`                 // Maximum category level
-	Status         string   `dynamodbav:"Status"`                   // Offer status
-	CreatedAt      int64    `dynamodbav:"CreatedAt"`                // Unix timestamp of creation
-	ExpiresAt      int64    `dynamodbav:"ExpiresAt"`                // TTL for DynamoDB (Unix timestamp)
-	OwnerAccountId string   `dynamodbav:"OwnerAccountId,omitempty"` // Account ID of the owner
-	Capacity       int      `dynamodbav:"Capacity"`                 // 0 = no auto-confirm; >0 = total spots
+	EntityId            string            `dynamodbav:"EntityId"`                      // "Entity#MatchOffer"
+	Id                  string            `dynamodbav:"Id"`                            // Generated UUID
+	TeamId              string            `dynamodbav:"TeamId,omitempty"`              // ULID of the team; absent when the offer names none
+	TeamName            string            `dynamodbav:"TeamName"`                      // Team name when the offer was published
+	Sport               string            `dynamodbav:"Sport"`                         // Sport type
+	Day                 int64             `dynamodbav:"Day"`                           // Unix timestamp of the day
+	StartTime           int64             `dynamodbav:"StartTime"`                     // Unix timestamp of start time
+	EndTime             int64             `dynamodbav:"EndTime"`                       // Unix timestamp of end time
+	Country             string            `dynamodbav:"Country"`                       // Location country
+	Province            string            `dynamodbav:"Province"`                      // Location province
+	Locality            string            `dynamodbav:"Locality"`                      // Location locality
+	GeohashPrefix       *string           `dynamodbav:"GeohashPrefix,omitempty"`       // Geohash prefix (precision 3) for GSI — absent when no coords
+	Latitude            *float64          `dynamodbav:"Latitude,omitempty"`            // GPS latitude — absent when no coords
+	Longitude           *float64          `dynamodbav:"Longitude,omitempty"`           // GPS longitude — absent when no coords
+	RangeType           string            `dynamodbav:"RangeType"`                     // Category range type
+	Categories          []int             `dynamodbav:"Categories"`                    // Specific categories
+	MinLevel            int               `dynamodbav:"MinLevel"`                      // Minimum category level
+	MaxLevel            int               `dynamodbav:"MaxLevel"`                      // Maximum category level
+	Status              string            `dynamodbav:"Status"`                        // Offer status
+	CreatedAt           int64             `dynamodbav:"CreatedAt"`                     // Unix timestamp of creation
+	OwnerAccountId      string            `dynamodbav:"OwnerAccountId,omitempty"`      // Account ID of the owner
+	Capacity            int               `dynamodbav:"Capacity"`                      // 0 = no auto-confirm; >0 = total spots
+	Committed           int               `dynamodbav:"PlayersCommitted"`              // Players already in the owner's group
+	SpotsNeeded         int               `dynamodbav:"SpotsNeeded"`                   // Players still missing; 0 = Capacity applies
+	PositionNeeds       []PositionNeedDto `dynamodbav:"PositionNeeds,omitempty"`       // Optional breakdown of SpotsNeeded
+	Visibility          string            `dynamodbav:"Visibility,omitempty"`          // PUBLIC, TEAM, INVITE or LINK; absent means PUBLIC
+	InvitedAccountIds   []string          `dynamodbav:"InvitedAccountIds,omitempty"`   // Accounts that can always see the offer
+	ShareLinkVersion    int               `dynamodbav:"ShareLinkVersion,omitempty"`    // Share links signed for older versions are revoked
+	CostAmount          int64             `dynamodbav:"CostAmount,omitempty"`          // Court cost in minor units; absent when free
+	CostCurrency        string            `dynamodbav:"CostCurrency,omitempty"`        // ISO 4217 code of CostAmount
+	AcceptedCount       int               `dynamodbav:"AcceptedCount"`                 // Accepted requests, condition-checked on accept
+	AcceptedByPosition  map[string]int    `dynamodbav:"AcceptedByPosition,omitempty"`  // AcceptedCount per position
+	OpenSpots           *int              `dynamodbav:"OpenSpots,omitempty"`           // Spots still free, searched on; absent when there is no limit
+	OpenSpotsByPosition map[string]int    `dynamodbav:"OpenSpotsByPosition,omitempty"` // OpenSpots per needed position
+	Version             int               `dynamodbav:"Version"`                       // Optimistic lock, bumped on every write
+}
+
+type PositionNeedDto struct {
+	Position string `dynamodbav:"Position"`
+	Spots    int    `dynamodbav:"Spots"`
 }
 
 func (d *Dto) ToDomain() matchoffer.Entity {
@@ -66,9 +84,11 @@ func (d *Dto) ToDomain() matchoffer.Entity {
 	}
 
 	status, _ := matchoffer.ParseStatus(d.Status)
+	visibility, _ := matchoffer.ParseVisibility(d.Visibility)
 
 	return matchoffer.Entity{
 		ID:                 d.Id,
+		TeamID:             d.TeamId,
 		TeamName:           d.TeamName,
 		Sport:              common.Sport(d.Sport),
 		Day:                day,
@@ -79,5 +99,25 @@ func (d *Dto) ToDomain() matchoffer.Entity {
 		CreatedAt:          createdAt,
 		OwnerAccountID:     d.OwnerAccountId,
 		Capacity:           d.Capacity,
+		PlayerNeeds:        d.playerNeeds(),
+		Visibility:         visibility,
+		InvitedAccountIDs:  d.InvitedAccountIds,
+		ShareLinkVersion:   d.ShareLinkVersion,
+		Cost:               matchoffer.Cost{Amount: d.CostAmount, Currency: d.CostCurrency},
+		AcceptedCount:      d.AcceptedCount,
+		AcceptedByPosition: d.AcceptedByPosition,
+		Version:            d.Version,
+	}
+}
+
+func (d *Dto) playerNeeds() matchoffer.PlayerNeeds {
+	var positions []matchoffer.PositionNeed
+	for _, p := range d.PositionNeeds {
+		positions = append(positions, matchoffer.PositionNeed{Position: p.Position, Spots: p.Spots})
+	}
+	return matchoffer.PlayerNeeds{
+		Committed:   d.Committed,
+		SpotsNeeded: d.SpotsNeeded,
+		Positions:   positions,
 	}
 }
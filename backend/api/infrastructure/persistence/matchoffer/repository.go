@@ -2,9 +2,17 @@ package matchoffer
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math"
+	"slices"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
 	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	ddb "sportlink/api/infrastructure/persistence/dynamodb"
+	ihistory "sportlink/api/infrastructure/persistence/history"
+	ioutbox "sportlink/api/infrastructure/persistence/outbox"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,6 +30,8 @@ type DynamoDBClientInterface interface {
 	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
 	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
 	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
 }
 
 type RepositoryAdapter struct {
@@ -44,82 +54,59 @@ func NewRepositoryWithInterface(client DynamoDBClientInterface, tableName string
 	}
 }
 
-func (repo *RepositoryAdapter) Delete(ctx context.Context, offerID string) error {
-	key, err := attributevalue.MarshalMap(map[string]string{
-		"EntityId": "Entity#MatchOffer",
-		"Id":       offerID,
-	})
+// Save writes the offer with its history and the outbox messages, and bumps its version.
+// Offers read at a version only overwrite the stored item when nobody else wrote it since;
+// otherwise common.ErrVersionConflict.
+func (repo *RepositoryAdapter) Save(ctx context.Context, entity matchoffer.Entity, messages []outbox.Message) error {
+	dto, err := From(entity)
 	if err != nil {
 		return err
 	}
 
-	_, err = repo.dbClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
-		TableName: aws.String(repo.tableName),
-		Key:       key,
-	})
-	return err
-}
-
-func (repo *RepositoryAdapter) Save(ctx context.Context, entity matchoffer.Entity) error {
-	dto, err := From(entity)
+	av, err := attributevalue.MarshalMap(dto)
 	if err != nil {
 		return err
 	}
 
-	av, err := attributevalue.MarshalMap(dto)
+	put, err := ddb.VersionedPut(repo.tableName, av, entity.Version)
+	if err != nil {
+		return err
+	}
+	items, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
+		return err
+	}
+	historyItems, err := ihistory.BuildPuts(ctx, repo.tableName, history.KindMatchOffer, entity.ID, entity.Changes)
 	if err != nil {
 		return err
 	}
 
-	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: &repo.tableName,
-		Item:      av,
-	})
+	err = ihistory.PutWithHistory(ctx, repo.dbClient, put, append(items, historyItems...))
+	if ddb.IsConditionFailed(err) {
+		return fmt.Errorf("match offer %s: %w", entity.ID, common.ErrVersionConflict)
+	}
 	return err
 }
 
-const batchWriteSize = 25
-
+// SaveAll writes each offer like Save, so an offer read at a version only overwrites the
+// stored item while nobody else wrote it since. Offers that changed are skipped and
+// reported together as common.ErrVersionConflict once the others are written. It returns
+// how many offers were written.
 func (repo *RepositoryAdapter) SaveAll(ctx context.Context, entities []matchoffer.Entity) (int, error) {
 	saved := 0
-	for i := 0; i < len(entities); i += batchWriteSize {
-		end := i + batchWriteSize
-		if end > len(entities) {
-			end = len(entities)
-		}
-		batch := entities[i:end]
-
-		requests := make([]types.WriteRequest, 0, len(batch))
-		for _, entity := range batch {
-			dto, err := From(entity)
-			if err != nil {
-				return saved, err
-			}
-			av, err := attributevalue.MarshalMap(dto)
-			if err != nil {
-				return saved, err
-			}
-			requests = append(requests, types.WriteRequest{
-				PutRequest: &types.PutRequest{Item: av},
-			})
+	var conflicts []error
+	for _, entity := range entities {
+		err := repo.Save(ctx, entity, nil)
+		if errors.Is(err, common.ErrVersionConflict) {
+			conflicts = append(conflicts, err)
+			continue
 		}
-
-		out, err := repo.dbClient.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
-			RequestItems: map[string][]types.WriteRequest{
-				repo.tableName: requests,
-			},
-		})
 		if err != nil {
 			return saved, err
 		}
-
-		unprocessed := 0
-		if out != nil {
-			unprocessed = len(out.UnprocessedItems[repo.tableName])
-		}
-		saved += len(requests) - unprocessed
+		saved++
 	}
-	return saved, nil
+	return saved, errors.Join(conflicts...)
 }
 
 const (
@@ -130,6 +117,10 @@ const (
 )
 
 func (repo *RepositoryAdapter) Find(ctx context.Context, query matchoffer.DomainQuery) (matchoffer.Page, error) {
+	if len(query.Categories) > 0 || len(query.ExcludedIDs) > 0 {
+		return repo.findMatching(ctx, query)
+	}
+
 	if query.GeoFilter != nil {
 		return repo.findByGeoFilter(ctx, query)
 	}
@@ -181,29 +172,61 @@ func (repo *RepositoryAdapter) Find(ctx context.Context, query matchoffer.Domain
 	}, nil
 }
 
+// findMatching reads every offer matching the criteria DynamoDB can evaluate and checks the
+// rest in memory: admitted categories cannot be expressed as a filter, and filters cannot
+// reference the Id sort key that excluded offers are told apart by. Pagination and the
+// total follow the in-memory check.
+func (repo *RepositoryAdapter) findMatching(ctx context.Context, query matchoffer.DomainQuery) (matchoffer.Page, error) {
+	broad := query
+	broad.Categories = nil
+	broad.ExcludedIDs = nil
+	broad.Limit = 0
+	broad.Offset = 0
+	page, err := repo.Find(ctx, broad)
+	if err != nil {
+		return matchoffer.Page{}, err
+	}
+
+	matching := slices.DeleteFunc(page.Entities, func(offer matchoffer.Entity) bool {
+		return !query.Matches(offer)
+	})
+	sortByID(matching)
+	return matchoffer.Page{
+		Entities: applyPagination(matching, query.Limit, query.Offset),
+		Total:    len(matching),
+	}, nil
+}
+
+// findByMultipleIDs reads the offers in batches and checks the other criteria in memory.
 func (repo *RepositoryAdapter) findByMultipleIDs(ctx context.Context, query matchoffer.DomainQuery) (matchoffer.Page, error) {
-	seen := make(map[string]bool)
-	var all []matchoffer.Entity
+	offers, err := repo.FindByIDs(ctx, query.IDs)
+	if err != nil {
+		return matchoffer.Page{}, err
+	}
 
-	for _, id := range query.IDs {
-		singleQuery := query
-		singleQuery.IDs = []string{id}
-		page, err := repo.Find(ctx, singleQuery)
-		if err != nil {
-			return matchoffer.Page{}, err
-		}
-		for _, e := range page.Entities {
-			if !seen[e.ID] {
-				seen[e.ID] = true
-				all = append(all, e)
-			}
-		}
+	matching := slices.DeleteFunc(offers, func(offer matchoffer.Entity) bool {
+		return !query.Matches(offer)
+	})
+	sortByID(matching)
+	return matchoffer.Page{Entities: applyPagination(matching, query.Limit, query.Offset), Total: len(matching)}, nil
+}
+
+// FindByIDs reads the offers with BatchGetItem, ddb.BatchGetMaxKeys keys per call.
+func (repo *RepositoryAdapter) FindByIDs(ctx context.Context, ids []string) ([]matchoffer.Entity, error) {
+	items, err := ddb.BatchGet(ctx, repo.dbClient, repo.tableName, "Entity#MatchOffer", ids)
+	if err != nil {
+		return nil, err
 	}
 
-	if all == nil {
-		all = []matchoffer.Entity{}
+	offers := make([]matchoffer.Entity, 0, len(items))
+	for _, item := range items {
+		dto, err := repo.unmarshalItem(item)
+		if err != nil {
+			return nil, err
+		}
+		offers = append(offers, dto.ToDomain())
 	}
-	return matchoffer.Page{Entities: all, Total: len(all)}, nil
+	return offers, nil
 }
 
 func (repo *RepositoryAdapter) buildQueryInput(expr expression.Expression) *dynamodb.QueryInput {
@@ -336,9 +359,6 @@ func From(entity matchoffer.Entity) (Dto, error) {
 	endTime := entity.TimeSlot.EndTime.In(tz).Unix()
 	createdAt := entity.CreatedAt.In(tz).Unix()
 
-	// Calculate ExpiresAt: 30 days from creation date
-	expiresAt := entity.CreatedAt.In(tz).AddDate(0, 0, 30).Unix()
-
 	// Extract category range data
 	var categories []int
 	var minLevel, maxLevel int
@@ -359,29 +379,44 @@ func From(entity matchoffer.Entity) (Dto, error) {
 	}
 
 	dto := Dto{
-		EntityId:       "Entity#MatchOffer",
-		Id:             entity.ID,
-		TeamName:       entity.TeamName,
-		Sport:          string(entity.Sport),
-		Day:            day,
-		StartTime:      startTime,
-		EndTime:        endTime,
-		Country:        entity.Location.Country,
-		Province:       entity.Location.Province,
-		Locality:       entity.Location.Locality,
-		RangeType:      string(entity.AdmittedCategories.Type),
-		Categories:     categories,
-		MinLevel:       minLevel,
-		MaxLevel:       maxLevel,
-		Status:         entity.Status.String(),
-		CreatedAt:      createdAt,
-		ExpiresAt:      expiresAt,
-		OwnerAccountId: entity.OwnerAccountID,
-		Capacity:       entity.Capacity,
+		EntityId:           "Entity#MatchOffer",
+		Id:                 entity.ID,
+		TeamId:             entity.TeamID,
+		TeamName:           entity.TeamName,
+		Sport:              string(entity.Sport),
+		Day:                day,
+		StartTime:          startTime,
+		EndTime:            endTime,
+		Country:            entity.Location.Country,
+		Province:           entity.Location.Province,
+		Locality:           entity.Location.Locality,
+		RangeType:          string(entity.AdmittedCategories.Type),
+		Categories:         categories,
+		MinLevel:           minLevel,
+		MaxLevel:           maxLevel,
+		Status:             entity.Status.String(),
+		CreatedAt:          createdAt,
+		OwnerAccountId:     entity.OwnerAccountID,
+		Capacity:           entity.Capacity,
+		Committed:          entity.PlayerNeeds.Committed,
+		SpotsNeeded:        entity.PlayerNeeds.SpotsNeeded,
+		Visibility:         entity.Visibility.String(),
+		InvitedAccountIds:  entity.InvitedAccountIDs,
+		ShareLinkVersion:   entity.ShareLinkVersion,
+		CostAmount:         entity.Cost.Amount,
+		CostCurrency:       entity.Cost.Currency,
+		AcceptedCount:      entity.AcceptedCount,
+		AcceptedByPosition: entity.AcceptedByPosition,
+		Version:            entity.Version + 1,
+	}
+	dto.OpenSpots, dto.OpenSpotsByPosition = OpenSpots(entity)
+
+	for _, p := range entity.PlayerNeeds.Positions {
+		dto.PositionNeeds = append(dto.PositionNeeds, PositionNeedDto{Position: p.Position, Spots: p.Spots})
 	}
 
 	if entity.Location.HasCoords() {
-		prefix := geohash.EncodeWithPrecision(entity.Location.Latitude, entity.Location.Longitude, 3)
+		prefix := geohash.EncodeWithPrecision(entity.Location.Latitude, entity.Location.Longitude, GeohashPrecision)
 		lat := entity.Location.Latitude
 		lng := entity.Location.Longitude
 		dto.GeohashPrefix = &prefix
@@ -392,15 +427,28 @@ func From(entity matchoffer.Entity) (Dto, error) {
 	return dto, nil
 }
 
+// OpenSpots returns the spots the offer has free in total, nil when it has no limit, and
+// per needed position, as stored for searches to filter on.
+func OpenSpots(entity matchoffer.Entity) (*int, map[string]int) {
+	byPosition := entity.OpenSpotsByPosition()
+	if open := entity.OpenSpots(entity.AcceptedCount); open >= 0 {
+		return &open, byPosition
+	}
+	return nil, byPosition
+}
+
 const geoIndexName = "GeohashPrefix-Day-index"
 
+// GeohashPrecision is the precision of the GeohashPrefix the geo index is keyed by.
+const GeohashPrecision = 3
+
 var noTime = time.Time{}
 
 // findByGeoFilter queries the GSI for each of the 9 geohash cells covering the search radius,
 // merges results, then filters in-memory by exact Haversine distance.
 func (repo *RepositoryAdapter) findByGeoFilter(ctx context.Context, query matchoffer.DomainQuery) (matchoffer.Page, error) {
 	gf := query.GeoFilter
-	targetHash := geohash.EncodeWithPrecision(gf.Latitude, gf.Longitude, 3)
+	targetHash := geohash.EncodeWithPrecision(gf.Latitude, gf.Longitude, GeohashPrecision)
 	cells := append(geohash.Neighbors(targetHash), targetHash)
 
 	type cellResult struct {
@@ -438,14 +486,12 @@ func (repo *RepositoryAdapter) findByGeoFilter(ctx context.Context, query matcho
 	// Filter by exact Haversine distance
 	var filtered []matchoffer.Entity
 	for _, e := range merged {
-		if e.Location.HasCoords() {
-			dist := haversineKm(gf.Latitude, gf.Longitude, e.Location.Latitude, e.Location.Longitude)
-			if dist <= gf.RadiusKm {
-				filtered = append(filtered, e)
-			}
+		if gf.Covers(e.Location) {
+			filtered = append(filtered, e)
 		}
 	}
 
+	sortByID(filtered)
 	total := len(filtered)
 	filtered = applyPagination(filtered, query.Limit, query.Offset)
 	if filtered == nil {
@@ -510,17 +556,6 @@ func (repo *RepositoryAdapter) queryGSICell(ctx context.Context, hashCell string
 	return entities, nil
 }
 
-// haversineKm returns the great-circle distance in km between two GPS coordinates.
-func haversineKm(lat1, lng1, lat2, lng2 float64) float64 {
-	const R = 6371.0
-	dLat := (lat2 - lat1) * math.Pi / 180
-	dLng := (lng2 - lng1) * math.Pi / 180
-	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
-		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*
-			math.Sin(dLng/2)*math.Sin(dLng/2)
-	return R * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-}
-
 // applyDynamoDBLimit sets the DynamoDB query limit, accounting for offset and filters
 // Note: DynamoDB limit applies before FilterExpression, so we need to fetch more items
 // to ensure we have enough results after filtering. We use a multiplier to account for
@@ -552,9 +587,9 @@ func applyDynamoDBLimit(limit, offset int, queryInput *dynamodb.QueryInput) {
 // applyPagination applies limit and offset to a slice of results
 // Returns the paginated slice
 func applyPagination(results []matchoffer.Entity, limit, offset int) []matchoffer.Entity {
-	// Apply offset in memory
-	if offset > 0 && len(results) > offset {
-		results = results[offset:]
+	// Apply offset in memory; an offset past the last result leaves an empty page
+	if offset > 0 {
+		results = results[min(offset, len(results)):]
 	}
 
 	// Apply limit in memory (in case we fetched more than needed)
@@ -565,6 +600,14 @@ func applyPagination(results []matchoffer.Entity, limit, offset int) []matchoffe
 	return results
 }
 
+// sortByID orders offers the way the base table returns them, so every query path pages
+// through its results the same way.
+func sortByID(offers []matchoffer.Entity) {
+	slices.SortFunc(offers, func(a, b matchoffer.Entity) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
 func includeFilters(query matchoffer.DomainQuery, builder *expression.Builder) {
 	var filters []expression.ConditionBuilder
 
@@ -616,6 +659,23 @@ func includeFilters(query matchoffer.DomainQuery, builder *expression.Builder) {
 		}
 	}
 
+	if query.VisibleTo != nil {
+		filters = append(filters, visibleToFilter(*query.VisibleTo))
+	}
+	if query.ExcludedOwner != "" {
+		filters = append(filters, expression.Name("OwnerAccountId").NotEqual(expression.Value(query.ExcludedOwner)))
+	}
+	if query.MinOpenSpots > 0 {
+		filters = append(filters, expression.Or(
+			expression.AttributeNotExists(expression.Name("OpenSpots")),
+			expression.Name("OpenSpots").GreaterThanEqual(expression.Value(query.MinOpenSpots)),
+		))
+	}
+	if query.OpenPosition != "" {
+		filters = append(filters, expression.Name("OpenSpotsByPosition."+query.OpenPosition).
+			GreaterThanEqual(expression.Value(max(query.MinOpenSpots, 1))))
+	}
+
 	// Combine all filters with AND
 	if len(filters) > 0 {
 		combinedFilter := filters[0]
@@ -625,3 +685,26 @@ func includeFilters(query matchoffer.DomainQuery, builder *expression.Builder) {
 		*builder = builder.WithFilter(combinedFilter)
 	}
 }
+
+// visibleToFilter mirrors matchoffer.Audience.CanView: public offers (also those saved
+// before visibility existed), offers owned by or inviting the account, and TEAM offers of
+// the audience's teams.
+func visibleToFilter(audience matchoffer.Audience) expression.ConditionBuilder {
+	visible := expression.Or(
+		expression.AttributeNotExists(expression.Name("Visibility")),
+		expression.Name("Visibility").Equal(expression.Value(matchoffer.VisibilityPublic.String())),
+	)
+	if audience.AccountID != "" {
+		visible = visible.Or(
+			expression.Name("OwnerAccountId").Equal(expression.Value(audience.AccountID)),
+			expression.Contains(expression.Name("InvitedAccountIds"), audience.AccountID),
+		)
+	}
+	for _, t := range audience.Teams {
+		visible = visible.Or(expression.And(
+			expression.Name("Visibility").Equal(expression.Value(matchoffer.VisibilityTeam.String())),
+			expression.Name("TeamId").Equal(expression.Value(t.ID)),
+		))
+	}
+	return visible
+}
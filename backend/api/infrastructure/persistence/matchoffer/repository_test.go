@@ -3,6 +3,7 @@ package matchoffer_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	ddomain "sportlink/api/domain/matchoffer"
 	"sportlink/api/infrastructure/persistence/matchoffer"
 	amocks "sportlink/mocks/api/infrastructure/persistence/matchoffer"
@@ -101,6 +102,33 @@ func TestRepository_Save(t *testing.T) {
 				assert.Contains(t, err.Error(), "database error")
 			},
 		},
+		{
+			name: "given stale match offer version when saving then returns version conflict",
+			entity: func() ddomain.Entity {
+				offer := ddomain.NewMatchOffer(
+					"Thunder Strikers",
+					common.Paddle,
+					tomorrow,
+					timeSlot,
+					location,
+					ddomain.NewSpecificCategories([]common.Category{5, 6, 7}),
+					ddomain.StatusPending,
+					time.Now().In(tz), "", 0,
+				)
+				offer.Version = 3
+				return offer
+			}(),
+			setupMock: func(mockClient *amocks.DynamoDBClientInterface, entity ddomain.Entity) {
+				mockClient.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+					var savedDto matchoffer.Dto
+					_ = attributevalue.UnmarshalMap(input.Item, &savedDto)
+					return input.ConditionExpression != nil && savedDto.Version == 4
+				})).Return(nil, &types.ConditionalCheckFailedException{})
+			},
+			assertions: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, common.ErrVersionConflict)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -112,7 +140,7 @@ func TestRepository_Save(t *testing.T) {
 			repository := matchoffer.NewRepositoryWithInterface(mockClient, "SportLinkCore")
 
 			// when
-			err := repository.Save(context.Background(), tc.entity)
+			err := repository.Save(context.Background(), tc.entity, nil)
 
 			// then
 			tc.assertions(t, err)
@@ -579,3 +607,129 @@ func TestRepository_Find(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_FindByIDs(t *testing.T) {
+	location := ddomain.NewLocation("Argentina", "Buenos Aires", "CABA")
+	tz := location.GetTimezone()
+	tomorrow := time.Now().In(tz).AddDate(0, 0, 1)
+	startTime := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, tz)
+	timeSlot, _ := ddomain.NewTimeSlot(startTime, startTime.Add(2*time.Hour))
+	item := func(id string) map[string]types.AttributeValue {
+		entity := ddomain.NewMatchOffer(
+			"Team "+id,
+			common.Paddle,
+			tomorrow,
+			timeSlot,
+			location,
+			ddomain.NewSpecificCategories([]common.Category{5}),
+			ddomain.StatusPending,
+			time.Now().In(tz), "", 0,
+		)
+		entity.ID = id
+		dto, _ := matchoffer.From(entity)
+		av, _ := attributevalue.MarshalMap(dto)
+		return av
+	}
+	keysOf := func(input *dynamodb.BatchGetItemInput) []map[string]types.AttributeValue {
+		return input.RequestItems["SportLinkCore"].Keys
+	}
+	manyIDs := make([]string, 0, 150)
+	for i := range 150 {
+		manyIDs = append(manyIDs, fmt.Sprintf("offer-%03d", i))
+	}
+
+	testCases := []struct {
+		name       string
+		ids        []string
+		setupMock  func(*amocks.DynamoDBClientInterface)
+		assertions func(*testing.T, []ddomain.Entity, error)
+	}{
+		{
+			name: "given repeated ids when finding then reads each key once",
+			ids:  []string{"offer-1", "offer-2", "offer-1"},
+			setupMock: func(mockClient *amocks.DynamoDBClientInterface) {
+				mockClient.On("BatchGetItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+					return len(keysOf(input)) == 2
+				})).Return(&dynamodb.BatchGetItemOutput{
+					Responses: map[string][]map[string]types.AttributeValue{"SportLinkCore": {item("offer-1"), item("offer-2")}},
+				}, nil).Once()
+			},
+			assertions: func(t *testing.T, offers []ddomain.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"offer-1", "offer-2"}, []string{offers[0].ID, offers[1].ID})
+			},
+		},
+		{
+			name: "given more ids than a batch takes when finding then reads them in chunks of 100",
+			ids:  manyIDs,
+			setupMock: func(mockClient *amocks.DynamoDBClientInterface) {
+				mockClient.On("BatchGetItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+					return len(keysOf(input)) == 100
+				})).Return(&dynamodb.BatchGetItemOutput{
+					Responses: map[string][]map[string]types.AttributeValue{"SportLinkCore": {item("offer-000")}},
+				}, nil).Once()
+				mockClient.On("BatchGetItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+					return len(keysOf(input)) == 50
+				})).Return(&dynamodb.BatchGetItemOutput{
+					Responses: map[string][]map[string]types.AttributeValue{"SportLinkCore": {item("offer-100")}},
+				}, nil).Once()
+			},
+			assertions: func(t *testing.T, offers []ddomain.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, offers, 2)
+			},
+		},
+		{
+			name: "given keys left unprocessed when finding then retries them",
+			ids:  []string{"offer-1", "offer-2"},
+			setupMock: func(mockClient *amocks.DynamoDBClientInterface) {
+				unprocessed := map[string]types.AttributeValue{
+					"EntityId": &types.AttributeValueMemberS{Value: "Entity#MatchOffer"},
+					"Id":       &types.AttributeValueMemberS{Value: "offer-2"},
+				}
+				mockClient.On("BatchGetItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+					return len(keysOf(input)) == 2
+				})).Return(&dynamodb.BatchGetItemOutput{
+					Responses:       map[string][]map[string]types.AttributeValue{"SportLinkCore": {item("offer-1")}},
+					UnprocessedKeys: map[string]types.KeysAndAttributes{"SportLinkCore": {Keys: []map[string]types.AttributeValue{unprocessed}}},
+				}, nil).Once()
+				mockClient.On("BatchGetItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.BatchGetItemInput) bool {
+					return len(keysOf(input)) == 1
+				})).Return(&dynamodb.BatchGetItemOutput{
+					Responses: map[string][]map[string]types.AttributeValue{"SportLinkCore": {item("offer-2")}},
+				}, nil).Once()
+			},
+			assertions: func(t *testing.T, offers []ddomain.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"offer-1", "offer-2"}, []string{offers[0].ID, offers[1].ID})
+			},
+		},
+		{
+			name: "given BatchGetItem fails when finding then returns error",
+			ids:  []string{"offer-1", "offer-2"},
+			setupMock: func(mockClient *amocks.DynamoDBClientInterface) {
+				mockClient.On("BatchGetItem", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+			},
+			assertions: func(t *testing.T, offers []ddomain.Entity, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "database error")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			mockClient := amocks.NewDynamoDBClientInterface(t)
+			tc.setupMock(mockClient)
+
+			repository := matchoffer.NewRepositoryWithInterface(mockClient, "SportLinkCore")
+
+			// when
+			offers, err := repository.FindByIDs(context.Background(), tc.ids)
+
+			// then
+			tc.assertions(t, offers, err)
+		})
+	}
+}
@@ -11,8 +11,11 @@ type Dto struct {
 	MatchOfferId string `dynamodbav:"MatchOfferId"` // Referenced announcement ID
 	OwnerAccountId      string `dynamodbav:"OwnerAccountId"`      // Announcement owner account ID (GSI partition key)
 	RequesterAccountId  string `dynamodbav:"RequesterAccountId"`  // Requester account ID
+	Position            string `dynamodbav:"Position,omitempty"`  // Position/role applied for, empty when not set
 	Status              string `dynamodbav:"Status"`              // PENDING, ACCEPTED, REJECTED
+	RejectionReason     string `dynamodbav:"RejectionReason,omitempty"` // Shown to the requester, empty when none was given
 	CreatedAt           int64  `dynamodbav:"CreatedAt"`           // Unix timestamp
+	Version             int    `dynamodbav:"Version"`             // Optimistic lock, bumped on every write
 }
 
 func (d *Dto) ToDomain() matchrequest.Entity {
@@ -23,8 +26,11 @@ func (d *Dto) ToDomain() matchrequest.Entity {
 		MatchOfferID: d.MatchOfferId,
 		OwnerAccountID:      d.OwnerAccountId,
 		RequesterAccountID:  d.RequesterAccountId,
+		Position:            d.Position,
 		Status:              status,
+		RejectionReason:     d.RejectionReason,
 		CreatedAt:           time.Unix(d.CreatedAt, 0).UTC(),
+		Version:             d.Version,
 	}
 }
 
@@ -35,7 +41,10 @@ func From(entity matchrequest.Entity) Dto {
 		MatchOfferId: entity.MatchOfferID,
 		OwnerAccountId:      entity.OwnerAccountID,
 		RequesterAccountId:  entity.RequesterAccountID,
+		Position:            entity.Position,
 		Status:              entity.Status.String(),
+		RejectionReason:     entity.RejectionReason,
 		CreatedAt:           entity.CreatedAt.Unix(),
+		Version:             entity.Version + 1,
 	}
 }
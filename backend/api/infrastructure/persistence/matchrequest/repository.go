@@ -3,7 +3,16 @@ package matchrequest
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/matchoffer"
 	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	ddb "sportlink/api/infrastructure/persistence/dynamodb"
+	ihistory "sportlink/api/infrastructure/persistence/history"
+	imatchoffer "sportlink/api/infrastructure/persistence/matchoffer"
+	ioutbox "sportlink/api/infrastructure/persistence/outbox"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -17,7 +26,8 @@ type DynamoDBClientInterface interface {
 	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
 	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
 	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
-	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
 }
 
 type RepositoryAdapter struct {
@@ -39,45 +49,239 @@ func NewRepositoryWithInterface(client DynamoDBClientInterface, tableName string
 	}
 }
 
-func (repo *RepositoryAdapter) Save(ctx context.Context, entity matchrequest.Entity) error {
-	dto := From(entity)
+// Save writes the request with its history and the outbox messages, and bumps its version.
+// Requests read at a version only overwrite the stored item when nobody else wrote it
+// since; otherwise common.ErrVersionConflict.
+func (repo *RepositoryAdapter) Save(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	put, err := repo.buildVersionedPut(entity)
+	if err != nil {
+		return err
+	}
+	items, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
+		return err
+	}
+	historyItems, err := repo.buildHistoryPuts(ctx, entity)
+	if err != nil {
+		return err
+	}
 
-	av, err := attributevalue.MarshalMap(dto)
+	err = ihistory.PutWithHistory(ctx, repo.dbClient, put, append(items, historyItems...))
+	if ddb.IsConditionFailed(err) {
+		return fmt.Errorf("match request %s: %w", entity.ID, common.ErrVersionConflict)
+	}
+	return err
+}
+
+// Create writes a new request with the outbox messages, unless a request still in play is
+// stored under its ID.
+func (repo *RepositoryAdapter) Create(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	av, err := attributevalue.MarshalMap(From(entity))
+	if err != nil {
+		return fmt.Errorf("failed to marshal match request: %w", err)
+	}
+
+	finals := matchrequest.FinalStatuses()
+	operands := make([]expression.OperandBuilder, 0, len(finals))
+	for _, status := range finals {
+		operands = append(operands, expression.Value(status.String()))
+	}
+	cond := expression.AttributeNotExists(expression.Name("Id")).
+		Or(expression.Name("Status").In(operands[0], operands[1:]...))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
 	if err != nil {
 		return err
 	}
 
-	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(repo.tableName),
-		Item:      av,
-	})
+	messageItems, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
+		return err
+	}
+
+	err = ihistory.PutWithHistory(ctx, repo.dbClient, &types.Put{
+		TableName:                 aws.String(repo.tableName),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}, messageItems)
+	if ddb.IsConditionFailed(err) {
+		return fmt.Errorf("match request %s: %w", entity.ID, matchrequest.ErrOpenRequestExists)
+	}
 	return err
 }
 
+// SaveWithOffer writes the request with its history, the offer's accepted counters and the
+// outbox messages in one transaction.
+// When the request is accepted on an offer with a spot limit, the offer write is also
+// conditioned on the stored AcceptedCount still being below that limit.
+func (repo *RepositoryAdapter) SaveWithOffer(
+	ctx context.Context,
+	request matchrequest.Entity,
+	offer matchoffer.Entity,
+	messages []outbox.Message,
+) error {
+	requestPut, err := repo.buildVersionedPut(request)
+	if err != nil {
+		return err
+	}
+	offerUpdate, err := repo.buildOfferSpotsUpdate(request, offer)
+	if err != nil {
+		return err
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{Put: requestPut},
+		{Update: offerUpdate},
+	}
+	messageItems, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
+		return err
+	}
+	transactItems = append(transactItems, messageItems...)
+	historyItems, err := repo.buildHistoryPuts(ctx, request)
+	if err != nil {
+		return err
+	}
+	transactItems = append(transactItems, historyItems...)
+
+	_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err == nil {
+		return nil
+	}
+
+	reasons := ddb.CancellationReasons(err)
+	if reasons == nil {
+		return fmt.Errorf("failed to save match request with offer transaction: %w", err)
+	}
+	if len(reasons) > 1 && isConditionFailure(reasons[1]) && offerWasFull(reasons[1], offer) {
+		return fmt.Errorf("match offer %s: %w", offer.ID, matchoffer.ErrOfferFull)
+	}
+	for _, r := range reasons {
+		if isConditionFailure(r) {
+			return fmt.Errorf("match request %s: %w", request.ID, common.ErrVersionConflict)
+		}
+	}
+	return fmt.Errorf("failed to save match request with offer transaction: %w", err)
+}
+
+// buildVersionedPut returns the put for the request, conditioned on its read version.
+func (repo *RepositoryAdapter) buildVersionedPut(entity matchrequest.Entity) (*types.Put, error) {
+	av, err := attributevalue.MarshalMap(From(entity))
+	if err != nil {
+		return nil, err
+	}
+	return ddb.VersionedPut(repo.tableName, av, entity.Version)
+}
+
+// buildHistoryPuts returns the puts of the status changes of the request.
+func (repo *RepositoryAdapter) buildHistoryPuts(ctx context.Context, entity matchrequest.Entity) ([]types.TransactWriteItem, error) {
+	return ihistory.BuildPuts(ctx, repo.tableName, history.KindMatchRequest, entity.ID, entity.Changes)
+}
+
+// buildOfferSpotsUpdate sets the offer's accepted counters and bumps its version. Offers
+// read without a version must still lack one, so two first writers cannot both win.
+func (repo *RepositoryAdapter) buildOfferSpotsUpdate(request matchrequest.Entity, offer matchoffer.Entity) (*types.Update, error) {
+	update := expression.
+		Set(expression.Name("AcceptedCount"), expression.Value(offer.AcceptedCount)).
+		Set(expression.Name(ddb.VersionAttribute), expression.Value(offer.Version+1))
+	if len(offer.AcceptedByPosition) > 0 {
+		update = update.Set(expression.Name("AcceptedByPosition"), expression.Value(offer.AcceptedByPosition))
+	}
+	openSpots, openSpotsByPosition := imatchoffer.OpenSpots(offer)
+	if openSpots != nil {
+		update = update.Set(expression.Name("OpenSpots"), expression.Value(*openSpots))
+	}
+	if len(openSpotsByPosition) > 0 {
+		update = update.Set(expression.Name("OpenSpotsByPosition"), expression.Value(openSpotsByPosition))
+	}
+
+	cond := expression.And(expression.AttributeExists(expression.Name("Id")), ddb.VersionCondition(offer.Version))
+	if limit := offer.SpotsToFill(); request.IsAccepted() && limit > 0 {
+		cond = cond.And(expression.Or(
+			expression.AttributeNotExists(expression.Name("AcceptedCount")),
+			expression.Name("AcceptedCount").LessThan(expression.Value(limit)),
+		))
+	}
+
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(cond).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := attributevalue.MarshalMap(map[string]string{
+		"EntityId": "Entity#MatchOffer",
+		"Id":       offer.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Update{
+		TableName:                           aws.String(repo.tableName),
+		Key:                                 key,
+		UpdateExpression:                    expr.Update(),
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}, nil
+}
+
+func isConditionFailure(reason types.CancellationReason) bool {
+	return aws.ToString(reason.Code) == "ConditionalCheckFailed"
+}
+
+// offerWasFull tells an "offer full" rejection apart from a version conflict by looking
+// at the counter stored when the condition failed.
+func offerWasFull(reason types.CancellationReason, offer matchoffer.Entity) bool {
+	limit := offer.SpotsToFill()
+	if limit == 0 || reason.Item == nil {
+		return false
+	}
+	var stored struct {
+		AcceptedCount int `dynamodbav:"AcceptedCount"`
+	}
+	if err := attributevalue.UnmarshalMap(reason.Item, &stored); err != nil {
+		return false
+	}
+	return stored.AcceptedCount >= limit
+}
+
 const ownerAccountIDIndexName = "OwnerAccountId-index"
 
+// Find looks the requests up by the most selective criterion the query sets, in this
+// order: owners, IDs, requesters and offers. Every request returned matches all the
+// criteria of the query; a query without any of those criteria returns no requests.
 func (repo *RepositoryAdapter) Find(ctx context.Context, query matchrequest.DomainQuery) ([]matchrequest.Entity, error) {
-	hasOwnerCriteria := len(query.OwnerAccountIDs) > 0
-	hasIDCriteria := len(query.IDs) > 0
-	hasRequesterCriteria := len(query.RequesterAccountIDs) == 1 && !hasOwnerCriteria && !hasIDCriteria
-	hasOfferCriteria := len(query.MatchOfferIDs) > 0 && !hasOwnerCriteria && !hasIDCriteria && !hasRequesterCriteria
-
+	var entities []matchrequest.Entity
+	var err error
 	switch {
-	case hasOwnerCriteria && len(query.OwnerAccountIDs) > 1:
-		return repo.findByMultipleOwners(ctx, query)
-	case hasOwnerCriteria:
-		return repo.findByOwnerAccountID(ctx, query.OwnerAccountIDs[0], query)
-	case hasIDCriteria && len(query.IDs) > 1:
-		return repo.findByMultipleIDs(ctx, query)
-	case hasIDCriteria:
-		return repo.findByPrimaryKey(ctx, query.IDs[0], query)
-	case hasRequesterCriteria:
-		return repo.findByRequesterAccountID(ctx, query.RequesterAccountIDs[0], query)
-	case hasOfferCriteria:
-		return repo.findByMatchOfferIDs(ctx, query)
+	case len(query.OwnerAccountIDs) > 1:
+		entities, err = repo.findByMultipleOwners(ctx, query)
+	case len(query.OwnerAccountIDs) == 1:
+		entities, err = repo.findByOwnerAccountID(ctx, query.OwnerAccountIDs[0], query)
+	case len(query.IDs) > 1:
+		entities, err = repo.findByMultipleIDs(ctx, query)
+	case len(query.IDs) == 1:
+		entities, err = repo.findByPrimaryKey(ctx, query.IDs[0], query)
+	case len(query.RequesterAccountIDs) > 0:
+		entities, err = repo.findByRequesterAccountIDs(ctx, query)
+	case len(query.MatchOfferIDs) > 0:
+		entities, err = repo.findByMatchOfferIDs(ctx, query)
 	default:
 		return []matchrequest.Entity{}, nil
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The key and filter expressions above do not cover every criterion on every path
+	return slices.DeleteFunc(entities, func(entity matchrequest.Entity) bool {
+		return !query.Matches(entity)
+	}), nil
 }
 
 func (repo *RepositoryAdapter) findByOwnerAccountID(ctx context.Context, ownerAccountID string, query matchrequest.DomainQuery) ([]matchrequest.Entity, error) {
@@ -160,12 +364,26 @@ func (repo *RepositoryAdapter) findByMultipleOwners(ctx context.Context, query m
 	return all, nil
 }
 
+// findByMultipleIDs reads the requests in batches; Find checks the other criteria.
 func (repo *RepositoryAdapter) findByMultipleIDs(ctx context.Context, query matchrequest.DomainQuery) ([]matchrequest.Entity, error) {
+	return repo.FindByIDs(ctx, query.IDs)
+}
+
+// FindByIDs reads the requests with BatchGetItem, ddb.BatchGetMaxKeys keys per call.
+func (repo *RepositoryAdapter) FindByIDs(ctx context.Context, ids []string) ([]matchrequest.Entity, error) {
+	items, err := ddb.BatchGet(ctx, repo.dbClient, repo.tableName, "Entity#MatchRequest", ids)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalItems(items)
+}
+
+func (repo *RepositoryAdapter) findByRequesterAccountIDs(ctx context.Context, query matchrequest.DomainQuery) ([]matchrequest.Entity, error) {
 	seen := make(map[string]bool)
-	var all []matchrequest.Entity
+	all := make([]matchrequest.Entity, 0)
 
-	for _, id := range query.IDs {
-		results, err := repo.findByPrimaryKey(ctx, id, query)
+	for _, requesterID := range query.RequesterAccountIDs {
+		results, err := repo.findByRequesterAccountID(ctx, requesterID, query)
 		if err != nil {
 			return nil, err
 		}
@@ -176,10 +394,6 @@ func (repo *RepositoryAdapter) findByMultipleIDs(ctx context.Context, query matc
 			}
 		}
 	}
-
-	if all == nil {
-		return []matchrequest.Entity{}, nil
-	}
 	return all, nil
 }
 
@@ -194,14 +408,7 @@ func (repo *RepositoryAdapter) findByRequesterAccountID(ctx context.Context, req
 	)
 
 	builder := expression.NewBuilder().WithKeyCondition(keyCond)
-
-	if len(query.Statuses) > 0 {
-		var vals []expression.OperandBuilder
-		for _, s := range query.Statuses {
-			vals = append(vals, expression.Value(s.String()))
-		}
-		builder = builder.WithFilter(expression.Name("Status").In(vals[0], vals[1:]...))
-	}
+	builder = includeFilters(query, builder)
 
 	expr, err := builder.Build()
 	if err != nil {
@@ -244,42 +451,51 @@ func (repo *RepositoryAdapter) findByRequesterAccountID(ctx context.Context, req
 
 const batchWriteMaxItems = 25
 
+// SaveAll writes the requests and their history in batches, writing again whatever
+// DynamoDB leaves unprocessed. Batch writes cannot be conditional, so versions are
+// bumped without being checked.
 func (repo *RepositoryAdapter) SaveAll(ctx context.Context, entities []matchrequest.Entity) error {
-	for i := 0; i < len(entities); i += batchWriteMaxItems {
-		end := i + batchWriteMaxItems
-		if end > len(entities) {
-			end = len(entities)
+	writes := make([]types.WriteRequest, 0, len(entities))
+	for _, entity := range entities {
+		av, err := attributevalue.MarshalMap(From(entity))
+		if err != nil {
+			return fmt.Errorf("failed to marshal match request: %w", err)
 		}
-		chunk := entities[i:end]
+		writes = append(writes, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: av},
+		})
 
-		requests := make([]types.WriteRequest, 0, len(chunk))
-		for _, entity := range chunk {
-			dto := From(entity)
-			av, err := attributevalue.MarshalMap(dto)
-			if err != nil {
-				return fmt.Errorf("failed to marshal match request: %w", err)
-			}
-			requests = append(requests, types.WriteRequest{
-				PutRequest: &types.PutRequest{Item: av},
-			})
+		historyWrites, err := ihistory.BuildWriteRequests(ctx, repo.tableName, history.KindMatchRequest, entity.ID, entity.Changes)
+		if err != nil {
+			return err
 		}
+		writes = append(writes, historyWrites...)
+	}
 
-		_, err := repo.dbClient.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
-			RequestItems: map[string][]types.WriteRequest{
-				repo.tableName: requests,
-			},
-		})
-		if err != nil {
-			return fmt.Errorf("failed to batch write match requests: %w", err)
+	for i := 0; i < len(writes); i += batchWriteMaxItems {
+		requests := writes[i:min(i+batchWriteMaxItems, len(writes))]
+		for len(requests) > 0 {
+			resp, err := repo.dbClient.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{
+					repo.tableName: requests,
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to batch write match requests: %w", err)
+			}
+			requests = resp.UnprocessedItems[repo.tableName]
 		}
 	}
 	return nil
 }
 
-func (repo *RepositoryAdapter) UpdateStatus(ctx context.Context, id string, ownerAccountID string, newStatus matchrequest.Status) error {
-	update := expression.Set(expression.Name("Status"), expression.Value(newStatus.String()))
+// UpdateStatus writes the status of entity with its history and the outbox messages, only
+// while the stored request is still PENDING and belongs to the owner of entity.
+func (repo *RepositoryAdapter) UpdateStatus(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	update := expression.Set(expression.Name("Status"), expression.Value(entity.Status.String())).
+		Set(expression.Name(ddb.VersionAttribute), expression.Plus(expression.IfNotExists(expression.Name(ddb.VersionAttribute), expression.Value(0)), expression.Value(1)))
 	cond := expression.And(
-		expression.Equal(expression.Name("OwnerAccountId"), expression.Value(ownerAccountID)),
+		expression.Equal(expression.Name("OwnerAccountId"), expression.Value(entity.OwnerAccountID)),
 		expression.Equal(expression.Name("Status"), expression.Value(matchrequest.StatusPending.String())),
 	)
 
@@ -293,16 +509,30 @@ func (repo *RepositoryAdapter) UpdateStatus(ctx context.Context, id string, owne
 
 	key := map[string]types.AttributeValue{
 		"EntityId": &types.AttributeValueMemberS{Value: "Entity#MatchRequest"},
-		"Id":       &types.AttributeValueMemberS{Value: id},
+		"Id":       &types.AttributeValueMemberS{Value: entity.ID},
 	}
 
-	_, err = repo.dbClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
-		TableName:                 aws.String(repo.tableName),
-		Key:                       key,
-		UpdateExpression:          expr.Update(),
-		ConditionExpression:       expr.Condition(),
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
+	messageItems, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
+		return err
+	}
+	historyItems, err := repo.buildHistoryPuts(ctx, entity)
+	if err != nil {
+		return err
+	}
+	transactItems := append([]types.TransactWriteItem{{
+		Update: &types.Update{
+			TableName:                 aws.String(repo.tableName),
+			Key:                       key,
+			UpdateExpression:          expr.Update(),
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		},
+	}}, append(messageItems, historyItems...)...)
+
+	_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to update match request status: %w", err)
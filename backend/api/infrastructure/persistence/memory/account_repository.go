@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sportlink/api/domain/account"
+	"sportlink/api/domain/outbox"
+	"strings"
+)
+
+type AccountRepository struct {
+	store *Store
+}
+
+func NewAccountRepository(store *Store) account.Repository {
+	return &AccountRepository{store: store}
+}
+
+// Save writes the account under the ID derived from its email, like the DynamoDB backend,
+// together with the outbox messages.
+func (repo *AccountRepository) Save(_ context.Context, entity account.Entity, messages []outbox.Message) error {
+	if entity.Email == "" {
+		return fmt.Errorf("email could not be empty")
+	}
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	entity.ID = account.GenerateAccountID(entity.Email)
+	repo.store.accounts[entity.ID] = entity
+	repo.store.putMessages(messages)
+	return nil
+}
+
+// Find returns the accounts matching every criterion of the query in ID order. Accounts
+// are looked up by Ids, Emails or AccountIDs, so a query without any of them returns no
+// accounts; Emails and Ids cannot be combined.
+func (repo *AccountRepository) Find(_ context.Context, query account.DomainQuery) ([]account.Entity, error) {
+	if len(query.Emails) > 0 && len(query.Ids) > 0 {
+		return []account.Entity{}, fmt.Errorf("cannot use both Emails and Ids in query")
+	}
+
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	accounts := make([]account.Entity, 0)
+	if len(query.Emails) == 0 && len(query.Ids) == 0 && len(query.AccountIDs) == 0 {
+		return accounts, nil
+	}
+	for _, entity := range repo.store.accounts {
+		if query.Matches(entity) {
+			accounts = append(accounts, entity)
+		}
+	}
+	slices.SortFunc(accounts, func(a, b account.Entity) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return accounts, nil
+}
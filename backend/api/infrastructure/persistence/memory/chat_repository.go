@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sportlink/api/domain/chat"
+	"strings"
+)
+
+type ChatRepository struct {
+	store *Store
+}
+
+func NewChatRepository(store *Store) chat.Repository {
+	return &ChatRepository{store: store}
+}
+
+func (repo *ChatRepository) Save(_ context.Context, message chat.Message) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	key := message.Thread.Key()
+	if repo.store.chatMessages[key] == nil {
+		repo.store.chatMessages[key] = map[string]chat.Message{}
+	}
+	repo.store.chatMessages[key][message.ID] = message
+	return nil
+}
+
+// Find returns the thread newest message first, starting right after the cursor.
+func (repo *ChatRepository) Find(_ context.Context, query chat.DomainQuery) (chat.Page, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	messages := make([]chat.Message, 0)
+	for _, message := range repo.store.chatMessages[query.Thread.Key()] {
+		if query.Cursor == "" || message.ID < query.Cursor {
+			messages = append(messages, message)
+		}
+	}
+	slices.SortFunc(messages, func(a, b chat.Message) int {
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	page := chat.Page{Entities: messages}
+	if query.Limit > 0 && len(messages) > query.Limit {
+		page.Entities = messages[:query.Limit]
+		page.NextCursor = page.Entities[query.Limit-1].ID
+	}
+	return page, nil
+}
+
+func (repo *ChatRepository) SaveReceipt(_ context.Context, receipt chat.Receipt) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	key := receipt.Thread.Key()
+	if repo.store.receipts[key] == nil {
+		repo.store.receipts[key] = map[string]chat.Receipt{}
+	}
+	repo.store.receipts[key][receipt.AccountID] = receipt
+	return nil
+}
+
+// FindReceipts returns the receipts of the thread ordered by account.
+func (repo *ChatRepository) FindReceipts(_ context.Context, thread chat.Thread) ([]chat.Receipt, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	receipts := make([]chat.Receipt, 0, len(repo.store.receipts[thread.Key()]))
+	for _, receipt := range repo.store.receipts[thread.Key()] {
+		receipts = append(receipts, receipt)
+	}
+	slices.SortFunc(receipts, func(a, b chat.Receipt) int {
+		return strings.Compare(a.AccountID, b.AccountID)
+	})
+	return receipts, nil
+}
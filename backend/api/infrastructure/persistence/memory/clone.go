@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"maps"
+	"slices"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/domain/player"
+	"sportlink/api/domain/team"
+	"sportlink/api/domain/user"
+)
+
+// The clone functions copy everything an entity shares by reference. Stored entities never
+// carry pending status changes: those are written as history.
+
+func cloneOffer(offer matchoffer.Entity) matchoffer.Entity {
+	offer.AdmittedCategories.Categories = slices.Clone(offer.AdmittedCategories.Categories)
+	offer.PlayerNeeds.Positions = slices.Clone(offer.PlayerNeeds.Positions)
+	offer.InvitedAccountIDs = slices.Clone(offer.InvitedAccountIDs)
+	offer.AcceptedByPosition = maps.Clone(offer.AcceptedByPosition)
+	offer.Changes = nil
+	return offer
+}
+
+func cloneRequest(request matchrequest.Entity) matchrequest.Entity {
+	request.Changes = nil
+	return request
+}
+
+func cloneMatch(entity match.Entity) match.Entity {
+	entity.Participants = slices.Clone(entity.Participants)
+	if entity.Result != nil {
+		result := *entity.Result
+		entity.Result = &result
+	}
+	if entity.Payment != nil {
+		payment := *entity.Payment
+		payment.Shares = slices.Clone(payment.Shares)
+		for i, share := range payment.Shares {
+			if share.PaidAt != nil {
+				paidAt := *share.PaidAt
+				payment.Shares[i].PaidAt = &paidAt
+			}
+		}
+		payment.Refunds = slices.Clone(payment.Refunds)
+		entity.Payment = &payment
+	}
+	entity.Changes = nil
+	return entity
+}
+
+// cloneTeam keeps what the DynamoDB backend keeps of a team: members are stored by ID
+// only and stats are not persisted yet.
+func cloneTeam(entity team.Entity) team.Entity {
+	members := make([]player.Entity, len(entity.Members))
+	for i, m := range entity.Members {
+		members[i] = player.Entity{ID: m.ID}
+	}
+	entity.Members = members
+	entity.Stats = *common.NewStats(0, 0, 0)
+	if entity.HomeArea != nil {
+		area := *entity.HomeArea
+		entity.HomeArea = &area
+	}
+	return entity
+}
+
+func cloneUser(entity user.Entity) user.Entity {
+	entity.PlayerIDs = slices.Clone(entity.PlayerIDs)
+	return entity
+}
+
+func cloneMessage(message outbox.Message) outbox.Message {
+	message.Payload = slices.Clone(message.Payload)
+	return message
+}
+
+func cloneNotification(entity notification.Entity) notification.Entity {
+	entity.Data = maps.Clone(entity.Data)
+	if entity.ReadAt != nil {
+		readAt := *entity.ReadAt
+		entity.ReadAt = &readAt
+	}
+	return entity
+}
+
+func clonePreferences(preferences notification.Preferences) notification.Preferences {
+	preferences.Disabled = maps.Clone(preferences.Disabled)
+	preferences.DisabledChannels = maps.Clone(preferences.DisabledChannels)
+	if preferences.QuietHours != nil {
+		quietHours := *preferences.QuietHours
+		preferences.QuietHours = &quietHours
+	}
+	preferences.PushTokens = slices.Clone(preferences.PushTokens)
+	return preferences
+}
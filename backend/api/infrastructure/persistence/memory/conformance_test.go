@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"sportlink/tests/conformance"
+	"testing"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, func(t *testing.T) conformance.Repositories {
+		store := NewStore()
+		return conformance.Repositories{
+			Account:      NewAccountRepository(store),
+			Player:       NewPlayerRepository(store),
+			User:         NewUserRepository(store),
+			Team:         NewTeamRepository(store),
+			MatchOffer:   NewMatchOfferRepository(store),
+			MatchRequest: NewMatchRequestRepository(store),
+			Match:        NewMatchRepository(store),
+			Reminder:     NewReminderRepository(store),
+			Outbox:       NewOutboxRepository(store),
+			Notification: NewNotificationRepository(store),
+			Chat:         NewChatRepository(store),
+		}
+	})
+}
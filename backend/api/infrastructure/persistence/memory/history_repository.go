@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sportlink/api/domain/history"
+)
+
+// HistoryRepository reads the entries the other repositories of the store append.
+type HistoryRepository struct {
+	store *Store
+}
+
+func NewHistoryRepository(store *Store) history.Repository {
+	return &HistoryRepository{store: store}
+}
+
+func (repo *HistoryRepository) Find(_ context.Context, kind history.Kind, entityID string) ([]history.Entry, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	entries := slices.Clone(repo.store.history[historyKey(kind, entityID)])
+	if entries == nil {
+		entries = []history.Entry{}
+	}
+	return entries, nil
+}
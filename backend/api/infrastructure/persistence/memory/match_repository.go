@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	"strings"
+)
+
+type MatchRepository struct {
+	store *Store
+}
+
+func NewMatchRepository(store *Store) match.Repository {
+	return &MatchRepository{store: store}
+}
+
+// Save writes the match with its history and the outbox messages, and lists it for every
+// participant. The match is only overwritten at the version it was read; matches read
+// without a version must still lack one.
+func (repo *MatchRepository) Save(ctx context.Context, entity match.Entity, messages []outbox.Message) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, exists := repo.store.matches[entity.ID]
+	if !versionMatches(stored.Version, entity.Version, exists) {
+		return fmt.Errorf("match %s: %w", entity.ID, common.ErrVersionConflict)
+	}
+	repo.store.putMatch(entity)
+	repo.store.putMessages(messages)
+	repo.store.appendHistory(ctx, history.KindMatch, entity.ID, entity.Changes)
+	return nil
+}
+
+// SaveConfirmation writes the match, the confirmed offer, the outbox messages and the
+// waitlisted requests at once, each entity with its history. The match must not exist
+// yet, the offer must still be PENDING at the version it was read and each request must
+// still be at the version it was read. All of them fit in one write here, so
+// match.ErrWaitlistIncomplete is never returned.
+func (repo *MatchRepository) SaveConfirmation(
+	ctx context.Context,
+	entity match.Entity,
+	offer matchoffer.Entity,
+	waitlisted []matchrequest.Entity,
+	messages []outbox.Message,
+) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	_, matchExists := repo.store.matches[entity.ID]
+	storedOffer, offerExists := repo.store.offers[offer.ID]
+	offerConfirmable := offerExists && storedOffer.Status == matchoffer.StatusPending &&
+		versionMatches(storedOffer.Version, offer.Version, offerExists)
+	if matchExists || !offerConfirmable || !repo.store.requestVersionsMatch(waitlisted) {
+		return fmt.Errorf("confirmation of match offer %s: %w", offer.ID, common.ErrVersionConflict)
+	}
+
+	repo.store.putMatch(entity)
+	repo.store.putOffer(offer)
+	repo.store.putMessages(messages)
+	repo.store.appendHistory(ctx, history.KindMatchOffer, offer.ID, offer.Changes)
+	repo.store.putRequestsWithHistory(ctx, waitlisted)
+	return nil
+}
+
+// RemoveParticipant writes the match and the requests with their history and the outbox
+// messages, and stops listing the match for removedAccountID. The match and each request
+// are only overwritten at the version they were read.
+func (repo *MatchRepository) RemoveParticipant(
+	ctx context.Context,
+	entity match.Entity,
+	removedAccountID string,
+	requests []matchrequest.Entity,
+	messages []outbox.Message,
+) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, exists := repo.store.matches[entity.ID]
+	if !versionMatches(stored.Version, entity.Version, exists) || !repo.store.requestVersionsMatch(requests) {
+		return fmt.Errorf("match %s: %w", entity.ID, common.ErrVersionConflict)
+	}
+
+	repo.store.putMatch(entity)
+	delete(repo.store.matchAccounts[removedAccountID], entity.ID)
+	repo.store.putRequestsWithHistory(ctx, requests)
+	repo.store.putMessages(messages)
+	return nil
+}
+
+// Find returns the matches listed for the account in ID order, only those in one of the
+// statuses when the query sets any.
+func (repo *MatchRepository) Find(_ context.Context, query match.DomainQuery) ([]match.Entity, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	entities := make([]match.Entity, 0)
+	for matchID := range repo.store.matchAccounts[query.AccountID] {
+		entity, ok := repo.store.matches[matchID]
+		if !ok || (len(query.Statuses) > 0 && !slices.Contains(query.Statuses, entity.Status)) {
+			continue
+		}
+		entities = append(entities, cloneMatch(entity))
+	}
+	slices.SortFunc(entities, func(a, b match.Entity) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return entities, nil
+}
+
+// FindByID returns the match by its ID alone, as the DynamoDB backend does.
+func (repo *MatchRepository) FindByID(_ context.Context, _, matchID string) (*match.Entity, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	entity, ok := repo.store.matches[matchID]
+	if !ok {
+		return nil, nil
+	}
+	entity = cloneMatch(entity)
+	return &entity, nil
+}
+
+// putMatch stores the match one version ahead of the one it was read at and lists it for
+// its participants. The caller holds the write lock.
+func (s *Store) putMatch(entity match.Entity) {
+	stored := cloneMatch(entity)
+	stored.Version = entity.Version + 1
+	s.matches[entity.ID] = stored
+	for _, accountID := range entity.Participants {
+		if s.matchAccounts[accountID] == nil {
+			s.matchAccounts[accountID] = map[string]bool{}
+		}
+		s.matchAccounts[accountID][entity.ID] = true
+	}
+}
+
+// requestVersionsMatch reports whether every request may be written at the version it was
+// read. The caller holds the lock.
+func (s *Store) requestVersionsMatch(requests []matchrequest.Entity) bool {
+	for _, request := range requests {
+		if !s.requestVersionMatches(request) {
+			return false
+		}
+	}
+	return true
+}
+
+// putRequestsWithHistory stores the requests and their history. The caller holds the
+// write lock.
+func (s *Store) putRequestsWithHistory(ctx context.Context, requests []matchrequest.Entity) {
+	for _, request := range requests {
+		s.putRequest(request)
+		s.appendHistory(ctx, history.KindMatchRequest, request.ID, request.Changes)
+	}
+}
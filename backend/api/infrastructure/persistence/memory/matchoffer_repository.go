@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	"strings"
+)
+
+type MatchOfferRepository struct {
+	store *Store
+}
+
+func NewMatchOfferRepository(store *Store) matchoffer.Repository {
+	return &MatchOfferRepository{store: store}
+}
+
+// Save writes the offer with its history and the outbox messages, and bumps its version.
+// Offers read at a version only overwrite the stored one when nobody else wrote it since;
+// otherwise common.ErrVersionConflict.
+func (repo *MatchOfferRepository) Save(ctx context.Context, entity matchoffer.Entity, messages []outbox.Message) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, exists := repo.store.offers[entity.ID]
+	if !versionMatches(stored.Version, entity.Version, exists) {
+		return fmt.Errorf("match offer %s: %w", entity.ID, common.ErrVersionConflict)
+	}
+	repo.store.putOffer(entity)
+	repo.store.appendHistory(ctx, history.KindMatchOffer, entity.ID, entity.Changes)
+	repo.store.putMessages(messages)
+	return nil
+}
+
+// SaveAll writes each offer like Save. Offers that changed since they were read are
+// skipped and reported together as common.ErrVersionConflict once the others are
+// written. It returns how many offers were written.
+func (repo *MatchOfferRepository) SaveAll(ctx context.Context, entities []matchoffer.Entity) (int, error) {
+	saved := 0
+	var conflicts []error
+	for _, entity := range entities {
+		if err := repo.Save(ctx, entity, nil); err != nil {
+			conflicts = append(conflicts, err)
+			continue
+		}
+		saved++
+	}
+	return saved, errors.Join(conflicts...)
+}
+
+// Find returns the offers matching every criterion of the query in ID order, the page
+// selected by Limit and Offset and how many offers matched in total.
+func (repo *MatchOfferRepository) Find(_ context.Context, query matchoffer.DomainQuery) (matchoffer.Page, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	matched := make([]matchoffer.Entity, 0)
+	for _, offer := range repo.store.offers {
+		if query.Matches(offer) {
+			matched = append(matched, cloneOffer(offer))
+		}
+	}
+	slices.SortFunc(matched, func(a, b matchoffer.Entity) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return matchoffer.Page{
+		Entities: paginate(matched, query.Limit, query.Offset),
+		Total:    len(matched),
+	}, nil
+}
+
+// FindByIDs returns the offers stored under ids, each one once.
+func (repo *MatchOfferRepository) FindByIDs(_ context.Context, ids []string) ([]matchoffer.Entity, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	found := make([]matchoffer.Entity, 0, len(ids))
+	for _, id := range uniqueIDs(ids) {
+		if offer, ok := repo.store.offers[id]; ok {
+			found = append(found, cloneOffer(offer))
+		}
+	}
+	return found, nil
+}
+
+// putOffer stores the offer one version ahead of the one it was read at. The caller holds
+// the write lock.
+func (s *Store) putOffer(offer matchoffer.Entity) {
+	stored := cloneOffer(offer)
+	stored.Version = offer.Version + 1
+	s.offers[offer.ID] = stored
+}
+
+// uniqueIDs returns ids without the repeated ones, in the order they first appear.
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	return slices.DeleteFunc(slices.Clone(ids), func(id string) bool {
+		if seen[id] {
+			return true
+		}
+		seen[id] = true
+		return false
+	})
+}
+
+// paginate skips offset entities and keeps up to limit of the rest; 0 means no limit.
+func paginate[T any](entities []T, limit, offset int) []T {
+	entities = entities[min(max(offset, 0), len(entities)):]
+	if limit > 0 && len(entities) > limit {
+		entities = entities[:limit]
+	}
+	return entities
+}
@@ -0,0 +1,153 @@
+package memory_test
+
+import (
+	"context"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/infrastructure/persistence/memory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newOffer(ownerAccountID string, status matchoffer.Status, location matchoffer.Location) matchoffer.Entity {
+	tz := location.GetTimezone()
+	tomorrow := time.Now().In(tz).AddDate(0, 0, 1)
+	startTime := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, tz)
+	timeSlot, _ := matchoffer.NewTimeSlot(startTime, startTime.Add(2*time.Hour))
+	return matchoffer.NewMatchOffer(
+		"Thunder Strikers",
+		common.Paddle,
+		tomorrow,
+		timeSlot,
+		location,
+		matchoffer.NewSpecificCategories([]common.Category{5, 6, 7}),
+		status,
+		time.Now().In(tz),
+		ownerAccountID,
+		0,
+	)
+}
+
+func TestMatchOfferRepository_Find(t *testing.T) {
+	palermo := matchoffer.NewLocationWithCoords("Argentina", "Buenos Aires", "Palermo", -34.5885, -58.4300)
+	belgrano := matchoffer.NewLocationWithCoords("Argentina", "Buenos Aires", "Belgrano", -34.5627, -58.4583)
+	cordoba := matchoffer.NewLocationWithCoords("Argentina", "Cordoba", "Cordoba", -31.4201, -64.1888)
+
+	testCases := []struct {
+		name       string
+		given      []matchoffer.Entity
+		query      matchoffer.DomainQuery
+		assertions func(t *testing.T, page matchoffer.Page, err error)
+	}{
+		{
+			name: "given offers in several cities when finding by radius then returns only the nearby ones",
+			given: []matchoffer.Entity{
+				newOffer("owner-1", matchoffer.StatusPending, palermo),
+				newOffer("owner-2", matchoffer.StatusPending, belgrano),
+				newOffer("owner-3", matchoffer.StatusPending, cordoba),
+			},
+			query: matchoffer.DomainQuery{GeoFilter: &matchoffer.GeoFilter{Latitude: -34.6037, Longitude: -58.3816, RadiusKm: 20}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, page.Total)
+				for _, offer := range page.Entities {
+					assert.NotEqual(t, "owner-3", offer.OwnerAccountID)
+				}
+			},
+		},
+		{
+			name: "given offers in several statuses when finding by status then returns only those statuses",
+			given: []matchoffer.Entity{
+				newOffer("owner-1", matchoffer.StatusPending, palermo),
+				newOffer("owner-2", matchoffer.StatusConfirmed, palermo),
+			},
+			query: matchoffer.DomainQuery{Statuses: []matchoffer.Status{matchoffer.StatusConfirmed}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, page.Entities, 1)
+				assert.Equal(t, "owner-2", page.Entities[0].OwnerAccountID)
+			},
+		},
+		{
+			name: "given more offers than the limit when finding a page then returns the page and the total",
+			given: []matchoffer.Entity{
+				newOffer("owner-1", matchoffer.StatusPending, palermo),
+				newOffer("owner-1", matchoffer.StatusPending, palermo),
+				newOffer("owner-1", matchoffer.StatusPending, palermo),
+			},
+			query: matchoffer.DomainQuery{OwnerAccountID: "owner-1", Limit: 2, Offset: 2},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, page.Entities, 1)
+				assert.Equal(t, 3, page.Total)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := memory.NewMatchOfferRepository(memory.NewStore())
+			_, err := repository.SaveAll(context.Background(), testCase.given)
+			assert.NoError(t, err)
+
+			// when
+			page, err := repository.Find(context.Background(), testCase.query)
+
+			// then
+			testCase.assertions(t, page, err)
+		})
+	}
+}
+
+func TestMatchOfferRepository_Save(t *testing.T) {
+	location := matchoffer.NewLocation("Argentina", "Buenos Aires", "CABA")
+
+	testCases := []struct {
+		name       string
+		on         func(repository matchoffer.Repository) matchoffer.Entity
+		assertions func(t *testing.T, err error)
+	}{
+		{
+			name: "given an offer read at its stored version when saving then saves successfully",
+			on: func(repository matchoffer.Repository) matchoffer.Entity {
+				offer := newOffer("owner-1", matchoffer.StatusPending, location)
+				_ = repository.Save(context.Background(), offer, nil)
+				offer.Version = 1
+				return offer
+			},
+			assertions: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "given an offer written since it was read when saving then returns version conflict",
+			on: func(repository matchoffer.Repository) matchoffer.Entity {
+				offer := newOffer("owner-1", matchoffer.StatusPending, location)
+				_ = repository.Save(context.Background(), offer, nil)
+				offer.Version = 1
+				_ = repository.Save(context.Background(), offer, nil)
+				return offer
+			},
+			assertions: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, common.ErrVersionConflict)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := memory.NewMatchOfferRepository(memory.NewStore())
+			offer := testCase.on(repository)
+
+			// when
+			err := repository.Save(context.Background(), offer, nil)
+
+			// then
+			testCase.assertions(t, err)
+		})
+	}
+}
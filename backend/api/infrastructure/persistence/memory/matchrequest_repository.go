@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	"strings"
+)
+
+type MatchRequestRepository struct {
+	store *Store
+}
+
+func NewMatchRequestRepository(store *Store) matchrequest.Repository {
+	return &MatchRequestRepository{store: store}
+}
+
+// Create writes a new request with the outbox messages, unless a request still in play is
+// stored under its ID.
+func (repo *MatchRequestRepository) Create(_ context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if stored, exists := repo.store.requests[entity.ID]; exists && !slices.Contains(matchrequest.FinalStatuses(), stored.Status) {
+		return fmt.Errorf("match request %s: %w", entity.ID, matchrequest.ErrOpenRequestExists)
+	}
+	repo.store.putRequest(entity)
+	repo.store.putMessages(messages)
+	return nil
+}
+
+// Save writes the request with its history and the outbox messages, and bumps its version.
+// Requests read at a version only overwrite the stored one when nobody else wrote it since;
+// otherwise common.ErrVersionConflict.
+func (repo *MatchRequestRepository) Save(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if !repo.store.requestVersionMatches(entity) {
+		return fmt.Errorf("match request %s: %w", entity.ID, common.ErrVersionConflict)
+	}
+	repo.store.putRequest(entity)
+	repo.store.appendHistory(ctx, history.KindMatchRequest, entity.ID, entity.Changes)
+	repo.store.putMessages(messages)
+	return nil
+}
+
+// SaveAll writes the requests with their history without checking their versions, like
+// the batch writes of the DynamoDB backend.
+func (repo *MatchRequestRepository) SaveAll(ctx context.Context, entities []matchrequest.Entity) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, entity := range entities {
+		repo.store.putRequest(entity)
+		repo.store.appendHistory(ctx, history.KindMatchRequest, entity.ID, entity.Changes)
+	}
+	return nil
+}
+
+// Find returns the requests matching every criterion of the query in ID order. A query
+// without any criterion returns no requests rather than all of them.
+func (repo *MatchRequestRepository) Find(_ context.Context, query matchrequest.DomainQuery) ([]matchrequest.Entity, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	matched := make([]matchrequest.Entity, 0)
+	if len(query.IDs) == 0 && len(query.MatchOfferIDs) == 0 && len(query.OwnerAccountIDs) == 0 && len(query.RequesterAccountIDs) == 0 {
+		return matched, nil
+	}
+	for _, request := range repo.store.requests {
+		if query.Matches(request) {
+			matched = append(matched, cloneRequest(request))
+		}
+	}
+	slices.SortFunc(matched, func(a, b matchrequest.Entity) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return matched, nil
+}
+
+// FindByIDs returns the requests stored under ids, each one once.
+func (repo *MatchRequestRepository) FindByIDs(_ context.Context, ids []string) ([]matchrequest.Entity, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	found := make([]matchrequest.Entity, 0, len(ids))
+	for _, id := range uniqueIDs(ids) {
+		if request, ok := repo.store.requests[id]; ok {
+			found = append(found, cloneRequest(request))
+		}
+	}
+	return found, nil
+}
+
+// UpdateStatus writes the status of entity with its history and the outbox messages, only
+// while the stored request is still PENDING and belongs to the owner of entity.
+func (repo *MatchRequestRepository) UpdateStatus(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, exists := repo.store.requests[entity.ID]
+	if !exists || stored.OwnerAccountID != entity.OwnerAccountID || stored.Status != matchrequest.StatusPending {
+		return fmt.Errorf("failed to update match request status: match request %s is not a pending request of %s", entity.ID, entity.OwnerAccountID)
+	}
+	stored.Status = entity.Status
+	stored.Version++
+	repo.store.requests[entity.ID] = stored
+	repo.store.appendHistory(ctx, history.KindMatchRequest, entity.ID, entity.Changes)
+	repo.store.putMessages(messages)
+	return nil
+}
+
+// SaveWithOffer writes the request with its history, the offer's accepted counters and the
+// outbox messages at once. When the request is accepted on an offer with a spot limit,
+// the stored AcceptedCount must still be below that limit.
+func (repo *MatchRequestRepository) SaveWithOffer(
+	ctx context.Context,
+	request matchrequest.Entity,
+	offer matchoffer.Entity,
+	messages []outbox.Message,
+) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	storedOffer, offerExists := repo.store.offers[offer.ID]
+	limit := offer.SpotsToFill()
+	offerWritable := offerExists && versionMatches(storedOffer.Version, offer.Version, offerExists)
+	if offerWritable && request.IsAccepted() && limit > 0 {
+		offerWritable = storedOffer.AcceptedCount < limit
+	}
+	if offerExists && !offerWritable && limit > 0 && storedOffer.AcceptedCount >= limit {
+		return fmt.Errorf("match offer %s: %w", offer.ID, matchoffer.ErrOfferFull)
+	}
+	if !offerWritable || !repo.store.requestVersionMatches(request) {
+		return fmt.Errorf("match request %s: %w", request.ID, common.ErrVersionConflict)
+	}
+
+	repo.store.putRequest(request)
+	storedOffer.AcceptedCount = offer.AcceptedCount
+	if len(offer.AcceptedByPosition) > 0 {
+		storedOffer.AcceptedByPosition = maps.Clone(offer.AcceptedByPosition)
+	}
+	storedOffer.Version = offer.Version + 1
+	repo.store.offers[offer.ID] = storedOffer
+	repo.store.putMessages(messages)
+	repo.store.appendHistory(ctx, history.KindMatchRequest, request.ID, request.Changes)
+	return nil
+}
+
+// requestVersionMatches reports whether the request may be written at the version it was
+// read. The caller holds the lock.
+func (s *Store) requestVersionMatches(request matchrequest.Entity) bool {
+	stored, exists := s.requests[request.ID]
+	return versionMatches(stored.Version, request.Version, exists)
+}
+
+// putRequest stores the request one version ahead of the one it was read at. The caller
+// holds the write lock.
+func (s *Store) putRequest(request matchrequest.Entity) {
+	stored := cloneRequest(request)
+	stored.Version = request.Version + 1
+	s.requests[request.ID] = stored
+}
@@ -0,0 +1,124 @@
+package memory_test
+
+import (
+	"context"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/infrastructure/persistence/memory"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchRequestRepository_Create(t *testing.T) {
+	testCases := []struct {
+		name       string
+		stored     func() []matchrequest.Entity
+		assertions func(t *testing.T, err error)
+	}{
+		{
+			name: "given no request for the offer when creating then creates successfully",
+			stored: func() []matchrequest.Entity {
+				return nil
+			},
+			assertions: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "given a pending request for the offer when creating then returns open request exists",
+			stored: func() []matchrequest.Entity {
+				return []matchrequest.Entity{matchrequest.NewMatchRequest("offer-1", "owner-1", "requester-1", "")}
+			},
+			assertions: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, matchrequest.ErrOpenRequestExists)
+			},
+		},
+		{
+			name: "given a rejected request for the offer when creating then creates successfully",
+			stored: func() []matchrequest.Entity {
+				request, _ := matchrequest.NewMatchRequest("offer-1", "owner-1", "requester-1", "").Reject("")
+				return []matchrequest.Entity{request}
+			},
+			assertions: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := memory.NewMatchRequestRepository(memory.NewStore())
+			assert.NoError(t, repository.SaveAll(context.Background(), testCase.stored()))
+
+			// when
+			err := repository.Create(context.Background(), matchrequest.NewMatchRequest("offer-1", "owner-1", "requester-1", ""), nil)
+
+			// then
+			testCase.assertions(t, err)
+		})
+	}
+}
+
+func TestMatchRequestRepository_SaveWithOffer(t *testing.T) {
+	location := matchoffer.NewLocation("Argentina", "Buenos Aires", "CABA")
+
+	testCases := []struct {
+		name          string
+		acceptedCount int
+		readVersion   int
+		assertions    func(t *testing.T, err error)
+	}{
+		{
+			name:          "given a spot left when accepting a request then saves the request and the counter",
+			acceptedCount: 0,
+			readVersion:   1,
+			assertions: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:          "given no spot left when accepting a request then returns offer full",
+			acceptedCount: 1,
+			readVersion:   1,
+			assertions: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, matchoffer.ErrOfferFull)
+			},
+		},
+		{
+			name:          "given an offer written since it was read when accepting a request then returns version conflict",
+			acceptedCount: 0,
+			readVersion:   2,
+			assertions: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, common.ErrVersionConflict)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			store := memory.NewStore()
+			offers := memory.NewMatchOfferRepository(store)
+			repository := memory.NewMatchRequestRepository(store)
+			offer := newOffer("owner-1", matchoffer.StatusPending, location)
+			offer.Capacity = 2
+			offer.AcceptedCount = testCase.acceptedCount
+			assert.NoError(t, offers.Save(context.Background(), offer, nil))
+			request := matchrequest.NewMatchRequest(offer.ID, "owner-1", "requester-1", "")
+			assert.NoError(t, repository.Create(context.Background(), request, nil))
+			request.Version = 1
+			accepted, _ := request.Accept(common.ActorOwner)
+			offer.Version = testCase.readVersion
+			offer.AcceptedCount++
+
+			// when
+			err := repository.SaveWithOffer(context.Background(), accepted, offer, nil)
+
+			// then
+			testCase.assertions(t, err)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	"strings"
+)
+
+type NotificationRepository struct {
+	store *Store
+}
+
+func NewNotificationRepository(store *Store) notification.Repository {
+	return &NotificationRepository{store: store}
+}
+
+func (repo *NotificationRepository) Save(_ context.Context, entity notification.Entity, messages []outbox.Message) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	repo.store.putNotification(entity)
+	repo.store.putMessages(messages)
+	return nil
+}
+
+func (repo *NotificationRepository) SaveAll(_ context.Context, entities []notification.Entity) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, entity := range entities {
+		repo.store.putNotification(entity)
+	}
+	return nil
+}
+
+// Find returns the inbox newest first, starting right after the cursor.
+func (repo *NotificationRepository) Find(_ context.Context, query notification.DomainQuery) (notification.Page, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	entities := make([]notification.Entity, 0)
+	for _, entity := range repo.store.notifications[query.AccountID] {
+		if query.Cursor != "" && entity.ID >= query.Cursor {
+			continue
+		}
+		if query.UnreadOnly && entity.IsRead() {
+			continue
+		}
+		entities = append(entities, cloneNotification(entity))
+	}
+	slices.SortFunc(entities, func(a, b notification.Entity) int {
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	page := notification.Page{Entities: entities}
+	if query.Limit > 0 && len(entities) > query.Limit {
+		page.Entities = entities[:query.Limit]
+		page.NextCursor = page.Entities[query.Limit-1].ID
+	}
+	return page, nil
+}
+
+func (repo *NotificationRepository) FindByID(_ context.Context, accountID, notificationID string) (*notification.Entity, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	entity, ok := repo.store.notifications[accountID][notificationID]
+	if !ok {
+		return nil, nil
+	}
+	entity = cloneNotification(entity)
+	return &entity, nil
+}
+
+// putNotification stores the notification in the inbox of its account. The caller holds
+// the write lock.
+func (s *Store) putNotification(entity notification.Entity) {
+	inbox := s.notifications[entity.AccountID]
+	if inbox == nil {
+		inbox = map[string]notification.Entity{}
+		s.notifications[entity.AccountID] = inbox
+	}
+	inbox[entity.ID] = cloneNotification(entity)
+}
+
+type PreferencesRepository struct {
+	store *Store
+}
+
+func NewPreferencesRepository(store *Store) notification.PreferencesRepository {
+	return &PreferencesRepository{store: store}
+}
+
+// Find returns the saved preferences of the account, or the defaults when it never
+// changed them.
+func (repo *PreferencesRepository) Find(_ context.Context, accountID string) (notification.Preferences, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	preferences, ok := repo.store.preferences[accountID]
+	if !ok {
+		return notification.NewPreferences(accountID), nil
+	}
+	return clonePreferences(preferences), nil
+}
+
+func (repo *PreferencesRepository) Save(_ context.Context, preferences notification.Preferences) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	repo.store.preferences[preferences.AccountID] = clonePreferences(preferences)
+	return nil
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sportlink/api/domain/outbox"
+	"strings"
+	"time"
+)
+
+type OutboxRepository struct {
+	store *Store
+}
+
+func NewOutboxRepository(store *Store) outbox.Repository {
+	return &OutboxRepository{store: store}
+}
+
+func (repo *OutboxRepository) Save(_ context.Context, message outbox.Message) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	repo.store.putMessages([]outbox.Message{message})
+	return nil
+}
+
+func (repo *OutboxRepository) Delete(_ context.Context, messageID string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	delete(repo.store.messages, messageID)
+	return nil
+}
+
+// FindDue returns up to limit pending messages whose next attempt is due at now, in ID
+// order, which is the order they were raised.
+func (repo *OutboxRepository) FindDue(_ context.Context, now time.Time, limit int) ([]outbox.Message, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	due := make([]outbox.Message, 0)
+	for _, message := range repo.store.messages {
+		if message.IsDue(now) {
+			due = append(due, cloneMessage(message))
+		}
+	}
+	slices.SortFunc(due, func(a, b outbox.Message) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return paginate(due, limit, 0), nil
+}
+
+type ProcessedRepository struct {
+	store *Store
+}
+
+func NewProcessedRepository(store *Store) outbox.ProcessedRepository {
+	return &ProcessedRepository{store: store}
+}
+
+func (repo *ProcessedRepository) IsProcessed(_ context.Context, consumer, messageID string) (bool, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	return repo.store.processed[consumer+"#"+messageID], nil
+}
+
+// MarkProcessed remembers the message for as long as the process lives; unlike the
+// DynamoDB backend nothing is purged.
+func (repo *ProcessedRepository) MarkProcessed(_ context.Context, consumer, messageID string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	repo.store.processed[consumer+"#"+messageID] = true
+	return nil
+}
@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sportlink/api/domain/player"
+	"strings"
+)
+
+type PlayerRepository struct {
+	store *Store
+}
+
+func NewPlayerRepository(store *Store) player.Repository {
+	return &PlayerRepository{store: store}
+}
+
+func (repo *PlayerRepository) Save(_ context.Context, entity player.Entity) error {
+	if entity.ID == "" {
+		return fmt.Errorf("id could not be empty")
+	}
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	repo.store.players[entity.ID] = entity
+	return nil
+}
+
+// Find returns the players matching every criterion of the query in ID order.
+func (repo *PlayerRepository) Find(_ context.Context, query player.DomainQuery) ([]player.Entity, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	players := make([]player.Entity, 0)
+	for _, entity := range repo.store.players {
+		if matchesPlayerQuery(entity, query) {
+			players = append(players, entity)
+		}
+	}
+	slices.SortFunc(players, func(a, b player.Entity) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return players, nil
+}
+
+func matchesPlayerQuery(entity player.Entity, query player.DomainQuery) bool {
+	return (query.Id == "" || entity.ID == query.Id) &&
+		(len(query.Ids) == 0 || slices.Contains(query.Ids, entity.ID)) &&
+		(query.Category == 0 || entity.Category == query.Category) &&
+		(query.Sport == "" || entity.Sport == query.Sport)
+}
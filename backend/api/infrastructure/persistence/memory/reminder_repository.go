@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/outbox"
+	"strings"
+	"time"
+)
+
+type ReminderRepository struct {
+	store *Store
+}
+
+func NewReminderRepository(store *Store) match.ReminderRepository {
+	return &ReminderRepository{store: store}
+}
+
+// Schedule stores the reminders by match, offset and due time, so scheduling one again
+// overwrites it with the same content and without a lease.
+func (repo *ReminderRepository) Schedule(_ context.Context, reminders []match.Reminder) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, reminder := range reminders {
+		key := reminderKey(reminder)
+		repo.store.reminders[key] = reminder
+		delete(repo.store.leases, key)
+	}
+	return nil
+}
+
+// FindDue returns up to limit reminders due at or before now and not leased past it,
+// the earliest first.
+func (repo *ReminderRepository) FindDue(_ context.Context, now time.Time, limit int) ([]match.Reminder, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	keys := make([]string, 0)
+	for key, reminder := range repo.store.reminders {
+		if !reminder.DueAt().After(now) && !repo.store.leases[key].After(now) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	reminders := make([]match.Reminder, 0, min(limit, len(keys)))
+	for _, key := range paginate(keys, limit, 0) {
+		reminders = append(reminders, repo.store.reminders[key])
+	}
+	return reminders, nil
+}
+
+// Claim leases the reminder until the given time. It returns false when the reminder is
+// gone or another lease runs past now.
+func (repo *ReminderRepository) Claim(_ context.Context, reminder match.Reminder, now, until time.Time) (bool, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	key := reminderKey(reminder)
+	if _, ok := repo.store.reminders[key]; !ok || repo.store.leases[key].After(now) {
+		return false, nil
+	}
+	repo.store.leases[key] = until
+	return true, nil
+}
+
+// Delete takes the reminder out of the schedule while it is still leased until
+// leasedUntil, and writes the outbox messages at once.
+func (repo *ReminderRepository) Delete(_ context.Context, reminder match.Reminder, leasedUntil time.Time, messages []outbox.Message) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	key := reminderKey(reminder)
+	if _, ok := repo.store.reminders[key]; !ok || !repo.store.leases[key].Equal(leasedUntil) {
+		return fmt.Errorf("reminder of match %s: %w", reminder.MatchID, match.ErrReminderLeaseLost)
+	}
+	delete(repo.store.reminders, key)
+	delete(repo.store.leases, key)
+	repo.store.putMessages(messages)
+	return nil
+}
+
+// reminderKey sorts reminders by the time they are due. The timestamp is zero padded so
+// that the lexical order of the keys is the chronological one.
+func reminderKey(reminder match.Reminder) string {
+	return fmt.Sprintf("%015d#%s#%d", reminder.DueAt().UnixMilli(), reminder.MatchID, int64(reminder.Offset/time.Minute))
+}
+
+type AttendanceRepository struct {
+	store *Store
+}
+
+func NewAttendanceRepository(store *Store) match.AttendanceRepository {
+	return &AttendanceRepository{store: store}
+}
+
+// Save only records the confirmation when the participant has none yet, so the time of
+// the first tap is kept.
+func (repo *AttendanceRepository) Save(_ context.Context, attendance match.Attendance) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	confirmed := repo.store.attendances[attendance.MatchID]
+	if confirmed == nil {
+		confirmed = map[string]match.Attendance{}
+		repo.store.attendances[attendance.MatchID] = confirmed
+	}
+	if _, ok := confirmed[attendance.AccountID]; !ok {
+		confirmed[attendance.AccountID] = attendance
+	}
+	return nil
+}
+
+// Find returns the confirmations of the match ordered by account.
+func (repo *AttendanceRepository) Find(_ context.Context, matchID string) ([]match.Attendance, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	attendances := make([]match.Attendance, 0, len(repo.store.attendances[matchID]))
+	for _, attendance := range repo.store.attendances[matchID] {
+		attendances = append(attendances, attendance)
+	}
+	slices.SortFunc(attendances, func(a, b match.Attendance) int {
+		return strings.Compare(a.AccountID, b.AccountID)
+	})
+	return attendances, nil
+}
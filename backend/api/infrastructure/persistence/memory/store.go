@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"sportlink/api/domain/account"
+	"sportlink/api/domain/chat"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/domain/player"
+	"sportlink/api/domain/team"
+	"sportlink/api/domain/user"
+	"sync"
+	"time"
+)
+
+// Store holds every entity of the in-memory backend. The repositories built on one store
+// share a single lock, so writes spanning several entities, like the confirmation of an
+// offer, are as atomic as the DynamoDB transactions they stand in for.
+//
+// Entities are copied on the way in and on the way out, so callers can never change
+// stored data by mutating what they passed or got back.
+type Store struct {
+	mu sync.RWMutex
+
+	accounts      map[string]account.Entity // by ID
+	players       map[string]player.Entity
+	users         map[string]user.Entity
+	teams         map[string]team.Entity
+	offers        map[string]matchoffer.Entity
+	requests      map[string]matchrequest.Entity
+	matches       map[string]match.Entity
+	matchAccounts map[string]map[string]bool // match IDs listed for each account
+	reminders     map[string]match.Reminder  // by reminderKey, so they sort by due time
+	leases        map[string]time.Time       // until when each claimed reminder is leased, by reminderKey
+	attendances   map[string]map[string]match.Attendance
+	messages      map[string]outbox.Message
+	processed     map[string]bool // by consumer and message ID
+	notifications map[string]map[string]notification.Entity
+	preferences   map[string]notification.Preferences
+	chatMessages  map[string]map[string]chat.Message // by thread key, then message ID
+	receipts      map[string]map[string]chat.Receipt // by thread key, then account ID
+	history       map[string][]history.Entry         // by historyKey, in the order written
+}
+
+func NewStore() *Store {
+	return &Store{
+		accounts:      map[string]account.Entity{},
+		players:       map[string]player.Entity{},
+		users:         map[string]user.Entity{},
+		teams:         map[string]team.Entity{},
+		offers:        map[string]matchoffer.Entity{},
+		requests:      map[string]matchrequest.Entity{},
+		matches:       map[string]match.Entity{},
+		matchAccounts: map[string]map[string]bool{},
+		reminders:     map[string]match.Reminder{},
+		leases:        map[string]time.Time{},
+		attendances:   map[string]map[string]match.Attendance{},
+		messages:      map[string]outbox.Message{},
+		processed:     map[string]bool{},
+		notifications: map[string]map[string]notification.Entity{},
+		preferences:   map[string]notification.Preferences{},
+		chatMessages:  map[string]map[string]chat.Message{},
+		receipts:      map[string]map[string]chat.Receipt{},
+		history:       map[string][]history.Entry{},
+	}
+}
+
+// versionMatches mirrors the optimistic lock of the DynamoDB backend: entities read at a
+// version are only written while the stored one is still at it, entities read without a
+// version only while the stored one lacks one too, so two first writers cannot both win.
+func versionMatches(stored, read int, exists bool) bool {
+	if read == 0 {
+		return !exists || stored == 0
+	}
+	return exists && stored == read
+}
+
+// appendHistory records the status changes of an entity, stamped with the origin stored
+// in ctx. The caller holds the write lock.
+func (s *Store) appendHistory(ctx context.Context, kind history.Kind, entityID string, changes []common.StatusChange) {
+	if len(changes) == 0 {
+		return
+	}
+	key := historyKey(kind, entityID)
+	entries := history.NewEntries(history.OriginOf(ctx), kind, entityID, changes, time.Now())
+	s.history[key] = append(s.history[key], entries...)
+}
+
+// putMessages adds outbox messages. The caller holds the write lock.
+func (s *Store) putMessages(messages []outbox.Message) {
+	for _, message := range messages {
+		s.messages[message.ID] = cloneMessage(message)
+	}
+}
+
+func historyKey(kind history.Kind, entityID string) string {
+	return string(kind) + "#" + entityID
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/domain/team"
+	"strings"
+)
+
+type TeamRepository struct {
+	store *Store
+}
+
+func NewTeamRepository(store *Store) team.Repository {
+	return &TeamRepository{store: store}
+}
+
+func (repo *TeamRepository) Save(_ context.Context, entity team.Entity, messages []outbox.Message) error {
+	if entity.ID == "" {
+		return fmt.Errorf("ID could not be empty")
+	}
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if repo.nameTaken(entity) {
+		return fmt.Errorf("team %s: %w", entity.Name, team.ErrNameTaken)
+	}
+	repo.store.teams[entity.ID] = cloneTeam(entity)
+	repo.store.putMessages(messages)
+	return nil
+}
+
+// Update overwrites the team while it still has the name it was read with.
+func (repo *TeamRepository) Update(_ context.Context, previousName string, entity team.Entity, messages []outbox.Message) error {
+	if entity.ID == "" {
+		return fmt.Errorf("ID could not be empty")
+	}
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, ok := repo.store.teams[entity.ID]
+	if !ok || stored.Name != previousName {
+		return fmt.Errorf("team %s: %w", entity.ID, common.ErrVersionConflict)
+	}
+	if repo.nameTaken(entity) {
+		return fmt.Errorf("team %s: %w", entity.Name, team.ErrNameTaken)
+	}
+	repo.store.teams[entity.ID] = cloneTeam(entity)
+	repo.store.putMessages(messages)
+	return nil
+}
+
+// nameTaken reports whether another team of the sport has the name of entity.
+func (repo *TeamRepository) nameTaken(entity team.Entity) bool {
+	for _, stored := range repo.store.teams {
+		if stored.ID != entity.ID && stored.NameKey() == entity.NameKey() {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the teams matching every criterion of the query in ID order.
+func (repo *TeamRepository) Find(_ context.Context, query team.DomainQuery) ([]team.Entity, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	teams := make([]team.Entity, 0)
+	for _, entity := range repo.store.teams {
+		if query.Matches(entity) {
+			teams = append(teams, cloneTeam(entity))
+		}
+	}
+	slices.SortFunc(teams, func(a, b team.Entity) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return teams, nil
+}
+
+// Search returns the teams matching the query in SearchKey order, starting right after
+// the cursor.
+func (repo *TeamRepository) Search(_ context.Context, query team.SearchQuery) (team.Page, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	page := team.Page{Entities: make([]team.Entity, 0)}
+	for _, entity := range repo.store.teams {
+		if query.Matches(entity) && entity.SearchKey() > query.Cursor {
+			page.Entities = append(page.Entities, cloneTeam(entity))
+		}
+	}
+	slices.SortFunc(page.Entities, func(a, b team.Entity) int {
+		return strings.Compare(a.SearchKey(), b.SearchKey())
+	})
+
+	if query.Limit > 0 && len(page.Entities) > query.Limit {
+		page.Entities = page.Entities[:query.Limit]
+		page.NextCursor = page.Entities[query.Limit-1].SearchKey()
+	}
+	return page, nil
+}
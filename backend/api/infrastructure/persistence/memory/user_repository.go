@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sportlink/api/domain/user"
+	"strings"
+)
+
+type UserRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) user.Repository {
+	return &UserRepository{store: store}
+}
+
+func (repo *UserRepository) Save(_ context.Context, entity user.Entity) error {
+	if entity.ID == "" {
+		return fmt.Errorf("id could not be empty")
+	}
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	repo.store.users[entity.ID] = cloneUser(entity)
+	return nil
+}
+
+// Find returns the users with one of the Ids in ID order, only those with one of the
+// PlayerIDs when the query sets any. A query without Ids returns no users.
+func (repo *UserRepository) Find(_ context.Context, query user.DomainQuery) ([]user.Entity, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	users := make([]user.Entity, 0)
+	for _, id := range query.Ids {
+		entity, ok := repo.store.users[id]
+		if !ok || slices.ContainsFunc(users, func(u user.Entity) bool { return u.ID == id }) {
+			continue
+		}
+		if len(query.PlayerIDs) > 0 && !slices.ContainsFunc(query.PlayerIDs, func(playerID string) bool {
+			return slices.Contains(entity.PlayerIDs, playerID)
+		}) {
+			continue
+		}
+		users = append(users, cloneUser(entity))
+	}
+	slices.SortFunc(users, func(a, b user.Entity) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return users, nil
+}
@@ -0,0 +1,78 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const checkpointPartition = "Entity#Migration"
+
+// checkpoint is how far a migration got, stored in the table after every page.
+type checkpoint struct {
+	EntityId    string `dynamodbav:"EntityId"` // "Entity#Migration"
+	Version     string `dynamodbav:"Id"`       // Version of the migration
+	Description string `dynamodbav:"Description"`
+	Partition   string `dynamodbav:"Partition"`
+	LastID      string `dynamodbav:"LastId,omitempty"` // Id of the last item scanned; empty once Done
+	Scanned     int    `dynamodbav:"Scanned"`
+	Rewritten   int    `dynamodbav:"Rewritten"`
+	Done        bool   `dynamodbav:"Done"`
+	UpdatedAt   int64  `dynamodbav:"UpdatedAt"` // Unix timestamp of the last page
+}
+
+// findCheckpoint returns where the migration stopped, or an empty checkpoint when it
+// never ran.
+func (r *Runner) findCheckpoint(ctx context.Context, version string) (checkpoint, error) {
+	output, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:              aws.String(r.tableName),
+		Key:                    key(checkpointPartition, version),
+		ConsistentRead:         aws.Bool(true),
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+	})
+	if err != nil {
+		return checkpoint{}, fmt.Errorf("error finding checkpoint of migration %s: %w", version, err)
+	}
+	if err := r.reads.consume(ctx, output.ConsumedCapacity); err != nil {
+		return checkpoint{}, err
+	}
+
+	var cp checkpoint
+	if output.Item != nil {
+		if err := attributevalue.UnmarshalMap(output.Item, &cp); err != nil {
+			return checkpoint{}, err
+		}
+	}
+	return cp, nil
+}
+
+// saveCheckpoint records how far the migration got. Dry runs leave no checkpoints, so the
+// next run scans the same items.
+func (r *Runner) saveCheckpoint(ctx context.Context, migration Migration, cp checkpoint) error {
+	if r.options.DryRun {
+		return nil
+	}
+	cp.EntityId = checkpointPartition
+	cp.Description = migration.Description
+	cp.Partition = migration.Partition
+	cp.UpdatedAt = time.Now().Unix()
+	item, err := attributevalue.MarshalMap(cp)
+	if err != nil {
+		return err
+	}
+
+	output, err := r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:              aws.String(r.tableName),
+		Item:                   item,
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+	})
+	if err != nil {
+		return fmt.Errorf("error saving checkpoint of migration %s: %w", migration.Version, err)
+	}
+	return r.writes.consume(ctx, output.ConsumedCapacity)
+}
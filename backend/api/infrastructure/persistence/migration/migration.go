@@ -0,0 +1,425 @@
+// Package migration rewrites the items of the SportLinkCore table when their shape
+// changes. Each migration goes over a single entity partition a page at a time and
+// records how far it got in the table, so an interrupted run picks up where it stopped.
+package migration
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	ddb "sportlink/api/infrastructure/persistence/dynamodb"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Item is a table item as stored, so migrations work on the shape they are migrating
+// from rather than on the Dto of the current code.
+type Item = map[string]types.AttributeValue
+
+// Migration rewrites the items of one entity partition.
+type Migration struct {
+	Version     string // migrations run in the order of their versions, e.g. "0001"
+	Description string
+	Partition   string // EntityId of the items it rewrites, e.g. "Entity#MatchOffer"
+	// Prepare, when set, runs before the partition is scanned, on every run that resumes
+	// the migration too, to gather from other partitions what Rewrite needs.
+	Prepare func(ctx context.Context, scan Scan) error
+	// Rewrite returns the item as the current code writes it and whether it changed. It
+	// must leave migrated items unchanged, since the items of the page being migrated when
+	// a run stops are gone over again. Returning an item with another Id moves it.
+	Rewrite func(item Item) (Item, bool, error)
+	// Companions, when set, returns the items of other partitions that go with a rewritten
+	// item, e.g. the records indexing it. They are put in the same transaction as the
+	// item, only where no item is stored under their keys yet.
+	Companions func(migrated Item) ([]Item, error)
+}
+
+// Scan goes over every item of a partition, consuming read capacity like the run does.
+type Scan func(ctx context.Context, partition string, each func(item Item) error) error
+
+// Report tells what a run did with a migration.
+type Report struct {
+	Version   string
+	Done      bool // it had already run to completion, so nothing was scanned
+	Scanned   int
+	Rewritten int // the items that were, or on a dry run would have been, rewritten
+}
+
+// DynamoDBClientInterface defines the DynamoDB operations needed by the runner
+type DynamoDBClientInterface interface {
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+}
+
+// Options tune a run.
+type Options struct {
+	DryRun   bool  // scan and count, without rewriting items nor recording checkpoints
+	PageSize int32 // items read per page, and between checkpoints
+	// ReadUnitsPerSecond and WriteUnitsPerSecond cap the capacity the run consumes, so it
+	// leaves room for the API. Zero means no cap.
+	ReadUnitsPerSecond  float64
+	WriteUnitsPerSecond float64
+}
+
+const defaultPageSize = 100
+
+// conflictRetries is how many times an item written by someone else while it was being
+// rewritten is read and rewritten again.
+const conflictRetries = 3
+
+type Runner struct {
+	client    DynamoDBClientInterface
+	tableName string
+	options   Options
+	reads     *throttle
+	writes    *throttle
+}
+
+func NewRunner(client DynamoDBClientInterface, tableName string, options Options) *Runner {
+	if options.PageSize <= 0 {
+		options.PageSize = defaultPageSize
+	}
+	return &Runner{
+		client:    client,
+		tableName: tableName,
+		options:   options,
+		reads:     newThrottle(options.ReadUnitsPerSecond),
+		writes:    newThrottle(options.WriteUnitsPerSecond),
+	}
+}
+
+// Run runs the migrations in the order of their versions, skipping the ones already run
+// to completion.
+func (r *Runner) Run(ctx context.Context, migrations []Migration) ([]Report, error) {
+	migrations = slices.Clone(migrations)
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("migration version %s is used twice", migrations[i].Version)
+		}
+	}
+
+	var reports []Report
+	for _, migration := range migrations {
+		report, err := r.run(ctx, migration)
+		reports = append(reports, report)
+		if err != nil {
+			return reports, fmt.Errorf("migration %s failed: %w", migration.Version, err)
+		}
+	}
+	return reports, nil
+}
+
+func (r *Runner) run(ctx context.Context, migration Migration) (Report, error) {
+	report := Report{Version: migration.Version}
+	cp, err := r.findCheckpoint(ctx, migration.Version)
+	if err != nil {
+		return report, err
+	}
+	if cp.Done {
+		report.Done = true
+		return report, nil
+	}
+	report.Scanned, report.Rewritten = cp.Scanned, cp.Rewritten
+
+	if migration.Prepare != nil {
+		if err := migration.Prepare(ctx, r.scan); err != nil {
+			return report, fmt.Errorf("error preparing: %w", err)
+		}
+	}
+
+	input, err := r.partitionQuery(migration.Partition)
+	if err != nil {
+		return report, err
+	}
+	if cp.LastID != "" {
+		input.ExclusiveStartKey = key(migration.Partition, cp.LastID)
+	}
+
+	for {
+		output, err := r.query(ctx, migration.Partition, input)
+		if err != nil {
+			return report, err
+		}
+
+		for _, item := range output.Items {
+			rewritten, err := r.rewrite(ctx, migration, item)
+			if err != nil {
+				return report, err
+			}
+			report.Scanned++
+			if rewritten {
+				report.Rewritten++
+			}
+		}
+
+		cp = checkpoint{Version: migration.Version, Scanned: report.Scanned, Rewritten: report.Rewritten}
+		if len(output.LastEvaluatedKey) == 0 {
+			cp.Done = true
+		} else {
+			cp.LastID = stringAttribute(output.LastEvaluatedKey, "Id")
+		}
+		if err := r.saveCheckpoint(ctx, migration, cp); err != nil {
+			return report, err
+		}
+		log.Printf("migration %s: %d items scanned, %d rewritten", migration.Version, report.Scanned, report.Rewritten)
+		if cp.Done {
+			return report, nil
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+}
+
+func (r *Runner) scan(ctx context.Context, partition string, each func(item Item) error) error {
+	input, err := r.partitionQuery(partition)
+	if err != nil {
+		return err
+	}
+	for {
+		output, err := r.query(ctx, partition, input)
+		if err != nil {
+			return err
+		}
+		for _, item := range output.Items {
+			if err := each(item); err != nil {
+				return err
+			}
+		}
+		if len(output.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+}
+
+func (r *Runner) partitionQuery(partition string) (*dynamodb.QueryInput, error) {
+	keyCond := expression.Key("EntityId").Equal(expression.Value(partition))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		return nil, err
+	}
+	return &dynamodb.QueryInput{
+		TableName:                 aws.String(r.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int32(r.options.PageSize),
+		ReturnConsumedCapacity:    types.ReturnConsumedCapacityTotal,
+	}, nil
+}
+
+func (r *Runner) query(ctx context.Context, partition string, input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	output, err := r.client.Query(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("error querying %s: %w", partition, err)
+	}
+	return output, r.reads.consume(ctx, output.ConsumedCapacity)
+}
+
+// rewrite migrates a single item and reports whether it changed. An item written by
+// someone else in the meantime is read again, and an item deleted in the meantime is left
+// alone.
+func (r *Runner) rewrite(ctx context.Context, migration Migration, item Item) (bool, error) {
+	for attempt := 0; ; attempt++ {
+		migrated, changed, err := migration.Rewrite(maps.Clone(item))
+		if err != nil {
+			return false, fmt.Errorf("error rewriting %s: %w", stringAttribute(item, "Id"), err)
+		}
+		if !changed || r.options.DryRun {
+			return changed, nil
+		}
+
+		var companions []Item
+		if migration.Companions != nil {
+			if companions, err = migration.Companions(migrated); err != nil {
+				return false, fmt.Errorf("error rewriting %s: %w", stringAttribute(item, "Id"), err)
+			}
+		}
+
+		err = r.write(ctx, item, migrated, companions)
+		if err == nil {
+			return true, nil
+		}
+		if !ddb.IsConditionFailed(err) || attempt == conflictRetries {
+			return false, fmt.Errorf("error writing %s: %w", stringAttribute(item, "Id"), err)
+		}
+
+		current, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName:              aws.String(r.tableName),
+			Key:                    key(stringAttribute(item, "EntityId"), stringAttribute(item, "Id")),
+			ConsistentRead:         aws.Bool(true),
+			ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+		})
+		if err != nil {
+			return false, err
+		}
+		if err := r.reads.consume(ctx, current.ConsumedCapacity); err != nil {
+			return false, err
+		}
+		if current.Item == nil {
+			return false, nil
+		}
+		item = current.Item
+	}
+}
+
+// write replaces the item read with the migrated one, as long as it was not written in
+// the meantime. Versioned items get their version bumped, so writers holding the item as
+// read before the migration reload it instead of overwriting the migrated one. Items
+// without a version must still lack one, which writers of the current code give them.
+func (r *Runner) write(ctx context.Context, read Item, migrated Item, companions []Item) error {
+	version := intAttribute(read, ddb.VersionAttribute)
+	cond := expression.AttributeExists(expression.Name("Id")).And(ddb.VersionCondition(version))
+	if version > 0 {
+		migrated[ddb.VersionAttribute] = &types.AttributeValueMemberN{Value: fmt.Sprint(version + 1)}
+	}
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return err
+	}
+
+	readID := stringAttribute(read, "Id")
+	moved := stringAttribute(migrated, "Id") != readID
+	if !moved && len(companions) == 0 {
+		output, err := r.client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName:                 aws.String(r.tableName),
+			Item:                      migrated,
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ReturnConsumedCapacity:    types.ReturnConsumedCapacityTotal,
+		})
+		if err != nil {
+			return err
+		}
+		return r.writes.consume(ctx, output.ConsumedCapacity)
+	}
+
+	// The item moves, or carries companions: the old one is deleted, and the companions
+	// put, in the same transaction the new one is put in.
+	var items []types.TransactWriteItem
+	if moved {
+		items = append(items,
+			types.TransactWriteItem{Put: &types.Put{
+				TableName:           aws.String(r.tableName),
+				Item:                migrated,
+				ConditionExpression: aws.String("attribute_not_exists(Id)"),
+			}},
+			types.TransactWriteItem{Delete: &types.Delete{
+				TableName:                 aws.String(r.tableName),
+				Key:                       key(stringAttribute(read, "EntityId"), readID),
+				ConditionExpression:       expr.Condition(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+			}},
+		)
+	} else {
+		items = append(items, types.TransactWriteItem{Put: &types.Put{
+			TableName:                 aws.String(r.tableName),
+			Item:                      migrated,
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		}})
+	}
+	for _, companion := range companions {
+		items = append(items, types.TransactWriteItem{Put: &types.Put{
+			TableName:           aws.String(r.tableName),
+			Item:                companion,
+			ConditionExpression: aws.String("attribute_not_exists(Id)"),
+		}})
+	}
+	output, err := r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems:          items,
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+	})
+	if err != nil {
+		return err
+	}
+	for _, consumed := range output.ConsumedCapacity {
+		if err := r.writes.consume(ctx, &consumed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ProvisionedUnits returns share of the read and write capacity provisioned for the
+// table, to run migrations alongside the API. Both are zero for on-demand tables.
+func ProvisionedUnits(ctx context.Context, client DynamoDBClientInterface, tableName string, share float64) (read float64, write float64, err error) {
+	output, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+	if err != nil {
+		return 0, 0, err
+	}
+	throughput := output.Table.ProvisionedThroughput
+	if throughput == nil {
+		return 0, 0, nil
+	}
+	return float64(aws.ToInt64(throughput.ReadCapacityUnits)) * share,
+		float64(aws.ToInt64(throughput.WriteCapacityUnits)) * share, nil
+}
+
+func key(entityID string, id string) Item {
+	return Item{
+		"EntityId": &types.AttributeValueMemberS{Value: entityID},
+		"Id":       &types.AttributeValueMemberS{Value: id},
+	}
+}
+
+func stringAttribute(item Item, name string) string {
+	if value, ok := item[name].(*types.AttributeValueMemberS); ok {
+		return value.Value
+	}
+	return ""
+}
+
+func intAttribute(item Item, name string) int {
+	var value int
+	if n, ok := item[name].(*types.AttributeValueMemberN); ok {
+		_, _ = fmt.Sscan(n.Value, &value)
+	}
+	return value
+}
+
+// throttle keeps the capacity consumed under a number of units per second, by sleeping
+// off what each request consumed before the next one is sent.
+type throttle struct {
+	unitsPerSecond float64
+	sleep          func(ctx context.Context, d time.Duration) error
+}
+
+func newThrottle(unitsPerSecond float64) *throttle {
+	return &throttle{unitsPerSecond: unitsPerSecond, sleep: sleep}
+}
+
+func (t *throttle) consume(ctx context.Context, consumed *types.ConsumedCapacity) error {
+	if t.unitsPerSecond <= 0 || consumed == nil {
+		return nil
+	}
+	units := aws.ToFloat64(consumed.CapacityUnits)
+	return t.sleep(ctx, time.Duration(units/t.unitsPerSecond*float64(time.Second)))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
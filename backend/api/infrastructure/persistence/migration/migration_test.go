@@ -0,0 +1,316 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"sportlink/api/infrastructure/persistence/migration"
+	amocks "sportlink/mocks/api/infrastructure/persistence/migration"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type checkpointDto struct {
+	EntityId  string `dynamodbav:"EntityId"`
+	Id        string `dynamodbav:"Id"`
+	LastId    string `dynamodbav:"LastId,omitempty"`
+	Scanned   int    `dynamodbav:"Scanned"`
+	Rewritten int    `dynamodbav:"Rewritten"`
+	Done      bool   `dynamodbav:"Done"`
+}
+
+type offerDto struct {
+	EntityId string `dynamodbav:"EntityId"`
+	Id       string `dynamodbav:"Id"`
+	Capacity *int   `dynamodbav:"Capacity,omitempty"`
+	Version  int    `dynamodbav:"Version"`
+}
+
+func item(t *testing.T, value any) migration.Item {
+	marshalled, err := attributevalue.MarshalMap(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return marshalled
+}
+
+func offer(t *testing.T, id string, version int) migration.Item {
+	return item(t, offerDto{EntityId: "Entity#MatchOffer", Id: id, Version: version})
+}
+
+// capacity is a migration setting the Capacity of offers lacking one.
+var capacity = migration.Migration{
+	Version:     "0001",
+	Description: "set the Capacity of match offers",
+	Partition:   "Entity#MatchOffer",
+	Rewrite: func(item migration.Item) (migration.Item, bool, error) {
+		if _, ok := item["Capacity"]; ok {
+			return item, false, nil
+		}
+		item["Capacity"] = &types.AttributeValueMemberN{Value: "0"}
+		return item, true, nil
+	},
+}
+
+func isCheckpoint(input *dynamodb.PutItemInput) bool {
+	return input.Item["EntityId"].(*types.AttributeValueMemberS).Value == "Entity#Migration"
+}
+
+func savedCheckpoint(input *dynamodb.PutItemInput) checkpointDto {
+	var cp checkpointDto
+	_ = attributevalue.UnmarshalMap(input.Item, &cp)
+	return cp
+}
+
+func savedOffer(input *dynamodb.PutItemInput) offerDto {
+	var dto offerDto
+	_ = attributevalue.UnmarshalMap(input.Item, &dto)
+	return dto
+}
+
+func TestRunner_Run(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name       string
+		options    migration.Options
+		setupMock  func(t *testing.T, client *amocks.DynamoDBClientInterface)
+		assertions func(t *testing.T, client *amocks.DynamoDBClientInterface, reports []migration.Report, err error)
+	}{
+		{
+			name:    "given a migration run to completion when running then skips it",
+			options: migration.Options{},
+			setupMock: func(t *testing.T, client *amocks.DynamoDBClientInterface) {
+				client.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{
+					Item: item(t, checkpointDto{EntityId: "Entity#Migration", Id: "0001", Scanned: 7, Done: true}),
+				}, nil)
+			},
+			assertions: func(t *testing.T, client *amocks.DynamoDBClientInterface, reports []migration.Report, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []migration.Report{{Version: "0001", Done: true}}, reports)
+				client.AssertNotCalled(t, "Query", mock.Anything, mock.Anything)
+			},
+		},
+		{
+			name:    "given a partition of two pages when running then rewrites the items lacking the change and checkpoints every page",
+			options: migration.Options{PageSize: 2},
+			setupMock: func(t *testing.T, client *amocks.DynamoDBClientInterface) {
+				migrated := 0
+				client.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+				client.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+					return input.ExclusiveStartKey == nil && aws.ToInt32(input.Limit) == 2
+				})).Return(&dynamodb.QueryOutput{
+					Items:            []map[string]types.AttributeValue{offer(t, "offer-1", 3), item(t, offerDto{EntityId: "Entity#MatchOffer", Id: "offer-2", Capacity: &migrated})},
+					LastEvaluatedKey: item(t, map[string]string{"EntityId": "Entity#MatchOffer", "Id": "offer-2"}),
+				}, nil).Once()
+				client.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+					return input.ExclusiveStartKey != nil
+				})).Return(&dynamodb.QueryOutput{
+					Items: []map[string]types.AttributeValue{offer(t, "offer-3", 0)},
+				}, nil).Once()
+				client.On("PutItem", mock.Anything, mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+			},
+			assertions: func(t *testing.T, client *amocks.DynamoDBClientInterface, reports []migration.Report, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []migration.Report{{Version: "0001", Scanned: 3, Rewritten: 2}}, reports)
+
+				var offers []offerDto
+				var checkpoints []checkpointDto
+				for _, call := range client.Calls {
+					if call.Method != "PutItem" {
+						continue
+					}
+					input := call.Arguments.Get(1).(*dynamodb.PutItemInput)
+					if isCheckpoint(input) {
+						checkpoints = append(checkpoints, savedCheckpoint(input))
+					} else {
+						offers = append(offers, savedOffer(input))
+					}
+				}
+				zero := 0
+				assert.Equal(t, []offerDto{
+					{EntityId: "Entity#MatchOffer", Id: "offer-1", Capacity: &zero, Version: 4},
+					{EntityId: "Entity#MatchOffer", Id: "offer-3", Capacity: &zero, Version: 0},
+				}, offers)
+				assert.Equal(t, []checkpointDto{
+					{EntityId: "Entity#Migration", Id: "0001", LastId: "offer-2", Scanned: 2, Rewritten: 1},
+					{EntityId: "Entity#Migration", Id: "0001", Scanned: 3, Rewritten: 2, Done: true},
+				}, checkpoints)
+			},
+		},
+		{
+			name:    "given a migration stopped halfway when running then resumes after the last item scanned",
+			options: migration.Options{},
+			setupMock: func(t *testing.T, client *amocks.DynamoDBClientInterface) {
+				client.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{
+					Item: item(t, checkpointDto{EntityId: "Entity#Migration", Id: "0001", LastId: "offer-2", Scanned: 2, Rewritten: 1}),
+				}, nil)
+				client.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+					return input.ExclusiveStartKey["Id"].(*types.AttributeValueMemberS).Value == "offer-2"
+				})).Return(&dynamodb.QueryOutput{
+					Items: []map[string]types.AttributeValue{offer(t, "offer-3", 1)},
+				}, nil).Once()
+				client.On("PutItem", mock.Anything, mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+			},
+			assertions: func(t *testing.T, client *amocks.DynamoDBClientInterface, reports []migration.Report, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []migration.Report{{Version: "0001", Scanned: 3, Rewritten: 2}}, reports)
+			},
+		},
+		{
+			name:    "given a dry run when running then counts the items to rewrite without writing anything",
+			options: migration.Options{DryRun: true},
+			setupMock: func(t *testing.T, client *amocks.DynamoDBClientInterface) {
+				client.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+				client.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{
+					Items: []map[string]types.AttributeValue{offer(t, "offer-1", 1), offer(t, "offer-2", 1)},
+				}, nil).Once()
+			},
+			assertions: func(t *testing.T, client *amocks.DynamoDBClientInterface, reports []migration.Report, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []migration.Report{{Version: "0001", Scanned: 2, Rewritten: 2}}, reports)
+				client.AssertNotCalled(t, "PutItem", mock.Anything, mock.Anything)
+			},
+		},
+		{
+			name:    "given an item written while being rewritten when running then rewrites it again as read once more",
+			options: migration.Options{},
+			setupMock: func(t *testing.T, client *amocks.DynamoDBClientInterface) {
+				client.On("GetItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+					return input.Key["EntityId"].(*types.AttributeValueMemberS).Value == "Entity#Migration"
+				})).Return(&dynamodb.GetItemOutput{}, nil)
+				client.On("GetItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+					return input.Key["Id"].(*types.AttributeValueMemberS).Value == "offer-1"
+				})).Return(&dynamodb.GetItemOutput{Item: offer(t, "offer-1", 2)}, nil)
+				client.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{
+					Items: []map[string]types.AttributeValue{offer(t, "offer-1", 1)},
+				}, nil).Once()
+				client.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+					return !isCheckpoint(input) && savedOffer(input).Version == 2
+				})).Return(nil, &types.ConditionalCheckFailedException{}).Once()
+				client.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+					return isCheckpoint(input) || savedOffer(input).Version == 3
+				})).Return(&dynamodb.PutItemOutput{}, nil)
+			},
+			assertions: func(t *testing.T, client *amocks.DynamoDBClientInterface, reports []migration.Report, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []migration.Report{{Version: "0001", Scanned: 1, Rewritten: 1}}, reports)
+			},
+		},
+		{
+			name:    "given a failing query when running then returns the error without checkpointing",
+			options: migration.Options{},
+			setupMock: func(t *testing.T, client *amocks.DynamoDBClientInterface) {
+				client.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+				client.On("Query", mock.Anything, mock.Anything).Return(nil, errors.New("throttled"))
+			},
+			assertions: func(t *testing.T, client *amocks.DynamoDBClientInterface, reports []migration.Report, err error) {
+				assert.ErrorContains(t, err, "throttled")
+				client.AssertNotCalled(t, "PutItem", mock.Anything, mock.Anything)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			client := amocks.NewDynamoDBClientInterface(t)
+			testCase.setupMock(t, client)
+			runner := migration.NewRunner(client, "SportLinkCore", testCase.options)
+
+			// when
+			reports, err := runner.Run(ctx, []migration.Migration{capacity})
+
+			// then
+			testCase.assertions(t, client, reports, err)
+		})
+	}
+}
+
+func TestRunner_Run_DuplicatedVersion(t *testing.T) {
+	// given
+	runner := migration.NewRunner(amocks.NewDynamoDBClientInterface(t), "SportLinkCore", migration.Options{})
+
+	// when
+	_, err := runner.Run(context.Background(), []migration.Migration{capacity, capacity})
+
+	// then
+	assert.ErrorContains(t, err, "0001 is used twice")
+}
+
+func TestRunner_Run_Prepare(t *testing.T) {
+	// given
+	client := amocks.NewDynamoDBClientInterface(t)
+	client.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+	isPartition := func(partition string) func(input *dynamodb.QueryInput) bool {
+		return func(input *dynamodb.QueryInput) bool {
+			return input.ExpressionAttributeValues[":0"].(*types.AttributeValueMemberS).Value == partition
+		}
+	}
+	client.On("Query", mock.Anything, mock.MatchedBy(isPartition("Entity#MatchRequest"))).Return(&dynamodb.QueryOutput{
+		Items:            []map[string]types.AttributeValue{item(t, offerDto{EntityId: "Entity#MatchRequest", Id: "request-1"})},
+		LastEvaluatedKey: item(t, map[string]string{"EntityId": "Entity#MatchRequest", "Id": "request-1"}),
+	}, nil).Once()
+	client.On("Query", mock.Anything, mock.MatchedBy(isPartition("Entity#MatchRequest"))).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{item(t, offerDto{EntityId: "Entity#MatchRequest", Id: "request-2"})},
+	}, nil).Once()
+	client.On("Query", mock.Anything, mock.MatchedBy(isPartition("Entity#MatchOffer"))).Return(&dynamodb.QueryOutput{}, nil).Once()
+	client.On("PutItem", mock.Anything, mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+	var prepared []string
+	withPreparation := capacity
+	withPreparation.Prepare = func(ctx context.Context, scan migration.Scan) error {
+		return scan(ctx, "Entity#MatchRequest", func(item migration.Item) error {
+			prepared = append(prepared, item["Id"].(*types.AttributeValueMemberS).Value)
+			return nil
+		})
+	}
+	runner := migration.NewRunner(client, "SportLinkCore", migration.Options{})
+
+	// when
+	reports, err := runner.Run(context.Background(), []migration.Migration{withPreparation})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"request-1", "request-2"}, prepared)
+	assert.Equal(t, []migration.Report{{Version: "0001"}}, reports)
+}
+
+func TestRunner_Run_Companions(t *testing.T) {
+	// given
+	client := amocks.NewDynamoDBClientInterface(t)
+	client.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+	client.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{offer(t, "legacy-1", 0)},
+	}, nil).Once()
+	client.On("TransactWriteItems", mock.Anything, mock.Anything).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+	client.On("PutItem", mock.Anything, mock.MatchedBy(isCheckpoint)).Return(&dynamodb.PutItemOutput{}, nil)
+
+	moving := capacity
+	moving.Rewrite = func(item migration.Item) (migration.Item, bool, error) {
+		item["Id"] = &types.AttributeValueMemberS{Value: "offer-1"}
+		return item, true, nil
+	}
+	moving.Companions = func(migrated migration.Item) ([]migration.Item, error) {
+		return []migration.Item{item(t, offerDto{EntityId: "Entity#OfferIndex", Id: "index-1"})}, nil
+	}
+	runner := migration.NewRunner(client, "SportLinkCore", migration.Options{})
+
+	// when
+	reports, err := runner.Run(context.Background(), []migration.Migration{moving})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []migration.Report{{Version: "0001", Scanned: 1, Rewritten: 1}}, reports)
+	input := client.Calls[2].Arguments.Get(1).(*dynamodb.TransactWriteItemsInput)
+	assert.Len(t, input.TransactItems, 3)
+	assert.Equal(t, "offer-1", savedOffer(&dynamodb.PutItemInput{Item: input.TransactItems[0].Put.Item}).Id)
+	assert.Equal(t, "legacy-1", input.TransactItems[1].Delete.Key["Id"].(*types.AttributeValueMemberS).Value)
+	assert.Equal(t, "index-1", savedOffer(&dynamodb.PutItemInput{Item: input.TransactItems[2].Put.Item}).Id)
+	assert.Equal(t, "attribute_not_exists(Id)", aws.ToString(input.TransactItems[2].Put.ConditionExpression))
+}
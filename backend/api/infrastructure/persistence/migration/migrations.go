@@ -0,0 +1,304 @@
+package migration
+
+import (
+	"context"
+	"maps"
+	"sportlink/api/domain/matchrequest"
+	domainteam "sportlink/api/domain/team"
+	"sportlink/api/infrastructure/persistence/matchoffer"
+	"sportlink/api/infrastructure/persistence/team"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/mmcloughlin/geohash"
+	"github.com/oklog/ulid/v2"
+)
+
+// All returns every migration, each one a rewrite from a shape the items of the table
+// once had to the one the current code writes. Versions are never reused nor reordered.
+func All() []Migration {
+	accepted := &acceptedRequests{}
+	teams := &teamIDs{}
+	return []Migration{
+		{
+			Version:     "0001",
+			Description: "set the GeohashPrefix of match offers with coordinates",
+			Partition:   "Entity#MatchOffer",
+			Rewrite:     matchOfferGeohashPrefix,
+		},
+		{
+			Version:     "0002",
+			Description: "set the Capacity of match offers written before it existed",
+			Partition:   "Entity#MatchOffer",
+			Rewrite:     matchOfferCapacity,
+		},
+		{
+			Version:     "0003",
+			Description: "remove the ExpiresAt of match offers, no longer their TTL",
+			Partition:   "Entity#MatchOffer",
+			Rewrite:     withoutAttribute("ExpiresAt"),
+		},
+		{
+			Version:     "0004",
+			Description: "count the accepted requests of match offers written before the spot counters existed",
+			Partition:   "Entity#MatchOffer",
+			Prepare:     accepted.count,
+			Rewrite:     accepted.backfill,
+		},
+		{
+			Version:     "0005",
+			Description: "set the OpenSpots searches filter match offers on",
+			Partition:   "Entity#MatchOffer",
+			Rewrite:     matchOfferOpenSpots,
+		},
+		{
+			Version:     "0006",
+			Description: "give teams keyed by their sport and name a ULID and claim their names",
+			Partition:   team.Partition,
+			Rewrite:     teamULID,
+			Companions:  teamNameClaim,
+		},
+		{
+			Version:     "0007",
+			Description: "index the teams written before searches for them",
+			Partition:   team.Partition,
+			Rewrite:     teamSearchName,
+			Companions:  teamSearchRecord,
+		},
+		{
+			Version:     "0008",
+			Description: "point match offers to the ULID of their team instead of its name",
+			Partition:   "Entity#MatchOffer",
+			Prepare:     teams.collect,
+			Rewrite:     teams.backfill,
+		},
+	}
+}
+
+// matchOfferGeohashPrefix keys offers with coordinates in the geo index, which only
+// finds the offers written since it was added.
+func matchOfferGeohashPrefix(item Item) (Item, bool, error) {
+	latitude, hasLatitude := floatAttribute(item, "Latitude")
+	longitude, hasLongitude := floatAttribute(item, "Longitude")
+	if !hasLatitude || !hasLongitude {
+		return item, false, nil
+	}
+	prefix := geohash.EncodeWithPrecision(latitude, longitude, matchoffer.GeohashPrecision)
+	if stringAttribute(item, "GeohashPrefix") == prefix {
+		return item, false, nil
+	}
+	item["GeohashPrefix"] = &types.AttributeValueMemberS{Value: prefix}
+	return item, true, nil
+}
+
+// matchOfferCapacity writes the Capacity offers without one are read with, no automatic
+// confirmation, so filters on it see every offer.
+func matchOfferCapacity(item Item) (Item, bool, error) {
+	if _, ok := item["Capacity"]; ok {
+		return item, false, nil
+	}
+	item["Capacity"] = &types.AttributeValueMemberN{Value: "0"}
+	return item, true, nil
+}
+
+// acceptedRequests tallies the accepted requests of every offer, so offers written before
+// AcceptedCount existed do not let requests be accepted past their spots.
+type acceptedRequests struct {
+	byOffer    map[string]int
+	byPosition map[string]map[string]int
+}
+
+func (a *acceptedRequests) count(ctx context.Context, scan Scan) error {
+	a.byOffer = map[string]int{}
+	a.byPosition = map[string]map[string]int{}
+	return scan(ctx, "Entity#MatchRequest", func(item Item) error {
+		if stringAttribute(item, "Status") != string(matchrequest.StatusAccepted) {
+			return nil
+		}
+		offerID := stringAttribute(item, "MatchOfferId")
+		a.byOffer[offerID]++
+		if position := stringAttribute(item, "Position"); position != "" {
+			if a.byPosition[offerID] == nil {
+				a.byPosition[offerID] = map[string]int{}
+			}
+			a.byPosition[offerID][position]++
+		}
+		return nil
+	})
+}
+
+// backfill sets the counters of offers lacking them. AcceptedByPosition only counts the
+// positions the offer needs, like accepting a request does.
+func (a *acceptedRequests) backfill(item Item) (Item, bool, error) {
+	if _, ok := item["AcceptedCount"]; ok {
+		return item, false, nil
+	}
+	offerID := stringAttribute(item, "Id")
+	item["AcceptedCount"] = &types.AttributeValueMemberN{Value: strconv.Itoa(a.byOffer[offerID])}
+
+	byPosition := map[string]types.AttributeValue{}
+	if needs, ok := item["PositionNeeds"].(*types.AttributeValueMemberL); ok {
+		for _, need := range needs.Value {
+			need, ok := need.(*types.AttributeValueMemberM)
+			if !ok {
+				continue
+			}
+			position := stringAttribute(need.Value, "Position")
+			if count := a.byPosition[offerID][position]; count > 0 {
+				byPosition[position] = &types.AttributeValueMemberN{Value: strconv.Itoa(count)}
+			}
+		}
+	}
+	if len(byPosition) > 0 {
+		item["AcceptedByPosition"] = &types.AttributeValueMemberM{Value: byPosition}
+	}
+	return item, true, nil
+}
+
+// matchOfferOpenSpots writes the open spots of offers from their needs and accepted
+// counters, the way saving the offer does, so searches filter them in the query.
+func matchOfferOpenSpots(item Item) (Item, bool, error) {
+	var dto matchoffer.Dto
+	if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+		return item, false, err
+	}
+	openSpots, byPosition := matchoffer.OpenSpots(dto.ToDomain())
+	if intPointersEqual(dto.OpenSpots, openSpots) && maps.Equal(dto.OpenSpotsByPosition, byPosition) {
+		return item, false, nil
+	}
+
+	delete(item, "OpenSpots")
+	delete(item, "OpenSpotsByPosition")
+	if openSpots != nil {
+		item["OpenSpots"] = &types.AttributeValueMemberN{Value: strconv.Itoa(*openSpots)}
+	}
+	if len(byPosition) > 0 {
+		value, err := attributevalue.Marshal(byPosition)
+		if err != nil {
+			return item, false, err
+		}
+		item["OpenSpotsByPosition"] = value
+	}
+	return item, true, nil
+}
+
+// teamULID moves teams keyed by SPORT#<sport>#NAME#<name> to a ULID, so renaming them no
+// longer changes their ID, and writes the Name older items only had in their ID.
+func teamULID(item Item) (Item, bool, error) {
+	if !strings.HasPrefix(stringAttribute(item, "Id"), "SPORT#") {
+		return item, false, nil
+	}
+	var dto team.Dto
+	if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+		return item, false, err
+	}
+	item["Id"] = &types.AttributeValueMemberS{Value: ulid.Make().String()}
+	item["Name"] = &types.AttributeValueMemberS{Value: dto.ToDomain().Name}
+	return item, true, nil
+}
+
+// teamNameClaim is the record claiming the name of a migrated team, which team names
+// are kept unique within their sport by.
+func teamNameClaim(migrated Item) ([]Item, error) {
+	var dto team.Dto
+	if err := attributevalue.UnmarshalMap(migrated, &dto); err != nil {
+		return nil, err
+	}
+	claim, err := attributevalue.MarshalMap(team.NameFrom(dto.ToDomain()))
+	if err != nil {
+		return nil, err
+	}
+	return []Item{claim}, nil
+}
+
+// teamSearchName sets the SearchName of teams written before searches, which their search
+// record is keyed by.
+func teamSearchName(item Item) (Item, bool, error) {
+	if _, ok := item["SearchName"]; ok {
+		return item, false, nil
+	}
+	var dto team.Dto
+	if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+		return item, false, err
+	}
+	item["SearchName"] = &types.AttributeValueMemberS{Value: domainteam.SearchName(dto.ToDomain().Name)}
+	return item, true, nil
+}
+
+// teamSearchRecord is the record indexing a migrated team for searches.
+func teamSearchRecord(migrated Item) ([]Item, error) {
+	var dto team.Dto
+	if err := attributevalue.UnmarshalMap(migrated, &dto); err != nil {
+		return nil, err
+	}
+	record, err := attributevalue.MarshalMap(team.SearchFrom(dto.ToDomain()))
+	if err != nil {
+		return nil, err
+	}
+	return []Item{record}, nil
+}
+
+// teamIDs maps the sport, name and owner of every team to its ID, so offers that named
+// their team keep pointing to it after it is renamed.
+type teamIDs struct {
+	byName map[string]string
+}
+
+func (t *teamIDs) collect(ctx context.Context, scan Scan) error {
+	t.byName = map[string]string{}
+	return scan(ctx, team.Partition, func(item Item) error {
+		var dto team.Dto
+		if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+			return err
+		}
+		t.byName[teamNameOf(dto.Sport, dto.Name, dto.OwnerAccountId)] = dto.Id
+		return nil
+	})
+}
+
+// backfill sets the TeamId of offers naming a team of their owner. Offers naming no team,
+// or a team their owner does not have, are left without one.
+func (t *teamIDs) backfill(item Item) (Item, bool, error) {
+	if _, ok := item["TeamId"]; ok {
+		return item, false, nil
+	}
+	id, ok := t.byName[teamNameOf(stringAttribute(item, "Sport"), stringAttribute(item, "TeamName"), stringAttribute(item, "OwnerAccountId"))]
+	if !ok {
+		return item, false, nil
+	}
+	item["TeamId"] = &types.AttributeValueMemberS{Value: id}
+	return item, true, nil
+}
+
+func teamNameOf(sport, name, ownerAccountID string) string {
+	return sport + "#" + name + "#" + ownerAccountID
+}
+
+func intPointersEqual(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// withoutAttribute removes an attribute the items no longer have.
+func withoutAttribute(name string) func(item Item) (Item, bool, error) {
+	return func(item Item) (Item, bool, error) {
+		if _, ok := item[name]; !ok {
+			return item, false, nil
+		}
+		delete(item, name)
+		return item, true, nil
+	}
+}
+
+func floatAttribute(item Item, name string) (float64, bool) {
+	n, ok := item[name].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(n.Value, 64)
+	return value, err == nil
+}
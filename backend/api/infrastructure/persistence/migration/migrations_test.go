@@ -0,0 +1,464 @@
+package migration_test
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/infrastructure/persistence/migration"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	migrations := map[string]migration.Migration{}
+	for _, m := range migration.All() {
+		migrations[m.Version] = m
+	}
+
+	testCases := []struct {
+		name       string
+		version    string
+		item       migration.Item
+		assertions func(t *testing.T, item migration.Item, changed bool, err error)
+	}{
+		{
+			name:    "given an offer with coordinates and no geohash prefix when migrating then sets the prefix",
+			version: "0001",
+			item: migration.Item{
+				"Latitude":  &types.AttributeValueMemberN{Value: "-34.6037"},
+				"Longitude": &types.AttributeValueMemberN{Value: "-58.3816"},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, changed)
+				assert.Equal(t, &types.AttributeValueMemberS{Value: "69y"}, item["GeohashPrefix"])
+			},
+		},
+		{
+			name:    "given an offer with its geohash prefix when migrating then leaves it unchanged",
+			version: "0001",
+			item: migration.Item{
+				"Latitude":      &types.AttributeValueMemberN{Value: "-34.6037"},
+				"Longitude":     &types.AttributeValueMemberN{Value: "-58.3816"},
+				"GeohashPrefix": &types.AttributeValueMemberS{Value: "69y"},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+			},
+		},
+		{
+			name:    "given an offer without coordinates when migrating then leaves it unchanged",
+			version: "0001",
+			item:    migration.Item{"Locality": &types.AttributeValueMemberS{Value: "CABA"}},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+				assert.NotContains(t, item, "GeohashPrefix")
+			},
+		},
+		{
+			name:    "given an offer without capacity when migrating then sets no capacity",
+			version: "0002",
+			item:    migration.Item{},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, changed)
+				assert.Equal(t, &types.AttributeValueMemberN{Value: "0"}, item["Capacity"])
+			},
+		},
+		{
+			name:    "given an offer with capacity when migrating then leaves it unchanged",
+			version: "0002",
+			item:    migration.Item{"Capacity": &types.AttributeValueMemberN{Value: "10"}},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+				assert.Equal(t, &types.AttributeValueMemberN{Value: "10"}, item["Capacity"])
+			},
+		},
+		{
+			name:    "given an offer with the former TTL attribute when migrating then removes it",
+			version: "0003",
+			item: migration.Item{
+				"Id":        &types.AttributeValueMemberS{Value: "offer-1"},
+				"ExpiresAt": &types.AttributeValueMemberN{Value: "1700000000"},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, changed)
+				assert.Equal(t, migration.Item{"Id": &types.AttributeValueMemberS{Value: "offer-1"}}, item)
+			},
+		},
+		{
+			name:    "given an offer without the former TTL attribute when migrating then leaves it unchanged",
+			version: "0003",
+			item:    migration.Item{"Id": &types.AttributeValueMemberS{Value: "offer-1"}},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+			},
+		},
+		{
+			name:    "given an offer that accepted one of its three spots when migrating then sets its open spots",
+			version: "0005",
+			item: migration.Item{
+				"Id":          &types.AttributeValueMemberS{Value: "offer-1"},
+				"SpotsNeeded": &types.AttributeValueMemberN{Value: "3"},
+				"PositionNeeds": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+					&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+						"Position": &types.AttributeValueMemberS{Value: "defender"},
+						"Spots":    &types.AttributeValueMemberN{Value: "2"},
+					}},
+				}},
+				"AcceptedCount": &types.AttributeValueMemberN{Value: "1"},
+				"AcceptedByPosition": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"defender": &types.AttributeValueMemberN{Value: "1"},
+				}},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, changed)
+				assert.Equal(t, &types.AttributeValueMemberN{Value: "2"}, item["OpenSpots"])
+				assert.Equal(t, &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"defender": &types.AttributeValueMemberN{Value: "1"},
+				}}, item["OpenSpotsByPosition"])
+			},
+		},
+		{
+			name:    "given an offer with its open spots when migrating then leaves it unchanged",
+			version: "0005",
+			item: migration.Item{
+				"Id":            &types.AttributeValueMemberS{Value: "offer-1"},
+				"Capacity":      &types.AttributeValueMemberN{Value: "10"},
+				"AcceptedCount": &types.AttributeValueMemberN{Value: "4"},
+				"OpenSpots":     &types.AttributeValueMemberN{Value: "5"},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+			},
+		},
+		{
+			name:    "given an offer without a limit when migrating then leaves it without open spots",
+			version: "0005",
+			item:    migration.Item{"Id": &types.AttributeValueMemberS{Value: "offer-1"}},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+				assert.NotContains(t, item, "OpenSpots")
+			},
+		},
+		{
+			name:    "given a team keyed by its sport and name when migrating then gives it a ULID and its name",
+			version: "0006",
+			item: migration.Item{
+				"EntityId": &types.AttributeValueMemberS{Value: "Entity#Team"},
+				"Id":       &types.AttributeValueMemberS{Value: "SPORT#Football#NAME#Boca Juniors"},
+				"Sport":    &types.AttributeValueMemberS{Value: "Football"},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, changed)
+				assert.Len(t, item["Id"].(*types.AttributeValueMemberS).Value, 26)
+				assert.Equal(t, &types.AttributeValueMemberS{Value: "Boca Juniors"}, item["Name"])
+			},
+		},
+		{
+			name:    "given a team with a ULID when migrating then leaves it unchanged",
+			version: "0006",
+			item: migration.Item{
+				"EntityId": &types.AttributeValueMemberS{Value: "Entity#Team"},
+				"Id":       &types.AttributeValueMemberS{Value: "01JA0000000000000000000001"},
+				"Name":     &types.AttributeValueMemberS{Value: "Boca Juniors"},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+			},
+		},
+		{
+			name:    "given a team without a search name when migrating then folds its name into one",
+			version: "0007",
+			item: migration.Item{
+				"EntityId": &types.AttributeValueMemberS{Value: "Entity#Team"},
+				"Id":       &types.AttributeValueMemberS{Value: "01JA0000000000000000000001"},
+				"Name":     &types.AttributeValueMemberS{Value: "Club Atlético Unión"},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, changed)
+				assert.Equal(t, &types.AttributeValueMemberS{Value: "club atletico union"}, item["SearchName"])
+			},
+		},
+		{
+			name:    "given a team with a search name when migrating then leaves it unchanged",
+			version: "0007",
+			item: migration.Item{
+				"EntityId":   &types.AttributeValueMemberS{Value: "Entity#Team"},
+				"Id":         &types.AttributeValueMemberS{Value: "01JA0000000000000000000001"},
+				"Name":       &types.AttributeValueMemberS{Value: "Boca Juniors"},
+				"SearchName": &types.AttributeValueMemberS{Value: "boca juniors"},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// when
+			item, changed, err := migrations[testCase.version].Rewrite(testCase.item)
+
+			// then
+			testCase.assertions(t, item, changed, err)
+		})
+	}
+}
+
+func TestAll_AcceptedCounters(t *testing.T) {
+	ctx := context.Background()
+	request := func(offerID string, position string, status string) migration.Item {
+		return migration.Item{
+			"MatchOfferId": &types.AttributeValueMemberS{Value: offerID},
+			"Position":     &types.AttributeValueMemberS{Value: position},
+			"Status":       &types.AttributeValueMemberS{Value: status},
+		}
+	}
+	requests := []migration.Item{
+		request("offer-1", "goalkeeper", "ACCEPTED"),
+		request("offer-1", "defender", "ACCEPTED"),
+		request("offer-1", "defender", "PENDING"),
+		request("offer-1", "", "ACCEPTED"),
+		request("offer-2", "", "REJECTED"),
+	}
+	scan := func(ctx context.Context, partition string, each func(item migration.Item) error) error {
+		if partition != "Entity#MatchRequest" {
+			return fmt.Errorf("unexpected partition %s", partition)
+		}
+		for _, item := range requests {
+			if err := each(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	testCases := []struct {
+		name       string
+		item       migration.Item
+		assertions func(t *testing.T, item migration.Item, changed bool, err error)
+	}{
+		{
+			name: "given an offer without counters when migrating then counts its accepted requests for the positions it needs",
+			item: migration.Item{
+				"Id": &types.AttributeValueMemberS{Value: "offer-1"},
+				"PositionNeeds": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+					&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+						"Position": &types.AttributeValueMemberS{Value: "defender"},
+						"Spots":    &types.AttributeValueMemberN{Value: "2"},
+					}},
+				}},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, changed)
+				assert.Equal(t, &types.AttributeValueMemberN{Value: "3"}, item["AcceptedCount"])
+				assert.Equal(t, &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"defender": &types.AttributeValueMemberN{Value: "1"},
+				}}, item["AcceptedByPosition"])
+			},
+		},
+		{
+			name: "given an offer without accepted requests when migrating then sets its count to zero",
+			item: migration.Item{"Id": &types.AttributeValueMemberS{Value: "offer-2"}},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, changed)
+				assert.Equal(t, &types.AttributeValueMemberN{Value: "0"}, item["AcceptedCount"])
+				assert.NotContains(t, item, "AcceptedByPosition")
+			},
+		},
+		{
+			name: "given an offer with counters when migrating then leaves it unchanged",
+			item: migration.Item{
+				"Id":            &types.AttributeValueMemberS{Value: "offer-1"},
+				"AcceptedCount": &types.AttributeValueMemberN{Value: "4"},
+			},
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+				assert.Equal(t, &types.AttributeValueMemberN{Value: "4"}, item["AcceptedCount"])
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			var counters migration.Migration
+			for _, m := range migration.All() {
+				if m.Version == "0004" {
+					counters = m
+				}
+			}
+			assert.NoError(t, counters.Prepare(ctx, scan))
+
+			// when
+			item, changed, err := counters.Rewrite(testCase.item)
+
+			// then
+			testCase.assertions(t, item, changed, err)
+		})
+	}
+}
+
+func TestAll_TeamNameClaim(t *testing.T) {
+	// given
+	var teamIDs migration.Migration
+	for _, m := range migration.All() {
+		if m.Version == "0006" {
+			teamIDs = m
+		}
+	}
+	migrated := migration.Item{
+		"EntityId": &types.AttributeValueMemberS{Value: "Entity#Team"},
+		"Id":       &types.AttributeValueMemberS{Value: "01JA0000000000000000000001"},
+		"Name":     &types.AttributeValueMemberS{Value: "Boca Juniors"},
+		"Sport":    &types.AttributeValueMemberS{Value: "Football"},
+	}
+
+	// when
+	companions, err := teamIDs.Companions(migrated)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []migration.Item{{
+		"EntityId": &types.AttributeValueMemberS{Value: "Entity#TeamName"},
+		"Id":       &types.AttributeValueMemberS{Value: "SPORT#Football#NAME#Boca Juniors"},
+		"TeamId":   &types.AttributeValueMemberS{Value: "01JA0000000000000000000001"},
+	}}, companions)
+}
+
+func TestAll_TeamSearchRecord(t *testing.T) {
+	// given
+	var teamSearch migration.Migration
+	for _, m := range migration.All() {
+		if m.Version == "0007" {
+			teamSearch = m
+		}
+	}
+	migrated := migration.Item{
+		"EntityId":          &types.AttributeValueMemberS{Value: "Entity#Team"},
+		"Id":                &types.AttributeValueMemberS{Value: "01JA0000000000000000000001"},
+		"Name":              &types.AttributeValueMemberS{Value: "Boca Juniors"},
+		"Sport":             &types.AttributeValueMemberS{Value: "Football"},
+		"Category":          &types.AttributeValueMemberN{Value: "3"},
+		"SearchName":        &types.AttributeValueMemberS{Value: "boca juniors"},
+		"LookingForPlayers": &types.AttributeValueMemberBOOL{Value: false},
+	}
+
+	// when
+	companions, err := teamSearch.Companions(migrated)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []migration.Item{{
+		"EntityId":          &types.AttributeValueMemberS{Value: "Entity#TeamSearch"},
+		"Id":                &types.AttributeValueMemberS{Value: "SPORT#Football#boca juniors#01JA0000000000000000000001"},
+		"TeamId":            &types.AttributeValueMemberS{Value: "01JA0000000000000000000001"},
+		"SearchName":        &types.AttributeValueMemberS{Value: "boca juniors"},
+		"Category":          &types.AttributeValueMemberN{Value: "3"},
+		"LookingForPlayers": &types.AttributeValueMemberBOOL{Value: false},
+	}}, companions)
+}
+
+func TestAll_OfferTeamIDs(t *testing.T) {
+	ctx := context.Background()
+	teams := []migration.Item{{
+		"EntityId":       &types.AttributeValueMemberS{Value: "Entity#Team"},
+		"Id":             &types.AttributeValueMemberS{Value: "01JA0000000000000000000001"},
+		"Name":           &types.AttributeValueMemberS{Value: "Boca Juniors"},
+		"Sport":          &types.AttributeValueMemberS{Value: "Football"},
+		"OwnerAccountId": &types.AttributeValueMemberS{Value: "owner-1"},
+	}}
+	scan := func(ctx context.Context, partition string, each func(item migration.Item) error) error {
+		if partition != "Entity#Team" {
+			return fmt.Errorf("unexpected partition %s", partition)
+		}
+		for _, item := range teams {
+			if err := each(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	offer := func(teamName, owner string) migration.Item {
+		return migration.Item{
+			"Id":             &types.AttributeValueMemberS{Value: "offer-1"},
+			"TeamName":       &types.AttributeValueMemberS{Value: teamName},
+			"Sport":          &types.AttributeValueMemberS{Value: "Football"},
+			"OwnerAccountId": &types.AttributeValueMemberS{Value: owner},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		item       migration.Item
+		assertions func(t *testing.T, item migration.Item, changed bool, err error)
+	}{
+		{
+			name: "given an offer naming a team of its owner when migrating then points it to the team ID",
+			item: offer("Boca Juniors", "owner-1"),
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.True(t, changed)
+				assert.Equal(t, &types.AttributeValueMemberS{Value: "01JA0000000000000000000001"}, item["TeamId"])
+			},
+		},
+		{
+			name: "given an offer naming a team its owner does not have when migrating then leaves it without a team",
+			item: offer("Boca Juniors", "owner-2"),
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+				assert.NotContains(t, item, "TeamId")
+			},
+		},
+		{
+			name: "given an offer with a team ID when migrating then leaves it unchanged",
+			item: func() migration.Item {
+				item := offer("Boca Juniors", "owner-1")
+				item["TeamId"] = &types.AttributeValueMemberS{Value: "01JA0000000000000000000002"}
+				return item
+			}(),
+			assertions: func(t *testing.T, item migration.Item, changed bool, err error) {
+				assert.NoError(t, err)
+				assert.False(t, changed)
+				assert.Equal(t, &types.AttributeValueMemberS{Value: "01JA0000000000000000000002"}, item["TeamId"])
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			var teamIDs migration.Migration
+			for _, m := range migration.All() {
+				if m.Version == "0008" {
+					teamIDs = m
+				}
+			}
+			assert.NoError(t, teamIDs.Prepare(ctx, scan))
+
+			// when
+			item, changed, err := teamIDs.Rewrite(testCase.item)
+
+			// then
+			testCase.assertions(t, item, changed, err)
+		})
+	}
+}
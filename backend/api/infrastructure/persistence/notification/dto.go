@@ -0,0 +1,128 @@
+package notification
+
+import (
+	"sportlink/api/domain/notification"
+	"time"
+)
+
+const preferencesEntityID = "Entity#NotificationPreferences"
+
+// inboxEntityID is the partition holding the notifications of one account
+func inboxEntityID(accountID string) string {
+	return "Entity#Notification#" + accountID
+}
+
+// Dto is one notification. The notifications of an account share a partition and
+// sort by their ULID, so the inbox is read newest first by querying backwards.
+type Dto struct {
+	EntityId  string            `dynamodbav:"EntityId"` // "Entity#Notification#<accountId>"
+	Id        string            `dynamodbav:"Id"`       // "<ulid>" of the event that raised it
+	AccountId string            `dynamodbav:"AccountId"`
+	Type      string            `dynamodbav:"Type"`
+	Data      map[string]string `dynamodbav:"Data,omitempty"`
+	CreatedAt int64             `dynamodbav:"CreatedAt"`        // Unix timestamp in milliseconds
+	ReadAt    *int64            `dynamodbav:"ReadAt,omitempty"` // Unix timestamp in milliseconds; absent while unread
+}
+
+// PreferencesDto stores the notification types and channels an account turned off
+// and how it is reached off-app.
+type PreferencesDto struct {
+	EntityId         string         `dynamodbav:"EntityId"` // "Entity#NotificationPreferences"
+	Id               string         `dynamodbav:"Id"`       // "<accountId>"
+	Disabled         []string       `dynamodbav:"Disabled"`
+	DisabledChannels []string       `dynamodbav:"DisabledChannels,omitempty"`
+	QuietHours       *QuietHoursDto `dynamodbav:"QuietHours,omitempty"`
+	Locale           string         `dynamodbav:"Locale,omitempty"`
+	PushTokens       []string       `dynamodbav:"PushTokens,omitempty"`
+}
+
+type QuietHoursDto struct {
+	Start    int    `dynamodbav:"Start"` // minutes after midnight
+	End      int    `dynamodbav:"End"`   // minutes after midnight
+	TimeZone string `dynamodbav:"TimeZone"`
+}
+
+func (d *Dto) ToDomain() notification.Entity {
+	entity := notification.Entity{
+		ID:        d.Id,
+		AccountID: d.AccountId,
+		Type:      notification.Type(d.Type),
+		Data:      d.Data,
+		CreatedAt: time.UnixMilli(d.CreatedAt).UTC(),
+	}
+	if d.ReadAt != nil {
+		readAt := time.UnixMilli(*d.ReadAt).UTC()
+		entity.ReadAt = &readAt
+	}
+	return entity
+}
+
+func From(entity notification.Entity) Dto {
+	dto := Dto{
+		EntityId:  inboxEntityID(entity.AccountID),
+		Id:        entity.ID,
+		AccountId: entity.AccountID,
+		Type:      entity.Type.String(),
+		Data:      entity.Data,
+		CreatedAt: entity.CreatedAt.UnixMilli(),
+	}
+	if entity.ReadAt != nil {
+		readAt := entity.ReadAt.UnixMilli()
+		dto.ReadAt = &readAt
+	}
+	return dto
+}
+
+func (d *PreferencesDto) ToDomain() notification.Preferences {
+	preferences := notification.NewPreferences(d.Id)
+	for _, t := range d.Disabled {
+		preferences.Disabled[notification.Type(t)] = true
+	}
+	for _, c := range d.DisabledChannels {
+		preferences.DisabledChannels[notification.Channel(c)] = true
+	}
+	if d.QuietHours != nil {
+		preferences.QuietHours = &notification.QuietHours{
+			Start:    d.QuietHours.Start,
+			End:      d.QuietHours.End,
+			TimeZone: d.QuietHours.TimeZone,
+		}
+	}
+	if d.Locale != "" {
+		preferences.Locale = d.Locale
+	}
+	preferences.PushTokens = d.PushTokens
+	return preferences
+}
+
+func FromPreferences(preferences notification.Preferences) PreferencesDto {
+	disabled := make([]string, 0, len(preferences.Disabled))
+	for _, t := range notification.Types() {
+		if !preferences.Allows(t) {
+			disabled = append(disabled, t.String())
+		}
+	}
+	disabledChannels := make([]string, 0, len(preferences.DisabledChannels))
+	for _, c := range notification.Channels() {
+		if !preferences.AllowsChannel(c) {
+			disabledChannels = append(disabledChannels, c.String())
+		}
+	}
+
+	dto := PreferencesDto{
+		EntityId:         preferencesEntityID,
+		Id:               preferences.AccountID,
+		Disabled:         disabled,
+		DisabledChannels: disabledChannels,
+		Locale:           preferences.Locale,
+		PushTokens:       preferences.PushTokens,
+	}
+	if preferences.QuietHours != nil {
+		dto.QuietHours = &QuietHoursDto{
+			Start:    preferences.QuietHours.Start,
+			End:      preferences.QuietHours.End,
+			TimeZone: preferences.QuietHours.TimeZone,
+		}
+	}
+	return dto
+}
@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/notification"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+type PreferencesRepositoryAdapter struct {
+	dbClient  DynamoDBClientInterface
+	tableName string
+}
+
+func NewPreferencesRepository(client *dynamodb.Client, tableName string) notification.PreferencesRepository {
+	return &PreferencesRepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func NewPreferencesRepositoryWithInterface(client DynamoDBClientInterface, tableName string) notification.PreferencesRepository {
+	return &PreferencesRepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func (repo *PreferencesRepositoryAdapter) Find(ctx context.Context, accountID string) (notification.Preferences, error) {
+	key, err := attributevalue.MarshalMap(map[string]string{
+		"EntityId": preferencesEntityID,
+		"Id":       accountID,
+	})
+	if err != nil {
+		return notification.Preferences{}, err
+	}
+
+	resp, err := repo.dbClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(repo.tableName),
+		Key:       key,
+	})
+	if err != nil {
+		return notification.Preferences{}, err
+	}
+	if resp.Item == nil {
+		return notification.NewPreferences(accountID), nil
+	}
+
+	var dto PreferencesDto
+	if err := attributevalue.UnmarshalMap(resp.Item, &dto); err != nil {
+		return notification.Preferences{}, fmt.Errorf("failed to unmarshal notification preferences: %w", err)
+	}
+	return dto.ToDomain(), nil
+}
+
+func (repo *PreferencesRepositoryAdapter) Save(ctx context.Context, preferences notification.Preferences) error {
+	av, err := attributevalue.MarshalMap(FromPreferences(preferences))
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification preferences: %w", err)
+	}
+	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(repo.tableName),
+		Item:      av,
+	})
+	return err
+}
@@ -0,0 +1,187 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	ioutbox "sportlink/api/infrastructure/persistence/outbox"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBClientInterface defines the interface for DynamoDB operations needed by the repositories
+type DynamoDBClientInterface interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+}
+
+// batchWriteMaxItems is the most items DynamoDB accepts in a single BatchWriteItem call.
+const batchWriteMaxItems = 25
+
+type RepositoryAdapter struct {
+	dbClient  DynamoDBClientInterface
+	tableName string
+}
+
+func NewRepository(client *dynamodb.Client, tableName string) notification.Repository {
+	return &RepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func NewRepositoryWithInterface(client DynamoDBClientInterface, tableName string) notification.Repository {
+	return &RepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func (repo *RepositoryAdapter) Save(ctx context.Context, entity notification.Entity, messages []outbox.Message) error {
+	av, err := attributevalue.MarshalMap(From(entity))
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification %s: %w", entity.ID, err)
+	}
+	if len(messages) == 0 {
+		_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName: aws.String(repo.tableName),
+			Item:      av,
+		})
+		return err
+	}
+	messageItems, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append([]types.TransactWriteItem{{
+			Put: &types.Put{TableName: aws.String(repo.tableName), Item: av},
+		}}, messageItems...),
+	})
+	return err
+}
+
+func (repo *RepositoryAdapter) SaveAll(ctx context.Context, entities []notification.Entity) error {
+	for i := 0; i < len(entities); i += batchWriteMaxItems {
+		chunk := entities[i:min(i+batchWriteMaxItems, len(entities))]
+
+		requests := make([]types.WriteRequest, 0, len(chunk))
+		for _, entity := range chunk {
+			av, err := attributevalue.MarshalMap(From(entity))
+			if err != nil {
+				return fmt.Errorf("failed to marshal notification %s: %w", entity.ID, err)
+			}
+			requests = append(requests, types.WriteRequest{
+				PutRequest: &types.PutRequest{Item: av},
+			})
+		}
+
+		_, err := repo.dbClient.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{
+				repo.tableName: requests,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to batch write notifications: %w", err)
+		}
+	}
+	return nil
+}
+
+// Find reads the inbox partition backwards, starting right after the cursor. One more
+// notification than requested is read to know whether another page follows; DynamoDB
+// applies the unread filter after its own page limit, hence the loop.
+func (repo *RepositoryAdapter) Find(ctx context.Context, query notification.DomainQuery) (notification.Page, error) {
+	builder := expression.NewBuilder().WithKeyCondition(
+		expression.KeyEqual(expression.Key("EntityId"), expression.Value(inboxEntityID(query.AccountID))),
+	)
+	if query.UnreadOnly {
+		builder = builder.WithFilter(expression.AttributeNotExists(expression.Name("ReadAt")))
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return notification.Page{}, err
+	}
+
+	var lastKey map[string]types.AttributeValue
+	if query.Cursor != "" {
+		lastKey, err = attributevalue.MarshalMap(map[string]string{
+			"EntityId": inboxEntityID(query.AccountID),
+			"Id":       query.Cursor,
+		})
+		if err != nil {
+			return notification.Page{}, err
+		}
+	}
+
+	entities := make([]notification.Entity, 0, query.Limit+1)
+	for len(entities) <= query.Limit {
+		resp, err := repo.dbClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(repo.tableName),
+			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ExclusiveStartKey:         lastKey,
+			ScanIndexForward:          aws.Bool(false),
+			Limit:                     aws.Int32(int32(query.Limit + 1 - len(entities))),
+		})
+		if err != nil {
+			return notification.Page{}, err
+		}
+		for _, item := range resp.Items {
+			var dto Dto
+			if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+				return notification.Page{}, fmt.Errorf("failed to unmarshal notification: %w", err)
+			}
+			entities = append(entities, dto.ToDomain())
+		}
+		if resp.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = resp.LastEvaluatedKey
+	}
+
+	page := notification.Page{Entities: entities}
+	if len(entities) > query.Limit {
+		page.Entities = entities[:query.Limit]
+		page.NextCursor = page.Entities[query.Limit-1].ID
+	}
+	return page, nil
+}
+
+func (repo *RepositoryAdapter) FindByID(ctx context.Context, accountID, notificationID string) (*notification.Entity, error) {
+	key, err := attributevalue.MarshalMap(map[string]string{
+		"EntityId": inboxEntityID(accountID),
+		"Id":       notificationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := repo.dbClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(repo.tableName),
+		Key:       key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Item == nil {
+		return nil, nil
+	}
+
+	var dto Dto
+	if err := attributevalue.UnmarshalMap(resp.Item, &dto); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+	entity := dto.ToDomain()
+	return &entity, nil
+}
@@ -0,0 +1,76 @@
+package outbox
+
+import (
+	"sportlink/api/domain/outbox"
+	"time"
+)
+
+const (
+	messageEntityID = "Entity#OutboxMessage"
+	processedTTL    = 14 * 24 * time.Hour // longer than any broker redelivery window
+)
+
+func processedEntityID(consumer string) string {
+	return "Entity#ProcessedMessage#" + consumer
+}
+
+// Dto is an outbox message still waiting to be handed over to the broker. All
+// messages share one partition and sort by their ULID, so the oldest come first.
+type Dto struct {
+	EntityId      string `dynamodbav:"EntityId"` // "Entity#OutboxMessage"
+	Id            string `dynamodbav:"Id"`       // "<ulid>"
+	Type          string `dynamodbav:"Type"`
+	EventVersion  int    `dynamodbav:"EventVersion"` // schema version of the payload
+	Payload       string `dynamodbav:"Payload"`      // JSON body of the event
+	OccurredAt    int64  `dynamodbav:"OccurredAt"`   // Unix timestamp in milliseconds
+	Actor         string `dynamodbav:"Actor,omitempty"`
+	CorrelationId string `dynamodbav:"CorrelationId,omitempty"`
+	Status        string `dynamodbav:"Status"` // PENDING, FAILED
+	Attempts      int    `dynamodbav:"Attempts"`
+	NextAttemptAt int64  `dynamodbav:"NextAttemptAt"` // Unix timestamp in milliseconds
+	LastError     string `dynamodbav:"LastError,omitempty"`
+}
+
+// ProcessedDto records that a consumer handled a message. DynamoDB drops it after PurgeAt.
+// The TTL attribute has its own name because match offers not written since migration 0003
+// ran may still carry the ExpiresAt they used to be deleted after.
+type ProcessedDto struct {
+	EntityId string `dynamodbav:"EntityId"` // "Entity#ProcessedMessage#<consumer>"
+	Id       string `dynamodbav:"Id"`       // message ID
+	PurgeAt  int64  `dynamodbav:"PurgeAt"`  // Unix timestamp, TTL attribute
+}
+
+func (d *Dto) ToDomain() outbox.Message {
+	status, _ := outbox.ParseStatus(d.Status)
+
+	return outbox.Message{
+		ID:            d.Id,
+		Type:          d.Type,
+		Version:       d.EventVersion,
+		Payload:       []byte(d.Payload),
+		OccurredAt:    time.UnixMilli(d.OccurredAt).UTC(),
+		Actor:         d.Actor,
+		CorrelationID: d.CorrelationId,
+		Status:        status,
+		Attempts:      d.Attempts,
+		NextAttemptAt: time.UnixMilli(d.NextAttemptAt).UTC(),
+		LastError:     d.LastError,
+	}
+}
+
+func From(message outbox.Message) Dto {
+	return Dto{
+		EntityId:      messageEntityID,
+		Id:            message.ID,
+		Type:          message.Type,
+		EventVersion:  message.Version,
+		Payload:       string(message.Payload),
+		OccurredAt:    message.OccurredAt.UnixMilli(),
+		Actor:         message.Actor,
+		CorrelationId: message.CorrelationID,
+		Status:        message.Status.String(),
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt.UnixMilli(),
+		LastError:     message.LastError,
+	}
+}
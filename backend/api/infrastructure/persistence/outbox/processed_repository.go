@@ -0,0 +1,64 @@
+package outbox
+
+import (
+	"context"
+	"sportlink/api/domain/outbox"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+type ProcessedRepositoryAdapter struct {
+	dbClient  DynamoDBClientInterface
+	tableName string
+}
+
+func NewProcessedRepository(client *dynamodb.Client, tableName string) outbox.ProcessedRepository {
+	return &ProcessedRepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func NewProcessedRepositoryWithInterface(client DynamoDBClientInterface, tableName string) outbox.ProcessedRepository {
+	return &ProcessedRepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func (repo *ProcessedRepositoryAdapter) IsProcessed(ctx context.Context, consumer, messageID string) (bool, error) {
+	key, err := attributevalue.MarshalMap(map[string]string{
+		"EntityId": processedEntityID(consumer),
+		"Id":       messageID,
+	})
+	if err != nil {
+		return false, err
+	}
+	resp, err := repo.dbClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(repo.tableName),
+		Key:       key,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Item != nil, nil
+}
+
+func (repo *ProcessedRepositoryAdapter) MarkProcessed(ctx context.Context, consumer, messageID string) error {
+	av, err := attributevalue.MarshalMap(ProcessedDto{
+		EntityId: processedEntityID(consumer),
+		Id:       messageID,
+		PurgeAt:  time.Now().Add(processedTTL).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(repo.tableName),
+		Item:      av,
+	})
+	return err
+}
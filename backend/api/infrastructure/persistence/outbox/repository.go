@@ -0,0 +1,142 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/outbox"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBClientInterface defines the interface for DynamoDB operations needed by the repositories
+type DynamoDBClientInterface interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+}
+
+type RepositoryAdapter struct {
+	dbClient  DynamoDBClientInterface
+	tableName string
+}
+
+func NewRepository(client *dynamodb.Client, tableName string) outbox.Repository {
+	return &RepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+func NewRepositoryWithInterface(client DynamoDBClientInterface, tableName string) outbox.Repository {
+	return &RepositoryAdapter{
+		dbClient:  client,
+		tableName: tableName,
+	}
+}
+
+// BuildPut returns the transactional put that adds message to the outbox, so other
+// repositories can write it together with the entity change that raised it.
+func BuildPut(tableName string, message outbox.Message) (*types.Put, error) {
+	av, err := attributevalue.MarshalMap(From(message))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal outbox message %s: %w", message.ID, err)
+	}
+	return &types.Put{
+		TableName: aws.String(tableName),
+		Item:      av,
+	}, nil
+}
+
+// BuildPuts returns the transactional puts of BuildPut for each message, in order.
+func BuildPuts(tableName string, messages []outbox.Message) ([]types.TransactWriteItem, error) {
+	items := make([]types.TransactWriteItem, 0, len(messages))
+	for _, message := range messages {
+		put, err := BuildPut(tableName, message)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, types.TransactWriteItem{Put: put})
+	}
+	return items, nil
+}
+
+func (repo *RepositoryAdapter) Save(ctx context.Context, message outbox.Message) error {
+	put, err := BuildPut(repo.tableName, message)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: put.TableName,
+		Item:      put.Item,
+	})
+	return err
+}
+
+func (repo *RepositoryAdapter) Delete(ctx context.Context, messageID string) error {
+	key, err := attributevalue.MarshalMap(map[string]string{
+		"EntityId": messageEntityID,
+		"Id":       messageID,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = repo.dbClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.tableName),
+		Key:       key,
+	})
+	return err
+}
+
+// FindDue queries the outbox partition in ID order and keeps the pending messages
+// whose next attempt is not in the future.
+func (repo *RepositoryAdapter) FindDue(ctx context.Context, now time.Time, limit int) ([]outbox.Message, error) {
+	keyCond := expression.KeyEqual(expression.Key("EntityId"), expression.Value(messageEntityID))
+	filter := expression.Name("Status").Equal(expression.Value(outbox.StatusPending.String())).
+		And(expression.Name("NextAttemptAt").LessThanEqual(expression.Value(now.UnixMilli())))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).WithFilter(filter).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]outbox.Message, 0, limit)
+	var lastKey map[string]types.AttributeValue
+	for len(messages) < limit {
+		input := &dynamodb.QueryInput{
+			TableName:                 aws.String(repo.tableName),
+			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		}
+		if lastKey != nil {
+			input.ExclusiveStartKey = lastKey
+		}
+		resp, err := repo.dbClient.Query(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Items {
+			var dto Dto
+			if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal outbox message: %w", err)
+			}
+			messages = append(messages, dto.ToDomain())
+		}
+		if resp.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = resp.LastEvaluatedKey
+	}
+
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
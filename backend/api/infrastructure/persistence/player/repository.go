@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"slices"
 	"sportlink/api/domain/player"
 )
 
@@ -75,6 +76,10 @@ func (repo *DynamoDBRepository) Find(ctx context.Context, query player.DomainQue
 		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal item: %w", err)
 		}
+		// Id is the sort key, which filter expressions cannot reference
+		if len(query.Ids) > 0 && !slices.Contains(query.Ids, entity.ID) {
+			continue
+		}
 		results = append(results, entity)
 	}
 
@@ -82,26 +87,21 @@ func (repo *DynamoDBRepository) Find(ctx context.Context, query player.DomainQue
 }
 
 func includeFilters(query player.DomainQuery, builder expression.Builder) expression.Builder {
-	if query.Category != 0 || query.Sport != "" {
-		var filter expression.ConditionBuilder
-		filtersInitialized := false
-
-		if query.Category != 0 {
-			categoryFilter := expression.Name("Category").Equal(expression.Value(int(query.Category)))
-			filter = categoryFilter
-			filtersInitialized = true
-		}
+	var filters []expression.ConditionBuilder
 
-		if query.Sport != "" {
-			sportFilter := expression.Name("Sport").Equal(expression.Value(query.Sport))
-			if filtersInitialized {
-				filter = expression.And(filter, sportFilter)
-			} else {
-				filter = sportFilter
-			}
-		}
+	if query.Category != 0 {
+		filters = append(filters, expression.Name("Category").Equal(expression.Value(int(query.Category))))
+	}
+	if query.Sport != "" {
+		filters = append(filters, expression.Name("Sport").Equal(expression.Value(query.Sport)))
+	}
 
-		builder = builder.WithFilter(filter)
+	if len(filters) == 0 {
+		return builder
+	}
+	filter := filters[0]
+	for _, f := range filters[1:] {
+		filter = expression.And(filter, f)
 	}
-	return builder
+	return builder.WithFilter(filter)
 }
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/account"
+	"sportlink/api/domain/outbox"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AccountRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAccountRepository(pool *pgxpool.Pool) account.Repository {
+	return &AccountRepository{pool: pool}
+}
+
+// Save writes the account under the ID derived from its email, like the DynamoDB backend,
+// with the outbox messages in the same transaction.
+func (repo *AccountRepository) Save(ctx context.Context, entity account.Entity, messages []outbox.Message) error {
+	if entity.Email == "" {
+		return fmt.Errorf("email could not be empty")
+	}
+	entity.ID = account.GenerateAccountID(entity.Email)
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		err := row{
+			table:   "accounts",
+			key:     1,
+			columns: []string{"id", "account_id", "email", "nickname", "document"},
+			values:  []any{entity.ID, entity.AccountID, entity.Email, entity.Nickname, entity},
+		}.upsert(ctx, tx)
+		if err != nil {
+			return err
+		}
+		return putMessages(ctx, tx, messages)
+	})
+}
+
+// Find returns the accounts matching every criterion of the query in ID order. Accounts
+// are looked up by Ids, Emails or AccountIDs, so a query without any of them returns no
+// accounts; Emails and Ids cannot be combined.
+func (repo *AccountRepository) Find(ctx context.Context, query account.DomainQuery) ([]account.Entity, error) {
+	if len(query.Emails) > 0 && len(query.Ids) > 0 {
+		return []account.Entity{}, fmt.Errorf("cannot use both Emails and Ids in query")
+	}
+	if len(query.Emails) == 0 && len(query.Ids) == 0 && len(query.AccountIDs) == 0 {
+		return []account.Entity{}, nil
+	}
+
+	var f filter
+	if len(query.Ids) > 0 {
+		f.where("id = ANY(" + f.arg(query.Ids) + ")")
+	}
+	if len(query.AccountIDs) > 0 {
+		f.where("account_id = ANY(" + f.arg(query.AccountIDs) + ")")
+	}
+	if len(query.Emails) > 0 {
+		f.where("email = ANY(" + f.arg(query.Emails) + ")")
+	}
+	if len(query.Nicknames) > 0 {
+		f.where("nickname = ANY(" + f.arg(query.Nicknames) + ")")
+	}
+	rows, err := repo.pool.Query(ctx, "SELECT document FROM accounts"+f.String()+" ORDER BY id", f.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find accounts: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[account.Entity])
+}
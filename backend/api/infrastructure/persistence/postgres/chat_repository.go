@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/chat"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ChatRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewChatRepository(pool *pgxpool.Pool) chat.Repository {
+	return &ChatRepository{pool: pool}
+}
+
+func (repo *ChatRepository) Save(ctx context.Context, message chat.Message) error {
+	return row{
+		table:   "chat_messages",
+		key:     2,
+		columns: []string{"thread_key", "id", "document"},
+		values:  []any{message.Thread.Key(), message.ID, message},
+	}.upsert(ctx, repo.pool)
+}
+
+// Find returns the thread newest message first, starting right after the cursor. One
+// more message than requested is read to know whether older ones follow.
+func (repo *ChatRepository) Find(ctx context.Context, query chat.DomainQuery) (chat.Page, error) {
+	var f filter
+	f.where("thread_key = " + f.arg(query.Thread.Key()))
+	if query.Cursor != "" {
+		f.where("id < " + f.arg(query.Cursor))
+	}
+	rows, err := repo.pool.Query(ctx,
+		"SELECT document FROM chat_messages"+f.String()+" ORDER BY id DESC LIMIT "+f.arg(query.Limit+1), f.args...)
+	if err != nil {
+		return chat.Page{}, fmt.Errorf("failed to find chat messages: %w", err)
+	}
+	messages, err := pgx.CollectRows(rows, pgx.RowTo[chat.Message])
+	if err != nil {
+		return chat.Page{}, err
+	}
+
+	page := chat.Page{Entities: messages}
+	if len(messages) > query.Limit {
+		page.Entities = messages[:query.Limit]
+		page.NextCursor = page.Entities[query.Limit-1].ID
+	}
+	return page, nil
+}
+
+func (repo *ChatRepository) SaveReceipt(ctx context.Context, receipt chat.Receipt) error {
+	return row{
+		table:   "chat_receipts",
+		key:     2,
+		columns: []string{"thread_key", "account_id", "document"},
+		values:  []any{receipt.Thread.Key(), receipt.AccountID, receipt},
+	}.upsert(ctx, repo.pool)
+}
+
+// FindReceipts returns the receipts of the thread ordered by account.
+func (repo *ChatRepository) FindReceipts(ctx context.Context, thread chat.Thread) ([]chat.Receipt, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT document FROM chat_receipts WHERE thread_key = $1 ORDER BY account_id", thread.Key())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find chat receipts: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[chat.Receipt])
+}
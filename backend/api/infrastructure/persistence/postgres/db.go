@@ -0,0 +1,196 @@
+// Package postgres keeps the entities in PostgreSQL with PostGIS. Each entity is stored as
+// a jsonb document next to the columns it is looked up, filtered and sorted by, and the
+// writes spanning several entities run in one transaction.
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/outbox"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// querier runs statements on the pool, or on the transaction of a write spanning several
+// entities.
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// row is an entity as a table stores it. The key columns come first in columns.
+type row struct {
+	table   string
+	key     int // how many of the columns make up the primary key
+	columns []string
+	values  []any
+}
+
+// upsert inserts the row, or overwrites the one stored under its key.
+func (r row) upsert(ctx context.Context, q querier) error {
+	if _, err := q.Exec(ctx, r.insertSQL(""), r.values...); err != nil {
+		return fmt.Errorf("failed to write %s: %w", r.table, err)
+	}
+	return nil
+}
+
+// writeAtVersion writes the row at version read+1, mirroring the optimistic lock of the
+// other backends: rows read at a version are only overwritten while the stored one is
+// still at it, rows read without a version only while the stored one lacks one too, so
+// two first writers cannot both win. It reports whether the row was written.
+func (r row) writeAtVersion(ctx context.Context, q querier, read int) (bool, error) {
+	r = r.withVersion(read + 1)
+	if read == 0 {
+		return r.exec(ctx, q, r.insertSQL(fmt.Sprintf("WHERE %s.version = 0", r.table)), r.values...)
+	}
+	return r.exec(ctx, q, r.updateSQL("version"), append(r.values, read)...)
+}
+
+func (r row) withVersion(version int) row {
+	r.columns = append(r.columns[:len(r.columns):len(r.columns)], "version")
+	r.values = append(r.values[:len(r.values):len(r.values)], version)
+	return r
+}
+
+func (r row) exec(ctx context.Context, q querier, sql string, args ...any) (bool, error) {
+	tag, err := q.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", r.table, err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// uniqueViolation is the SQLSTATE of writes rejected by a unique constraint.
+const uniqueViolation = "23505"
+
+// isUniqueViolation reports whether a write was rejected by the unique constraint.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == constraint
+}
+
+// insert writes the row unless one is stored under its key. It reports whether the row
+// was written.
+func (r row) insert(ctx context.Context, q querier) (bool, error) {
+	return r.exec(ctx, q, r.valuesSQL()+" ON CONFLICT DO NOTHING", r.values...)
+}
+
+func (r row) valuesSQL() string {
+	placeholders := make([]string, len(r.columns))
+	for i := range r.columns {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", r.table, strings.Join(r.columns, ", "), strings.Join(placeholders, ", "))
+}
+
+// insertSQL inserts the row and overwrites the stored one, when condition holds for it.
+func (r row) insertSQL(condition string) string {
+	sql := r.valuesSQL() + fmt.Sprintf(" ON CONFLICT (%s) ", strings.Join(r.columns[:r.key], ", "))
+	if len(r.columns) == r.key {
+		return sql + "DO NOTHING"
+	}
+	sets := make([]string, 0, len(r.columns)-r.key)
+	for _, column := range r.columns[r.key:] {
+		sets = append(sets, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+	}
+	return sql + "DO UPDATE SET " + strings.Join(sets, ", ") + " " + condition
+}
+
+// updateSQL overwrites the stored row while its guard column, e.g. its version, holds the
+// value given as the last argument.
+func (r row) updateSQL(guard string) string {
+	sets := make([]string, 0, len(r.columns)-r.key)
+	for i, column := range r.columns[r.key:] {
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, r.key+i+1))
+	}
+	keys := make([]string, 0, r.key+1)
+	for i, column := range r.columns[:r.key] {
+		keys = append(keys, fmt.Sprintf("%s = $%d", column, i+1))
+	}
+	keys = append(keys, fmt.Sprintf("%s = $%d", guard, len(r.columns)+1))
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s", r.table, strings.Join(sets, ", "), strings.Join(keys, " AND "))
+}
+
+// filter collects the conditions of a WHERE clause and the arguments they refer to.
+type filter struct {
+	conditions []string
+	args       []any
+}
+
+// arg adds an argument and returns its placeholder.
+func (f *filter) arg(value any) string {
+	f.args = append(f.args, value)
+	return fmt.Sprintf("$%d", len(f.args))
+}
+
+func (f *filter) where(condition string) {
+	f.conditions = append(f.conditions, condition)
+}
+
+func (f *filter) String() string {
+	if len(f.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(f.conditions, " AND ")
+}
+
+// appendHistory records the status changes of an entity, stamped with the origin stored
+// in ctx.
+func appendHistory(ctx context.Context, q querier, kind history.Kind, entityID string, changes []common.StatusChange) error {
+	for _, entry := range history.NewEntries(history.OriginOf(ctx), kind, entityID, changes, time.Now()) {
+		r := row{
+			table:   "status_history",
+			key:     3,
+			columns: []string{"kind", "entity_id", "id", "document"},
+			values:  []any{string(entry.Kind), entry.EntityID, entry.ID, entry},
+		}
+		if err := r.upsert(ctx, q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putMessages writes outbox messages, inserting them or updating their delivery state.
+func putMessages(ctx context.Context, q querier, messages []outbox.Message) error {
+	for _, message := range messages {
+		r := row{
+			table:   "outbox_messages",
+			key:     1,
+			columns: []string{"id", "status", "next_attempt_at", "document"},
+			values:  []any{message.ID, message.Status.String(), message.NextAttemptAt, message},
+		}
+		if err := r.upsert(ctx, q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// texts converts values of a string type, so they are sent as a text array.
+func texts[T ~string](values []T) []string {
+	if values == nil {
+		return nil
+	}
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = string(value)
+	}
+	return result
+}
+
+// integers converts categories, so they are sent as an integer array.
+func integers(categories []common.Category) []int32 {
+	result := make([]int32, len(categories))
+	for i, category := range categories {
+		result[i] = int32(category)
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/history"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HistoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewHistoryRepository(pool *pgxpool.Pool) history.Repository {
+	return &HistoryRepository{pool: pool}
+}
+
+// Find returns the entries of the entity in the order they were written.
+func (repo *HistoryRepository) Find(ctx context.Context, kind history.Kind, entityID string) ([]history.Entry, error) {
+	rows, err := repo.pool.Query(ctx,
+		"SELECT document FROM status_history WHERE kind = $1 AND entity_id = $2 ORDER BY id", string(kind), entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find history of %s %s: %w", kind, entityID, err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[history.Entry])
+}
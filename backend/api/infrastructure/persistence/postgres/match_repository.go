@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const matchColumns = "matches.document, matches.version"
+
+type MatchRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMatchRepository(pool *pgxpool.Pool) match.Repository {
+	return &MatchRepository{pool: pool}
+}
+
+// Save writes the match with its history and the outbox messages, and lists it for every
+// participant. The match is only overwritten at the version it was read; matches read
+// without a version must still lack one.
+func (repo *MatchRepository) Save(ctx context.Context, entity match.Entity, messages []outbox.Message) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		written, err := matchRow(entity).writeAtVersion(ctx, tx, entity.Version)
+		if err != nil {
+			return err
+		}
+		if !written {
+			return fmt.Errorf("match %s: %w", entity.ID, common.ErrVersionConflict)
+		}
+		if err := listMatch(ctx, tx, entity); err != nil {
+			return err
+		}
+		if err := putMessages(ctx, tx, messages); err != nil {
+			return err
+		}
+		return appendHistory(ctx, tx, history.KindMatch, entity.ID, entity.Changes)
+	})
+}
+
+// SaveConfirmation writes the match, the confirmed offer, the outbox messages and the
+// waitlisted requests in one transaction, each entity with its history. The match must
+// not exist yet, the offer must still be PENDING at the version it was read and each
+// request must still be at the version it was read. All of them fit in one transaction,
+// so match.ErrWaitlistIncomplete is never returned.
+func (repo *MatchRepository) SaveConfirmation(
+	ctx context.Context,
+	entity match.Entity,
+	offer matchoffer.Entity,
+	waitlisted []matchrequest.Entity,
+	messages []outbox.Message,
+) error {
+	conflict := fmt.Errorf("confirmation of match offer %s: %w", offer.ID, common.ErrVersionConflict)
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		inserted, err := matchRow(entity).withVersion(entity.Version+1).insert(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if !inserted {
+			return conflict
+		}
+		if err := listMatch(ctx, tx, entity); err != nil {
+			return err
+		}
+
+		var status string
+		var version int
+		err = tx.QueryRow(ctx, "SELECT status, version FROM match_offers WHERE id = $1 FOR UPDATE", offer.ID).Scan(&status, &version)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return conflict
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read match offer %s: %w", offer.ID, err)
+		}
+		if status != matchoffer.StatusPending.String() || version != offer.Version {
+			return conflict
+		}
+		if err := offerRow(offer).withVersion(offer.Version+1).upsert(ctx, tx); err != nil {
+			return err
+		}
+		if err := appendHistory(ctx, tx, history.KindMatchOffer, offer.ID, offer.Changes); err != nil {
+			return err
+		}
+
+		if err := putMessages(ctx, tx, messages); err != nil {
+			return err
+		}
+		for _, request := range waitlisted {
+			if err := saveRequest(ctx, tx, request); err != nil {
+				if errors.Is(err, common.ErrVersionConflict) {
+					return conflict
+				}
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveParticipant writes the match and the requests with their history and the outbox
+// messages, and stops listing the match for removedAccountID. The match and each request
+// are only overwritten at the version they were read.
+func (repo *MatchRepository) RemoveParticipant(
+	ctx context.Context,
+	entity match.Entity,
+	removedAccountID string,
+	requests []matchrequest.Entity,
+	messages []outbox.Message,
+) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		written, err := matchRow(entity).writeAtVersion(ctx, tx, entity.Version)
+		if err != nil {
+			return err
+		}
+		if !written {
+			return fmt.Errorf("match %s: %w", entity.ID, common.ErrVersionConflict)
+		}
+		if err := listMatch(ctx, tx, entity); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM match_accounts WHERE account_id = $1 AND match_id = $2", removedAccountID, entity.ID); err != nil {
+			return fmt.Errorf("failed to unlist match %s: %w", entity.ID, err)
+		}
+		for _, request := range requests {
+			if err := saveRequest(ctx, tx, request); err != nil {
+				if errors.Is(err, common.ErrVersionConflict) {
+					return fmt.Errorf("match %s: %w", entity.ID, common.ErrVersionConflict)
+				}
+				return err
+			}
+		}
+		return putMessages(ctx, tx, messages)
+	})
+}
+
+// Find returns the matches listed for the account in ID order, only those in one of the
+// statuses when the query sets any.
+func (repo *MatchRepository) Find(ctx context.Context, query match.DomainQuery) ([]match.Entity, error) {
+	var f filter
+	f.where("match_accounts.account_id = " + f.arg(query.AccountID))
+	if len(query.Statuses) > 0 {
+		f.where("matches.status = ANY(" + f.arg(texts(query.Statuses)) + ")")
+	}
+	rows, err := repo.pool.Query(ctx,
+		"SELECT "+matchColumns+" FROM matches JOIN match_accounts ON match_accounts.match_id = matches.id"+f.String()+" ORDER BY matches.id",
+		f.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find matches: %w", err)
+	}
+	return pgx.CollectRows(rows, scanMatch)
+}
+
+// FindByID returns the match by its ID alone, as the DynamoDB backend does.
+func (repo *MatchRepository) FindByID(ctx context.Context, _, matchID string) (*match.Entity, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT "+matchColumns+" FROM matches WHERE id = $1", matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find match %s: %w", matchID, err)
+	}
+	entity, err := pgx.CollectExactlyOneRow(rows, scanMatch)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// listMatch lists the match for each of its participants.
+func listMatch(ctx context.Context, q querier, entity match.Entity) error {
+	for _, accountID := range entity.Participants {
+		if _, err := q.Exec(ctx,
+			"INSERT INTO match_accounts (account_id, match_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", accountID, entity.ID); err != nil {
+			return fmt.Errorf("failed to list match %s: %w", entity.ID, err)
+		}
+	}
+	return nil
+}
+
+func matchRow(entity match.Entity) row {
+	document := entity
+	document.Version, document.Changes = 0, nil
+	return row{
+		table:   "matches",
+		key:     1,
+		columns: []string{"id", "status", "document"},
+		values:  []any{entity.ID, entity.Status.String(), document},
+	}
+}
+
+func scanMatch(r pgx.CollectableRow) (match.Entity, error) {
+	var entity match.Entity
+	if err := r.Scan(&entity, &entity.Version); err != nil {
+		return match.Entity{}, fmt.Errorf("failed to read match: %w", err)
+	}
+	return entity, nil
+}
@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// offerColumns are read back into an offer by scanOffer. The accepted counters and the
+// version live in their own columns, as SaveWithOffer of the match requests updates them
+// without rewriting the document.
+const offerColumns = "document, accepted_count, accepted_by_position, version"
+
+type MatchOfferRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMatchOfferRepository(pool *pgxpool.Pool) matchoffer.Repository {
+	return &MatchOfferRepository{pool: pool}
+}
+
+// Save writes the offer with its history and the outbox messages, and bumps its version.
+// Offers read at a version only overwrite the stored one when nobody else wrote it since;
+// otherwise common.ErrVersionConflict.
+func (repo *MatchOfferRepository) Save(ctx context.Context, entity matchoffer.Entity, messages []outbox.Message) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		written, err := offerRow(entity).writeAtVersion(ctx, tx, entity.Version)
+		if err != nil {
+			return err
+		}
+		if !written {
+			return fmt.Errorf("match offer %s: %w", entity.ID, common.ErrVersionConflict)
+		}
+		if err = appendHistory(ctx, tx, history.KindMatchOffer, entity.ID, entity.Changes); err != nil {
+			return err
+		}
+		return putMessages(ctx, tx, messages)
+	})
+}
+
+// SaveAll writes each offer like Save. Offers that changed since they were read are
+// skipped and reported together as common.ErrVersionConflict once the others are
+// written. It returns how many offers were written.
+func (repo *MatchOfferRepository) SaveAll(ctx context.Context, entities []matchoffer.Entity) (int, error) {
+	saved := 0
+	var conflicts []error
+	for _, entity := range entities {
+		err := repo.Save(ctx, entity, nil)
+		if errors.Is(err, common.ErrVersionConflict) {
+			conflicts = append(conflicts, err)
+			continue
+		}
+		if err != nil {
+			return saved, err
+		}
+		saved++
+	}
+	return saved, errors.Join(conflicts...)
+}
+
+// Find returns the offers matching every criterion of the query in ID order, the page
+// selected by Limit and Offset and how many offers matched in total. Proximity is the
+// great-circle distance PostGIS measures on the sphere, as GeoFilter.Covers does.
+func (repo *MatchOfferRepository) Find(ctx context.Context, query matchoffer.DomainQuery) (matchoffer.Page, error) {
+	f := offerFilter(query)
+
+	var total int
+	if err := repo.pool.QueryRow(ctx, "SELECT count(*) FROM match_offers"+f.String(), f.args...).Scan(&total); err != nil {
+		return matchoffer.Page{}, fmt.Errorf("failed to count match offers: %w", err)
+	}
+
+	sql := "SELECT " + offerColumns + " FROM match_offers" + f.String() + " ORDER BY id"
+	if query.Limit > 0 {
+		sql += " LIMIT " + f.arg(query.Limit)
+	}
+	if query.Offset > 0 {
+		sql += " OFFSET " + f.arg(query.Offset)
+	}
+	rows, err := repo.pool.Query(ctx, sql, f.args...)
+	if err != nil {
+		return matchoffer.Page{}, fmt.Errorf("failed to find match offers: %w", err)
+	}
+	offers, err := pgx.CollectRows(rows, scanOffer)
+	if err != nil {
+		return matchoffer.Page{}, err
+	}
+	return matchoffer.Page{Entities: offers, Total: total}, nil
+}
+
+// FindByIDs returns the offers stored under ids in a single query.
+func (repo *MatchOfferRepository) FindByIDs(ctx context.Context, ids []string) ([]matchoffer.Entity, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT "+offerColumns+" FROM match_offers WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find match offers: %w", err)
+	}
+	return pgx.CollectRows(rows, scanOffer)
+}
+
+func offerFilter(query matchoffer.DomainQuery) *filter {
+	f := &filter{}
+	if len(query.IDs) > 0 {
+		f.where("id = ANY(" + f.arg(query.IDs) + ")")
+	}
+	if len(query.Sports) > 0 {
+		f.where("sport = ANY(" + f.arg(texts(query.Sports)) + ")")
+	}
+	if len(query.Categories) > 0 {
+		f.where("admitted_categories && " + f.arg(integers(query.Categories)))
+	}
+	if len(query.Statuses) > 0 {
+		f.where("status = ANY(" + f.arg(texts(query.Statuses)) + ")")
+	}
+	if !query.FromDate.IsZero() {
+		f.where("day >= " + f.arg(query.FromDate))
+	}
+	if !query.ToDate.IsZero() {
+		f.where("day <= " + f.arg(query.ToDate))
+	}
+	if location := query.Location; location != nil {
+		if location.Country != "" {
+			f.where("country = " + f.arg(location.Country))
+		}
+		if location.Province != "" {
+			f.where("province = " + f.arg(location.Province))
+		}
+		if location.Locality != "" {
+			f.where("locality = " + f.arg(location.Locality))
+		}
+	}
+	if geo := query.GeoFilter; geo != nil {
+		f.where(fmt.Sprintf("ST_DWithin(location, ST_SetSRID(ST_MakePoint(%s, %s), 4326)::geography, %s, false)",
+			f.arg(geo.Longitude), f.arg(geo.Latitude), f.arg(geo.RadiusKm*1000)))
+	}
+	if query.OwnerAccountID != "" {
+		f.where("owner_account_id = " + f.arg(query.OwnerAccountID))
+	}
+	if query.VisibleTo != nil {
+		f.where(visibleTo(f, *query.VisibleTo))
+	}
+	if query.ExcludedOwner != "" {
+		f.where("owner_account_id <> " + f.arg(query.ExcludedOwner))
+	}
+	if len(query.ExcludedIDs) > 0 {
+		f.where("NOT id = ANY(" + f.arg(query.ExcludedIDs) + ")")
+	}
+	if query.MinOpenSpots > 0 {
+		f.where("(open_spots IS NULL OR open_spots >= " + f.arg(query.MinOpenSpots) + ")")
+	}
+	if query.OpenPosition != "" {
+		f.where(fmt.Sprintf("(open_spots_by_position ->> %s)::int >= %s", f.arg(query.OpenPosition), f.arg(max(query.MinOpenSpots, 1))))
+	}
+	return f
+}
+
+// visibleTo is the condition of Audience.CanView: public offers, the offers of the
+// account or it is invited to, and the TEAM offers of the teams of the audience.
+func visibleTo(f *filter, audience matchoffer.Audience) string {
+	conditions := []string{fmt.Sprintf("visibility IN ('', '%s')", matchoffer.VisibilityPublic)}
+	if audience.AccountID != "" {
+		account := f.arg(audience.AccountID)
+		conditions = append(conditions, fmt.Sprintf("owner_account_id = %s OR %s = ANY(invited_account_ids)", account, account))
+	}
+	if len(audience.Teams) > 0 {
+		ids := make([]string, len(audience.Teams))
+		for i, team := range audience.Teams {
+			ids[i] = team.ID
+		}
+		conditions = append(conditions, fmt.Sprintf("visibility = '%s' AND team_id = ANY(%s)", matchoffer.VisibilityTeam, f.arg(ids)))
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+// offerRow stores the offer without its version, counters and changes, which have
+// columns and a table of their own. The open spots are stored for searches to filter on.
+func offerRow(offer matchoffer.Entity) row {
+	var location any
+	if offer.Location.HasCoords() {
+		location = fmt.Sprintf("SRID=4326;POINT(%f %f)", offer.Location.Longitude, offer.Location.Latitude)
+	}
+	admitted := make([]int32, 0, common.MaxCategory+1)
+	for category := common.Unranked; category <= common.MaxCategory; category++ {
+		if offer.AdmittedCategories.Admits(category) {
+			admitted = append(admitted, int32(category))
+		}
+	}
+	invited := offer.InvitedAccountIDs
+	if invited == nil {
+		invited = []string{}
+	}
+	document := offer
+	document.AcceptedCount, document.AcceptedByPosition, document.Version, document.Changes = 0, nil, 0, nil
+
+	return row{
+		table: "match_offers",
+		key:   1,
+		columns: []string{
+			"id", "sport", "day", "status", "owner_account_id", "team_id", "team_name", "visibility", "invited_account_ids",
+			"country", "province", "locality", "location", "admitted_categories", "accepted_count", "accepted_by_position",
+			"open_spots", "open_spots_by_position", "document",
+		},
+		values: []any{
+			offer.ID, string(offer.Sport), offer.Day, offer.Status.String(), offer.OwnerAccountID, offer.TeamID, offer.TeamName,
+			string(offer.Visibility), invited, offer.Location.Country, offer.Location.Province, offer.Location.Locality,
+			location, admitted, offer.AcceptedCount, offer.AcceptedByPosition, openSpots(offer),
+			offer.OpenSpotsByPosition(), document,
+		},
+	}
+}
+
+// openSpots returns the spots the offer has free, or nil, stored as NULL, when it has no
+// limit.
+func openSpots(offer matchoffer.Entity) any {
+	if open := offer.OpenSpots(offer.AcceptedCount); open >= 0 {
+		return open
+	}
+	return nil
+}
+
+func scanOffer(r pgx.CollectableRow) (matchoffer.Entity, error) {
+	var offer matchoffer.Entity
+	if err := r.Scan(&offer, &offer.AcceptedCount, &offer.AcceptedByPosition, &offer.Version); err != nil {
+		return matchoffer.Entity{}, fmt.Errorf("failed to read match offer: %w", err)
+	}
+	return offer, nil
+}
@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// requestColumns are read back into a request by scanRequest. UpdateStatus only writes
+// the status column, which wins over the status in the document.
+const requestColumns = "document, status, version"
+
+type MatchRequestRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMatchRequestRepository(pool *pgxpool.Pool) matchrequest.Repository {
+	return &MatchRequestRepository{pool: pool}
+}
+
+// Create writes a new request with the outbox messages, unless a request still in play is
+// stored under its ID.
+func (repo *MatchRequestRepository) Create(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		r := requestRow(entity).withVersion(entity.Version + 1)
+		condition := fmt.Sprintf("WHERE match_requests.status = ANY($%d)", len(r.values)+1)
+		written, err := r.exec(ctx, tx, r.insertSQL(condition), append(r.values, texts(matchrequest.FinalStatuses()))...)
+		if err != nil {
+			return err
+		}
+		if !written {
+			return fmt.Errorf("match request %s: %w", entity.ID, matchrequest.ErrOpenRequestExists)
+		}
+		return putMessages(ctx, tx, messages)
+	})
+}
+
+// Save writes the request with its history and the outbox messages, and bumps its version.
+// Requests read at a version only overwrite the stored one when nobody else wrote it since;
+// otherwise common.ErrVersionConflict.
+func (repo *MatchRequestRepository) Save(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		if err := saveRequest(ctx, tx, entity); err != nil {
+			return err
+		}
+		return putMessages(ctx, tx, messages)
+	})
+}
+
+// SaveAll writes the requests with their history without checking their versions, like
+// the batch writes of the DynamoDB backend.
+func (repo *MatchRequestRepository) SaveAll(ctx context.Context, entities []matchrequest.Entity) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		for _, entity := range entities {
+			if err := requestRow(entity).withVersion(entity.Version+1).upsert(ctx, tx); err != nil {
+				return err
+			}
+			if err := appendHistory(ctx, tx, history.KindMatchRequest, entity.ID, entity.Changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Find returns the requests matching every criterion of the query in ID order. A query
+// without any criterion returns no requests rather than all of them.
+func (repo *MatchRequestRepository) Find(ctx context.Context, query matchrequest.DomainQuery) ([]matchrequest.Entity, error) {
+	if len(query.IDs) == 0 && len(query.MatchOfferIDs) == 0 && len(query.OwnerAccountIDs) == 0 && len(query.RequesterAccountIDs) == 0 {
+		return []matchrequest.Entity{}, nil
+	}
+
+	var f filter
+	if len(query.IDs) > 0 {
+		f.where("id = ANY(" + f.arg(query.IDs) + ")")
+	}
+	if len(query.MatchOfferIDs) > 0 {
+		f.where("match_offer_id = ANY(" + f.arg(query.MatchOfferIDs) + ")")
+	}
+	if len(query.OwnerAccountIDs) > 0 {
+		f.where("owner_account_id = ANY(" + f.arg(query.OwnerAccountIDs) + ")")
+	}
+	if len(query.RequesterAccountIDs) > 0 {
+		f.where("requester_account_id = ANY(" + f.arg(query.RequesterAccountIDs) + ")")
+	}
+	if len(query.Statuses) > 0 {
+		f.where("status = ANY(" + f.arg(texts(query.Statuses)) + ")")
+	}
+	rows, err := repo.pool.Query(ctx, "SELECT "+requestColumns+" FROM match_requests"+f.String()+" ORDER BY id", f.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find match requests: %w", err)
+	}
+	return pgx.CollectRows(rows, scanRequest)
+}
+
+// FindByIDs returns the requests stored under ids in a single query.
+func (repo *MatchRequestRepository) FindByIDs(ctx context.Context, ids []string) ([]matchrequest.Entity, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT "+requestColumns+" FROM match_requests WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find match requests: %w", err)
+	}
+	return pgx.CollectRows(rows, scanRequest)
+}
+
+// UpdateStatus writes the status of entity with its history and the outbox messages, only
+// while the stored request is still PENDING and belongs to the owner of entity.
+func (repo *MatchRequestRepository) UpdateStatus(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx,
+			"UPDATE match_requests SET status = $1, version = version + 1 WHERE id = $2 AND owner_account_id = $3 AND status = $4",
+			entity.Status.String(), entity.ID, entity.OwnerAccountID, matchrequest.StatusPending.String())
+		if err != nil {
+			return fmt.Errorf("failed to update match request status: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("failed to update match request status: match request %s is not a pending request of %s", entity.ID, entity.OwnerAccountID)
+		}
+		if err = appendHistory(ctx, tx, history.KindMatchRequest, entity.ID, entity.Changes); err != nil {
+			return err
+		}
+		return putMessages(ctx, tx, messages)
+	})
+}
+
+// SaveWithOffer writes the request with its history, the offer's accepted counters and the
+// outbox messages in one transaction. When the request is accepted on an offer with a
+// spot limit, the stored accepted_count must still be below that limit.
+func (repo *MatchRequestRepository) SaveWithOffer(
+	ctx context.Context,
+	request matchrequest.Entity,
+	offer matchoffer.Entity,
+	messages []outbox.Message,
+) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		if err := updateOfferSpots(ctx, tx, request, offer); err != nil {
+			return err
+		}
+		if err := saveRequest(ctx, tx, request); err != nil {
+			return err
+		}
+		return putMessages(ctx, tx, messages)
+	})
+}
+
+// updateOfferSpots sets the offer's accepted counters and open spots and bumps its version. Offers read
+// without a version must still lack one, so two first writers cannot both win.
+func updateOfferSpots(ctx context.Context, tx pgx.Tx, request matchrequest.Entity, offer matchoffer.Entity) error {
+	var f filter
+	set := "accepted_count = " + f.arg(offer.AcceptedCount) + ", version = " + f.arg(offer.Version+1)
+	if len(offer.AcceptedByPosition) > 0 {
+		set += ", accepted_by_position = " + f.arg(offer.AcceptedByPosition)
+	}
+	set += ", open_spots = " + f.arg(openSpots(offer))
+	if byPosition := offer.OpenSpotsByPosition(); len(byPosition) > 0 {
+		set += ", open_spots_by_position = " + f.arg(byPosition)
+	}
+	f.where("id = " + f.arg(offer.ID))
+	f.where("version = " + f.arg(offer.Version))
+	limit := offer.SpotsToFill()
+	if request.IsAccepted() && limit > 0 {
+		f.where("accepted_count < " + f.arg(limit))
+	}
+	tag, err := tx.Exec(ctx, "UPDATE match_offers SET "+set+f.String(), f.args...)
+	if err != nil {
+		return fmt.Errorf("failed to update match offer %s: %w", offer.ID, err)
+	}
+	if tag.RowsAffected() == 1 {
+		return nil
+	}
+
+	var stored int
+	err = tx.QueryRow(ctx, "SELECT accepted_count FROM match_offers WHERE id = $1", offer.ID).Scan(&stored)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to read match offer %s: %w", offer.ID, err)
+	}
+	if err == nil && limit > 0 && stored >= limit {
+		return fmt.Errorf("match offer %s: %w", offer.ID, matchoffer.ErrOfferFull)
+	}
+	return fmt.Errorf("match request %s: %w", request.ID, common.ErrVersionConflict)
+}
+
+// saveRequest writes the request at the version it was read, with its history.
+func saveRequest(ctx context.Context, q querier, request matchrequest.Entity) error {
+	written, err := requestRow(request).writeAtVersion(ctx, q, request.Version)
+	if err != nil {
+		return err
+	}
+	if !written {
+		return fmt.Errorf("match request %s: %w", request.ID, common.ErrVersionConflict)
+	}
+	return appendHistory(ctx, q, history.KindMatchRequest, request.ID, request.Changes)
+}
+
+func requestRow(request matchrequest.Entity) row {
+	document := request
+	document.Version, document.Changes = 0, nil
+	return row{
+		table:   "match_requests",
+		key:     1,
+		columns: []string{"id", "match_offer_id", "owner_account_id", "requester_account_id", "status", "document"},
+		values: []any{
+			request.ID, request.MatchOfferID, request.OwnerAccountID, request.RequesterAccountID, request.Status.String(), document,
+		},
+	}
+}
+
+func scanRequest(r pgx.CollectableRow) (matchrequest.Entity, error) {
+	var request matchrequest.Entity
+	if err := r.Scan(&request, &request.Status, &request.Version); err != nil {
+		return matchrequest.Entity{}, fmt.Errorf("failed to read match request: %w", err)
+	}
+	return request, nil
+}
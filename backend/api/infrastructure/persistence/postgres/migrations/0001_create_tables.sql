@@ -0,0 +1,155 @@
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+-- Every table keeps the entity as a jsonb document next to the columns it is looked up,
+-- filtered or sorted by. The columns win over the document when both hold a value.
+
+CREATE TABLE accounts (
+    id         TEXT PRIMARY KEY, -- EMAIL#<email>
+    account_id TEXT  NOT NULL,
+    email      TEXT  NOT NULL,
+    nickname   TEXT  NOT NULL,
+    document   JSONB NOT NULL
+);
+CREATE INDEX accounts_account_id_idx ON accounts (account_id);
+
+CREATE TABLE players (
+    id       TEXT PRIMARY KEY,
+    category INTEGER NOT NULL,
+    sport    TEXT    NOT NULL,
+    document JSONB   NOT NULL
+);
+
+CREATE TABLE users (
+    id         TEXT PRIMARY KEY,
+    player_ids TEXT[] NOT NULL,
+    document   JSONB  NOT NULL
+);
+
+CREATE TABLE teams (
+    id               TEXT PRIMARY KEY,
+    name             TEXT    NOT NULL,
+    sport            TEXT    NOT NULL,
+    category         INTEGER NOT NULL,
+    owner_account_id TEXT    NOT NULL,
+    member_ids       TEXT[]  NOT NULL,
+    document         JSONB   NOT NULL
+);
+CREATE INDEX teams_sport_name_idx ON teams (sport, name text_pattern_ops);
+CREATE INDEX teams_owner_account_id_idx ON teams (owner_account_id);
+CREATE INDEX teams_member_ids_idx ON teams USING GIN (member_ids);
+
+CREATE TABLE match_offers (
+    id                   TEXT PRIMARY KEY,
+    sport                TEXT      NOT NULL,
+    day                  TIMESTAMPTZ NOT NULL,
+    status               TEXT      NOT NULL,
+    owner_account_id     TEXT      NOT NULL,
+    team_name            TEXT      NOT NULL,
+    visibility           TEXT      NOT NULL,
+    invited_account_ids  TEXT[]    NOT NULL,
+    country              TEXT      NOT NULL,
+    province             TEXT      NOT NULL,
+    locality             TEXT      NOT NULL,
+    location             GEOGRAPHY(POINT, 4326), -- NULL when the offer has no coordinates
+    admitted_categories  INTEGER[] NOT NULL,     -- every category the offer admits
+    accepted_count       INTEGER   NOT NULL,
+    accepted_by_position JSONB,
+    version              INTEGER   NOT NULL,
+    document             JSONB     NOT NULL
+);
+CREATE INDEX match_offers_status_day_idx ON match_offers (status, day);
+CREATE INDEX match_offers_owner_account_id_idx ON match_offers (owner_account_id);
+CREATE INDEX match_offers_location_idx ON match_offers USING GIST (location);
+CREATE INDEX match_offers_admitted_categories_idx ON match_offers USING GIN (admitted_categories);
+
+CREATE TABLE match_requests (
+    id                   TEXT PRIMARY KEY, -- AccountId#<requester>#MatchOfferId#<offer>
+    match_offer_id       TEXT    NOT NULL,
+    owner_account_id     TEXT    NOT NULL,
+    requester_account_id TEXT    NOT NULL,
+    status               TEXT    NOT NULL,
+    version              INTEGER NOT NULL,
+    document             JSONB   NOT NULL
+);
+CREATE INDEX match_requests_match_offer_id_idx ON match_requests (match_offer_id);
+CREATE INDEX match_requests_owner_account_id_idx ON match_requests (owner_account_id);
+CREATE INDEX match_requests_requester_account_id_idx ON match_requests (requester_account_id);
+
+CREATE TABLE matches (
+    id       TEXT PRIMARY KEY,
+    status   TEXT    NOT NULL,
+    version  INTEGER NOT NULL,
+    document JSONB   NOT NULL
+);
+
+-- The accounts a match is listed for, which stop being its participants once removed.
+CREATE TABLE match_accounts (
+    account_id TEXT NOT NULL,
+    match_id   TEXT NOT NULL REFERENCES matches (id) ON DELETE CASCADE,
+    PRIMARY KEY (account_id, match_id)
+);
+
+CREATE TABLE match_reminders (
+    due_at         TIMESTAMPTZ NOT NULL,
+    match_id       TEXT        NOT NULL,
+    offset_minutes INTEGER     NOT NULL,
+    document       JSONB       NOT NULL,
+    PRIMARY KEY (due_at, match_id, offset_minutes)
+);
+
+CREATE TABLE match_attendances (
+    match_id   TEXT  NOT NULL,
+    account_id TEXT  NOT NULL,
+    document   JSONB NOT NULL,
+    PRIMARY KEY (match_id, account_id)
+);
+
+CREATE TABLE outbox_messages (
+    id              TEXT PRIMARY KEY, -- ULID, so messages sort in the order they were raised
+    status          TEXT        NOT NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    document        JSONB       NOT NULL
+);
+CREATE INDEX outbox_messages_pending_idx ON outbox_messages (id) WHERE status = 'PENDING';
+
+CREATE TABLE processed_messages (
+    consumer     TEXT        NOT NULL,
+    message_id   TEXT        NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (consumer, message_id)
+);
+
+CREATE TABLE notifications (
+    account_id TEXT    NOT NULL,
+    id         TEXT    NOT NULL,
+    read       BOOLEAN NOT NULL,
+    document   JSONB   NOT NULL,
+    PRIMARY KEY (account_id, id)
+);
+
+CREATE TABLE notification_preferences (
+    account_id TEXT PRIMARY KEY,
+    document   JSONB NOT NULL
+);
+
+CREATE TABLE chat_messages (
+    thread_key TEXT  NOT NULL, -- <KIND>#<ref ID>
+    id         TEXT  NOT NULL, -- ULID of the time it was sent
+    document   JSONB NOT NULL,
+    PRIMARY KEY (thread_key, id)
+);
+
+CREATE TABLE chat_receipts (
+    thread_key TEXT  NOT NULL,
+    account_id TEXT  NOT NULL,
+    document   JSONB NOT NULL,
+    PRIMARY KEY (thread_key, account_id)
+);
+
+CREATE TABLE status_history (
+    kind      TEXT  NOT NULL,
+    entity_id TEXT  NOT NULL,
+    id        TEXT  NOT NULL, -- ULID, so entries sort in the order they were written
+    document  JSONB NOT NULL,
+    PRIMARY KEY (kind, entity_id, id)
+);
@@ -0,0 +1,25 @@
+-- The spots offers have free, so searches filter on them. Offers without a spot limit have
+-- no open_spots, and open_spots_by_position only lists the positions an offer needs.
+ALTER TABLE match_offers
+    ADD COLUMN open_spots             INTEGER,
+    ADD COLUMN open_spots_by_position JSONB;
+
+-- Mirrors matchoffer.Entity.SpotsToFill: SpotsNeeded wins over the Capacity the committed
+-- players, or at least the owner, take part of.
+UPDATE match_offers
+SET open_spots = GREATEST(
+        CASE
+            WHEN (document -> 'PlayerNeeds' ->> 'SpotsNeeded')::int > 0
+                THEN (document -> 'PlayerNeeds' ->> 'SpotsNeeded')::int
+            ELSE (document ->> 'Capacity')::int - GREATEST((document -> 'PlayerNeeds' ->> 'Committed')::int, 1)
+        END - accepted_count, 0)
+WHERE (document -> 'PlayerNeeds' ->> 'SpotsNeeded')::int > 0
+   OR (document ->> 'Capacity')::int > 0;
+
+UPDATE match_offers
+SET open_spots_by_position = (
+        SELECT jsonb_object_agg(
+                   need ->> 'Position',
+                   GREATEST((need ->> 'Spots')::int - COALESCE((accepted_by_position ->> (need ->> 'Position'))::int, 0), 0))
+        FROM jsonb_array_elements(document -> 'PlayerNeeds' -> 'Positions') AS need)
+WHERE jsonb_typeof(document -> 'PlayerNeeds' -> 'Positions') = 'array';
@@ -0,0 +1,26 @@
+-- Teams were keyed by SPORT#<sport>#NAME#<name>, so renaming one changed its ID. They get
+-- a ULID instead, and the name stays unique within the sport through its own constraint.
+CREATE FUNCTION pg_temp.ulid() RETURNS TEXT AS $$
+DECLARE
+    alphabet CONSTANT TEXT := '0123456789ABCDEFGHJKMNPQRSTVWXYZ';
+    -- 48 bits of milliseconds followed by 80 random bits, padded to the 130 bits of 26
+    -- base32 characters.
+    bits              BIT(130) := B'00' || ('x' ||
+        lpad(to_hex((extract(EPOCH FROM clock_timestamp()) * 1000)::BIGINT), 12, '0') ||
+        substr(md5(random()::TEXT || clock_timestamp()::TEXT), 1, 20))::BIT(128);
+    id                TEXT     := '';
+BEGIN
+    FOR i IN 0..25 LOOP
+        id := id || substr(alphabet, substring(bits FROM i * 5 + 1 FOR 5)::INTEGER + 1, 1);
+    END LOOP;
+    RETURN id;
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+UPDATE teams
+SET id       = moved.id,
+    document = jsonb_set(teams.document, '{ID}', to_jsonb(moved.id))
+FROM (SELECT id AS legacy_id, pg_temp.ulid() AS id FROM teams WHERE id LIKE 'SPORT#%') AS moved
+WHERE teams.id = moved.legacy_id;
+
+ALTER TABLE teams ADD CONSTRAINT teams_sport_name_key UNIQUE (sport, name);
@@ -0,0 +1,19 @@
+-- Teams are searched by their name folded like team.SearchName: lower case, without
+-- accents and with single spaces, in (search_name, id) order. The home area and whether
+-- the team looks for players are filtered on too.
+ALTER TABLE teams
+    ADD COLUMN search_name         TEXT,
+    ADD COLUMN looking_for_players BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN home_location       GEOGRAPHY(POINT, 4326); -- NULL when the team has no home area
+
+-- lower() only folds ASCII letters under some collations, so the accented capitals are
+-- translated too.
+UPDATE teams
+SET search_name = btrim(regexp_replace(lower(translate(name,
+        'ÁÀÂÄÃÅáàâäãåÉÈÊËéèêëÍÌÎÏíìîïÓÒÔÖÕóòôöõÚÙÛÜúùûüÑñÇç',
+        'aaaaaaaaaaaaeeeeeeeeiiiiiiiioooooooooouuuuuuuunncc')), '\s+', ' ', 'g'));
+
+ALTER TABLE teams ALTER COLUMN search_name SET NOT NULL;
+
+CREATE INDEX teams_search_idx ON teams ((search_name || '#' || id) COLLATE "C");
+CREATE INDEX teams_home_location_idx ON teams USING GIST (home_location);
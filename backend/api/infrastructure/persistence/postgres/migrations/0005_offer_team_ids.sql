@@ -0,0 +1,14 @@
+-- Offers pointed to their team by name, so renaming the team cut TEAM offers off from its
+-- members. They get the ID of the team of their owner that had the name in their sport.
+ALTER TABLE match_offers ADD COLUMN team_id TEXT NOT NULL DEFAULT '';
+
+UPDATE match_offers
+SET team_id  = teams.id,
+    document = jsonb_set(match_offers.document, '{TeamID}', to_jsonb(teams.id))
+FROM teams
+WHERE teams.sport = match_offers.sport
+  AND teams.name = match_offers.team_name
+  AND teams.owner_account_id = match_offers.owner_account_id
+  AND match_offers.team_id = '';
+
+CREATE INDEX match_offers_team_id_idx ON match_offers (team_id) WHERE team_id <> '';
@@ -0,0 +1,3 @@
+-- Reminders were deleted when claimed, so one whose publishing failed was lost. They are
+-- now leased while being sent and deleted only once sent.
+ALTER TABLE match_reminders ADD COLUMN claimed_until TIMESTAMPTZ;
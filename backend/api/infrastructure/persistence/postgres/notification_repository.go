@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NotificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationRepository(pool *pgxpool.Pool) notification.Repository {
+	return &NotificationRepository{pool: pool}
+}
+
+func (repo *NotificationRepository) Save(ctx context.Context, entity notification.Entity, messages []outbox.Message) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		if err := notificationRow(entity).upsert(ctx, tx); err != nil {
+			return err
+		}
+		return putMessages(ctx, tx, messages)
+	})
+}
+
+func (repo *NotificationRepository) SaveAll(ctx context.Context, entities []notification.Entity) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		for _, entity := range entities {
+			if err := notificationRow(entity).upsert(ctx, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Find returns the inbox newest first, starting right after the cursor. One more
+// notification than requested is read to know whether another page follows.
+func (repo *NotificationRepository) Find(ctx context.Context, query notification.DomainQuery) (notification.Page, error) {
+	var f filter
+	f.where("account_id = " + f.arg(query.AccountID))
+	if query.Cursor != "" {
+		f.where("id < " + f.arg(query.Cursor))
+	}
+	if query.UnreadOnly {
+		f.where("NOT read")
+	}
+	rows, err := repo.pool.Query(ctx,
+		"SELECT document FROM notifications"+f.String()+" ORDER BY id DESC LIMIT "+f.arg(query.Limit+1), f.args...)
+	if err != nil {
+		return notification.Page{}, fmt.Errorf("failed to find notifications: %w", err)
+	}
+	entities, err := pgx.CollectRows(rows, pgx.RowTo[notification.Entity])
+	if err != nil {
+		return notification.Page{}, err
+	}
+
+	page := notification.Page{Entities: entities}
+	if len(entities) > query.Limit {
+		page.Entities = entities[:query.Limit]
+		page.NextCursor = page.Entities[query.Limit-1].ID
+	}
+	return page, nil
+}
+
+func (repo *NotificationRepository) FindByID(ctx context.Context, accountID, notificationID string) (*notification.Entity, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT document FROM notifications WHERE account_id = $1 AND id = $2", accountID, notificationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification %s: %w", notificationID, err)
+	}
+	entity, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[notification.Entity])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+func notificationRow(entity notification.Entity) row {
+	return row{
+		table:   "notifications",
+		key:     2,
+		columns: []string{"account_id", "id", "read", "document"},
+		values:  []any{entity.AccountID, entity.ID, entity.IsRead(), entity},
+	}
+}
+
+type PreferencesRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPreferencesRepository(pool *pgxpool.Pool) notification.PreferencesRepository {
+	return &PreferencesRepository{pool: pool}
+}
+
+// Find returns the saved preferences of the account, or the defaults when it never
+// changed them.
+func (repo *PreferencesRepository) Find(ctx context.Context, accountID string) (notification.Preferences, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT document FROM notification_preferences WHERE account_id = $1", accountID)
+	if err != nil {
+		return notification.Preferences{}, fmt.Errorf("failed to find notification preferences: %w", err)
+	}
+	preferences, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[notification.Preferences])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return notification.NewPreferences(accountID), nil
+	}
+	return preferences, err
+}
+
+func (repo *PreferencesRepository) Save(ctx context.Context, preferences notification.Preferences) error {
+	return row{
+		table:   "notification_preferences",
+		key:     1,
+		columns: []string{"account_id", "document"},
+		values:  []any{preferences.AccountID, preferences},
+	}.upsert(ctx, repo.pool)
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/outbox"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// processedRetention is how long a handled message is remembered, longer than any broker
+// redelivery window like the TTL of the DynamoDB backend.
+const processedRetention = 14 * 24 * time.Hour
+
+type OutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) outbox.Repository {
+	return &OutboxRepository{pool: pool}
+}
+
+func (repo *OutboxRepository) Save(ctx context.Context, message outbox.Message) error {
+	return putMessages(ctx, repo.pool, []outbox.Message{message})
+}
+
+func (repo *OutboxRepository) Delete(ctx context.Context, messageID string) error {
+	if _, err := repo.pool.Exec(ctx, "DELETE FROM outbox_messages WHERE id = $1", messageID); err != nil {
+		return fmt.Errorf("failed to delete outbox message %s: %w", messageID, err)
+	}
+	return nil
+}
+
+// FindDue returns up to limit pending messages whose next attempt is due at now, in ID
+// order, which is the order they were raised.
+func (repo *OutboxRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]outbox.Message, error) {
+	rows, err := repo.pool.Query(ctx,
+		"SELECT document FROM outbox_messages WHERE status = $1 AND next_attempt_at <= $2 ORDER BY id LIMIT $3",
+		outbox.StatusPending.String(), now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due outbox messages: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[outbox.Message])
+}
+
+type ProcessedRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewProcessedRepository(pool *pgxpool.Pool) outbox.ProcessedRepository {
+	return &ProcessedRepository{pool: pool}
+}
+
+func (repo *ProcessedRepository) IsProcessed(ctx context.Context, consumer, messageID string) (bool, error) {
+	var processed bool
+	err := repo.pool.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM processed_messages WHERE consumer = $1 AND message_id = $2 AND processed_at > $3)",
+		consumer, messageID, time.Now().Add(-processedRetention)).Scan(&processed)
+	if err != nil {
+		return false, fmt.Errorf("failed to check message %s: %w", messageID, err)
+	}
+	return processed, nil
+}
+
+// MarkProcessed remembers the message and forgets the ones of the consumer handled longer
+// than processedRetention ago, standing in for the TTL of the DynamoDB backend.
+func (repo *ProcessedRepository) MarkProcessed(ctx context.Context, consumer, messageID string) error {
+	now := time.Now()
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		if err := (row{
+			table:   "processed_messages",
+			key:     2,
+			columns: []string{"consumer", "message_id", "processed_at"},
+			values:  []any{consumer, messageID, now},
+		}).upsert(ctx, tx); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx,
+			"DELETE FROM processed_messages WHERE consumer = $1 AND processed_at <= $2", consumer, now.Add(-processedRetention)); err != nil {
+			return fmt.Errorf("failed to purge processed messages: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/player"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PlayerRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPlayerRepository(pool *pgxpool.Pool) player.Repository {
+	return &PlayerRepository{pool: pool}
+}
+
+func (repo *PlayerRepository) Save(ctx context.Context, entity player.Entity) error {
+	if entity.ID == "" {
+		return fmt.Errorf("id could not be empty")
+	}
+	return row{
+		table:   "players",
+		key:     1,
+		columns: []string{"id", "category", "sport", "document"},
+		values:  []any{entity.ID, int32(entity.Category), string(entity.Sport), entity},
+	}.upsert(ctx, repo.pool)
+}
+
+// Find returns the players matching every criterion of the query in ID order.
+func (repo *PlayerRepository) Find(ctx context.Context, query player.DomainQuery) ([]player.Entity, error) {
+	var f filter
+	if query.Id != "" {
+		f.where("id = " + f.arg(query.Id))
+	}
+	if len(query.Ids) > 0 {
+		f.where("id = ANY(" + f.arg(query.Ids) + ")")
+	}
+	if query.Category != 0 {
+		f.where("category = " + f.arg(int32(query.Category)))
+	}
+	if query.Sport != "" {
+		f.where("sport = " + f.arg(string(query.Sport)))
+	}
+	rows, err := repo.pool.Query(ctx, "SELECT document FROM players"+f.String()+" ORDER BY id", f.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find players: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[player.Entity])
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/outbox"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReminderRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReminderRepository(pool *pgxpool.Pool) match.ReminderRepository {
+	return &ReminderRepository{pool: pool}
+}
+
+// Schedule stores the reminders by due time, match and offset, so scheduling one again
+// overwrites it with the same content and without a lease.
+func (repo *ReminderRepository) Schedule(ctx context.Context, reminders []match.Reminder) error {
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		for _, reminder := range reminders {
+			if err := reminderRow(reminder).upsert(ctx, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindDue returns up to limit reminders due at or before now and not leased past it, the
+// earliest first.
+func (repo *ReminderRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]match.Reminder, error) {
+	rows, err := repo.pool.Query(ctx,
+		"SELECT document FROM match_reminders WHERE due_at <= $1 AND (claimed_until IS NULL OR claimed_until <= $1)"+
+			" ORDER BY due_at, match_id, offset_minutes LIMIT $2", now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due reminders: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[match.Reminder])
+}
+
+// Claim leases the reminder until the given time. It returns false when the reminder is
+// gone or another instance holds a lease running past now.
+func (repo *ReminderRepository) Claim(ctx context.Context, reminder match.Reminder, now, until time.Time) (bool, error) {
+	r := reminderRow(reminder)
+	tag, err := repo.pool.Exec(ctx,
+		"UPDATE match_reminders SET claimed_until = $4 WHERE due_at = $1 AND match_id = $2 AND offset_minutes = $3"+
+			" AND (claimed_until IS NULL OR claimed_until <= $5)", append(r.values[:r.key:r.key], until, now)...)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim reminder of match %s: %w", reminder.MatchID, err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Delete takes the reminder out of the schedule while it is still leased until
+// leasedUntil, and writes the outbox messages in the same transaction.
+func (repo *ReminderRepository) Delete(ctx context.Context, reminder match.Reminder, leasedUntil time.Time, messages []outbox.Message) error {
+	r := reminderRow(reminder)
+	return pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx,
+			"DELETE FROM match_reminders WHERE due_at = $1 AND match_id = $2 AND offset_minutes = $3 AND claimed_until = $4",
+			append(r.values[:r.key:r.key], leasedUntil)...)
+		if err != nil {
+			return fmt.Errorf("failed to delete reminder of match %s: %w", reminder.MatchID, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("reminder of match %s: %w", reminder.MatchID, match.ErrReminderLeaseLost)
+		}
+		return putMessages(ctx, tx, messages)
+	})
+}
+
+func reminderRow(reminder match.Reminder) row {
+	return row{
+		table:   "match_reminders",
+		key:     3,
+		columns: []string{"due_at", "match_id", "offset_minutes", "document", "claimed_until"},
+		values:  []any{reminder.DueAt(), reminder.MatchID, int32(reminder.Offset / time.Minute), reminder, nil},
+	}
+}
+
+type AttendanceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAttendanceRepository(pool *pgxpool.Pool) match.AttendanceRepository {
+	return &AttendanceRepository{pool: pool}
+}
+
+// Save only records the confirmation when the participant has none yet, so the time of
+// the first tap is kept.
+func (repo *AttendanceRepository) Save(ctx context.Context, attendance match.Attendance) error {
+	_, err := row{
+		table:   "match_attendances",
+		key:     2,
+		columns: []string{"match_id", "account_id", "document"},
+		values:  []any{attendance.MatchID, attendance.AccountID, attendance},
+	}.insert(ctx, repo.pool)
+	return err
+}
+
+// Find returns the confirmations of the match ordered by account.
+func (repo *AttendanceRepository) Find(ctx context.Context, matchID string) ([]match.Attendance, error) {
+	rows, err := repo.pool.Query(ctx, "SELECT document FROM match_attendances WHERE match_id = $1 ORDER BY account_id", matchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find attendances of match %s: %w", matchID, err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[match.Attendance])
+}
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/domain/team"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TeamRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewTeamRepository(pool *pgxpool.Pool) team.Repository {
+	return &TeamRepository{pool: pool}
+}
+
+// Save writes the team with the outbox messages, which the unique name of the sport makes
+// fail with team.ErrNameTaken when another team of the sport has its name.
+func (repo *TeamRepository) Save(ctx context.Context, entity team.Entity, messages []outbox.Message) error {
+	if entity.ID == "" {
+		return fmt.Errorf("ID could not be empty")
+	}
+	err := pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		if err := teamRow(entity).upsert(ctx, tx); err != nil {
+			return err
+		}
+		return putMessages(ctx, tx, messages)
+	})
+	if isUniqueViolation(err, teamNameConstraint) {
+		return fmt.Errorf("team %s: %w", entity.Name, team.ErrNameTaken)
+	}
+	return err
+}
+
+// Update overwrites the team while it still has the name it was read with, renaming it
+// in the same statement, and writes the outbox messages in the same transaction.
+func (repo *TeamRepository) Update(ctx context.Context, previousName string, entity team.Entity, messages []outbox.Message) error {
+	if entity.ID == "" {
+		return fmt.Errorf("ID could not be empty")
+	}
+	err := pgx.BeginFunc(ctx, repo.pool, func(tx pgx.Tx) error {
+		r := teamRow(entity)
+		written, err := r.exec(ctx, tx, r.updateSQL("name"), append(r.values, previousName)...)
+		if err != nil {
+			return err
+		}
+		if !written {
+			return fmt.Errorf("team %s: %w", entity.ID, common.ErrVersionConflict)
+		}
+		return putMessages(ctx, tx, messages)
+	})
+	if isUniqueViolation(err, teamNameConstraint) {
+		return fmt.Errorf("team %s: %w", entity.Name, team.ErrNameTaken)
+	}
+	return err
+}
+
+// Find returns the teams matching every criterion of the query in ID order. Name matches
+// the teams whose name starts with it.
+func (repo *TeamRepository) Find(ctx context.Context, query team.DomainQuery) ([]team.Entity, error) {
+	var f filter
+	if query.Name != "" {
+		f.where("starts_with(name, " + f.arg(query.Name) + ")")
+	}
+	if len(query.Ids) > 0 {
+		f.where("id = ANY(" + f.arg(query.Ids) + ")")
+	}
+	if len(query.Categories) > 0 {
+		f.where("category = ANY(" + f.arg(integers(query.Categories)) + ")")
+	}
+	if len(query.Sports) > 0 {
+		f.where("sport = ANY(" + f.arg(texts(query.Sports)) + ")")
+	}
+	if query.OwnerAccountID != "" {
+		f.where("owner_account_id = " + f.arg(query.OwnerAccountID))
+	}
+	if query.MemberAccountID != "" {
+		f.where(f.arg(query.MemberAccountID) + " = ANY(member_ids)")
+	}
+	rows, err := repo.pool.Query(ctx, "SELECT document FROM teams"+f.String()+" ORDER BY id", f.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find teams: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[team.Entity])
+}
+
+// Search returns the teams matching the query in SearchKey order, starting right after
+// the cursor. One more team than requested is read to know whether another page follows;
+// fuzzy names are matched as the rows are read, so those are read until it is found.
+func (repo *TeamRepository) Search(ctx context.Context, query team.SearchQuery) (team.Page, error) {
+	const searchKey = `(search_name || '#' || id) COLLATE "C"`
+	var f filter
+	if prefix := query.Prefix(); prefix != "" {
+		f.where("starts_with(search_name, " + f.arg(prefix) + ")")
+	}
+	if query.Cursor != "" {
+		f.where(searchKey + " > " + f.arg(query.Cursor))
+	}
+	f.where("sport = ANY(" + f.arg(texts(query.SearchedSports())) + ")")
+	if len(query.Categories) > 0 {
+		f.where("category = ANY(" + f.arg(integers(query.Categories)) + ")")
+	}
+	if geo := query.Near; geo != nil {
+		f.where(fmt.Sprintf("ST_DWithin(home_location, ST_SetSRID(ST_MakePoint(%s, %s), 4326)::geography, %s, false)",
+			f.arg(geo.Longitude), f.arg(geo.Latitude), f.arg(geo.RadiusKm*1000)))
+	}
+	if query.LookingForPlayers {
+		f.where("looking_for_players")
+	}
+	sql := "SELECT document FROM teams" + f.String() + " ORDER BY " + searchKey
+	if !query.Fuzzy {
+		sql += " LIMIT " + f.arg(query.Limit+1)
+	}
+	rows, err := repo.pool.Query(ctx, sql, f.args...)
+	if err != nil {
+		return team.Page{}, fmt.Errorf("failed to search teams: %w", err)
+	}
+	defer rows.Close()
+
+	entities := make([]team.Entity, 0, query.Limit+1)
+	for len(entities) <= query.Limit && rows.Next() {
+		entity, err := pgx.RowTo[team.Entity](rows)
+		if err != nil {
+			return team.Page{}, err
+		}
+		if query.MatchesName(team.SearchName(entity.Name)) {
+			entities = append(entities, entity)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return team.Page{}, err
+	}
+
+	page := team.Page{Entities: entities}
+	if len(entities) > query.Limit {
+		page.Entities = entities[:query.Limit]
+		page.NextCursor = page.Entities[query.Limit-1].SearchKey()
+	}
+	return page, nil
+}
+
+// teamNameConstraint keeps the names of the teams of a sport unique.
+const teamNameConstraint = "teams_sport_name_key"
+
+func teamRow(entity team.Entity) row {
+	memberIDs := make([]string, 0, len(entity.Members))
+	for _, member := range entity.Members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+	var homeLocation any
+	if area := entity.HomeArea; area != nil {
+		homeLocation = fmt.Sprintf("SRID=4326;POINT(%f %f)", area.Longitude, area.Latitude)
+	}
+	return row{
+		table: "teams",
+		key:   1,
+		columns: []string{"id", "name", "sport", "category", "owner_account_id", "member_ids",
+			"search_name", "looking_for_players", "home_location", "document"},
+		values: []any{entity.ID, entity.Name, string(entity.Sport), int32(entity.Category), entity.OwnerAccountID, memberIDs,
+			team.SearchName(entity.Name), entity.LookingForPlayers, homeLocation, entity},
+	}
+}
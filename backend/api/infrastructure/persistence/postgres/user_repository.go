@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/user"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewUserRepository(pool *pgxpool.Pool) user.Repository {
+	return &UserRepository{pool: pool}
+}
+
+func (repo *UserRepository) Save(ctx context.Context, entity user.Entity) error {
+	if entity.ID == "" {
+		return fmt.Errorf("id could not be empty")
+	}
+	playerIDs := entity.PlayerIDs
+	if playerIDs == nil {
+		playerIDs = []string{}
+	}
+	return row{
+		table:   "users",
+		key:     1,
+		columns: []string{"id", "player_ids", "document"},
+		values:  []any{entity.ID, playerIDs, entity},
+	}.upsert(ctx, repo.pool)
+}
+
+// Find returns the users with one of the Ids in ID order, only those with one of the
+// PlayerIDs when the query sets any. A query without Ids returns no users.
+func (repo *UserRepository) Find(ctx context.Context, query user.DomainQuery) ([]user.Entity, error) {
+	if len(query.Ids) == 0 {
+		return []user.Entity{}, nil
+	}
+	var f filter
+	f.where("id = ANY(" + f.arg(query.Ids) + ")")
+	if len(query.PlayerIDs) > 0 {
+		f.where("player_ids && " + f.arg(query.PlayerIDs))
+	}
+	rows, err := repo.pool.Query(ctx, "SELECT document FROM users"+f.String()+" ORDER BY id", f.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[user.Entity])
+}
@@ -1,6 +1,7 @@
 package team
 
 import (
+	"fmt"
 	"sportlink/api/domain/common"
 	"sportlink/api/domain/player"
 	"sportlink/api/domain/team"
@@ -8,30 +9,128 @@ import (
 
 // TODO faltan las stats
 type Dto struct {
-	EntityId       string `dynamodbav:"EntityId"`
-	Id             string `dynamodbav:"Id"`
-	Name           string `dynamodbav:"Name,omitempty"`
-	Category       int    `dynamodbav:"Category"`
-	Sport          string `dynamodbav:"Sport"`
-	OwnerAccountId string `dynamodbav:"OwnerAccountId,omitempty"`
+	EntityId       string   `dynamodbav:"EntityId"`
+	Id             string   `dynamodbav:"Id"`
+	Name           string   `dynamodbav:"Name,omitempty"`
+	Category       int      `dynamodbav:"Category"`
+	Sport          string   `dynamodbav:"Sport"`
+	OwnerAccountId string   `dynamodbav:"OwnerAccountId,omitempty"`
+	MemberIds      []string `dynamodbav:"MemberIds,omitempty"` // Player IDs of the members, only the IDs are kept
+	// SearchName is the folded name the search record of the team is keyed by, missing on
+	// the teams written before they had one.
+	SearchName        string       `dynamodbav:"SearchName,omitempty"`
+	LookingForPlayers bool         `dynamodbav:"LookingForPlayers"`
+	HomeArea          *HomeAreaDto `dynamodbav:"HomeArea,omitempty"`
+}
+
+type HomeAreaDto struct {
+	Locality  string  `dynamodbav:"Locality,omitempty"`
+	Latitude  float64 `dynamodbav:"Latitude"`
+	Longitude float64 `dynamodbav:"Longitude"`
+}
+
+// NameDto claims the name of a team within its sport, so the conditional write of the
+// record keeps any other team of the sport from taking it.
+type NameDto struct {
+	EntityId string `dynamodbav:"EntityId"`
+	Id       string `dynamodbav:"Id"` // SPORT#<sport>#NAME#<name>
+	TeamId   string `dynamodbav:"TeamId"`
+}
+
+// SearchDto indexes a team for searches, keyed by its sport and SearchKey so the teams of
+// a sport are read in the order searches return them. It carries what searches filter
+// on, the team being read once it makes the page.
+type SearchDto struct {
+	EntityId          string   `dynamodbav:"EntityId"`
+	Id                string   `dynamodbav:"Id"` // SPORT#<sport>#<search name>#<team ID>
+	TeamId            string   `dynamodbav:"TeamId"`
+	SearchName        string   `dynamodbav:"SearchName"`
+	Category          int      `dynamodbav:"Category"`
+	LookingForPlayers bool     `dynamodbav:"LookingForPlayers"`
+	Latitude          *float64 `dynamodbav:"Latitude,omitempty"`
+	Longitude         *float64 `dynamodbav:"Longitude,omitempty"`
+}
+
+// SearchFrom returns the record indexing the team for searches.
+func SearchFrom(entity team.Entity) SearchDto {
+	dto := SearchDto{
+		EntityId:          SearchPartition,
+		Id:                SearchID(entity.Sport, entity.SearchKey()),
+		TeamId:            entity.ID,
+		SearchName:        team.SearchName(entity.Name),
+		Category:          int(entity.Category),
+		LookingForPlayers: entity.LookingForPlayers,
+	}
+	if entity.HomeArea != nil {
+		dto.Latitude = &entity.HomeArea.Latitude
+		dto.Longitude = &entity.HomeArea.Longitude
+	}
+	return dto
+}
+
+// SearchID builds the Id of a search record from the sport and a search key, or from the
+// start of one to read the records from.
+func SearchID(sport common.Sport, key string) string {
+	return fmt.Sprintf("SPORT#%s#%s", sport, key)
+}
+
+// SearchKey returns the team.Entity.SearchKey of the indexed team.
+func (d SearchDto) SearchKey() string {
+	return d.SearchName + "#" + d.TeamId
+}
+
+// homeArea returns the coordinates of the home area, nil when the team has none.
+func (d SearchDto) homeArea() *team.HomeArea {
+	if d.Latitude == nil || d.Longitude == nil {
+		return nil
+	}
+	return &team.HomeArea{Latitude: *d.Latitude, Longitude: *d.Longitude}
+}
+
+// NameFrom returns the record claiming the name of the team.
+func NameFrom(entity team.Entity) NameDto {
+	return NameDto{
+		EntityId: NamePartition,
+		Id:       entity.NameKey(),
+		TeamId:   entity.ID,
+	}
 }
 
 func (d *Dto) ToDomain() team.Entity {
-	// Use stored Name if available, otherwise extract from ID format: SPORT#<sport>#NAME#<name>
+	// Use stored Name if available, otherwise extract it from the ID teams were keyed by
+	// before they had a ULID: SPORT#<sport>#NAME#<name>
 	name := d.Name
 	if name == "" {
 		name = extractNameFromID(d.Id)
 	}
 
 	return team.Entity{
-		ID:             d.Id,
-		Name:           name,
-		Category:       common.Category(d.Category),
-		Sport:          common.Sport(d.Sport),
-		Stats:          *common.NewStats(0, 0, 0), // Default stats (not persisted yet)
-		Members:        []player.Entity{},         // Default empty members (not persisted yet)
-		OwnerAccountID: d.OwnerAccountId,
+		ID:                d.Id,
+		Name:              name,
+		Category:          common.Category(d.Category),
+		Sport:             common.Sport(d.Sport),
+		Stats:             *common.NewStats(0, 0, 0), // Default stats (not persisted yet)
+		Members:           d.members(),
+		OwnerAccountID:    d.OwnerAccountId,
+		HomeArea:          d.HomeArea.toDomain(),
+		LookingForPlayers: d.LookingForPlayers,
+	}
+}
+
+func (d *HomeAreaDto) toDomain() *team.HomeArea {
+	if d == nil {
+		return nil
+	}
+	return &team.HomeArea{Locality: d.Locality, Latitude: d.Latitude, Longitude: d.Longitude}
+}
+
+// members returns the members as players carrying only their ID.
+func (d *Dto) members() []player.Entity {
+	members := make([]player.Entity, len(d.MemberIds))
+	for i, id := range d.MemberIds {
+		members[i] = player.Entity{ID: id}
 	}
+	return members
 }
 
 // extractNameFromID extracts the team name from the ID format SPORT#<sport>#NAME#<name>
@@ -3,13 +3,27 @@ package team
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/outbox"
 	"sportlink/api/domain/team"
+	ddb "sportlink/api/infrastructure/persistence/dynamodb"
+	ioutbox "sportlink/api/infrastructure/persistence/outbox"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Partitions of the team items, of the records claiming their names and of the records
+// indexing them for searches.
+const (
+	Partition       = "Entity#Team"
+	NamePartition   = "Entity#TeamName"
+	SearchPartition = "Entity#TeamSearch"
 )
 
 type RepositoryAdapter struct {
@@ -24,112 +38,408 @@ func NewRepository(client *dynamodb.Client, tableName string) team.Repository {
 	}
 }
 
-func (repo *RepositoryAdapter) Save(ctx context.Context, entity team.Entity) error {
-	dto, err := From(entity)
+// Save writes the team together with the record claiming its name, the one indexing it
+// for searches and the outbox messages, in one transaction that fails with
+// team.ErrNameTaken when another team of the sport claimed the name.
+func (repo *RepositoryAdapter) Save(ctx context.Context, entity team.Entity, messages []outbox.Message) error {
+	put, err := repo.teamPut(entity, nil)
 	if err != nil {
 		return err
 	}
-
-	av, err := attributevalue.MarshalMap(dto)
+	claim, err := repo.namePut(entity)
+	if err != nil {
+		return err
+	}
+	index, err := repo.searchPut(entity)
+	if err != nil {
+		return err
+	}
+	messageItems, err := ioutbox.BuildPuts(repo.tableName, messages)
 	if err != nil {
 		return err
 	}
 
-	_, err = repo.dbClient.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: &repo.tableName,
-		Item:      av,
+	_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append([]types.TransactWriteItem{{Put: put}, {Put: claim}, {Put: index}}, messageItems...),
 	})
+	if ddb.IsTransactionConditionFailed(err) {
+		return fmt.Errorf("team %s: %w", entity.Name, team.ErrNameTaken)
+	}
 	return err
 }
 
-func (repo *RepositoryAdapter) Update(ctx context.Context, oldID string, entity team.Entity) error {
-	if err := repo.Save(ctx, entity); err != nil {
+// Update overwrites the team and its search record while the team still has the name it
+// was read with. A rename moves the claim from the previous name to the new one, and the
+// search record to the new search name, in the same transaction as the outbox messages.
+func (repo *RepositoryAdapter) Update(ctx context.Context, previousName string, entity team.Entity, messages []outbox.Message) error {
+	readName := expression.Name("Name").Equal(expression.Value(previousName))
+	put, err := repo.teamPut(entity, &readName)
+	if err != nil {
+		return err
+	}
+	index, err := repo.searchPut(entity)
+	if err != nil {
 		return err
 	}
+	items := []types.TransactWriteItem{{Put: put}, {Put: index}}
 
-	if oldID != entity.ID {
-		key, err := attributevalue.MarshalMap(map[string]interface{}{
-			"EntityId": "Entity#Team",
-			"Id":       oldID,
-		})
+	claimAt := -1
+	if previousName != entity.Name {
+		claim, err := repo.namePut(entity)
 		if err != nil {
 			return err
 		}
-		_, err = repo.dbClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
-			TableName: aws.String(repo.tableName),
-			Key:       key,
+		release, err := repo.nameDelete(entity.WithName(previousName))
+		if err != nil {
+			return err
+		}
+		claimAt = len(items)
+		items = append(items, types.TransactWriteItem{Put: claim}, types.TransactWriteItem{Delete: release})
+	}
+	if previous := entity.WithName(previousName); previous.SearchKey() != entity.SearchKey() {
+		key, err := attributevalue.MarshalMap(map[string]string{
+			"EntityId": SearchPartition,
+			"Id":       SearchID(previous.Sport, previous.SearchKey()),
 		})
+		if err != nil {
+			return err
+		}
+		items = append(items, types.TransactWriteItem{Delete: &types.Delete{TableName: aws.String(repo.tableName), Key: key}})
+	}
+	messageItems, err := ioutbox.BuildPuts(repo.tableName, messages)
+	if err != nil {
 		return err
 	}
+	items = append(items, messageItems...)
+
+	_, err = repo.dbClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+	reasons := ddb.CancellationReasons(err)
+	switch {
+	case claimAt >= 0 && len(reasons) > claimAt && aws.ToString(reasons[claimAt].Code) == "ConditionalCheckFailed":
+		return fmt.Errorf("team %s: %w", entity.Name, team.ErrNameTaken)
+	case ddb.IsTransactionConditionFailed(err):
+		return fmt.Errorf("team %s: %w", entity.ID, common.ErrVersionConflict)
+	}
+	return err
+}
 
-	return nil
+// teamPut writes the team item, when cond holds for the stored one.
+func (repo *RepositoryAdapter) teamPut(entity team.Entity, cond *expression.ConditionBuilder) (*types.Put, error) {
+	dto, err := From(entity)
+	if err != nil {
+		return nil, err
+	}
+	av, err := attributevalue.MarshalMap(dto)
+	if err != nil {
+		return nil, err
+	}
+	put := &types.Put{TableName: aws.String(repo.tableName), Item: av}
+	if cond != nil {
+		expr, err := expression.NewBuilder().WithCondition(*cond).Build()
+		if err != nil {
+			return nil, err
+		}
+		put.ConditionExpression = expr.Condition()
+		put.ExpressionAttributeNames = expr.Names()
+		put.ExpressionAttributeValues = expr.Values()
+	}
+	return put, nil
+}
+
+// namePut claims the name of the team, unless another team claimed it.
+func (repo *RepositoryAdapter) namePut(entity team.Entity) (*types.Put, error) {
+	av, err := attributevalue.MarshalMap(NameFrom(entity))
+	if err != nil {
+		return nil, err
+	}
+	cond := expression.AttributeNotExists(expression.Name("Id")).
+		Or(expression.Name("TeamId").Equal(expression.Value(entity.ID)))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return nil, err
+	}
+	return &types.Put{
+		TableName:                 aws.String(repo.tableName),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}, nil
+}
+
+// searchPut writes the record indexing the team for searches.
+func (repo *RepositoryAdapter) searchPut(entity team.Entity) (*types.Put, error) {
+	av, err := attributevalue.MarshalMap(SearchFrom(entity))
+	if err != nil {
+		return nil, err
+	}
+	return &types.Put{TableName: aws.String(repo.tableName), Item: av}, nil
+}
+
+// nameDelete releases the name of the team, as long as the team is the one claiming it.
+func (repo *RepositoryAdapter) nameDelete(entity team.Entity) (*types.Delete, error) {
+	key, err := attributevalue.MarshalMap(map[string]string{
+		"EntityId": NamePartition,
+		"Id":       entity.NameKey(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	expr, err := expression.NewBuilder().
+		WithCondition(expression.Name("TeamId").Equal(expression.Value(entity.ID))).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return &types.Delete{
+		TableName:                 aws.String(repo.tableName),
+		Key:                       key,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}, nil
 }
 
+// Find reads the teams by ID, the teams of the owner through its GSI, the teams of each
+// sport through the names they claimed, or else every team, and keeps those matching every
+// criterion of the query.
 func (repo *RepositoryAdapter) Find(ctx context.Context, query team.DomainQuery) ([]team.Entity, error) {
-	if query.OwnerAccountID != "" {
-		return repo.findByOwner(ctx, query.OwnerAccountID)
+	var candidates []team.Entity
+	var err error
+	switch {
+	case len(query.Ids) > 0:
+		candidates, err = repo.findByIDs(ctx, query.Ids)
+	case query.OwnerAccountID != "":
+		candidates, err = repo.findByOwner(ctx, query.OwnerAccountID)
+	case len(query.Sports) > 0:
+		candidates, err = repo.findBySports(ctx, query)
+	default:
+		candidates, err = repo.findAll(ctx, query)
+	}
+	if err != nil {
+		return []team.Entity{}, err
+	}
+
+	results := make([]team.Entity, 0, len(candidates))
+	for _, entity := range candidates {
+		if query.Matches(entity) {
+			results = append(results, entity)
+		}
+	}
+	return results, nil
+}
+
+// Search reads the search records of each sport from the cursor on, in SearchKey order,
+// until one more than requested meets the query, to know whether another page follows.
+// Categories and the looking for players flag are filtered by DynamoDB; fuzzy names and
+// home areas once read. The teams of the page are then read in a batch.
+func (repo *RepositoryAdapter) Search(ctx context.Context, query team.SearchQuery) (team.Page, error) {
+	var records []SearchDto
+	for _, sport := range query.SearchedSports() {
+		found, err := repo.searchSport(ctx, sport, query)
+		if err != nil {
+			return team.Page{}, err
+		}
+		records = append(records, found...)
+	}
+	slices.SortFunc(records, func(a, b SearchDto) int {
+		return strings.Compare(a.SearchKey(), b.SearchKey())
+	})
+
+	page := team.Page{Entities: make([]team.Entity, 0, len(records))}
+	if len(records) > query.Limit {
+		records = records[:query.Limit]
+		page.NextCursor = records[query.Limit-1].SearchKey()
+	}
+	if len(records) == 0 {
+		return page, nil
+	}
+
+	ids := make([]string, len(records))
+	for i, record := range records {
+		ids[i] = record.TeamId
+	}
+	teams, err := repo.findByIDs(ctx, ids)
+	if err != nil {
+		return team.Page{}, err
+	}
+	byID := make(map[string]team.Entity, len(teams))
+	for _, entity := range teams {
+		byID[entity.ID] = entity
+	}
+	for _, id := range ids {
+		if entity, ok := byID[id]; ok {
+			page.Entities = append(page.Entities, entity)
+		}
+	}
+	return page, nil
+}
+
+// searchSport returns the first search records of the sport after the cursor meeting the
+// query, one more than its limit at most.
+func (repo *RepositoryAdapter) searchSport(ctx context.Context, sport common.Sport, query team.SearchQuery) ([]SearchDto, error) {
+	prefix := SearchID(sport, query.Prefix())
+
+	// A cursor before the prefix leaves every record of the prefix to read; one past the
+	// records of the prefix leaves none.
+	var lastKey map[string]types.AttributeValue
+	if start := SearchID(sport, query.Cursor); query.Cursor != "" && start > prefix {
+		if !strings.HasPrefix(start, prefix) {
+			return nil, nil
+		}
+		key, err := attributevalue.MarshalMap(map[string]string{"EntityId": SearchPartition, "Id": start})
+		if err != nil {
+			return nil, err
+		}
+		lastKey = key
+	}
+
+	builder := expression.NewBuilder().WithKeyCondition(expression.KeyAnd(
+		expression.KeyEqual(expression.Key("EntityId"), expression.Value(SearchPartition)),
+		expression.KeyBeginsWith(expression.Key("Id"), prefix),
+	))
+	var filters []expression.ConditionBuilder
+	if len(query.Categories) > 0 {
+		var categoryValues []expression.OperandBuilder
+		for _, c := range query.Categories {
+			categoryValues = append(categoryValues, expression.Value(int(c)))
+		}
+		filters = append(filters, expression.Name("Category").In(categoryValues[0], categoryValues[1:]...))
+	}
+	if query.LookingForPlayers {
+		filters = append(filters, expression.Name("LookingForPlayers").Equal(expression.Value(true)))
+	}
+	if len(filters) > 0 {
+		filter := filters[0]
+		for _, f := range filters[1:] {
+			filter = expression.And(filter, f)
+		}
+		builder = builder.WithFilter(filter)
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, err
 	}
 
-	keyCond := expression.KeyEqual(expression.Key("EntityId"), expression.Value("Entity#Team"))
+	records := make([]SearchDto, 0, query.Limit+1)
+	for len(records) <= query.Limit {
+		resp, err := repo.dbClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(repo.tableName),
+			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ExclusiveStartKey:         lastKey,
+			Limit:                     aws.Int32(int32(query.Limit + 1 - len(records))),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Items {
+			var dto SearchDto
+			if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal item: %w", err)
+			}
+			if query.MatchesName(dto.SearchName) && (query.Near == nil || query.Near.Covers(dto.homeArea())) {
+				records = append(records, dto)
+			}
+		}
+		if resp.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = resp.LastEvaluatedKey
+	}
+	return records, nil
+}
+
+func (repo *RepositoryAdapter) findByIDs(ctx context.Context, ids []string) ([]team.Entity, error) {
+	items, err := ddb.BatchGet(ctx, repo.dbClient, repo.tableName, Partition, ids)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalTeams(items)
+}
 
-	if query.Name != "" && len(query.Sports) > 0 {
-		// Search by the full ID format: SPORT#<sport>#NAME#<name>
-		// Use the first sport for the key condition, filter the rest if needed
-		idPrefix := fmt.Sprintf("SPORT#%s#NAME#%s", query.Sports[0], query.Name)
-		keyCond = expression.KeyAnd(keyCond, expression.KeyBeginsWith(expression.Key("Id"), idPrefix))
-	} else if query.Name != "" {
-		// If only name specified, search by name pattern (requires filter, not key condition)
-		// We'll search for any ID that starts with SPORT# and filter by name
-		keyCond = expression.KeyAnd(keyCond, expression.KeyBeginsWith(expression.Key("Id"), "SPORT#"))
-	} else if len(query.Sports) > 0 {
-		// If only sports specified, search by sport prefix
-		sportPrefix := fmt.Sprintf("SPORT#%s", query.Sports[0])
-		keyCond = expression.KeyAnd(keyCond, expression.KeyBeginsWith(expression.Key("Id"), sportPrefix))
+// findBySports reads the teams whose names each sport has claimed, narrowed down to the
+// names starting with the one of the query.
+func (repo *RepositoryAdapter) findBySports(ctx context.Context, query team.DomainQuery) ([]team.Entity, error) {
+	var ids []string
+	for _, sport := range query.Sports {
+		keyCond := expression.KeyAnd(
+			expression.KeyEqual(expression.Key("EntityId"), expression.Value(NamePartition)),
+			expression.KeyBeginsWith(expression.Key("Id"), team.NameKey(sport, query.Name)),
+		)
+		err := repo.query(ctx, expression.NewBuilder().WithKeyCondition(keyCond), func(item map[string]types.AttributeValue) error {
+			var dto NameDto
+			if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+				return fmt.Errorf("failed to unmarshal item: %w", err)
+			}
+			ids = append(ids, dto.TeamId)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return repo.findByIDs(ctx, ids)
+}
 
+func (repo *RepositoryAdapter) findAll(ctx context.Context, query team.DomainQuery) ([]team.Entity, error) {
+	keyCond := expression.KeyEqual(expression.Key("EntityId"), expression.Value(Partition))
 	builder := expression.NewBuilder().WithKeyCondition(keyCond)
 	includeFilters(query, &builder)
+
+	var results []team.Entity
+	err := repo.query(ctx, builder, func(item map[string]types.AttributeValue) error {
+		var dto Dto
+		if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+			return fmt.Errorf("failed to unmarshal item: %w", err)
+		}
+		results = append(results, dto.ToDomain())
+		return nil
+	})
+	return results, err
+}
+
+// query reads every page of the query the builder describes.
+func (repo *RepositoryAdapter) query(ctx context.Context, builder expression.Builder, each func(item map[string]types.AttributeValue) error) error {
 	expr, err := builder.Build()
 	if err != nil {
-		return []team.Entity{}, err
+		return err
 	}
-
-	resp, err := repo.dbClient.Query(ctx, &dynamodb.QueryInput{
+	paginator := dynamodb.NewQueryPaginator(repo.dbClient, &dynamodb.QueryInput{
 		TableName:                 aws.String(repo.tableName),
 		KeyConditionExpression:    expr.KeyCondition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		FilterExpression:          expr.Filter(),
 	})
-	if err != nil {
-		return []team.Entity{}, err
-	}
-
-	var results []team.Entity
-	for _, item := range resp.Items {
-		var dto Dto
-		err = attributevalue.UnmarshalMap(item, &dto)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			return []team.Entity{}, fmt.Errorf("failed to unmarshal item: %w", err)
+			return err
 		}
-		entity := dto.ToDomain()
-
-		// If only name is specified without sports, filter by name in memory
-		// (DynamoDB Contains filter may not work as expected for partial matches)
-		if query.Name != "" && len(query.Sports) == 0 {
-			if !strings.Contains(entity.Name, query.Name) {
-				continue
+		for _, item := range page.Items {
+			if err := each(item); err != nil {
+				return err
 			}
 		}
-
-		results = append(results, entity)
 	}
+	return nil
+}
 
-	// Return empty slice if no results found
-	if results == nil {
-		return []team.Entity{}, nil
+func unmarshalTeams(items []map[string]types.AttributeValue) ([]team.Entity, error) {
+	results := make([]team.Entity, 0, len(items))
+	for _, item := range items {
+		var dto Dto
+		if err := attributevalue.UnmarshalMap(item, &dto); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal item: %w", err)
+		}
+		results = append(results, dto.ToDomain())
 	}
-
 	return results, nil
 }
 
@@ -138,19 +448,37 @@ func From(entity team.Entity) (Dto, error) {
 		return Dto{}, fmt.Errorf("ID could not be empty")
 	}
 
+	var memberIDs []string
+	for _, m := range entity.Members {
+		memberIDs = append(memberIDs, m.ID)
+	}
+
+	var homeArea *HomeAreaDto
+	if entity.HomeArea != nil {
+		homeArea = &HomeAreaDto{
+			Locality:  entity.HomeArea.Locality,
+			Latitude:  entity.HomeArea.Latitude,
+			Longitude: entity.HomeArea.Longitude,
+		}
+	}
+
 	return Dto{
-		EntityId:       "Entity#Team",
-		Id:             entity.ID,
-		Name:           entity.Name,
-		Category:       int(entity.Category),
-		Sport:          string(entity.Sport),
-		OwnerAccountId: entity.OwnerAccountID,
+		EntityId:          Partition,
+		Id:                entity.ID,
+		Name:              entity.Name,
+		Category:          int(entity.Category),
+		Sport:             string(entity.Sport),
+		OwnerAccountId:    entity.OwnerAccountID,
+		MemberIds:         memberIDs,
+		SearchName:        team.SearchName(entity.Name),
+		LookingForPlayers: entity.LookingForPlayers,
+		HomeArea:          homeArea,
 	}, nil
 }
 
 func (repo *RepositoryAdapter) findByOwner(ctx context.Context, ownerAccountID string) ([]team.Entity, error) {
 	keyCond := expression.KeyEqual(expression.Key("OwnerAccountId"), expression.Value(ownerAccountID))
-	filter := expression.Name("EntityId").Equal(expression.Value("Entity#Team"))
+	filter := expression.Name("EntityId").Equal(expression.Value(Partition))
 
 	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).WithFilter(filter).Build()
 	if err != nil {
@@ -204,8 +532,11 @@ func includeFilters(query team.DomainQuery, builder *expression.Builder) {
 		filters = append(filters, expression.Name("Sport").In(sportValues[0], sportValues[1:]...))
 	}
 
-	// Note: Name filtering without sports is done in memory after query
-	// to avoid DynamoDB Contains filter limitations
+	if query.MemberAccountID != "" {
+		filters = append(filters, expression.Contains(expression.Name("MemberIds"), query.MemberAccountID))
+	}
+
+	// Name and the remaining criteria are checked by Find once the items are read
 
 	// Combine all filters with AND
 	if len(filters) > 0 {
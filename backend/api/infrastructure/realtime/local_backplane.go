@@ -0,0 +1,21 @@
+package realtime
+
+import (
+	"context"
+	"sportlink/api/application/realtime"
+)
+
+// LocalBackplane delivers updates straight to the hub of this instance. It is enough
+// while a single API instance serves every stream.
+type LocalBackplane struct {
+	hub *realtime.Hub
+}
+
+func NewLocalBackplane(hub *realtime.Hub) *LocalBackplane {
+	return &LocalBackplane{hub: hub}
+}
+
+func (b *LocalBackplane) Publish(ctx context.Context, update realtime.Update) error {
+	b.hub.Deliver(ctx, update)
+	return nil
+}
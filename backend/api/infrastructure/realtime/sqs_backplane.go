@@ -0,0 +1,83 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sportlink/api/application/messaging"
+	"sportlink/api/application/realtime"
+	"sportlink/pkg/log"
+	"time"
+)
+
+const (
+	receiveBatchSize = 10
+	receiveWait      = 10 * time.Second
+	receiveErrorWait = 5 * time.Second
+)
+
+// SQSBackplane fans updates out to several API instances through SQS. Every instance
+// owns a queue: Publish sends the update to the queue of each instance, and Start
+// drains the queue of this one into its hub. Updates are only useful while they are
+// fresh, so failed deliveries are logged and not retried.
+type SQSBackplane struct {
+	inbox messaging.Broker
+	peers []messaging.Broker
+	hub   *realtime.Hub
+}
+
+// NewSQSBackplane builds the backplane of the instance that owns inbox. peers are the
+// queues of every instance, this one included.
+func NewSQSBackplane(inbox messaging.Broker, peers []messaging.Broker, hub *realtime.Hub) *SQSBackplane {
+	return &SQSBackplane{
+		inbox: inbox,
+		peers: peers,
+		hub:   hub,
+	}
+}
+
+func (b *SQSBackplane) Publish(ctx context.Context, update realtime.Update) error {
+	body, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to serialize update %s: %w", update.ID, err)
+	}
+	for _, peer := range b.peers {
+		if err = peer.SendMessage(ctx, string(body)); err != nil {
+			return fmt.Errorf("failed to fan out update %s: %w", update.ID, err)
+		}
+	}
+	return nil
+}
+
+// Start launches the goroutine delivering the updates of this instance's queue. It
+// stops when ctx is cancelled.
+func (b *SQSBackplane) Start(ctx context.Context) {
+	go func() {
+		for ctx.Err() == nil {
+			received, err := b.inbox.Receive(ctx, receiveBatchSize, receiveWait)
+			if err != nil {
+				log.GetLogger(ctx).Error("realtime backplane failed to receive updates", err)
+				select {
+				case <-time.After(receiveErrorWait):
+				case <-ctx.Done():
+				}
+				continue
+			}
+			for _, r := range received {
+				b.deliver(ctx, r)
+			}
+		}
+	}()
+}
+
+func (b *SQSBackplane) deliver(ctx context.Context, received messaging.ReceivedMessage) {
+	var update realtime.Update
+	if err := json.Unmarshal([]byte(received.Body), &update); err != nil {
+		log.GetLogger(ctx).Error("realtime backplane received a malformed update", err)
+	} else {
+		b.hub.Deliver(ctx, update)
+	}
+	if err := b.inbox.Delete(ctx, received.ReceiptHandle); err != nil {
+		log.GetLogger(ctx).Error("realtime backplane failed to delete update "+update.ID, err)
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	accountevent "sportlink/api/application/account/events"
 	"sportlink/api/application/auth/service"
 	"sportlink/api/application/auth/usecases"
 	"sportlink/api/domain/account"
+	"sportlink/api/domain/outbox"
 	"sportlink/api/infrastructure/middleware"
 	cauth "sportlink/api/infrastructure/rest/auth"
 	authmocks "sportlink/mocks/api/application/auth/service"
@@ -53,7 +55,9 @@ func TestGoogleAuthController(t *testing.T) {
 			on: func(verifier *mockGoogleVerifier, repo *amocks.Repository, jwt *authmocks.JWTService) {
 				verifier.On("Verify", mock.Anything, "valid-token").Return(googleTokenInfo, nil)
 				repo.On("Find", mock.Anything, mock.Anything).Return([]account.Entity{}, nil)
-				repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+				repo.On("Save", mock.Anything, mock.Anything, mock.MatchedBy(func(messages []outbox.Message) bool {
+					return len(messages) == 1 && messages[0].Type == accountevent.AccountCreatedEventType
+				})).Return(nil)
 				jwt.On("Generate", mock.Anything).Return("jwt-token", nil)
 			},
 			assertions: func(t *testing.T, code int, body map[string]interface{}, resp *httptest.ResponseRecorder) {
@@ -69,7 +73,7 @@ func TestGoogleAuthController(t *testing.T) {
 			on: func(verifier *mockGoogleVerifier, repo *amocks.Repository, jwt *authmocks.JWTService) {
 				verifier.On("Verify", mock.Anything, "valid-token").Return(googleTokenInfo, nil)
 				repo.On("Find", mock.Anything, mock.Anything).Return([]account.Entity{
-					{ID: "EMAIL#user@gmail.com", AccountID: "01JQTEST0000000000000000AB", Email: "user@gmail.com"},
+					{ID: "EMAIL#user@gmail.com", AccountID: "01JQTEST0000000000000000AB", Email: "user@gmail.com", Picture: "https://photo.url"},
 				}, nil)
 				jwt.On("Generate", "01JQTEST0000000000000000AB").Return("jwt-token", nil)
 			},
@@ -79,9 +83,9 @@ func TestGoogleAuthController(t *testing.T) {
 			},
 		},
 		{
-			name:       "fails with missing id_token",
-			body:       map[string]interface{}{},
-			on:         func(verifier *mockGoogleVerifier, repo *amocks.Repository, jwt *authmocks.JWTService) {},
+			name: "fails with missing id_token",
+			body: map[string]interface{}{},
+			on:   func(verifier *mockGoogleVerifier, repo *amocks.Repository, jwt *authmocks.JWTService) {},
 			assertions: func(t *testing.T, code int, body map[string]interface{}, resp *httptest.ResponseRecorder) {
 				assert.Equal(t, http.StatusBadRequest, code)
 			},
@@ -0,0 +1,48 @@
+package chat
+
+import (
+	"sportlink/api/application"
+	"sportlink/api/application/chat/usecases"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/chat"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Controller interface {
+	FindMessages(c *gin.Context)
+	SendMessage(c *gin.Context)
+	MarkThreadRead(c *gin.Context)
+}
+
+type DefaultController struct {
+	findMessagesUC   application.UseCase[usecases.FindMessagesInput, usecases.ThreadPage]
+	sendMessageUC    application.UseCase[usecases.SendMessageInput, chat.Message]
+	markThreadReadUC application.UseCase[usecases.MarkThreadReadInput, chat.Receipt]
+	validator        *validator.Validate
+}
+
+func NewController(
+	findMessagesUC application.UseCase[usecases.FindMessagesInput, usecases.ThreadPage],
+	sendMessageUC application.UseCase[usecases.SendMessageInput, chat.Message],
+	markThreadReadUC application.UseCase[usecases.MarkThreadReadInput, chat.Receipt],
+	validator *validator.Validate,
+) Controller {
+	return &DefaultController{
+		findMessagesUC:   findMessagesUC,
+		sendMessageUC:    sendMessageUC,
+		markThreadReadUC: markThreadReadUC,
+		validator:        validator,
+	}
+}
+
+// threadParam reads the thread from the :kind ("request" or "match") and :thread_id
+// path parameters.
+func threadParam(c *gin.Context) (chat.Thread, error) {
+	kind, err := chat.ParseKind(c.Param("kind"))
+	if err != nil {
+		return chat.Thread{}, errors.RequestValidationFailed(err.Error())
+	}
+	return chat.NewThread(kind, c.Param("thread_id")), nil
+}
@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"sportlink/api/application/chat/usecases"
+	"sportlink/api/domain/chat"
+	"sportlink/api/infrastructure/rest/chat/response"
+)
+
+func MessageToResponse(message chat.Message, receipts []chat.Receipt) response.MessageResponse {
+	readBy := make([]string, 0, len(receipts))
+	for _, r := range receipts {
+		if r.AccountID != message.SenderAccountID && r.HasRead(message.ID) {
+			readBy = append(readBy, r.AccountID)
+		}
+	}
+	return response.MessageResponse{
+		ID:              message.ID,
+		SenderAccountID: message.SenderAccountID,
+		Body:            message.Body,
+		SentAt:          message.SentAt,
+		ReadBy:          readBy,
+	}
+}
+
+func ThreadPageToResponse(page usecases.ThreadPage) response.ThreadPageResponse {
+	data := make([]response.MessageResponse, len(page.Page.Entities))
+	for i, message := range page.Page.Entities {
+		data[i] = MessageToResponse(message, page.Receipts)
+	}
+	return response.ThreadPageResponse{
+		Data:       data,
+		NextCursor: page.Page.NextCursor,
+	}
+}
+
+func ReceiptToResponse(receipt chat.Receipt) response.ReceiptResponse {
+	r := response.ReceiptResponse{
+		AccountID:         receipt.AccountID,
+		LastReadMessageID: receipt.LastReadMessageID,
+	}
+	if !receipt.ReadAt.IsZero() {
+		readAt := receipt.ReadAt
+		r.ReadAt = &readAt
+	}
+	return r
+}
@@ -0,0 +1,109 @@
+package chat
+
+import (
+	"net/http"
+	"sportlink/api/application/chat/request"
+	"sportlink/api/application/chat/usecases"
+	"sportlink/api/application/errors"
+	"sportlink/api/infrastructure/rest/chat/mapper"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FindMessages handles GET /account/:account_id/chat/:kind/:thread_id/message
+// Returns the thread newest first with who read each message. Supports limit and
+// cursor query parameters.
+func (sc *DefaultController) FindMessages(c *gin.Context) {
+	thread, err := threadParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := usecases.FindMessagesInput{
+		Thread:    thread,
+		AccountID: c.Param("account_id"),
+		Cursor:    c.Query("cursor"),
+	}
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			c.Error(errors.RequestValidationFailed("limit must be a positive number"))
+			return
+		}
+		input.Limit = parsed
+	}
+
+	result, err := sc.findMessagesUC.Invoke(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ThreadPageToResponse(*result))
+}
+
+// SendMessage handles POST /account/:account_id/chat/:kind/:thread_id/message
+func (sc *DefaultController) SendMessage(c *gin.Context) {
+	thread, err := threadParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var sendRequest request.SendMessageRequest
+	if err := c.ShouldBindJSON(&sendRequest); err != nil {
+		c.Error(errors.InvalidRequestFormat())
+		return
+	}
+	if err := sc.validator.Struct(sendRequest); err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+
+	result, err := sc.sendMessageUC.Invoke(c.Request.Context(), usecases.SendMessageInput{
+		Thread:    thread,
+		AccountID: c.Param("account_id"),
+		Body:      sendRequest.Body,
+		Now:       time.Now(),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.MessageToResponse(*result, nil))
+}
+
+// MarkThreadRead handles POST /account/:account_id/chat/:kind/:thread_id/read
+// Moves the read receipt of the account forward; an empty body marks the whole thread read.
+func (sc *DefaultController) MarkThreadRead(c *gin.Context) {
+	thread, err := threadParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var readRequest request.MarkThreadReadRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&readRequest); err != nil {
+			c.Error(errors.InvalidRequestFormat())
+			return
+		}
+	}
+
+	result, err := sc.markThreadReadUC.Invoke(c.Request.Context(), usecases.MarkThreadReadInput{
+		Thread:    thread,
+		AccountID: c.Param("account_id"),
+		MessageID: readRequest.MessageID,
+		Now:       time.Now(),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ReceiptToResponse(*result))
+}
@@ -0,0 +1,25 @@
+package response
+
+import "time"
+
+type MessageResponse struct {
+	ID              string    `json:"id"`
+	SenderAccountID string    `json:"sender_account_id"`
+	Body            string    `json:"body"`
+	SentAt          time.Time `json:"sent_at"`
+	ReadBy          []string  `json:"read_by"` // members other than the sender who read the message
+}
+
+// ThreadPageResponse is one page of a thread, newest messages first. NextCursor is
+// passed back as the cursor query parameter to get older messages; it is absent on
+// the last page.
+type ThreadPageResponse struct {
+	Data       []MessageResponse `json:"data"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+type ReceiptResponse struct {
+	AccountID         string     `json:"account_id"`
+	LastReadMessageID string     `json:"last_read_message_id,omitempty"`
+	ReadAt            *time.Time `json:"read_at,omitempty"`
+}
@@ -0,0 +1,25 @@
+package history
+
+import (
+	"sportlink/api/application"
+	"sportlink/api/application/history/usecases"
+	"sportlink/api/domain/history"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Controller interface {
+	FindMatchOfferHistory(c *gin.Context)
+	FindMatchRequestHistory(c *gin.Context)
+	FindMatchHistory(c *gin.Context)
+}
+
+type DefaultController struct {
+	findHistoryUC application.UseCase[usecases.FindHistoryInput, []history.Entry]
+}
+
+func NewController(findHistoryUC application.UseCase[usecases.FindHistoryInput, []history.Entry]) Controller {
+	return &DefaultController{
+		findHistoryUC: findHistoryUC,
+	}
+}
@@ -0,0 +1,40 @@
+package history
+
+import (
+	"net/http"
+	"sportlink/api/application/history/usecases"
+	"sportlink/api/domain/history"
+	"sportlink/api/infrastructure/rest/history/mapper"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FindMatchOfferHistory handles GET /account/:account_id/match-offer/:offer_id/history
+func (sc *DefaultController) FindMatchOfferHistory(c *gin.Context) {
+	sc.findHistory(c, history.KindMatchOffer, c.Param("offer_id"))
+}
+
+// FindMatchRequestHistory handles GET /account/:account_id/match-request/:request_id/history
+func (sc *DefaultController) FindMatchRequestHistory(c *gin.Context) {
+	sc.findHistory(c, history.KindMatchRequest, c.Param("request_id"))
+}
+
+// FindMatchHistory handles GET /account/:account_id/match/:match_id/history
+func (sc *DefaultController) FindMatchHistory(c *gin.Context) {
+	sc.findHistory(c, history.KindMatch, c.Param("match_id"))
+}
+
+// findHistory returns the status changes of the entity, oldest first.
+func (sc *DefaultController) findHistory(c *gin.Context, kind history.Kind, entityID string) {
+	result, err := sc.findHistoryUC.Invoke(c.Request.Context(), usecases.FindHistoryInput{
+		Kind:      kind,
+		EntityID:  entityID,
+		AccountID: c.Param("account_id"),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.EntriesToResponse(*result))
+}
@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	"sportlink/api/domain/history"
+	"sportlink/api/infrastructure/rest/history/response"
+)
+
+func EntriesToResponse(entries []history.Entry) response.HistoryResponse {
+	data := make([]response.HistoryEntryResponse, len(entries))
+	for i, entry := range entries {
+		data[i] = response.HistoryEntryResponse{
+			ID:         entry.ID,
+			From:       entry.From,
+			To:         entry.To,
+			Actor:      string(entry.Actor),
+			AccountID:  entry.AccountID,
+			Reason:     entry.Reason,
+			Source:     string(entry.Source),
+			OccurredAt: entry.OccurredAt,
+		}
+	}
+	return response.HistoryResponse{Data: data}
+}
@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+// HistoryEntryResponse is one status change. account_id is absent when the system made
+// the change.
+type HistoryEntryResponse struct {
+	ID         string    `json:"id"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Actor      string    `json:"actor"`
+	AccountID  string    `json:"account_id,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Source     string    `json:"source"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type HistoryResponse struct {
+	Data []HistoryEntryResponse `json:"data"`
+}
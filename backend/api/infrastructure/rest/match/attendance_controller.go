@@ -0,0 +1,41 @@
+package match
+
+import (
+	"net/http"
+	"sportlink/api/application/match/usecases"
+	"sportlink/api/infrastructure/rest/match/mapper"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfirmAttendance handles POST /account/:account_id/match/:match_id/attendance
+// The one-tap "I'm still coming" answer to a match reminder.
+func (sc *DefaultController) ConfirmAttendance(c *gin.Context) {
+	result, err := sc.confirmAttendanceUC.Invoke(c.Request.Context(), usecases.ConfirmAttendanceInput{
+		MatchID:   c.Param("match_id"),
+		AccountID: c.Param("account_id"),
+		Now:       time.Now(),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ConfirmationToResponse(*result))
+}
+
+// FindMatchAttendance handles GET /account/:account_id/match/:match_id/attendance
+// Only the owner of the match offer can see who has not confirmed they are coming.
+func (sc *DefaultController) FindMatchAttendance(c *gin.Context) {
+	result, err := sc.findMatchAttendanceUC.Invoke(c.Request.Context(), usecases.FindMatchAttendanceInput{
+		MatchID:        c.Param("match_id"),
+		OwnerAccountID: c.Param("account_id"),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.AttendanceToResponse(*result))
+}
@@ -0,0 +1,123 @@
+package match_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "sportlink/api/application/errors"
+	"sportlink/api/application/match/usecases"
+	domainmatch "sportlink/api/domain/match"
+	cmatches "sportlink/api/infrastructure/rest/match"
+	amocks "sportlink/mocks/api/application"
+)
+
+type ConfirmAttendanceUCMock = amocks.UseCase[usecases.ConfirmAttendanceInput, domainmatch.Attendance]
+type FindMatchAttendanceUCMock = amocks.UseCase[usecases.FindMatchAttendanceInput, usecases.MatchAttendance]
+
+func TestConfirmAttendance(t *testing.T) {
+	confirmedAt := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		on   func(t *testing.T, uc *ConfirmAttendanceUCMock)
+		then func(t *testing.T, code int, body map[string]interface{})
+	}{
+		{
+			name: "given a participant when confirming attendance then returns the confirmation",
+			on: func(t *testing.T, uc *ConfirmAttendanceUCMock) {
+				uc.On("Invoke", mock.Anything, mock.MatchedBy(func(in usecases.ConfirmAttendanceInput) bool {
+					return in.MatchID == "01MATCH001" && in.AccountID == "player-1" && !in.Now.IsZero()
+				})).Return(&domainmatch.Attendance{MatchID: "01MATCH001", AccountID: "player-1", ConfirmedAt: confirmedAt}, nil)
+			},
+			then: func(t *testing.T, code int, body map[string]interface{}) {
+				assert.Equal(t, http.StatusOK, code)
+				assert.Equal(t, "player-1", body["account_id"])
+				assert.Equal(t, "2026-05-10T09:00:00Z", body["confirmed_at"])
+			},
+		},
+		{
+			name: "given account outside the match when confirming attendance then returns unauthorized",
+			on: func(t *testing.T, uc *ConfirmAttendanceUCMock) {
+				uc.On("Invoke", mock.Anything, mock.Anything).Return(nil, apperrors.Unauthorized("account is not a participant of the match"))
+			},
+			then: func(t *testing.T, code int, body map[string]interface{}) {
+				assert.Equal(t, http.StatusUnauthorized, code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			ucMock := amocks.NewUseCase[usecases.ConfirmAttendanceInput, domainmatch.Attendance](t)
+			controller := cmatches.NewController(nil, nil, nil, ucMock, nil, nil, nil, nil)
+			tc.on(t, ucMock)
+
+			// when
+			code, body := serve(controller.ConfirmAttendance, http.MethodPost, "/account/:account_id/match/:match_id/attendance", "/account/player-1/match/01MATCH001/attendance", "")
+
+			// then
+			tc.then(t, code, body)
+		})
+	}
+}
+
+func TestFindMatchAttendance(t *testing.T) {
+	confirmedAt := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		on   func(t *testing.T, uc *FindMatchAttendanceUCMock)
+		then func(t *testing.T, code int, body map[string]interface{})
+	}{
+		{
+			name: "given the owner of the offer when finding attendance then returns who confirmed and who did not",
+			on: func(t *testing.T, uc *FindMatchAttendanceUCMock) {
+				uc.On("Invoke", mock.Anything, usecases.FindMatchAttendanceInput{MatchID: "01MATCH001", OwnerAccountID: "owner-1"}).
+					Return(&usecases.MatchAttendance{
+						MatchID:     "01MATCH001",
+						Confirmed:   []domainmatch.Attendance{{MatchID: "01MATCH001", AccountID: "owner-1", ConfirmedAt: confirmedAt}},
+						Unconfirmed: []string{"player-1"},
+					}, nil)
+			},
+			then: func(t *testing.T, code int, body map[string]interface{}) {
+				assert.Equal(t, http.StatusOK, code)
+				assert.Equal(t, "01MATCH001", body["match_id"])
+				assert.Len(t, body["confirmed"], 1)
+				assert.Equal(t, []interface{}{"player-1"}, body["unconfirmed"])
+			},
+		},
+		{
+			name: "given an account other than the owner when finding attendance then returns unauthorized",
+			on: func(t *testing.T, uc *FindMatchAttendanceUCMock) {
+				uc.On("Invoke", mock.Anything, mock.Anything).Return(nil, apperrors.Unauthorized("only the owner of the match offer can see attendance"))
+			},
+			then: func(t *testing.T, code int, body map[string]interface{}) {
+				assert.Equal(t, http.StatusUnauthorized, code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			ucMock := amocks.NewUseCase[usecases.FindMatchAttendanceInput, usecases.MatchAttendance](t)
+			controller := cmatches.NewController(nil, nil, nil, nil, ucMock, nil, nil, nil)
+			tc.on(t, ucMock)
+
+			// when
+			code, body := serve(controller.FindMatchAttendance, http.MethodGet, "/account/:account_id/match/:match_id/attendance", "/account/owner-1/match/01MATCH001/attendance", "")
+
+			// then
+			tc.then(t, code, body)
+		})
+	}
+}
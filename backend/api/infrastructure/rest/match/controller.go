@@ -3,18 +3,51 @@ package match
 import (
 	"sportlink/api/application"
 	"sportlink/api/application/match/usecases"
+	"sportlink/api/domain/match"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 type Controller interface {
 	FindMatches(c *gin.Context)
+	PayMatchShare(c *gin.Context)
+	FindMatchPayment(c *gin.Context)
+	ConfirmAttendance(c *gin.Context)
+	FindMatchAttendance(c *gin.Context)
+	RecordMatchResult(c *gin.Context)
+	CancelMatch(c *gin.Context)
 }
 
 type DefaultController struct {
-	findMatchesUC application.UseCase[usecases.FindMatchesInput, []usecases.MatchWithOffer]
+	findMatchesUC         application.UseCase[usecases.FindMatchesInput, []usecases.MatchWithOffer]
+	payMatchShareUC       application.UseCase[usecases.PayMatchShareInput, match.Entity]
+	findMatchPaymentUC    application.UseCase[usecases.FindMatchPaymentInput, match.Payment]
+	confirmAttendanceUC   application.UseCase[usecases.ConfirmAttendanceInput, match.Attendance]
+	findMatchAttendanceUC application.UseCase[usecases.FindMatchAttendanceInput, usecases.MatchAttendance]
+	recordMatchResultUC   application.UseCase[usecases.RecordMatchResultInput, match.Entity]
+	cancelMatchUC         application.UseCase[usecases.CancelMatchInput, match.Entity]
+	validator             *validator.Validate
 }
 
-func NewController(findMatchesUC application.UseCase[usecases.FindMatchesInput, []usecases.MatchWithOffer]) Controller {
-	return &DefaultController{findMatchesUC: findMatchesUC}
+func NewController(
+	findMatchesUC application.UseCase[usecases.FindMatchesInput, []usecases.MatchWithOffer],
+	payMatchShareUC application.UseCase[usecases.PayMatchShareInput, match.Entity],
+	findMatchPaymentUC application.UseCase[usecases.FindMatchPaymentInput, match.Payment],
+	confirmAttendanceUC application.UseCase[usecases.ConfirmAttendanceInput, match.Attendance],
+	findMatchAttendanceUC application.UseCase[usecases.FindMatchAttendanceInput, usecases.MatchAttendance],
+	recordMatchResultUC application.UseCase[usecases.RecordMatchResultInput, match.Entity],
+	cancelMatchUC application.UseCase[usecases.CancelMatchInput, match.Entity],
+	validator *validator.Validate,
+) Controller {
+	return &DefaultController{
+		findMatchesUC:         findMatchesUC,
+		payMatchShareUC:       payMatchShareUC,
+		findMatchPaymentUC:    findMatchPaymentUC,
+		confirmAttendanceUC:   confirmAttendanceUC,
+		findMatchAttendanceUC: findMatchAttendanceUC,
+		recordMatchResultUC:   recordMatchResultUC,
+		cancelMatchUC:         cancelMatchUC,
+		validator:             validator,
+	}
 }
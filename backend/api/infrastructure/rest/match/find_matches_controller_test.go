@@ -20,7 +20,7 @@ import (
 	amocks "sportlink/mocks/api/application"
 )
 
-type FindMatchesUCMock = amocks.UseCase[usecases.FindMatchesInput, []domainmatch.Entity]
+type FindMatchesUCMock = amocks.UseCase[usecases.FindMatchesInput, []usecases.MatchWithOffer]
 
 func TestFindMatches(t *testing.T) {
 	fixedDay := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
@@ -46,7 +46,7 @@ func TestFindMatches(t *testing.T) {
 			accountID:   "owner-1",
 			queryParams: map[string]string{},
 			on: func(t *testing.T, uc *FindMatchesUCMock) {
-				matches := []domainmatch.Entity{acceptedMatch}
+				matches := []usecases.MatchWithOffer{{Match: acceptedMatch}}
 				uc.On("Invoke",
 					mock.Anything,
 					mock.MatchedBy(func(in usecases.FindMatchesInput) bool {
@@ -71,7 +71,7 @@ func TestFindMatches(t *testing.T) {
 			accountID:   "owner-1",
 			queryParams: map[string]string{"statuses": "ACCEPTED"},
 			on: func(t *testing.T, uc *FindMatchesUCMock) {
-				matches := []domainmatch.Entity{acceptedMatch}
+				matches := []usecases.MatchWithOffer{{Match: acceptedMatch}}
 				uc.On("Invoke",
 					mock.Anything,
 					mock.MatchedBy(func(in usecases.FindMatchesInput) bool {
@@ -92,7 +92,7 @@ func TestFindMatches(t *testing.T) {
 			accountID:   "owner-1",
 			queryParams: map[string]string{"statuses": "ACCEPTED,PLAYED"},
 			on: func(t *testing.T, uc *FindMatchesUCMock) {
-				matches := []domainmatch.Entity{acceptedMatch}
+				matches := []usecases.MatchWithOffer{{Match: acceptedMatch}}
 				uc.On("Invoke",
 					mock.Anything,
 					mock.MatchedBy(func(in usecases.FindMatchesInput) bool {
@@ -114,7 +114,7 @@ func TestFindMatches(t *testing.T) {
 			accountID:   "owner-1",
 			queryParams: map[string]string{},
 			on: func(t *testing.T, uc *FindMatchesUCMock) {
-				empty := []domainmatch.Entity{}
+				empty := []usecases.MatchWithOffer{}
 				uc.On("Invoke",
 					mock.Anything,
 					mock.MatchedBy(func(in usecases.FindMatchesInput) bool {
@@ -165,8 +165,8 @@ func TestFindMatches(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			ucMock := amocks.NewUseCase[usecases.FindMatchesInput, []domainmatch.Entity](t)
-			controller := cmatches.NewController(ucMock)
+			ucMock := amocks.NewUseCase[usecases.FindMatchesInput, []usecases.MatchWithOffer](t)
+			controller := cmatches.NewController(ucMock, nil, nil, nil, nil, nil, nil, nil)
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"sportlink/api/application/match/usecases"
 	"sportlink/api/domain/match"
 	"sportlink/api/domain/matchoffer"
 	"sportlink/api/infrastructure/rest/match/response"
@@ -16,6 +17,11 @@ func EntityToResponse(entity match.Entity, offer *matchoffer.Entity) response.Ma
 		CreatedAt:    entity.CreatedAt,
 	}
 
+	if entity.Payment != nil {
+		payment := PaymentToResponse(*entity.Payment)
+		r.Payment = &payment
+	}
+
 	if offer != nil {
 		r.Title = offer.GetTitle()
 		r.TimeSlot = &response.TimeSlotResponse{
@@ -26,3 +32,45 @@ func EntityToResponse(entity match.Entity, offer *matchoffer.Entity) response.Ma
 
 	return r
 }
+
+func PaymentToResponse(payment match.Payment) response.PaymentResponse {
+	shares := make([]response.ShareResponse, len(payment.Shares))
+	for i, s := range payment.Shares {
+		shares[i] = response.ShareResponse{
+			AccountID: s.AccountID,
+			Amount:    s.Amount,
+			Status:    string(s.Status),
+			PaidAt:    s.PaidAt,
+		}
+	}
+	var refunds []response.RefundResponse
+	for _, r := range payment.Refunds {
+		refunds = append(refunds, response.RefundResponse{AccountID: r.AccountID, Amount: r.Amount})
+	}
+	return response.PaymentResponse{
+		Total:             payment.Total,
+		Currency:          payment.Currency,
+		OutstandingAmount: payment.OutstandingAmount(),
+		Shares:            shares,
+		Refunds:           refunds,
+	}
+}
+
+func AttendanceToResponse(attendance usecases.MatchAttendance) response.AttendanceResponse {
+	confirmed := make([]response.ConfirmationResponse, len(attendance.Confirmed))
+	for i, a := range attendance.Confirmed {
+		confirmed[i] = ConfirmationToResponse(a)
+	}
+	return response.AttendanceResponse{
+		MatchID:     attendance.MatchID,
+		Confirmed:   confirmed,
+		Unconfirmed: attendance.Unconfirmed,
+	}
+}
+
+func ConfirmationToResponse(attendance match.Attendance) response.ConfirmationResponse {
+	return response.ConfirmationResponse{
+		AccountID:   attendance.AccountID,
+		ConfirmedAt: attendance.ConfirmedAt,
+	}
+}
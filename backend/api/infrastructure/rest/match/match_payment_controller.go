@@ -0,0 +1,39 @@
+package match
+
+import (
+	"net/http"
+	"sportlink/api/application/match/usecases"
+	"sportlink/api/infrastructure/rest/match/mapper"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PayMatchShare handles POST /account/:account_id/match/:match_id/payment
+// Charges the account's share of the court cost and returns the updated match.
+func (sc *DefaultController) PayMatchShare(c *gin.Context) {
+	result, err := sc.payMatchShareUC.Invoke(c.Request.Context(), usecases.PayMatchShareInput{
+		MatchID:   c.Param("match_id"),
+		AccountID: c.Param("account_id"),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.EntityToResponse(*result, nil))
+}
+
+// FindMatchPayment handles GET /account/:account_id/match/:match_id/payment
+// Only the owner of the match offer can see who still owes their share.
+func (sc *DefaultController) FindMatchPayment(c *gin.Context) {
+	result, err := sc.findMatchPaymentUC.Invoke(c.Request.Context(), usecases.FindMatchPaymentInput{
+		MatchID:        c.Param("match_id"),
+		OwnerAccountID: c.Param("account_id"),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.PaymentToResponse(*result))
+}
@@ -0,0 +1,159 @@
+package match_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "sportlink/api/application/errors"
+	"sportlink/api/application/match/usecases"
+	domainmatch "sportlink/api/domain/match"
+	"sportlink/api/infrastructure/middleware"
+	cmatches "sportlink/api/infrastructure/rest/match"
+	amocks "sportlink/mocks/api/application"
+)
+
+type PayMatchShareUCMock = amocks.UseCase[usecases.PayMatchShareInput, domainmatch.Entity]
+type FindMatchPaymentUCMock = amocks.UseCase[usecases.FindMatchPaymentInput, domainmatch.Payment]
+
+var fixedPaidAt = time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+
+func TestPayMatchShare(t *testing.T) {
+	paidMatch, _ := domainmatch.Entity{
+		ID:           "01MATCH001",
+		Participants: []string{"owner-1", "player-1"},
+		Status:       domainmatch.StatusAccepted,
+	}.SplitCost(2000, "ARS").MarkSharePaid("player-1", "ref-1", fixedPaidAt)
+
+	testCases := []struct {
+		name string
+		on   func(t *testing.T, uc *PayMatchShareUCMock)
+		then func(t *testing.T, code int, body map[string]interface{})
+	}{
+		{
+			name: "given pending share when paying then returns the match with the share paid",
+			on: func(t *testing.T, uc *PayMatchShareUCMock) {
+				uc.On("Invoke", mock.Anything, usecases.PayMatchShareInput{MatchID: "01MATCH001", AccountID: "player-1"}).
+					Return(&paidMatch, nil)
+			},
+			then: func(t *testing.T, code int, body map[string]interface{}) {
+				assert.Equal(t, http.StatusOK, code)
+				payment := body["payment"].(map[string]interface{})
+				assert.Equal(t, float64(1000), payment["outstanding_amount"])
+				shares := payment["shares"].([]interface{})
+				assert.Equal(t, "PAID", shares[1].(map[string]interface{})["status"])
+			},
+		},
+		{
+			name: "given share already paid when paying then returns conflict",
+			on: func(t *testing.T, uc *PayMatchShareUCMock) {
+				uc.On("Invoke", mock.Anything, mock.Anything).Return(nil, apperrors.UseCaseExecutionFailed("share is already paid"))
+			},
+			then: func(t *testing.T, code int, body map[string]interface{}) {
+				assert.Equal(t, http.StatusConflict, code)
+				assert.Equal(t, "use_case_execution_error", body["code"])
+			},
+		},
+		{
+			name: "given account outside the match when paying then returns unauthorized",
+			on: func(t *testing.T, uc *PayMatchShareUCMock) {
+				uc.On("Invoke", mock.Anything, mock.Anything).Return(nil, apperrors.Unauthorized("account is not a participant of the match"))
+			},
+			then: func(t *testing.T, code int, body map[string]interface{}) {
+				assert.Equal(t, http.StatusUnauthorized, code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			ucMock := amocks.NewUseCase[usecases.PayMatchShareInput, domainmatch.Entity](t)
+			controller := cmatches.NewController(nil, ucMock, nil, nil, nil, nil, nil, nil)
+			tc.on(t, ucMock)
+
+			// when
+			code, body := serve(controller.PayMatchShare, http.MethodPost, "/account/:account_id/match/:match_id/payment", "/account/player-1/match/01MATCH001/payment", "")
+
+			// then
+			tc.then(t, code, body)
+		})
+	}
+}
+
+func TestFindMatchPayment(t *testing.T) {
+	payment := domainmatch.NewPayment(3000, "ARS", []string{"owner-1", "player-1", "player-2"})
+
+	testCases := []struct {
+		name string
+		on   func(t *testing.T, uc *FindMatchPaymentUCMock)
+		then func(t *testing.T, code int, body map[string]interface{})
+	}{
+		{
+			name: "given the owner of the offer when finding the payment then returns the shares still owed",
+			on: func(t *testing.T, uc *FindMatchPaymentUCMock) {
+				uc.On("Invoke", mock.Anything, usecases.FindMatchPaymentInput{MatchID: "01MATCH001", OwnerAccountID: "owner-1"}).
+					Return(payment, nil)
+			},
+			then: func(t *testing.T, code int, body map[string]interface{}) {
+				assert.Equal(t, http.StatusOK, code)
+				assert.Equal(t, float64(3000), body["outstanding_amount"])
+				assert.Len(t, body["shares"], 3)
+			},
+		},
+		{
+			name: "given match not found when finding the payment then returns not found",
+			on: func(t *testing.T, uc *FindMatchPaymentUCMock) {
+				uc.On("Invoke", mock.Anything, mock.Anything).Return(nil, apperrors.NotFound("match not found"))
+			},
+			then: func(t *testing.T, code int, body map[string]interface{}) {
+				assert.Equal(t, http.StatusNotFound, code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			ucMock := amocks.NewUseCase[usecases.FindMatchPaymentInput, domainmatch.Payment](t)
+			controller := cmatches.NewController(nil, nil, ucMock, nil, nil, nil, nil, nil)
+			tc.on(t, ucMock)
+
+			// when
+			code, body := serve(controller.FindMatchPayment, http.MethodGet, "/account/:account_id/match/:match_id/payment", "/account/owner-1/match/01MATCH001/payment", "")
+
+			// then
+			tc.then(t, code, body)
+		})
+	}
+}
+
+// serve routes a single request to handler and decodes the JSON object it answers with.
+func serve(handler gin.HandlerFunc, method, route, path, body string) (int, map[string]interface{}) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+	r.Handle(method, route, handler)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	var decoded map[string]interface{}
+	if rec.Body.Len() > 0 {
+		_ = json.Unmarshal(rec.Body.Bytes(), &decoded)
+	}
+	return rec.Code, decoded
+}
@@ -0,0 +1,57 @@
+package match
+
+import (
+	"net/http"
+	"sportlink/api/application/errors"
+	apprequest "sportlink/api/application/match/request"
+	"sportlink/api/application/match/usecases"
+	"sportlink/api/domain/match"
+	"sportlink/api/infrastructure/rest/match/mapper"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecordMatchResult handles POST /account/:account_id/match/:match_id/result
+// A participant records the score once the match kicked off, marking it as played.
+func (sc *DefaultController) RecordMatchResult(c *gin.Context) {
+	var req apprequest.RecordMatchResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.InvalidRequestFormat())
+		return
+	}
+	if err := sc.validator.Struct(req); err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+
+	result, err := sc.recordMatchResultUC.Invoke(c.Request.Context(), usecases.RecordMatchResultInput{
+		MatchID:         c.Param("match_id"),
+		AccountID:       c.Param("account_id"),
+		Result:          match.Result{LocalScore: *req.LocalScore, VisitorScore: *req.VisitorScore},
+		WinnerAccountID: req.WinnerAccountID,
+		Now:             time.Now(),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.EntityToResponse(*result, nil))
+}
+
+// CancelMatch handles POST /account/:account_id/match/:match_id/cancel
+// Only the owner of the match offer can call the match off, and only before kickoff.
+func (sc *DefaultController) CancelMatch(c *gin.Context) {
+	result, err := sc.cancelMatchUC.Invoke(c.Request.Context(), usecases.CancelMatchInput{
+		MatchID:        c.Param("match_id"),
+		OwnerAccountID: c.Param("account_id"),
+		Now:            time.Now(),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.EntityToResponse(*result, nil))
+}
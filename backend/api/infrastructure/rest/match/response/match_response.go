@@ -15,5 +15,40 @@ type MatchResponse struct {
 	TimeSlot     *TimeSlotResponse `json:"time_slot,omitempty"`
 	Title        string            `json:"title,omitempty"`
 	Status       string            `json:"status"`
+	Payment      *PaymentResponse  `json:"payment,omitempty"`
 	CreatedAt    time.Time         `json:"created_at"`
 }
+
+// PaymentResponse shows how the court cost is split and what is still owed
+type PaymentResponse struct {
+	Total             int64            `json:"total"`
+	Currency          string           `json:"currency"`
+	OutstandingAmount int64            `json:"outstanding_amount"`
+	Shares            []ShareResponse  `json:"shares"`
+	Refunds           []RefundResponse `json:"refunds,omitempty"`
+}
+
+type ShareResponse struct {
+	AccountID string     `json:"account_id"`
+	Amount    int64      `json:"amount"`
+	Status    string     `json:"status"`
+	PaidAt    *time.Time `json:"paid_at,omitempty"`
+}
+
+// RefundResponse is a paid share owed back to a participant who left the match
+type RefundResponse struct {
+	AccountID string `json:"account_id"`
+	Amount    int64  `json:"amount"`
+}
+
+// AttendanceResponse tells the organiser who confirmed they are still coming
+type AttendanceResponse struct {
+	MatchID     string                 `json:"match_id"`
+	Confirmed   []ConfirmationResponse `json:"confirmed"`
+	Unconfirmed []string               `json:"unconfirmed"`
+}
+
+type ConfirmationResponse struct {
+	AccountID   string    `json:"account_id"`
+	ConfirmedAt time.Time `json:"confirmed_at"`
+}
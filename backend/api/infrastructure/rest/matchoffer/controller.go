@@ -18,6 +18,8 @@ type Controller interface {
 	RetrieveMatchOffer(c *gin.Context)
 	DeleteMatchOffer(c *gin.Context)
 	ConfirmMatchOffer(c *gin.Context)
+	CreateShareLink(c *gin.Context)
+	RevokeShareLinks(c *gin.Context)
 }
 
 type DefaultController struct {
@@ -27,6 +29,8 @@ type DefaultController struct {
 	retrieveMatchOfferUC     *usecases.RetrieveMatchOfferUC
 	deleteMatchOfferUC       *usecases.DeleteMatchOfferUC
 	confirmMatchOfferUC      application.UseCase[usecases.ConfirmMatchOfferInput, domainmatch.Entity]
+	createShareLinkUC        application.UseCase[usecases.CreateShareLinkInput, usecases.ShareLinkResult]
+	revokeShareLinksUC       application.UseCase[usecases.RevokeShareLinksInput, matchoffer.Entity]
 	validator                *validator.Validate
 	queryParser              parser.QueryParser
 }
@@ -38,6 +42,8 @@ func NewController(
 	retrieveMatchOfferUC *usecases.RetrieveMatchOfferUC,
 	deleteMatchOfferUC *usecases.DeleteMatchOfferUC,
 	confirmMatchOfferUC application.UseCase[usecases.ConfirmMatchOfferInput, domainmatch.Entity],
+	createShareLinkUC application.UseCase[usecases.CreateShareLinkInput, usecases.ShareLinkResult],
+	revokeShareLinksUC application.UseCase[usecases.RevokeShareLinksInput, matchoffer.Entity],
 	validator *validator.Validate,
 ) Controller {
 	return NewControllerWithParser(
@@ -47,6 +53,8 @@ func NewController(
 		retrieveMatchOfferUC,
 		deleteMatchOfferUC,
 		confirmMatchOfferUC,
+		createShareLinkUC,
+		revokeShareLinksUC,
 		validator,
 		nil,
 	)
@@ -62,6 +70,8 @@ func NewControllerWithParser(
 	retrieveMatchOfferUC *usecases.RetrieveMatchOfferUC,
 	deleteMatchOfferUC *usecases.DeleteMatchOfferUC,
 	confirmMatchOfferUC application.UseCase[usecases.ConfirmMatchOfferInput, domainmatch.Entity],
+	createShareLinkUC application.UseCase[usecases.CreateShareLinkInput, usecases.ShareLinkResult],
+	revokeShareLinksUC application.UseCase[usecases.RevokeShareLinksInput, matchoffer.Entity],
 	validator *validator.Validate,
 	queryParser parser.QueryParser,
 ) Controller {
@@ -75,6 +85,8 @@ func NewControllerWithParser(
 		retrieveMatchOfferUC:     retrieveMatchOfferUC,
 		deleteMatchOfferUC:       deleteMatchOfferUC,
 		confirmMatchOfferUC:      confirmMatchOfferUC,
+		createShareLinkUC:        createShareLinkUC,
+		revokeShareLinksUC:       revokeShareLinksUC,
 		validator:                validator,
 		queryParser:              queryParser,
 	}
@@ -0,0 +1,41 @@
+package matchoffer
+
+import (
+	"net/http"
+	"sportlink/api/application/matchoffer/usecases"
+	"sportlink/api/infrastructure/rest/matchoffer/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateShareLink handles POST /account/:account_id/match-offer/:offer_id/share-link
+func (c *DefaultController) CreateShareLink(ctx *gin.Context) {
+	result, err := c.createShareLinkUC.Invoke(ctx.Request.Context(), usecases.CreateShareLinkInput{
+		MatchOfferID:   ctx.Param("offer_id"),
+		OwnerAccountID: ctx.Param("account_id"),
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.ShareLinkResponse{
+		MatchOfferID: result.MatchOfferID,
+		ShareToken:   result.Token,
+		ExpiresAt:    result.ExpiresAt,
+	})
+}
+
+// RevokeShareLinks handles DELETE /account/:account_id/match-offer/:offer_id/share-link
+func (c *DefaultController) RevokeShareLinks(ctx *gin.Context) {
+	_, err := c.revokeShareLinksUC.Invoke(ctx.Request.Context(), usecases.RevokeShareLinksInput{
+		MatchOfferID:   ctx.Param("offer_id"),
+		OwnerAccountID: ctx.Param("account_id"),
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
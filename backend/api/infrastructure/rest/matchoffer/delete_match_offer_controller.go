@@ -1,8 +1,10 @@
 package matchoffer
 
 import (
+	stderrors "errors"
 	"net/http"
 	"sportlink/api/application/errors"
+	"sportlink/api/application/matchoffer/usecases"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,9 +17,16 @@ func (sc *DefaultController) DeleteMatchOffer(c *gin.Context) {
 		return
 	}
 
-	err := sc.deleteMatchOfferUC.Invoke(c.Request.Context(), offerID)
+	err := sc.deleteMatchOfferUC.Invoke(c.Request.Context(), usecases.DeleteMatchOfferInput{
+		MatchOfferID:   offerID,
+		OwnerAccountID: c.Param("account_id"),
+	})
 	if err != nil {
-		c.Error(errors.UseCaseExecutionFailed(err.Error()))
+		var appErr errors.AppError
+		if !stderrors.As(err, &appErr) {
+			err = errors.UseCaseExecutionFailed(err.Error())
+		}
+		c.Error(err)
 		return
 	}
 
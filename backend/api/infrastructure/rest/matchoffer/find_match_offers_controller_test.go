@@ -50,6 +50,7 @@ func TestSearchMatchOffers(t *testing.T) {
 				parserMock.On("GeoFilter", "", "", "").Return(nil, nil)
 				parserMock.On("Limit", "").Return(0, nil)
 				parserMock.On("Offset", "").Return(0, nil)
+				parserMock.On("MinOpenSpots", "").Return(0, nil)
 
 				expectedResult := &usecases.FindMatchOfferResult{
 					Entities: []domain.Entity{createTestOffer("Boca", common.Paddle, domain.StatusPending)},
@@ -87,6 +88,7 @@ func TestSearchMatchOffers(t *testing.T) {
 				parserMock.On("GeoFilter", "", "", "").Return(nil, nil)
 				parserMock.On("Limit", "").Return(0, nil)
 				parserMock.On("Offset", "").Return(0, nil)
+				parserMock.On("MinOpenSpots", "").Return(0, nil)
 
 				expectedResult := &usecases.FindMatchOfferResult{
 					Entities: []domain.Entity{
@@ -137,6 +139,7 @@ func TestSearchMatchOffers(t *testing.T) {
 				parserMock.On("GeoFilter", "", "", "").Return(nil, nil)
 				parserMock.On("Limit", "").Return(0, nil)
 				parserMock.On("Offset", "").Return(0, nil)
+				parserMock.On("MinOpenSpots", "").Return(0, nil)
 
 				ucMock.On("Invoke", mock.Anything, mock.Anything).Return(nil, assert.AnError)
 			},
@@ -160,6 +163,7 @@ func TestSearchMatchOffers(t *testing.T) {
 				parserMock.On("GeoFilter", "", "", "").Return(nil, nil)
 				parserMock.On("Limit", "").Return(0, nil)
 				parserMock.On("Offset", "").Return(0, nil)
+				parserMock.On("MinOpenSpots", "").Return(0, nil)
 
 				ucMock.On("Invoke", mock.Anything, mock.Anything).Return(&usecases.FindMatchOfferResult{
 					Entities: []domain.Entity{},
@@ -179,7 +183,7 @@ func TestSearchMatchOffers(t *testing.T) {
 
 			ucMock := amocks.NewUseCase[usecases.SearchMatchOffersInput, usecases.FindMatchOfferResult](t)
 			parserMock := pmocks.NewQueryParser(t)
-			controller := matchoffer.NewControllerWithParser(nil, nil, ucMock, nil, nil, nil, validator, parserMock)
+			controller := matchoffer.NewControllerWithParser(nil, nil, ucMock, nil, nil, nil, nil, nil, validator, parserMock)
 
 			gin.SetMode(gin.TestMode)
 			router := gin.Default()
@@ -20,9 +20,10 @@ func locationToResponse(loc matchoffer.Location) response.LocationResponse {
 
 // EntityToResponse converts a domain entity to an API response DTO
 func EntityToResponse(entity matchoffer.Entity) response.MatchOfferResponse {
-	return response.MatchOfferResponse{
+	resp := response.MatchOfferResponse{
 		ID:       entity.ID,
 		Title:    entity.GetTitle(),
+		TeamID:   entity.TeamID,
 		TeamName: entity.TeamName,
 		Sport:    string(entity.Sport),
 		Day:      entity.Day,
@@ -35,7 +36,28 @@ func EntityToResponse(entity matchoffer.Entity) response.MatchOfferResponse {
 		Status:             entity.Status.String(),
 		CreatedAt:          entity.CreatedAt,
 		OwnerAccountID:     entity.OwnerAccountID,
+		Capacity:           entity.Capacity,
+		PlayersCommitted:   entity.PlayerNeeds.Committed,
+		SpotsNeeded:        entity.PlayerNeeds.SpotsNeeded,
+		PositionNeeds:      positionNeedsToResponse(entity.PlayerNeeds.Positions),
+		Visibility:         entity.Visibility.String(),
+		InvitedAccountIDs:  entity.InvitedAccountIDs,
 	}
+	if !entity.Cost.IsFree() {
+		resp.Cost = &response.CostResponse{Amount: entity.Cost.Amount, Currency: entity.Cost.Currency}
+	}
+	return resp
+}
+
+func positionNeedsToResponse(positions []matchoffer.PositionNeed) []response.PositionNeedResponse {
+	if len(positions) == 0 {
+		return nil
+	}
+	resp := make([]response.PositionNeedResponse, len(positions))
+	for i, p := range positions {
+		resp[i] = response.PositionNeedResponse{Position: p.Position, Spots: p.Spots}
+	}
+	return resp
 }
 
 // categoryRangeToResponse converts a domain CategoryRange to response DTO
@@ -19,6 +19,7 @@ type QueryParser interface {
 	GeoFilter(lat, lng, radiusKm string) (*matchoffer.GeoFilter, error)
 	Limit(limitQuery string) (int, error)
 	Offset(offsetQuery string) (int, error)
+	MinOpenSpots(minOpenSpotsQuery string) (int, error)
 }
 
 // DefaultQueryParser implements QueryParser interface
@@ -49,7 +50,8 @@ func (p *DefaultQueryParser) Sports(sportsQuery string) ([]common.Sport, error)
 	return sports, nil
 }
 
-// Categories parses a comma-separated string of category numbers into a slice of Category
+// Categories parses a comma-separated string of category numbers into a slice of Category.
+// An entry may also be an inclusive range, e.g. "2-4" for categories 2, 3 and 4.
 func (p *DefaultQueryParser) Categories(categoriesQuery string) ([]common.Category, error) {
 	if categoriesQuery == "" {
 		return nil, nil
@@ -64,22 +66,44 @@ func (p *DefaultQueryParser) Categories(categoriesQuery string) ([]common.Catego
 			continue
 		}
 
-		catInt, err := strconv.Atoi(trimmed)
+		fromStr, toStr, isRange := strings.Cut(trimmed, "-")
+		if !isRange {
+			toStr = fromStr
+		}
+		from, err := parseCategory(fromStr)
 		if err != nil {
-			return nil, fmt.Errorf("invalid category format: %s", trimmed)
+			return nil, err
 		}
-
-		category, err := common.GetCategory(catInt)
+		to, err := parseCategory(toStr)
 		if err != nil {
-			return nil, fmt.Errorf("invalid category value: %w", err)
+			return nil, err
+		}
+		if from > to {
+			return nil, fmt.Errorf("invalid category range: %s", trimmed)
 		}
 
-		categories = append(categories, category)
+		for category := from; category <= to; category++ {
+			categories = append(categories, category)
+		}
 	}
 
 	return categories, nil
 }
 
+func parseCategory(categoryStr string) (common.Category, error) {
+	trimmed := strings.TrimSpace(categoryStr)
+	catInt, err := strconv.Atoi(trimmed)
+	if err != nil {
+		return 0, fmt.Errorf("invalid category format: %s", trimmed)
+	}
+
+	category, err := common.GetCategory(catInt)
+	if err != nil {
+		return 0, fmt.Errorf("invalid category value: %w", err)
+	}
+	return category, nil
+}
+
 // Statuses parses a comma-separated string of statuses into a slice of Status
 func (p *DefaultQueryParser) Statuses(statusesQuery string) ([]matchoffer.Status, error) {
 	if statusesQuery == "" {
@@ -203,3 +227,21 @@ func (p *DefaultQueryParser) Offset(offsetQuery string) (int, error) {
 
 	return offset, nil
 }
+
+// MinOpenSpots parses the minimum number of open spots an offer must have
+func (p *DefaultQueryParser) MinOpenSpots(minOpenSpotsQuery string) (int, error) {
+	if minOpenSpotsQuery == "" {
+		return 0, nil // 0 means no filter
+	}
+
+	minOpenSpots, err := strconv.Atoi(minOpenSpotsQuery)
+	if err != nil {
+		return 0, fmt.Errorf("invalid min_open_spots format: %w", err)
+	}
+
+	if minOpenSpots < 0 {
+		return 0, fmt.Errorf("min_open_spots must be non-negative, got: %d", minOpenSpots)
+	}
+
+	return minOpenSpots, nil
+}
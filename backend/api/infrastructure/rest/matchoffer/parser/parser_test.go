@@ -113,6 +113,30 @@ func TestDefaultQueryParser_Categories(t *testing.T) {
 			want:      []common.Category{common.L1, common.L3},
 			wantError: false,
 		},
+		{
+			name:      "range of categories",
+			input:     "2-4",
+			want:      []common.Category{common.L2, common.L3, common.L4},
+			wantError: false,
+		},
+		{
+			name:      "ranges and single categories",
+			input:     "0, 5 - 6",
+			want:      []common.Category{common.Unranked, common.L5, common.L6},
+			wantError: false,
+		},
+		{
+			name:      "reversed range",
+			input:     "4-2",
+			want:      nil,
+			wantError: true,
+		},
+		{
+			name:      "range past the highest category",
+			input:     "6-9",
+			want:      nil,
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -459,3 +483,52 @@ func equalStatuses(a, b []matchoffer.Status) bool {
 	}
 	return true
 }
+
+func TestDefaultQueryParser_MinOpenSpots(t *testing.T) {
+	parser := NewQueryParser()
+
+	tests := []struct {
+		name         string
+		minOpenSpots string
+		want         int
+		wantErr      bool
+	}{
+		{
+			name:         "given valid number when parsing min open spots then returns value",
+			minOpenSpots: "2",
+			want:         2,
+			wantErr:      false,
+		},
+		{
+			name:         "given empty string when parsing min open spots then returns zero",
+			minOpenSpots: "",
+			want:         0,
+			wantErr:      false,
+		},
+		{
+			name:         "given invalid string when parsing min open spots then returns error",
+			minOpenSpots: "two",
+			want:         0,
+			wantErr:      true,
+		},
+		{
+			name:         "given negative number when parsing min open spots then returns error",
+			minOpenSpots: "-1",
+			want:         0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.MinOpenSpots(tt.minOpenSpots)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MinOpenSpots() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MinOpenSpots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,17 +4,35 @@ import "time"
 
 // MatchOfferResponse represents the API response for a match offer
 type MatchOfferResponse struct {
-	ID                 string                `json:"id,omitempty"`
-	Title              string                `json:"title"`
-	TeamName           string                `json:"team_name"`
-	Sport              string                `json:"sport"`
-	Day                time.Time             `json:"day"`
-	TimeSlot           TimeSlotResponse      `json:"time_slot"`
-	Location           LocationResponse      `json:"location"`
-	AdmittedCategories CategoryRangeResponse `json:"admitted_categories"`
-	Status             string                `json:"status"`
-	CreatedAt          time.Time             `json:"created_at"`
-	OwnerAccountID     string                `json:"owner_account_id,omitempty"`
+	ID                 string                 `json:"id,omitempty"`
+	Title              string                 `json:"title"`
+	TeamID             string                 `json:"team_id,omitempty"`
+	TeamName           string                 `json:"team_name"`
+	Sport              string                 `json:"sport"`
+	Day                time.Time              `json:"day"`
+	TimeSlot           TimeSlotResponse       `json:"time_slot"`
+	Location           LocationResponse       `json:"location"`
+	AdmittedCategories CategoryRangeResponse  `json:"admitted_categories"`
+	Status             string                 `json:"status"`
+	CreatedAt          time.Time              `json:"created_at"`
+	OwnerAccountID     string                 `json:"owner_account_id,omitempty"`
+	Capacity           int                    `json:"capacity,omitempty"`
+	PlayersCommitted   int                    `json:"players_committed,omitempty"`
+	SpotsNeeded        int                    `json:"spots_needed,omitempty"`
+	PositionNeeds      []PositionNeedResponse `json:"position_needs,omitempty"`
+	Visibility         string                 `json:"visibility,omitempty"`
+	InvitedAccountIDs  []string               `json:"invited_account_ids,omitempty"`
+	Cost               *CostResponse          `json:"cost,omitempty"`
+}
+
+type CostResponse struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type PositionNeedResponse struct {
+	Position string `json:"position"`
+	Spots    int    `json:"spots"`
 }
 
 type TimeSlotResponse struct {
@@ -0,0 +1,10 @@
+package response
+
+import "time"
+
+// ShareLinkResponse carries the token to append as share_token when opening a LINK offer
+type ShareLinkResponse struct {
+	MatchOfferID string    `json:"match_offer_id"`
+	ShareToken   string    `json:"share_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
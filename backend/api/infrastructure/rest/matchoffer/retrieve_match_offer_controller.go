@@ -3,18 +3,22 @@ package matchoffer
 import (
 	"net/http"
 	"sportlink/api/application/errors"
-	"sportlink/api/domain/matchoffer"
+	"sportlink/api/application/matchoffer/usecases"
 	restmapper "sportlink/api/infrastructure/rest/matchoffer/mapper"
 
 	"github.com/gin-gonic/gin"
 )
 
-// RetrieveMatchOffer handles GET /match-offer/:offer_id
+// RetrieveMatchOffer handles GET /match-offer/:offer_id for anonymous viewers and
+// GET /account/:account_id/match-offer/:offer_id for authenticated ones. The viewer is the
+// account the route was authenticated for; LINK offers also open with the share_token param.
 func (sc *DefaultController) RetrieveMatchOffer(c *gin.Context) {
 	offerID := c.Param("offer_id")
 
-	entity, err := sc.retrieveMatchOfferUC.Invoke(c.Request.Context(), matchoffer.DomainQuery{
-		IDs: []string{offerID},
+	entity, err := sc.retrieveMatchOfferUC.Invoke(c.Request.Context(), usecases.RetrieveMatchOfferInput{
+		MatchOfferID:    offerID,
+		ViewerAccountID: c.Param("account_id"),
+		ShareToken:      c.Query("share_token"),
 	})
 	if err != nil {
 		c.Error(errors.UseCaseExecutionFailed(err.Error()))
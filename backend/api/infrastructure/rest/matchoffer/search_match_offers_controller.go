@@ -12,7 +12,9 @@ import (
 // SearchMatchOffers handles GET /account/:account_id/match-offer/search.
 // Returns paginated match offers available for the given account, excluding:
 //   - offers owned by the account
-//   - offers where the account already has a PENDING or ACCEPTED match request
+//   - offers where the account already has a PENDING, ACCEPTED or WAITLISTED match request
+//
+// min_open_spots and position narrow the results to offers that still need players.
 func (sc *DefaultController) SearchMatchOffers(c *gin.Context) {
 	accountID := c.Param("account_id")
 
@@ -22,9 +24,17 @@ func (sc *DefaultController) SearchMatchOffers(c *gin.Context) {
 		return
 	}
 
+	minOpenSpots, err := sc.queryParser.MinOpenSpots(c.Query("min_open_spots"))
+	if err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+
 	result, err := sc.searchMatchOffersUC.Invoke(c.Request.Context(), usecases.SearchMatchOffersInput{
 		ViewerAccountID: accountID,
 		Query:           query,
+		MinOpenSpots:    minOpenSpots,
+		Position:        c.Query("position"),
 	})
 	if err != nil {
 		c.Error(errors.UseCaseExecutionFailed(err.Error()))
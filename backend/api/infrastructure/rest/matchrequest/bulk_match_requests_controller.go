@@ -0,0 +1,88 @@
+package matchrequest
+
+import (
+	"net/http"
+	"sportlink/api/application/errors"
+	apprequest "sportlink/api/application/matchrequest/request"
+	"sportlink/api/application/matchrequest/usecases"
+	reqmapper "sportlink/api/infrastructure/rest/matchrequest/mapper"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BulkAcceptMatchRequests handles POST /account/:account_id/match-request/accept
+func (sc *DefaultController) BulkAcceptMatchRequests(c *gin.Context) {
+	var req apprequest.BulkAcceptMatchRequestsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.InvalidRequestFormat())
+		return
+	}
+	if err := sc.validator.Struct(req); err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+
+	results, err := sc.bulkAcceptMatchRequestsUC.Invoke(c.Request.Context(), usecases.BulkAcceptMatchRequestsInput{
+		OwnerAccountID: c.Param("account_id"),
+		IDs:            req.IDs,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reqmapper.BulkResultsToResponse(*results))
+}
+
+// BulkRejectMatchRequests handles POST /account/:account_id/match-request/reject
+func (sc *DefaultController) BulkRejectMatchRequests(c *gin.Context) {
+	var req apprequest.BulkRejectMatchRequestsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errors.InvalidRequestFormat())
+		return
+	}
+	if err := sc.validator.Struct(req); err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+
+	results, err := sc.bulkRejectMatchRequestsUC.Invoke(c.Request.Context(), usecases.BulkRejectMatchRequestsInput{
+		OwnerAccountID: c.Param("account_id"),
+		IDs:            req.IDs,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reqmapper.BulkResultsToResponse(*results))
+}
+
+// RejectPendingMatchRequests handles POST /account/:account_id/match-offer/:offer_id/match-request/reject
+func (sc *DefaultController) RejectPendingMatchRequests(c *gin.Context) {
+	var req apprequest.RejectPendingMatchRequestsRequest
+	// the body is optional; it only carries the reason
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(errors.InvalidRequestFormat())
+			return
+		}
+	}
+	if err := sc.validator.Struct(req); err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+
+	results, err := sc.bulkRejectMatchRequestsUC.Invoke(c.Request.Context(), usecases.BulkRejectMatchRequestsInput{
+		OwnerAccountID: c.Param("account_id"),
+		MatchOfferID:   c.Param("offer_id"),
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reqmapper.BulkResultsToResponse(*results))
+}
@@ -15,6 +15,9 @@ type Controller interface {
 	UpdateMatchRequestStatus(c *gin.Context)
 	AcceptMatchRequest(c *gin.Context)
 	CancelMatchRequest(c *gin.Context)
+	BulkAcceptMatchRequests(c *gin.Context)
+	BulkRejectMatchRequests(c *gin.Context)
+	RejectPendingMatchRequests(c *gin.Context)
 }
 
 type DefaultController struct {
@@ -23,6 +26,8 @@ type DefaultController struct {
 	updateMatchRequestStatusUC *usecases.UpdateMatchRequestStatusUC
 	acceptMatchRequestUC       application.UseCase[usecases.AcceptMatchRequestInput, matchrequest.Entity]
 	cancelMatchRequestUC       application.UseCase[usecases.CancelMatchRequestInput, matchrequest.Entity]
+	bulkAcceptMatchRequestsUC  application.UseCase[usecases.BulkAcceptMatchRequestsInput, []usecases.BulkItemResult]
+	bulkRejectMatchRequestsUC  application.UseCase[usecases.BulkRejectMatchRequestsInput, []usecases.BulkItemResult]
 	validator                  *validator.Validate
 }
 
@@ -32,6 +37,8 @@ func NewController(
 	updateMatchRequestStatusUC *usecases.UpdateMatchRequestStatusUC,
 	acceptMatchRequestUC application.UseCase[usecases.AcceptMatchRequestInput, matchrequest.Entity],
 	cancelMatchRequestUC application.UseCase[usecases.CancelMatchRequestInput, matchrequest.Entity],
+	bulkAcceptMatchRequestsUC application.UseCase[usecases.BulkAcceptMatchRequestsInput, []usecases.BulkItemResult],
+	bulkRejectMatchRequestsUC application.UseCase[usecases.BulkRejectMatchRequestsInput, []usecases.BulkItemResult],
 	validator *validator.Validate,
 ) Controller {
 	return &DefaultController{
@@ -40,6 +47,8 @@ func NewController(
 		updateMatchRequestStatusUC: updateMatchRequestStatusUC,
 		acceptMatchRequestUC:       acceptMatchRequestUC,
 		cancelMatchRequestUC:       cancelMatchRequestUC,
+		bulkAcceptMatchRequestsUC:  bulkAcceptMatchRequestsUC,
+		bulkRejectMatchRequestsUC:  bulkRejectMatchRequestsUC,
 		validator:                  validator,
 	}
 }
@@ -3,6 +3,7 @@ package matchrequest
 import (
 	"net/http"
 	"sportlink/api/application/errors"
+	apprequest "sportlink/api/application/matchrequest/request"
 	"sportlink/api/application/matchrequest/usecases"
 	restmapper "sportlink/api/infrastructure/rest/matchrequest/mapper"
 
@@ -14,9 +15,19 @@ func (sc *DefaultController) CreateMatchRequest(c *gin.Context) {
 	requesterAccountID := c.Param("account_id")
 	matchOfferID := c.Param("offer_id")
 
+	var req apprequest.CreateMatchRequestRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(errors.InvalidRequestFormat())
+			return
+		}
+	}
+
 	result, err := sc.createMatchRequestUC.Invoke(c.Request.Context(), usecases.CreateMatchRequestInput{
 		MatchOfferID: matchOfferID,
 		RequesterAccountID:  requesterAccountID,
+		Position:            req.Position,
+		ShareToken:          req.ShareToken,
 	})
 	if err != nil {
 		c.Error(errors.UseCaseExecutionFailed(err.Error()))
@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	stderrors "errors"
+	"sportlink/api/application/errors"
+	"sportlink/api/application/matchrequest/usecases"
 	"sportlink/api/domain/matchrequest"
 	"sportlink/api/infrastructure/rest/matchrequest/response"
 )
@@ -11,7 +14,9 @@ func EntityToResponse(entity matchrequest.Entity) response.MatchRequestResponse
 		MatchOfferID: entity.MatchOfferID,
 		OwnerAccountID:      entity.OwnerAccountID,
 		RequesterAccountID:  entity.RequesterAccountID,
+		Position:            entity.Position,
 		Status:              entity.Status.String(),
+		RejectionReason:     entity.RejectionReason,
 		CreatedAt:           entity.CreatedAt,
 	}
 }
@@ -23,3 +28,32 @@ func EntitiesToResponses(entities []matchrequest.Entity) []response.MatchRequest
 	}
 	return responses
 }
+
+func BulkResultsToResponse(results []usecases.BulkItemResult) response.BulkResponse {
+	resp := response.BulkResponse{Results: make([]response.BulkItemResponse, len(results))}
+	for i, r := range results {
+		item := response.BulkItemResponse{MatchRequestID: r.MatchRequestID, Succeeded: r.Succeeded()}
+		if r.Succeeded() {
+			resp.Succeeded++
+			if r.Request != nil {
+				matchRequest := EntityToResponse(*r.Request)
+				item.MatchRequest = &matchRequest
+			}
+		} else {
+			resp.Failed++
+			item.Error = bulkItemError(r.Err)
+		}
+		resp.Results[i] = item
+	}
+	return resp
+}
+
+// bulkItemError reports application errors as the error handler does; anything else is
+// unexpected and its details stay in the logs.
+func bulkItemError(err error) *response.BulkItemError {
+	var appErr errors.AppError
+	if stderrors.As(err, &appErr) {
+		return &response.BulkItemError{Code: string(appErr.Code), Message: appErr.Message}
+	}
+	return &response.BulkItemError{Code: string(errors.UnexpectedErrorCode), Message: "Oops, something went wrong"}
+}
@@ -0,0 +1,21 @@
+package response
+
+// BulkItemResponse is the outcome of a bulk operation for one match request. Error is
+// only set when the request could not be updated.
+type BulkItemResponse struct {
+	MatchRequestID string                `json:"match_request_id"`
+	Succeeded      bool                  `json:"succeeded"`
+	MatchRequest   *MatchRequestResponse `json:"match_request,omitempty"`
+	Error          *BulkItemError        `json:"error,omitempty"`
+}
+
+type BulkItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type BulkResponse struct {
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []BulkItemResponse `json:"results"`
+}
@@ -7,6 +7,8 @@ type MatchRequestResponse struct {
 	MatchOfferID string    `json:"match_offer_id"`
 	OwnerAccountID      string    `json:"owner_account_id"`
 	RequesterAccountID  string    `json:"requester_account_id"`
+	Position            string    `json:"position,omitempty"`
 	Status              string    `json:"status"`
+	RejectionReason     string    `json:"rejection_reason,omitempty"`
 	CreatedAt           time.Time `json:"created_at"`
 }
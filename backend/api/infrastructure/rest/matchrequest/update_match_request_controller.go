@@ -1,6 +1,7 @@
 package matchrequest
 
 import (
+	stderrors "errors"
 	"net/http"
 	"sportlink/api/application/errors"
 	apprequest "sportlink/api/application/matchrequest/request"
@@ -38,7 +39,11 @@ func (sc *DefaultController) UpdateMatchRequestStatus(c *gin.Context) {
 		NewStatus:      newStatus,
 	})
 	if err != nil {
-		c.Error(errors.UseCaseExecutionFailed(err.Error()))
+		var appErr errors.AppError
+		if !stderrors.As(err, &appErr) {
+			err = errors.UseCaseExecutionFailed(err.Error())
+		}
+		c.Error(err)
 		return
 	}
 
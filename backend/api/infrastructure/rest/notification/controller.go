@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"sportlink/api/application"
+	"sportlink/api/application/notification/usecases"
+	"sportlink/api/domain/notification"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type Controller interface {
+	FindNotifications(c *gin.Context)
+	MarkNotificationRead(c *gin.Context)
+	MarkAllNotificationsRead(c *gin.Context)
+	RetrievePreferences(c *gin.Context)
+	UpdatePreferences(c *gin.Context)
+	RegisterPushToken(c *gin.Context)
+}
+
+type DefaultController struct {
+	findNotificationsUC        application.UseCase[notification.DomainQuery, notification.Page]
+	markNotificationReadUC     application.UseCase[usecases.MarkNotificationReadInput, notification.Entity]
+	markAllNotificationsReadUC application.UseCase[string, usecases.MarkAllNotificationsReadResult]
+	retrievePreferencesUC      application.UseCase[string, notification.Preferences]
+	updatePreferencesUC        application.UseCase[usecases.UpdateNotificationPreferencesInput, notification.Preferences]
+	registerPushTokenUC        application.UseCase[usecases.RegisterPushTokenInput, notification.Preferences]
+	validator                  *validator.Validate
+}
+
+func NewController(
+	findNotificationsUC application.UseCase[notification.DomainQuery, notification.Page],
+	markNotificationReadUC application.UseCase[usecases.MarkNotificationReadInput, notification.Entity],
+	markAllNotificationsReadUC application.UseCase[string, usecases.MarkAllNotificationsReadResult],
+	retrievePreferencesUC application.UseCase[string, notification.Preferences],
+	updatePreferencesUC application.UseCase[usecases.UpdateNotificationPreferencesInput, notification.Preferences],
+	registerPushTokenUC application.UseCase[usecases.RegisterPushTokenInput, notification.Preferences],
+	validator *validator.Validate,
+) Controller {
+	return &DefaultController{
+		findNotificationsUC:        findNotificationsUC,
+		markNotificationReadUC:     markNotificationReadUC,
+		markAllNotificationsReadUC: markAllNotificationsReadUC,
+		retrievePreferencesUC:      retrievePreferencesUC,
+		updatePreferencesUC:        updatePreferencesUC,
+		registerPushTokenUC:        registerPushTokenUC,
+		validator:                  validator,
+	}
+}
@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"net/http"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/notification"
+	"sportlink/api/infrastructure/rest/notification/mapper"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FindNotifications handles GET /account/:account_id/notification
+// Returns the inbox newest first. Supports unread=true, limit and cursor query parameters.
+func (sc *DefaultController) FindNotifications(c *gin.Context) {
+	query := notification.DomainQuery{
+		AccountID: c.Param("account_id"),
+		Cursor:    c.Query("cursor"),
+	}
+
+	if unread := c.Query("unread"); unread != "" {
+		unreadOnly, err := strconv.ParseBool(unread)
+		if err != nil {
+			c.Error(errors.RequestValidationFailed("unread must be true or false"))
+			return
+		}
+		query.UnreadOnly = unreadOnly
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			c.Error(errors.RequestValidationFailed("limit must be a positive number"))
+			return
+		}
+		query.Limit = parsed
+	}
+
+	result, err := sc.findNotificationsUC.Invoke(c.Request.Context(), query)
+	if err != nil {
+		c.Error(errors.UseCaseExecutionFailed(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.PageToResponse(*result))
+}
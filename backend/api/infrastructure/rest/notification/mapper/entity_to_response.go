@@ -0,0 +1,60 @@
+package mapper
+
+import (
+	"sportlink/api/domain/notification"
+	"sportlink/api/infrastructure/rest/notification/response"
+)
+
+func EntityToResponse(entity notification.Entity) response.NotificationResponse {
+	data := entity.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+	return response.NotificationResponse{
+		ID:        entity.ID,
+		Type:      entity.Type.String(),
+		Data:      data,
+		Read:      entity.IsRead(),
+		CreatedAt: entity.CreatedAt,
+		ReadAt:    entity.ReadAt,
+	}
+}
+
+func PageToResponse(page notification.Page) response.NotificationPageResponse {
+	data := make([]response.NotificationResponse, len(page.Entities))
+	for i, entity := range page.Entities {
+		data[i] = EntityToResponse(entity)
+	}
+	return response.NotificationPageResponse{
+		Data:       data,
+		NextCursor: page.NextCursor,
+	}
+}
+
+func PreferencesToResponse(preferences notification.Preferences) response.NotificationPreferencesResponse {
+	types := make(map[string]bool, len(notification.Types()))
+	for _, t := range notification.Types() {
+		types[t.String()] = preferences.Allows(t)
+	}
+	channels := make(map[string]bool, len(notification.Channels()))
+	for _, c := range notification.Channels() {
+		channels[c.String()] = preferences.AllowsChannel(c)
+	}
+
+	var quietHours *response.QuietHoursResponse
+	if preferences.QuietHours != nil {
+		quietHours = &response.QuietHoursResponse{
+			Start:    preferences.QuietHours.StartClock(),
+			End:      preferences.QuietHours.EndClock(),
+			TimeZone: preferences.QuietHours.TimeZone,
+		}
+	}
+
+	return response.NotificationPreferencesResponse{
+		Types:          types,
+		Channels:       channels,
+		QuietHours:     quietHours,
+		Locale:         preferences.Locale,
+		PushTokenCount: len(preferences.PushTokens),
+	}
+}
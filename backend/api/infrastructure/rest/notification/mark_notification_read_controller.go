@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"net/http"
+	"sportlink/api/application/notification/usecases"
+	"sportlink/api/infrastructure/rest/notification/mapper"
+	"sportlink/api/infrastructure/rest/notification/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MarkNotificationRead handles POST /account/:account_id/notification/:notification_id/read
+func (sc *DefaultController) MarkNotificationRead(c *gin.Context) {
+	result, err := sc.markNotificationReadUC.Invoke(c.Request.Context(), usecases.MarkNotificationReadInput{
+		AccountID:      c.Param("account_id"),
+		NotificationID: c.Param("notification_id"),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.EntityToResponse(*result))
+}
+
+// MarkAllNotificationsRead handles POST /account/:account_id/notification/read
+// Marks every unread notification of the account as read.
+func (sc *DefaultController) MarkAllNotificationsRead(c *gin.Context) {
+	result, err := sc.markAllNotificationsReadUC.Invoke(c.Request.Context(), c.Param("account_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.MarkAllReadResponse{Updated: result.Updated})
+}
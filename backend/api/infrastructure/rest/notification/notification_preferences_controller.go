@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"net/http"
+	"sportlink/api/application/errors"
+	"sportlink/api/application/notification/request"
+	"sportlink/api/application/notification/usecases"
+	"sportlink/api/domain/notification"
+	"sportlink/api/infrastructure/rest/notification/mapper"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetrievePreferences handles GET /account/:account_id/notification/preferences
+func (sc *DefaultController) RetrievePreferences(c *gin.Context) {
+	result, err := sc.retrievePreferencesUC.Invoke(c.Request.Context(), c.Param("account_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.PreferencesToResponse(*result))
+}
+
+// UpdatePreferences handles PUT /account/:account_id/notification/preferences
+// Turns the listed notification types and channels on or off and sets the quiet hours
+// and language; anything omitted is left as it is.
+func (sc *DefaultController) UpdatePreferences(c *gin.Context) {
+	var updateRequest request.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.Error(errors.InvalidRequestFormat())
+		return
+	}
+
+	if err := sc.validator.Struct(updateRequest); err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+
+	input, err := toUpdatePreferencesInput(c.Param("account_id"), updateRequest)
+	if err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+
+	result, err := sc.updatePreferencesUC.Invoke(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.PreferencesToResponse(*result))
+}
+
+// RegisterPushToken handles POST /account/:account_id/notification/push-token
+func (sc *DefaultController) RegisterPushToken(c *gin.Context) {
+	var registerRequest request.RegisterPushTokenRequest
+	if err := c.ShouldBindJSON(&registerRequest); err != nil {
+		c.Error(errors.InvalidRequestFormat())
+		return
+	}
+
+	if err := sc.validator.Struct(registerRequest); err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+
+	result, err := sc.registerPushTokenUC.Invoke(c.Request.Context(), usecases.RegisterPushTokenInput{
+		AccountID: c.Param("account_id"),
+		Token:     registerRequest.Token,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.PreferencesToResponse(*result))
+}
+
+func toUpdatePreferencesInput(accountID string, updateRequest request.UpdateNotificationPreferencesRequest) (usecases.UpdateNotificationPreferencesInput, error) {
+	input := usecases.UpdateNotificationPreferencesInput{
+		AccountID:       accountID,
+		Types:           make(map[notification.Type]bool, len(updateRequest.Types)),
+		Channels:        make(map[notification.Channel]bool, len(updateRequest.Channels)),
+		ClearQuietHours: updateRequest.ClearQuietHours,
+		Locale:          updateRequest.Locale,
+	}
+	for name, enabled := range updateRequest.Types {
+		notificationType, err := notification.ParseType(name)
+		if err != nil {
+			return input, err
+		}
+		input.Types[notificationType] = enabled
+	}
+	for name, enabled := range updateRequest.Channels {
+		channel, err := notification.ParseChannel(name)
+		if err != nil {
+			return input, err
+		}
+		input.Channels[channel] = enabled
+	}
+	if updateRequest.QuietHours != nil {
+		quietHours, err := notification.NewQuietHours(updateRequest.QuietHours.Start, updateRequest.QuietHours.End, updateRequest.QuietHours.TimeZone)
+		if err != nil {
+			return input, err
+		}
+		input.QuietHours = &quietHours
+	}
+	return input, nil
+}
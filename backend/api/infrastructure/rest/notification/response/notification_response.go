@@ -0,0 +1,39 @@
+package response
+
+import "time"
+
+type NotificationResponse struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	Data      map[string]string `json:"data"`
+	Read      bool              `json:"read"`
+	CreatedAt time.Time         `json:"created_at"`
+	ReadAt    *time.Time        `json:"read_at,omitempty"`
+}
+
+// NotificationPageResponse is one page of the inbox. NextCursor is passed back as the
+// cursor query parameter to get the following page; it is absent on the last page.
+type NotificationPageResponse struct {
+	Data       []NotificationResponse `json:"data"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+type MarkAllReadResponse struct {
+	Updated int `json:"updated"`
+}
+
+// NotificationPreferencesResponse tells, per notification type and per off-app channel,
+// whether the account receives it, and when and in which language it is reached.
+type NotificationPreferencesResponse struct {
+	Types          map[string]bool     `json:"types"`
+	Channels       map[string]bool     `json:"channels"`
+	QuietHours     *QuietHoursResponse `json:"quiet_hours,omitempty"`
+	Locale         string              `json:"locale"`
+	PushTokenCount int                 `json:"push_token_count"`
+}
+
+type QuietHoursResponse struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"time_zone"`
+}
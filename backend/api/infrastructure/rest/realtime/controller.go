@@ -0,0 +1,26 @@
+package realtime
+
+import (
+	"sportlink/api/application/realtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Controller interface {
+	Stream(c *gin.Context)
+}
+
+type DefaultController struct {
+	hub       *realtime.Hub
+	heartbeat time.Duration
+}
+
+// NewController builds the stream controller. A comment line is written every
+// heartbeat so proxies do not close idle streams.
+func NewController(hub *realtime.Hub, heartbeat time.Duration) Controller {
+	return &DefaultController{
+		hub:       hub,
+		heartbeat: heartbeat,
+	}
+}
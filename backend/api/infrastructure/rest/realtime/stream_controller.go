@@ -0,0 +1,44 @@
+package realtime
+
+import (
+	"io"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// Stream handles GET /account/:account_id/stream
+// Keeps a Server-Sent Events stream open and writes each update of the account as an
+// event named after the update type, with the update as JSON data.
+func (sc *DefaultController) Stream(c *gin.Context) {
+	updates, unsubscribe := sc.hub.Subscribe(c.Param("account_id"))
+	defer unsubscribe()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // keeps reverse proxies from buffering the stream
+
+	heartbeat := time.NewTicker(sc.heartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case update, ok := <-updates:
+			if !ok {
+				return false
+			}
+			c.Render(-1, sse.Event{
+				Id:    update.ID,
+				Event: update.Type,
+				Data:  update,
+			})
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
+}
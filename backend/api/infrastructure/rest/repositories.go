@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"context"
+	"log"
+	"sportlink/api/domain/account"
+	"sportlink/api/domain/chat"
+	"sportlink/api/domain/history"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/domain/player"
+	"sportlink/api/domain/team"
+	"sportlink/api/infrastructure/cache"
+	"sportlink/api/infrastructure/config"
+	ievents "sportlink/api/infrastructure/events"
+	iaccount "sportlink/api/infrastructure/persistence/account"
+	ichat "sportlink/api/infrastructure/persistence/chat"
+	ihistory "sportlink/api/infrastructure/persistence/history"
+	imatch "sportlink/api/infrastructure/persistence/match"
+	imatchoffer "sportlink/api/infrastructure/persistence/matchoffer"
+	imatchrequest "sportlink/api/infrastructure/persistence/matchrequest"
+	"sportlink/api/infrastructure/persistence/memory"
+	inotification "sportlink/api/infrastructure/persistence/notification"
+	ioutbox "sportlink/api/infrastructure/persistence/outbox"
+	iplayer "sportlink/api/infrastructure/persistence/player"
+	"sportlink/api/infrastructure/persistence/postgres"
+	iteam "sportlink/api/infrastructure/persistence/team"
+)
+
+// repositories groups the persistence adapters of one storage backend.
+type repositories struct {
+	account                 account.Repository
+	player                  player.Repository
+	team                    team.Repository
+	matchOffer              matchoffer.Repository
+	matchRequest            matchrequest.Repository
+	match                   match.Repository
+	reminder                match.ReminderRepository
+	attendance              match.AttendanceRepository
+	outbox                  outbox.Repository
+	processedMessage        outbox.ProcessedRepository
+	notification            notification.Repository
+	notificationPreferences notification.PreferencesRepository
+	chat                    chat.Repository
+	history                 history.Repository
+}
+
+// newRepositories picks the storage backend configured by STORAGE_BACKEND.
+func newRepositories(cfg *config.Config) repositories {
+	switch cfg.StorageCfg.Backend {
+	case "dynamodb":
+		client := config.NewDynamoDBClient(cfg.DynamoDbCfg)
+		return repositories{
+			account:                 iaccount.NewRepository(client, "SportLinkCore"),
+			player:                  iplayer.NewDynamoDBRepository(client, "SportLinkCore"),
+			team:                    iteam.NewRepository(client, "SportLinkCore"),
+			matchOffer:              imatchoffer.NewRepository(client, "SportLinkCore"),
+			matchRequest:            imatchrequest.NewRepository(client, "SportLinkCore"),
+			match:                   imatch.NewRepository(client, "SportLinkCore"),
+			reminder:                imatch.NewReminderRepository(client, "SportLinkCore"),
+			attendance:              imatch.NewAttendanceRepository(client, "SportLinkCore"),
+			outbox:                  ioutbox.NewRepository(client, "SportLinkCore"),
+			processedMessage:        ioutbox.NewProcessedRepository(client, "SportLinkCore"),
+			notification:            inotification.NewRepository(client, "SportLinkCore"),
+			notificationPreferences: inotification.NewPreferencesRepository(client, "SportLinkCore"),
+			chat:                    ichat.NewRepository(client, "SportLinkCore"),
+			history:                 ihistory.NewRepository(client, "SportLinkCore"),
+		}
+	case "postgres":
+		pool := config.NewPostgresPool(cfg.PostgresCfg)
+		if err := postgres.Migrate(context.Background(), pool); err != nil {
+			log.Fatalf("unable to migrate PostgreSQL, %v", err)
+		}
+		return repositories{
+			account:                 postgres.NewAccountRepository(pool),
+			player:                  postgres.NewPlayerRepository(pool),
+			team:                    postgres.NewTeamRepository(pool),
+			matchOffer:              postgres.NewMatchOfferRepository(pool),
+			matchRequest:            postgres.NewMatchRequestRepository(pool),
+			match:                   postgres.NewMatchRepository(pool),
+			reminder:                postgres.NewReminderRepository(pool),
+			attendance:              postgres.NewAttendanceRepository(pool),
+			outbox:                  postgres.NewOutboxRepository(pool),
+			processedMessage:        postgres.NewProcessedRepository(pool),
+			notification:            postgres.NewNotificationRepository(pool),
+			notificationPreferences: postgres.NewPreferencesRepository(pool),
+			chat:                    postgres.NewChatRepository(pool),
+			history:                 postgres.NewHistoryRepository(pool),
+		}
+	case "memory":
+		store := memory.NewStore()
+		return repositories{
+			account:                 memory.NewAccountRepository(store),
+			player:                  memory.NewPlayerRepository(store),
+			team:                    memory.NewTeamRepository(store),
+			matchOffer:              memory.NewMatchOfferRepository(store),
+			matchRequest:            memory.NewMatchRequestRepository(store),
+			match:                   memory.NewMatchRepository(store),
+			reminder:                memory.NewReminderRepository(store),
+			attendance:              memory.NewAttendanceRepository(store),
+			outbox:                  memory.NewOutboxRepository(store),
+			processedMessage:        memory.NewProcessedRepository(store),
+			notification:            memory.NewNotificationRepository(store),
+			notificationPreferences: memory.NewPreferencesRepository(store),
+			chat:                    memory.NewChatRepository(store),
+			history:                 memory.NewHistoryRepository(store),
+		}
+	default:
+		log.Fatalf("unknown storage backend: %s", cfg.StorageCfg.Backend)
+		return repositories{}
+	}
+}
+
+// withCache puts the read-through cache configured by CACHE_BACKEND in front of the offer,
+// account and team repositories. The request and match repositories, which also write
+// offers, invalidate them. The consumer returned drops the entries domain events report
+// changed; it is nil without a cache.
+func withCache(repos repositories, cfg config.CacheCfg) (repositories, *ievents.CacheInvalidationConsumer) {
+	var backend cache.Backend
+	switch cfg.Backend {
+	case "none":
+		return repos, nil
+	case "local":
+		backend = cache.NewLRU(cfg.LocalCapacity)
+	case "redis":
+		backend = cache.Tiered(cache.NewLRU(cfg.LocalCapacity), cache.NewRedis(cfg.RedisAddr, cfg.RedisPoolSize), cfg.LocalMaxTTL)
+	default:
+		log.Fatalf("unknown cache backend: %s", cfg.Backend)
+	}
+
+	offers := cache.NewMatchOfferRepository(repos.matchOffer, backend, cfg.MatchOfferTTL)
+	accounts := cache.NewAccountRepository(repos.account, backend, cfg.AccountTTL)
+	teams := cache.NewTeamRepository(repos.team, backend, cfg.TeamTTL)
+	repos.matchOffer = offers
+	repos.matchRequest = cache.NewMatchRequestRepository(repos.matchRequest, offers)
+	repos.match = cache.NewMatchRepository(repos.match, offers)
+	repos.account = accounts
+	repos.team = teams
+	return repos, ievents.NewCacheInvalidationConsumer(offers, accounts, teams)
+}
@@ -6,31 +6,47 @@ import (
 	uaccount "sportlink/api/application/account/usecases"
 	authservice "sportlink/api/application/auth/service"
 	uauth "sportlink/api/application/auth/usecases"
+	chatservice "sportlink/api/application/chat/service"
+	uchat "sportlink/api/application/chat/usecases"
+	uhistory "sportlink/api/application/history/usecases"
+	matchevent "sportlink/api/application/match/events"
+	matchservice "sportlink/api/application/match/service"
 	umatch "sportlink/api/application/match/usecases"
 	matchofferevent "sportlink/api/application/matchoffer/events"
+	matchofferservice "sportlink/api/application/matchoffer/service"
 	umatchoffer "sportlink/api/application/matchoffer/usecases"
 	umatchrequest "sportlink/api/application/matchrequest/usecases"
+	"sportlink/api/application/messaging"
+	notificationevent "sportlink/api/application/notification/events"
+	notificationservice "sportlink/api/application/notification/service"
+	unotification "sportlink/api/application/notification/usecases"
+	uoutbox "sportlink/api/application/outbox/usecases"
 	uplayer "sportlink/api/application/player/usecases"
+	"sportlink/api/application/realtime"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
 	ievents "sportlink/api/infrastructure/events"
-	imatch "sportlink/api/infrastructure/persistence/match"
+	"sportlink/api/infrastructure/middleware"
+	inotificationdelivery "sportlink/api/infrastructure/notification"
 	cmatch "sportlink/api/infrastructure/rest/match"
+	"sportlink/api/infrastructure/scheduler"
 
 	uteam "sportlink/api/application/team/usecases"
 	"sportlink/api/infrastructure/config"
-	iaccount "sportlink/api/infrastructure/persistence/account"
-	imatchoffer "sportlink/api/infrastructure/persistence/matchoffer"
-	imatchrequest "sportlink/api/infrastructure/persistence/matchrequest"
-	iplayer "sportlink/api/infrastructure/persistence/player"
-	iteam "sportlink/api/infrastructure/persistence/team"
+	irealtime "sportlink/api/infrastructure/realtime"
 
 	"github.com/gin-gonic/gin"
 
 	caccount "sportlink/api/infrastructure/rest/account"
 	cauth "sportlink/api/infrastructure/rest/auth"
+	cchat "sportlink/api/infrastructure/rest/chat"
+	chistory "sportlink/api/infrastructure/rest/history"
 	cmatchoffer "sportlink/api/infrastructure/rest/matchoffer"
 	cmatchrequest "sportlink/api/infrastructure/rest/matchrequest"
 	"sportlink/api/infrastructure/rest/monitoring"
+	cnotification "sportlink/api/infrastructure/rest/notification"
 	cplayer "sportlink/api/infrastructure/rest/player"
+	crealtime "sportlink/api/infrastructure/rest/realtime"
 	cteam "sportlink/api/infrastructure/rest/team"
 	"sportlink/api/infrastructure/validator"
 )
@@ -44,15 +60,26 @@ func Routes(router *gin.Engine) {
 		log.Fatalf("failed to load config: %v", err)
 	}
 	customValidator := validator.GetInstance()
-	dynamoDbClient := config.NewDynamoDBClient(cfg.DynamoDbCfg)
 
-	// Repositories
-	accountRepository := iaccount.NewRepository(dynamoDbClient, "SportLinkCore")
-	playerRepository := iplayer.NewDynamoDBRepository(dynamoDbClient, "SportLinkCore")
-	teamRepository := iteam.NewRepository(dynamoDbClient, "SportLinkCore")
-	matchOfferRepository := imatchoffer.NewRepository(dynamoDbClient, "SportLinkCore")
-	matchRequestRepository := imatchrequest.NewRepository(dynamoDbClient, "SportLinkCore")
-	matchRepository := imatch.NewRepository(dynamoDbClient, "SportLinkCore")
+	// Repositories — the backend is picked by STORAGE_BACKEND, the cache in front of it by CACHE_BACKEND
+	repos, cacheInvalidation := withCache(newRepositories(cfg), cfg.CacheCfg)
+	accountRepository := repos.account
+	playerRepository := repos.player
+	teamRepository := repos.team
+	matchOfferRepository := repos.matchOffer
+	matchRequestRepository := repos.matchRequest
+	matchRepository := repos.match
+	reminderRepository := repos.reminder
+	attendanceRepository := repos.attendance
+	outboxRepository := repos.outbox
+	processedMessageRepository := repos.processedMessage
+	notificationRepository := repos.notification
+	notificationPreferencesRepository := repos.notificationPreferences
+	chatRepository := repos.chat
+	historyRepository := repos.history
+
+	// Domain events are written to the outbox and relayed to the broker
+	eventPublisher := ievents.NewOutboxPublisher(outboxRepository)
 
 	// Account Use Cases
 	findAccount := uaccount.NewFindAccountUC(accountRepository)
@@ -64,32 +91,115 @@ func Routes(router *gin.Engine) {
 	createTeam := uteam.NewCreateTeamUC(playerRepository, teamRepository)
 	retrieveTeam := uteam.NewRetrieveTeamUC(teamRepository)
 	findTeam := uteam.NewFindTeamUC(teamRepository)
+	searchTeams := uteam.NewSearchTeamsUC(teamRepository)
+	listAccountTeams := uteam.NewListAccountTeamsUC(teamRepository)
 	updateTeam := uteam.NewUpdateTeamUC(teamRepository)
 
+	// Match Offer visibility
+	shareLinkSigner := matchofferservice.NewShareLinkSigner(cfg.AuthCfg.ShareLinkSecret, cfg.AuthCfg.ShareLinkTTL)
+	visibilityPolicy := matchofferservice.NewVisibilityPolicy(teamRepository, shareLinkSigner)
+
 	// Match Offer Use Cases
-	createMatchOffer := umatchoffer.NewCreateMatchOfferUC(matchOfferRepository)
+	createMatchOffer := umatchoffer.NewCreateMatchOfferUC(matchOfferRepository, teamRepository)
 	findAccountMatchOffers := umatchoffer.NewFindAccountMatchOffersUC(matchOfferRepository)
-	searchMatchOffers := umatchoffer.NewSearchMatchOffersUC(matchOfferRepository, matchRequestRepository)
-	retrieveMatchOffer := umatchoffer.NewRetrieveMatchOfferUC(matchOfferRepository)
-	deleteMatchOffer := umatchoffer.NewDeleteMatchOfferUC(matchOfferRepository)
+	searchMatchOffers := umatchoffer.NewSearchMatchOffersUC(matchOfferRepository, matchRequestRepository, visibilityPolicy)
+	retrieveMatchOffer := umatchoffer.NewRetrieveMatchOfferUC(matchOfferRepository, visibilityPolicy)
+	createShareLink := umatchoffer.NewCreateShareLinkUC(matchOfferRepository, shareLinkSigner)
+	revokeShareLinks := umatchoffer.NewRevokeShareLinksUC(matchOfferRepository)
+	deleteMatchOffer := umatchoffer.NewDeleteMatchOfferUC(matchOfferRepository, matchRequestRepository)
 
 	// Match Use Cases
+	paymentProvider := newPaymentProvider(cfg.PaymentCfg)
 	findMatches := umatch.NewFindMatchesUC(matchRepository, matchOfferRepository)
+	payMatchShare := umatch.NewPayMatchShareUC(matchRepository, paymentProvider)
+	findMatchPayment := umatch.NewFindMatchPaymentUC(matchRepository, matchOfferRepository)
+	confirmAttendance := umatch.NewConfirmAttendanceUC(matchRepository, attendanceRepository)
+	findMatchAttendance := umatch.NewFindMatchAttendanceUC(matchRepository, matchOfferRepository, attendanceRepository)
+	recordMatchResult := umatch.NewRecordMatchResultUC(matchRepository, matchOfferRepository)
+	cancelMatch := umatch.NewCancelMatchUC(matchRepository, matchOfferRepository)
+	scheduleMatchReminders := umatch.NewScheduleMatchRemindersUC(reminderRepository, matchOfferRepository, cfg.SchedulerCfg.ReminderOffsets)
 
 	// Match Offer — Confirm Use Case (creates the match)
 	confirmMatchOffer := umatchoffer.NewConfirmMatchOfferUC(matchRepository, matchOfferRepository, matchRequestRepository)
 
-	// Event infrastructure — auto-confirm when capacity is reached
-	capacityPublisher := ievents.NewChannelPublisher[matchofferevent.MatchOfferCapacityReachedEvent](100)
-	capacityConsumer := ievents.NewMatchOfferCapacityConsumer(capacityPublisher.Ch(), confirmMatchOffer)
-	capacityConsumer.Start(context.Background())
+	// Notification Use Cases
+	notifyAccounts := unotification.NewNotifyAccountsUC(notificationRepository, notificationPreferencesRepository)
+	findNotifications := unotification.NewFindNotificationsUC(notificationRepository)
+	markNotificationRead := unotification.NewMarkNotificationReadUC(notificationRepository)
+	markAllNotificationsRead := unotification.NewMarkAllNotificationsReadUC(notificationRepository)
+	retrieveNotificationPreferences := unotification.NewRetrieveNotificationPreferencesUC(notificationPreferencesRepository)
+	updateNotificationPreferences := unotification.NewUpdateNotificationPreferencesUC(notificationPreferencesRepository)
+	registerPushToken := unotification.NewRegisterPushTokenUC(notificationPreferencesRepository)
+	deliverNotification := unotification.NewDeliverNotificationUC(
+		notificationRepository,
+		notificationPreferencesRepository,
+		accountRepository,
+		matchOfferRepository,
+		processedMessageRepository,
+		notificationservice.NewRenderer(),
+		map[notification.Channel]notificationservice.Sender{
+			notification.ChannelEmail: newEmailSender(cfg.DeliveryCfg),
+			notification.ChannelPush:  newPushSender(cfg.DeliveryCfg),
+		},
+	)
+
+	// Chat Use Cases — threads belong to the people of a match request or a match
+	chatMembers := chatservice.NewMembers(matchRequestRepository, matchRepository)
+	chatModerator := chatservice.Moderators{chatservice.NewBlocklistModerator(cfg.ChatCfg.BlockedWords)}
+	findChatMessages := uchat.NewFindMessagesUC(chatRepository, chatMembers)
+	sendChatMessage := uchat.NewSendMessageUC(chatRepository, chatMembers, chatModerator, eventPublisher)
+	markChatThreadRead := uchat.NewMarkThreadReadUC(chatRepository, chatMembers, eventPublisher)
+
+	// History Use Cases — status changes are written by the repositories of each entity
+	findHistory := uhistory.NewFindHistoryUC(historyRepository, matchOfferRepository, matchRequestRepository, matchRepository, cfg.AuthCfg.AdminAccountIDs)
+
+	// Event infrastructure — the outbox is relayed to SQS, where consumers auto-confirm offers
+	// once their capacity is reached and fill the notification inboxes. All handlers share
+	// one queue, so they are registered on a single consumer.
+	retryPolicy := outbox.DefaultRetryPolicy()
+	retryPolicy.MaxAttempts = cfg.EventsCfg.MaxPublishAttempts
+	eventsBroker := newEventsBroker(cfg)
+	relayOutbox := uoutbox.NewRelayOutboxUC(outboxRepository, eventsBroker, retryPolicy)
+	scheduler.NewOutboxRelay(cfg.EventsCfg.RelayInterval, cfg.EventsCfg.RelayBatchSize, relayOutbox).Start(context.Background())
+	eventsConsumer := ievents.NewSQSConsumer("domain-events", eventsBroker, processedMessageRepository, retryPolicy).
+		Register(matchofferevent.MatchOfferCapacityReachedEventType, ievents.NewMatchOfferCapacityConsumer(confirmMatchOffer))
+	notificationConsumer := ievents.NewNotificationConsumer(notifyAccounts)
+	for _, eventType := range notificationConsumer.EventTypes() {
+		eventsConsumer.Register(eventType, notificationConsumer)
+	}
+	eventsConsumer.Register(notificationevent.NotificationDeliveryRequestedEventType, ievents.NewDeliveryConsumer(deliverNotification))
+	eventsConsumer.Register(matchevent.MatchConfirmedEventType, ievents.NewReminderConsumer(scheduleMatchReminders))
+
+	// Realtime streams — consumers push updates through the backplane to every instance,
+	// which writes them to the streams its clients keep open
+	realtimeHub := realtime.NewHub()
+	realtimeConsumer := ievents.NewRealtimeConsumer(newRealtimeBackplane(cfg, realtimeHub))
+	for _, eventType := range realtimeConsumer.EventTypes() {
+		eventsConsumer.Register(eventType, realtimeConsumer)
+	}
+	if cacheInvalidation != nil {
+		for _, eventType := range cacheInvalidation.EventTypes() {
+			eventsConsumer.Register(eventType, cacheInvalidation)
+		}
+	}
+	eventsConsumer.Start(context.Background())
+
+	// Expiry sweeper — archives offers and pending requests once the match time slot has ended
+	expireMatchOffers := umatchoffer.NewExpireMatchOffersUC(matchOfferRepository, matchRequestRepository)
+	scheduler.NewExpirySweeper(cfg.SchedulerCfg.ExpirySweepInterval, expireMatchOffers).Start(context.Background())
+
+	// Reminder sweeper — sends the reminders of upcoming matches once they are due
+	sendDueReminders := umatch.NewSendDueRemindersUC(reminderRepository, matchRepository, attendanceRepository, cfg.SchedulerCfg.ReminderBatchSize)
+	scheduler.NewReminderSweeper(cfg.SchedulerCfg.ReminderSweepInterval, sendDueReminders).Start(context.Background())
 
 	// Match Request Use Cases
-	createMatchRequest := umatchrequest.NewCreateMatchRequestUC(matchRequestRepository, matchOfferRepository)
+	createMatchRequest := umatchrequest.NewCreateMatchRequestUC(matchRequestRepository, matchOfferRepository, visibilityPolicy)
 	findMatchRequests := umatchrequest.NewFindMatchRequestsUC(matchRequestRepository)
 	updateMatchRequestStatus := umatchrequest.NewUpdateMatchRequestStatusUC(matchRequestRepository)
-	acceptMatchRequest := umatchrequest.NewAcceptMatchRequestUC(matchRequestRepository, matchOfferRepository, capacityPublisher)
-	cancelMatchRequest := umatchrequest.NewCancelMatchRequestUC(matchRequestRepository, matchOfferRepository)
+	acceptMatchRequest := umatchrequest.NewAcceptMatchRequestUC(matchRequestRepository, matchOfferRepository)
+	cancelMatchRequest := umatchrequest.NewCancelMatchRequestUC(matchRequestRepository, matchOfferRepository, matchRepository, paymentProvider)
+	bulkAcceptMatchRequests := umatchrequest.NewBulkAcceptMatchRequestsUC(matchRequestRepository, matchOfferRepository)
+	bulkRejectMatchRequests := umatchrequest.NewBulkRejectMatchRequestsUC(matchRequestRepository, matchOfferRepository)
 
 	// Auth Use Cases
 	googleVerifier := authservice.NewGoogleTokenVerifier(cfg.AuthCfg.GoogleClientID)
@@ -104,7 +214,7 @@ func Routes(router *gin.Engine) {
 	playerController := cplayer.NewController(&createPlayer, customValidator)
 	router.POST("/player", playerController.CreatePlayer)
 
-	teamController := cteam.NewController(createTeam, retrieveTeam, findTeam, findTeam, updateTeam, customValidator)
+	teamController := cteam.NewController(createTeam, retrieveTeam, findTeam, searchTeams, listAccountTeams, updateTeam, customValidator)
 	router.GET("/account", accountController.Find)
 	router.GET("/account/:account_id", accountController.Retrieve)
 
@@ -112,6 +222,7 @@ func Routes(router *gin.Engine) {
 	router.GET("/account/:account_id/team", teamController.ListAccountTeams)
 	router.GET("/sport/:sport/team/:team", teamController.RetrieveTeam)
 	router.GET("/sport/:sport/team", teamController.FindTeam)
+	router.GET("/team", teamController.SearchTeams)
 	router.PATCH("/sport/:sport/team/:team", teamController.UpdateTeam)
 
 	matchOfferController := cmatchoffer.NewController(
@@ -121,13 +232,17 @@ func Routes(router *gin.Engine) {
 		retrieveMatchOffer,
 		deleteMatchOffer,
 		confirmMatchOffer,
+		createShareLink,
+		revokeShareLinks,
 		customValidator,
 	)
+	offerAuth := middleware.AccountAuth(jwtService)
 	router.POST("/account/:account_id/match-offer", matchOfferController.CreateMatchOffer)
-	router.GET("/account/:account_id/match-offer/search", matchOfferController.SearchMatchOffers)
-	router.GET("/account/:account_id/match-offer", matchOfferController.FindAccountMatchOffers)
+	router.GET("/account/:account_id/match-offer/search", offerAuth, matchOfferController.SearchMatchOffers)
+	router.GET("/account/:account_id/match-offer", offerAuth, matchOfferController.FindAccountMatchOffers)
 	router.GET("/match-offer/:offer_id", matchOfferController.RetrieveMatchOffer)
-	router.DELETE("/account/:account_id/match-offer/:offer_id", matchOfferController.DeleteMatchOffer)
+	router.GET("/account/:account_id/match-offer/:offer_id", offerAuth, matchOfferController.RetrieveMatchOffer)
+	router.DELETE("/account/:account_id/match-offer/:offer_id", offerAuth, matchOfferController.DeleteMatchOffer)
 
 	matchRequestController := cmatchrequest.NewController(
 		createMatchRequest,
@@ -135,18 +250,135 @@ func Routes(router *gin.Engine) {
 		updateMatchRequestStatus,
 		acceptMatchRequest,
 		cancelMatchRequest,
+		bulkAcceptMatchRequests,
+		bulkRejectMatchRequests,
 		customValidator,
 	)
-	router.POST("/account/:account_id/match-offer/:offer_id/match-request", matchRequestController.CreateMatchRequest)
+	requestAuth := middleware.AccountAuth(jwtService)
+	router.POST("/account/:account_id/match-offer/:offer_id/match-request", offerAuth, matchRequestController.CreateMatchRequest)
 	router.GET("/account/:account_id/match-request", matchRequestController.FindMatchRequests)
-	router.PATCH("/account/:account_id/match-request/:request_id", matchRequestController.UpdateMatchRequestStatus)
-	router.POST("/account/:account_id/match-request/:request_id/accept", matchRequestController.AcceptMatchRequest)
-	router.POST("/account/:account_id/match-request/:request_id/cancel", matchRequestController.CancelMatchRequest)
+	router.PATCH("/account/:account_id/match-request/:request_id", requestAuth, matchRequestController.UpdateMatchRequestStatus)
+	router.POST("/account/:account_id/match-request/:request_id/accept", requestAuth, matchRequestController.AcceptMatchRequest)
+	router.POST("/account/:account_id/match-request/:request_id/cancel", middleware.AccountAuth(jwtService), matchRequestController.CancelMatchRequest)
+	router.POST("/account/:account_id/match-request/accept", requestAuth, matchRequestController.BulkAcceptMatchRequests)
+	router.POST("/account/:account_id/match-request/reject", requestAuth, matchRequestController.BulkRejectMatchRequests)
+	router.POST("/account/:account_id/match-offer/:offer_id/match-request/reject", requestAuth, matchRequestController.RejectPendingMatchRequests)
+
+	router.POST("/account/:account_id/match-offer/:offer_id/confirm", offerAuth, matchOfferController.ConfirmMatchOffer)
+	router.POST("/account/:account_id/match-offer/:offer_id/share-link", offerAuth, matchOfferController.CreateShareLink)
+	router.DELETE("/account/:account_id/match-offer/:offer_id/share-link", offerAuth, matchOfferController.RevokeShareLinks)
+
+	notificationController := cnotification.NewController(
+		findNotifications,
+		markNotificationRead,
+		markAllNotificationsRead,
+		retrieveNotificationPreferences,
+		updateNotificationPreferences,
+		registerPushToken,
+		customValidator,
+	)
+	notificationAuth := middleware.AccountAuth(jwtService)
+	router.GET("/account/:account_id/notification", notificationAuth, notificationController.FindNotifications)
+	router.POST("/account/:account_id/notification/read", notificationAuth, notificationController.MarkAllNotificationsRead)
+	router.POST("/account/:account_id/notification/:notification_id/read", notificationAuth, notificationController.MarkNotificationRead)
+	router.GET("/account/:account_id/notification/preferences", notificationAuth, notificationController.RetrievePreferences)
+	router.PUT("/account/:account_id/notification/preferences", notificationAuth, notificationController.UpdatePreferences)
+	router.POST("/account/:account_id/notification/push-token", notificationAuth, notificationController.RegisterPushToken)
+
+	chatController := cchat.NewController(findChatMessages, sendChatMessage, markChatThreadRead, customValidator)
+	chatAuth := middleware.AccountAuth(jwtService)
+	router.GET("/account/:account_id/chat/:kind/:thread_id/message", chatAuth, chatController.FindMessages)
+	router.POST("/account/:account_id/chat/:kind/:thread_id/message", chatAuth, chatController.SendMessage)
+	router.POST("/account/:account_id/chat/:kind/:thread_id/read", chatAuth, chatController.MarkThreadRead)
 
-	router.POST("/account/:account_id/match-offer/:offer_id/confirm", matchOfferController.ConfirmMatchOffer)
+	realtimeController := crealtime.NewController(realtimeHub, cfg.RealtimeCfg.HeartbeatInterval)
+	router.GET("/account/:account_id/stream", middleware.AccountAuth(jwtService), realtimeController.Stream)
 
-	matchController := cmatch.NewController(findMatches)
-	router.GET("/account/:account_id/match", matchController.FindMatches)
+	matchController := cmatch.NewController(findMatches, payMatchShare, findMatchPayment, confirmAttendance, findMatchAttendance, recordMatchResult, cancelMatch, customValidator)
+	matchAuth := middleware.AccountAuth(jwtService)
+	router.GET("/account/:account_id/match", matchAuth, matchController.FindMatches)
+	router.POST("/account/:account_id/match/:match_id/payment", matchAuth, matchController.PayMatchShare)
+	router.GET("/account/:account_id/match/:match_id/payment", matchAuth, matchController.FindMatchPayment)
+	router.POST("/account/:account_id/match/:match_id/attendance", matchAuth, matchController.ConfirmAttendance)
+	router.GET("/account/:account_id/match/:match_id/attendance", matchAuth, matchController.FindMatchAttendance)
+	router.POST("/account/:account_id/match/:match_id/result", matchAuth, matchController.RecordMatchResult)
+	router.POST("/account/:account_id/match/:match_id/cancel", matchAuth, matchController.CancelMatch)
+
+	historyController := chistory.NewController(findHistory)
+	historyAuth := middleware.AccountAuth(jwtService)
+	router.GET("/account/:account_id/match-offer/:offer_id/history", historyAuth, historyController.FindMatchOfferHistory)
+	router.GET("/account/:account_id/match-request/:request_id/history", historyAuth, historyController.FindMatchRequestHistory)
+	router.GET("/account/:account_id/match/:match_id/history", historyAuth, historyController.FindMatchHistory)
 
 	monitoring.RegisterMetricsRoute(router)
 }
+
+// newEventsBroker picks the queue domain events are relayed to, configured by EVENTS_BROKER.
+func newEventsBroker(cfg *config.Config) messaging.Broker {
+	switch cfg.EventsCfg.Broker {
+	case "sqs":
+		return messaging.NewBroker(config.NewSQSClient(cfg.DynamoDbCfg, cfg.EventsCfg), cfg.EventsCfg.QueueUrl)
+	case "memory":
+		return messaging.NewMemoryBroker()
+	default:
+		log.Fatalf("unknown events broker: %s", cfg.EventsCfg.Broker)
+		return nil
+	}
+}
+
+// newRealtimeBackplane picks the backplane configured by REALTIME_BACKPLANE.
+func newRealtimeBackplane(cfg *config.Config, hub *realtime.Hub) realtime.Backplane {
+	switch cfg.RealtimeCfg.Backplane {
+	case "local":
+		return irealtime.NewLocalBackplane(hub)
+	case "sqs":
+		sqsClient := config.NewSQSClient(cfg.DynamoDbCfg, cfg.EventsCfg)
+		peers := make([]messaging.Broker, len(cfg.RealtimeCfg.PeerQueueUrls))
+		for i, queueUrl := range cfg.RealtimeCfg.PeerQueueUrls {
+			peers[i] = messaging.NewBroker(sqsClient, queueUrl)
+		}
+		backplane := irealtime.NewSQSBackplane(messaging.NewBroker(sqsClient, cfg.RealtimeCfg.QueueUrl), peers, hub)
+		backplane.Start(context.Background())
+		return backplane
+	default:
+		log.Fatalf("unknown realtime backplane: %s", cfg.RealtimeCfg.Backplane)
+		return nil
+	}
+}
+
+// newEmailSender picks the email transport configured by EMAIL_PROVIDER.
+func newEmailSender(cfg config.DeliveryCfg) notificationservice.Sender {
+	switch cfg.EmailProvider {
+	case "fake":
+		return notificationservice.NewFakeSender()
+	case "smtp":
+		return inotificationdelivery.NewSMTPSender(cfg.SmtpHost, cfg.SmtpPort, cfg.SmtpUsername, cfg.SmtpPassword, cfg.EmailFrom)
+	default:
+		log.Fatalf("unknown email provider: %s", cfg.EmailProvider)
+		return nil
+	}
+}
+
+// newPushSender picks the push transport configured by PUSH_PROVIDER.
+func newPushSender(cfg config.DeliveryCfg) notificationservice.Sender {
+	switch cfg.PushProvider {
+	case "fake":
+		return notificationservice.NewFakeSender()
+	case "fcm":
+		return inotificationdelivery.NewFCMSender(cfg.FcmProjectID, cfg.FcmAccessToken)
+	default:
+		log.Fatalf("unknown push provider: %s", cfg.PushProvider)
+		return nil
+	}
+}
+
+// newPaymentProvider picks the payment provider implementation configured by PAYMENT_PROVIDER.
+func newPaymentProvider(cfg config.PaymentCfg) matchservice.PaymentProvider {
+	switch cfg.Provider {
+	case "fake":
+		return matchservice.NewFakePaymentProvider()
+	default:
+		log.Fatalf("unknown payment provider: %s", cfg.Provider)
+		return nil
+	}
+}
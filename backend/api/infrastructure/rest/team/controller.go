@@ -12,6 +12,7 @@ type Controller interface {
 	CreateTeam(c *gin.Context)
 	RetrieveTeam(c *gin.Context)
 	FindTeam(c *gin.Context)
+	SearchTeams(c *gin.Context)
 	ListAccountTeams(c *gin.Context)
 	UpdateTeam(c *gin.Context)
 }
@@ -20,7 +21,8 @@ type DefaultController struct {
 	createTeamUC       application.UseCase[team.Entity, team.Entity]
 	retrieveTeamUC     application.UseCase[team.ID, team.Entity]
 	findTeamUC         application.UseCase[team.DomainQuery, []team.Entity]
-	listAccountTeamsUC application.UseCase[team.DomainQuery, []team.Entity]
+	searchTeamsUC      application.UseCase[team.SearchQuery, team.Page]
+	listAccountTeamsUC application.UseCase[string, []team.Entity]
 	updateTeamUC       application.UseCase[team.PatchInput, team.Entity]
 	validator          *validator.Validate
 }
@@ -29,7 +31,8 @@ func NewController(
 	createTeamUc application.UseCase[team.Entity, team.Entity],
 	retrieveTeamUC application.UseCase[team.ID, team.Entity],
 	findTeamUC application.UseCase[team.DomainQuery, []team.Entity],
-	listAccountTeamsUC application.UseCase[team.DomainQuery, []team.Entity],
+	searchTeamsUC application.UseCase[team.SearchQuery, team.Page],
+	listAccountTeamsUC application.UseCase[string, []team.Entity],
 	updateTeamUC application.UseCase[team.PatchInput, team.Entity],
 	validator *validator.Validate,
 ) Controller {
@@ -37,6 +40,7 @@ func NewController(
 		createTeamUC:       createTeamUc,
 		retrieveTeamUC:     retrieveTeamUC,
 		findTeamUC:         findTeamUC,
+		searchTeamsUC:      searchTeamsUC,
 		listAccountTeamsUC: listAccountTeamsUC,
 		updateTeamUC:       updateTeamUC,
 		validator:          validator,
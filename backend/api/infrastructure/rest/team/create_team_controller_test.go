@@ -45,7 +45,7 @@ func TestTeamCreationHandlerWithEmptyFields(t *testing.T) {
 						entity.Stats == *common.NewStats(0, 0, 0) &&
 						entity.Name == "Boca Juniors" &&
 						entity.Category == common.L1
-				})).Return(nil)
+				}), mock.Anything).Return(nil)
 				playerRepository.On("Find", mock.Anything, mock.Anything).Return([]player.Entity{
 					{
 						ID: "1",
@@ -68,7 +68,7 @@ func TestTeamCreationHandlerWithEmptyFields(t *testing.T) {
 				PlayerIds: []string{"1", "2"},
 			},
 			on: func(t *testing.T, playerRepository *pmocks.Repository, teamRepository *tmocks.Repository) {
-				teamRepository.On("Save", mock.Anything, mock.Anything).Return(nil)
+				teamRepository.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				playerRepository.On("Find", mock.Anything, mock.Anything).Return([]player.Entity{
 					{
 						ID: "1",
@@ -89,7 +89,7 @@ func TestTeamCreationHandlerWithEmptyFields(t *testing.T) {
 				Category: 1,
 			},
 			on: func(t *testing.T, playerRepository *pmocks.Repository, teamRepository *tmocks.Repository) {
-				teamRepository.On("Save", mock.Anything, mock.Anything).Return(nil)
+				teamRepository.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				playerRepository.On("Find", mock.Anything, mock.Anything).Return([]player.Entity{}, nil)
 			},
 			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
@@ -100,7 +100,7 @@ func TestTeamCreationHandlerWithEmptyFields(t *testing.T) {
 			name:           "create a new River Plate team successfully",
 			payloadRequest: request2.NewTeamRequest{Sport: "Football", Name: "River Plate", Category: 2},
 			on: func(t *testing.T, playerRepository *pmocks.Repository, teamRepository *tmocks.Repository) {
-				teamRepository.On("Save", mock.Anything, mock.Anything).Return(nil)
+				teamRepository.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				playerRepository.On("Find", mock.Anything, mock.Anything).Return([]player.Entity{}, nil)
 			},
 			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
@@ -111,7 +111,7 @@ func TestTeamCreationHandlerWithEmptyFields(t *testing.T) {
 			name:           "create a new Los Delfines paddle team successfully",
 			payloadRequest: request2.NewTeamRequest{Sport: "Paddle", Name: "Los Delfines", Category: 7},
 			on: func(t *testing.T, playerRepository *pmocks.Repository, teamRepository *tmocks.Repository) {
-				teamRepository.On("Save", mock.Anything, mock.Anything).Return(nil)
+				teamRepository.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				playerRepository.On("Find", mock.Anything, mock.Anything).Return([]player.Entity{}, nil)
 			},
 			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
@@ -122,7 +122,7 @@ func TestTeamCreationHandlerWithEmptyFields(t *testing.T) {
 			name:           "fails when create a new team with invalid category",
 			payloadRequest: request2.NewTeamRequest{Sport: "Football", Name: "Boca Juniors", Category: 9},
 			on: func(t *testing.T, playerRepository *pmocks.Repository, teamRepository *tmocks.Repository) {
-				teamRepository.On("Save", mock.Anything, mock.Anything).Return(nil)
+				teamRepository.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				playerRepository.On("Find", mock.Anything, mock.Anything).Return([]player.Entity{}, nil)
 			},
 			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
@@ -134,7 +134,7 @@ func TestTeamCreationHandlerWithEmptyFields(t *testing.T) {
 			name:           "fails when create a new team with invalid sport",
 			payloadRequest: request2.NewTeamRequest{Sport: "fuchibol", Name: "River Plate", Category: 2},
 			on: func(t *testing.T, playerRepository *pmocks.Repository, teamRepository *tmocks.Repository) {
-				teamRepository.On("Save", mock.Anything, mock.Anything).Return(nil)
+				teamRepository.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				playerRepository.On("Find", mock.Anything, mock.Anything).Return([]player.Entity{}, nil)
 			},
 			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
@@ -146,7 +146,7 @@ func TestTeamCreationHandlerWithEmptyFields(t *testing.T) {
 			name:           "create a new team successfully",
 			payloadRequest: request2.NewTeamRequest{Sport: "Football", Name: "", Category: 1},
 			on: func(t *testing.T, playerRepository *pmocks.Repository, teamRepository *tmocks.Repository) {
-				teamRepository.On("Save", mock.Anything, mock.Anything).Return(nil)
+				teamRepository.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				playerRepository.On("Find", mock.Anything, mock.Anything).Return([]player.Entity{}, nil)
 			},
 			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
@@ -166,7 +166,9 @@ func TestTeamCreationHandlerWithEmptyFields(t *testing.T) {
 			findTeamUC := usecases.NewFindTeamUC(teamRepository)
 
 			updateTeamUC := usecases.NewUpdateTeamUC(teamRepository)
-			controller := team.NewController(createTeamUC, retrieveTeamUC, findTeamUC, findTeamUC, updateTeamUC, validator)
+			searchTeamsUC := usecases.NewSearchTeamsUC(teamRepository)
+			listAccountTeamsUC := usecases.NewListAccountTeamsUC(teamRepository)
+			controller := team.NewController(createTeamUC, retrieveTeamUC, findTeamUC, searchTeamsUC, listAccountTeamsUC, updateTeamUC, validator)
 
 			gin.SetMode(gin.TestMode)
 			router := gin.Default()
@@ -5,14 +5,12 @@ import (
 	"sportlink/api/application/errors"
 	"sportlink/api/domain/common"
 	"sportlink/api/domain/team"
-	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 // FindTeam handles the GET request to find teams by sport, name pattern, and categories.
-// Endpoint: GET /sport/:sport/team?name=<name>&category=<category1,category2>
+// Endpoint: GET /sport/:sport/team?name=<name>&category=<category1,category2|from-to>
 // All query parameters are optional (sport in path is required)
 func (sc *DefaultController) FindTeam(c *gin.Context) {
 	sportParam := c.Param("sport")
@@ -30,24 +28,12 @@ func (sc *DefaultController) FindTeam(c *gin.Context) {
 	}
 
 	// Parse categories if provided
-	if categoryQuery != "" {
-		categoryStrings := strings.Split(categoryQuery, ",")
-		var categories []common.Category
-		for _, catStr := range categoryStrings {
-			catInt, err := strconv.Atoi(strings.TrimSpace(catStr))
-			if err != nil {
-				c.Error(errors.RequestValidationFailed("invalid category format"))
-				return
-			}
-			category, err := common.GetCategory(catInt)
-			if err != nil {
-				c.Error(errors.RequestValidationFailed(err.Error()))
-				return
-			}
-			categories = append(categories, category)
-		}
-		query.Categories = categories
+	categories, err := queryParser.Categories(categoryQuery)
+	if err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
 	}
+	query.Categories = categories
 
 	teams, err := sc.findTeamUC.Invoke(c.Request.Context(), query)
 
@@ -335,7 +335,9 @@ func TestFindTeamController(t *testing.T) {
 			findTeamUC := usecases.NewFindTeamUC(teamRepository)
 
 			updateTeamUC := usecases.NewUpdateTeamUC(teamRepository)
-			controller := team.NewController(createTeamUC, retrieveTeamUC, findTeamUC, findTeamUC, updateTeamUC, validator)
+			searchTeamsUC := usecases.NewSearchTeamsUC(teamRepository)
+			listAccountTeamsUC := usecases.NewListAccountTeamsUC(teamRepository)
+			controller := team.NewController(createTeamUC, retrieveTeamUC, findTeamUC, searchTeamsUC, listAccountTeamsUC, updateTeamUC, validator)
 
 			gin.SetMode(gin.TestMode)
 			router := gin.Default()
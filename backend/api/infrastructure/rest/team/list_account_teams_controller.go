@@ -4,7 +4,6 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"sportlink/api/application/errors"
-	"sportlink/api/domain/team"
 )
 
 // ListAccountTeams handles GET /account/:account_id/team
@@ -15,11 +14,7 @@ func (sc *DefaultController) ListAccountTeams(c *gin.Context) {
 		return
 	}
 
-	query := team.DomainQuery{
-		OwnerAccountID: accountID,
-	}
-
-	result, err := sc.listAccountTeamsUC.Invoke(c.Request.Context(), query)
+	result, err := sc.listAccountTeamsUC.Invoke(c.Request.Context(), accountID)
 	if err != nil {
 		c.Error(errors.UseCaseExecutionFailed(err.Error()))
 		return
@@ -0,0 +1,10 @@
+package response
+
+import "sportlink/api/domain/team"
+
+// TeamPageResponse is one page of a team search. NextCursor is passed back as the cursor
+// query parameter to get the following page; it is absent on the last page.
+type TeamPageResponse struct {
+	Teams      []team.Entity `json:"teams"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
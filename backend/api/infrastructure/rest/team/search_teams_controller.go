@@ -0,0 +1,101 @@
+package team
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sportlink/api/application/errors"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/team"
+	"sportlink/api/infrastructure/rest/matchoffer/parser"
+	"sportlink/api/infrastructure/rest/team/response"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var queryParser = parser.NewQueryParser()
+
+// SearchTeams handles GET /team
+// Returns the teams to discover in search name order. Supports the query parameters name,
+// fuzzy=true, sport=<sport1,sport2>, category=<category1,category2|from-to>, lat, lng and
+// radius_km, looking_for_players=true, limit and cursor. Every parameter is optional.
+func (sc *DefaultController) SearchTeams(c *gin.Context) {
+	query := team.SearchQuery{
+		Name:   c.Query("name"),
+		Cursor: c.Query("cursor"),
+	}
+
+	fuzzy, err := boolQuery(c, "fuzzy")
+	if err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+	query.Fuzzy = fuzzy
+
+	sports, err := queryParser.Sports(c.Query("sport"))
+	if err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+	for _, sport := range sports {
+		if !slices.Contains(common.Sports(), sport) {
+			c.Error(errors.RequestValidationFailed("invalid sport: " + string(sport)))
+			return
+		}
+	}
+	query.Sports = sports
+
+	categories, err := queryParser.Categories(c.Query("category"))
+	if err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+	query.Categories = categories
+
+	near, err := queryParser.GeoFilter(c.Query("lat"), c.Query("lng"), c.Query("radius_km"))
+	if err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+	if near != nil {
+		query.Near = &team.GeoFilter{Latitude: near.Latitude, Longitude: near.Longitude, RadiusKm: near.RadiusKm}
+	}
+
+	lookingForPlayers, err := boolQuery(c, "looking_for_players")
+	if err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
+	query.LookingForPlayers = lookingForPlayers
+
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			c.Error(errors.RequestValidationFailed("limit must be a positive number"))
+			return
+		}
+		query.Limit = parsed
+	}
+
+	page, err := sc.searchTeamsUC.Invoke(c.Request.Context(), query)
+	if err != nil {
+		c.Error(errors.UseCaseExecutionFailed(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.TeamPageResponse{Teams: page.Entities, NextCursor: page.NextCursor})
+}
+
+// boolQuery parses the query parameter, false when it is absent.
+func boolQuery(c *gin.Context, name string) (bool, error) {
+	value := c.Query(name)
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false", name)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,149 @@
+package team_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sportlink/api/application/team/usecases"
+	"sportlink/api/domain/common"
+	team2 "sportlink/api/domain/team"
+	"sportlink/api/infrastructure/middleware"
+	"sportlink/api/infrastructure/rest/team"
+	tmocks "sportlink/mocks/api/domain/team"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchTeamsController(t *testing.T) {
+	validator := validator.New()
+
+	testCases := []struct {
+		name       string
+		query      string
+		on         func(t *testing.T, teamRepository *tmocks.Repository)
+		assertions func(t *testing.T, responseCode int, response map[string]interface{})
+	}{
+		{
+			name:  "searches by every criterion and returns the page with its next cursor",
+			query: "name=atletico&fuzzy=true&sport=Football,Paddle&category=2-4&lat=-34.6&lng=-58.38&radius_km=10&looking_for_players=true&limit=1&cursor=boca%2301JA",
+			on: func(t *testing.T, teamRepository *tmocks.Repository) {
+				teamRepository.On("Search", mock.Anything, mock.MatchedBy(func(query team2.SearchQuery) bool {
+					return query.Name == "atletico" && query.Fuzzy &&
+						assert.ObjectsAreEqual([]common.Sport{common.Football, common.Paddle}, query.Sports) &&
+						assert.ObjectsAreEqual([]common.Category{common.L2, common.L3, common.L4}, query.Categories) &&
+						assert.ObjectsAreEqual(&team2.GeoFilter{Latitude: -34.6, Longitude: -58.38, RadiusKm: 10}, query.Near) &&
+						query.LookingForPlayers && query.Limit == 1 && query.Cursor == "boca#01JA"
+				})).Return(team2.Page{
+					Entities:   []team2.Entity{{ID: "01JB", Name: "Club Atlético Tigre", Sport: common.Football, Category: common.L2}},
+					NextCursor: "club atletico tigre#01JB",
+				}, nil)
+			},
+			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
+				assert.Equal(t, http.StatusOK, responseCode)
+				teams := response["teams"].([]interface{})
+				assert.Len(t, teams, 1)
+				assert.Equal(t, "Club Atlético Tigre", teams[0].(map[string]interface{})["Name"])
+				assert.Equal(t, "club atletico tigre#01JB", response["next_cursor"])
+			},
+		},
+		{
+			name:  "searches every sport with a default page when no parameter is given",
+			query: "",
+			on: func(t *testing.T, teamRepository *tmocks.Repository) {
+				teamRepository.On("Search", mock.Anything, mock.MatchedBy(func(query team2.SearchQuery) bool {
+					return len(query.Sports) == 0 && query.Near == nil && query.Limit == team2.DefaultPageSize
+				})).Return(team2.Page{Entities: []team2.Entity{}}, nil)
+			},
+			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
+				assert.Equal(t, http.StatusOK, responseCode)
+				assert.Empty(t, response["teams"])
+				assert.NotContains(t, response, "next_cursor")
+			},
+		},
+		{
+			name:  "fails when a sport is unknown",
+			query: "sport=Football,Curling",
+			on:    func(t *testing.T, teamRepository *tmocks.Repository) {},
+			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
+				assert.Equal(t, http.StatusBadRequest, responseCode)
+				assert.Contains(t, response["message"], "invalid sport: Curling")
+			},
+		},
+		{
+			name:  "fails when a category range is reversed",
+			query: "category=4-2",
+			on:    func(t *testing.T, teamRepository *tmocks.Repository) {},
+			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
+				assert.Equal(t, http.StatusBadRequest, responseCode)
+				assert.Contains(t, response["message"], "invalid category range: 4-2")
+			},
+		},
+		{
+			name:  "fails when fuzzy is not a boolean",
+			query: "name=boca&fuzzy=maybe",
+			on:    func(t *testing.T, teamRepository *tmocks.Repository) {},
+			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
+				assert.Equal(t, http.StatusBadRequest, responseCode)
+				assert.Contains(t, response["message"], "fuzzy must be true or false")
+			},
+		},
+		{
+			name:  "fails when the limit is not positive",
+			query: "limit=0",
+			on:    func(t *testing.T, teamRepository *tmocks.Repository) {},
+			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
+				assert.Equal(t, http.StatusBadRequest, responseCode)
+				assert.Contains(t, response["message"], "limit must be a positive number")
+			},
+		},
+		{
+			name:  "fails when the repository fails",
+			query: "name=boca",
+			on: func(t *testing.T, teamRepository *tmocks.Repository) {
+				teamRepository.On("Search", mock.Anything, mock.Anything).Return(team2.Page{}, fmt.Errorf("database connection error"))
+			},
+			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
+				assert.Equal(t, http.StatusConflict, responseCode)
+				assert.Contains(t, response["message"], "database connection error")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			teamRepository := new(tmocks.Repository)
+			createTeamUC := usecases.NewCreateTeamUC(nil, teamRepository)
+			retrieveTeamUC := usecases.NewRetrieveTeamUC(teamRepository)
+			findTeamUC := usecases.NewFindTeamUC(teamRepository)
+			updateTeamUC := usecases.NewUpdateTeamUC(teamRepository)
+			searchTeamsUC := usecases.NewSearchTeamsUC(teamRepository)
+			listAccountTeamsUC := usecases.NewListAccountTeamsUC(teamRepository)
+			controller := team.NewController(createTeamUC, retrieveTeamUC, findTeamUC, searchTeamsUC, listAccountTeamsUC, updateTeamUC, validator)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.Use(middleware.ErrorHandler())
+			router.GET("/team", controller.SearchTeams)
+
+			// given
+			tc.on(t, teamRepository)
+			req, _ := http.NewRequest("GET", "/team?"+tc.query, nil)
+			resp := httptest.NewRecorder()
+
+			// when
+			router.ServeHTTP(resp, req)
+
+			// then
+			var response map[string]interface{}
+			json.Unmarshal(resp.Body.Bytes(), &response)
+			tc.assertions(t, resp.Code, response)
+			teamRepository.AssertExpectations(t)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package team
 import (
 	"net/http"
 	"sportlink/api/application/errors"
+	"sportlink/api/application/team/mapper"
 	"sportlink/api/application/team/request"
 	"sportlink/api/domain/common"
 	"sportlink/api/domain/team"
@@ -21,6 +22,10 @@ func (sc *DefaultController) UpdateTeam(c *gin.Context) {
 		c.Error(errors.InvalidRequestFormat())
 		return
 	}
+	if err := sc.validator.Struct(req); err != nil {
+		c.Error(errors.RequestValidationFailed(err.Error()))
+		return
+	}
 
 	input := team.PatchInput{
 		ID: team.ID{
@@ -31,6 +36,8 @@ func (sc *DefaultController) UpdateTeam(c *gin.Context) {
 	if req.Name != "" {
 		input.Name = &req.Name
 	}
+	input.LookingForPlayers = req.LookingForPlayers
+	input.HomeArea = mapper.HomeAreaRequestToDomain(req.HomeArea)
 
 	result, err := sc.updateTeamUC.Invoke(c.Request.Context(), input)
 	if err != nil {
@@ -25,7 +25,7 @@ func TestUpdateTeamController(t *testing.T) {
 	v := validator.New()
 
 	existingTeam := team2.Entity{
-		ID:       "SPORT#Football#NAME#Boca Juniors",
+		ID:       "01JA0000000000000000000001",
 		Name:     "Boca Juniors",
 		Sport:    common.Football,
 		Category: common.L1,
@@ -41,6 +41,38 @@ func TestUpdateTeamController(t *testing.T) {
 		on         func(t *testing.T, teamRepository *tmocks.Repository)
 		assertions func(t *testing.T, responseCode int, response map[string]interface{})
 	}{
+		{
+			name:     "sets the home area of the team and that it looks for players",
+			sport:    "Football",
+			teamName: "Boca Juniors",
+			body: map[string]interface{}{
+				"looking_for_players": true,
+				"home_area":           map[string]interface{}{"locality": "La Boca", "latitude": -34.6345, "longitude": -58.3631},
+			},
+			on: func(t *testing.T, teamRepository *tmocks.Repository) {
+				teamRepository.On("Find", mock.Anything, mock.Anything).Return([]team2.Entity{existingTeam}, nil)
+				teamRepository.On("Update", mock.Anything, "Boca Juniors", mock.MatchedBy(func(e team2.Entity) bool {
+					return e.LookingForPlayers && e.HomeArea != nil && e.HomeArea.Locality == "La Boca" && e.HomeArea.Latitude == -34.6345
+				}), mock.Anything).Return(nil)
+			},
+			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
+				assert.Equal(t, http.StatusOK, responseCode)
+				assert.Equal(t, true, response["LookingForPlayers"])
+			},
+		},
+		{
+			name:     "fails when the home area has an invalid latitude",
+			sport:    "Football",
+			teamName: "Boca Juniors",
+			body: map[string]interface{}{
+				"home_area": map[string]interface{}{"locality": "La Boca", "latitude": -134.6, "longitude": -58.3631},
+			},
+			on: func(t *testing.T, teamRepository *tmocks.Repository) {},
+			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
+				assert.Equal(t, http.StatusBadRequest, responseCode)
+				assert.Contains(t, response["message"], "Field validation for 'Latitude'")
+			},
+		},
 		{
 			name:     "updates team name successfully",
 			sport:    "Football",
@@ -50,9 +82,9 @@ func TestUpdateTeamController(t *testing.T) {
 				teamRepository.On("Find", mock.Anything, mock.MatchedBy(func(q team2.DomainQuery) bool {
 					return q.Name == "Boca Juniors" && len(q.Sports) == 1 && q.Sports[0] == common.Football
 				})).Return([]team2.Entity{existingTeam}, nil)
-				teamRepository.On("Update", mock.Anything, "SPORT#Football#NAME#Boca Juniors", mock.MatchedBy(func(e team2.Entity) bool {
+				teamRepository.On("Update", mock.Anything, "Boca Juniors", mock.MatchedBy(func(e team2.Entity) bool {
 					return e.Name == "Boca Senior"
-				})).Return(nil)
+				}), mock.Anything).Return(nil)
 			},
 			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
 				assert.Equal(t, http.StatusOK, responseCode)
@@ -66,9 +98,9 @@ func TestUpdateTeamController(t *testing.T) {
 			body:     map[string]interface{}{},
 			on: func(t *testing.T, teamRepository *tmocks.Repository) {
 				teamRepository.On("Find", mock.Anything, mock.Anything).Return([]team2.Entity{existingTeam}, nil)
-				teamRepository.On("Update", mock.Anything, "SPORT#Football#NAME#Boca Juniors", mock.MatchedBy(func(e team2.Entity) bool {
+				teamRepository.On("Update", mock.Anything, "Boca Juniors", mock.MatchedBy(func(e team2.Entity) bool {
 					return e.Name == "Boca Juniors"
-				})).Return(nil)
+				}), mock.Anything).Return(nil)
 			},
 			assertions: func(t *testing.T, responseCode int, response map[string]interface{}) {
 				assert.Equal(t, http.StatusOK, responseCode)
@@ -112,8 +144,10 @@ func TestUpdateTeamController(t *testing.T) {
 			retrieveTeamUC := usecases.NewRetrieveTeamUC(teamRepository)
 			findTeamUC := usecases.NewFindTeamUC(teamRepository)
 			updateTeamUC := usecases.NewUpdateTeamUC(teamRepository)
+			searchTeamsUC := usecases.NewSearchTeamsUC(teamRepository)
+			listAccountTeamsUC := usecases.NewListAccountTeamsUC(teamRepository)
 
-			controller := team.NewController(createTeamUC, retrieveTeamUC, findTeamUC, findTeamUC, updateTeamUC, v)
+			controller := team.NewController(createTeamUC, retrieveTeamUC, findTeamUC, searchTeamsUC, listAccountTeamsUC, updateTeamUC, v)
 
 			gin.SetMode(gin.TestMode)
 			router := gin.Default()
@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application"
+	"sportlink/api/application/matchoffer/usecases"
+	"sportlink/api/domain/history"
+	"sportlink/pkg/log"
+	"time"
+)
+
+// ExpirySweeper periodically expires match offers whose time slot has ended.
+// Every sweep is idempotent, so several API instances can run it concurrently.
+type ExpirySweeper struct {
+	interval time.Duration
+	expireUC application.UseCase[usecases.ExpireMatchOffersInput, usecases.ExpireMatchOffersResult]
+}
+
+func NewExpirySweeper(
+	interval time.Duration,
+	expireUC application.UseCase[usecases.ExpireMatchOffersInput, usecases.ExpireMatchOffersResult],
+) *ExpirySweeper {
+	return &ExpirySweeper{interval: interval, expireUC: expireUC}
+}
+
+// Start launches the sweeper goroutine. It stops when ctx is cancelled.
+func (s *ExpirySweeper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.sweep(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *ExpirySweeper) sweep(ctx context.Context) {
+	ctx = history.WithOrigin(ctx, history.Origin{Source: history.SourceSweeper})
+	result, err := s.expireUC.Invoke(ctx, usecases.ExpireMatchOffersInput{Now: time.Now()})
+	if err != nil {
+		log.GetLogger(ctx).Error("expiry sweep failed", err)
+		return
+	}
+	if len(result.ExpiredOfferIDs) > 0 {
+		log.GetLogger(ctx).Info(fmt.Sprintf("expiry sweep expired %d match offers", len(result.ExpiredOfferIDs)))
+	}
+	if len(result.ExpiredWaitlistRequestIDs) > 0 {
+		log.GetLogger(ctx).Info(fmt.Sprintf("expiry sweep closed %d waitlisted match requests", len(result.ExpiredWaitlistRequestIDs)))
+	}
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application"
+	"sportlink/api/application/outbox/usecases"
+	"sportlink/pkg/log"
+	"time"
+)
+
+// OutboxRelay periodically publishes the due outbox messages to the broker.
+// Several API instances may relay the same message; consumers deduplicate by message ID.
+type OutboxRelay struct {
+	interval  time.Duration
+	batchSize int
+	relayUC   application.UseCase[usecases.RelayOutboxInput, usecases.RelayOutboxResult]
+}
+
+func NewOutboxRelay(
+	interval time.Duration,
+	batchSize int,
+	relayUC application.UseCase[usecases.RelayOutboxInput, usecases.RelayOutboxResult],
+) *OutboxRelay {
+	return &OutboxRelay{interval: interval, batchSize: batchSize, relayUC: relayUC}
+}
+
+// Start launches the relay goroutine. It stops when ctx is cancelled.
+func (r *OutboxRelay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.relay(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (r *OutboxRelay) relay(ctx context.Context) {
+	result, err := r.relayUC.Invoke(ctx, usecases.RelayOutboxInput{Now: time.Now(), BatchSize: r.batchSize})
+	if err != nil {
+		log.GetLogger(ctx).Error("outbox relay failed", err)
+		return
+	}
+	if result.Retried > 0 || result.Failed > 0 {
+		log.GetLogger(ctx).Info(fmt.Sprintf("outbox relay published %d messages, %d will be retried, %d failed",
+			result.Published, result.Retried, result.Failed))
+	}
+}
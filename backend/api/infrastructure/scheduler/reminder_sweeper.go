@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/application"
+	"sportlink/api/application/match/usecases"
+	"sportlink/pkg/log"
+	"time"
+)
+
+// ReminderSweeper periodically sends the match reminders that are due. Reminders are
+// stored, not held in timers, and claimed one by one, so several API instances can
+// run it concurrently and a restart loses none.
+type ReminderSweeper struct {
+	interval time.Duration
+	sendUC   application.UseCase[usecases.SendDueRemindersInput, usecases.SendDueRemindersResult]
+}
+
+func NewReminderSweeper(
+	interval time.Duration,
+	sendUC application.UseCase[usecases.SendDueRemindersInput, usecases.SendDueRemindersResult],
+) *ReminderSweeper {
+	return &ReminderSweeper{interval: interval, sendUC: sendUC}
+}
+
+// Start launches the sweeper goroutine. It stops when ctx is cancelled.
+func (s *ReminderSweeper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.sweep(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *ReminderSweeper) sweep(ctx context.Context) {
+	result, err := s.sendUC.Invoke(ctx, usecases.SendDueRemindersInput{Now: time.Now()})
+	if err != nil {
+		log.GetLogger(ctx).Error("reminder sweep failed", err)
+		return
+	}
+	if result.Sent > 0 {
+		log.GetLogger(ctx).Info(fmt.Sprintf("reminder sweep sent %d match reminders", result.Sent))
+	}
+}
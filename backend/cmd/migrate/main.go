@@ -0,0 +1,70 @@
+// Command migrate rewrites the items of the SportLinkCore table whose shape changed, running
+// the migrations that did not run to completion yet.
+//
+//	go run ./cmd/migrate -dry-run
+//
+// It takes a share of the capacity provisioned for the table, or the units given with
+// -read-units and -write-units, which on-demand tables need to be throttled at all.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"sportlink/api/infrastructure/config"
+	"sportlink/api/infrastructure/persistence/migration"
+
+	"github.com/sethvargo/go-envconfig"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "count the items to rewrite without writing anything")
+	tableName := flag.String("table", "SportLinkCore", "table to migrate")
+	pageSize := flag.Int("page-size", 100, "items read per page, and between checkpoints")
+	capacityShare := flag.Float64("capacity-share", 0.5, "share of the provisioned capacity to consume")
+	readUnits := flag.Float64("read-units", 0, "read units per second to consume, instead of a share of the provisioned ones")
+	writeUnits := flag.Float64("write-units", 0, "write units per second to consume, instead of a share of the provisioned ones")
+	flag.Parse()
+
+	ctx := context.Background()
+	var dynamoDbCfg config.DynamoDbCfg
+	if err := envconfig.Process(ctx, &dynamoDbCfg); err != nil {
+		log.Fatalf("unable to load config, %v", err)
+	}
+	client := config.NewDynamoDBClient(dynamoDbCfg)
+
+	provisionedRead, provisionedWrite, err := migration.ProvisionedUnits(ctx, client, *tableName, *capacityShare)
+	if err != nil {
+		log.Fatalf("unable to describe table %s, %v", *tableName, err)
+	}
+	if *readUnits == 0 {
+		*readUnits = provisionedRead
+	}
+	if *writeUnits == 0 {
+		*writeUnits = provisionedWrite
+	}
+	if *readUnits == 0 && *writeUnits == 0 {
+		log.Printf("%s has no provisioned capacity, running without throttling", *tableName)
+	}
+
+	runner := migration.NewRunner(client, *tableName, migration.Options{
+		DryRun:              *dryRun,
+		PageSize:            int32(*pageSize),
+		ReadUnitsPerSecond:  *readUnits,
+		WriteUnitsPerSecond: *writeUnits,
+	})
+	reports, err := runner.Run(ctx, migration.All())
+	for _, report := range reports {
+		switch {
+		case report.Done:
+			log.Printf("migration %s: already done", report.Version)
+		case *dryRun:
+			log.Printf("migration %s: would rewrite %d of %d items", report.Version, report.Rewritten, report.Scanned)
+		default:
+			log.Printf("migration %s: rewrote %d of %d items", report.Version, report.Rewritten, report.Scanned)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -10,6 +10,7 @@ func main() {
 	r := gin.Default()
 
 	r.Use(middleware.ErrorHandler())
+	r.Use(middleware.EventContext())
 
 	rest.Routes(r)
 
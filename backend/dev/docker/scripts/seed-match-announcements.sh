@@ -200,7 +200,6 @@ insert_match_announcement() {
     local start_time=$((day_seconds + (hour * 3600)))
     local end_time=$((start_time + (duration_hours * 3600)))
     local created_at=$NOW
-    local expires_at=$((created_at + (30 * 86400)))  # 30 días desde ahora
 
     # Construir el item JSON según el tipo de rango usando un archivo temporal
     local temp_file=$(mktemp)
@@ -255,8 +254,7 @@ insert_match_announcement() {
 
         echo "    \"OwnerAccountId\": {\"S\": \"${owner_account_id}\"},"
         echo "    \"Status\": {\"S\": \"${status}\"},"
-        echo "    \"CreatedAt\": {\"N\": \"${created_at}\"},"
-        echo "    \"ExpiresAt\": {\"N\": \"${expires_at}\"}"
+        echo "    \"CreatedAt\": {\"N\": \"${created_at}\"}"
         echo "}"
     } > "$temp_file"
 
@@ -18,13 +18,76 @@ print_banner() {
 
 print_banner "Insertando equipos de ejemplo en DynamoDB..."
 
+# Los equipos se identifican por un ULID fijo, para que cada seed les dé el mismo ID
+TEAM_COUNT=0
+
+next_team_id() {
+    TEAM_COUNT=$((TEAM_COUNT + 1))
+    TEAM_ID=$(printf '01JSEED%019d' "$TEAM_COUNT")
+}
+
+# Función auxiliar para reservar el nombre de un equipo dentro de su deporte
+claim_team_name() {
+    local sport=$1
+    local name=$2
+    local team_id=$3
+
+    awslocal dynamodb put-item \
+        --table-name "$TABLE_NAME" \
+        --region "$REGION" \
+        --item "{
+            \"EntityId\": {\"S\": \"Entity#TeamName\"},
+            \"Id\": {\"S\": \"SPORT#${sport}#NAME#${name}\"},
+            \"TeamId\": {\"S\": \"${team_id}\"}
+        }" \
+        --return-consumed-capacity TOTAL > /dev/null
+}
+
+# Nombre con el que se buscan los equipos: en minúsculas, sin acentos y con un solo espacio
+# entre palabras, como team.SearchName
+search_name() {
+    local name=$1
+    local pair
+    for pair in á:a à:a â:a ä:a ã:a å:a Á:a À:a Â:a Ä:a Ã:a Å:a \
+        é:e è:e ê:e ë:e É:e È:e Ê:e Ë:e í:i ì:i î:i ï:i Í:i Ì:i Î:i Ï:i \
+        ó:o ò:o ô:o ö:o õ:o Ó:o Ò:o Ô:o Ö:o Õ:o ú:u ù:u û:u ü:u Ú:u Ù:u Û:u Ü:u \
+        ñ:n Ñ:n ç:c Ç:c; do
+        name=${name//${pair%%:*}/${pair##*:}}
+    done
+    echo "$name" | tr '[:upper:]' '[:lower:]' | tr -s '[:space:]' ' ' | sed -e 's/^ //' -e 's/ $//'
+}
+
+# Función auxiliar para indexar un equipo en las búsquedas
+index_team() {
+    local sport=$1
+    local name=$2
+    local category=$3
+    local team_id=$4
+    local folded
+    folded=$(search_name "$name")
+
+    awslocal dynamodb put-item \
+        --table-name "$TABLE_NAME" \
+        --region "$REGION" \
+        --item "{
+            \"EntityId\": {\"S\": \"Entity#TeamSearch\"},
+            \"Id\": {\"S\": \"SPORT#${sport}#${folded}#${team_id}\"},
+            \"TeamId\": {\"S\": \"${team_id}\"},
+            \"SearchName\": {\"S\": \"${folded}\"},
+            \"Category\": {\"N\": \"${category}\"},
+            \"LookingForPlayers\": {\"BOOL\": false}
+        }" \
+        --return-consumed-capacity TOTAL > /dev/null
+}
+
 # Función auxiliar para insertar un equipo
 insert_team() {
     local sport=$1
     local name=$2
     local category=$3
     local entity_id="Entity#Team"
-    local id="SPORT#${sport}#NAME#${name}"
+    next_team_id
+    local id=$TEAM_ID
 
     awslocal dynamodb put-item \
         --table-name "$TABLE_NAME" \
@@ -32,10 +95,15 @@ insert_team() {
         --item "{
             \"EntityId\": {\"S\": \"${entity_id}\"},
             \"Id\": {\"S\": \"${id}\"},
+            \"Name\": {\"S\": \"${name}\"},
+            \"SearchName\": {\"S\": \"$(search_name "$name")\"},
+            \"LookingForPlayers\": {\"BOOL\": false},
             \"Category\": {\"N\": \"${category}\"},
             \"Sport\": {\"S\": \"${sport}\"}
         }" \
         --return-consumed-capacity TOTAL > /dev/null
+    claim_team_name "$sport" "$name" "$id"
+    index_team "$sport" "$name" "$category" "$id"
 
     echo "✓ Equipo insertado: ${name} (${sport}, Categoría ${category})"
 }
@@ -47,7 +115,8 @@ insert_owner_team() {
     local category=$3
     local owner=$4
     local entity_id="Entity#Team"
-    local id="SPORT#${sport}#NAME#${name}"
+    next_team_id
+    local id=$TEAM_ID
 
     awslocal dynamodb put-item \
         --table-name "$TABLE_NAME" \
@@ -56,11 +125,15 @@ insert_owner_team() {
             \"EntityId\": {\"S\": \"${entity_id}\"},
             \"Id\": {\"S\": \"${id}\"},
             \"Name\": {\"S\": \"${name}\"},
+            \"SearchName\": {\"S\": \"$(search_name "$name")\"},
+            \"LookingForPlayers\": {\"BOOL\": false},
             \"Category\": {\"N\": \"${category}\"},
             \"Sport\": {\"S\": \"${sport}\"},
             \"OwnerAccountId\": {\"S\": \"${owner}\"}
         }" \
         --return-consumed-capacity TOTAL > /dev/null
+    claim_team_name "$sport" "$name" "$id"
+    index_team "$sport" "$name" "$category" "$id"
 
     echo "✓ Equipo (con propietario) insertado: ${name} (${sport}, Categoría ${category}, owner: ${owner})"
 }
@@ -0,0 +1,59 @@
+package testcontainer
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/infrastructure/persistence/postgres"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// postgresTables are the tables ClearPostgresTables empties, every one the migrations create.
+const postgresTables = "accounts, players, users, teams, match_offers, match_requests, matches, match_accounts, " +
+	"match_reminders, match_attendances, outbox_messages, processed_messages, notifications, " +
+	"notification_preferences, chat_messages, chat_receipts, status_history"
+
+func PostgresContainer(t *testing.T, ctx context.Context) testcontainers.Container {
+	containerCtx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+
+	return createContainer(t, containerCtx, testcontainers.ContainerRequest{
+		Image:        "postgis/postgis:16-3.4",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "sportlink",
+			"POSTGRES_PASSWORD": "sportlink",
+			"POSTGRES_DB":       "sportlink",
+		},
+		// The server restarts once the init scripts ran, so it is only ready the second time.
+		WaitingFor: wait.ForLog("database system is ready to accept connections").
+			WithOccurrence(2).
+			WithStartupTimeout(2 * time.Minute),
+	})
+}
+
+// GetPostgresPool connects to the container and migrates its schema.
+func GetPostgresPool(t *testing.T, container testcontainers.Container, ctx context.Context) *pgxpool.Pool {
+	endpoint, err := container.PortEndpoint(ctx, "5432/tcp", "")
+	if err != nil {
+		t.Fatalf("Failed to get container endpoint: %s", err)
+	}
+	pool, err := pgxpool.New(ctx, fmt.Sprintf("postgres://sportlink:sportlink@%s/sportlink?sslmode=disable", endpoint))
+	if err != nil {
+		t.Fatalf("Failed to connect to PostgreSQL: %s", err)
+	}
+	if err := postgres.Migrate(ctx, pool); err != nil {
+		t.Fatalf("Failed to migrate PostgreSQL: %s", err)
+	}
+	return pool
+}
+
+func ClearPostgresTables(t *testing.T, pool *pgxpool.Pool) {
+	if _, err := pool.Exec(context.TODO(), "TRUNCATE "+postgresTables); err != nil {
+		t.Fatalf("Failed to truncate PostgreSQL tables: %s", err)
+	}
+}
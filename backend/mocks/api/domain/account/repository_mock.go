@@ -5,6 +5,7 @@ package mocks
 import (
 	"context"
 	account "sportlink/api/domain/account"
+	outbox "sportlink/api/domain/outbox"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -44,17 +45,17 @@ func (_m *Repository) Find(ctx context.Context, query account.DomainQuery) ([]ac
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: ctx, entity
-func (_m *Repository) Save(ctx context.Context, entity account.Entity) error {
-	ret := _m.Called(ctx, entity)
+// Save provides a mock function with given fields: ctx, entity, messages
+func (_m *Repository) Save(ctx context.Context, entity account.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, entity, messages)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, account.Entity) error); ok {
-		r0 = rf(ctx, entity)
+	if rf, ok := ret.Get(0).(func(context.Context, account.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, entity, messages)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -5,6 +5,9 @@ package mocks
 import (
 	"context"
 	match "sportlink/api/domain/match"
+	matchoffer "sportlink/api/domain/matchoffer"
+	matchrequest "sportlink/api/domain/matchrequest"
+	outbox "sportlink/api/domain/outbox"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,17 +17,35 @@ type Repository struct {
 	mock.Mock
 }
 
-// Save provides a mock function with given fields: ctx, entity
-func (_m *Repository) Save(ctx context.Context, entity match.Entity) error {
-	ret := _m.Called(ctx, entity)
+// Save provides a mock function with given fields: ctx, entity, messages
+func (_m *Repository) Save(ctx context.Context, entity match.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, entity, messages)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, match.Entity) error); ok {
-		r0 = rf(ctx, entity)
+	if rf, ok := ret.Get(0).(func(context.Context, match.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, entity, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveConfirmation provides a mock function with given fields: ctx, entity, offer, waitlisted, messages
+func (_m *Repository) SaveConfirmation(ctx context.Context, entity match.Entity, offer matchoffer.Entity, waitlisted []matchrequest.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, entity, offer, waitlisted, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveConfirmation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, match.Entity, matchoffer.Entity, []matchrequest.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, entity, offer, waitlisted, messages)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -92,6 +113,24 @@ func (_m *Repository) FindByID(ctx context.Context, accountID string, matchID st
 	return r0, r1
 }
 
+// RemoveParticipant provides a mock function with given fields: ctx, entity, removedAccountID, requests, messages
+func (_m *Repository) RemoveParticipant(ctx context.Context, entity match.Entity, removedAccountID string, requests []matchrequest.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, entity, removedAccountID, requests, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveParticipant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, match.Entity, string, []matchrequest.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, entity, removedAccountID, requests, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
@@ -5,6 +5,7 @@ package mocks
 import (
 	"context"
 	matchoffer "sportlink/api/domain/matchoffer"
+	outbox "sportlink/api/domain/outbox"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -72,17 +73,17 @@ func (_m *Repository) Find(ctx context.Context, query matchoffer.DomainQuery) (m
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: ctx, offerID
-func (_m *Repository) Delete(ctx context.Context, offerID string) error {
-	ret := _m.Called(ctx, offerID)
+// Save provides a mock function with given fields: ctx, entity, messages
+func (_m *Repository) Save(ctx context.Context, entity matchoffer.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, entity, messages)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for Save")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, offerID)
+	if rf, ok := ret.Get(0).(func(context.Context, matchoffer.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, entity, messages)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -90,22 +91,34 @@ func (_m *Repository) Delete(ctx context.Context, offerID string) error {
 	return r0
 }
 
-// Save provides a mock function with given fields: ctx, entity
-func (_m *Repository) Save(ctx context.Context, entity matchoffer.Entity) error {
-	ret := _m.Called(ctx, entity)
+// FindByIDs provides a mock function with given fields: ctx, ids
+func (_m *Repository) FindByIDs(ctx context.Context, ids []string) ([]matchoffer.Entity, error) {
+	ret := _m.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Save")
+		panic("no return value specified for FindByIDs")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, matchoffer.Entity) error); ok {
-		r0 = rf(ctx, entity)
+	var r0 []matchoffer.Entity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]matchoffer.Entity, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []matchoffer.Entity); ok {
+		r0 = rf(ctx, ids)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]matchoffer.Entity)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -4,7 +4,9 @@ package mocks
 
 import (
 	"context"
+	matchoffer "sportlink/api/domain/matchoffer"
 	matchrequest "sportlink/api/domain/matchrequest"
+	outbox "sportlink/api/domain/outbox"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,17 +16,35 @@ type Repository struct {
 	mock.Mock
 }
 
-// Save provides a mock function with given fields: ctx, entity
-func (_m *Repository) Save(ctx context.Context, entity matchrequest.Entity) error {
-	ret := _m.Called(ctx, entity)
+// Create provides a mock function with given fields: ctx, entity, messages
+func (_m *Repository) Create(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, entity, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, matchrequest.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, entity, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: ctx, entity, messages
+func (_m *Repository) Save(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, entity, messages)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, matchrequest.Entity) error); ok {
-		r0 = rf(ctx, entity)
+	if rf, ok := ret.Get(0).(func(context.Context, matchrequest.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, entity, messages)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -80,17 +100,35 @@ func (_m *Repository) SaveAll(ctx context.Context, entities []matchrequest.Entit
 	return r0
 }
 
-// UpdateStatus provides a mock function with given fields: ctx, id, ownerAccountID, newStatus
-func (_m *Repository) UpdateStatus(ctx context.Context, id string, ownerAccountID string, newStatus matchrequest.Status) error {
-	ret := _m.Called(ctx, id, ownerAccountID, newStatus)
+// UpdateStatus provides a mock function with given fields: ctx, entity, messages
+func (_m *Repository) UpdateStatus(ctx context.Context, entity matchrequest.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, entity, messages)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateStatus")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, matchrequest.Status) error); ok {
-		r0 = rf(ctx, id, ownerAccountID, newStatus)
+	if rf, ok := ret.Get(0).(func(context.Context, matchrequest.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, entity, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveWithOffer provides a mock function with given fields: ctx, request, offer, messages
+func (_m *Repository) SaveWithOffer(ctx context.Context, request matchrequest.Entity, offer matchoffer.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, request, offer, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveWithOffer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, matchrequest.Entity, matchoffer.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, request, offer, messages)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -98,6 +136,36 @@ func (_m *Repository) UpdateStatus(ctx context.Context, id string, ownerAccountI
 	return r0
 }
 
+// FindByIDs provides a mock function with given fields: ctx, ids
+func (_m *Repository) FindByIDs(ctx context.Context, ids []string) ([]matchrequest.Entity, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIDs")
+	}
+
+	var r0 []matchrequest.Entity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]matchrequest.Entity, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []matchrequest.Entity); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]matchrequest.Entity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
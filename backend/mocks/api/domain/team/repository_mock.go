@@ -4,6 +4,7 @@ package mocks
 
 import (
 	"context"
+	outbox "sportlink/api/domain/outbox"
 	team "sportlink/api/domain/team"
 
 	mock "github.com/stretchr/testify/mock"
@@ -44,17 +45,17 @@ func (_m *Repository) Find(ctx context.Context, query team.DomainQuery) ([]team.
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: ctx, entity
-func (_m *Repository) Save(ctx context.Context, entity team.Entity) error {
-	ret := _m.Called(ctx, entity)
+// Save provides a mock function with given fields: ctx, entity, messages
+func (_m *Repository) Save(ctx context.Context, entity team.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, entity, messages)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, team.Entity) error); ok {
-		r0 = rf(ctx, entity)
+	if rf, ok := ret.Get(0).(func(context.Context, team.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, entity, messages)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -62,17 +63,45 @@ func (_m *Repository) Save(ctx context.Context, entity team.Entity) error {
 	return r0
 }
 
-// Update provides a mock function with given fields: ctx, oldID, entity
-func (_m *Repository) Update(ctx context.Context, oldID string, entity team.Entity) error {
-	ret := _m.Called(ctx, oldID, entity)
+// Search provides a mock function with given fields: ctx, query
+func (_m *Repository) Search(ctx context.Context, query team.SearchQuery) (team.Page, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 team.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, team.SearchQuery) (team.Page, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, team.SearchQuery) team.Page); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(team.Page)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, team.SearchQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, previousName, entity, messages
+func (_m *Repository) Update(ctx context.Context, previousName string, entity team.Entity, messages []outbox.Message) error {
+	ret := _m.Called(ctx, previousName, entity, messages)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, team.Entity) error); ok {
-		r0 = rf(ctx, oldID, entity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, team.Entity, []outbox.Message) error); ok {
+		r0 = rf(ctx, previousName, entity, messages)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -239,6 +239,34 @@ func (_m *QueryParser) GeoFilter(lat string, lng string, radiusKm string) (*matc
 	return r0, r1
 }
 
+// MinOpenSpots provides a mock function with given fields: minOpenSpotsQuery
+func (_m *QueryParser) MinOpenSpots(minOpenSpotsQuery string) (int, error) {
+	ret := _m.Called(minOpenSpotsQuery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MinOpenSpots")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(minOpenSpotsQuery)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(minOpenSpotsQuery)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(minOpenSpotsQuery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewQueryParser creates a new instance of QueryParser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewQueryParser(t interface {
@@ -48,7 +48,7 @@ func Test_Save(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			// when
-			err := repository.Save(ctx, testCase.entity)
+			err := repository.Save(ctx, testCase.entity, nil)
 
 			// then
 			testCase.assertions(t, err)
@@ -75,7 +75,7 @@ func Test_Find(t *testing.T) {
 				Emails: []string{"test@example.com"},
 			},
 			on: func(t *testing.T, repository daccount.Repository) {
-				if err := repository.Save(ctx, daccount.NewAccount("test@example.com", "testuser")); err != nil {
+				if err := repository.Save(ctx, daccount.NewAccount("test@example.com", "testuser"), nil); err != nil {
 					t.Fatal(err)
 				}
 			},
@@ -97,7 +97,7 @@ func Test_Find(t *testing.T) {
 					daccount.NewAccount("user2@example.com", "user2"),
 					daccount.NewAccount("user3@example.com", "user3"),
 				} {
-					if err := repository.Save(ctx, acc); err != nil {
+					if err := repository.Save(ctx, acc, nil); err != nil {
 						t.Fatal(err)
 					}
 				}
@@ -117,7 +117,7 @@ func Test_Find(t *testing.T) {
 				Ids: []string{"EMAIL#test@example.com"},
 			},
 			on: func(t *testing.T, repository daccount.Repository) {
-				if err := repository.Save(ctx, daccount.NewAccount("test@example.com", "testuser")); err != nil {
+				if err := repository.Save(ctx, daccount.NewAccount("test@example.com", "testuser"), nil); err != nil {
 					t.Fatal(err)
 				}
 			},
@@ -138,7 +138,7 @@ func Test_Find(t *testing.T) {
 					daccount.NewAccount("user1@example.com", "user1"),
 					daccount.NewAccount("user2@example.com", "user2"),
 				} {
-					if err := repository.Save(ctx, acc); err != nil {
+					if err := repository.Save(ctx, acc, nil); err != nil {
 						t.Fatal(err)
 					}
 				}
@@ -162,7 +162,7 @@ func Test_Find(t *testing.T) {
 					daccount.NewAccount("test@example.com", "testuser"),
 					daccount.NewAccount("other@example.com", "otheruser"),
 				} {
-					if err := repository.Save(ctx, acc); err != nil {
+					if err := repository.Save(ctx, acc, nil); err != nil {
 						t.Fatal(err)
 					}
 				}
@@ -181,7 +181,7 @@ func Test_Find(t *testing.T) {
 				Nicknames: []string{"testuser", "anotheruser"},
 			},
 			on: func(t *testing.T, repository daccount.Repository) {
-				if err := repository.Save(ctx, daccount.NewAccount("test@example.com", "testuser")); err != nil {
+				if err := repository.Save(ctx, daccount.NewAccount("test@example.com", "testuser"), nil); err != nil {
 					t.Fatal(err)
 				}
 			},
@@ -207,7 +207,7 @@ func Test_Find(t *testing.T) {
 			name:  "find with no criteria returns empty",
 			query: daccount.DomainQuery{},
 			on: func(t *testing.T, repository daccount.Repository) {
-				if err := repository.Save(ctx, daccount.NewAccount("test@example.com", "testuser")); err != nil {
+				if err := repository.Save(ctx, daccount.NewAccount("test@example.com", "testuser"), nil); err != nil {
 					t.Fatal(err)
 				}
 			},
@@ -222,7 +222,7 @@ func Test_Find(t *testing.T) {
 				Nicknames: []string{"testuser"},
 			},
 			on: func(t *testing.T, repository daccount.Repository) {
-				if err := repository.Save(ctx, daccount.NewAccount("test@example.com", "testuser")); err != nil {
+				if err := repository.Save(ctx, daccount.NewAccount("test@example.com", "testuser"), nil); err != nil {
 					t.Fatal(err)
 				}
 			},
@@ -250,7 +250,7 @@ func Test_FindByAccountID(t *testing.T) {
 	repository := account.NewRepository(dynamoDbClient, "SportLinkCore")
 
 	acc := daccount.NewAccount("ulid@example.com", "uliduser")
-	if err := repository.Save(ctx, acc); err != nil {
+	if err := repository.Save(ctx, acc, nil); err != nil {
 		t.Fatal(err)
 	}
 
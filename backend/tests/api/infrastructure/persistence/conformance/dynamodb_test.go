@@ -0,0 +1,43 @@
+package conformance_test
+
+import (
+	"context"
+	"sportlink/api/infrastructure/persistence/account"
+	"sportlink/api/infrastructure/persistence/chat"
+	"sportlink/api/infrastructure/persistence/match"
+	"sportlink/api/infrastructure/persistence/matchoffer"
+	"sportlink/api/infrastructure/persistence/matchrequest"
+	"sportlink/api/infrastructure/persistence/notification"
+	"sportlink/api/infrastructure/persistence/outbox"
+	"sportlink/api/infrastructure/persistence/player"
+	"sportlink/api/infrastructure/persistence/team"
+	"sportlink/api/infrastructure/persistence/user"
+	"sportlink/dev/testcontainer"
+	"sportlink/tests/conformance"
+	"testing"
+)
+
+func TestDynamoDBConformance(t *testing.T) {
+	ctx := context.Background()
+	container := testcontainer.SportLinkContainer(t, ctx)
+	defer container.Terminate(ctx)
+	dynamoDbClient := testcontainer.GetDynamoDbClient(t, container, ctx)
+	tableName := "SportLinkCore"
+
+	conformance.Run(t, func(t *testing.T) conformance.Repositories {
+		testcontainer.ClearDynamoDbTable(t, dynamoDbClient, tableName)
+		return conformance.Repositories{
+			Account:      account.NewRepository(dynamoDbClient, tableName),
+			Player:       player.NewDynamoDBRepository(dynamoDbClient, tableName),
+			User:         user.NewRepository(dynamoDbClient, tableName),
+			Team:         team.NewRepository(dynamoDbClient, tableName),
+			MatchOffer:   matchoffer.NewRepository(dynamoDbClient, tableName),
+			MatchRequest: matchrequest.NewRepository(dynamoDbClient, tableName),
+			Match:        match.NewRepository(dynamoDbClient, tableName),
+			Reminder:     match.NewReminderRepository(dynamoDbClient, tableName),
+			Outbox:       outbox.NewRepository(dynamoDbClient, tableName),
+			Notification: notification.NewRepository(dynamoDbClient, tableName),
+			Chat:         chat.NewRepository(dynamoDbClient, tableName),
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package conformance_test
+
+import (
+	"context"
+	"sportlink/api/infrastructure/persistence/postgres"
+	"sportlink/dev/testcontainer"
+	"sportlink/tests/conformance"
+	"testing"
+)
+
+func TestPostgresConformance(t *testing.T) {
+	ctx := context.Background()
+	container := testcontainer.PostgresContainer(t, ctx)
+	defer container.Terminate(ctx)
+	pool := testcontainer.GetPostgresPool(t, container, ctx)
+	defer pool.Close()
+
+	conformance.Run(t, func(t *testing.T) conformance.Repositories {
+		testcontainer.ClearPostgresTables(t, pool)
+		return conformance.Repositories{
+			Account:      postgres.NewAccountRepository(pool),
+			Player:       postgres.NewPlayerRepository(pool),
+			User:         postgres.NewUserRepository(pool),
+			Team:         postgres.NewTeamRepository(pool),
+			MatchOffer:   postgres.NewMatchOfferRepository(pool),
+			MatchRequest: postgres.NewMatchRequestRepository(pool),
+			Match:        postgres.NewMatchRepository(pool),
+			Reminder:     postgres.NewReminderRepository(pool),
+			Outbox:       postgres.NewOutboxRepository(pool),
+			Notification: postgres.NewNotificationRepository(pool),
+			Chat:         postgres.NewChatRepository(pool),
+		}
+	})
+}
@@ -44,7 +44,7 @@ func Test_Save(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			// when
-			err := repository.Save(ctx, testCase.entity)
+			err := repository.Save(ctx, testCase.entity, nil)
 
 			// then
 			testCase.assertions(t, err)
@@ -78,7 +78,7 @@ func Test_Find(t *testing.T) {
 					common.Paddle,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -107,7 +107,7 @@ func Test_Find(t *testing.T) {
 					common.Football,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 
 				repository.Save(ctx, dteam.NewTeam(
 					"Second Test B",
@@ -116,7 +116,7 @@ func Test_Find(t *testing.T) {
 					common.Paddle,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 
 			},
 			assertions: func(t *testing.T, entities []dteam.Entity, err error) {
@@ -163,7 +163,7 @@ func Test_Find(t *testing.T) {
 					common.Football,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 				repository.Save(ctx, dteam.NewTeam(
 					"Team Cat Sport B",
 					common.L1,
@@ -171,7 +171,7 @@ func Test_Find(t *testing.T) {
 					common.Football,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 				// Guardar equipo de Football L2 (no debe aparecer)
 				repository.Save(ctx, dteam.NewTeam(
 					"Team Cat Sport C",
@@ -180,7 +180,7 @@ func Test_Find(t *testing.T) {
 					common.Football,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 				// Guardar equipo de Paddle L1 (no debe aparecer)
 				repository.Save(ctx, dteam.NewTeam(
 					"Team Cat Sport D",
@@ -189,7 +189,7 @@ func Test_Find(t *testing.T) {
 					common.Paddle,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 			},
 			assertions: func(t *testing.T, entities []dteam.Entity, err error) {
 				assert.Nil(t, err)
@@ -222,7 +222,7 @@ func Test_Find(t *testing.T) {
 					common.Paddle,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 				repository.Save(ctx, dteam.NewTeam(
 					"Multi Cat Team B",
 					common.L7,
@@ -230,7 +230,7 @@ func Test_Find(t *testing.T) {
 					common.Paddle,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 				repository.Save(ctx, dteam.NewTeam(
 					"Multi Cat Team C",
 					common.L3,
@@ -238,7 +238,7 @@ func Test_Find(t *testing.T) {
 					common.Paddle,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 			},
 			assertions: func(t *testing.T, entities []dteam.Entity, err error) {
 				assert.Nil(t, err)
@@ -277,7 +277,7 @@ func Test_Find(t *testing.T) {
 					common.Tennis,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 				repository.Save(ctx, dteam.NewTeam(
 					"Sport Only Team B",
 					common.L4,
@@ -285,7 +285,7 @@ func Test_Find(t *testing.T) {
 					common.Tennis,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 				repository.Save(ctx, dteam.NewTeam(
 					"Sport Only Team C",
 					common.L1,
@@ -293,7 +293,7 @@ func Test_Find(t *testing.T) {
 					common.Football,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 			},
 			assertions: func(t *testing.T, entities []dteam.Entity, err error) {
 				assert.Nil(t, err)
@@ -334,7 +334,7 @@ func Test_Find(t *testing.T) {
 					common.Football,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 				repository.Save(ctx, dteam.NewTeam(
 					"Pattern No Match Category",
 					common.L2,
@@ -342,7 +342,7 @@ func Test_Find(t *testing.T) {
 					common.Football,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 				repository.Save(ctx, dteam.NewTeam(
 					"Pattern No Match Sport",
 					common.L1,
@@ -350,7 +350,7 @@ func Test_Find(t *testing.T) {
 					common.Paddle,
 					[]player.Entity{},
 					"",
-				))
+				), nil)
 			},
 			assertions: func(t *testing.T, entities []dteam.Entity, err error) {
 				assert.Nil(t, err)
@@ -143,7 +143,7 @@ func Test_ConfirmMatchOfferUC(t *testing.T) {
 			},
 		},
 		{
-			name: "given an already confirmed offer when confirming again then it fails",
+			name: "given an already confirmed offer when confirming again then it returns the same match",
 			setup: func(t *testing.T) usecase.ConfirmMatchOfferInput {
 				ownerAcc := helper.NewAccountBuilder(t, acRepo).
 					WithEmail("confirm-double-owner@gmail.com").
@@ -167,8 +167,9 @@ func Test_ConfirmMatchOfferUC(t *testing.T) {
 				return input
 			},
 			then: func(t *testing.T, offerId string, entity *dmatch.Entity, err error) {
-				assert.NotNil(t, err)
-				assert.Nil(t, entity)
+				assert.NoError(t, err)
+				assert.NotNil(t, entity)
+				assert.Equal(t, dmatch.IDForOffer(offerId), entity.ID)
 			},
 		},
 		{
@@ -208,7 +209,8 @@ func Test_ConfirmMatchOfferUC(t *testing.T) {
 					Build(ctx)
 
 				// accept visitor1's request directly via repository
-				if err := mrRepo.Save(ctx, request1.Accept()); err != nil {
+				accepted, _ := request1.Accept(common.ActorOwner)
+				if err := mrRepo.Save(ctx, accepted, nil); err != nil {
 					t.Fatalf("failed to accept request1: %v", err)
 				}
 
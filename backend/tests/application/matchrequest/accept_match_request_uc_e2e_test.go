@@ -3,16 +3,17 @@ package matchrequest_test
 import (
 	"context"
 	"github.com/stretchr/testify/assert"
-	matchofferevent "sportlink/api/application/matchoffer/events"
+	offerservice "sportlink/api/application/matchoffer/service"
 	usecase "sportlink/api/application/matchrequest/usecases"
 	dmatchrequest "sportlink/api/domain/matchrequest"
-	ievents "sportlink/api/infrastructure/events"
 	"sportlink/api/infrastructure/persistence/account"
 	"sportlink/api/infrastructure/persistence/matchoffer"
 	"sportlink/api/infrastructure/persistence/matchrequest"
+	"sportlink/api/infrastructure/persistence/team"
 	"sportlink/dev/testcontainer"
 	"sportlink/tests/helper"
 	"testing"
+	"time"
 )
 
 func Test_AcceptMatchRequest(t *testing.T) {
@@ -25,11 +26,14 @@ func Test_AcceptMatchRequest(t *testing.T) {
 	moRepo := matchoffer.NewRepository(dynamoDbClient, "SportLinkCore")
 	mrRepo := matchrequest.NewRepository(dynamoDbClient, "SportLinkCore")
 	acRepo := account.NewRepository(dynamoDbClient, "SportLinkCore")
-	capacityPublisher := ievents.NewChannelPublisher[matchofferevent.MatchOfferCapacityReachedEvent](100)
 
 	// use cases
-	createMatchRequestUC := usecase.NewCreateMatchRequestUC(mrRepo, moRepo)
-	acceptMatchRequestUC := usecase.NewAcceptMatchRequestUC(mrRepo, moRepo, capacityPublisher)
+	visibilityPolicy := offerservice.NewVisibilityPolicy(
+		team.NewRepository(dynamoDbClient, "SportLinkCore"),
+		offerservice.NewShareLinkSigner("e2e-share-secret", time.Hour),
+	)
+	createMatchRequestUC := usecase.NewCreateMatchRequestUC(mrRepo, moRepo, visibilityPolicy)
+	acceptMatchRequestUC := usecase.NewAcceptMatchRequestUC(mrRepo, moRepo)
 	findMatchRequestUC := usecase.NewFindMatchRequestsUC(mrRepo)
 
 	tests := []struct {
@@ -3,14 +3,20 @@ package matchrequest_test
 import (
 	"context"
 	"github.com/stretchr/testify/assert"
+	matchservice "sportlink/api/application/match/service"
+	offerservice "sportlink/api/application/matchoffer/service"
 	usecase "sportlink/api/application/matchrequest/usecases"
+	"sportlink/api/domain/common"
 	dmatchrequest "sportlink/api/domain/matchrequest"
 	"sportlink/api/infrastructure/persistence/account"
+	"sportlink/api/infrastructure/persistence/match"
 	"sportlink/api/infrastructure/persistence/matchoffer"
 	"sportlink/api/infrastructure/persistence/matchrequest"
+	"sportlink/api/infrastructure/persistence/team"
 	"sportlink/dev/testcontainer"
 	"sportlink/tests/helper"
 	"testing"
+	"time"
 )
 
 func Test_CancelMatchRequest(t *testing.T) {
@@ -23,10 +29,15 @@ func Test_CancelMatchRequest(t *testing.T) {
 	moRepo := matchoffer.NewRepository(dynamoDbClient, "SportLinkCore")
 	mrRepo := matchrequest.NewRepository(dynamoDbClient, "SportLinkCore")
 	acRepo := account.NewRepository(dynamoDbClient, "SportLinkCore")
+	mRepo := match.NewRepository(dynamoDbClient, "SportLinkCore")
 
 	// use cases
-	createMatchRequestUC := usecase.NewCreateMatchRequestUC(mrRepo, moRepo)
-	cancelMatchRequestUC := usecase.NewCancelMatchRequestUC(mrRepo, moRepo)
+	visibilityPolicy := offerservice.NewVisibilityPolicy(
+		team.NewRepository(dynamoDbClient, "SportLinkCore"),
+		offerservice.NewShareLinkSigner("e2e-share-secret", time.Hour),
+	)
+	createMatchRequestUC := usecase.NewCreateMatchRequestUC(mrRepo, moRepo, visibilityPolicy)
+	cancelMatchRequestUC := usecase.NewCancelMatchRequestUC(mrRepo, moRepo, mRepo, matchservice.NewFakePaymentProvider())
 	findMatchRequestUC := usecase.NewFindMatchRequestsUC(mrRepo)
 
 	tests := []struct {
@@ -81,14 +92,15 @@ func Test_CancelMatchRequest(t *testing.T) {
 					MatchOfferID:       offer.ID,
 					RequesterAccountID: visitor.AccountID,
 				})
-				if err := mrRepo.Save(ctx, entity.Reject()); err != nil {
+				rejected, _ := entity.Reject("")
+				if err := mrRepo.Save(ctx, rejected, nil); err != nil {
 					t.Fatalf("failed to reject request: %v", err)
 				}
 				return entity
 			},
 			then: func(t *testing.T, cancelErr error, result []dmatchrequest.Entity) {
 				assert.NotNil(t, cancelErr)
-				assert.Contains(t, cancelErr.Error(), "match request is already rejected")
+				assert.Contains(t, cancelErr.Error(), "match request cannot go from REJECTED to CANCEL")
 			},
 		},
 		{
@@ -110,7 +122,8 @@ func Test_CancelMatchRequest(t *testing.T) {
 					MatchOfferID:       offer.ID,
 					RequesterAccountID: visitor.AccountID,
 				})
-				if err := moRepo.Save(ctx, offer.Confirm()); err != nil {
+				confirmed, _ := offer.Confirm(common.ActorOwner)
+				if err := moRepo.Save(ctx, confirmed, nil); err != nil {
 					t.Fatalf("failed to confirm offer: %v", err)
 				}
 				return entity
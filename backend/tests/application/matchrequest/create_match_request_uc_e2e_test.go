@@ -6,12 +6,15 @@ import (
 	dmatchrequest "sportlink/api/domain/matchrequest"
 	"sportlink/tests/helper"
 
+	offerservice "sportlink/api/application/matchoffer/service"
 	usecase "sportlink/api/application/matchrequest/usecases"
 	"sportlink/api/infrastructure/persistence/account"
 	"sportlink/api/infrastructure/persistence/matchoffer"
 	"sportlink/api/infrastructure/persistence/matchrequest"
+	"sportlink/api/infrastructure/persistence/team"
 	"sportlink/dev/testcontainer"
 	"testing"
+	"time"
 )
 
 func Test_CreateMatchRequest(t *testing.T) {
@@ -25,7 +28,11 @@ func Test_CreateMatchRequest(t *testing.T) {
 	mrRepo := matchrequest.NewRepository(dynamoDbClient, "SportLinkCore")
 	acRepo := account.NewRepository(dynamoDbClient, "SportLinkCore")
 
-	uc := usecase.NewCreateMatchRequestUC(mrRepo, moRepo)
+	visibilityPolicy := offerservice.NewVisibilityPolicy(
+		team.NewRepository(dynamoDbClient, "SportLinkCore"),
+		offerservice.NewShareLinkSigner("e2e-share-secret", time.Hour),
+	)
+	uc := usecase.NewCreateMatchRequestUC(mrRepo, moRepo, visibilityPolicy)
 
 	tests := []struct {
 		name  string
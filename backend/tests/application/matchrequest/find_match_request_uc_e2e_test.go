@@ -3,22 +3,26 @@ package matchrequest_test
 import (
 	"context"
 	"github.com/stretchr/testify/assert"
+	offerservice "sportlink/api/application/matchoffer/service"
 	usecase "sportlink/api/application/matchrequest/usecases"
+	"sportlink/api/domain/common"
 	dmatchrequest "sportlink/api/domain/matchrequest"
 	"sportlink/api/infrastructure/persistence/account"
 	"sportlink/api/infrastructure/persistence/matchoffer"
 	"sportlink/api/infrastructure/persistence/matchrequest"
+	"sportlink/api/infrastructure/persistence/team"
 	"sportlink/dev/testcontainer"
 	"sportlink/tests/helper"
 	"testing"
+	"time"
 )
 
 // cancelMatchRequest is a test helper that cancels a match request by directly
 // updating the entity status in the repository, bypassing business-rule checks.
 func cancelMatchRequest(t *testing.T, ctx context.Context, mrRepo dmatchrequest.Repository, entity *dmatchrequest.Entity) {
 	t.Helper()
-	cancelled := entity.Cancel()
-	if err := mrRepo.Save(ctx, cancelled); err != nil {
+	cancelled, _ := entity.Cancel(common.ActorRequester)
+	if err := mrRepo.Save(ctx, cancelled, nil); err != nil {
 		t.Fatalf("failed to cancel match request in setup: %v", err)
 	}
 }
@@ -35,7 +39,11 @@ func Test_FindMatchRequest(t *testing.T) {
 	acRepo := account.NewRepository(dynamoDbClient, "SportLinkCore")
 
 	// use cases
-	createMatchRequestUC := usecase.NewCreateMatchRequestUC(mrRepo, moRepo)
+	visibilityPolicy := offerservice.NewVisibilityPolicy(
+		team.NewRepository(dynamoDbClient, "SportLinkCore"),
+		offerservice.NewShareLinkSigner("e2e-share-secret", time.Hour),
+	)
+	createMatchRequestUC := usecase.NewCreateMatchRequestUC(mrRepo, moRepo, visibilityPolicy)
 	findMatchRequestUC := usecase.NewFindMatchRequestsUC(mrRepo)
 
 	tests := []struct {
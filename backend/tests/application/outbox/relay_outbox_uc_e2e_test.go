@@ -0,0 +1,57 @@
+package outbox_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sportlink/api/application/messaging"
+	"sportlink/api/application/outbox/usecases"
+	doutbox "sportlink/api/domain/outbox"
+	"sportlink/api/infrastructure/persistence/outbox"
+	"sportlink/dev/testcontainer"
+	"testing"
+	"time"
+)
+
+func Test_RelayOutbox(t *testing.T) {
+	ctx := context.Background()
+	container := testcontainer.SportLinkContainer(t, ctx)
+	defer container.Terminate(ctx)
+	dynamoDbClient := testcontainer.GetDynamoDbClient(t, container, ctx)
+	sqsClient := testcontainer.GetSqsClient(t, container, ctx)
+
+	outboxRepo := outbox.NewRepository(dynamoDbClient, "SportLinkCore")
+	processedRepo := outbox.NewProcessedRepository(dynamoDbClient, "SportLinkCore")
+	broker := messaging.NewBroker(sqsClient, "http://localhost:4566/000000000000/sportlink-news")
+	relayOutboxUC := usecases.NewRelayOutboxUC(outboxRepo, broker, doutbox.DefaultRetryPolicy())
+
+	now := time.Now().UTC()
+	message, err := doutbox.NewMessage("matchoffer.capacity_reached", map[string]string{"match_offer_id": "offer-1"}, now)
+	assert.NoError(t, err)
+	assert.NoError(t, outboxRepo.Save(ctx, message))
+
+	result, err := relayOutboxUC.Invoke(ctx, usecases.RelayOutboxInput{Now: now, BatchSize: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Published)
+
+	due, err := outboxRepo.FindDue(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, due)
+
+	received, err := broker.Receive(ctx, 10, time.Second)
+	assert.NoError(t, err)
+	assert.Len(t, received, 1)
+	delivered, err := doutbox.FromEnvelope(received[0].Body)
+	assert.NoError(t, err)
+	assert.Equal(t, message.ID, delivered.ID)
+	assert.Equal(t, message.Type, delivered.Type)
+
+	processed, err := processedRepo.IsProcessed(ctx, "e2e", delivered.ID)
+	assert.NoError(t, err)
+	assert.False(t, processed)
+	assert.NoError(t, processedRepo.MarkProcessed(ctx, "e2e", delivered.ID))
+	processed, err = processedRepo.IsProcessed(ctx, "e2e", delivered.ID)
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	assert.NoError(t, broker.Delete(ctx, received[0].ReceiptHandle))
+}
@@ -0,0 +1,99 @@
+package conformance
+
+import (
+	"context"
+	"sportlink/api/domain/account"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// AccountRepository specifies account.Repository: accounts are looked up by Ids, Emails or
+// AccountIDs, and every other criterion of the query narrows the result down.
+func AccountRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	jorge := account.NewAccount("jorge@sportlink.app", "jorge")
+	maria := account.NewAccount("maria@sportlink.app", "maria")
+	pedro := account.NewAccount("pedro@sportlink.app", "pedro")
+	emails := func(accounts []account.Entity) []string {
+		return ids(accounts, func(a account.Entity) string { return a.Email })
+	}
+
+	testCases := []struct {
+		name       string
+		query      account.DomainQuery
+		assertions func(t *testing.T, accounts []account.Entity, err error)
+	}{
+		{
+			name:  "given saved accounts when finding by ids then returns those accounts",
+			query: account.DomainQuery{Ids: []string{jorge.ID, maria.ID}},
+			assertions: func(t *testing.T, accounts []account.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{jorge.Email, maria.Email}, emails(accounts))
+			},
+		},
+		{
+			name:  "given saved accounts when finding by emails then returns those accounts",
+			query: account.DomainQuery{Emails: []string{maria.Email, pedro.Email}},
+			assertions: func(t *testing.T, accounts []account.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{maria.Email, pedro.Email}, emails(accounts))
+			},
+		},
+		{
+			name:  "given saved accounts when finding by account ids and nicknames then returns the accounts matching both",
+			query: account.DomainQuery{AccountIDs: []string{jorge.AccountID, maria.AccountID}, Nicknames: []string{"maria", "pedro"}},
+			assertions: func(t *testing.T, accounts []account.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{maria.Email}, emails(accounts))
+			},
+		},
+		{
+			name:  "given saved accounts when finding by emails and nicknames then returns the accounts matching both",
+			query: account.DomainQuery{Emails: []string{jorge.Email, maria.Email}, Nicknames: []string{"jorge"}},
+			assertions: func(t *testing.T, accounts []account.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{jorge.Email}, emails(accounts))
+			},
+		},
+		{
+			name:  "given saved accounts when finding without ids, emails or account ids then returns no accounts",
+			query: account.DomainQuery{Nicknames: []string{"jorge"}},
+			assertions: func(t *testing.T, accounts []account.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, accounts)
+			},
+		},
+		{
+			name:  "given saved accounts when finding by emails and ids then returns an error",
+			query: account.DomainQuery{Emails: []string{jorge.Email}, Ids: []string{jorge.ID}},
+			assertions: func(t *testing.T, accounts []account.Entity, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).Account
+			for _, entity := range []account.Entity{jorge, maria, pedro} {
+				noError(t, repository.Save(ctx, entity, nil))
+			}
+
+			// when
+			accounts, err := repository.Find(ctx, testCase.query)
+
+			// then
+			testCase.assertions(t, accounts, err)
+		})
+	}
+
+	t.Run("given an account without email when saving then returns an error", func(t *testing.T) {
+		// when
+		err := backend(t).Account.Save(ctx, account.Entity{Nickname: "nobody"}, nil)
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,99 @@
+package conformance
+
+import (
+	"context"
+	"sportlink/api/domain/chat"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ChatRepository specifies chat.Repository: a thread is read newest message first in
+// pages, and each member keeps a single receipt per thread.
+func ChatRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	thread := chat.NewThread(chat.KindMatch, "match-1")
+	otherThread := chat.NewThread(chat.KindRequest, "request-1")
+	messages := []chat.Message{
+		chat.NewMessage(thread, "account-1", "hi", now.Add(-3*time.Minute)),
+		chat.NewMessage(thread, "account-2", "hello", now.Add(-2*time.Minute)),
+		chat.NewMessage(thread, "account-1", "see you at 6", now.Add(-time.Minute)),
+		chat.NewMessage(otherThread, "account-3", "can I join?", now),
+	}
+	bodies := func(messages []chat.Message) []string {
+		return ids(messages, func(m chat.Message) string { return m.Body })
+	}
+
+	testCases := []struct {
+		name       string
+		query      func(page chat.Page) chat.DomainQuery
+		assertions func(t *testing.T, page chat.Page, err error)
+	}{
+		{
+			name:  "given a thread when finding the first page then returns the newest messages and where the next page starts",
+			query: func(chat.Page) chat.DomainQuery { return chat.DomainQuery{Thread: thread, Limit: 2} },
+			assertions: func(t *testing.T, page chat.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"see you at 6", "hello"}, bodies(page.Entities))
+				assert.Equal(t, messages[1].ID, page.NextCursor)
+			},
+		},
+		{
+			name: "given a thread when finding the page after the first then returns the oldest messages without a cursor",
+			query: func(first chat.Page) chat.DomainQuery {
+				return chat.DomainQuery{Thread: thread, Limit: 2, Cursor: first.NextCursor}
+			},
+			assertions: func(t *testing.T, page chat.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"hi"}, bodies(page.Entities))
+				assert.Empty(t, page.NextCursor)
+			},
+		},
+		{
+			name:  "given several threads when finding one then returns only its messages",
+			query: func(chat.Page) chat.DomainQuery { return chat.DomainQuery{Thread: otherThread, Limit: 10} },
+			assertions: func(t *testing.T, page chat.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"can I join?"}, bodies(page.Entities))
+				assert.Empty(t, page.NextCursor)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).Chat
+			for _, message := range messages {
+				noError(t, repository.Save(ctx, message))
+			}
+			first, err := repository.Find(ctx, chat.DomainQuery{Thread: thread, Limit: 2})
+			noError(t, err)
+
+			// when
+			page, err := repository.Find(ctx, testCase.query(first))
+
+			// then
+			testCase.assertions(t, page, err)
+		})
+	}
+
+	t.Run("given a member who read a thread twice when finding the receipts then keeps the latest one", func(t *testing.T) {
+		// given
+		repository := backend(t).Chat
+		noError(t, repository.SaveReceipt(ctx, chat.Receipt{Thread: thread, AccountID: "account-1", LastReadMessageID: messages[0].ID, ReadAt: now.Add(-2 * time.Minute)}))
+		noError(t, repository.SaveReceipt(ctx, chat.Receipt{Thread: thread, AccountID: "account-1", LastReadMessageID: messages[2].ID, ReadAt: now}))
+		noError(t, repository.SaveReceipt(ctx, chat.Receipt{Thread: thread, AccountID: "account-2", LastReadMessageID: messages[1].ID, ReadAt: now}))
+		noError(t, repository.SaveReceipt(ctx, chat.Receipt{Thread: otherThread, AccountID: "account-3", LastReadMessageID: messages[3].ID, ReadAt: now}))
+
+		// when
+		receipts, err := repository.FindReceipts(ctx, thread)
+
+		// then
+		assert.NoError(t, err)
+		lastRead := ids(receipts, func(r chat.Receipt) string { return r.AccountID + "/" + r.LastReadMessageID })
+		assert.ElementsMatch(t, []string{"account-1/" + messages[2].ID, "account-2/" + messages[1].ID}, lastRead)
+	})
+}
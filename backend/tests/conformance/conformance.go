@@ -0,0 +1,91 @@
+// Package conformance specifies once how the domain repositories behave, so that every
+// storage backend (DynamoDB, in-memory, ...) is checked against the same expectations.
+// A backend passes the suite by calling Run from one of its tests.
+package conformance
+
+import (
+	"context"
+	"sportlink/api/domain/account"
+	"sportlink/api/domain/chat"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	"sportlink/api/domain/player"
+	"sportlink/api/domain/team"
+	"sportlink/api/domain/user"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Repositories are the repositories of one backend, all sharing the same storage.
+type Repositories struct {
+	Account      account.Repository
+	Player       player.Repository
+	User         user.Repository
+	Team         team.Repository
+	MatchOffer   matchoffer.Repository
+	MatchRequest matchrequest.Repository
+	Match        match.Repository
+	Reminder     match.ReminderRepository
+	Outbox       outbox.Repository
+	Notification notification.Repository
+	Chat         chat.Repository
+}
+
+// Backend returns the repositories of a backend whose storage holds no entities. It is
+// called once per test case, so cases never see each other's entities.
+type Backend func(t *testing.T) Repositories
+
+// Run checks every repository of the backend.
+func Run(t *testing.T, backend Backend) {
+	t.Run("account", func(t *testing.T) { AccountRepository(t, backend) })
+	t.Run("player", func(t *testing.T) { PlayerRepository(t, backend) })
+	t.Run("user", func(t *testing.T) { UserRepository(t, backend) })
+	t.Run("team", func(t *testing.T) { TeamRepository(t, backend) })
+	t.Run("team search", func(t *testing.T) { TeamSearch(t, backend) })
+	t.Run("match offer", func(t *testing.T) { MatchOfferRepository(t, backend) })
+	t.Run("match request", func(t *testing.T) { MatchRequestRepository(t, backend) })
+	t.Run("match", func(t *testing.T) { MatchRepository(t, backend) })
+	t.Run("reminder", func(t *testing.T) { ReminderRepository(t, backend) })
+	t.Run("outbox", func(t *testing.T) { OutboxRepository(t, backend) })
+	t.Run("notification", func(t *testing.T) { NotificationRepository(t, backend) })
+	t.Run("chat", func(t *testing.T) { ChatRepository(t, backend) })
+}
+
+// ids maps entities to their IDs, so results can be compared regardless of how each
+// backend stores times or optional fields.
+func ids[E any](entities []E, id func(E) string) []string {
+	result := make([]string, len(entities))
+	for i, entity := range entities {
+		result[i] = id(entity)
+	}
+	return result
+}
+
+// newMessage returns a pending outbox message of the given type, due right away.
+func newMessage(t *testing.T, messageType string) outbox.Message {
+	t.Helper()
+	message, err := outbox.NewMessage(messageType, map[string]string{"type": messageType}, time.Now().Add(-time.Minute))
+	noError(t, err)
+	return message
+}
+
+// dueMessageIDs returns the IDs of the outbox messages the repositories wrote so far.
+func dueMessageIDs(t *testing.T, repositories Repositories) []string {
+	t.Helper()
+	due, err := repositories.Outbox.FindDue(context.Background(), time.Now(), 100)
+	noError(t, err)
+	return ids(due, func(m outbox.Message) string { return m.ID })
+}
+
+// noError fails the test right away when the given data could not be stored.
+func noError(t *testing.T, err error) {
+	t.Helper()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+}
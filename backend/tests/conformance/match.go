@@ -0,0 +1,153 @@
+package conformance
+
+import (
+	"context"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/matchoffer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MatchRepository specifies match.Repository: matches are listed for each of their
+// participants, and every write is checked against the version the match was read at.
+func MatchRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	matches := []match.Entity{
+		match.NewMatch("offer-1", []string{"account-1", "account-2"}, common.Paddle, day),
+		match.NewMatch("offer-2", []string{"account-1", "account-3"}, common.Paddle, day),
+		match.NewMatch("offer-3", []string{"account-2", "account-3"}, common.Tennis, day),
+	}
+	matches[1].Status = match.StatusPlayed
+	matchIDs := func(matches []match.Entity) []string {
+		return ids(matches, func(m match.Entity) string { return m.ID })
+	}
+
+	testCases := []struct {
+		name       string
+		query      match.DomainQuery
+		assertions func(t *testing.T, found []match.Entity, err error)
+	}{
+		{
+			name:  "given saved matches when finding by account then returns the matches the account plays in",
+			query: match.DomainQuery{AccountID: "account-1"},
+			assertions: func(t *testing.T, found []match.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"offer-1", "offer-2"}, matchIDs(found))
+			},
+		},
+		{
+			name:  "given saved matches when finding by account and status then returns the matches of the account in that status",
+			query: match.DomainQuery{AccountID: "account-3", Statuses: []match.Status{match.StatusPlayed}},
+			assertions: func(t *testing.T, found []match.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-2"}, matchIDs(found))
+			},
+		},
+		{
+			name:  "given saved matches when finding by an account without matches then returns no matches",
+			query: match.DomainQuery{AccountID: "account-4"},
+			assertions: func(t *testing.T, found []match.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, found)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).Match
+			for _, entity := range matches {
+				noError(t, repository.Save(ctx, entity, nil))
+			}
+
+			// when
+			found, err := repository.Find(ctx, testCase.query)
+
+			// then
+			testCase.assertions(t, found, err)
+		})
+	}
+
+	t.Run("given a saved match when finding it by id then returns it", func(t *testing.T) {
+		// given
+		repository := backend(t).Match
+		noError(t, repository.Save(ctx, matches[2], nil))
+
+		// when
+		found, err := repository.FindByID(ctx, "account-2", matches[2].ID)
+
+		// then
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, []string{"account-2", "account-3"}, found.Participants)
+			assert.Equal(t, common.Tennis, found.Sport)
+		}
+	})
+
+	t.Run("given no match when finding it by id then returns nil", func(t *testing.T) {
+		// when
+		found, err := backend(t).Match.FindByID(ctx, "account-1", "offer-9")
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("given a match read at its stored version when saving it twice then the second write is a version conflict", func(t *testing.T) {
+		// given
+		repository := backend(t).Match
+		noError(t, repository.Save(ctx, matches[0], nil))
+		read, err := repository.FindByID(ctx, "account-1", matches[0].ID)
+		noError(t, err)
+
+		// when
+		first := repository.Save(ctx, *read, nil)
+		second := repository.Save(ctx, *read, nil)
+
+		// then
+		assert.NoError(t, first)
+		assert.ErrorIs(t, second, common.ErrVersionConflict)
+	})
+
+	t.Run("given a confirmed offer when confirming it again then returns a version conflict", func(t *testing.T) {
+		// given
+		repositories := backend(t)
+		offer := newOffer("offer-1", 1, nil)
+		noError(t, repositories.MatchOffer.Save(ctx, offer, nil))
+		offer.Version = 1
+		offer.Status = matchoffer.StatusConfirmed
+		noError(t, repositories.Match.SaveConfirmation(ctx, matches[0], offer, nil, nil))
+
+		// when
+		err := repositories.Match.SaveConfirmation(ctx, matches[0], offer, nil, nil)
+
+		// then
+		assert.ErrorIs(t, err, common.ErrVersionConflict)
+	})
+
+	t.Run("given a saved match when removing a participant then it is no longer listed for it", func(t *testing.T) {
+		// given
+		repository := backend(t).Match
+		noError(t, repository.Save(ctx, matches[0], nil))
+		read, err := repository.FindByID(ctx, "account-1", matches[0].ID)
+		noError(t, err)
+		read.Participants = []string{"account-1"}
+
+		// when
+		err = repository.RemoveParticipant(ctx, *read, "account-2", nil, nil)
+
+		// then
+		assert.NoError(t, err)
+		found, err := repository.Find(ctx, match.DomainQuery{AccountID: "account-2"})
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+		found, err = repository.Find(ctx, match.DomainQuery{AccountID: "account-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"offer-1"}, matchIDs(found))
+	})
+}
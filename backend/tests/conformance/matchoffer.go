@@ -0,0 +1,342 @@
+package conformance
+
+import (
+	"context"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/outbox"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	palermo  = matchoffer.NewLocationWithCoords("Argentina", "Buenos Aires", "Palermo", -34.5885, -58.4300)
+	belgrano = matchoffer.NewLocationWithCoords("Argentina", "Buenos Aires", "Belgrano", -34.5627, -58.4583)
+	cordoba  = matchoffer.NewLocationWithCoords("Argentina", "Cordoba", "Cordoba", -31.4201, -64.1888)
+)
+
+// newOffer builds a pending public paddle offer of owner-1 in Palermo, daysAhead days from
+// today, admitting categories 5 to 7. change adjusts whatever the test case is about.
+func newOffer(id string, daysAhead int, change func(offer *matchoffer.Entity)) matchoffer.Entity {
+	tz := palermo.GetTimezone()
+	now := time.Now().In(tz)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, tz).AddDate(0, 0, daysAhead)
+	start := day.Add(18 * time.Hour)
+	timeSlot, _ := matchoffer.NewTimeSlot(start, start.Add(2*time.Hour))
+	offer := matchoffer.NewMatchOffer(
+		"Thunder Strikers",
+		common.Paddle,
+		day,
+		timeSlot,
+		palermo,
+		matchoffer.NewSpecificCategories([]common.Category{5, 6, 7}),
+		matchoffer.StatusPending,
+		now,
+		"owner-1",
+		0,
+	)
+	offer.ID = id
+	if change != nil {
+		change(&offer)
+	}
+	return offer
+}
+
+func offerIDs(offers []matchoffer.Entity) []string {
+	return ids(offers, func(o matchoffer.Entity) string { return o.ID })
+}
+
+// MatchOfferRepository specifies matchoffer.Repository: every criterion of the query
+// narrows the result down, whichever of them the backend looks offers up by. Results
+// come in ID order, so Limit and Offset page through them the same way on every backend,
+// and Total counts every matching offer.
+func MatchOfferRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	offers := []matchoffer.Entity{
+		newOffer("offer-1", 1, nil),
+		newOffer("offer-2", 3, func(o *matchoffer.Entity) {
+			o.Sport = common.Tennis
+			o.Location = belgrano
+			o.PlayerNeeds = matchoffer.PlayerNeeds{
+				SpotsNeeded: 2,
+				Positions:   []matchoffer.PositionNeed{{Position: "DEFENDER", Spots: 2}},
+			}
+		}),
+		newOffer("offer-3", 7, func(o *matchoffer.Entity) {
+			o.Status = matchoffer.StatusConfirmed
+			o.AdmittedCategories = matchoffer.NewGreaterThanCategory(3)
+			o.OwnerAccountID = "owner-2"
+		}),
+		newOffer("offer-4", 3, func(o *matchoffer.Entity) {
+			o.Location = cordoba
+			o.AdmittedCategories = matchoffer.NewLessThanCategory(3)
+			o.OwnerAccountID = "owner-2"
+			o.Capacity = 4
+			o.PlayerNeeds = matchoffer.PlayerNeeds{Committed: 3}
+		}),
+		newOffer("offer-5", 1, func(o *matchoffer.Entity) {
+			o.Visibility = matchoffer.VisibilityInvite
+			o.InvitedAccountIDs = []string{"guest-1"}
+			o.OwnerAccountID = "owner-3"
+		}),
+	}
+	today := time.Now().In(palermo.GetTimezone())
+
+	testCases := []struct {
+		name       string
+		query      matchoffer.DomainQuery
+		assertions func(t *testing.T, page matchoffer.Page, err error)
+	}{
+		{
+			name:  "given saved offers when finding by ids then returns those offers",
+			query: matchoffer.DomainQuery{IDs: []string{"offer-4", "offer-2"}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-2", "offer-4"}, offerIDs(page.Entities))
+				assert.Equal(t, 2, page.Total)
+			},
+		},
+		{
+			name:  "given saved offers when finding by sport then returns the offers of that sport",
+			query: matchoffer.DomainQuery{Sports: []common.Sport{common.Tennis}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-2"}, offerIDs(page.Entities))
+			},
+		},
+		{
+			name:  "given saved offers when finding by status then returns the offers in that status",
+			query: matchoffer.DomainQuery{Statuses: []matchoffer.Status{matchoffer.StatusConfirmed}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-3"}, offerIDs(page.Entities))
+			},
+		},
+		{
+			name:  "given saved offers when finding by categories then returns the offers admitting any of them",
+			query: matchoffer.DomainQuery{Categories: []common.Category{1, 2}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-4"}, offerIDs(page.Entities))
+				assert.Equal(t, 1, page.Total)
+			},
+		},
+		{
+			name:  "given saved offers when finding by date range then returns the offers of those days",
+			query: matchoffer.DomainQuery{FromDate: today.AddDate(0, 0, 2), ToDate: today.AddDate(0, 0, 5)},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-2", "offer-4"}, offerIDs(page.Entities))
+			},
+		},
+		{
+			name:  "given saved offers when finding by location then returns the offers in that place",
+			query: matchoffer.DomainQuery{Location: &matchoffer.Location{Country: "Argentina", Province: "Buenos Aires", Locality: "Belgrano"}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-2"}, offerIDs(page.Entities))
+			},
+		},
+		{
+			name:  "given saved offers when finding by radius then returns the offers within it",
+			query: matchoffer.DomainQuery{GeoFilter: &matchoffer.GeoFilter{Latitude: -34.6037, Longitude: -58.3816, RadiusKm: 20}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-1", "offer-2", "offer-3", "offer-5"}, offerIDs(page.Entities))
+				assert.Equal(t, 4, page.Total)
+			},
+		},
+		{
+			name: "given saved offers when finding by radius and categories then returns the nearby offers admitting them",
+			query: matchoffer.DomainQuery{
+				GeoFilter:  &matchoffer.GeoFilter{Latitude: -34.6037, Longitude: -58.3816, RadiusKm: 20},
+				Categories: []common.Category{3},
+			},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-3"}, offerIDs(page.Entities))
+			},
+		},
+		{
+			name:  "given saved offers when finding by owner then returns the offers of that owner",
+			query: matchoffer.DomainQuery{OwnerAccountID: "owner-2"},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-3", "offer-4"}, offerIDs(page.Entities))
+			},
+		},
+		{
+			name:  "given saved offers when finding the ones visible to an invited account then includes the offers it is invited to",
+			query: matchoffer.DomainQuery{VisibleTo: &matchoffer.Audience{AccountID: "guest-1"}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-1", "offer-2", "offer-3", "offer-4", "offer-5"}, offerIDs(page.Entities))
+			},
+		},
+		{
+			name:  "given saved offers when finding the ones visible to any account then leaves out the offers it is not invited to",
+			query: matchoffer.DomainQuery{VisibleTo: &matchoffer.Audience{AccountID: "stranger"}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-1", "offer-2", "offer-3", "offer-4"}, offerIDs(page.Entities))
+			},
+		},
+		{
+			name:  "given saved offers when excluding an owner and some offers then leaves them out",
+			query: matchoffer.DomainQuery{ExcludedOwner: "owner-2", ExcludedIDs: []string{"offer-1"}},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-2", "offer-5"}, offerIDs(page.Entities))
+				assert.Equal(t, 2, page.Total)
+			},
+		},
+		{
+			name:  "given saved offers when finding the ones with open spots then leaves out the offers with fewer free",
+			query: matchoffer.DomainQuery{MinOpenSpots: 2},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-1", "offer-2", "offer-3", "offer-5"}, offerIDs(page.Entities))
+				assert.Equal(t, 4, page.Total)
+			},
+		},
+		{
+			name:  "given saved offers when finding the ones with an open position then returns the offers needing it",
+			query: matchoffer.DomainQuery{OpenPosition: "DEFENDER"},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-2"}, offerIDs(page.Entities))
+			},
+		},
+		{
+			name:  "given saved offers when finding a page then returns it in id order with the total",
+			query: matchoffer.DomainQuery{Statuses: []matchoffer.Status{matchoffer.StatusPending}, Limit: 2, Offset: 1},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-2", "offer-4"}, offerIDs(page.Entities))
+				assert.Equal(t, 4, page.Total)
+			},
+		},
+		{
+			name:  "given saved offers when finding a page with excluded offers then pages through the remaining ones",
+			query: matchoffer.DomainQuery{ExcludedIDs: []string{"offer-2"}, Limit: 2, Offset: 2},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"offer-4", "offer-5"}, offerIDs(page.Entities))
+				assert.Equal(t, 4, page.Total)
+			},
+		},
+		{
+			name:  "given saved offers when finding a page past the last offer then returns an empty page with the total",
+			query: matchoffer.DomainQuery{OwnerAccountID: "owner-2", Limit: 2, Offset: 2},
+			assertions: func(t *testing.T, page matchoffer.Page, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, page.Entities)
+				assert.Equal(t, 2, page.Total)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).MatchOffer
+			_, err := repository.SaveAll(ctx, offers)
+			noError(t, err)
+
+			// when
+			page, err := repository.Find(ctx, testCase.query)
+
+			// then
+			testCase.assertions(t, page, err)
+		})
+	}
+
+	t.Run("given an offer read at its stored version when saving it twice then the second write is a version conflict", func(t *testing.T) {
+		// given
+		repository := backend(t).MatchOffer
+		noError(t, repository.Save(ctx, newOffer("offer-1", 1, nil), nil))
+		page, err := repository.Find(ctx, matchoffer.DomainQuery{IDs: []string{"offer-1"}})
+		noError(t, err)
+		read := page.Entities[0]
+
+		// when
+		first := repository.Save(ctx, read, nil)
+		second := repository.Save(ctx, read, nil)
+
+		// then
+		assert.NoError(t, first)
+		assert.ErrorIs(t, second, common.ErrVersionConflict)
+	})
+
+	t.Run("given an offer saved as new when saving it again as new then the second write is a version conflict", func(t *testing.T) {
+		// given
+		repository := backend(t).MatchOffer
+		offer := newOffer("offer-1", 1, nil)
+		noError(t, repository.Save(ctx, offer, nil))
+
+		// when
+		err := repository.Save(ctx, offer, nil)
+
+		// then
+		assert.ErrorIs(t, err, common.ErrVersionConflict)
+	})
+
+	t.Run("given an offer changed since it was read when saving all then the others are written and the stale one is a version conflict", func(t *testing.T) {
+		// given
+		repository := backend(t).MatchOffer
+		noError(t, repository.Save(ctx, newOffer("offer-1", 1, nil), nil))
+		page, err := repository.Find(ctx, matchoffer.DomainQuery{IDs: []string{"offer-1"}})
+		noError(t, err)
+		stale := page.Entities[0]
+		noError(t, repository.Save(ctx, stale, nil))
+
+		// when
+		saved, err := repository.SaveAll(ctx, []matchoffer.Entity{stale, newOffer("offer-2", 1, nil)})
+
+		// then
+		assert.ErrorIs(t, err, common.ErrVersionConflict)
+		assert.Equal(t, 1, saved)
+		found, err := repository.FindByIDs(ctx, []string{"offer-1", "offer-2"})
+		noError(t, err)
+		assert.Len(t, found, 2)
+	})
+
+	t.Run("given saved offers when finding by ids then returns the stored ones once", func(t *testing.T) {
+		// given
+		repository := backend(t).MatchOffer
+		_, err := repository.SaveAll(ctx, offers)
+		noError(t, err)
+
+		// when
+		found, err := repository.FindByIDs(ctx, []string{"offer-4", "missing", "offer-2", "offer-4"})
+
+		// then
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"offer-2", "offer-4"}, offerIDs(found))
+	})
+
+	t.Run("given a saved offer when saving it cancelled with messages then it is archived and writes them with it", func(t *testing.T) {
+		// given
+		repositories := backend(t)
+		noError(t, repositories.MatchOffer.Save(ctx, newOffer("offer-1", 1, nil), nil))
+		page, err := repositories.MatchOffer.Find(ctx, matchoffer.DomainQuery{IDs: []string{"offer-1"}})
+		noError(t, err)
+		cancelled, err := page.Entities[0].Cancel()
+		noError(t, err)
+		message := newMessage(t, "MatchOfferCancelled")
+
+		// when
+		err = repositories.MatchOffer.Save(ctx, cancelled, []outbox.Message{message})
+
+		// then
+		assert.NoError(t, err)
+		page, err = repositories.MatchOffer.Find(ctx, matchoffer.DomainQuery{IDs: []string{"offer-1"}})
+		assert.NoError(t, err)
+		if assert.Len(t, page.Entities, 1) {
+			assert.Equal(t, matchoffer.StatusCancelled, page.Entities[0].Status)
+		}
+		assert.Equal(t, []string{message.ID}, dueMessageIDs(t, repositories))
+	})
+}
@@ -0,0 +1,239 @@
+package conformance
+
+import (
+	"context"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/matchrequest"
+	"sportlink/api/domain/outbox"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MatchRequestRepository specifies matchrequest.Repository: every criterion of the query
+// narrows the result down, whichever of them the backend looks requests up by, and a
+// query without IDs, offers, owners or requesters returns no requests.
+func MatchRequestRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	requests := []matchrequest.Entity{
+		matchrequest.NewMatchRequest("offer-1", "owner-1", "requester-1", ""),
+		matchrequest.NewMatchRequest("offer-1", "owner-1", "requester-2", ""),
+		matchrequest.NewMatchRequest("offer-2", "owner-2", "requester-1", ""),
+		matchrequest.NewMatchRequest("offer-2", "owner-2", "requester-3", ""),
+	}
+	requests[1].Status = matchrequest.StatusAccepted
+	requests[3].Status = matchrequest.StatusRejected
+	requestIDs := func(requests []matchrequest.Entity) []string {
+		return ids(requests, func(r matchrequest.Entity) string { return r.ID })
+	}
+
+	testCases := []struct {
+		name       string
+		query      matchrequest.DomainQuery
+		assertions func(t *testing.T, found []matchrequest.Entity, err error)
+	}{
+		{
+			name:  "given saved requests when finding by several owners then returns the requests of any of them",
+			query: matchrequest.DomainQuery{OwnerAccountIDs: []string{"owner-1", "owner-2"}},
+			assertions: func(t *testing.T, found []matchrequest.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, requestIDs(requests), requestIDs(found))
+			},
+		},
+		{
+			name:  "given saved requests when finding by owner and status then returns the requests of the owner in that status",
+			query: matchrequest.DomainQuery{OwnerAccountIDs: []string{"owner-1"}, Statuses: []matchrequest.Status{matchrequest.StatusPending}},
+			assertions: func(t *testing.T, found []matchrequest.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{requests[0].ID}, requestIDs(found))
+			},
+		},
+		{
+			name:  "given saved requests when finding by ids then returns those requests",
+			query: matchrequest.DomainQuery{IDs: []string{requests[0].ID, requests[3].ID}},
+			assertions: func(t *testing.T, found []matchrequest.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{requests[0].ID, requests[3].ID}, requestIDs(found))
+			},
+		},
+		{
+			name:  "given saved requests when finding by several requesters then returns the requests of any of them",
+			query: matchrequest.DomainQuery{RequesterAccountIDs: []string{"requester-2", "requester-3"}},
+			assertions: func(t *testing.T, found []matchrequest.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{requests[1].ID, requests[3].ID}, requestIDs(found))
+			},
+		},
+		{
+			name:  "given saved requests when finding by requester and offer then returns the requests matching both",
+			query: matchrequest.DomainQuery{RequesterAccountIDs: []string{"requester-1"}, MatchOfferIDs: []string{"offer-2"}},
+			assertions: func(t *testing.T, found []matchrequest.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{requests[2].ID}, requestIDs(found))
+			},
+		},
+		{
+			name:  "given saved requests when finding by offers and status then returns the requests on those offers in that status",
+			query: matchrequest.DomainQuery{MatchOfferIDs: []string{"offer-1", "offer-2"}, Statuses: []matchrequest.Status{matchrequest.StatusAccepted, matchrequest.StatusRejected}},
+			assertions: func(t *testing.T, found []matchrequest.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{requests[1].ID, requests[3].ID}, requestIDs(found))
+			},
+		},
+		{
+			name:  "given saved requests when finding by status only then returns no requests",
+			query: matchrequest.DomainQuery{Statuses: []matchrequest.Status{matchrequest.StatusPending}},
+			assertions: func(t *testing.T, found []matchrequest.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, found)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).MatchRequest
+			noError(t, repository.SaveAll(ctx, requests))
+
+			// when
+			found, err := repository.Find(ctx, testCase.query)
+
+			// then
+			testCase.assertions(t, found, err)
+		})
+	}
+
+	t.Run("given saved requests when finding by ids then returns the stored ones once", func(t *testing.T) {
+		// given
+		repository := backend(t).MatchRequest
+		noError(t, repository.SaveAll(ctx, requests))
+
+		// when
+		found, err := repository.FindByIDs(ctx, []string{requests[2].ID, "missing", requests[0].ID, requests[2].ID})
+
+		// then
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{requests[0].ID, requests[2].ID}, requestIDs(found))
+	})
+
+	t.Run("given a pending request when creating it again then returns ErrOpenRequestExists", func(t *testing.T) {
+		// given
+		repository := backend(t).MatchRequest
+		noError(t, repository.Create(ctx, requests[0], nil))
+
+		// when
+		err := repository.Create(ctx, requests[0], nil)
+
+		// then
+		assert.ErrorIs(t, err, matchrequest.ErrOpenRequestExists)
+	})
+
+	t.Run("given a new request when creating it with messages then writes them with it", func(t *testing.T) {
+		// given
+		repositories := backend(t)
+		message := newMessage(t, "MatchRequestCreated")
+
+		// when
+		err := repositories.MatchRequest.Create(ctx, requests[0], []outbox.Message{message})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []string{message.ID}, dueMessageIDs(t, repositories))
+	})
+
+	t.Run("given a pending request when creating it again with messages then writes none of them", func(t *testing.T) {
+		// given
+		repositories := backend(t)
+		noError(t, repositories.MatchRequest.Create(ctx, requests[0], nil))
+
+		// when
+		err := repositories.MatchRequest.Create(ctx, requests[0], []outbox.Message{newMessage(t, "MatchRequestCreated")})
+
+		// then
+		assert.ErrorIs(t, err, matchrequest.ErrOpenRequestExists)
+		assert.Empty(t, dueMessageIDs(t, repositories))
+	})
+
+	t.Run("given a request changed since it was read when saving it with messages then writes none of them", func(t *testing.T) {
+		// given
+		repositories := backend(t)
+		noError(t, repositories.MatchRequest.Save(ctx, requests[0], nil))
+		rejected, err := requests[0].Reject("")
+		noError(t, err)
+
+		// when
+		err = repositories.MatchRequest.Save(ctx, rejected, []outbox.Message{newMessage(t, "MatchRequestRejected")})
+
+		// then
+		assert.ErrorIs(t, err, common.ErrVersionConflict)
+		assert.Empty(t, dueMessageIDs(t, repositories))
+	})
+
+	t.Run("given a cancelled request when creating it again then writes the new request", func(t *testing.T) {
+		// given
+		repository := backend(t).MatchRequest
+		cancelled := requests[0]
+		cancelled.Status = matchrequest.StatusCancel
+		noError(t, repository.Save(ctx, cancelled, nil))
+
+		// when
+		err := repository.Create(ctx, requests[0], nil)
+
+		// then
+		assert.NoError(t, err)
+		found, err := repository.Find(ctx, matchrequest.DomainQuery{IDs: []string{requests[0].ID}})
+		assert.NoError(t, err)
+		assert.Equal(t, matchrequest.StatusPending, found[0].Status)
+	})
+
+	t.Run("given an accepted request when updating its status then returns an error", func(t *testing.T) {
+		// given
+		repository := backend(t).MatchRequest
+		noError(t, repository.Save(ctx, requests[1], nil))
+		rejected := requests[1]
+		rejected.Status = matchrequest.StatusRejected
+
+		// when
+		err := repository.UpdateStatus(ctx, rejected, nil)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("given a full offer when accepting a request with it then returns ErrOfferFull", func(t *testing.T) {
+		// given
+		repositories := backend(t)
+		offer := newOffer("offer-1", 1, func(o *matchoffer.Entity) {
+			o.Capacity = 2
+			o.AcceptedCount = 1
+		})
+		noError(t, repositories.MatchOffer.Save(ctx, offer, nil))
+		offer.Version = 1
+		offer.AcceptedCount = 2
+		accepted := requests[0]
+		accepted.Status = matchrequest.StatusAccepted
+
+		// when
+		err := repositories.MatchRequest.SaveWithOffer(ctx, accepted, offer, nil)
+
+		// then
+		assert.ErrorIs(t, err, matchoffer.ErrOfferFull)
+	})
+
+	t.Run("given an offer changed since it was read when saving a request with it then returns a version conflict", func(t *testing.T) {
+		// given
+		repositories := backend(t)
+		offer := newOffer("offer-1", 1, nil)
+		noError(t, repositories.MatchOffer.Save(ctx, offer, nil))
+		offer.Version = 1
+		noError(t, repositories.MatchRequest.SaveWithOffer(ctx, requests[0], offer, nil))
+
+		// when
+		err := repositories.MatchRequest.SaveWithOffer(ctx, requests[1], offer, nil)
+
+		// then
+		assert.ErrorIs(t, err, common.ErrVersionConflict)
+	})
+}
@@ -0,0 +1,120 @@
+package conformance
+
+import (
+	"context"
+	"sportlink/api/domain/notification"
+	"sportlink/api/domain/outbox"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// NotificationRepository specifies notification.Repository: an inbox is read newest first
+// in pages, each NextCursor picking up right after the last notification of its page.
+func NotificationRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	readAt := now
+	notifications := []notification.Entity{
+		notification.NewNotification("n-1", "account-1", notification.TypeMatchRequestReceived, map[string]string{"match_request_id": "request-1"}, now.Add(-4*time.Minute)),
+		notification.NewNotification("n-2", "account-1", notification.TypeMatchRequestReceived, map[string]string{"match_request_id": "request-2"}, now.Add(-3*time.Minute)),
+		notification.NewNotification("n-3", "account-1", notification.TypeMatchRequestReceived, map[string]string{"match_request_id": "request-3"}, now.Add(-2*time.Minute)),
+		notification.NewNotification("n-4", "account-1", notification.TypeMatchRequestReceived, map[string]string{"match_request_id": "request-4"}, now.Add(-time.Minute)),
+	}
+	notifications[2].ReadAt = &readAt
+	other := notification.NewNotification("n-5", "account-2", notification.TypeMatchRequestReceived, nil, now)
+	notificationIDs := func(notifications []notification.Entity) []string {
+		return ids(notifications, func(n notification.Entity) string { return n.ID })
+	}
+
+	testCases := []struct {
+		name       string
+		query      notification.DomainQuery
+		assertions func(t *testing.T, page notification.Page, err error)
+	}{
+		{
+			name:  "given an inbox when finding the first page then returns the newest notifications and where the next page starts",
+			query: notification.DomainQuery{AccountID: "account-1", Limit: 2},
+			assertions: func(t *testing.T, page notification.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"n-4", "n-3"}, notificationIDs(page.Entities))
+				assert.Equal(t, "n-3", page.NextCursor)
+			},
+		},
+		{
+			name:  "given an inbox when finding the last page then returns the oldest notifications without a cursor",
+			query: notification.DomainQuery{AccountID: "account-1", Limit: 2, Cursor: "n-3"},
+			assertions: func(t *testing.T, page notification.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"n-2", "n-1"}, notificationIDs(page.Entities))
+				assert.Empty(t, page.NextCursor)
+			},
+		},
+		{
+			name:  "given an inbox when finding the unread notifications then leaves out the read ones",
+			query: notification.DomainQuery{AccountID: "account-1", UnreadOnly: true, Limit: 2},
+			assertions: func(t *testing.T, page notification.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"n-4", "n-2"}, notificationIDs(page.Entities))
+				assert.Equal(t, "n-2", page.NextCursor)
+			},
+		},
+		{
+			name:  "given an inbox when finding the notifications of another account then returns only those",
+			query: notification.DomainQuery{AccountID: "account-2", Limit: 10},
+			assertions: func(t *testing.T, page notification.Page, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"n-5"}, notificationIDs(page.Entities))
+				assert.Empty(t, page.NextCursor)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).Notification
+			noError(t, repository.SaveAll(ctx, notifications))
+			noError(t, repository.Save(ctx, other, nil))
+
+			// when
+			page, err := repository.Find(ctx, testCase.query)
+
+			// then
+			testCase.assertions(t, page, err)
+		})
+	}
+
+	t.Run("given a saved notification when finding it by id then returns it only for its account", func(t *testing.T) {
+		// given
+		repository := backend(t).Notification
+		noError(t, repository.Save(ctx, notifications[0], nil))
+
+		// when
+		found, err := repository.FindByID(ctx, "account-1", "n-1")
+		missing, missingErr := repository.FindByID(ctx, "account-2", "n-1")
+
+		// then
+		assert.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, map[string]string{"match_request_id": "request-1"}, found.Data)
+			assert.Nil(t, found.ReadAt)
+		}
+		assert.NoError(t, missingErr)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("given a notification with delivery requests when saving it then writes them with the notification", func(t *testing.T) {
+		// given
+		repositories := backend(t)
+		message := newMessage(t, "NotificationDeliveryRequested")
+
+		// when
+		err := repositories.Notification.Save(ctx, notifications[0], []outbox.Message{message})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []string{message.ID}, dueMessageIDs(t, repositories))
+	})
+}
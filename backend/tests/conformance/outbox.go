@@ -0,0 +1,85 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"sportlink/api/domain/outbox"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// OutboxRepository specifies outbox.Repository: due messages are the pending ones whose
+// next attempt is not in the future, in the order they were raised.
+func OutboxRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	message := func(occurredAt time.Time) outbox.Message {
+		m, err := outbox.NewMessage("MatchOfferCreated", map[string]string{"match_offer_id": "offer-1"}, occurredAt)
+		noError(t, err)
+		return m
+	}
+	first := message(now.Add(-3 * time.Minute))
+	second := message(now.Add(-2 * time.Minute))
+	retried := message(now.Add(-time.Minute)).FailedAttempt(errors.New("broker unavailable"), now, outbox.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Minute, MaxDelay: time.Hour})
+	failed := message(now.Add(-time.Minute)).FailedAttempt(errors.New("broker unavailable"), now, outbox.RetryPolicy{MaxAttempts: 1})
+	messageIDs := func(messages []outbox.Message) []string {
+		return ids(messages, func(m outbox.Message) string { return m.ID })
+	}
+
+	testCases := []struct {
+		name       string
+		limit      int
+		assertions func(t *testing.T, due []outbox.Message, err error)
+	}{
+		{
+			name:  "given saved messages when finding the due ones then returns the pending ones in the order they were raised",
+			limit: 10,
+			assertions: func(t *testing.T, due []outbox.Message, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{first.ID, second.ID}, messageIDs(due))
+			},
+		},
+		{
+			name:  "given saved messages when finding fewer than are due then returns the oldest ones",
+			limit: 1,
+			assertions: func(t *testing.T, due []outbox.Message, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{first.ID}, messageIDs(due))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).Outbox
+			for _, m := range []outbox.Message{second, failed, retried, first} {
+				noError(t, repository.Save(ctx, m))
+			}
+
+			// when
+			due, err := repository.FindDue(ctx, now, testCase.limit)
+
+			// then
+			testCase.assertions(t, due, err)
+		})
+	}
+
+	t.Run("given a published message when deleting it then it is no longer due", func(t *testing.T) {
+		// given
+		repository := backend(t).Outbox
+		noError(t, repository.Save(ctx, first))
+		noError(t, repository.Save(ctx, second))
+
+		// when
+		err := repository.Delete(ctx, first.ID)
+
+		// then
+		assert.NoError(t, err)
+		due, err := repository.FindDue(ctx, now, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{second.ID}, messageIDs(due))
+	})
+}
@@ -0,0 +1,87 @@
+package conformance
+
+import (
+	"context"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/player"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// PlayerRepository specifies player.Repository: every criterion of the query narrows the
+// result down, and a query without criteria returns every player.
+func PlayerRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	players := []player.Entity{
+		{ID: "player-1", Category: common.L1, Sport: common.Paddle},
+		{ID: "player-2", Category: common.L2, Sport: common.Paddle},
+		{ID: "player-3", Category: common.L1, Sport: common.Football},
+	}
+	playerIDs := func(players []player.Entity) []string {
+		return ids(players, func(p player.Entity) string { return p.ID })
+	}
+
+	testCases := []struct {
+		name       string
+		query      player.DomainQuery
+		assertions func(t *testing.T, players []player.Entity, err error)
+	}{
+		{
+			name:  "given saved players when finding by id then returns that player",
+			query: player.DomainQuery{Id: "player-2"},
+			assertions: func(t *testing.T, players []player.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"player-2"}, playerIDs(players))
+			},
+		},
+		{
+			name:  "given saved players when finding by ids then returns those players",
+			query: player.DomainQuery{Ids: []string{"player-1", "player-3"}},
+			assertions: func(t *testing.T, players []player.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"player-1", "player-3"}, playerIDs(players))
+			},
+		},
+		{
+			name:  "given saved players when finding by category and sport then returns the players matching both",
+			query: player.DomainQuery{Category: common.L1, Sport: common.Paddle},
+			assertions: func(t *testing.T, players []player.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"player-1"}, playerIDs(players))
+			},
+		},
+		{
+			name:  "given saved players when finding without criteria then returns every player",
+			query: player.DomainQuery{},
+			assertions: func(t *testing.T, players []player.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"player-1", "player-2", "player-3"}, playerIDs(players))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).Player
+			for _, entity := range players {
+				noError(t, repository.Save(ctx, entity))
+			}
+
+			// when
+			found, err := repository.Find(ctx, testCase.query)
+
+			// then
+			testCase.assertions(t, found, err)
+		})
+	}
+
+	t.Run("given a player without id when saving then returns an error", func(t *testing.T) {
+		// when
+		err := backend(t).Player.Save(ctx, player.Entity{Category: common.L1, Sport: common.Paddle})
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,167 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"sportlink/api/domain/match"
+	"sportlink/api/domain/outbox"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ReminderRepository specifies match.ReminderRepository: due reminders come earliest
+// first, scheduling a reminder again keeps a single one, only one claim of a reminder
+// wins while its lease runs, a reminder whose lease ended can be claimed again, and only
+// the holder of the lease deletes it, together with its outbox messages.
+func ReminderRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	reminders := []match.Reminder{
+		{MatchID: "match-1", OrganiserAccountID: "owner-1", StartTime: now.Add(time.Hour), Offset: 2 * time.Hour},
+		{MatchID: "match-1", OrganiserAccountID: "owner-1", StartTime: now.Add(time.Hour), Offset: 90 * time.Minute, Final: true},
+		{MatchID: "match-2", OrganiserAccountID: "owner-2", StartTime: now.Add(2 * time.Hour), Offset: 4 * time.Hour},
+		{MatchID: "match-3", OrganiserAccountID: "owner-3", StartTime: now.Add(3 * time.Hour), Offset: time.Hour},
+	}
+	dueKeys := func(reminders []match.Reminder) []string {
+		return ids(reminders, func(r match.Reminder) string { return fmt.Sprintf("%s/%s", r.MatchID, r.Lead()) })
+	}
+
+	testCases := []struct {
+		name       string
+		limit      int
+		assertions func(t *testing.T, due []match.Reminder, err error)
+	}{
+		{
+			name:  "given scheduled reminders when finding the due ones then returns them earliest first",
+			limit: 10,
+			assertions: func(t *testing.T, due []match.Reminder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"match-2/4h", "match-1/2h", "match-1/90m"}, dueKeys(due))
+			},
+		},
+		{
+			name:  "given scheduled reminders when finding fewer than are due then returns the earliest ones",
+			limit: 2,
+			assertions: func(t *testing.T, due []match.Reminder, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"match-2/4h", "match-1/2h"}, dueKeys(due))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).Reminder
+			noError(t, repository.Schedule(ctx, reminders))
+
+			// when
+			due, err := repository.FindDue(ctx, now, testCase.limit)
+
+			// then
+			testCase.assertions(t, due, err)
+		})
+	}
+
+	t.Run("given a reminder scheduled twice when finding the due ones then returns it once", func(t *testing.T) {
+		// given
+		repository := backend(t).Reminder
+		noError(t, repository.Schedule(ctx, reminders[:1]))
+		noError(t, repository.Schedule(ctx, reminders[:1]))
+
+		// when
+		due, err := repository.FindDue(ctx, now, 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"match-1/2h"}, dueKeys(due))
+	})
+
+	lease := 5 * time.Minute
+
+	t.Run("given a due reminder when claiming it twice then only the first claim wins", func(t *testing.T) {
+		// given
+		repository := backend(t).Reminder
+		noError(t, repository.Schedule(ctx, reminders))
+
+		// when
+		first, firstErr := repository.Claim(ctx, reminders[0], now, now.Add(lease))
+		second, secondErr := repository.Claim(ctx, reminders[0], now, now.Add(lease))
+
+		// then
+		assert.NoError(t, firstErr)
+		assert.NoError(t, secondErr)
+		assert.True(t, first)
+		assert.False(t, second)
+		due, err := repository.FindDue(ctx, now, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"match-2/4h", "match-1/90m"}, dueKeys(due))
+	})
+
+	t.Run("given a claimed reminder that was never deleted when its lease is over then it is due and claimed again", func(t *testing.T) {
+		// given
+		repository := backend(t).Reminder
+		noError(t, repository.Schedule(ctx, reminders[:1]))
+		claimed, err := repository.Claim(ctx, reminders[0], now, now.Add(lease))
+		noError(t, err)
+		assert.True(t, claimed)
+		later := now.Add(lease)
+
+		// when
+		due, dueErr := repository.FindDue(ctx, later, 10)
+		again, againErr := repository.Claim(ctx, reminders[0], later, later.Add(lease))
+
+		// then
+		assert.NoError(t, dueErr)
+		assert.Equal(t, []string{"match-1/2h"}, dueKeys(due))
+		assert.NoError(t, againErr)
+		assert.True(t, again)
+	})
+
+	t.Run("given a claimed reminder when deleting it with messages then it is neither due nor claimable anymore and writes them with it", func(t *testing.T) {
+		// given
+		repositories := backend(t)
+		repository := repositories.Reminder
+		noError(t, repository.Schedule(ctx, reminders[:1]))
+		_, err := repository.Claim(ctx, reminders[0], now, now.Add(lease))
+		noError(t, err)
+		message := newMessage(t, "MatchReminderDue")
+
+		// when
+		err = repository.Delete(ctx, reminders[0], now.Add(lease), []outbox.Message{message})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []string{message.ID}, dueMessageIDs(t, repositories))
+		later := now.Add(lease)
+		due, dueErr := repository.FindDue(ctx, later, 10)
+		assert.NoError(t, dueErr)
+		assert.Empty(t, due)
+		claimed, claimErr := repository.Claim(ctx, reminders[0], later, later.Add(lease))
+		assert.NoError(t, claimErr)
+		assert.False(t, claimed)
+	})
+
+	t.Run("given a reminder claimed again after a lease ended when the first holder deletes it then the lease is lost and nothing is written", func(t *testing.T) {
+		// given
+		repositories := backend(t)
+		repository := repositories.Reminder
+		noError(t, repository.Schedule(ctx, reminders[:1]))
+		_, err := repository.Claim(ctx, reminders[0], now, now.Add(lease))
+		noError(t, err)
+		later := now.Add(lease)
+		_, err = repository.Claim(ctx, reminders[0], later, later.Add(lease))
+		noError(t, err)
+
+		// when
+		err = repository.Delete(ctx, reminders[0], now.Add(lease), []outbox.Message{newMessage(t, "MatchReminderDue")})
+
+		// then
+		assert.ErrorIs(t, err, match.ErrReminderLeaseLost)
+		assert.Empty(t, dueMessageIDs(t, repositories))
+		again := repository.Delete(ctx, reminders[0], later.Add(lease), nil)
+		assert.NoError(t, again)
+	})
+}
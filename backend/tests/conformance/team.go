@@ -0,0 +1,365 @@
+package conformance
+
+import (
+	"context"
+	"sportlink/api/domain/common"
+	"sportlink/api/domain/player"
+	"sportlink/api/domain/team"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TeamRepository specifies team.Repository: every criterion of the query narrows the
+// result down, whichever of them the backend looks teams up by. Name matches the teams
+// whose name starts with it.
+func TeamRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	stats := *common.NewStats(0, 0, 0)
+	boca := team.NewTeam("Boca", common.L1, stats, common.Football, []player.Entity{{ID: "member-1"}}, "owner-1")
+	bocaJuniors := team.NewTeam("Boca Juniors", common.L2, stats, common.Football, []player.Entity{}, "owner-2")
+	river := team.NewTeam("River", common.L1, stats, common.Football, []player.Entity{}, "owner-1")
+	bocanada := team.NewTeam("Bocanada", common.L1, stats, common.Paddle, []player.Entity{{ID: "member-1"}}, "owner-1")
+	names := func(teams []team.Entity) []string {
+		return ids(teams, func(e team.Entity) string { return e.Name })
+	}
+
+	testCases := []struct {
+		name       string
+		query      team.DomainQuery
+		assertions func(t *testing.T, teams []team.Entity, err error)
+	}{
+		{
+			name:  "given saved teams when finding by name and sport then returns the teams of the sport whose name starts with it",
+			query: team.DomainQuery{Name: "Boca", Sports: []common.Sport{common.Football}},
+			assertions: func(t *testing.T, teams []team.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"Boca", "Boca Juniors"}, names(teams))
+			},
+		},
+		{
+			name:  "given saved teams when finding by name only then returns the teams of every sport whose name starts with it",
+			query: team.DomainQuery{Name: "Boca"},
+			assertions: func(t *testing.T, teams []team.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"Boca", "Boca Juniors", "Bocanada"}, names(teams))
+			},
+		},
+		{
+			name:  "given saved teams when finding by a word in the middle of a name then returns no teams",
+			query: team.DomainQuery{Name: "Juniors"},
+			assertions: func(t *testing.T, teams []team.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, teams)
+			},
+		},
+		{
+			name:  "given saved teams when finding by several sports and a category then returns the teams matching both",
+			query: team.DomainQuery{Sports: []common.Sport{common.Football, common.Paddle}, Categories: []common.Category{common.L1}},
+			assertions: func(t *testing.T, teams []team.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"Boca", "River", "Bocanada"}, names(teams))
+			},
+		},
+		{
+			name:  "given saved teams when finding by owner and sport then returns the teams of the owner in that sport",
+			query: team.DomainQuery{OwnerAccountID: "owner-1", Sports: []common.Sport{common.Football}},
+			assertions: func(t *testing.T, teams []team.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"Boca", "River"}, names(teams))
+			},
+		},
+		{
+			name:  "given saved teams when finding by owner and name then returns the teams of the owner whose name starts with it",
+			query: team.DomainQuery{OwnerAccountID: "owner-1", Name: "Boca"},
+			assertions: func(t *testing.T, teams []team.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"Boca", "Bocanada"}, names(teams))
+			},
+		},
+		{
+			name:  "given saved teams when finding by member and sport then returns the teams of the member in that sport",
+			query: team.DomainQuery{MemberAccountID: "member-1", Sports: []common.Sport{common.Paddle}},
+			assertions: func(t *testing.T, teams []team.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"Bocanada"}, names(teams))
+			},
+		},
+		{
+			name:  "given saved teams when finding by ids then returns those teams",
+			query: team.DomainQuery{Ids: []string{boca.ID, river.ID}},
+			assertions: func(t *testing.T, teams []team.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"Boca", "River"}, names(teams))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).Team
+			for _, entity := range []team.Entity{boca, bocaJuniors, river, bocanada} {
+				noError(t, repository.Save(ctx, entity, nil))
+			}
+
+			// when
+			teams, err := repository.Find(ctx, testCase.query)
+
+			// then
+			testCase.assertions(t, teams, err)
+		})
+	}
+
+	t.Run("given a saved team when renaming it then it keeps its ID and is only found under the new name", func(t *testing.T) {
+		// given
+		repository := backend(t).Team
+		noError(t, repository.Save(ctx, river, nil))
+
+		// when
+		err := repository.Update(ctx, "River", river.WithName("Riverside"), nil)
+
+		// then
+		assert.NoError(t, err)
+		teams, err := repository.Find(ctx, team.DomainQuery{Sports: []common.Sport{common.Football}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Riverside"}, names(teams))
+		assert.Equal(t, river.ID, teams[0].ID)
+	})
+
+	t.Run("given a renamed team when saving a team with its former name then it is saved", func(t *testing.T) {
+		// given
+		repository := backend(t).Team
+		noError(t, repository.Save(ctx, river, nil))
+		noError(t, repository.Update(ctx, "River", river.WithName("Riverside"), nil))
+		newRiver := team.NewTeam("River", common.L2, stats, common.Football, []player.Entity{}, "owner-2")
+
+		// when
+		err := repository.Save(ctx, newRiver, nil)
+
+		// then
+		assert.NoError(t, err)
+		teams, err := repository.Find(ctx, team.DomainQuery{Name: "River", Sports: []common.Sport{common.Football}})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"River", "Riverside"}, names(teams))
+	})
+
+	t.Run("given a saved team when saving another team of the sport with its name then fails with ErrNameTaken", func(t *testing.T) {
+		// given
+		repository := backend(t).Team
+		noError(t, repository.Save(ctx, boca, nil))
+
+		// when
+		err := repository.Save(ctx, team.NewTeam("Boca", common.L2, stats, common.Football, []player.Entity{}, "owner-2"), nil)
+
+		// then
+		assert.ErrorIs(t, err, team.ErrNameTaken)
+		teams, err := repository.Find(ctx, team.DomainQuery{Sports: []common.Sport{common.Football}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{boca.ID}, ids(teams, func(e team.Entity) string { return e.ID }))
+	})
+
+	t.Run("given a saved team when saving a team of another sport with its name then it is saved", func(t *testing.T) {
+		// given
+		repository := backend(t).Team
+		noError(t, repository.Save(ctx, boca, nil))
+
+		// when
+		err := repository.Save(ctx, team.NewTeam("Boca", common.L1, stats, common.Paddle, []player.Entity{}, "owner-1"), nil)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("given two saved teams when renaming one to the name of the other then fails with ErrNameTaken and keeps its name", func(t *testing.T) {
+		// given
+		repository := backend(t).Team
+		noError(t, repository.Save(ctx, boca, nil))
+		noError(t, repository.Save(ctx, river, nil))
+
+		// when
+		err := repository.Update(ctx, "River", river.WithName("Boca"), nil)
+
+		// then
+		assert.ErrorIs(t, err, team.ErrNameTaken)
+		teams, err := repository.Find(ctx, team.DomainQuery{Ids: []string{river.ID}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"River"}, names(teams))
+	})
+
+	t.Run("given a team renamed since it was read when updating it then fails with ErrVersionConflict", func(t *testing.T) {
+		// given
+		repository := backend(t).Team
+		noError(t, repository.Save(ctx, river, nil))
+		noError(t, repository.Update(ctx, "River", river.WithName("Riverside"), nil))
+
+		// when
+		err := repository.Update(ctx, "River", river.WithName("River Plate"), nil)
+
+		// then
+		assert.ErrorIs(t, err, common.ErrVersionConflict)
+		teams, err := repository.Find(ctx, team.DomainQuery{Ids: []string{river.ID}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Riverside"}, names(teams))
+	})
+}
+
+// TeamSearch specifies team.Repository.Search: names are compared folded, fuzzy names
+// match any word within a few typos, every other criterion narrows the result down, and
+// the pages come in SearchKey order, each one starting right after the cursor.
+func TeamSearch(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	stats := *common.NewStats(0, 0, 0)
+	tigre := team.NewTeam("Club Atlético Tigre", common.L2, stats, common.Football, []player.Entity{}, "owner-1")
+	tigre.HomeArea = &team.HomeArea{Locality: "Victoria", Latitude: -34.4541, Longitude: -58.5469}
+	tigre.LookingForPlayers = true
+	boca := team.NewTeam("Club Atletico Boca", common.L4, stats, common.Football, []player.Entity{}, "owner-2")
+	boca.HomeArea = &team.HomeArea{Locality: "La Boca", Latitude: -34.6345, Longitude: -58.3631}
+	nandues := team.NewTeam("Ñandúes", common.L3, stats, common.Paddle, []player.Entity{}, "owner-1")
+	nandues.HomeArea = &team.HomeArea{Locality: "Palermo", Latitude: -34.5800, Longitude: -58.4200}
+	nandues.LookingForPlayers = true
+	nautico := team.NewTeam("Club Náutico", common.L5, stats, common.Tennis, []player.Entity{}, "owner-2")
+	nautico.LookingForPlayers = true
+	names := func(teams []team.Entity) []string {
+		return ids(teams, func(e team.Entity) string { return e.Name })
+	}
+	saveAll := func(t *testing.T, repository team.Repository) {
+		for _, entity := range []team.Entity{tigre, boca, nandues, nautico} {
+			noError(t, repository.Save(ctx, entity, nil))
+		}
+	}
+	searchAll := func(t *testing.T, repository team.Repository, query team.SearchQuery) []string {
+		var found []string
+		for {
+			page, err := repository.Search(ctx, query)
+			noError(t, err)
+			found = append(found, names(page.Entities)...)
+			if page.NextCursor == "" {
+				return found
+			}
+			query.Cursor = page.NextCursor
+		}
+	}
+
+	testCases := []struct {
+		name  string
+		query team.SearchQuery
+		want  []string
+	}{
+		{
+			name:  "given saved teams when searching by a name without its accents then returns the teams starting with it in search name order",
+			query: team.SearchQuery{Name: "CLUB atletico"},
+			want:  []string{"Club Atletico Boca", "Club Atlético Tigre"},
+		},
+		{
+			name:  "given saved teams when searching by a name with an eñe then returns the teams starting with it folded",
+			query: team.SearchQuery{Name: "ñandu"},
+			want:  []string{"Ñandúes"},
+		},
+		{
+			name:  "given saved teams when searching by a later word with typos then returns the teams with a word close to it",
+			query: team.SearchQuery{Name: "Nautco", Fuzzy: true},
+			want:  []string{"Club Náutico"},
+		},
+		{
+			name:  "given saved teams when searching by several sports then returns the teams of those sports",
+			query: team.SearchQuery{Sports: []common.Sport{common.Paddle, common.Tennis}},
+			want:  []string{"Club Náutico", "Ñandúes"},
+		},
+		{
+			name:  "given saved teams when searching by categories then returns the teams of those categories",
+			query: team.SearchQuery{Categories: []common.Category{common.L2, common.L3}},
+			want:  []string{"Club Atlético Tigre", "Ñandúes"},
+		},
+		{
+			name:  "given saved teams when searching near a point then returns the teams whose home area is within the radius",
+			query: team.SearchQuery{Near: &team.GeoFilter{Latitude: -34.6037, Longitude: -58.3816, RadiusKm: 10}},
+			want:  []string{"Club Atletico Boca", "Ñandúes"},
+		},
+		{
+			name:  "given saved teams when searching the teams looking for players then returns only those",
+			query: team.SearchQuery{LookingForPlayers: true, Sports: []common.Sport{common.Football, common.Paddle}},
+			want:  []string{"Club Atlético Tigre", "Ñandúes"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).Team
+			saveAll(t, repository)
+			testCase.query.Limit = team.DefaultPageSize
+
+			// when
+			page, err := repository.Search(ctx, testCase.query)
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, names(page.Entities))
+			assert.Empty(t, page.NextCursor)
+		})
+	}
+
+	t.Run("given more teams than the limit when searching then returns them page by page", func(t *testing.T) {
+		// given
+		repository := backend(t).Team
+		saveAll(t, repository)
+
+		// when
+		first, err := repository.Search(ctx, team.SearchQuery{Limit: 2})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Club Atletico Boca", "Club Atlético Tigre"}, names(first.Entities))
+		assert.NotEmpty(t, first.NextCursor)
+		second, err := repository.Search(ctx, team.SearchQuery{Limit: 2, Cursor: first.NextCursor})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Club Náutico", "Ñandúes"}, names(second.Entities))
+		assert.Empty(t, second.NextCursor)
+	})
+
+	t.Run("given more matching teams than the limit when searching by a fuzzy name then every page follows the cursor", func(t *testing.T) {
+		// given
+		repository := backend(t).Team
+		saveAll(t, repository)
+
+		// when
+		found := searchAll(t, repository, team.SearchQuery{Name: "atletco", Fuzzy: true, Limit: 1})
+
+		// then
+		assert.Equal(t, []string{"Club Atletico Boca", "Club Atlético Tigre"}, found)
+	})
+
+	t.Run("given a cursor past every name with the prefix when searching then returns no teams", func(t *testing.T) {
+		// given
+		repository := backend(t).Team
+		saveAll(t, repository)
+
+		// when
+		page, err := repository.Search(ctx, team.SearchQuery{Name: "club", Limit: 2, Cursor: nandues.SearchKey()})
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, page.Entities)
+	})
+
+	t.Run("given a team renamed and no longer looking for players when searching then finds it as updated", func(t *testing.T) {
+		// given
+		repository := backend(t).Team
+		saveAll(t, repository)
+		updated := tigre.WithName("Tigre")
+		updated.LookingForPlayers = false
+
+		// when
+		err := repository.Update(ctx, tigre.Name, updated, nil)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Club Atletico Boca"}, searchAll(t, repository, team.SearchQuery{Name: "club atletico", Limit: 1}))
+		assert.Equal(t, []string{"Tigre"}, searchAll(t, repository, team.SearchQuery{Name: "tigre", Limit: 1}))
+		assert.Empty(t, searchAll(t, repository, team.SearchQuery{Name: "tigre", LookingForPlayers: true, Limit: 1}))
+		teams, err := repository.Find(ctx, team.DomainQuery{Ids: []string{tigre.ID}})
+		assert.NoError(t, err)
+		assert.Equal(t, tigre.HomeArea, teams[0].HomeArea)
+	})
+}
@@ -0,0 +1,70 @@
+package conformance
+
+import (
+	"context"
+	"sportlink/api/domain/user"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// UserRepository specifies user.Repository: users are looked up by Ids, and PlayerIDs
+// keeps those with any of the players.
+func UserRepository(t *testing.T, backend Backend) {
+	ctx := context.Background()
+	users := []user.Entity{
+		{ID: "user-1", FirstName: "Jorge", PlayerIDs: []string{"player-1", "player-2"}},
+		{ID: "user-2", FirstName: "Maria", PlayerIDs: []string{"player-3"}},
+		{ID: "user-3", FirstName: "Pedro", PlayerIDs: []string{}},
+	}
+	userIDs := func(users []user.Entity) []string {
+		return ids(users, func(u user.Entity) string { return u.ID })
+	}
+
+	testCases := []struct {
+		name       string
+		query      user.DomainQuery
+		assertions func(t *testing.T, users []user.Entity, err error)
+	}{
+		{
+			name:  "given saved users when finding by ids then returns those users",
+			query: user.DomainQuery{Ids: []string{"user-1", "user-3"}},
+			assertions: func(t *testing.T, users []user.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"user-1", "user-3"}, userIDs(users))
+			},
+		},
+		{
+			name:  "given saved users when finding by ids and player ids then returns the users with any of the players",
+			query: user.DomainQuery{Ids: []string{"user-1", "user-2", "user-3"}, PlayerIDs: []string{"player-2", "player-3"}},
+			assertions: func(t *testing.T, users []user.Entity, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"user-1", "user-2"}, userIDs(users))
+			},
+		},
+		{
+			name:  "given saved users when finding without ids then returns no users",
+			query: user.DomainQuery{PlayerIDs: []string{"player-1"}},
+			assertions: func(t *testing.T, users []user.Entity, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, users)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			repository := backend(t).User
+			for _, entity := range users {
+				noError(t, repository.Save(ctx, entity))
+			}
+
+			// when
+			found, err := repository.Find(ctx, testCase.query)
+
+			// then
+			testCase.assertions(t, found, err)
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	matchofferuc "sportlink/api/application/matchoffer/usecases"
 	"sportlink/api/domain/common"
 	"sportlink/api/domain/matchoffer"
+	"sportlink/api/domain/team"
+	"sportlink/api/infrastructure/persistence/memory"
 )
 
 // MatchOfferBuilder builds and persists a matchoffer.Entity for e2e tests.
@@ -15,6 +17,7 @@ import (
 type MatchOfferBuilder struct {
 	t              *testing.T
 	repo           matchoffer.Repository
+	teams          team.Repository
 	teamName       string
 	sport          common.Sport
 	day            time.Time
@@ -44,6 +47,7 @@ func NewMatchOfferBuilder(t *testing.T, repo matchoffer.Repository) *MatchOfferB
 	return &MatchOfferBuilder{
 		t:             t,
 		repo:          repo,
+		teams:         memory.NewTeamRepository(memory.NewStore()),
 		sport:         common.Paddle,
 		day:           tomorrow,
 		startTime:     start,
@@ -61,6 +65,13 @@ func (b *MatchOfferBuilder) WithTeamName(name string) *MatchOfferBuilder {
 	return b
 }
 
+// WithTeams resolves the team name against teams, so the offer points to the team by ID.
+// Without it, team names are kept as free text.
+func (b *MatchOfferBuilder) WithTeams(teams team.Repository) *MatchOfferBuilder {
+	b.teams = teams
+	return b
+}
+
 func (b *MatchOfferBuilder) WithSport(sport common.Sport) *MatchOfferBuilder {
 	b.sport = sport
 	return b
@@ -141,7 +152,7 @@ func (b *MatchOfferBuilder) Build(ctx context.Context) *matchoffer.Entity {
 		b.capacity,
 	)
 
-	uc := matchofferuc.NewCreateMatchOfferUC(b.repo)
+	uc := matchofferuc.NewCreateMatchOfferUC(b.repo, b.teams)
 	result, err := uc.Invoke(ctx, entity)
 	if err != nil {
 		b.t.Fatalf("MatchOfferBuilder: failed to save match offer: %v", err)
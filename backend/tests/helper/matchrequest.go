@@ -3,7 +3,9 @@ package helper
 import (
 	"context"
 	"testing"
+	"time"
 
+	offerservice "sportlink/api/application/matchoffer/service"
 	matchrequestuc "sportlink/api/application/matchrequest/usecases"
 	"sportlink/api/domain/matchoffer"
 	"sportlink/api/domain/matchrequest"
@@ -57,7 +59,9 @@ func (b *MatchRequestBuilder) Build(ctx context.Context) *matchrequest.Entity {
 		b.t.Fatal("MatchRequestBuilder: RequesterAccountID is required")
 	}
 
-	uc := matchrequestuc.NewCreateMatchRequestUC(b.mrRepo, b.moRepo)
+	// Offers built by MatchOfferBuilder are public, so the policy never looks up teams.
+	policy := offerservice.NewVisibilityPolicy(nil, offerservice.NewShareLinkSigner("e2e-share-secret", time.Hour))
+	uc := matchrequestuc.NewCreateMatchRequestUC(b.mrRepo, b.moRepo, policy)
 	result, err := uc.Invoke(ctx, matchrequestuc.CreateMatchRequestInput{
 		MatchOfferID:       b.matchOfferID,
 		RequesterAccountID: b.requesterAccountID,
@@ -1,3 +1,17 @@
+export interface MatchShare {
+  account_id: string
+  amount: number // unidades menores de la moneda (ej. centavos)
+  status: 'PENDING' | 'PAID'
+  paid_at?: string
+}
+
+export interface MatchPayment {
+  total: number
+  currency: string
+  outstanding_amount: number
+  shares: MatchShare[]
+}
+
 export interface Match {
   id: string
   participants: string[]
@@ -9,5 +23,6 @@ export interface Match {
   }
   title: string
   status: 'ACCEPTED' | 'PLAYED' | 'CANCELLED'
+  payment?: MatchPayment // ausente cuando la cancha no tiene costo
   created_at: string
 }
@@ -3,7 +3,8 @@ export interface MatchRequest {
   match_offer_id: string
   owner_account_id: string
   requester_account_id: string
-  status: 'PENDING' | 'ACCEPTED' | 'REJECTED' | 'CANCEL'
+  position?: string
+  status: 'PENDING' | 'ACCEPTED' | 'REJECTED' | 'CANCEL' | 'EXPIRED' | 'WAITLISTED'
   created_at: string
 }
 
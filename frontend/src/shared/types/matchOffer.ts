@@ -1,6 +1,19 @@
+export interface PositionNeed {
+  position: string
+  spots: number
+}
+
+export interface MatchOfferCost {
+  amount: number // costo total de la cancha en unidades menores (ej. centavos)
+  currency: string // código ISO 4217, ej. ARS
+}
+
+export type MatchOfferVisibility = 'PUBLIC' | 'TEAM' | 'INVITE' | 'LINK'
+
 export interface MatchOffer {
   id?: string
   title?: string
+  team_id?: string
   team_name: string
   sport: string
   day: string // ISO date string
@@ -22,12 +35,19 @@ export interface MatchOffer {
     max_level?: number
   }
   capacity?: number // 0 = sin auto-confirmación; >0 = cupos totales (owner + jugadores aceptados)
+  players_committed?: number // jugadores que ya tiene el grupo (owner incluido)
+  spots_needed?: number // jugadores que faltan; si se informa tiene prioridad sobre capacity
+  position_needs?: PositionNeed[] // desglose opcional de spots_needed por posición
   status: 'PENDING' | 'CONFIRMED' | 'CANCELLED' | 'EXPIRED'
   created_at: string
   owner_account_id?: string
+  visibility?: MatchOfferVisibility // ausente = PUBLIC
+  invited_account_ids?: string[]
+  cost?: MatchOfferCost // ausente = sin costo
 }
 
 export interface CreateMatchOfferRequest {
+  team_id?: string // si se envía, tiene prioridad sobre team_name
   team_name: string
   sport: string
   day: string
@@ -49,6 +69,17 @@ export interface CreateMatchOfferRequest {
     max_level?: number
   }
   capacity?: number // 0 = sin auto-confirmación; >0 = cupos totales (owner + jugadores aceptados)
+  players_committed?: number // jugadores que ya tiene el grupo (owner incluido)
+  spots_needed?: number // jugadores que faltan; si se informa tiene prioridad sobre capacity
+  position_needs?: PositionNeed[] // desglose opcional de spots_needed por posición
+  visibility?: MatchOfferVisibility // ausente = PUBLIC
+  invited_account_ids?: string[] // cuentas que siempre pueden ver la oferta
+  cost?: MatchOfferCost // se divide entre los participantes al confirmar
+}
+
+export interface ShareLinkResponse {
+  match_offer_id: string
+  share_token: string // se envía como share_token al ver la oferta o al pedir unirse
 }
 
 export interface GeoFilter {